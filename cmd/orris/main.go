@@ -6,6 +6,7 @@ import (
 	"github.com/spf13/cobra"
 
 	"github.com/orris-inc/orris/internal/interfaces/cli/migrate"
+	"github.com/orris-inc/orris/internal/interfaces/cli/node"
 	"github.com/orris-inc/orris/internal/interfaces/cli/server"
 	"github.com/orris-inc/orris/internal/shared/version"
 )
@@ -24,6 +25,7 @@ func main() {
 	rootCmd.AddCommand(
 		server.NewCommand(),
 		migrate.NewCommand(),
+		node.NewCommand(),
 	)
 
 	if err := rootCmd.Execute(); err != nil {
@@ -0,0 +1,113 @@
+package dto
+
+import (
+	"fmt"
+	"time"
+)
+
+// SystemMetricsResolution is the bucket size of a system metrics time series.
+type SystemMetricsResolution string
+
+const (
+	// SystemMetricsResolutionMinute aggregates samples into 1-minute buckets kept for 24 hours.
+	SystemMetricsResolutionMinute SystemMetricsResolution = "minute"
+	// SystemMetricsResolutionHour aggregates samples into 1-hour buckets kept for 30 days.
+	SystemMetricsResolutionHour SystemMetricsResolution = "hour"
+)
+
+// IsValid checks if the resolution is supported.
+func (r SystemMetricsResolution) IsValid() bool {
+	return r == SystemMetricsResolutionMinute || r == SystemMetricsResolutionHour
+}
+
+// Step returns the bucket size of the resolution.
+func (r SystemMetricsResolution) Step() time.Duration {
+	if r == SystemMetricsResolutionHour {
+		return time.Hour
+	}
+	return time.Minute
+}
+
+// Retention returns how long buckets of the resolution are kept.
+func (r SystemMetricsResolution) Retention() time.Duration {
+	if r == SystemMetricsResolutionHour {
+		return 30 * 24 * time.Hour
+	}
+	return 24 * time.Hour
+}
+
+// MetricStat holds the average and peak of a metric within one bucket.
+type MetricStat struct {
+	Avg float64 `json:"avg"`
+	Max float64 `json:"max"`
+}
+
+// SystemMetricsPoint is one bucket of a system metrics time series.
+// Buckets without any status report (agent offline) are omitted from the series.
+type SystemMetricsPoint struct {
+	Time           time.Time  `json:"time"`    // Bucket start (UTC)
+	Samples        int        `json:"samples"` // Number of status reports aggregated into the bucket
+	CPUPercent     MetricStat `json:"cpu_percent"`
+	MemoryPercent  MetricStat `json:"memory_percent"`
+	DiskPercent    MetricStat `json:"disk_percent"`
+	NetworkRxRate  MetricStat `json:"network_rx_rate"` // Bytes per second
+	NetworkTxRate  MetricStat `json:"network_tx_rate"` // Bytes per second
+	TCPConnections MetricStat `json:"tcp_connections"`
+	UDPConnections MetricStat `json:"udp_connections"`
+}
+
+// SystemMetricsSeries is the response of a system metrics history query.
+type SystemMetricsSeries struct {
+	Resolution SystemMetricsResolution `json:"resolution"`
+	From       time.Time               `json:"from"`
+	To         time.Time               `json:"to"`
+	Points     []SystemMetricsPoint    `json:"points"`
+}
+
+// SystemMetricsRange is a validated system metrics history query window.
+type SystemMetricsRange struct {
+	Resolution SystemMetricsResolution
+	From       time.Time
+	To         time.Time
+}
+
+// NewSystemMetricsRange validates a history query and fills in defaults.
+// An empty resolution picks minute buckets when the window lies within the last 24 hours
+// and hour buckets otherwise; zero times default to the last 24 hours. The start is
+// clamped to the retention of the chosen resolution.
+func NewSystemMetricsRange(resolution string, from, to, now time.Time) (SystemMetricsRange, error) {
+	r := SystemMetricsResolution(resolution)
+	if resolution != "" && !r.IsValid() {
+		return SystemMetricsRange{}, fmt.Errorf("invalid resolution %q, must be minute or hour", resolution)
+	}
+
+	if to.IsZero() || to.After(now) {
+		to = now
+	}
+	if from.IsZero() {
+		from = to.Add(-SystemMetricsResolutionMinute.Retention())
+	}
+	if !from.Before(to) {
+		return SystemMetricsRange{}, fmt.Errorf("from must be before to")
+	}
+
+	if resolution == "" {
+		r = SystemMetricsResolutionMinute
+		if from.Before(now.Add(-SystemMetricsResolutionMinute.Retention())) {
+			r = SystemMetricsResolutionHour
+		}
+	}
+
+	if earliest := now.Add(-r.Retention()); from.Before(earliest) {
+		from = earliest
+	}
+	if !from.Before(to) {
+		return SystemMetricsRange{}, fmt.Errorf("requested range is older than the %s retention", r)
+	}
+
+	return SystemMetricsRange{
+		Resolution: r,
+		From:       from.UTC(),
+		To:         to.UTC(),
+	}, nil
+}
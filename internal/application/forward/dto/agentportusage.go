@@ -0,0 +1,24 @@
+package dto
+
+// AgentPortDTO represents a port a forward agent listens on for a rule.
+type AgentPortDTO struct {
+	Port     uint16 `json:"port"`      // Listen port
+	RuleID   string `json:"rule_id"`   // Stripe-style rule ID (e.g., "fr_xK9mP2vL3nQ")
+	RuleName string `json:"rule_name"` // Rule name
+	ChainHop bool   `json:"chain_hop"` // Whether the port is a chain hop port from chain_port_config
+	InPool   bool   `json:"in_pool"`   // Whether the port is within the agent's port pool
+}
+
+// AgentPortUsageResponse represents the listen port utilization of a forward agent.
+type AgentPortUsageResponse struct {
+	AgentID          string         `json:"agent_id"`                     // Stripe-style agent ID (e.g., "fa_xK9mP2vL3nQ")
+	AllowedPortRange string         `json:"allowed_port_range,omitempty"` // Allowed port ranges, empty when all ports are allowed
+	PortPool         string         `json:"port_pool"`                    // Ports listen ports are auto-assigned from
+	TotalPorts       int            `json:"total_ports"`                  // Number of ports in the pool
+	UsedPorts        int            `json:"used_ports"`                   // Ports of the pool used by rules
+	ReservedPorts    int            `json:"reserved_ports"`               // Ports of the pool reserved by rules being created
+	AvailablePorts   int            `json:"available_ports"`              // Ports of the pool free to assign
+	Utilization      float64        `json:"utilization"`                  // Percentage of the pool used or reserved
+	Ports            []AgentPortDTO `json:"ports"`                        // Ports used by rules, sorted by port
+	Reserved         []uint16       `json:"reserved,omitempty"`           // Reserved ports, sorted
+}
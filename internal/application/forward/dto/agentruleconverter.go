@@ -39,6 +39,27 @@ type NodeInfoProvider interface {
 	GetByID(ctx context.Context, id uint) (*node.Node, error)
 }
 
+// DemotedExitsReader reports which exit agents of a rule are demoted to backups
+// because their tunnel from the entry agent is unhealthy.
+type DemotedExitsReader interface {
+	GetDemoted(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]bool, error)
+}
+
+// RuleLimitsResolver resolves the default limits of subscription-bound rules from the subscriptions' plans.
+type RuleLimitsResolver interface {
+	DefaultLimits(ctx context.Context, subscriptionIDs []uint) (map[uint]vo.RuleLimits, error)
+}
+
+// EffectiveRuleLimits returns the limits enforced for a rule. Limits not set on a
+// subscription-bound rule are taken from its subscription's plan, keyed by subscription ID.
+func EffectiveRuleLimits(rule *forward.ForwardRule, planLimits map[uint]vo.RuleLimits) vo.RuleLimits {
+	limits := rule.Limits()
+	if rule.SubscriptionID() != nil {
+		limits = limits.WithDefaults(planLimits[*rule.SubscriptionID()])
+	}
+	return limits
+}
+
 // TokenGenerator defines the interface for generating agent tokens.
 type TokenGenerator interface {
 	Generate(shortID string) (plainToken string, tokenHash string)
@@ -64,6 +85,8 @@ type AgentRuleConverter struct {
 	statusQuerier   AgentStatusProvider
 	tokenService    TokenGenerator
 	addressResolver AgentAddressResolver
+	demotedExits    DemotedExitsReader
+	limitsResolver  RuleLimitsResolver
 	logger          logger.Interface
 }
 
@@ -73,6 +96,16 @@ func (c *AgentRuleConverter) SetNodeRepo(nodeRepo NodeInfoProvider) {
 	c.nodeRepo = nodeRepo
 }
 
+// SetDemotedExitsReader sets the reader used to send demoted exit agents as backups (optional).
+func (c *AgentRuleConverter) SetDemotedExitsReader(reader DemotedExitsReader) {
+	c.demotedExits = reader
+}
+
+// SetRuleLimitsResolver sets the resolver used to apply plan limits to subscription-bound rules (optional).
+func (c *AgentRuleConverter) SetRuleLimitsResolver(resolver RuleLimitsResolver) {
+	c.limitsResolver = resolver
+}
+
 // NewAgentRuleConverter creates a new AgentRuleConverter.
 func NewAgentRuleConverter(
 	agentRepo AgentInfoProvider,
@@ -140,15 +173,61 @@ func (c *AgentRuleConverter) ConvertBatch(ctx context.Context, rules []*forward.
 	}
 
 	// Resolve node addresses and populate role-specific information
+	planLimits := c.loadPlanLimits(ctx, rules)
 	for i, rule := range rules {
 		ruleDTO := ruleDTOs[i]
 		c.resolveTargetNodeAddress(ctx, ruleDTO)
 		c.populateRoleSpecificInfo(ctx, rule, ruleDTO, agentID)
+		applyEffectiveLimits(rule, ruleDTO, planLimits)
 	}
 
 	return ruleDTOs, nil
 }
 
+// loadPlanLimits batch-loads the plan default limits of the subscription-bound rules,
+// keyed by subscription ID.
+func (c *AgentRuleConverter) loadPlanLimits(ctx context.Context, rules []*forward.ForwardRule) map[uint]vo.RuleLimits {
+	if c.limitsResolver == nil {
+		return nil
+	}
+
+	subIDSet := make(map[uint]struct{})
+	for _, rule := range rules {
+		if rule.SubscriptionID() != nil {
+			subIDSet[*rule.SubscriptionID()] = struct{}{}
+		}
+	}
+	if len(subIDSet) == 0 {
+		return nil
+	}
+	subIDs := make([]uint, 0, len(subIDSet))
+	for subID := range subIDSet {
+		subIDs = append(subIDs, subID)
+	}
+
+	planLimits, err := c.limitsResolver.DefaultLimits(ctx, subIDs)
+	if err != nil {
+		c.logger.Warnw("failed to resolve plan limits for rules",
+			"subscription_ids", subIDs,
+			"error", err,
+		)
+		return nil
+	}
+	return planLimits
+}
+
+// applyEffectiveLimits sends the limits the agent has to enforce. Like the rules pushed over
+// WebSocket, only the entry agent receives limits, with unset limits taken from the plan.
+func applyEffectiveLimits(rule *forward.ForwardRule, ruleDTO *ForwardRuleDTO, planLimits map[uint]vo.RuleLimits) {
+	var limits vo.RuleLimits
+	if ruleDTO.Role == "entry" {
+		limits = EffectiveRuleLimits(rule, planLimits)
+	}
+	ruleDTO.UploadLimitMbps = limits.UploadMbps
+	ruleDTO.DownloadLimitMbps = limits.DownloadMbps
+	ruleDTO.MaxConnections = limits.MaxConnections
+}
+
 // ConvertForAgent converts a single rule to DTO with role-specific information.
 func (c *AgentRuleConverter) ConvertForAgent(ctx context.Context, rule *forward.ForwardRule, agentID uint) (*ForwardRuleDTO, error) {
 	dtos, err := c.ConvertBatch(ctx, []*forward.ForwardRule{rule}, agentID)
@@ -297,6 +376,7 @@ func (c *AgentRuleConverter) populateEntryRuleInfo(ctx context.Context, rule *fo
 		// This agent is the entry point
 		ruleDTO.Role = "entry"
 		c.populateEntryNextHopInfo(ctx, rule, ruleDTO, addrPref)
+		c.applyDemotedExits(ctx, rule, ruleDTO)
 	} else if c.isExitAgent(rule, agentID) {
 		// This agent is one of the exit points - clear exit_agent_id and exit_agents (minimum info principle)
 		ruleDTO.Role = "exit"
@@ -305,6 +385,32 @@ func (c *AgentRuleConverter) populateEntryRuleInfo(ctx context.Context, rule *fo
 	}
 }
 
+// applyDemotedExits sends exits with unhealthy tunnels as backups (weight 0) until they
+// recover, as in the rules pushed to agents over WebSocket.
+func (c *AgentRuleConverter) applyDemotedExits(ctx context.Context, rule *forward.ForwardRule, ruleDTO *ForwardRuleDTO) {
+	exitAgents := rule.ExitAgents()
+	if c.demotedExits == nil || len(exitAgents) == 0 || len(ruleDTO.ExitAgents) != len(exitAgents) {
+		return
+	}
+
+	exitAgentIDs := make([]uint, len(exitAgents))
+	for i, aw := range exitAgents {
+		exitAgentIDs[i] = aw.AgentID()
+	}
+	demoted, err := c.demotedExits.GetDemoted(ctx, rule.ID(), exitAgentIDs)
+	if err != nil {
+		c.logger.Warnw("failed to get demoted exit agents, using configured weights",
+			"rule_id", rule.ID(),
+			"error", err,
+		)
+		return
+	}
+
+	for i, aw := range rule.EffectiveExitAgents(demoted) {
+		ruleDTO.ExitAgents[i].Weight = aw.Weight()
+	}
+}
+
 // isExitAgent checks if the given agent is an exit agent for this entry rule.
 // Supports both single exit agent (exitAgentID) and load balancing (exitAgents).
 func (c *AgentRuleConverter) isExitAgent(rule *forward.ForwardRule, agentID uint) bool {
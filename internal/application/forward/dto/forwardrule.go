@@ -30,7 +30,7 @@ type ForwardRuleDTO struct {
 	RuleType            string            `json:"rule_type"`                               // direct, entry, chain, direct_chain
 	ExitAgentID         string            `json:"exit_agent_id,omitempty"`                 // for entry type (Stripe-style prefixed ID, mutually exclusive with ExitAgents)
 	ExitAgents          []ExitAgentDTO    `json:"exit_agents,omitempty"`                   // for entry type with load balancing (mutually exclusive with ExitAgentID)
-	LoadBalanceStrategy string            `json:"load_balance_strategy,omitempty"`         // failover (default), weighted
+	LoadBalanceStrategy string            `json:"load_balance_strategy,omitempty"`         // failover (default), weighted, round_robin, least_connections, lowest_latency
 	ChainAgentIDs       []string          `json:"chain_agent_ids,omitempty"`               // for chain and direct_chain types (ordered Stripe-style prefixed IDs)
 	ChainPortConfig     map[string]uint16 `json:"chain_port_config,omitempty"`             // for direct_chain type (Stripe-style agent ID -> listen port)
 	Name            string            `json:"name"`
@@ -96,6 +96,14 @@ type ForwardRuleDTO struct {
 	// Address preference for next hop connections
 	AddressPreference string `json:"address_preference,omitempty"` // auto, public, tunnel
 
+	// Bandwidth and connection limits (0 = inherit from plan or unlimited)
+	UploadLimitMbps   uint32 `json:"upload_limit_mbps,omitempty"`   // upload bandwidth limit in Mbps
+	DownloadLimitMbps uint32 `json:"download_limit_mbps,omitempty"` // download bandwidth limit in Mbps
+	MaxConnections    uint32 `json:"max_connections,omitempty"`     // max concurrent connections
+
+	// Source IP access control list
+	ACL *RuleACLDTO `json:"acl,omitempty"` // source IP access control list
+
 	// Per-rule routing configuration
 	Route *nodedto.RouteConfigDTO `json:"route,omitempty"` // per-rule routing configuration
 
@@ -175,6 +183,10 @@ func ToForwardRuleDTO(rule *forward.ForwardRule) *ForwardRuleDTO {
 		TunnelType:                 tunnelType,
 		TunnelHops:                 rule.TunnelHops(),
 		AddressPreference:          rule.AddressPreference().String(),
+		UploadLimitMbps:            rule.Limits().UploadMbps,
+		DownloadLimitMbps:          rule.Limits().DownloadMbps,
+		MaxConnections:             rule.Limits().MaxConnections,
+		ACL:                        ToRuleACLDTO(rule.ACL()),
 		Route:                      nodedto.ToRouteConfigDTO(rule.RouteConfig()),
 		ServerAddress:              rule.ServerAddress(),
 		ExternalSource:             rule.ExternalSource(),
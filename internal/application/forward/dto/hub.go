@@ -68,6 +68,15 @@ type ExitAgentSyncData = hubproto.ExitAgentSyncData
 // HealthCheckConfig represents health check configuration for load balancing failover (type alias from shared hubprotocol).
 type HealthCheckConfig = hubproto.HealthCheckConfig
 
+// LoadBalanceConfig represents parameters of metric-based load balance strategies (type alias from shared hubprotocol).
+type LoadBalanceConfig = hubproto.LoadBalanceConfig
+
+// RuleLimits represents the bandwidth and connection limits of a rule (type alias from shared hubprotocol).
+type RuleLimits = hubproto.RuleLimits
+
+// RuleACL represents the source IP access control list of a rule (type alias from shared hubprotocol).
+type RuleACL = hubproto.RuleACL
+
 // RuleSyncData represents rule sync data for config sync (type alias from shared hubprotocol).
 type RuleSyncData = hubproto.RuleSyncData
 
@@ -0,0 +1,36 @@
+package dto
+
+import (
+	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
+)
+
+// RuleACLDTO represents the source IP access control list of a forward rule.
+// Deny entries take precedence; when any allow list is set, only matching sources are accepted.
+type RuleACLDTO struct {
+	AllowCIDRs     []string `json:"allow_cidrs,omitempty" example:"[\"203.0.113.0/24\"]"` // accepted source CIDRs (single IPs allowed)
+	DenyCIDRs      []string `json:"deny_cidrs,omitempty" example:"[\"198.51.100.7\"]"`    // rejected source CIDRs (single IPs allowed)
+	AllowCountries []string `json:"allow_countries,omitempty" example:"[\"us\"]"`         // accepted GeoIP country codes
+	DenyCountries  []string `json:"deny_countries,omitempty" example:"[\"cn\"]"`          // rejected GeoIP country codes
+}
+
+// ToRuleACLDTO converts a domain rule ACL to DTO. Returns nil if the ACL is empty.
+func ToRuleACLDTO(acl *vo.RuleACL) *RuleACLDTO {
+	if acl.IsEmpty() {
+		return nil
+	}
+	return &RuleACLDTO{
+		AllowCIDRs:     acl.AllowCIDRs,
+		DenyCIDRs:      acl.DenyCIDRs,
+		AllowCountries: acl.AllowCountries,
+		DenyCountries:  acl.DenyCountries,
+	}
+}
+
+// FromRuleACLDTO converts a DTO to a validated domain rule ACL.
+// Returns nil if the DTO is nil or all lists are empty.
+func FromRuleACLDTO(d *RuleACLDTO) (*vo.RuleACL, error) {
+	if d == nil {
+		return nil, nil
+	}
+	return vo.NewRuleACL(d.AllowCIDRs, d.DenyCIDRs, d.AllowCountries, d.DenyCountries)
+}
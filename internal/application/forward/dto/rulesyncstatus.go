@@ -3,13 +3,15 @@ package dto
 
 // RuleSyncStatusItem represents the sync and runtime status of a single forward rule.
 type RuleSyncStatusItem struct {
-	RuleID       string `json:"rule_id"`       // Stripe-style rule ID (e.g., "fr_xK9mP2vL3nQ")
-	SyncStatus   string `json:"sync_status"`   // Sync status: synced, pending, failed
-	RunStatus    string `json:"run_status"`    // Runtime status: running, stopped, error, starting
-	ListenPort   uint16 `json:"listen_port"`   // Actual listening port
-	Connections  int    `json:"connections"`   // Current number of connections
-	ErrorMessage string `json:"error_message"` // Error message if any
-	SyncedAt     int64  `json:"synced_at"`     // Last sync timestamp (Unix seconds)
+	RuleID                    string `json:"rule_id"`                               // Stripe-style rule ID (e.g., "fr_xK9mP2vL3nQ")
+	SyncStatus                string `json:"sync_status"`                           // Sync status: synced, pending, failed
+	RunStatus                 string `json:"run_status"`                            // Runtime status: running, stopped, error, starting
+	ListenPort                uint16 `json:"listen_port"`                           // Actual listening port
+	Connections               int    `json:"connections"`                           // Current number of connections
+	ConnectionLimitRejections int64  `json:"connection_limit_rejections,omitempty"` // Connections rejected by the connection limit since the agent started
+	RateLimitThrottles        int64  `json:"rate_limit_throttles,omitempty"`        // Times traffic was throttled by the bandwidth limit since the agent started
+	ErrorMessage              string `json:"error_message"`                         // Error message if any
+	SyncedAt                  int64  `json:"synced_at"`                             // Last sync timestamp (Unix seconds)
 }
 
 // ReportRuleSyncStatusInput represents the input for ReportRuleSyncStatus use case.
@@ -26,15 +28,17 @@ type RuleSyncStatusQueryResult struct {
 
 // AgentRuleSyncStatus represents the sync status of a single agent for a specific rule.
 type AgentRuleSyncStatus struct {
-	AgentID      string `json:"agent_id"`      // Stripe-style agent ID (e.g., "fa_xK9mP2vL3nQ")
-	AgentName    string `json:"agent_name"`    // Agent name
-	Position     int    `json:"position"`      // Position in forwarding chain (0=entry)
-	SyncStatus   string `json:"sync_status"`   // Sync status: synced, pending, failed
-	RunStatus    string `json:"run_status"`    // Runtime status: running, stopped, error, starting
-	ListenPort   uint16 `json:"listen_port"`   // Actual listening port
-	Connections  int    `json:"connections"`   // Current number of connections
-	ErrorMessage string `json:"error_message"` // Error message if any
-	SyncedAt     int64  `json:"synced_at"`     // Last sync timestamp (Unix seconds)
+	AgentID                   string `json:"agent_id"`                              // Stripe-style agent ID (e.g., "fa_xK9mP2vL3nQ")
+	AgentName                 string `json:"agent_name"`                            // Agent name
+	Position                  int    `json:"position"`                              // Position in forwarding chain (0=entry)
+	SyncStatus                string `json:"sync_status"`                           // Sync status: synced, pending, failed
+	RunStatus                 string `json:"run_status"`                            // Runtime status: running, stopped, error, starting
+	ListenPort                uint16 `json:"listen_port"`                           // Actual listening port
+	Connections               int    `json:"connections"`                           // Current number of connections
+	ConnectionLimitRejections int64  `json:"connection_limit_rejections,omitempty"` // Connections rejected by the connection limit since the agent started
+	RateLimitThrottles        int64  `json:"rate_limit_throttles,omitempty"`        // Times traffic was throttled by the bandwidth limit since the agent started
+	ErrorMessage              string `json:"error_message"`                         // Error message if any
+	SyncedAt                  int64  `json:"synced_at"`                             // Last sync timestamp (Unix seconds)
 }
 
 // RuleOverallStatusResponse represents the aggregated status response for a rule.
@@ -0,0 +1,31 @@
+package dto
+
+// TunnelExitHealth represents the current health of the tunnel from a rule's entry agent to one exit agent.
+type TunnelExitHealth struct {
+	ExitAgentID     string  `json:"exit_agent_id"`              // Stripe-style agent ID (e.g., "fa_xK9mP2vL3nQ")
+	ExitAgentName   string  `json:"exit_agent_name"`            // Agent name
+	Weight          *uint16 `json:"weight,omitempty"`           // Configured load balancing weight, multi-exit rules only (0=backup)
+	EffectiveWeight *uint16 `json:"effective_weight,omitempty"` // Weight sent to the entry agent, 0 while demoted
+	Reported        bool    `json:"reported"`                   // Whether the entry agent has reported this tunnel
+	Healthy         bool    `json:"healthy"`                    // Whether the tunnel is healthy (true when not reported)
+	Demoted         bool    `json:"demoted"`                    // Whether the exit is demoted to a backup because of its tunnel
+	Since           int64   `json:"since,omitempty"`            // When the tunnel entered its current state (Unix seconds)
+}
+
+// RuleTunnelHealthResponse represents the tunnel health of all exit agents of a rule.
+type RuleTunnelHealthResponse struct {
+	RuleID              string             `json:"rule_id"`                         // Stripe-style rule ID (e.g., "fr_xK9mP2vL3nQ")
+	LoadBalanceStrategy string             `json:"load_balance_strategy,omitempty"` // failover, weighted, round_robin, least_connections or lowest_latency (multi-exit rules only)
+	Exits               []TunnelExitHealth `json:"exits"`                           // Health per exit agent
+}
+
+// TunnelHealthRecordDTO represents one entry of a tunnel health timeline.
+type TunnelHealthRecordDTO struct {
+	EntryAgentID string `json:"entry_agent_id"`       // Stripe-style agent ID of the reporting entry agent
+	ExitAgentID  string `json:"exit_agent_id"`        // Stripe-style agent ID of the exit agent
+	Healthy      bool   `json:"healthy"`              // Whether the tunnel was healthy
+	FailCount    int    `json:"fail_count"`           // Consecutive failed checks
+	LatencyMs    *int64 `json:"latency_ms,omitempty"` // Measured latency in milliseconds (when healthy)
+	Error        string `json:"error,omitempty"`      // Check error (when unhealthy)
+	CheckedAt    int64  `json:"checked_at"`           // Check timestamp (Unix seconds)
+}
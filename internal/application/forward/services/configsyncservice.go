@@ -79,6 +79,21 @@ func NewConfigSyncService(
 	}
 }
 
+// SetDemotedExitsReader sets the reader used to send demoted exit agents as backups.
+func (s *ConfigSyncService) SetDemotedExitsReader(reader dto.DemotedExitsReader) {
+	s.converter.SetDemotedExitsReader(reader)
+}
+
+// SetLoadBalanceMetricsReader sets the reader used to send exit metrics to entry agents.
+func (s *ConfigSyncService) SetLoadBalanceMetricsReader(reader LoadBalanceMetricsReader) {
+	s.converter.SetLoadBalanceMetricsReader(reader)
+}
+
+// SetRuleLimitsResolver sets the resolver used to apply plan limits to subscription-bound rules.
+func (s *ConfigSyncService) SetRuleLimitsResolver(resolver dto.RuleLimitsResolver) {
+	s.converter.SetRuleLimitsResolver(resolver)
+}
+
 // String implements fmt.Stringer for logging purposes.
 func (s *ConfigSyncService) String() string {
 	return "ConfigSyncService"
@@ -239,9 +254,10 @@ func (s *ConfigSyncService) FullSyncToAgent(ctx context.Context, agentID uint) e
 		)
 
 		// Convert all rules to sync data
+		planLimits := s.converter.LoadPlanLimits(ctx, rules)
 		ruleSyncDataList := make([]dto.RuleSyncData, 0, len(rules))
 		for _, rule := range rules {
-			ruleSyncData, err := s.converter.Convert(ctx, rule, agentID)
+			ruleSyncData, err := s.converter.ConvertWithPlanLimits(ctx, rule, agentID, planLimits)
 			if err != nil {
 				s.logger.Warnw("failed to convert rule to sync data, skipping",
 					"rule_id", rule.ID(),
@@ -447,9 +463,10 @@ func (s *ConfigSyncService) NotifyExitPortChange(ctx context.Context, exitAgentI
 			}
 
 			// Convert rules to sync data
+			planLimits := s.converter.LoadPlanLimits(ctx, agentRules)
 			ruleSyncDataList := make([]dto.RuleSyncData, 0, len(agentRules))
 			for _, rule := range agentRules {
-				syncData, err := s.converter.Convert(ctx, rule, entryAgentID)
+				syncData, err := s.converter.ConvertWithPlanLimits(ctx, rule, entryAgentID, planLimits)
 				if err != nil {
 					s.logger.Warnw("failed to convert rule to sync data",
 						"rule_id", rule.ID(),
@@ -560,9 +577,10 @@ func (s *ConfigSyncService) NotifyNodeAddressChange(ctx context.Context, nodeID
 			}
 
 			// Convert rules to sync data
+			planLimits := s.converter.LoadPlanLimits(ctx, agentRules)
 			ruleSyncDataList := make([]dto.RuleSyncData, 0, len(ruleMap))
 			for _, rule := range ruleMap {
-				syncData, err := s.converter.Convert(ctx, rule, agentID)
+				syncData, err := s.converter.ConvertWithPlanLimits(ctx, rule, agentID, planLimits)
 				if err != nil {
 					s.logger.Warnw("failed to convert rule to sync data",
 						"rule_id", rule.ID(),
@@ -613,6 +631,42 @@ func (s *ConfigSyncService) NotifyNodeAddressChange(ctx context.Context, nodeID
 	return lastErr
 }
 
+// NotifySubscriptionRuleLimitsChange re-syncs the enabled forward rules of the given subscriptions
+// to their entry agents, which enforce the limits. Subscription-bound rules inherit the limits
+// they don't set from the subscription's plan, so this is called when that plan's speed or
+// connection limit changes or the subscription moves to another plan.
+func (s *ConfigSyncService) NotifySubscriptionRuleLimitsChange(ctx context.Context, subscriptionIDs []uint) error {
+	var lastErr error
+	for _, subID := range subscriptionIDs {
+		rules, err := s.repo.ListBySubscriptionID(ctx, subID)
+		if err != nil {
+			s.logger.Errorw("failed to list forward rules of subscription for limits sync",
+				"subscription_id", subID,
+				"error", err,
+			)
+			lastErr = err
+			continue
+		}
+
+		for _, rule := range rules {
+			if !rule.Status().IsEnabled() {
+				continue
+			}
+			if err := s.NotifyRuleChange(ctx, rule.AgentID(), rule.SID(), "updated"); err != nil {
+				s.logger.Warnw("failed to sync forward rule limits",
+					"subscription_id", subID,
+					"rule_sid", rule.SID(),
+					"agent_id", rule.AgentID(),
+					"error", err,
+				)
+				lastErr = err
+			}
+		}
+	}
+
+	return lastErr
+}
+
 // NotifyAgentBlockedProtocolsChange notifies an agent when its blocked protocols configuration changes.
 // This sends an incremental sync with only the updated blocked protocols list.
 func (s *ConfigSyncService) NotifyAgentBlockedProtocolsChange(ctx context.Context, agentID uint) error {
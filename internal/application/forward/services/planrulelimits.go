@@ -0,0 +1,93 @@
+package services
+
+import (
+	"context"
+	"fmt"
+
+	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+)
+
+// PlanRuleLimitsResolver resolves the default limits of subscription-bound forward rules
+// from the speed_limit and connection_limit of the subscription's plan.
+type PlanRuleLimitsResolver struct {
+	subscriptionRepo subscription.SubscriptionRepository
+	planRepo         subscription.PlanRepository
+}
+
+// NewPlanRuleLimitsResolver creates a new PlanRuleLimitsResolver.
+func NewPlanRuleLimitsResolver(
+	subscriptionRepo subscription.SubscriptionRepository,
+	planRepo subscription.PlanRepository,
+) *PlanRuleLimitsResolver {
+	return &PlanRuleLimitsResolver{
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+	}
+}
+
+// DefaultLimits returns the limits defined by the plans of the given subscriptions, keyed by
+// subscription ID. Subscriptions and plans are loaded in one batch each.
+// The plan speed limit applies to both directions. Subscriptions whose plan defines no limit
+// (or which no longer exist) are omitted.
+func (r *PlanRuleLimitsResolver) DefaultLimits(ctx context.Context, subscriptionIDs []uint) (map[uint]vo.RuleLimits, error) {
+	if len(subscriptionIDs) == 0 {
+		return nil, nil
+	}
+
+	subs, err := r.subscriptionRepo.GetByIDs(ctx, subscriptionIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	// Collect unique plan IDs
+	planIDSet := make(map[uint]struct{})
+	for _, sub := range subs {
+		if sub != nil {
+			planIDSet[sub.PlanID()] = struct{}{}
+		}
+	}
+	if len(planIDSet) == 0 {
+		return nil, nil
+	}
+	planIDs := make([]uint, 0, len(planIDSet))
+	for planID := range planIDSet {
+		planIDs = append(planIDs, planID)
+	}
+
+	plans, err := r.planRepo.GetByIDs(ctx, planIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+
+	planLimits := make(map[uint]vo.RuleLimits, len(plans))
+	for _, plan := range plans {
+		if plan == nil || plan.Features() == nil {
+			continue
+		}
+		speedLimit, err := plan.Features().GetSpeedLimit()
+		if err != nil {
+			return nil, fmt.Errorf("invalid speed limit of plan %d: %w", plan.ID(), err)
+		}
+		connectionLimit, err := plan.Features().GetConnectionLimit()
+		if err != nil {
+			return nil, fmt.Errorf("invalid connection limit of plan %d: %w", plan.ID(), err)
+		}
+		planLimits[plan.ID()] = vo.RuleLimits{
+			UploadMbps:     uint32(min(speedLimit, vo.MaxRuleBandwidthMbps)),
+			DownloadMbps:   uint32(min(speedLimit, vo.MaxRuleBandwidthMbps)),
+			MaxConnections: uint32(min(connectionLimit, vo.MaxRuleConnections)),
+		}
+	}
+
+	result := make(map[uint]vo.RuleLimits, len(subs))
+	for subID, sub := range subs {
+		if sub == nil {
+			continue
+		}
+		if limits, ok := planLimits[sub.PlanID()]; ok && !limits.IsZero() {
+			result[subID] = limits
+		}
+	}
+	return result, nil
+}
@@ -0,0 +1,115 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"math/rand"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// PortReservationStore holds listen ports reserved on forward agents while rules are created.
+type PortReservationStore interface {
+	// Reserve reserves a port on an agent. Returns false if the port is already reserved.
+	Reserve(ctx context.Context, agentID uint, port uint16) (bool, error)
+	// Release releases a port reservation.
+	Release(ctx context.Context, agentID uint, port uint16) error
+	// ListReserved returns the ports currently reserved on an agent.
+	ListReserved(ctx context.Context, agentID uint) ([]uint16, error)
+}
+
+// PortAllocator assigns listen ports to rules on forward agents. A port is reserved
+// before it is checked against existing rules, and stays reserved until the rule using
+// it is persisted, so concurrent requests never end up with the same port on an agent.
+// Ports are unique per agent across main listen ports and chain hop ports.
+type PortAllocator struct {
+	ruleRepo forward.RuleReader
+	store    PortReservationStore
+	logger   logger.Interface
+}
+
+// NewPortAllocator creates a new PortAllocator.
+func NewPortAllocator(ruleRepo forward.RuleReader, store PortReservationStore, logger logger.Interface) *PortAllocator {
+	return &PortAllocator{
+		ruleRepo: ruleRepo,
+		store:    store,
+		logger:   logger,
+	}
+}
+
+// Allocate reserves a free port from the agent's port pool. The caller must release it
+// once the rule using it is persisted or its creation fails.
+func (a *PortAllocator) Allocate(ctx context.Context, agent *forward.ForwardAgent) (uint16, error) {
+	usages, err := a.ruleRepo.ListPortUsageByAgent(ctx, agent.ID())
+	if err != nil {
+		return 0, fmt.Errorf("failed to list ports in use: %w", err)
+	}
+	reserved, err := a.store.ListReserved(ctx, agent.ID())
+	if err != nil {
+		return 0, err
+	}
+
+	taken := make(map[uint16]bool, len(usages)+len(reserved))
+	for _, usage := range usages {
+		taken[usage.Port] = true
+	}
+	for _, port := range reserved {
+		taken[port] = true
+	}
+
+	// Walk the pool from a random offset so agents do not fill up from the first port
+	pool := agent.PortPool()
+	total := pool.TotalPorts()
+	offset := rand.Intn(total)
+	for i := range total {
+		port := pool.PortAt((offset + i) % total)
+		if taken[port] {
+			continue
+		}
+		ok, err := a.Reserve(ctx, agent.ID(), port)
+		if err != nil {
+			return 0, err
+		}
+		if ok {
+			return port, nil
+		}
+	}
+
+	return 0, errors.NewConflictError(
+		fmt.Sprintf("no free port left on this agent, port pool: %s", pool.String()))
+}
+
+// Reserve reserves a specific port on an agent. Returns false if the port is used by a
+// rule or reserved by a concurrent request. The caller must release a reserved port once
+// the rule using it is persisted or its creation fails.
+func (a *PortAllocator) Reserve(ctx context.Context, agentID uint, port uint16) (bool, error) {
+	ok, err := a.store.Reserve(ctx, agentID, port)
+	if err != nil {
+		return false, err
+	}
+	if !ok {
+		return false, nil
+	}
+
+	// Checked after reserving: a rule created concurrently is either still holding the
+	// reservation or already persisted
+	inUse, err := a.ruleRepo.IsPortInUseByAgent(ctx, agentID, port, 0)
+	if err != nil {
+		a.Release(ctx, agentID, port)
+		return false, fmt.Errorf("failed to check port availability: %w", err)
+	}
+	if inUse {
+		a.Release(ctx, agentID, port)
+		return false, nil
+	}
+	return true, nil
+}
+
+// Release releases a reserved port. Reservations that cannot be released expire on their own.
+func (a *PortAllocator) Release(ctx context.Context, agentID uint, port uint16) {
+	if err := a.store.Release(ctx, agentID, port); err != nil {
+		a.logger.Warnw("failed to release port reservation", "agent_id", agentID, "port", port, "error", err)
+	}
+}
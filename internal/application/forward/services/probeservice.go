@@ -125,6 +125,37 @@ func (s *ProbeService) ProbeRule(ctx context.Context, ruleID uint, ipVersionOver
 	return s.probeRule(ctx, rule, ipVersionOverride)
 }
 
+// ProbeExitLatencies probes every exit agent of an entry rule and returns the total latency
+// (tunnel + target) through each exit that could be probed, keyed by exit agent ID.
+// Used to feed the lowest_latency load balance strategy.
+func (s *ProbeService) ProbeExitLatencies(ctx context.Context, rule *forward.ForwardRule) (map[uint]int64, error) {
+	if !rule.RuleType().IsEntry() {
+		return nil, fmt.Errorf("rule %s is not an entry rule", rule.SID())
+	}
+
+	response := &dto.RuleProbeResponse{
+		RuleID:   rule.SID(),
+		RuleType: rule.RuleType().String(),
+	}
+	response, err := s.probeEntryRule(ctx, rule, rule.IPVersion(), response)
+	if err != nil {
+		return nil, err
+	}
+	if response.ExitAgentResults == nil && response.Error != "" {
+		return nil, fmt.Errorf("probe rule %s: %s", rule.SID(), response.Error)
+	}
+
+	// Exit results are in the order of GetAllExitAgentIDs
+	exitAgentIDs := rule.GetAllExitAgentIDs()
+	latencies := make(map[uint]int64, len(exitAgentIDs))
+	for i, result := range response.ExitAgentResults {
+		if i < len(exitAgentIDs) && result != nil && result.Success && result.TotalLatencyMs != nil {
+			latencies[exitAgentIDs[i]] = *result.TotalLatencyMs
+		}
+	}
+	return latencies, nil
+}
+
 // probeRule is the internal implementation for probing a rule.
 func (s *ProbeService) probeRule(ctx context.Context, rule *forward.ForwardRule, ipVersionOverride string) (*dto.RuleProbeResponse, error) {
 
@@ -3,6 +3,7 @@ package services
 import (
 	"context"
 	"fmt"
+	"time"
 
 	"github.com/orris-inc/orris/internal/application/forward/dto"
 	"github.com/orris-inc/orris/internal/application/forward/usecases"
@@ -22,9 +23,21 @@ type RuleSyncConverter struct {
 	statusQuerier     usecases.AgentStatusQuerier
 	agentTokenService *auth.AgentTokenService
 	hub               SyncHub // Hub for checking agent online status
+	demotedExits      dto.DemotedExitsReader
+	lbMetrics         LoadBalanceMetricsReader
+	limitsResolver    dto.RuleLimitsResolver
 	logger            logger.Interface
 }
 
+// LoadBalanceMetricsReader reads the exit metrics collected for metric-based load balancing.
+type LoadBalanceMetricsReader interface {
+	Get(ctx context.Context, ruleID uint) (*forward.LoadBalanceMetrics, error)
+}
+
+// PlanLimits holds the plan default limits of subscription-bound rules, keyed by subscription ID.
+// It is loaded once per sync with LoadPlanLimits to avoid per-rule queries.
+type PlanLimits map[uint]vo.RuleLimits
+
 // NewRuleSyncConverter creates a new RuleSyncConverter.
 func NewRuleSyncConverter(
 	agentRepo forward.AgentRepository,
@@ -49,9 +62,67 @@ func (c *RuleSyncConverter) SetNodeRepo(nodeRepo node.NodeRepository) {
 	c.nodeRepo = nodeRepo
 }
 
+// SetDemotedExitsReader sets the reader used to send demoted exit agents as backups.
+func (c *RuleSyncConverter) SetDemotedExitsReader(reader dto.DemotedExitsReader) {
+	c.demotedExits = reader
+}
+
+// SetLoadBalanceMetricsReader sets the reader used to send exit metrics to entry agents.
+func (c *RuleSyncConverter) SetLoadBalanceMetricsReader(reader LoadBalanceMetricsReader) {
+	c.lbMetrics = reader
+}
+
+// SetRuleLimitsResolver sets the resolver used to apply plan limits to subscription-bound rules.
+func (c *RuleSyncConverter) SetRuleLimitsResolver(resolver dto.RuleLimitsResolver) {
+	c.limitsResolver = resolver
+}
+
+// LoadPlanLimits batch-loads the plan default limits of the subscription-bound rules.
+// The result is never nil, so it can be passed to ConvertWithPlanLimits even when empty.
+func (c *RuleSyncConverter) LoadPlanLimits(ctx context.Context, rules []*forward.ForwardRule) PlanLimits {
+	planLimits := make(PlanLimits)
+	if c.limitsResolver == nil {
+		return planLimits
+	}
+
+	subIDSet := make(map[uint]struct{})
+	for _, rule := range rules {
+		if rule.SubscriptionID() != nil {
+			subIDSet[*rule.SubscriptionID()] = struct{}{}
+		}
+	}
+	if len(subIDSet) == 0 {
+		return planLimits
+	}
+	subIDs := make([]uint, 0, len(subIDSet))
+	for subID := range subIDSet {
+		subIDs = append(subIDs, subID)
+	}
+
+	defaults, err := c.limitsResolver.DefaultLimits(ctx, subIDs)
+	if err != nil {
+		c.logger.Warnw("failed to resolve plan limits for rules",
+			"subscription_ids", subIDs,
+			"error", err,
+		)
+		return planLimits
+	}
+	for subID, limits := range defaults {
+		planLimits[subID] = limits
+	}
+	return planLimits
+}
+
 // Convert converts a ForwardRule to RuleSyncData for a specific agent.
 // This mirrors the logic in AgentHandler.GetEnabledRules for building rule DTOs.
+// Callers converting multiple rules should use LoadPlanLimits and ConvertWithPlanLimits.
 func (c *RuleSyncConverter) Convert(ctx context.Context, rule *forward.ForwardRule, agentID uint) (*dto.RuleSyncData, error) {
+	return c.ConvertWithPlanLimits(ctx, rule, agentID, nil)
+}
+
+// ConvertWithPlanLimits converts a ForwardRule using plan limits preloaded by LoadPlanLimits.
+// If planLimits is nil, the plan limits of the rule are loaded when needed.
+func (c *RuleSyncConverter) ConvertWithPlanLimits(ctx context.Context, rule *forward.ForwardRule, agentID uint, planLimits PlanLimits) (*dto.RuleSyncData, error) {
 	syncData := &dto.RuleSyncData{
 		ID:         rule.SID(),
 		ShortID:    rule.SID(),
@@ -116,9 +187,39 @@ func (c *RuleSyncConverter) Convert(ctx context.Context, rule *forward.ForwardRu
 		syncData.BindIP = ""
 	}
 
+	// Limits and ACL are enforced by the entry agent, where client connections arrive
+	if syncData.Role == "entry" {
+		if planLimits == nil && rule.SubscriptionID() != nil {
+			planLimits = c.LoadPlanLimits(ctx, []*forward.ForwardRule{rule})
+		}
+		syncData.Limits = resolveLimits(rule, planLimits)
+		if acl := rule.ACL(); !acl.IsEmpty() {
+			syncData.ACL = &dto.RuleACL{
+				AllowCIDRs:     acl.AllowCIDRs,
+				DenyCIDRs:      acl.DenyCIDRs,
+				AllowCountries: acl.AllowCountries,
+				DenyCountries:  acl.DenyCountries,
+			}
+		}
+	}
+
 	return syncData, nil
 }
 
+// resolveLimits returns the effective limits of a rule, or nil if it is unlimited.
+// Limits not set on a subscription-bound rule are taken from the subscription's plan.
+func resolveLimits(rule *forward.ForwardRule, planLimits PlanLimits) *dto.RuleLimits {
+	limits := dto.EffectiveRuleLimits(rule, planLimits)
+	if limits.IsZero() {
+		return nil
+	}
+	return &dto.RuleLimits{
+		UploadMbps:     limits.UploadMbps,
+		DownloadMbps:   limits.DownloadMbps,
+		MaxConnections: limits.MaxConnections,
+	}
+}
+
 // convertDirectRule handles direct rule type conversion.
 func (c *RuleSyncConverter) convertDirectRule(data *dto.RuleSyncData, targetAddress string, targetPort uint16) {
 	data.Role = "entry"
@@ -246,12 +347,39 @@ func (c *RuleSyncConverter) populateExitAgentsInfo(
 		exitAgentIDs[i] = aw.AgentID()
 	}
 
+	// Send exits with unhealthy tunnels as backups until they recover
+	if c.demotedExits != nil {
+		demoted, err := c.demotedExits.GetDemoted(ctx, rule.ID(), exitAgentIDs)
+		if err != nil {
+			c.logger.Warnw("failed to get demoted exit agents, using configured weights",
+				"rule_id", rule.ID(),
+				"error", err,
+			)
+		} else {
+			exitAgents = rule.EffectiveExitAgents(demoted)
+		}
+	}
+
 	// Batch fetch agents
 	agentMap, err := c.agentRepo.GetByIDs(ctx, exitAgentIDs)
 	if err != nil {
 		return fmt.Errorf("get exit agents: %w", err)
 	}
 
+	// Exit metrics for strategies that select exits by connections or latency
+	strategy := rule.LoadBalanceStrategy()
+	var metrics *forward.LoadBalanceMetrics
+	if strategy.UsesExitMetrics() && c.lbMetrics != nil {
+		metrics, err = c.lbMetrics.Get(ctx, rule.ID())
+		if err != nil {
+			c.logger.Warnw("failed to get load balance metrics",
+				"rule_id", rule.ID(),
+				"error", err,
+			)
+			metrics = nil
+		}
+	}
+
 	data.ExitAgents = make([]dto.ExitAgentSyncData, 0, len(exitAgents))
 	missingAgents := 0
 	for _, aw := range exitAgents {
@@ -271,6 +399,14 @@ func (c *RuleSyncConverter) populateExitAgentsInfo(
 			Address: c.resolveAgentAddress(agent, addrPref),
 			Online:  c.hub.IsAgentOnline(aw.AgentID()),
 		}
+		if metrics != nil {
+			exit := metrics.Exits[aw.AgentID()]
+			if strategy.IsLowestLatency() {
+				exitAgentData.LatencyMs = exit.LatencyMs
+			} else {
+				exitAgentData.Connections = exit.Connections
+			}
+		}
 
 		// Get tunnel ports from cached agent status
 		status, err := c.statusQuerier.GetStatus(ctx, aw.AgentID())
@@ -293,7 +429,23 @@ func (c *RuleSyncConverter) populateExitAgentsInfo(
 	}
 
 	// Populate load balance strategy
-	data.LoadBalanceStrategy = rule.LoadBalanceStrategy().String()
+	data.LoadBalanceStrategy = strategy.String()
+	if strategy.UsesExitMetrics() {
+		data.LoadBalance = &dto.LoadBalanceConfig{
+			MetricsMaxAge: int64(forward.LoadBalanceMetricsMaxAge / time.Second),
+		}
+		if strategy.IsLowestLatency() {
+			data.LoadBalance.LatencyToleranceMs = forward.LoadBalanceLatencyToleranceMs
+		} else {
+			data.LoadBalance.ConnectionTolerance = forward.LoadBalanceConnectionTolerance
+		}
+		if metrics != nil {
+			data.LoadBalance.MetricsUpdatedAt = metrics.UpdatedAt.Unix()
+			if preferred, ok := agentMap[metrics.PreferredExit]; ok && preferred != nil {
+				data.LoadBalance.PreferredExitAgentID = preferred.SID()
+			}
+		}
+	}
 
 	// Populate health check config with default values for failover strategy
 	data.HealthCheck = &dto.HealthCheckConfig{
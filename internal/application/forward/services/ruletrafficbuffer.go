@@ -8,6 +8,7 @@ import (
 	"github.com/orris-inc/orris/internal/infrastructure/cache"
 	"github.com/orris-inc/orris/internal/shared/goroutine"
 	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/metrics"
 )
 
 const (
@@ -92,6 +93,8 @@ func (b *RuleTrafficBuffer) AddTraffic(ruleID uint, upload, download int64) {
 		return
 	}
 
+	metrics.ForwardRuleTraffic.Add(ruleID, upload, download)
+
 	shard := b.getShard(ruleID)
 	shard.mu.Lock()
 	defer shard.mu.Unlock()
@@ -2,25 +2,31 @@
 package services
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/orris-inc/orris/internal/application/forward/dto"
+	"github.com/orris-inc/orris/internal/application/forward/usecases"
 	"github.com/orris-inc/orris/internal/shared/logger"
 )
 
 // TunnelHealthHandler handles tunnel health reports from entry agents.
-// When an entry agent detects that a tunnel to an exit agent is unhealthy,
-// it reports to the server for logging and monitoring purposes.
+// Entry agents periodically check the tunnels to their exit agents and report the
+// result. Reports are recorded in the tunnel health timeline, and unhealthy exits of
+// multi-exit rules are failed over.
 type TunnelHealthHandler struct {
-	logger logger.Interface
+	recordUC *usecases.RecordTunnelHealthUseCase
+	logger   logger.Interface
 }
 
 // NewTunnelHealthHandler creates a new TunnelHealthHandler.
-func NewTunnelHealthHandler(log logger.Interface) *TunnelHealthHandler {
+func NewTunnelHealthHandler(recordUC *usecases.RecordTunnelHealthUseCase, log logger.Interface) *TunnelHealthHandler {
 	return &TunnelHealthHandler{
-		logger: log,
+		recordUC: recordUC,
+		logger:   log,
 	}
 }
 
@@ -66,6 +72,25 @@ func (h *TunnelHealthHandler) HandleMessage(agentID uint, msgType string, data a
 		)
 	}
 
+	cmd := usecases.RecordTunnelHealthCommand{
+		EntryAgentID: agentID,
+		RuleSID:      report.RuleID,
+		ExitAgentSID: report.ExitAgentID,
+		Healthy:      report.Healthy,
+		FailCount:    report.FailCount,
+		LatencyMs:    report.LatencyMs,
+		Error:        report.Error,
+		CheckedAt:    time.Unix(report.CheckedAt, 0).UTC(),
+	}
+	if err := h.recordUC.Execute(context.Background(), cmd); err != nil {
+		h.logger.Warnw("failed to record tunnel health report",
+			"reporting_agent_id", agentID,
+			"rule_id", report.RuleID,
+			"exit_agent_id", report.ExitAgentID,
+			"error", err,
+		)
+	}
+
 	return true
 }
 
@@ -328,6 +328,31 @@ func (m *MockForwardRuleRepository) IsPortInUseByAgent(ctx context.Context, agen
 	return false, nil
 }
 
+// ListPortUsageByAgent returns the ports the specified agent listens on across all rules.
+func (m *MockForwardRuleRepository) ListPortUsageByAgent(ctx context.Context, agentID uint) ([]forward.AgentPortUsage, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+
+	if m.listError != nil {
+		return nil, m.listError
+	}
+
+	var usages []forward.AgentPortUsage
+	for _, rule := range m.rules {
+		if rule.AgentID() == agentID && rule.ListenPort() > 0 {
+			usages = append(usages, forward.AgentPortUsage{
+				Port: rule.ListenPort(), RuleID: rule.ID(), RuleSID: rule.SID(), RuleName: rule.Name(),
+			})
+		}
+		if port, ok := rule.ChainPortConfig()[agentID]; ok {
+			usages = append(usages, forward.AgentPortUsage{
+				Port: port, RuleID: rule.ID(), RuleSID: rule.SID(), RuleName: rule.Name(), ChainHop: true,
+			})
+		}
+	}
+	return usages, nil
+}
+
 // UpdateTraffic updates the traffic counters for a rule.
 func (m *MockForwardRuleRepository) UpdateTraffic(ctx context.Context, id uint, upload, download int64) error {
 	m.mu.Lock()
@@ -3,8 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"math/rand"
 
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	nodedto "github.com/orris-inc/orris/internal/application/node/dto"
 	"github.com/orris-inc/orris/internal/domain/forward"
 	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
@@ -30,13 +30,13 @@ type CreateForwardRuleCommand struct {
 	RuleType            string            // direct, entry, chain, direct_chain, external
 	ExitAgentShortID    string            // for entry type (Stripe-style short ID, mutually exclusive with ExitAgents)
 	ExitAgents          []ExitAgentInput  // for entry type with load balancing (mutually exclusive with ExitAgentShortID)
-	LoadBalanceStrategy string            // load balance strategy: failover (default), weighted
+	LoadBalanceStrategy string            // load balance strategy: failover (default), weighted, round_robin, least_connections, lowest_latency
 	ChainAgentShortIDs  []string          // required for chain type (ordered list of Stripe-style short IDs without prefix)
-	ChainPortConfig     map[string]uint16 // required for direct_chain type or hybrid chain direct hops (agent short_id -> listen port)
+	ChainPortConfig     map[string]uint16 // required for direct_chain type or hybrid chain direct hops (agent short_id -> listen port, 0 = auto-assign)
 	TunnelHops          *int              // number of hops using tunnel (nil=full tunnel, N=first N hops use tunnel) - for chain type only
 	TunnelType          string            // tunnel type: ws or tls (default: ws)
 	Name                string
-	ListenPort          uint16   // listen port (0 = auto-assign from agent's allowed range, required for external type)
+	ListenPort          uint16   // listen port (0 = auto-assign from agent's port pool, required for external type)
 	TargetAddress       string   // required for all types except external (mutually exclusive with TargetNodeSID)
 	TargetPort          uint16   // required for all types except external (mutually exclusive with TargetNodeSID)
 	TargetNodeSID       string   // optional for all types (Stripe-style short ID without prefix)
@@ -49,6 +49,10 @@ type CreateForwardRuleCommand struct {
 	GroupSIDs           []string                // optional resource group SIDs (admin only)
 	Route               *nodedto.RouteConfigDTO // optional per-rule routing configuration
 	AddressPreference   string                  // optional: auto (default), public, tunnel
+	UploadLimitMbps     uint32                  // optional upload bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	DownloadLimitMbps   uint32                  // optional download bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	MaxConnections      uint32                  // optional max concurrent connections (0 = inherit from plan or unlimited)
+	ACL                 *dto.RuleACLDTO         // optional source IP access control list
 	// External rule fields (only for rule_type=external)
 	ServerAddress  string // required for external type - server address for subscription delivery
 	ExternalSource string // required for external type - source identifier
@@ -80,6 +84,7 @@ type CreateForwardRuleUseCase struct {
 	resourceGroupRepo resource.Repository
 	planRepo          subscription.PlanRepository
 	configSyncSvc     ConfigSyncNotifier
+	portAllocator     PortAllocator
 	syncer            NodeSubscriptionSyncer
 	nodeConfigSyncer  NodeConfigChangeNotifier
 	logger            logger.Interface
@@ -105,6 +110,7 @@ func NewCreateForwardRuleUseCase(
 	resourceGroupRepo resource.Repository,
 	planRepo subscription.PlanRepository,
 	configSyncSvc ConfigSyncNotifier,
+	portAllocator PortAllocator,
 	logger logger.Interface,
 ) *CreateForwardRuleUseCase {
 	return &CreateForwardRuleUseCase{
@@ -114,6 +120,7 @@ func NewCreateForwardRuleUseCase(
 		resourceGroupRepo: resourceGroupRepo,
 		planRepo:          planRepo,
 		configSyncSvc:     configSyncSvc,
+		portAllocator:     portAllocator,
 		logger:            logger,
 	}
 }
@@ -143,9 +150,14 @@ func (uc *CreateForwardRuleUseCase) Execute(ctx context.Context, cmd CreateForwa
 	}
 	agentID := agent.ID()
 
+	// Ports stay reserved until the rule is persisted
+	reservations := newPortReservations(uc.portAllocator)
+	defer reservations.releaseAll(ctx)
+
 	// Auto-assign listen port if not specified
-	if cmd.ListenPort == 0 {
-		port, err := uc.assignAvailablePort(ctx, agent)
+	autoAssignPort := cmd.ListenPort == 0
+	if autoAssignPort {
+		port, err := reservations.allocate(ctx, agent)
 		if err != nil {
 			uc.logger.Errorw("failed to auto-assign listen port", "agent_id", agentID, "error", err)
 			return nil, err
@@ -249,19 +261,29 @@ func (uc *CreateForwardRuleUseCase) Execute(ctx context.Context, cmd CreateForwa
 			if !ok || chainAgent == nil {
 				return nil, errors.NewNotFoundError("chain forward agent in chain_port_config", shortID)
 			}
+			// Auto-assign chain hop port if not specified
+			if port == 0 {
+				port, err = reservations.allocate(ctx, chainAgent)
+				if err != nil {
+					uc.logger.Errorw("failed to auto-assign chain agent port", "chain_agent_id", chainAgent.ID(), "error", err)
+					return nil, err
+				}
+				chainPortConfig[chainAgent.ID()] = port
+				continue
+			}
 			// Validate port against chain agent's allowed port range
 			if !chainAgent.IsPortAllowed(port) {
 				return nil, errors.NewValidationError(
 					fmt.Sprintf("listen port %d is not allowed for chain agent %s, allowed ranges: %s",
 						port, shortID, chainAgent.AllowedPortRange().String()))
 			}
-			// Check if the port is already in use on this chain agent (including other rules' chain_port_config)
-			inUse, err := uc.repo.IsPortInUseByAgent(ctx, chainAgent.ID(), port, 0)
+			// Reserve the port on this chain agent (fails if in use, including other rules' chain_port_config)
+			reserved, err := reservations.reserve(ctx, chainAgent.ID(), port)
 			if err != nil {
 				uc.logger.Errorw("failed to check chain agent port", "chain_agent_id", chainAgent.ID(), "port", port, "error", err)
 				return nil, fmt.Errorf("failed to check chain agent port: %w", err)
 			}
-			if inUse {
+			if !reserved {
 				return nil, errors.NewConflictError(
 					fmt.Sprintf("listen port %d is already in use on chain agent %s", port, shortID),
 					fmt.Sprintf("%d", port))
@@ -314,15 +336,17 @@ func (uc *CreateForwardRuleUseCase) Execute(ctx context.Context, cmd CreateForwa
 		return nil, err
 	}
 
-	// Check if listen port is already in use on this agent (including other rules' chain_port_config)
-	inUse, err := uc.repo.IsPortInUseByAgent(ctx, agentID, cmd.ListenPort, 0)
-	if err != nil {
-		uc.logger.Errorw("failed to check existing forward rule", "agent_id", agentID, "port", cmd.ListenPort, "error", err)
-		return nil, fmt.Errorf("failed to check existing rule: %w", err)
-	}
-	if inUse {
-		uc.logger.Warnw("listen port already in use on this agent", "agent_id", agentID, "port", cmd.ListenPort)
-		return nil, errors.NewConflictError("listen port is already in use on this agent", fmt.Sprintf("%d", cmd.ListenPort))
+	// Reserve the specified listen port on this agent (fails if in use, including other rules' chain_port_config)
+	if !autoAssignPort {
+		reserved, err := reservations.reserve(ctx, agentID, cmd.ListenPort)
+		if err != nil {
+			uc.logger.Errorw("failed to check existing forward rule", "agent_id", agentID, "port", cmd.ListenPort, "error", err)
+			return nil, fmt.Errorf("failed to check existing rule: %w", err)
+		}
+		if !reserved {
+			uc.logger.Warnw("listen port already in use on this agent", "agent_id", agentID, "port", cmd.ListenPort)
+			return nil, errors.NewConflictError("listen port is already in use on this agent", fmt.Sprintf("%d", cmd.ListenPort))
+		}
 	}
 
 	// Resolve GroupSIDs to internal IDs and validate plan types (if provided)
@@ -420,6 +444,21 @@ func (uc *CreateForwardRuleUseCase) Execute(ctx context.Context, cmd CreateForwa
 		return nil, errors.NewValidationError(err.Error())
 	}
 
+	// Set limits if provided
+	limits := vo.RuleLimits{
+		UploadMbps:     cmd.UploadLimitMbps,
+		DownloadMbps:   cmd.DownloadLimitMbps,
+		MaxConnections: cmd.MaxConnections,
+	}
+	if err := rule.UpdateLimits(limits); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	// Set ACL if provided
+	if err := setRuleACL(rule, cmd.ACL); err != nil {
+		return nil, err
+	}
+
 	// Set group IDs if provided
 	if len(groupIDs) > 0 {
 		rule.SetGroupIDs(groupIDs)
@@ -668,50 +707,20 @@ func derefIntOrDefault(ptr *int, defaultVal int) int {
 	return *ptr
 }
 
-// Default port range for auto-assignment when agent has no port restrictions.
-const (
-	defaultPortRangeStart = 10000
-	defaultPortRangeEnd   = 60000
-	maxPortAssignAttempts = 100
-)
-
-// assignAvailablePort finds an available port for the given agent.
-// If the agent has allowed port ranges configured, picks from those ranges.
-// Otherwise, uses the default range (10000-60000).
-// Note: Port uniqueness is checked per-agent, not globally.
-func (uc *CreateForwardRuleUseCase) assignAvailablePort(ctx context.Context, agent *forward.ForwardAgent) (uint16, error) {
-	portRange := agent.AllowedPortRange()
-	agentID := agent.ID()
-
-	for range maxPortAssignAttempts {
-		var port uint16
-		if portRange != nil && !portRange.IsEmpty() {
-			// Use agent's allowed port range
-			port = portRange.RandomPort()
-		} else {
-			// Use default range: port range is 10000-65000, so result always fits in uint16
-			randomOffset := rand.Intn(defaultPortRangeEnd - defaultPortRangeStart + 1)
-			portVal := defaultPortRangeStart + randomOffset
-			// #nosec G115 -- portVal is bounded by defaultPortRangeStart(10000) to defaultPortRangeEnd(65000)
-			port = uint16(portVal)
-		}
-
-		// Defensive check: ensure port is within agent's allowed range
-		if !agent.IsPortAllowed(port) {
-			continue
-		}
-
-		// Check if port is already in use on this agent (including chain_port_config)
-		inUse, err := uc.repo.IsPortInUseByAgent(ctx, agentID, port, 0)
-		if err != nil {
-			return 0, fmt.Errorf("failed to check port availability: %w", err)
-		}
-		if !inUse {
-			return port, nil
-		}
+// setRuleACL validates and sets the source IP access control list of a rule.
+// A nil DTO leaves the ACL unchanged; a DTO with empty lists clears it.
+func setRuleACL(rule *forward.ForwardRule, aclDTO *dto.RuleACLDTO) error {
+	if aclDTO == nil {
+		return nil
 	}
-
-	return 0, errors.NewValidationError("failed to find available port after maximum attempts")
+	acl, err := dto.FromRuleACLDTO(aclDTO)
+	if err != nil {
+		return errors.NewValidationError(fmt.Sprintf("invalid acl: %s", err.Error()))
+	}
+	if err := rule.UpdateACL(acl); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+	return nil
 }
 
 // executeExternalRule handles external rule creation.
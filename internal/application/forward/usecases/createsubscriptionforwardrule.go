@@ -3,8 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"math/rand"
 
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	"github.com/orris-inc/orris/internal/domain/forward"
 	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
 	"github.com/orris-inc/orris/internal/domain/node"
@@ -22,7 +22,7 @@ type CreateSubscriptionForwardRuleCommand struct {
 	RuleType           string            // direct, entry, chain, direct_chain
 	ExitAgentShortID   string            // required for entry type
 	ChainAgentShortIDs []string          // required for chain type
-	ChainPortConfig    map[string]uint16 // required for direct_chain type
+	ChainPortConfig    map[string]uint16 // required for direct_chain type (0 = auto-assign)
 	TunnelHops         *int              // number of hops using tunnel (for chain type)
 	TunnelType         string            // tunnel type: ws or tls
 	Name               string
@@ -36,8 +36,9 @@ type CreateSubscriptionForwardRuleCommand struct {
 	TrafficMultiplier  *float64
 	SortOrder          *int
 	Remark             string
-	AddressPreference  string // optional: auto (default), public, tunnel
-	RuleLimit          int    // rule limit for the subscription (0 = unlimited, used for race condition check)
+	AddressPreference  string          // optional: auto (default), public, tunnel
+	ACL                *dto.RuleACLDTO // optional source IP access control list
+	RuleLimit          int             // rule limit for the subscription (0 = unlimited, used for race condition check)
 }
 
 // CreateSubscriptionForwardRuleResult represents the output of creating a subscription-bound forward rule.
@@ -64,6 +65,7 @@ type CreateSubscriptionForwardRuleUseCase struct {
 	agentRepo     forward.AgentRepository
 	nodeRepo      node.NodeRepository
 	configSyncSvc ConfigSyncNotifier
+	portAllocator PortAllocator
 	logger        logger.Interface
 }
 
@@ -73,6 +75,7 @@ func NewCreateSubscriptionForwardRuleUseCase(
 	agentRepo forward.AgentRepository,
 	nodeRepo node.NodeRepository,
 	configSyncSvc ConfigSyncNotifier,
+	portAllocator PortAllocator,
 	logger logger.Interface,
 ) *CreateSubscriptionForwardRuleUseCase {
 	return &CreateSubscriptionForwardRuleUseCase{
@@ -80,6 +83,7 @@ func NewCreateSubscriptionForwardRuleUseCase(
 		agentRepo:     agentRepo,
 		nodeRepo:      nodeRepo,
 		configSyncSvc: configSyncSvc,
+		portAllocator: portAllocator,
 		logger:        logger,
 	}
 }
@@ -115,12 +119,16 @@ func (uc *CreateSubscriptionForwardRuleUseCase) Execute(ctx context.Context, cmd
 	}
 	agentID := agent.ID()
 
+	// Ports stay reserved until the rule is persisted
+	reservations := newPortReservations(uc.portAllocator)
+	defer reservations.releaseAll(ctx)
+
 	// Record whether port should be auto-assigned (for retry logic on conflict)
 	isAutoAssignPort := cmd.ListenPort == 0
 
 	// Auto-assign listen port if not specified
 	if isAutoAssignPort {
-		port, err := uc.assignAvailablePort(ctx, agent)
+		port, err := reservations.allocate(ctx, agent)
 		if err != nil {
 			uc.logger.Errorw("failed to auto-assign listen port", "agent_id", agentID, "subscription_id", cmd.SubscriptionID, "error", err)
 			return nil, err
@@ -136,10 +144,24 @@ func (uc *CreateSubscriptionForwardRuleUseCase) Execute(ctx context.Context, cmd
 				cmd.ListenPort, agent.AllowedPortRange().String()))
 	}
 
+	// Reserve the specified listen port on this agent (fails if in use, including other rules' chain_port_config)
+	if !isAutoAssignPort {
+		reserved, err := reservations.reserve(ctx, agentID, cmd.ListenPort)
+		if err != nil {
+			uc.logger.Errorw("failed to check existing forward rule", "agent_id", agentID, "port", cmd.ListenPort, "subscription_id", cmd.SubscriptionID, "error", err)
+			return nil, fmt.Errorf("failed to check existing rule: %w", err)
+		}
+		if !reserved {
+			uc.logger.Warnw("listen port already in use on this agent", "agent_id", agentID, "port", cmd.ListenPort, "subscription_id", cmd.SubscriptionID)
+			return nil, errors.NewConflictError("listen port is already in use on this agent", fmt.Sprintf("%d", cmd.ListenPort))
+		}
+	}
+
 	// Store the context for port conflict retry
 	portRetryCtx := &portRetryContext{
 		agent:            agent,
 		isAutoAssignPort: isAutoAssignPort,
+		reservations:     reservations,
 	}
 
 	// Resolve ExitAgentShortID to internal ID (if provided)
@@ -186,19 +208,29 @@ func (uc *CreateSubscriptionForwardRuleUseCase) Execute(ctx context.Context, cmd
 			if chainAgent == nil {
 				return nil, errors.NewNotFoundError("chain forward agent in chain_port_config", shortID)
 			}
+			// Auto-assign chain hop port if not specified
+			if port == 0 {
+				port, err = reservations.allocate(ctx, chainAgent)
+				if err != nil {
+					uc.logger.Errorw("failed to auto-assign chain agent port", "chain_agent_id", chainAgent.ID(), "subscription_id", cmd.SubscriptionID, "error", err)
+					return nil, err
+				}
+				chainPortConfig[chainAgent.ID()] = port
+				continue
+			}
 			// Validate port against chain agent's allowed port range
 			if !chainAgent.IsPortAllowed(port) {
 				return nil, errors.NewValidationError(
 					fmt.Sprintf("listen port %d is not allowed for chain agent %s, allowed ranges: %s",
 						port, shortID, chainAgent.AllowedPortRange().String()))
 			}
-			// Check if the port is already in use on this chain agent
-			inUse, err := uc.repo.IsPortInUseByAgent(ctx, chainAgent.ID(), port, 0)
+			// Reserve the port on this chain agent
+			reserved, err := reservations.reserve(ctx, chainAgent.ID(), port)
 			if err != nil {
 				uc.logger.Errorw("failed to check chain agent port", "chain_agent_id", chainAgent.ID(), "port", port, "subscription_id", cmd.SubscriptionID, "error", err)
 				return nil, fmt.Errorf("failed to check chain agent port: %w", err)
 			}
-			if inUse {
+			if !reserved {
 				return nil, errors.NewConflictError(
 					fmt.Sprintf("listen port %d is already in use on chain agent %s", port, shortID),
 					fmt.Sprintf("%d", port))
@@ -248,6 +280,7 @@ func (uc *CreateSubscriptionForwardRuleUseCase) Execute(ctx context.Context, cmd
 type portRetryContext struct {
 	agent            *forward.ForwardAgent
 	isAutoAssignPort bool
+	reservations     *portReservations
 }
 
 // maxPortConflictRetries is the maximum number of retries when auto-assigned port conflicts.
@@ -301,7 +334,7 @@ func (uc *CreateSubscriptionForwardRuleUseCase) createRuleWithRetry(
 	for attempt := 0; attempt < maxRetries; attempt++ {
 		if attempt > 0 {
 			// Re-assign port on retry (only for auto-assign mode)
-			newPort, err := uc.assignAvailablePort(ctx, retryCtx.agent)
+			newPort, err := retryCtx.reservations.allocate(ctx, retryCtx.agent)
 			if err != nil {
 				uc.logger.Errorw("failed to re-assign port on retry",
 					"agent_id", agentID,
@@ -352,6 +385,11 @@ func (uc *CreateSubscriptionForwardRuleUseCase) createRuleWithRetry(
 			return nil, errors.NewValidationError(err.Error())
 		}
 
+		// Set ACL if provided
+		if err := setRuleACL(rule, cmd.ACL); err != nil {
+			return nil, err
+		}
+
 		// Persist - database unique constraint is the final protection against race conditions
 		if err := uc.repo.Create(ctx, rule); err != nil {
 			// Check if this is a port conflict error
@@ -532,43 +570,3 @@ func (uc *CreateSubscriptionForwardRuleUseCase) validateCommand(_ context.Contex
 
 	return nil
 }
-
-// Default port range for auto-assignment
-const (
-	subscriptionDefaultPortRangeStart = 10000
-	subscriptionDefaultPortRangeEnd   = 60000
-	subscriptionMaxPortAssignAttempts = 100
-)
-
-// assignAvailablePort finds an available port for the given agent.
-func (uc *CreateSubscriptionForwardRuleUseCase) assignAvailablePort(ctx context.Context, agent *forward.ForwardAgent) (uint16, error) {
-	portRange := agent.AllowedPortRange()
-	agentID := agent.ID()
-
-	for i := 0; i < subscriptionMaxPortAssignAttempts; i++ {
-		var port uint16
-		if portRange != nil && !portRange.IsEmpty() {
-			port = portRange.RandomPort()
-		} else {
-			// Use default range: port range is 10000-65000, so result always fits in uint16
-			randomOffset := rand.Intn(subscriptionDefaultPortRangeEnd - subscriptionDefaultPortRangeStart + 1)
-			portVal := subscriptionDefaultPortRangeStart + randomOffset
-			// #nosec G115 -- portVal is bounded by subscriptionDefaultPortRangeStart(10000) to subscriptionDefaultPortRangeEnd(65000)
-			port = uint16(portVal)
-		}
-
-		if !agent.IsPortAllowed(port) {
-			continue
-		}
-
-		inUse, err := uc.repo.IsPortInUseByAgent(ctx, agentID, port, 0)
-		if err != nil {
-			return 0, fmt.Errorf("failed to check port availability: %w", err)
-		}
-		if !inUse {
-			return port, nil
-		}
-	}
-
-	return 0, errors.NewValidationError("failed to find available port after maximum attempts")
-}
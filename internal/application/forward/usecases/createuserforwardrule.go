@@ -3,8 +3,8 @@ package usecases
 import (
 	"context"
 	"fmt"
-	"math/rand"
 
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	"github.com/orris-inc/orris/internal/domain/forward"
 	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
 	"github.com/orris-inc/orris/internal/domain/node"
@@ -21,11 +21,11 @@ type CreateUserForwardRuleCommand struct {
 	RuleType           string            // direct, entry, chain, direct_chain
 	ExitAgentShortID   string            // required for entry type (Stripe-style short ID without prefix)
 	ChainAgentShortIDs []string          // required for chain type (ordered list of Stripe-style short IDs without prefix)
-	ChainPortConfig    map[string]uint16 // required for direct_chain type or hybrid chain direct hops (agent short_id -> listen port)
+	ChainPortConfig    map[string]uint16 // required for direct_chain type or hybrid chain direct hops (agent short_id -> listen port, 0 = auto-assign)
 	TunnelHops         *int              // number of hops using tunnel (nil=full tunnel, N=first N hops use tunnel) - for chain type only
 	TunnelType         string            // tunnel type: ws or tls (default: ws)
 	Name               string
-	ListenPort         uint16 // listen port (0 = auto-assign from agent's allowed range)
+	ListenPort         uint16 // listen port (0 = auto-assign from agent's port pool)
 	TargetAddress      string // required for all types (mutually exclusive with TargetNodeSID)
 	TargetPort         uint16 // required for all types (mutually exclusive with TargetNodeSID)
 	TargetNodeSID      string // optional for all types (Stripe-style short ID without prefix)
@@ -35,7 +35,8 @@ type CreateUserForwardRuleCommand struct {
 	TrafficMultiplier  *float64 // optional traffic multiplier (nil for auto-calculation, 0-1000000)
 	SortOrder          *int     // optional sort order (nil defaults to 0)
 	Remark             string
-	AddressPreference  string // optional: auto (default), public, tunnel
+	AddressPreference  string          // optional: auto (default), public, tunnel
+	ACL                *dto.RuleACLDTO // optional source IP access control list
 }
 
 // CreateUserForwardRuleResult represents the output of creating a user forward rule.
@@ -61,6 +62,7 @@ type CreateUserForwardRuleUseCase struct {
 	agentRepo     forward.AgentRepository
 	nodeRepo      node.NodeRepository
 	configSyncSvc ConfigSyncNotifier
+	portAllocator PortAllocator
 	logger        logger.Interface
 }
 
@@ -70,6 +72,7 @@ func NewCreateUserForwardRuleUseCase(
 	agentRepo forward.AgentRepository,
 	nodeRepo node.NodeRepository,
 	configSyncSvc ConfigSyncNotifier,
+	portAllocator PortAllocator,
 	logger logger.Interface,
 ) *CreateUserForwardRuleUseCase {
 	return &CreateUserForwardRuleUseCase{
@@ -77,6 +80,7 @@ func NewCreateUserForwardRuleUseCase(
 		agentRepo:     agentRepo,
 		nodeRepo:      nodeRepo,
 		configSyncSvc: configSyncSvc,
+		portAllocator: portAllocator,
 		logger:        logger,
 	}
 }
@@ -104,9 +108,14 @@ func (uc *CreateUserForwardRuleUseCase) Execute(ctx context.Context, cmd CreateU
 	}
 	agentID := agent.ID()
 
+	// Ports stay reserved until the rule is persisted
+	reservations := newPortReservations(uc.portAllocator)
+	defer reservations.releaseAll(ctx)
+
 	// Auto-assign listen port if not specified
-	if cmd.ListenPort == 0 {
-		port, err := uc.assignAvailablePort(ctx, agent)
+	autoAssignPort := cmd.ListenPort == 0
+	if autoAssignPort {
+		port, err := reservations.allocate(ctx, agent)
 		if err != nil {
 			uc.logger.Errorw("failed to auto-assign listen port", "agent_id", agentID, "user_id", cmd.UserID, "error", err)
 			return nil, err
@@ -168,19 +177,29 @@ func (uc *CreateUserForwardRuleUseCase) Execute(ctx context.Context, cmd CreateU
 			if chainAgent == nil {
 				return nil, errors.NewNotFoundError("chain forward agent in chain_port_config", shortID)
 			}
+			// Auto-assign chain hop port if not specified
+			if port == 0 {
+				port, err = reservations.allocate(ctx, chainAgent)
+				if err != nil {
+					uc.logger.Errorw("failed to auto-assign chain agent port", "chain_agent_id", chainAgent.ID(), "user_id", cmd.UserID, "error", err)
+					return nil, err
+				}
+				chainPortConfig[chainAgent.ID()] = port
+				continue
+			}
 			// Validate port against chain agent's allowed port range
 			if !chainAgent.IsPortAllowed(port) {
 				return nil, errors.NewValidationError(
 					fmt.Sprintf("listen port %d is not allowed for chain agent %s, allowed ranges: %s",
 						port, shortID, chainAgent.AllowedPortRange().String()))
 			}
-			// Check if the port is already in use on this chain agent (including other rules' chain_port_config)
-			inUse, err := uc.repo.IsPortInUseByAgent(ctx, chainAgent.ID(), port, 0)
+			// Reserve the port on this chain agent (fails if in use, including other rules' chain_port_config)
+			reserved, err := reservations.reserve(ctx, chainAgent.ID(), port)
 			if err != nil {
 				uc.logger.Errorw("failed to check chain agent port", "chain_agent_id", chainAgent.ID(), "port", port, "user_id", cmd.UserID, "error", err)
 				return nil, fmt.Errorf("failed to check chain agent port: %w", err)
 			}
-			if inUse {
+			if !reserved {
 				return nil, errors.NewConflictError(
 					fmt.Sprintf("listen port %d is already in use on chain agent %s", port, shortID),
 					fmt.Sprintf("%d", port))
@@ -221,15 +240,17 @@ func (uc *CreateUserForwardRuleUseCase) Execute(ctx context.Context, cmd CreateU
 		return nil, err
 	}
 
-	// Check if listen port is already in use on this agent (including other rules' chain_port_config)
-	inUse, err := uc.repo.IsPortInUseByAgent(ctx, agentID, cmd.ListenPort, 0)
-	if err != nil {
-		uc.logger.Errorw("failed to check existing forward rule", "agent_id", agentID, "port", cmd.ListenPort, "user_id", cmd.UserID, "error", err)
-		return nil, fmt.Errorf("failed to check existing rule: %w", err)
-	}
-	if inUse {
-		uc.logger.Warnw("listen port already in use on this agent", "agent_id", agentID, "port", cmd.ListenPort, "user_id", cmd.UserID)
-		return nil, errors.NewConflictError("listen port is already in use on this agent", fmt.Sprintf("%d", cmd.ListenPort))
+	// Reserve the specified listen port on this agent (fails if in use, including other rules' chain_port_config)
+	if !autoAssignPort {
+		reserved, err := reservations.reserve(ctx, agentID, cmd.ListenPort)
+		if err != nil {
+			uc.logger.Errorw("failed to check existing forward rule", "agent_id", agentID, "port", cmd.ListenPort, "user_id", cmd.UserID, "error", err)
+			return nil, fmt.Errorf("failed to check existing rule: %w", err)
+		}
+		if !reserved {
+			uc.logger.Warnw("listen port already in use on this agent", "agent_id", agentID, "port", cmd.ListenPort, "user_id", cmd.UserID)
+			return nil, errors.NewConflictError("listen port is already in use on this agent", fmt.Sprintf("%d", cmd.ListenPort))
+		}
 	}
 
 	// Create domain entity with user_id
@@ -269,6 +290,11 @@ func (uc *CreateUserForwardRuleUseCase) Execute(ctx context.Context, cmd CreateU
 		return nil, errors.NewValidationError(err.Error())
 	}
 
+	// Set ACL if provided
+	if err := setRuleACL(rule, cmd.ACL); err != nil {
+		return nil, err
+	}
+
 	// Persist
 	if err := uc.repo.Create(ctx, rule); err != nil {
 		uc.logger.Errorw("failed to persist user forward rule", "user_id", cmd.UserID, "error", err)
@@ -428,49 +454,3 @@ func (uc *CreateUserForwardRuleUseCase) validateCommand(_ context.Context, cmd C
 
 	return nil
 }
-
-// Default port range for auto-assignment when agent has no port restrictions.
-const (
-	userDefaultPortRangeStart = 10000
-	userDefaultPortRangeEnd   = 60000
-	userMaxPortAssignAttempts = 100
-)
-
-// assignAvailablePort finds an available port for the given agent.
-// If the agent has allowed port ranges configured, picks from those ranges.
-// Otherwise, uses the default range (10000-60000).
-// Note: Port uniqueness is checked per-agent, not globally.
-func (uc *CreateUserForwardRuleUseCase) assignAvailablePort(ctx context.Context, agent *forward.ForwardAgent) (uint16, error) {
-	portRange := agent.AllowedPortRange()
-	agentID := agent.ID()
-
-	for i := 0; i < userMaxPortAssignAttempts; i++ {
-		var port uint16
-		if portRange != nil && !portRange.IsEmpty() {
-			// Use agent's allowed port range
-			port = portRange.RandomPort()
-		} else {
-			// Use default range: port range is 10000-65000, so result always fits in uint16
-			randomOffset := rand.Intn(userDefaultPortRangeEnd - userDefaultPortRangeStart + 1)
-			portVal := userDefaultPortRangeStart + randomOffset
-			// #nosec G115 -- portVal is bounded by userDefaultPortRangeStart(10000) to userDefaultPortRangeEnd(65000)
-			port = uint16(portVal)
-		}
-
-		// Defensive check: ensure port is within agent's allowed range
-		if !agent.IsPortAllowed(port) {
-			continue
-		}
-
-		// Check if port is already in use on this agent (including chain_port_config)
-		inUse, err := uc.repo.IsPortInUseByAgent(ctx, agentID, port, 0)
-		if err != nil {
-			return 0, fmt.Errorf("failed to check port availability: %w", err)
-		}
-		if !inUse {
-			return port, nil
-		}
-	}
-
-	return 0, errors.NewValidationError("failed to find available port after maximum attempts")
-}
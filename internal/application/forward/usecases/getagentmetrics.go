@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// AgentMetricsHistoryQuerier defines the interface for querying agent system metrics history.
+type AgentMetricsHistoryQuerier interface {
+	GetAgentMetrics(ctx context.Context, agentID uint, rng commondto.SystemMetricsRange) ([]commondto.SystemMetricsPoint, error)
+}
+
+// GetAgentMetricsQuery represents the query for an agent's system metrics history.
+type GetAgentMetricsQuery struct {
+	ShortID    string // External API identifier
+	From       time.Time
+	To         time.Time
+	Resolution string // "minute", "hour", or empty to pick by range
+}
+
+// GetAgentMetricsUseCase returns the downsampled system metrics history of a forward agent.
+type GetAgentMetricsUseCase struct {
+	agentRepo      forward.AgentRepository
+	historyQuerier AgentMetricsHistoryQuerier
+	logger         logger.Interface
+}
+
+// NewGetAgentMetricsUseCase creates a new GetAgentMetricsUseCase.
+func NewGetAgentMetricsUseCase(
+	agentRepo forward.AgentRepository,
+	historyQuerier AgentMetricsHistoryQuerier,
+	logger logger.Interface,
+) *GetAgentMetricsUseCase {
+	return &GetAgentMetricsUseCase{
+		agentRepo:      agentRepo,
+		historyQuerier: historyQuerier,
+		logger:         logger,
+	}
+}
+
+// Execute retrieves the metrics time series of a forward agent.
+func (uc *GetAgentMetricsUseCase) Execute(ctx context.Context, query GetAgentMetricsQuery) (*commondto.SystemMetricsSeries, error) {
+	if query.ShortID == "" {
+		return nil, errors.NewValidationError("short_id is required")
+	}
+
+	rng, err := commondto.NewSystemMetricsRange(query.Resolution, query.From, query.To, biztime.NowUTC())
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	agent, err := uc.agentRepo.GetBySID(ctx, query.ShortID)
+	if err != nil {
+		uc.logger.Errorw("failed to get forward agent", "short_id", query.ShortID, "error", err)
+		return nil, fmt.Errorf("failed to get forward agent: %w", err)
+	}
+	if agent == nil {
+		return nil, errors.NewNotFoundError("forward agent", query.ShortID)
+	}
+
+	points, err := uc.historyQuerier.GetAgentMetrics(ctx, agent.ID(), rng)
+	if err != nil {
+		uc.logger.Errorw("failed to get agent metrics history", "agent_id", agent.ID(), "error", err)
+		return nil, errors.NewInternalError("failed to get forward agent metrics")
+	}
+
+	return &commondto.SystemMetricsSeries{
+		Resolution: rng.Resolution,
+		From:       rng.From,
+		To:         rng.To,
+		Points:     points,
+	}, nil
+}
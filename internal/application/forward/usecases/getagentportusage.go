@@ -0,0 +1,114 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"slices"
+
+	"github.com/orris-inc/orris/internal/application/forward/dto"
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// PortReservationLister lists the ports reserved on an agent by rules being created.
+type PortReservationLister interface {
+	ListReserved(ctx context.Context, agentID uint) ([]uint16, error)
+}
+
+// GetAgentPortUsageUseCase returns the listen port utilization of a forward agent.
+type GetAgentPortUsageUseCase struct {
+	agentRepo    forward.AgentRepository
+	ruleRepo     forward.RuleReader
+	reservations PortReservationLister
+	logger       logger.Interface
+}
+
+// NewGetAgentPortUsageUseCase creates a new GetAgentPortUsageUseCase.
+func NewGetAgentPortUsageUseCase(
+	agentRepo forward.AgentRepository,
+	ruleRepo forward.RuleReader,
+	reservations PortReservationLister,
+	logger logger.Interface,
+) *GetAgentPortUsageUseCase {
+	return &GetAgentPortUsageUseCase{
+		agentRepo:    agentRepo,
+		ruleRepo:     ruleRepo,
+		reservations: reservations,
+		logger:       logger,
+	}
+}
+
+// Execute retrieves the ports used and reserved on a forward agent.
+func (uc *GetAgentPortUsageUseCase) Execute(ctx context.Context, shortID string) (*dto.AgentPortUsageResponse, error) {
+	if shortID == "" {
+		return nil, errors.NewValidationError("short_id is required")
+	}
+
+	agent, err := uc.agentRepo.GetBySID(ctx, shortID)
+	if err != nil {
+		uc.logger.Errorw("failed to get forward agent", "short_id", shortID, "error", err)
+		return nil, fmt.Errorf("failed to get forward agent: %w", err)
+	}
+	if agent == nil {
+		return nil, errors.NewNotFoundError("forward agent", shortID)
+	}
+
+	usages, err := uc.ruleRepo.ListPortUsageByAgent(ctx, agent.ID())
+	if err != nil {
+		uc.logger.Errorw("failed to list agent port usage", "agent_id", agent.ID(), "error", err)
+		return nil, errors.NewInternalError("failed to get forward agent port usage")
+	}
+	reserved, err := uc.reservations.ListReserved(ctx, agent.ID())
+	if err != nil {
+		uc.logger.Warnw("failed to list agent port reservations", "agent_id", agent.ID(), "error", err)
+	}
+
+	pool := agent.PortPool()
+	resp := &dto.AgentPortUsageResponse{
+		AgentID:    agent.SID(),
+		PortPool:   pool.String(),
+		TotalPorts: pool.TotalPorts(),
+		Ports:      make([]dto.AgentPortDTO, 0, len(usages)),
+	}
+	if !agent.AllowedPortRange().IsEmpty() {
+		resp.AllowedPortRange = agent.AllowedPortRange().String()
+	}
+
+	used := make(map[uint16]bool, len(usages))
+	for _, usage := range usages {
+		inPool := pool.Contains(usage.Port)
+		if inPool && !used[usage.Port] {
+			resp.UsedPorts++
+		}
+		used[usage.Port] = true
+		resp.Ports = append(resp.Ports, dto.AgentPortDTO{
+			Port:     usage.Port,
+			RuleID:   usage.RuleSID,
+			RuleName: usage.RuleName,
+			ChainHop: usage.ChainHop,
+			InPool:   inPool,
+		})
+	}
+	slices.SortStableFunc(resp.Ports, func(a, b dto.AgentPortDTO) int {
+		return int(a.Port) - int(b.Port)
+	})
+
+	slices.Sort(reserved)
+	resp.Reserved = reserved
+	for _, port := range reserved {
+		// Reservations are released right after the rule is persisted, so a port can briefly be both
+		if pool.Contains(port) && !used[port] {
+			resp.ReservedPorts++
+		}
+	}
+
+	resp.AvailablePorts = max(resp.TotalPorts-resp.UsedPorts-resp.ReservedPorts, 0)
+	if resp.TotalPorts > 0 {
+		utilization := float64(resp.UsedPorts+resp.ReservedPorts) / float64(resp.TotalPorts) * 100
+		resp.Utilization = math.Round(utilization*100) / 100
+	}
+
+	return resp, nil
+}
@@ -172,6 +172,8 @@ func (uc *GetRuleOverallStatusUseCase) buildAgentStatuses(
 					agentStatus.RunStatus = ruleStatus.RunStatus
 					agentStatus.ListenPort = ruleStatus.ListenPort
 					agentStatus.Connections = ruleStatus.Connections
+					agentStatus.ConnectionLimitRejections = ruleStatus.ConnectionLimitRejections
+					agentStatus.RateLimitThrottles = ruleStatus.RateLimitThrottles
 					agentStatus.ErrorMessage = ruleStatus.ErrorMessage
 					agentStatus.SyncedAt = ruleStatus.SyncedAt
 					break
@@ -0,0 +1,121 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/forward/dto"
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// GetTunnelHealthQuery represents the query for the tunnel health of a rule.
+type GetTunnelHealthQuery struct {
+	RuleSID string
+}
+
+// GetTunnelHealthUseCase returns the current health of the tunnels from a rule's entry
+// agent to its exit agents, including which exits are demoted.
+type GetTunnelHealthUseCase struct {
+	ruleRepo   forward.RuleReader
+	agentRepo  forward.AgentRepository
+	stateStore TunnelHealthStateStore
+	logger     logger.Interface
+}
+
+// NewGetTunnelHealthUseCase creates a new GetTunnelHealthUseCase.
+func NewGetTunnelHealthUseCase(
+	ruleRepo forward.RuleReader,
+	agentRepo forward.AgentRepository,
+	stateStore TunnelHealthStateStore,
+	logger logger.Interface,
+) *GetTunnelHealthUseCase {
+	return &GetTunnelHealthUseCase{
+		ruleRepo:   ruleRepo,
+		agentRepo:  agentRepo,
+		stateStore: stateStore,
+		logger:     logger,
+	}
+}
+
+// Execute retrieves the tunnel health of a rule.
+func (uc *GetTunnelHealthUseCase) Execute(ctx context.Context, query GetTunnelHealthQuery) (*dto.RuleTunnelHealthResponse, error) {
+	rule, err := getTunnelHealthRule(ctx, uc.ruleRepo, uc.logger, query.RuleSID)
+	if err != nil {
+		return nil, err
+	}
+
+	exitAgentIDs := rule.GetAllExitAgentIDs()
+	states, err := uc.stateStore.GetStates(ctx, rule.ID(), exitAgentIDs)
+	if err != nil {
+		uc.logger.Errorw("failed to get tunnel health states", "rule_id", rule.ID(), "error", err)
+		return nil, errors.NewInternalError("failed to get tunnel health")
+	}
+	agents, err := uc.agentRepo.GetByIDs(ctx, exitAgentIDs)
+	if err != nil {
+		uc.logger.Errorw("failed to get exit agents", "rule_id", rule.ID(), "error", err)
+		return nil, fmt.Errorf("failed to get exit agents: %w", err)
+	}
+
+	demoted := make(map[uint]bool)
+	for exitAgentID, state := range states {
+		if state.Demoted {
+			demoted[exitAgentID] = true
+		}
+	}
+	weights := make(map[uint][2]uint16)
+	for i, aw := range rule.EffectiveExitAgents(demoted) {
+		weights[aw.AgentID()] = [2]uint16{rule.ExitAgents()[i].Weight(), aw.Weight()}
+	}
+
+	result := &dto.RuleTunnelHealthResponse{
+		RuleID: rule.SID(),
+		Exits:  make([]dto.TunnelExitHealth, 0, len(exitAgentIDs)),
+	}
+	if rule.HasMultipleExitAgents() {
+		result.LoadBalanceStrategy = rule.LoadBalanceStrategy().String()
+	}
+
+	for _, exitAgentID := range exitAgentIDs {
+		exit := dto.TunnelExitHealth{Healthy: true}
+		if agent, ok := agents[exitAgentID]; ok && agent != nil {
+			exit.ExitAgentID = agent.SID()
+			exit.ExitAgentName = agent.Name()
+		}
+		if w, ok := weights[exitAgentID]; ok {
+			configured, effective := w[0], w[1]
+			exit.Weight = &configured
+			exit.EffectiveWeight = &effective
+		}
+		if state, ok := states[exitAgentID]; ok {
+			exit.Reported = true
+			exit.Healthy = state.Healthy
+			exit.Demoted = state.Demoted
+			exit.Since = state.Since.Unix()
+		}
+		result.Exits = append(result.Exits, exit)
+	}
+
+	return result, nil
+}
+
+// getTunnelHealthRule retrieves the rule whose tunnel health is queried.
+func getTunnelHealthRule(ctx context.Context, ruleRepo forward.RuleReader, log logger.Interface, sid string) (*forward.ForwardRule, error) {
+	if sid == "" {
+		return nil, errors.NewValidationError("rule ID is required")
+	}
+
+	rule, err := ruleRepo.GetBySID(ctx, sid)
+	if err != nil {
+		log.Errorw("failed to get forward rule", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to get forward rule: %w", err)
+	}
+	if rule == nil {
+		return nil, errors.NewNotFoundError("forward rule", sid)
+	}
+	if !rule.RuleType().IsEntry() {
+		return nil, errors.NewValidationError("tunnel health is only available for entry rules")
+	}
+	return rule, nil
+}
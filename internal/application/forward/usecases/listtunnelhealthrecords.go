@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/orris-inc/orris/internal/application/forward/dto"
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// ListTunnelHealthRecordsQuery represents the query for the tunnel health timeline of a rule.
+type ListTunnelHealthRecordsQuery struct {
+	RuleSID      string
+	ExitAgentSID string // Optional: only the tunnel to this exit agent
+	From         *time.Time
+	To           *time.Time
+	Page         int
+	PageSize     int
+}
+
+// ListTunnelHealthRecordsResult represents a page of the tunnel health timeline.
+type ListTunnelHealthRecordsResult struct {
+	Records []*dto.TunnelHealthRecordDTO
+	Total   int64
+}
+
+// ListTunnelHealthRecordsUseCase lists the tunnel health timeline of a rule, newest first.
+type ListTunnelHealthRecordsUseCase struct {
+	ruleRepo   forward.RuleReader
+	agentRepo  forward.AgentRepository
+	healthRepo forward.TunnelHealthRepository
+	logger     logger.Interface
+}
+
+// NewListTunnelHealthRecordsUseCase creates a new ListTunnelHealthRecordsUseCase.
+func NewListTunnelHealthRecordsUseCase(
+	ruleRepo forward.RuleReader,
+	agentRepo forward.AgentRepository,
+	healthRepo forward.TunnelHealthRepository,
+	logger logger.Interface,
+) *ListTunnelHealthRecordsUseCase {
+	return &ListTunnelHealthRecordsUseCase{
+		ruleRepo:   ruleRepo,
+		agentRepo:  agentRepo,
+		healthRepo: healthRepo,
+		logger:     logger,
+	}
+}
+
+// Execute lists the tunnel health timeline of a rule.
+func (uc *ListTunnelHealthRecordsUseCase) Execute(ctx context.Context, query ListTunnelHealthRecordsQuery) (*ListTunnelHealthRecordsResult, error) {
+	if query.From != nil && query.To != nil && query.From.After(*query.To) {
+		return nil, errors.NewValidationError("from must be before to")
+	}
+
+	rule, err := getTunnelHealthRule(ctx, uc.ruleRepo, uc.logger, query.RuleSID)
+	if err != nil {
+		return nil, err
+	}
+
+	filter := forward.TunnelHealthFilter{
+		RuleID:   rule.ID(),
+		From:     query.From,
+		To:       query.To,
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	if query.ExitAgentSID != "" {
+		exitAgent, err := uc.agentRepo.GetBySID(ctx, query.ExitAgentSID)
+		if err != nil {
+			uc.logger.Errorw("failed to get exit agent", "sid", query.ExitAgentSID, "error", err)
+			return nil, fmt.Errorf("failed to get exit agent: %w", err)
+		}
+		if exitAgent == nil || !slices.Contains(rule.GetAllExitAgentIDs(), exitAgent.ID()) {
+			return nil, errors.NewNotFoundError("exit agent", query.ExitAgentSID)
+		}
+		filter.ExitAgentID = exitAgent.ID()
+	}
+
+	records, total, err := uc.healthRepo.List(ctx, filter)
+	if err != nil {
+		uc.logger.Errorw("failed to list tunnel health records", "rule_id", rule.ID(), "error", err)
+		return nil, fmt.Errorf("failed to list tunnel health records: %w", err)
+	}
+
+	agentIDSet := make(map[uint]struct{})
+	for _, r := range records {
+		agentIDSet[r.EntryAgentID()] = struct{}{}
+		agentIDSet[r.ExitAgentID()] = struct{}{}
+	}
+	agentIDs := make([]uint, 0, len(agentIDSet))
+	for agentID := range agentIDSet {
+		agentIDs = append(agentIDs, agentID)
+	}
+
+	agentSIDs := make(map[uint]string)
+	if len(agentIDs) > 0 {
+		agentSIDs, err = uc.agentRepo.GetSIDsByIDs(ctx, agentIDs)
+		if err != nil {
+			uc.logger.Errorw("failed to get agent SIDs", "error", err)
+			return nil, fmt.Errorf("failed to get agent SIDs: %w", err)
+		}
+	}
+
+	result := &ListTunnelHealthRecordsResult{
+		Records: make([]*dto.TunnelHealthRecordDTO, 0, len(records)),
+		Total:   total,
+	}
+	for _, r := range records {
+		result.Records = append(result.Records, &dto.TunnelHealthRecordDTO{
+			EntryAgentID: agentSIDs[r.EntryAgentID()],
+			ExitAgentID:  agentSIDs[r.ExitAgentID()],
+			Healthy:      r.Healthy(),
+			FailCount:    r.FailCount(),
+			LatencyMs:    r.LatencyMs(),
+			Error:        r.ErrorMessage(),
+			CheckedAt:    r.CheckedAt().Unix(),
+		})
+	}
+
+	return result, nil
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+)
+
+// PortAllocator assigns listen ports to rules on forward agents.
+type PortAllocator interface {
+	// Allocate reserves a free port from the agent's port pool.
+	Allocate(ctx context.Context, agent *forward.ForwardAgent) (uint16, error)
+	// Reserve reserves a specific port on an agent. Returns false if the port is taken.
+	Reserve(ctx context.Context, agentID uint, port uint16) (bool, error)
+	// Release releases a reserved port.
+	Release(ctx context.Context, agentID uint, port uint16)
+}
+
+type reservedPort struct {
+	agentID uint
+	port    uint16
+}
+
+// portReservations tracks the ports reserved while creating a rule, so they can all be
+// released once the rule is persisted or its creation fails.
+type portReservations struct {
+	allocator PortAllocator
+	ports     []reservedPort
+}
+
+func newPortReservations(allocator PortAllocator) *portReservations {
+	return &portReservations{allocator: allocator}
+}
+
+// allocate reserves a free port from the agent's port pool.
+func (r *portReservations) allocate(ctx context.Context, agent *forward.ForwardAgent) (uint16, error) {
+	port, err := r.allocator.Allocate(ctx, agent)
+	if err != nil {
+		return 0, err
+	}
+	r.ports = append(r.ports, reservedPort{agentID: agent.ID(), port: port})
+	return port, nil
+}
+
+// reserve reserves a specific port on an agent. Returns false if the port is taken.
+func (r *portReservations) reserve(ctx context.Context, agentID uint, port uint16) (bool, error) {
+	ok, err := r.allocator.Reserve(ctx, agentID, port)
+	if err != nil || !ok {
+		return false, err
+	}
+	r.ports = append(r.ports, reservedPort{agentID: agentID, port: port})
+	return true, nil
+}
+
+// releaseAll releases all reserved ports.
+func (r *portReservations) releaseAll(ctx context.Context) {
+	// Released even if the request was canceled
+	ctx = context.WithoutCancel(ctx)
+	for _, p := range r.ports {
+		r.allocator.Release(ctx, p.agentID, p.port)
+	}
+	r.ports = nil
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// tunnelHealthRetention is how long tunnel health timeline records are kept.
+const tunnelHealthRetention = 7 * 24 * time.Hour
+
+// PruneTunnelHealthUseCase deletes tunnel health timeline records past the retention period.
+type PruneTunnelHealthUseCase struct {
+	healthRepo forward.TunnelHealthRepository
+	logger     logger.Interface
+}
+
+// NewPruneTunnelHealthUseCase creates a new PruneTunnelHealthUseCase.
+func NewPruneTunnelHealthUseCase(
+	healthRepo forward.TunnelHealthRepository,
+	logger logger.Interface,
+) *PruneTunnelHealthUseCase {
+	return &PruneTunnelHealthUseCase{
+		healthRepo: healthRepo,
+		logger:     logger,
+	}
+}
+
+// Execute deletes expired records and returns the number deleted.
+func (uc *PruneTunnelHealthUseCase) Execute(ctx context.Context) (int, error) {
+	deleted, err := uc.healthRepo.DeleteBefore(ctx, biztime.NowUTC().Add(-tunnelHealthRetention))
+	if err != nil {
+		return 0, fmt.Errorf("failed to prune tunnel health records: %w", err)
+	}
+	return int(deleted), nil
+}
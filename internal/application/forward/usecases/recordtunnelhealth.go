@@ -0,0 +1,252 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// tunnelHealthSampleInterval is how often a tunnel whose state does not change is
+// recorded in the timeline. State changes are always recorded.
+const tunnelHealthSampleInterval = time.Minute
+
+// TunnelHealthStateStore stores the latest health of entry-to-exit tunnels.
+type TunnelHealthStateStore interface {
+	// Get returns the state of a tunnel, or nil if it has not been reported.
+	Get(ctx context.Context, ruleID, exitAgentID uint) (*forward.TunnelHealthState, error)
+	// Set stores the state of a tunnel.
+	Set(ctx context.Context, ruleID, exitAgentID uint, state forward.TunnelHealthState) error
+	// GetStates returns the known states of the tunnels of a rule to the given exit agents.
+	GetStates(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]*forward.TunnelHealthState, error)
+	// GetDemoted returns which of the given exit agents are demoted for a rule.
+	GetDemoted(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]bool, error)
+}
+
+// TunnelHealthAlerter sends admin alerts when a tunnel becomes unhealthy or recovers.
+type TunnelHealthAlerter interface {
+	AlertTunnelUnhealthy(ctx context.Context, alert TunnelHealthAlert) error
+	AlertTunnelRecovered(ctx context.Context, alert TunnelHealthAlert) error
+}
+
+// TunnelHealthAlert contains the data of a tunnel health alert.
+type TunnelHealthAlert struct {
+	RuleSID          string
+	RuleName         string
+	EntryAgentSID    string
+	EntryAgentName   string
+	ExitAgentSID     string
+	ExitAgentName    string
+	Demoted          bool // Unhealthy: the exit was demoted. Recovered: the demotion was lifted.
+	FailCount        int
+	Error            string
+	Since            time.Time // When the tunnel became unhealthy
+	At               time.Time // When the state changed
+	MuteNotification bool      // Muted exit agents are not alerted on
+}
+
+// RecordTunnelHealthCommand is a tunnel health report of an entry agent.
+type RecordTunnelHealthCommand struct {
+	EntryAgentID uint // Agent that sent the report
+	RuleSID      string
+	ExitAgentSID string
+	Healthy      bool
+	FailCount    int
+	LatencyMs    *int64
+	Error        string
+	CheckedAt    time.Time
+}
+
+// RecordTunnelHealthUseCase records tunnel health reports in the timeline and fails over
+// multi-exit rules. When the tunnel to an exit turns unhealthy, the exit is demoted to a
+// backup (weight 0) and the entry agent receives the updated rule, so it stops getting
+// weighted traffic. The entry agent keeps checking backups, and the exit is restored when
+// its tunnel reports healthy again.
+type RecordTunnelHealthUseCase struct {
+	ruleRepo     forward.Repository
+	agentRepo    forward.AgentRepository
+	healthRepo   forward.TunnelHealthRepository
+	stateStore   TunnelHealthStateStore
+	syncNotifier ConfigSyncNotifier
+	alerter      TunnelHealthAlerter
+	logger       logger.Interface
+}
+
+// NewRecordTunnelHealthUseCase creates a new RecordTunnelHealthUseCase.
+func NewRecordTunnelHealthUseCase(
+	ruleRepo forward.Repository,
+	agentRepo forward.AgentRepository,
+	healthRepo forward.TunnelHealthRepository,
+	stateStore TunnelHealthStateStore,
+	syncNotifier ConfigSyncNotifier,
+	alerter TunnelHealthAlerter,
+	logger logger.Interface,
+) *RecordTunnelHealthUseCase {
+	return &RecordTunnelHealthUseCase{
+		ruleRepo:     ruleRepo,
+		agentRepo:    agentRepo,
+		healthRepo:   healthRepo,
+		stateStore:   stateStore,
+		syncNotifier: syncNotifier,
+		alerter:      alerter,
+		logger:       logger,
+	}
+}
+
+// Execute records a tunnel health report.
+func (uc *RecordTunnelHealthUseCase) Execute(ctx context.Context, cmd RecordTunnelHealthCommand) error {
+	rule, err := uc.ruleRepo.GetBySID(ctx, cmd.RuleSID)
+	if err != nil {
+		return fmt.Errorf("failed to get forward rule: %w", err)
+	}
+	if rule == nil {
+		return errors.NewNotFoundError("forward rule", cmd.RuleSID)
+	}
+	if rule.AgentID() != cmd.EntryAgentID {
+		return errors.NewValidationError("agent is not the entry agent of the rule")
+	}
+
+	exitAgent, err := uc.agentRepo.GetBySID(ctx, cmd.ExitAgentSID)
+	if err != nil {
+		return fmt.Errorf("failed to get exit agent: %w", err)
+	}
+	if exitAgent == nil {
+		return errors.NewNotFoundError("forward agent", cmd.ExitAgentSID)
+	}
+	if !slices.Contains(rule.GetAllExitAgentIDs(), exitAgent.ID()) {
+		return errors.NewValidationError("agent is not an exit agent of the rule")
+	}
+
+	now := biztime.NowUTC()
+	prev, err := uc.stateStore.Get(ctx, rule.ID(), exitAgent.ID())
+	if err != nil {
+		return err
+	}
+
+	// Tunnels that have not been reported yet are assumed healthy
+	state := forward.TunnelHealthState{Healthy: true, Since: now}
+	if prev != nil {
+		state = *prev
+	}
+	changed := state.Healthy != cmd.Healthy
+	unhealthySince := state.Since
+	if changed {
+		state.Healthy = cmd.Healthy
+		state.Since = now
+	}
+
+	if changed || now.Sub(state.RecordedAt) >= tunnelHealthSampleInterval {
+		record, err := forward.NewTunnelHealthRecord(
+			rule.ID(), cmd.EntryAgentID, exitAgent.ID(),
+			cmd.Healthy, cmd.FailCount, cmd.LatencyMs, cmd.Error, cmd.CheckedAt,
+		)
+		if err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+		// Failover does not depend on the timeline, so a failed write does not stop it
+		if err := uc.healthRepo.Create(ctx, record); err != nil {
+			uc.logger.Errorw("failed to record tunnel health", "rule_id", rule.ID(), "exit_agent_id", exitAgent.ID(), "error", err)
+		} else {
+			state.RecordedAt = now
+		}
+	}
+
+	syncNeeded, restored := false, false
+	if !cmd.Healthy && !state.Demoted && rule.HasMultipleExitAgents() {
+		// Checked on every unhealthy report: an exit that could not be demoted because
+		// no other exit was available is demoted once another exit recovers
+		demoted, err := uc.stateStore.GetDemoted(ctx, rule.ID(), rule.GetAllExitAgentIDs())
+		if err != nil {
+			uc.logger.Warnw("failed to get demoted exit agents", "rule_id", rule.ID(), "error", err)
+		} else if rule.CanDemoteExitAgent(exitAgent.ID(), demoted) {
+			state.Demoted = true
+			syncNeeded = true
+		}
+	}
+	if cmd.Healthy && state.Demoted {
+		state.Demoted = false
+		syncNeeded = true
+		restored = true
+	}
+
+	if err := uc.stateStore.Set(ctx, rule.ID(), exitAgent.ID(), state); err != nil {
+		return err
+	}
+
+	if syncNeeded {
+		uc.logger.Infow("exit agent failover",
+			"rule_sid", rule.SID(),
+			"exit_agent_sid", exitAgent.SID(),
+			"demoted", state.Demoted,
+		)
+		if err := uc.syncNotifier.NotifyRuleChange(ctx, rule.AgentID(), rule.SID(), "updated"); err != nil {
+			uc.logger.Warnw("failed to notify entry agent of exit failover",
+				"rule_sid", rule.SID(),
+				"agent_id", rule.AgentID(),
+				"error", err,
+			)
+		}
+	}
+
+	if changed {
+		alert := TunnelHealthAlert{
+			RuleSID:          rule.SID(),
+			RuleName:         rule.Name(),
+			ExitAgentSID:     exitAgent.SID(),
+			ExitAgentName:    exitAgent.Name(),
+			FailCount:        cmd.FailCount,
+			Error:            cmd.Error,
+			Since:            now,
+			At:               now,
+			MuteNotification: exitAgent.MuteNotification(),
+		}
+		if cmd.Healthy {
+			alert.Demoted = restored
+			alert.Since = unhealthySince
+		} else {
+			alert.Demoted = state.Demoted
+		}
+		uc.sendAlert(rule.AgentID(), alert, cmd.Healthy)
+	}
+
+	return nil
+}
+
+// sendAlert sends a tunnel health alert in the background. Admin alerts are rate limited
+// per recipient and must not hold up the agent's message loop.
+func (uc *RecordTunnelHealthUseCase) sendAlert(entryAgentID uint, alert TunnelHealthAlert, recovered bool) {
+	if uc.alerter == nil {
+		return
+	}
+
+	goroutine.SafeGo(uc.logger, "tunnel-health-alert", func() {
+		ctx := context.Background()
+
+		entryAgent, err := uc.agentRepo.GetByID(ctx, entryAgentID)
+		if err != nil {
+			uc.logger.Warnw("failed to get entry agent for tunnel health alert", "agent_id", entryAgentID, "error", err)
+		} else if entryAgent != nil {
+			alert.EntryAgentSID = entryAgent.SID()
+			alert.EntryAgentName = entryAgent.Name()
+		}
+
+		if recovered {
+			err = uc.alerter.AlertTunnelRecovered(ctx, alert)
+		} else {
+			err = uc.alerter.AlertTunnelUnhealthy(ctx, alert)
+		}
+		if err != nil {
+			uc.logger.Errorw("failed to send tunnel health alert",
+				"rule_sid", alert.RuleSID,
+				"exit_agent_sid", alert.ExitAgentSID,
+				"error", err,
+			)
+		}
+	})
+}
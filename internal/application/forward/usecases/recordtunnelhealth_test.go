@@ -0,0 +1,258 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// fakeTunnelRuleRepo only implements GetBySID; other methods panic.
+type fakeTunnelRuleRepo struct {
+	forward.Repository
+	rule *forward.ForwardRule
+}
+
+func (r *fakeTunnelRuleRepo) GetBySID(ctx context.Context, sid string) (*forward.ForwardRule, error) {
+	if r.rule.SID() != sid {
+		return nil, nil
+	}
+	return r.rule, nil
+}
+
+// fakeTunnelAgentRepo only implements GetBySID and GetByID; other methods panic.
+type fakeTunnelAgentRepo struct {
+	forward.AgentRepository
+	agents map[uint]*forward.ForwardAgent
+}
+
+func (r *fakeTunnelAgentRepo) GetBySID(ctx context.Context, sid string) (*forward.ForwardAgent, error) {
+	for _, agent := range r.agents {
+		if agent.SID() == sid {
+			return agent, nil
+		}
+	}
+	return nil, nil
+}
+
+func (r *fakeTunnelAgentRepo) GetByID(ctx context.Context, id uint) (*forward.ForwardAgent, error) {
+	agent, ok := r.agents[id]
+	if !ok {
+		return nil, errors.NewNotFoundError("forward agent")
+	}
+	return agent, nil
+}
+
+// fakeTunnelHealthRepo counts the stored timeline records.
+type fakeTunnelHealthRepo struct {
+	forward.TunnelHealthRepository
+	records int
+}
+
+func (r *fakeTunnelHealthRepo) Create(ctx context.Context, record *forward.TunnelHealthRecord) error {
+	r.records++
+	return nil
+}
+
+// memoryTunnelHealthStore keeps tunnel states of a single rule in memory.
+type memoryTunnelHealthStore struct {
+	states map[uint]forward.TunnelHealthState
+}
+
+func (s *memoryTunnelHealthStore) Get(ctx context.Context, ruleID, exitAgentID uint) (*forward.TunnelHealthState, error) {
+	state, ok := s.states[exitAgentID]
+	if !ok {
+		return nil, nil
+	}
+	return &state, nil
+}
+
+func (s *memoryTunnelHealthStore) Set(ctx context.Context, ruleID, exitAgentID uint, state forward.TunnelHealthState) error {
+	s.states[exitAgentID] = state
+	return nil
+}
+
+func (s *memoryTunnelHealthStore) GetStates(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]*forward.TunnelHealthState, error) {
+	result := make(map[uint]*forward.TunnelHealthState)
+	for _, id := range exitAgentIDs {
+		if state, ok := s.states[id]; ok {
+			result[id] = &state
+		}
+	}
+	return result, nil
+}
+
+func (s *memoryTunnelHealthStore) GetDemoted(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	for _, id := range exitAgentIDs {
+		if s.states[id].Demoted {
+			result[id] = true
+		}
+	}
+	return result, nil
+}
+
+type recordingSyncNotifier struct {
+	notified []uint
+}
+
+func (n *recordingSyncNotifier) NotifyRuleChange(ctx context.Context, agentID uint, ruleSID string, changeType string) error {
+	n.notified = append(n.notified, agentID)
+	return nil
+}
+
+type tunnelHealthAlertEvent struct {
+	recovered bool
+	alert     TunnelHealthAlert
+}
+
+type recordingTunnelHealthAlerter struct {
+	alerts chan tunnelHealthAlertEvent
+}
+
+func (a *recordingTunnelHealthAlerter) AlertTunnelUnhealthy(ctx context.Context, alert TunnelHealthAlert) error {
+	a.alerts <- tunnelHealthAlertEvent{alert: alert}
+	return nil
+}
+
+func (a *recordingTunnelHealthAlerter) AlertTunnelRecovered(ctx context.Context, alert TunnelHealthAlert) error {
+	a.alerts <- tunnelHealthAlertEvent{recovered: true, alert: alert}
+	return nil
+}
+
+func (a *recordingTunnelHealthAlerter) next(t *testing.T) tunnelHealthAlertEvent {
+	t.Helper()
+
+	select {
+	case event := <-a.alerts:
+		return event
+	case <-time.After(time.Second):
+		t.Fatal("tunnel health alert not sent")
+		return tunnelHealthAlertEvent{}
+	}
+}
+
+func newTunnelTestAgent(t *testing.T, id uint) *forward.ForwardAgent {
+	t.Helper()
+
+	now := time.Now()
+	agent, err := forward.ReconstructForwardAgent(
+		id, fmt.Sprintf("fa_%d", id), fmt.Sprintf("agent-%d", id), "hash", "token",
+		forward.AgentStatusEnabled, "", "", "", nil, "", "", "", nil, nil, 0, false,
+		nil, nil, nil, now, now,
+	)
+	require.NoError(t, err)
+	return agent
+}
+
+// newTunnelTestRule returns an entry rule of agent 1 balancing over exits 2 and 3.
+func newTunnelTestRule(t *testing.T) *forward.ForwardRule {
+	t.Helper()
+
+	var exits []vo.AgentWeight
+	for _, id := range []uint{2, 3} {
+		aw, err := vo.NewAgentWeight(id, 50)
+		require.NoError(t, err)
+		exits = append(exits, aw)
+	}
+	rule, err := forward.NewForwardRule(
+		1, nil, nil, vo.ForwardRuleTypeEntry, 0, exits, vo.DefaultLoadBalanceStrategy,
+		nil, nil, nil, "", "multi-exit", 8080, "192.168.1.100", 9000, nil, "",
+		vo.IPVersionAuto, vo.ForwardProtocolTCP, "", nil, 0, vo.AddressPreferenceAuto,
+		func() (string, error) { return "rule1", nil },
+	)
+	require.NoError(t, err)
+	require.NoError(t, rule.SetID(10))
+	return rule
+}
+
+func TestRecordTunnelHealthUseCase_Failover(t *testing.T) {
+	rule := newTunnelTestRule(t)
+	agents := map[uint]*forward.ForwardAgent{}
+	for _, id := range []uint{1, 2, 3} {
+		agents[id] = newTunnelTestAgent(t, id)
+	}
+	store := &memoryTunnelHealthStore{states: make(map[uint]forward.TunnelHealthState)}
+	notifier := &recordingSyncNotifier{}
+	alerter := &recordingTunnelHealthAlerter{alerts: make(chan tunnelHealthAlertEvent, 4)}
+	healthRepo := &fakeTunnelHealthRepo{}
+	uc := NewRecordTunnelHealthUseCase(&fakeTunnelRuleRepo{rule: rule}, &fakeTunnelAgentRepo{agents: agents},
+		healthRepo, store, notifier, alerter, logger.NewLogger())
+	ctx := context.Background()
+
+	report := func(exitID uint, healthy bool) {
+		t.Helper()
+		require.NoError(t, uc.Execute(ctx, RecordTunnelHealthCommand{
+			EntryAgentID: 1,
+			RuleSID:      rule.SID(),
+			ExitAgentSID: agents[exitID].SID(),
+			Healthy:      healthy,
+			CheckedAt:    time.Now(),
+		}))
+	}
+
+	// The unhealthy exit is demoted and the entry agent gets the updated rule
+	report(2, false)
+	assert.True(t, store.states[2].Demoted)
+	assert.Equal(t, []uint{1}, notifier.notified)
+	event := alerter.next(t)
+	assert.False(t, event.recovered)
+	assert.True(t, event.alert.Demoted)
+	assert.Equal(t, "fa_2", event.alert.ExitAgentSID)
+	assert.Equal(t, "fa_1", event.alert.EntryAgentSID)
+
+	// The last weighted exit is not demoted, but still alerted on
+	report(3, false)
+	assert.False(t, store.states[3].Demoted)
+	assert.Equal(t, []uint{1}, notifier.notified)
+	event = alerter.next(t)
+	assert.False(t, event.recovered)
+	assert.False(t, event.alert.Demoted)
+
+	// Repeated unhealthy reports do not alert again
+	report(2, false)
+	assert.Equal(t, []uint{1}, notifier.notified)
+
+	// The demoted exit is restored once its tunnel is healthy again
+	report(2, true)
+	assert.False(t, store.states[2].Demoted)
+	assert.Equal(t, []uint{1, 1}, notifier.notified)
+	event = alerter.next(t)
+	assert.True(t, event.recovered)
+	assert.True(t, event.alert.Demoted)
+
+	// Exit 3 can be demoted now that exit 2 takes traffic again
+	report(3, false)
+	assert.True(t, store.states[3].Demoted)
+	assert.Equal(t, []uint{1, 1, 1}, notifier.notified)
+
+	assert.Equal(t, 3, healthRepo.records, "only state changes are recorded within the sample interval")
+	select {
+	case event := <-alerter.alerts:
+		t.Fatalf("unexpected alert %+v", event)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestRecordTunnelHealthUseCase_RejectsUnknownAgents(t *testing.T) {
+	rule := newTunnelTestRule(t)
+	agents := map[uint]*forward.ForwardAgent{1: newTunnelTestAgent(t, 1), 4: newTunnelTestAgent(t, 4)}
+	uc := NewRecordTunnelHealthUseCase(&fakeTunnelRuleRepo{rule: rule}, &fakeTunnelAgentRepo{agents: agents},
+		&fakeTunnelHealthRepo{}, &memoryTunnelHealthStore{states: make(map[uint]forward.TunnelHealthState)},
+		&recordingSyncNotifier{}, nil, logger.NewLogger())
+	ctx := context.Background()
+
+	err := uc.Execute(ctx, RecordTunnelHealthCommand{EntryAgentID: 4, RuleSID: rule.SID(), ExitAgentSID: "fa_1"})
+	assert.True(t, errors.IsValidationError(err), "only the entry agent reports tunnel health")
+
+	err = uc.Execute(ctx, RecordTunnelHealthCommand{EntryAgentID: 1, RuleSID: rule.SID(), ExitAgentSID: "fa_4"})
+	assert.True(t, errors.IsValidationError(err), "agent 4 is not an exit of the rule")
+}
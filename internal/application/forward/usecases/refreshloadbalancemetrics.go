@@ -0,0 +1,195 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// LoadBalanceMetricsStore stores the exit metrics of rules using metric-based load balancing.
+type LoadBalanceMetricsStore interface {
+	// Get returns the metrics of a rule, or nil if none have been collected.
+	Get(ctx context.Context, ruleID uint) (*forward.LoadBalanceMetrics, error)
+	// Set stores the metrics of a rule.
+	Set(ctx context.Context, ruleID uint, metrics forward.LoadBalanceMetrics) error
+}
+
+// ExitLatencyProber probes the latency through each exit agent of an entry rule.
+type ExitLatencyProber interface {
+	ProbeExitLatencies(ctx context.Context, rule *forward.ForwardRule) (map[uint]int64, error)
+}
+
+// RefreshLoadBalanceMetricsUseCase periodically collects the exit metrics of multi-exit
+// rules using the least_connections or lowest_latency strategy. Latencies come from probes
+// through each exit; connections are the totals exit agents report in their rule sync status.
+// The entry agent is re-synced when the preferred exit changes, and before the metrics it
+// holds go stale.
+type RefreshLoadBalanceMetricsUseCase struct {
+	ruleRepo      forward.Repository
+	statusQuerier RuleSyncStatusBatchQuerier
+	prober        ExitLatencyProber
+	store         LoadBalanceMetricsStore
+	syncNotifier  ConfigSyncNotifier
+	logger        logger.Interface
+}
+
+// NewRefreshLoadBalanceMetricsUseCase creates a new RefreshLoadBalanceMetricsUseCase.
+func NewRefreshLoadBalanceMetricsUseCase(
+	ruleRepo forward.Repository,
+	statusQuerier RuleSyncStatusBatchQuerier,
+	prober ExitLatencyProber,
+	store LoadBalanceMetricsStore,
+	syncNotifier ConfigSyncNotifier,
+	logger logger.Interface,
+) *RefreshLoadBalanceMetricsUseCase {
+	return &RefreshLoadBalanceMetricsUseCase{
+		ruleRepo:      ruleRepo,
+		statusQuerier: statusQuerier,
+		prober:        prober,
+		store:         store,
+		syncNotifier:  syncNotifier,
+		logger:        logger,
+	}
+}
+
+// Execute refreshes the metrics of all eligible rules and returns the number of rules re-synced.
+func (uc *RefreshLoadBalanceMetricsUseCase) Execute(ctx context.Context) (int, error) {
+	enabled, err := uc.ruleRepo.ListEnabled(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list enabled forward rules: %w", err)
+	}
+
+	var rules []*forward.ForwardRule
+	exitAgentIDSet := make(map[uint]struct{})
+	for _, rule := range enabled {
+		strategy := rule.LoadBalanceStrategy()
+		if !rule.RuleType().IsEntry() || !rule.HasMultipleExitAgents() || !strategy.UsesExitMetrics() {
+			continue
+		}
+		rules = append(rules, rule)
+		if strategy.IsLeastConnections() {
+			for _, exitAgentID := range rule.GetAllExitAgentIDs() {
+				exitAgentIDSet[exitAgentID] = struct{}{}
+			}
+		}
+	}
+	if len(rules) == 0 {
+		return 0, nil
+	}
+
+	connections := uc.exitConnections(ctx, exitAgentIDSet)
+
+	now := biztime.NowUTC()
+	synced := 0
+	for _, rule := range rules {
+		if ctx.Err() != nil {
+			return synced, ctx.Err()
+		}
+
+		metrics := forward.LoadBalanceMetrics{
+			Exits:     make(map[uint]forward.ExitMetrics, len(rule.ExitAgents())),
+			UpdatedAt: now,
+		}
+		if rule.LoadBalanceStrategy().IsLowestLatency() {
+			latencies, err := uc.prober.ProbeExitLatencies(ctx, rule)
+			if err != nil {
+				// Previous metrics stay in use until they expire
+				uc.logger.Debugw("failed to probe exit latencies", "rule_id", rule.ID(), "error", err)
+				continue
+			}
+			for _, aw := range rule.ExitAgents() {
+				var exit forward.ExitMetrics
+				if latency, ok := latencies[aw.AgentID()]; ok {
+					exit.LatencyMs = &latency
+				}
+				metrics.Exits[aw.AgentID()] = exit
+			}
+		} else {
+			for _, aw := range rule.ExitAgents() {
+				var exit forward.ExitMetrics
+				if count, ok := connections[aw.AgentID()]; ok {
+					exit.Connections = &count
+				}
+				metrics.Exits[aw.AgentID()] = exit
+			}
+		}
+
+		prev, err := uc.store.Get(ctx, rule.ID())
+		if err != nil {
+			uc.logger.Warnw("failed to get load balance metrics", "rule_id", rule.ID(), "error", err)
+		}
+		var current uint
+		if prev != nil {
+			current = prev.PreferredExit
+			metrics.SyncedAt = prev.SyncedAt
+		}
+		metrics.PreferredExit = metrics.SelectPreferredExit(rule.LoadBalanceStrategy(), rule.ExitAgents(), current)
+
+		changed := current != metrics.PreferredExit
+		resync := changed || metrics.NeedsResync(now)
+		if resync {
+			metrics.SyncedAt = now
+		}
+		if err := uc.store.Set(ctx, rule.ID(), metrics); err != nil {
+			uc.logger.Warnw("failed to store load balance metrics", "rule_id", rule.ID(), "error", err)
+			continue
+		}
+		if !resync {
+			continue
+		}
+
+		if changed {
+			uc.logger.Infow("preferred exit agent changed",
+				"rule_sid", rule.SID(),
+				"strategy", rule.LoadBalanceStrategy().String(),
+				"previous_exit_agent_id", current,
+				"exit_agent_id", metrics.PreferredExit,
+			)
+		}
+		if err := uc.syncNotifier.NotifyRuleChange(ctx, rule.AgentID(), rule.SID(), "updated"); err != nil {
+			uc.logger.Warnw("failed to notify entry agent of load balance metrics",
+				"rule_sid", rule.SID(),
+				"agent_id", rule.AgentID(),
+				"error", err,
+			)
+			continue
+		}
+		synced++
+	}
+
+	return synced, nil
+}
+
+// exitConnections returns the total active connections of each exit agent across all its rules.
+// Agents without a recent rule sync status are missing from the result.
+func (uc *RefreshLoadBalanceMetricsUseCase) exitConnections(ctx context.Context, exitAgentIDSet map[uint]struct{}) map[uint]int {
+	result := make(map[uint]int, len(exitAgentIDSet))
+	if len(exitAgentIDSet) == 0 {
+		return result
+	}
+
+	exitAgentIDs := make([]uint, 0, len(exitAgentIDSet))
+	for exitAgentID := range exitAgentIDSet {
+		exitAgentIDs = append(exitAgentIDs, exitAgentID)
+	}
+
+	statuses, err := uc.statusQuerier.GetMultipleRuleStatus(ctx, exitAgentIDs)
+	if err != nil {
+		uc.logger.Warnw("failed to get exit agent rule status", "error", err)
+		return result
+	}
+	for exitAgentID, status := range statuses {
+		if status == nil {
+			continue
+		}
+		total := 0
+		for _, rule := range status.Rules {
+			total += rule.Connections
+		}
+		result[exitAgentID] = total
+	}
+	return result
+}
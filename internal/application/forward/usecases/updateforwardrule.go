@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	nodedto "github.com/orris-inc/orris/internal/application/node/dto"
 	"github.com/orris-inc/orris/internal/domain/forward"
 	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
@@ -24,7 +25,7 @@ type UpdateForwardRuleCommand struct {
 	AgentShortID        *string           // entry agent ID (for all rule types)
 	ExitAgentShortID    *string           // exit agent ID (for entry type, mutually exclusive with ExitAgents)
 	ExitAgents          []ExitAgentInput  // exit agents for load balancing (for entry type, mutually exclusive with ExitAgentShortID), nil means no update
-	LoadBalanceStrategy *string           // load balance strategy: failover, weighted (nil means no update)
+	LoadBalanceStrategy *string           // load balance strategy: failover, weighted, round_robin, least_connections, lowest_latency (nil means no update)
 	ChainAgentShortIDs  []string          // chain agent IDs (for chain type rules only), nil means no update
 	ChainPortConfig     map[string]uint16 // chain port config (for direct_chain type rules only), nil means no update
 	TunnelHops          *int              // number of tunnel hops for hybrid chain (nil means no update)
@@ -43,6 +44,10 @@ type UpdateForwardRuleCommand struct {
 	Route               *nodedto.RouteConfigDTO  // nil means no update, non-nil means set
 	ClearRoute          *bool                    // true means clear route config
 	AddressPreference   *string                  // nil means no update; auto, public, tunnel
+	UploadLimitMbps     *uint32                  // nil means no update, 0 means inherit from plan or unlimited
+	DownloadLimitMbps   *uint32                  // nil means no update, 0 means inherit from plan or unlimited
+	MaxConnections      *uint32                  // nil means no update, 0 means inherit from plan or unlimited
+	ACL                 *dto.RuleACLDTO          // nil means no update, empty lists mean clear
 }
 
 // UpdateForwardRuleUseCase handles forward rule updates.
@@ -534,6 +539,28 @@ func (uc *UpdateForwardRuleUseCase) Execute(ctx context.Context, cmd UpdateForwa
 		}
 	}
 
+	// Update limits if provided
+	if cmd.UploadLimitMbps != nil || cmd.DownloadLimitMbps != nil || cmd.MaxConnections != nil {
+		limits := rule.Limits()
+		if cmd.UploadLimitMbps != nil {
+			limits.UploadMbps = *cmd.UploadLimitMbps
+		}
+		if cmd.DownloadLimitMbps != nil {
+			limits.DownloadMbps = *cmd.DownloadLimitMbps
+		}
+		if cmd.MaxConnections != nil {
+			limits.MaxConnections = *cmd.MaxConnections
+		}
+		if err := rule.UpdateLimits(limits); err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+	}
+
+	// Update ACL if provided
+	if err := setRuleACL(rule, cmd.ACL); err != nil {
+		return err
+	}
+
 	// Update group IDs if provided
 	if cmd.GroupSIDs != nil {
 		var groupIDs []uint
@@ -15,6 +15,7 @@ import (
 	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
 	"github.com/orris-inc/orris/internal/domain/shared/routing"
 	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/shared/biztime"
 )
 
 // NOTE: Agent API responses are handled by utils.AgentAPISuccess/AgentAPIError in shared/utils/agentresponse.go
@@ -22,36 +23,39 @@ import (
 // NodeConfigResponse represents node configuration data for node agents
 // Compatible with sing-box inbound configuration
 type NodeConfigResponse struct {
-	NodeSID           string                `json:"node_id" binding:"required"`                                                             // Node SID (Stripe-style: node_xxx)
-	Protocol          string                `json:"protocol" binding:"required,oneof=shadowsocks trojan vless vmess hysteria2 tuic anytls"` // Protocol type
-	ServerHost        string                `json:"server_host" binding:"required"`                                                         // Server hostname or IP address
-	ServerPort        int                   `json:"server_port" binding:"required,min=1,max=65535"`                                         // Server port number
-	EncryptionMethod  string                `json:"encryption_method,omitempty"`                                                            // Encryption method for Shadowsocks
-	ServerKey         string                `json:"server_key,omitempty"`                                                                   // Server password for SS
-	TransportProtocol string                `json:"transport_protocol,omitempty"`                                                           // Transport protocol (tcp, ws, grpc, h2, http, quic)
-	Host              string                `json:"host,omitempty"`                                                                         // WebSocket/HTTP host header
-	Path              string                `json:"path,omitempty"`                                                                         // WebSocket/HTTP path
-	ServiceName       string                `json:"service_name,omitempty"`                                                                 // gRPC service name
-	SNI               string                `json:"sni,omitempty"`                                                                          // TLS Server Name Indication
-	AllowInsecure     bool                  `json:"allow_insecure"`                                                                         // Allow insecure TLS connection
-	EnableVless       bool                  `json:"enable_vless"`                                                                           // Enable VLESS protocol (deprecated, use Protocol=vless)
-	EnableXTLS        bool                  `json:"enable_xtls"`                                                                            // Enable XTLS (deprecated, use VLESSFlow)
-	SpeedLimit        uint64                `json:"speed_limit"`                                                                            // Speed limit in Mbps, 0 = unlimited
-	DeviceLimit       int                   `json:"device_limit"`                                                                           // Device connection limit, 0 = unlimited
-	RuleListPath      string                `json:"rule_list_path,omitempty"`                                                               // Path to routing rule list file (deprecated, use Route)
-	Route             *RouteConfigDTO       `json:"route,omitempty"`                                                                        // Routing configuration for traffic splitting
-	DNS               *DnsConfigDTO         `json:"dns,omitempty"`                                                                          // DNS configuration for DNS-based unlocking
-	Outbounds         []OutboundDTO         `json:"outbounds,omitempty"`                                                                    // Outbound configs for nodes referenced in route rules
-	ForwardRuleRoutes []ForwardRuleRouteDTO `json:"forward_rule_routes,omitempty"`                                                          // Per-forward-rule routing configurations
+	NodeSID           string                `json:"node_id" binding:"required"`                                                                       // Node SID (Stripe-style: node_xxx)
+	Protocol          string                `json:"protocol" binding:"required,oneof=shadowsocks trojan vless vmess hysteria2 tuic anytls wireguard"` // Protocol type
+	ServerHost        string                `json:"server_host" binding:"required"`                                                                   // Server hostname or IP address
+	ServerPort        int                   `json:"server_port" binding:"required,min=1,max=65535"`                                                   // Server port number
+	EncryptionMethod  string                `json:"encryption_method,omitempty"`                                                                      // Encryption method for Shadowsocks
+	ServerKey         string                `json:"server_key,omitempty"`                                                                             // Server password for SS
+	TransportProtocol string                `json:"transport_protocol,omitempty"`                                                                     // Transport protocol (tcp, ws, grpc, h2, http, quic)
+	Host              string                `json:"host,omitempty"`                                                                                   // WebSocket/HTTP host header
+	Path              string                `json:"path,omitempty"`                                                                                   // WebSocket/HTTP path
+	ServiceName       string                `json:"service_name,omitempty"`                                                                           // gRPC service name
+	XHTTPMode         string                `json:"xhttp_mode,omitempty"`                                                                             // XHTTP mode (auto, packet-up, stream-up, stream-one)
+	XHTTPExtra        string                `json:"xhttp_extra,omitempty"`                                                                            // XHTTP extra options (JSON object)
+	SNI               string                `json:"sni,omitempty"`                                                                                    // TLS Server Name Indication
+	AllowInsecure     bool                  `json:"allow_insecure"`                                                                                   // Allow insecure TLS connection
+	EnableVless       bool                  `json:"enable_vless"`                                                                                     // Enable VLESS protocol (deprecated, use Protocol=vless)
+	EnableXTLS        bool                  `json:"enable_xtls"`                                                                                      // Enable XTLS (deprecated, use VLESSFlow)
+	SpeedLimit        uint64                `json:"speed_limit"`                                                                                      // Speed limit in Mbps, 0 = unlimited
+	DeviceLimit       int                   `json:"device_limit"`                                                                                     // Device connection limit, 0 = unlimited
+	RuleListPath      string                `json:"rule_list_path,omitempty"`                                                                         // Path to routing rule list file (deprecated, use Route)
+	Route             *RouteConfigDTO       `json:"route,omitempty"`                                                                                  // Routing configuration for traffic splitting
+	DNS               *DnsConfigDTO         `json:"dns,omitempty"`                                                                                    // DNS configuration for DNS-based unlocking
+	Outbounds         []OutboundDTO         `json:"outbounds,omitempty"`                                                                              // Outbound configs for nodes referenced in route rules
+	ForwardRuleRoutes []ForwardRuleRouteDTO `json:"forward_rule_routes,omitempty"`                                                                    // Per-forward-rule routing configurations
 
 	// VLESS specific fields
-	VLESSFlow              string `json:"vless_flow,omitempty"`                // VLESS flow control (xtls-rprx-vision)
-	VLESSSecurity          string `json:"vless_security,omitempty"`            // VLESS security type (none, tls, reality)
-	VLESSFingerprint       string `json:"vless_fingerprint,omitempty"`         // TLS fingerprint for VLESS
-	VLESSRealityPrivateKey string `json:"vless_reality_private_key,omitempty"` // Reality private key (for server inbound)
-	VLESSRealityPublicKey  string `json:"vless_reality_public_key,omitempty"`  // Reality public key (for client outbound)
-	VLESSRealityShortID    string `json:"vless_reality_short_id,omitempty"`    // Reality short ID
-	VLESSRealitySpiderX    string `json:"vless_reality_spider_x,omitempty"`    // Reality spider X parameter
+	VLESSFlow              string   `json:"vless_flow,omitempty"`                // VLESS flow control (xtls-rprx-vision)
+	VLESSSecurity          string   `json:"vless_security,omitempty"`            // VLESS security type (none, tls, reality)
+	VLESSFingerprint       string   `json:"vless_fingerprint,omitempty"`         // TLS fingerprint for VLESS
+	VLESSRealityPrivateKey string   `json:"vless_reality_private_key,omitempty"` // Reality private key (for server inbound)
+	VLESSRealityPublicKey  string   `json:"vless_reality_public_key,omitempty"`  // Reality public key (for client outbound)
+	VLESSRealityShortID    string   `json:"vless_reality_short_id,omitempty"`    // Reality short ID
+	VLESSRealityShortIDs   []string `json:"vless_reality_short_ids,omitempty"`   // Accepted short IDs (current first, then the previous one during a rotation overlap)
+	VLESSRealitySpiderX    string   `json:"vless_reality_spider_x,omitempty"`    // Reality spider X parameter
 
 	// VMess specific fields
 	VMessAlterID  int    `json:"vmess_alter_id,omitempty"` // VMess alter ID (usually 0)
@@ -77,6 +81,17 @@ type NodeConfigResponse struct {
 	AnyTLSIdleSessionCheckInterval string `json:"anytls_idle_session_check_interval,omitempty"` // Idle session check interval
 	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty"`        // Idle session timeout
 	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty"`            // Minimum idle sessions
+
+	// WireGuard specific fields
+	WireGuardPrivateKey string `json:"wireguard_private_key,omitempty"` // Server private key (base64)
+	WireGuardAddress    string `json:"wireguard_address,omitempty"`     // Server tunnel address with pool prefix (e.g. 10.66.0.1/16)
+	WireGuardMTU        int    `json:"wireguard_mtu,omitempty"`         // Tunnel MTU
+
+	// ShadowTLS specific fields (Shadowsocks nodes only)
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty"`          // ShadowTLS protocol version (2 or 3)
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty"`         // ShadowTLS password
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty"` // TLS site the handshake is relayed to
+	ShadowTLSHandshakePort   int    `json:"shadowtls_handshake_port,omitempty"`   // Handshake server port
 }
 
 // RouteConfigDTO represents the routing configuration for sing-box
@@ -257,7 +272,7 @@ type OutboundRealityDTO struct {
 
 // OutboundTransportDTO represents transport configuration for outbound.
 type OutboundTransportDTO struct {
-	Type        string            `json:"type"`                   // Transport type: ws, grpc
+	Type        string            `json:"type"`                   // Transport type: ws, grpc, http, httpupgrade
 	Path        string            `json:"path,omitempty"`         // WebSocket path
 	Headers     map[string]string `json:"headers,omitempty"`      // Custom headers for WS
 	ServiceName string            `json:"service_name,omitempty"` // gRPC service name
@@ -271,6 +286,10 @@ type NodeSubscriptionInfo struct {
 	SpeedLimit      uint64 `json:"speed_limit"`                        // Speed limit in bps (0 = unlimited)
 	DeviceLimit     int    `json:"device_limit"`                       // Device connection limit (0 = unlimited)
 	ExpireTime      int64  `json:"expire_time"`                        // Unix timestamp of expiration date
+
+	// WireGuard specific fields (WireGuard nodes only)
+	WireGuardPublicKey string `json:"wireguard_public_key,omitempty"` // Peer public key allocated on the node
+	WireGuardAddress   string `json:"wireguard_address,omitempty"`    // Peer tunnel address (/32) allocated from the node address pool
 }
 
 // NodeSubscriptionsResponse represents the subscription list response for a node
@@ -336,8 +355,16 @@ func ToNodeConfigResponse(n *node.Node, referencedNodes []*node.Node, serverKeyF
 		config.Protocol = "shadowsocks"
 		config.EncryptionMethod = n.EncryptionConfig().Method()
 
-		// Generate server key for SS2022 methods
-		config.ServerKey = vo.GenerateSS2022ServerKey(n.TokenHash(), config.EncryptionMethod)
+		// Server PSK for SS2022 methods (stored key, or derived from token hash)
+		config.ServerKey = n.EncryptionConfig().EffectiveServerKey(n.TokenHash())
+
+		// ShadowTLS layer terminated by the agent in front of Shadowsocks
+		if st := n.ShadowTLSConfig(); st != nil {
+			config.ShadowTLSVersion = st.Version()
+			config.ShadowTLSPassword = st.Password()
+			config.ShadowTLSHandshakeServer = st.HandshakeServer()
+			config.ShadowTLSHandshakePort = int(st.HandshakePort())
+		}
 
 		// Handle plugin configuration for Shadowsocks transport
 		if n.PluginConfig() != nil {
@@ -397,13 +424,19 @@ func ToNodeConfigResponse(n *node.Node, referencedNodes []*node.Node, serverKeyF
 			config.VLESSRealityPrivateKey = vc.PrivateKey()
 			config.VLESSRealityPublicKey = vc.PublicKey()
 			config.VLESSRealityShortID = vc.ShortID()
+			config.VLESSRealityShortIDs = vc.AcceptedShortIDs(biztime.NowUTC())
 			config.VLESSRealitySpiderX = vc.SpiderX()
 
 			// Handle transport-specific fields
 			switch vc.TransportType() {
-			case "ws", "h2":
+			case "ws", "h2", "httpupgrade":
 				config.Host = vc.Host()
 				config.Path = vc.Path()
+			case "xhttp":
+				config.Host = vc.Host()
+				config.Path = vc.Path()
+				config.XHTTPMode = vc.XHTTPMode()
+				config.XHTTPExtra = vc.XHTTPExtra()
 			case "grpc":
 				config.ServiceName = vc.ServiceName()
 			}
@@ -426,9 +459,14 @@ func ToNodeConfigResponse(n *node.Node, referencedNodes []*node.Node, serverKeyF
 
 			// Handle transport-specific fields
 			switch vc.TransportType() {
-			case "ws", "http":
+			case "ws", "http", "httpupgrade":
+				config.Host = vc.Host()
+				config.Path = vc.Path()
+			case "xhttp":
 				config.Host = vc.Host()
 				config.Path = vc.Path()
+				config.XHTTPMode = vc.XHTTPMode()
+				config.XHTTPExtra = vc.XHTTPExtra()
 			case "grpc":
 				config.ServiceName = vc.ServiceName()
 			}
@@ -492,6 +530,18 @@ func ToNodeConfigResponse(n *node.Node, referencedNodes []*node.Node, serverKeyF
 			// AnyTLS uses TLS transport
 			config.TransportProtocol = "tcp"
 		}
+
+	case n.Protocol().IsWireGuard():
+		config.Protocol = "wireguard"
+		config.TransportProtocol = "udp"
+
+		// Extract WireGuard-specific configuration; peers are delivered with the subscription list
+		if n.WireGuardConfig() != nil {
+			wc := n.WireGuardConfig()
+			config.WireGuardPrivateKey = wc.PrivateKey()
+			config.WireGuardAddress = wc.ServerAddress()
+			config.WireGuardMTU = wc.MTU()
+		}
 	}
 
 	// Convert route configuration if present
@@ -879,6 +929,17 @@ func ToOutboundDTO(n *node.Node, serverKey string) *OutboundDTO {
 				if vc.Host() != "" {
 					dto.Transport.Headers = map[string]string{"Host": vc.Host()}
 				}
+			case "httpupgrade":
+				dto.Transport = &OutboundTransportDTO{
+					Type: "httpupgrade",
+					Path: vc.Path(),
+				}
+				if vc.Host() != "" {
+					dto.Transport.Headers = map[string]string{"Host": vc.Host()}
+				}
+			case "xhttp":
+				// sing-box has no XHTTP transport, the node cannot be used as an outbound
+				return nil
 			}
 		}
 
@@ -925,6 +986,17 @@ func ToOutboundDTO(n *node.Node, serverKey string) *OutboundDTO {
 				if vc.Host() != "" {
 					dto.Transport.Headers = map[string]string{"Host": vc.Host()}
 				}
+			case "httpupgrade":
+				dto.Transport = &OutboundTransportDTO{
+					Type: "httpupgrade",
+					Path: vc.Path(),
+				}
+				if vc.Host() != "" {
+					dto.Transport.Headers = map[string]string{"Host": vc.Host()}
+				}
+			case "xhttp":
+				// sing-box has no XHTTP transport, the node cannot be used as an outbound
+				return nil
 			}
 		}
 
@@ -995,6 +1067,10 @@ func ToOutboundDTO(n *node.Node, serverKey string) *OutboundDTO {
 			dto.AnyTLSIdleSessionTimeout = ac.IdleSessionTimeout()
 			dto.AnyTLSMinIdleSession = ac.MinIdleSession()
 		}
+
+	case n.Protocol().IsWireGuard():
+		// WireGuard peers are per-subscription, so a node cannot be used as a route outbound
+		return nil
 	}
 
 	return dto
@@ -1070,6 +1146,32 @@ func ToNodeSubscriptionsResponse(subscriptions []*subscription.Subscription, hma
 	}
 }
 
+// ApplyWireGuardPeers fills the WireGuard peer key and tunnel address of each subscription for a
+// WireGuard node. peers holds the peers allocated on the node keyed by subscription ID; entries
+// without a peer are dropped, since the node cannot accept them. Node agents only get the peer
+// public key, and the subscription password is cleared since WireGuard peers do not use it.
+func ApplyWireGuardPeers(infos []NodeSubscriptionInfo, subscriptions []*subscription.Subscription, peers map[uint]*node.WireGuardPeer) []NodeSubscriptionInfo {
+	subIDs := make(map[string]uint, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub != nil {
+			subIDs[sub.SID()] = sub.ID()
+		}
+	}
+
+	result := make([]NodeSubscriptionInfo, 0, len(infos))
+	for _, info := range infos {
+		peer, ok := peers[subIDs[info.SubscriptionSID]]
+		if !ok || peer == nil {
+			continue
+		}
+		info.Password = ""
+		info.WireGuardPublicKey = peer.PublicKey()
+		info.WireGuardAddress = peer.Address() + "/32"
+		result = append(result, info)
+	}
+	return result
+}
+
 // Helper functions
 
 // BuildPlanDeviceLimits extracts device limits from plans into a map of planID -> limit count.
@@ -0,0 +1,38 @@
+package dto
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+)
+
+func TestApplyWireGuardPeers(t *testing.T) {
+	now := time.Now()
+	var subs []*subscription.Subscription
+	for _, id := range []uint{1, 2} {
+		sub, err := subscription.NewSubscription(1, 1, now, now.Add(time.Hour), false, nil)
+		require.NoError(t, err)
+		require.NoError(t, sub.SetID(id))
+		subs = append(subs, sub)
+	}
+	peer, err := node.NewWireGuardPeer(1, "10.66.0.2")
+	require.NoError(t, err)
+
+	infos := []NodeSubscriptionInfo{
+		{SubscriptionSID: subs[0].SID(), Password: "secret-1"},
+		{SubscriptionSID: subs[1].SID(), Password: "secret-2"},
+	}
+	got := ApplyWireGuardPeers(infos, subs, map[uint]*node.WireGuardPeer{1: peer})
+
+	// Subscriptions without a peer are dropped; agents get the public key only
+	require.Len(t, got, 1)
+	assert.Equal(t, subs[0].SID(), got[0].SubscriptionSID)
+	assert.Equal(t, peer.PublicKey(), got[0].WireGuardPublicKey)
+	assert.Equal(t, "10.66.0.2/32", got[0].WireGuardAddress)
+	assert.Empty(t, got[0].Password)
+}
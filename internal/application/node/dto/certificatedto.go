@@ -0,0 +1,66 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
+
+// CertificateDTO represents a node TLS certificate for the admin API.
+// The private key is never exposed.
+type CertificateDTO struct {
+	ID            string     `json:"id" example:"cert_xK9mP2vL3nQ" description:"Certificate ID"`
+	Domain        string     `json:"domain" example:"*.example.com" description:"Certificate domain (exact name or single-level wildcard)"`
+	DNSProvider   string     `json:"dns_provider" example:"cloudflare" description:"DNS provider used for the DNS-01 challenge"`
+	AutoRenew     bool       `json:"auto_renew" description:"Whether the certificate is renewed automatically before it expires"`
+	Status        string     `json:"status" enums:"pending,issuing,active,failed" description:"Issuance status"`
+	Fingerprint   string     `json:"fingerprint,omitempty" description:"SHA-256 fingerprint of the leaf certificate (hex)"`
+	NotBefore     *time.Time `json:"not_before,omitempty" description:"Start of the validity period"`
+	NotAfter      *time.Time `json:"not_after,omitempty" description:"Expiry of the issued certificate"`
+	LastError     string     `json:"last_error,omitempty" description:"Error of the latest failed issuance"`
+	LastAttemptAt *time.Time `json:"last_attempt_at,omitempty" description:"When issuance was last started"`
+	CreatedAt     time.Time  `json:"created_at"`
+	UpdatedAt     time.Time  `json:"updated_at"`
+}
+
+// ToCertificateDTO converts a certificate entity to its DTO.
+func ToCertificateDTO(c *node.Certificate) *CertificateDTO {
+	if c == nil {
+		return nil
+	}
+
+	return &CertificateDTO{
+		ID:            c.SID(),
+		Domain:        c.Domain(),
+		DNSProvider:   c.DNSProvider(),
+		AutoRenew:     c.AutoRenew(),
+		Status:        c.Status().String(),
+		Fingerprint:   c.Fingerprint(),
+		NotBefore:     c.NotBefore(),
+		NotAfter:      c.NotAfter(),
+		LastError:     c.LastError(),
+		LastAttemptAt: c.LastAttemptAt(),
+		CreatedAt:     c.CreatedAt(),
+		UpdatedAt:     c.UpdatedAt(),
+	}
+}
+
+// ToNodeCertificateSyncData converts an issued certificate to the hub payload sent to node agents.
+func ToNodeCertificateSyncData(c *node.Certificate) *NodeCertificateSyncData {
+	if c == nil || !c.HasCertificate() {
+		return nil
+	}
+
+	data := &NodeCertificateSyncData{
+		CertificateID:  c.SID(),
+		Domain:         c.Domain(),
+		CertificatePEM: c.CertificatePEM(),
+		PrivateKeyPEM:  c.PrivateKeyPEM(),
+		Fingerprint:    c.Fingerprint(),
+		NotAfter:       c.NotAfter().Unix(),
+	}
+	if c.NotBefore() != nil {
+		data.NotBefore = c.NotBefore().Unix()
+	}
+	return data
+}
@@ -0,0 +1,327 @@
+package dto
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// ConfigVersionDTO represents a recorded node configuration version for the admin API.
+type ConfigVersionDTO struct {
+	Version      int                `json:"version" example:"3" description:"Version number, sequential per node"`
+	Source       string             `json:"source" enums:"create,update,rollback,baseline" description:"What produced the version"`
+	RestoredFrom *int               `json:"restored_from,omitempty" example:"1" description:"Version restored by a rollback"`
+	Author       *NodeOwnerDTO      `json:"author,omitempty" description:"User who made the change (omitted for versions recorded by the system)"`
+	Changes      []ConfigChangeDTO  `json:"changes" description:"Changes against the previous version"`
+	Config       *ConfigSnapshotDTO `json:"config" description:"Recorded protocol, route and DNS configuration"`
+	CreatedAt    time.Time          `json:"created_at"`
+}
+
+// ConfigChangeDTO represents a single configuration field that differs between two versions.
+type ConfigChangeDTO struct {
+	Path string          `json:"path" example:"route.rules[0].outbound" description:"Field path in the configuration document"`
+	Old  json.RawMessage `json:"old,omitempty" description:"Previous value (omitted if the field was added)"`
+	New  json.RawMessage `json:"new,omitempty" description:"New value (omitted if the field was removed)"`
+}
+
+// ConfigVersionDiffDTO represents the changes between two configuration versions.
+type ConfigVersionDiffDTO struct {
+	From    int               `json:"from" example:"2" description:"Base version"`
+	To      int               `json:"to" example:"5" description:"Compared version"`
+	Changes []ConfigChangeDTO `json:"changes" description:"Fields that differ, sorted by path"`
+}
+
+// ConfigSnapshotDTO is the document form of a node configuration snapshot, used for
+// display and diffing. Private keys are replaced by a short digest so that key changes
+// show up in diffs without exposing the keys.
+type ConfigSnapshotDTO struct {
+	Protocol    string                  `json:"protocol" example:"vless"`
+	Shadowsocks *ShadowsocksSnapshotDTO `json:"shadowsocks,omitempty"`
+	Trojan      *TrojanSnapshotDTO      `json:"trojan,omitempty"`
+	VLESS       *VLESSSnapshotDTO       `json:"vless,omitempty"`
+	VMess       *VMessSnapshotDTO       `json:"vmess,omitempty"`
+	Hysteria2   *Hysteria2SnapshotDTO   `json:"hysteria2,omitempty"`
+	TUIC        *TUICSnapshotDTO        `json:"tuic,omitempty"`
+	AnyTLS      *AnyTLSSnapshotDTO      `json:"anytls,omitempty"`
+	WireGuard   *WireGuardSnapshotDTO   `json:"wireguard,omitempty"`
+	Route       *RouteConfigDTO         `json:"route,omitempty"`
+	DNS         *DnsConfigDTO           `json:"dns,omitempty"`
+}
+
+// ShadowsocksSnapshotDTO is the Shadowsocks part of a configuration snapshot.
+type ShadowsocksSnapshotDTO struct {
+	EncryptionMethod         string            `json:"encryption_method"`
+	ServerKey                string            `json:"server_key,omitempty" description:"Digest of the SS2022 server key"`
+	Plugin                   string            `json:"plugin,omitempty"`
+	PluginOpts               map[string]string `json:"plugin_opts,omitempty"`
+	ShadowTLSVersion         int               `json:"shadowtls_version,omitempty"`
+	ShadowTLSPassword        string            `json:"shadowtls_password,omitempty"`
+	ShadowTLSHandshakeServer string            `json:"shadowtls_handshake_server,omitempty"`
+	ShadowTLSHandshakePort   uint16            `json:"shadowtls_handshake_port,omitempty"`
+}
+
+// TrojanSnapshotDTO is the Trojan part of a configuration snapshot.
+type TrojanSnapshotDTO struct {
+	TransportProtocol string `json:"transport_protocol"`
+	Host              string `json:"host"`
+	Path              string `json:"path"`
+	SNI               string `json:"sni"`
+	AllowInsecure     bool   `json:"allow_insecure"`
+}
+
+// VLESSSnapshotDTO is the VLESS part of a configuration snapshot.
+type VLESSSnapshotDTO struct {
+	TransportType                string `json:"transport_type"`
+	Flow                         string `json:"flow"`
+	Security                     string `json:"security"`
+	SNI                          string `json:"sni"`
+	Fingerprint                  string `json:"fingerprint"`
+	AllowInsecure                bool   `json:"allow_insecure"`
+	Host                         string `json:"host"`
+	Path                         string `json:"path"`
+	ServiceName                  string `json:"service_name"`
+	XHTTPMode                    string `json:"xhttp_mode"`
+	XHTTPExtra                   string `json:"xhttp_extra"`
+	RealityPrivateKey            string `json:"reality_private_key,omitempty" description:"Digest of the Reality private key"`
+	RealityPublicKey             string `json:"reality_public_key,omitempty"`
+	RealityShortID               string `json:"reality_short_id,omitempty"`
+	RealitySpiderX               string `json:"reality_spider_x,omitempty"`
+	RealityRotationIntervalHours int    `json:"reality_rotation_interval_hours,omitempty"`
+	RealityRotationOverlapHours  int    `json:"reality_rotation_overlap_hours,omitempty"`
+	RealityRotateKeyPair         bool   `json:"reality_rotate_key_pair,omitempty"`
+}
+
+// VMessSnapshotDTO is the VMess part of a configuration snapshot.
+type VMessSnapshotDTO struct {
+	AlterID       int    `json:"alter_id"`
+	Security      string `json:"security"`
+	TransportType string `json:"transport_type"`
+	Host          string `json:"host"`
+	Path          string `json:"path"`
+	ServiceName   string `json:"service_name"`
+	TLS           bool   `json:"tls"`
+	SNI           string `json:"sni"`
+	AllowInsecure bool   `json:"allow_insecure"`
+	XHTTPMode     string `json:"xhttp_mode"`
+	XHTTPExtra    string `json:"xhttp_extra"`
+}
+
+// Hysteria2SnapshotDTO is the Hysteria2 part of a configuration snapshot.
+type Hysteria2SnapshotDTO struct {
+	CongestionControl string `json:"congestion_control"`
+	Obfs              string `json:"obfs"`
+	ObfsPassword      string `json:"obfs_password"`
+	UpMbps            *int   `json:"up_mbps"`
+	DownMbps          *int   `json:"down_mbps"`
+	SNI               string `json:"sni"`
+	AllowInsecure     bool   `json:"allow_insecure"`
+	Fingerprint       string `json:"fingerprint"`
+}
+
+// TUICSnapshotDTO is the TUIC part of a configuration snapshot.
+type TUICSnapshotDTO struct {
+	CongestionControl string `json:"congestion_control"`
+	UDPRelayMode      string `json:"udp_relay_mode"`
+	ALPN              string `json:"alpn"`
+	SNI               string `json:"sni"`
+	AllowInsecure     bool   `json:"allow_insecure"`
+	DisableSNI        bool   `json:"disable_sni"`
+}
+
+// AnyTLSSnapshotDTO is the AnyTLS part of a configuration snapshot.
+type AnyTLSSnapshotDTO struct {
+	SNI                      string `json:"sni"`
+	AllowInsecure            bool   `json:"allow_insecure"`
+	Fingerprint              string `json:"fingerprint"`
+	IdleSessionCheckInterval string `json:"idle_session_check_interval"`
+	IdleSessionTimeout       string `json:"idle_session_timeout"`
+	MinIdleSession           int    `json:"min_idle_session"`
+}
+
+// WireGuardSnapshotDTO is the WireGuard part of a configuration snapshot.
+type WireGuardSnapshotDTO struct {
+	PrivateKey          string `json:"private_key" description:"Digest of the server private key"`
+	PublicKey           string `json:"public_key"`
+	AddressPool         string `json:"address_pool"`
+	MTU                 int    `json:"mtu"`
+	DNS                 string `json:"dns"`
+	PersistentKeepalive int    `json:"persistent_keepalive"`
+}
+
+// ToConfigSnapshotDTO converts a configuration snapshot to its document form.
+func ToConfigSnapshotDTO(s node.ConfigSnapshot) *ConfigSnapshotDTO {
+	d := &ConfigSnapshotDTO{
+		Protocol: s.Protocol.String(),
+	}
+
+	switch s.Protocol {
+	case vo.ProtocolShadowsocks:
+		ss := &ShadowsocksSnapshotDTO{
+			EncryptionMethod: s.Encryption.Method(),
+			ServerKey:        secretDigest(s.Encryption.ServerKey()),
+		}
+		if s.Plugin != nil {
+			ss.Plugin = s.Plugin.Plugin()
+			ss.PluginOpts = s.Plugin.Opts()
+		}
+		if s.ShadowTLS != nil {
+			ss.ShadowTLSVersion = s.ShadowTLS.Version()
+			ss.ShadowTLSPassword = s.ShadowTLS.Password()
+			ss.ShadowTLSHandshakeServer = s.ShadowTLS.HandshakeServer()
+			ss.ShadowTLSHandshakePort = s.ShadowTLS.HandshakePort()
+		}
+		d.Shadowsocks = ss
+	case vo.ProtocolTrojan:
+		if c := s.Trojan; c != nil {
+			d.Trojan = &TrojanSnapshotDTO{
+				TransportProtocol: c.TransportProtocol(),
+				Host:              c.Host(),
+				Path:              c.Path(),
+				SNI:               c.SNI(),
+				AllowInsecure:     c.AllowInsecure(),
+			}
+		}
+	case vo.ProtocolVLESS:
+		if c := s.VLESS; c != nil {
+			rotation := c.RealityRotation()
+			d.VLESS = &VLESSSnapshotDTO{
+				TransportType:                c.TransportType(),
+				Flow:                         c.Flow(),
+				Security:                     c.Security(),
+				SNI:                          c.SNI(),
+				Fingerprint:                  c.Fingerprint(),
+				AllowInsecure:                c.AllowInsecure(),
+				Host:                         c.Host(),
+				Path:                         c.Path(),
+				ServiceName:                  c.ServiceName(),
+				XHTTPMode:                    c.XHTTPMode(),
+				XHTTPExtra:                   c.XHTTPExtra(),
+				RealityPrivateKey:            secretDigest(c.PrivateKey()),
+				RealityPublicKey:             c.PublicKey(),
+				RealityShortID:               c.ShortID(),
+				RealitySpiderX:               c.SpiderX(),
+				RealityRotationIntervalHours: rotation.IntervalHours(),
+				RealityRotationOverlapHours:  rotation.OverlapHours(),
+				RealityRotateKeyPair:         rotation.RotateKeyPair(),
+			}
+		}
+	case vo.ProtocolVMess:
+		if c := s.VMess; c != nil {
+			d.VMess = &VMessSnapshotDTO{
+				AlterID:       c.AlterID(),
+				Security:      c.Security(),
+				TransportType: c.TransportType(),
+				Host:          c.Host(),
+				Path:          c.Path(),
+				ServiceName:   c.ServiceName(),
+				TLS:           c.TLS(),
+				SNI:           c.SNI(),
+				AllowInsecure: c.AllowInsecure(),
+				XHTTPMode:     c.XHTTPMode(),
+				XHTTPExtra:    c.XHTTPExtra(),
+			}
+		}
+	case vo.ProtocolHysteria2:
+		if c := s.Hysteria2; c != nil {
+			d.Hysteria2 = &Hysteria2SnapshotDTO{
+				CongestionControl: c.CongestionControl(),
+				Obfs:              c.Obfs(),
+				ObfsPassword:      c.ObfsPassword(),
+				UpMbps:            c.UpMbps(),
+				DownMbps:          c.DownMbps(),
+				SNI:               c.SNI(),
+				AllowInsecure:     c.AllowInsecure(),
+				Fingerprint:       c.Fingerprint(),
+			}
+		}
+	case vo.ProtocolTUIC:
+		if c := s.TUIC; c != nil {
+			d.TUIC = &TUICSnapshotDTO{
+				CongestionControl: c.CongestionControl(),
+				UDPRelayMode:      c.UDPRelayMode(),
+				ALPN:              c.ALPN(),
+				SNI:               c.SNI(),
+				AllowInsecure:     c.AllowInsecure(),
+				DisableSNI:        c.DisableSNI(),
+			}
+		}
+	case vo.ProtocolAnyTLS:
+		if c := s.AnyTLS; c != nil {
+			d.AnyTLS = &AnyTLSSnapshotDTO{
+				SNI:                      c.SNI(),
+				AllowInsecure:            c.AllowInsecure(),
+				Fingerprint:              c.Fingerprint(),
+				IdleSessionCheckInterval: c.IdleSessionCheckInterval(),
+				IdleSessionTimeout:       c.IdleSessionTimeout(),
+				MinIdleSession:           c.MinIdleSession(),
+			}
+		}
+	case vo.ProtocolWireGuard:
+		if c := s.WireGuard; c != nil {
+			d.WireGuard = &WireGuardSnapshotDTO{
+				PrivateKey:          secretDigest(c.PrivateKey()),
+				PublicKey:           c.PublicKey(),
+				AddressPool:         c.AddressPool(),
+				MTU:                 c.MTU(),
+				DNS:                 c.DNS(),
+				PersistentKeepalive: c.PersistentKeepalive(),
+			}
+		}
+	}
+
+	if s.Route != nil {
+		d.Route = ToRouteConfigDTO(s.Route)
+	}
+	if s.DNS != nil {
+		d.DNS = ToDnsConfigDTO(s.DNS)
+	}
+
+	return d
+}
+
+// ToConfigChangeDTOs converts recorded configuration changes to their DTOs.
+func ToConfigChangeDTOs(changes []node.ConfigChange) []ConfigChangeDTO {
+	result := make([]ConfigChangeDTO, 0, len(changes))
+	for _, c := range changes {
+		change := ConfigChangeDTO{Path: c.Path}
+		if c.Old != "" {
+			change.Old = json.RawMessage(c.Old)
+		}
+		if c.New != "" {
+			change.New = json.RawMessage(c.New)
+		}
+		result = append(result, change)
+	}
+	return result
+}
+
+// ToConfigVersionDTO converts a configuration version to its DTO.
+// author may be nil for versions recorded by the system or authors that no longer exist.
+func ToConfigVersionDTO(v *node.ConfigVersion, author *NodeOwnerDTO) *ConfigVersionDTO {
+	if v == nil {
+		return nil
+	}
+
+	return &ConfigVersionDTO{
+		Version:      v.Version(),
+		Source:       v.Source().String(),
+		RestoredFrom: v.RestoredFrom(),
+		Author:       author,
+		Changes:      ToConfigChangeDTOs(v.Changes()),
+		Config:       ToConfigSnapshotDTO(v.Snapshot()),
+		CreatedAt:    v.CreatedAt(),
+	}
+}
+
+// secretDigest returns a short SHA-256 digest of a secret (empty if the secret is empty).
+func secretDigest(secret string) string {
+	if secret == "" {
+		return ""
+	}
+	sum := sha256.Sum256([]byte(secret))
+	return "sha256:" + hex.EncodeToString(sum[:6])
+}
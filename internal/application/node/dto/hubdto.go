@@ -5,6 +5,7 @@ import (
 	"github.com/orris-inc/orris/internal/domain/forward"
 	"github.com/orris-inc/orris/internal/domain/node"
 	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/biztime"
 	nodehub "github.com/orris-inc/orris/internal/shared/hubprotocol/node"
 )
 
@@ -19,6 +20,7 @@ const (
 	NodeMsgTypeCommand          = nodehub.NodeMsgTypeCommand
 	NodeMsgTypeConfigSync       = nodehub.NodeMsgTypeConfigSync
 	NodeMsgTypeSubscriptionSync = nodehub.NodeMsgTypeSubscriptionSync
+	NodeMsgTypeCertificateSync  = nodehub.NodeMsgTypeCertificateSync
 )
 
 // NodeHubMessage is the unified WebSocket message envelope for node agents (type alias from shared hubprotocol).
@@ -27,6 +29,9 @@ type NodeHubMessage = nodehub.NodeHubMessage
 // NodeCommandData represents a command to be sent to node agent (type alias from shared hubprotocol).
 type NodeCommandData = nodehub.NodeCommandData
 
+// NodeCertificateSyncData carries the TLS certificate for a node (type alias from shared hubprotocol).
+type NodeCertificateSyncData = nodehub.NodeCertificateSyncData
+
 // Node command action constants (re-exported from shared hubprotocol).
 const (
 	NodeCmdActionReloadConfig   = nodehub.NodeCmdActionReloadConfig
@@ -72,6 +77,8 @@ type NodeConfigData struct {
 	Host              string          `json:"host,omitempty"`
 	Path              string          `json:"path,omitempty"`
 	ServiceName       string          `json:"service_name,omitempty"`
+	XHTTPMode         string          `json:"xhttp_mode,omitempty"`  // XHTTP mode (auto, packet-up, stream-up, stream-one)
+	XHTTPExtra        string          `json:"xhttp_extra,omitempty"` // XHTTP extra options (JSON object)
 	SNI               string          `json:"sni,omitempty"`
 	AllowInsecure     bool            `json:"allow_insecure"`
 	Route             *RouteConfigDTO       `json:"route,omitempty"`               // Routing configuration for traffic splitting
@@ -80,13 +87,14 @@ type NodeConfigData struct {
 	ForwardRuleRoutes []ForwardRuleRouteDTO `json:"forward_rule_routes,omitempty"` // Per-forward-rule routing configurations
 
 	// VLESS specific fields
-	Flow        string `json:"flow,omitempty"`         // VLESS flow control (xtls-rprx-vision)
-	Security    string `json:"security,omitempty"`     // Security type (none, tls, reality)
-	Fingerprint string `json:"fingerprint,omitempty"`  // TLS fingerprint
-	PrivateKey  string `json:"private_key,omitempty"`  // Reality private key
-	PublicKey   string `json:"public_key,omitempty"`   // Reality public key
-	ShortID     string `json:"short_id,omitempty"`     // Reality short ID
-	SpiderX     string `json:"spider_x,omitempty"`     // Reality spider X
+	Flow        string   `json:"flow,omitempty"`        // VLESS flow control (xtls-rprx-vision)
+	Security    string   `json:"security,omitempty"`    // Security type (none, tls, reality)
+	Fingerprint string   `json:"fingerprint,omitempty"` // TLS fingerprint
+	PrivateKey  string   `json:"private_key,omitempty"` // Reality private key
+	PublicKey   string   `json:"public_key,omitempty"`  // Reality public key
+	ShortID     string   `json:"short_id,omitempty"`    // Reality short ID
+	ShortIDs    []string `json:"short_ids,omitempty"`   // Accepted short IDs (current first, then the previous one during a rotation overlap)
+	SpiderX     string   `json:"spider_x,omitempty"`    // Reality spider X
 
 	// VMess specific fields
 	AlterID      int  `json:"alter_id,omitempty"`      // VMess alter ID
@@ -110,6 +118,17 @@ type NodeConfigData struct {
 	AnyTLSIdleSessionCheckInterval string `json:"anytls_idle_session_check_interval,omitempty"` // Idle session check interval
 	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty"`        // Idle session timeout
 	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty"`             // Minimum idle sessions
+
+	// WireGuard specific fields
+	WireGuardPrivateKey string `json:"wireguard_private_key,omitempty"` // Server private key (base64)
+	WireGuardAddress    string `json:"wireguard_address,omitempty"`     // Server tunnel address with pool prefix (e.g. 10.66.0.1/16)
+	WireGuardMTU        int    `json:"wireguard_mtu,omitempty"`         // Tunnel MTU
+
+	// ShadowTLS specific fields (Shadowsocks nodes only)
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty"`          // ShadowTLS protocol version (2 or 3)
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty"`         // ShadowTLS password
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty"` // TLS site the handshake is relayed to
+	ShadowTLSHandshakePort   int    `json:"shadowtls_handshake_port,omitempty"`   // Handshake server port
 }
 
 // ToNodeConfigData converts a domain node entity to NodeConfigData for Hub sync.
@@ -136,8 +155,16 @@ func ToNodeConfigData(n *node.Node, referencedNodes []*node.Node, serverKeyFunc
 		config.Protocol = "shadowsocks"
 		config.EncryptionMethod = n.EncryptionConfig().Method()
 
-		// Generate server key for SS2022 methods
-		config.ServerKey = vo.GenerateSS2022ServerKey(n.TokenHash(), config.EncryptionMethod)
+		// Server PSK for SS2022 methods (stored key, or derived from token hash)
+		config.ServerKey = n.EncryptionConfig().EffectiveServerKey(n.TokenHash())
+
+		// ShadowTLS layer terminated by the agent in front of Shadowsocks
+		if st := n.ShadowTLSConfig(); st != nil {
+			config.ShadowTLSVersion = st.Version()
+			config.ShadowTLSPassword = st.Password()
+			config.ShadowTLSHandshakeServer = st.HandshakeServer()
+			config.ShadowTLSHandshakePort = int(st.HandshakePort())
+		}
 
 		// Handle plugin configuration for Shadowsocks transport
 		if n.PluginConfig() != nil {
@@ -191,9 +218,14 @@ func ToNodeConfigData(n *node.Node, referencedNodes []*node.Node, serverKeyFunc
 
 			// Handle transport-specific fields
 			switch vc.TransportType() {
-			case "ws", "h2":
+			case "ws", "h2", "httpupgrade":
 				config.Host = vc.Host()
 				config.Path = vc.Path()
+			case "xhttp":
+				config.Host = vc.Host()
+				config.Path = vc.Path()
+				config.XHTTPMode = vc.XHTTPMode()
+				config.XHTTPExtra = vc.XHTTPExtra()
 			case "grpc":
 				config.ServiceName = vc.ServiceName()
 			}
@@ -203,6 +235,7 @@ func ToNodeConfigData(n *node.Node, referencedNodes []*node.Node, serverKeyFunc
 				config.PrivateKey = vc.PrivateKey()
 				config.PublicKey = vc.PublicKey()
 				config.ShortID = vc.ShortID()
+				config.ShortIDs = vc.AcceptedShortIDs(biztime.NowUTC())
 				config.SpiderX = vc.SpiderX()
 			}
 		}
@@ -221,9 +254,14 @@ func ToNodeConfigData(n *node.Node, referencedNodes []*node.Node, serverKeyFunc
 
 			// Handle transport-specific fields
 			switch vc.TransportType() {
-			case "ws", "http":
+			case "ws", "http", "httpupgrade":
 				config.Host = vc.Host()
 				config.Path = vc.Path()
+			case "xhttp":
+				config.Host = vc.Host()
+				config.Path = vc.Path()
+				config.XHTTPMode = vc.XHTTPMode()
+				config.XHTTPExtra = vc.XHTTPExtra()
 			case "grpc":
 				config.ServiceName = vc.ServiceName()
 			}
@@ -275,6 +313,17 @@ func ToNodeConfigData(n *node.Node, referencedNodes []*node.Node, serverKeyFunc
 			config.AnyTLSIdleSessionTimeout = ac.IdleSessionTimeout()
 			config.AnyTLSMinIdleSession = ac.MinIdleSession()
 		}
+	} else if n.Protocol().IsWireGuard() {
+		config.Protocol = "wireguard"
+		config.TransportProtocol = "udp"
+
+		// Extract WireGuard-specific configuration; peers are delivered with the subscription list
+		if n.WireGuardConfig() != nil {
+			wc := n.WireGuardConfig()
+			config.WireGuardPrivateKey = wc.PrivateKey()
+			config.WireGuardAddress = wc.ServerAddress()
+			config.WireGuardMTU = wc.MTU()
+		}
 	}
 
 	// Convert route configuration if present
@@ -320,5 +369,6 @@ const (
 type SubscriptionSyncData struct {
 	ChangeType    string                 `json:"change_type"`             // added, updated, removed
 	Subscriptions []NodeSubscriptionInfo `json:"subscriptions,omitempty"` // Affected subscriptions
+	ServerKey     string                 `json:"server_key,omitempty"`    // Current SS2022 server PSK the user keys pair with
 	Timestamp     int64                  `json:"timestamp"`               // Unix timestamp
 }
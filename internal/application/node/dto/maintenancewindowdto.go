@@ -0,0 +1,55 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
+
+// MaintenanceWindowDTO represents a scheduled node maintenance window for the admin API.
+type MaintenanceWindowDTO struct {
+	ID               string    `json:"id" example:"mw_xK9mP2vL3nQ" description:"Maintenance window ID"`
+	Reason           string    `json:"reason" description:"Maintenance reason shown to users and set on the nodes"`
+	StartsAt         time.Time `json:"starts_at" description:"When the affected nodes enter maintenance"`
+	EndsAt           time.Time `json:"ends_at" description:"When the affected nodes are restored"`
+	Status           string    `json:"status" enums:"scheduled,active,completed,cancelled" description:"Window status"`
+	NodeIDs          []string  `json:"node_ids" description:"Explicitly targeted node IDs"`
+	ResourceGroupIDs []string  `json:"resource_group_ids" description:"Targeted resource group IDs"`
+	AffectedNodeIDs  []string  `json:"affected_node_ids,omitempty" description:"All nodes covered by the window, resolved when it started"`
+	NoticePublished  bool      `json:"notice_published" description:"Whether a maintenance announcement was published for the window"`
+	CreatedAt        time.Time `json:"created_at"`
+	UpdatedAt        time.Time `json:"updated_at"`
+}
+
+// ToMaintenanceWindowDTO converts a maintenance window entity to its DTO.
+// The lookup maps translate internal node and resource group IDs to their SIDs;
+// IDs missing from a map (e.g. deleted nodes) are omitted.
+func ToMaintenanceWindowDTO(w *node.MaintenanceWindow, nodeSIDs, groupSIDs map[uint]string) *MaintenanceWindowDTO {
+	if w == nil {
+		return nil
+	}
+
+	return &MaintenanceWindowDTO{
+		ID:               w.SID(),
+		Reason:           w.Reason(),
+		StartsAt:         w.StartsAt(),
+		EndsAt:           w.EndsAt(),
+		Status:           w.Status().String(),
+		NodeIDs:          lookupSIDs(w.NodeIDs(), nodeSIDs),
+		ResourceGroupIDs: lookupSIDs(w.ResourceGroupIDs(), groupSIDs),
+		AffectedNodeIDs:  lookupSIDs(w.AffectedNodeIDs(), nodeSIDs),
+		NoticePublished:  w.AnnouncementID() != nil,
+		CreatedAt:        w.CreatedAt(),
+		UpdatedAt:        w.UpdatedAt(),
+	}
+}
+
+func lookupSIDs(ids []uint, sids map[uint]string) []string {
+	result := make([]string, 0, len(ids))
+	for _, id := range ids {
+		if sid, ok := sids[id]; ok {
+			result = append(result, sid)
+		}
+	}
+	return result
+}
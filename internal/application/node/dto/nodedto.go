@@ -5,6 +5,7 @@ import (
 
 	commondto "github.com/orris-inc/orris/internal/application/common/dto"
 	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
 	"github.com/orris-inc/orris/internal/shared/mapper"
 )
 
@@ -15,7 +16,7 @@ type NodeDTO struct {
 	AgentPort        uint16            `json:"agent_port" example:"8388" description:"Port for agent connections"`
 	SubscriptionPort *uint16           `json:"subscription_port,omitempty" example:"8389" description:"Port for client subscriptions (if null, uses agent_port)"`
 	Protocol         string            `json:"protocol" example:"shadowsocks" enums:"shadowsocks,trojan,vless,vmess,hysteria2,tuic,anytls" description:"Proxy protocol type"`
-	EncryptionMethod string            `json:"encryption_method" example:"aes-256-gcm" enums:"aes-256-gcm,aes-128-gcm,chacha20-ietf-poly1305" description:"Encryption method for the proxy connection"`
+	EncryptionMethod string            `json:"encryption_method" example:"2022-blake3-aes-128-gcm" enums:"2022-blake3-aes-128-gcm,2022-blake3-aes-256-gcm,2022-blake3-chacha20-poly1305" description:"Encryption method for the proxy connection"`
 	Plugin           string            `json:"plugin,omitempty" example:"obfs-local" description:"Optional plugin name"`
 	PluginOpts       map[string]string `json:"plugin_opts,omitempty" example:"obfs:http,obfs-host:example.com" description:"Plugin configuration options"`
 	Status           string            `json:"status" example:"active" enums:"active,inactive,maintenance" description:"Current operational status of the node"`
@@ -44,17 +45,28 @@ type NodeDTO struct {
 	VLESSRealityPublicKey string `json:"vless_reality_public_key,omitempty" description:"VLESS Reality public key"`
 	VLESSRealityShortID   string `json:"vless_reality_short_id,omitempty" description:"VLESS Reality short ID"`
 	VLESSRealitySpiderX   string `json:"vless_reality_spider_x,omitempty" description:"VLESS Reality spider X"`
+	VLESSXHTTPMode        string `json:"vless_xhttp_mode,omitempty" description:"VLESS XHTTP mode"`
+	VLESSXHTTPExtra       string `json:"vless_xhttp_extra,omitempty" description:"VLESS XHTTP extra options (JSON object)"`
+
+	// VLESS Reality rotation
+	VLESSRealityRotationIntervalHours    int        `json:"vless_reality_rotation_interval_hours,omitempty" description:"Scheduled Reality rotation interval in hours (0 = disabled)"`
+	VLESSRealityRotationOverlapHours     int        `json:"vless_reality_rotation_overlap_hours,omitempty" description:"Hours the previous short ID stays accepted after a rotation"`
+	VLESSRealityRotateKeyPair            bool       `json:"vless_reality_rotate_key_pair,omitempty" description:"Scheduled rotations also replace the key pair"`
+	VLESSRealityRotatedAt                *time.Time `json:"vless_reality_rotated_at,omitempty" description:"Last Reality rotation time"`
+	VLESSRealityPreviousShortIDExpiresAt *time.Time `json:"vless_reality_previous_short_id_expires_at,omitempty" description:"Time the previous short ID stops being accepted"`
 
 	// VMess specific fields
 	VMessAlterID       int    `json:"vmess_alter_id,omitempty" example:"0" description:"VMess alter ID"`
 	VMessSecurity      string `json:"vmess_security,omitempty" example:"auto" enums:"auto,aes-128-gcm,chacha20-poly1305,none,zero" description:"VMess security"`
 	VMessTransportType string `json:"vmess_transport_type,omitempty" example:"tcp" enums:"tcp,ws,grpc,http,quic" description:"VMess transport type"`
-	VMessHost          string `json:"vmess_host,omitempty" description:"VMess WS/HTTP host header"`
+	VMessHost          string `json:"vmess_host,omitempty" description:"VMess WS/HTTP/XHTTP/HTTPUpgrade host header"`
 	VMessPath          string `json:"vmess_path,omitempty" description:"VMess WS/HTTP path"`
 	VMessServiceName   string `json:"vmess_service_name,omitempty" description:"VMess gRPC service name"`
 	VMessTLS           bool   `json:"vmess_tls,omitempty" description:"VMess TLS enabled"`
 	VMessSni           string `json:"vmess_sni,omitempty" description:"VMess TLS SNI"`
 	VMessAllowInsecure bool   `json:"vmess_allow_insecure,omitempty" description:"VMess allow insecure TLS"`
+	VMessXHTTPMode     string `json:"vmess_xhttp_mode,omitempty" description:"VMess XHTTP mode"`
+	VMessXHTTPExtra    string `json:"vmess_xhttp_extra,omitempty" description:"VMess XHTTP extra options (JSON object)"`
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl string `json:"hysteria2_congestion_control,omitempty" example:"bbr" enums:"cubic,bbr,new_reno" description:"Hysteria2 congestion control"`
@@ -82,11 +94,26 @@ type NodeDTO struct {
 	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty" description:"AnyTLS idle session timeout"`
 	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty" description:"AnyTLS minimum idle sessions"`
 
+	// WireGuard specific fields (the server private key is never exposed)
+	WireGuardPublicKey           string `json:"wireguard_public_key,omitempty" description:"WireGuard server public key"`
+	WireGuardAddressPool         string `json:"wireguard_address_pool,omitempty" example:"10.66.0.0/16" description:"WireGuard tunnel address pool"`
+	WireGuardMTU                 int    `json:"wireguard_mtu,omitempty" example:"1420" description:"WireGuard tunnel MTU"`
+	WireGuardDNS                 string `json:"wireguard_dns,omitempty" example:"1.1.1.1" description:"WireGuard DNS servers pushed to clients"`
+	WireGuardPersistentKeepalive int    `json:"wireguard_persistent_keepalive,omitempty" example:"25" description:"WireGuard persistent keepalive in seconds"`
+
+	// ShadowTLS specific fields (Shadowsocks nodes only)
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty" example:"3" description:"ShadowTLS protocol version"`
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty" description:"ShadowTLS password"`
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty" example:"www.microsoft.com" description:"ShadowTLS handshake server"`
+	ShadowTLSHandshakePort   uint16 `json:"shadowtls_handshake_port,omitempty" example:"443" description:"ShadowTLS handshake server port"`
+
 	IsOnline                bool `json:"is_online" example:"true" description:"Indicates if the node agent is online (reported within 5 minutes)"`
 	OnlineSubscriptionCount int  `json:"online_subscription_count" description:"Number of online subscriptions on this node"`
 	LastSeenAt            *time.Time `json:"last_seen_at,omitempty" example:"2024-01-15T14:20:00Z" description:"Last time the node agent reported status"`
 	ExpiresAt             *string    `json:"expires_at,omitempty" example:"2025-12-31T23:59:59Z" description:"Expiration time in ISO8601 format (null = never expires)"`
 	CostLabel             string     `json:"cost_label,omitempty" example:"35$/m" description:"Cost label for display (e.g., '35$/m', '35¥/y')"`
+	CountryCode           string     `json:"country_code,omitempty" example:"JP" description:"ISO 3166-1 alpha-2 node location used for subscription region groups"`
+	TrafficMultiplier     float64    `json:"traffic_multiplier" example:"1" description:"Billing rate applied to subscription usage on this node (1 = billed 1:1)"`
 	IsExpired             bool       `json:"is_expired" example:"false" description:"True if node has expired"`
 	AgentVersion          string     `json:"agent_version,omitempty" example:"1.2.0" description:"Agent software version, extracted from system_status for easy display"`
 	Platform              string     `json:"platform,omitempty" example:"linux" description:"OS platform (linux, darwin, windows)"`
@@ -124,7 +151,7 @@ type CreateNodeDTO struct {
 	ServerAddress    string            `json:"server_address,omitempty" example:"proxy.example.com" description:"Server hostname or IP address (optional, can be auto-detected from agent)"`
 	AgentPort        uint16            `json:"agent_port" binding:"required,min=1,max=65535" example:"8388" description:"Port for agent connections (1-65535)"`
 	SubscriptionPort *uint16           `json:"subscription_port,omitempty" binding:"omitempty,min=1,max=65535" example:"8389" description:"Port for client subscriptions (if null, uses agent_port)"`
-	EncryptionMethod string            `json:"encryption_method" binding:"required" example:"aes-256-gcm" enums:"aes-256-gcm,aes-128-gcm,chacha20-ietf-poly1305" description:"Encryption method for the proxy connection"`
+	EncryptionMethod string            `json:"encryption_method" binding:"required" example:"2022-blake3-aes-128-gcm" enums:"2022-blake3-aes-128-gcm,2022-blake3-aes-256-gcm,2022-blake3-chacha20-poly1305" description:"Encryption method for the proxy connection"`
 	Password         string            `json:"password" binding:"required" example:"mySecurePassword123" description:"Authentication password"`
 	Plugin           string            `json:"plugin,omitempty" example:"obfs-local" description:"Optional plugin name"`
 	PluginOpts       map[string]string `json:"plugin_opts,omitempty" example:"obfs:http,obfs-host:example.com" description:"Plugin configuration options"`
@@ -195,6 +222,12 @@ func ToNodeDTO(n *node.Node) *NodeDTO {
 		dto.CostLabel = *n.CostLabel()
 	}
 
+	// Map country code
+	if n.CountryCode() != nil {
+		dto.CountryCode = n.CountryCode().String()
+	}
+	dto.TrafficMultiplier = n.TrafficMultiplier()
+
 	// Map agent info fields
 	if n.AgentVersion() != nil {
 		dto.AgentVersion = *n.AgentVersion()
@@ -234,6 +267,17 @@ func ToNodeDTO(n *node.Node) *NodeDTO {
 		dto.VLESSRealityPublicKey = n.VLESSConfig().PublicKey()
 		dto.VLESSRealityShortID = n.VLESSConfig().ShortID()
 		dto.VLESSRealitySpiderX = n.VLESSConfig().SpiderX()
+		dto.VLESSXHTTPMode = n.VLESSConfig().XHTTPMode()
+		dto.VLESSXHTTPExtra = n.VLESSConfig().XHTTPExtra()
+
+		rotation := n.VLESSConfig().RealityRotation()
+		dto.VLESSRealityRotationIntervalHours = rotation.IntervalHours()
+		dto.VLESSRealityRotationOverlapHours = rotation.OverlapHours()
+		dto.VLESSRealityRotateKeyPair = rotation.RotateKeyPair()
+		dto.VLESSRealityRotatedAt = rotation.RotatedAt()
+		if rotation.IsPreviousShortIDActive(biztime.NowUTC()) {
+			dto.VLESSRealityPreviousShortIDExpiresAt = rotation.PreviousShortIDExpiresAt()
+		}
 	}
 
 	// Map VMess specific fields
@@ -247,6 +291,8 @@ func ToNodeDTO(n *node.Node) *NodeDTO {
 		dto.VMessTLS = n.VMessConfig().TLS()
 		dto.VMessSni = n.VMessConfig().SNI()
 		dto.VMessAllowInsecure = n.VMessConfig().AllowInsecure()
+		dto.VMessXHTTPMode = n.VMessConfig().XHTTPMode()
+		dto.VMessXHTTPExtra = n.VMessConfig().XHTTPExtra()
 	}
 
 	// Map Hysteria2 specific fields
@@ -281,6 +327,23 @@ func ToNodeDTO(n *node.Node) *NodeDTO {
 		dto.AnyTLSMinIdleSession = n.AnyTLSConfig().MinIdleSession()
 	}
 
+	// Map WireGuard specific fields
+	if n.WireGuardConfig() != nil {
+		dto.WireGuardPublicKey = n.WireGuardConfig().PublicKey()
+		dto.WireGuardAddressPool = n.WireGuardConfig().AddressPool()
+		dto.WireGuardMTU = n.WireGuardConfig().MTU()
+		dto.WireGuardDNS = n.WireGuardConfig().DNS()
+		dto.WireGuardPersistentKeepalive = n.WireGuardConfig().PersistentKeepalive()
+	}
+
+	// Map ShadowTLS specific fields
+	if n.ShadowTLSConfig() != nil {
+		dto.ShadowTLSVersion = n.ShadowTLSConfig().Version()
+		dto.ShadowTLSPassword = n.ShadowTLSConfig().Password()
+		dto.ShadowTLSHandshakeServer = n.ShadowTLSConfig().HandshakeServer()
+		dto.ShadowTLSHandshakePort = n.ShadowTLSConfig().HandshakePort()
+	}
+
 	metadata := n.Metadata()
 	if metadata.Region() != "" {
 		dto.Region = metadata.Region()
@@ -339,17 +402,21 @@ type UserNodeDTO struct {
 	VLESSRealityPublicKey string `json:"vless_reality_public_key,omitempty" description:"VLESS Reality public key"`
 	VLESSRealityShortID   string `json:"vless_reality_short_id,omitempty" description:"VLESS Reality short ID"`
 	VLESSRealitySpiderX   string `json:"vless_reality_spider_x,omitempty" description:"VLESS Reality spider X"`
+	VLESSXHTTPMode        string `json:"vless_xhttp_mode,omitempty" description:"VLESS XHTTP mode"`
+	VLESSXHTTPExtra       string `json:"vless_xhttp_extra,omitempty" description:"VLESS XHTTP extra options (JSON object)"`
 
 	// VMess specific fields
 	VMessAlterID       int    `json:"vmess_alter_id,omitempty" description:"VMess alter ID"`
 	VMessSecurity      string `json:"vmess_security,omitempty" description:"VMess security"`
 	VMessTransportType string `json:"vmess_transport_type,omitempty" description:"VMess transport type"`
-	VMessHost          string `json:"vmess_host,omitempty" description:"VMess WS/HTTP host header"`
+	VMessHost          string `json:"vmess_host,omitempty" description:"VMess WS/HTTP/XHTTP/HTTPUpgrade host header"`
 	VMessPath          string `json:"vmess_path,omitempty" description:"VMess WS/HTTP path"`
 	VMessServiceName   string `json:"vmess_service_name,omitempty" description:"VMess gRPC service name"`
 	VMessTLS           bool   `json:"vmess_tls,omitempty" description:"VMess TLS enabled"`
 	VMessSni           string `json:"vmess_sni,omitempty" description:"VMess TLS SNI"`
 	VMessAllowInsecure bool   `json:"vmess_allow_insecure,omitempty" description:"VMess allow insecure TLS"`
+	VMessXHTTPMode     string `json:"vmess_xhttp_mode,omitempty" description:"VMess XHTTP mode"`
+	VMessXHTTPExtra    string `json:"vmess_xhttp_extra,omitempty" description:"VMess XHTTP extra options (JSON object)"`
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl string `json:"hysteria2_congestion_control,omitempty" description:"Hysteria2 congestion control"`
@@ -377,6 +444,19 @@ type UserNodeDTO struct {
 	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty" description:"AnyTLS idle session timeout"`
 	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty" description:"AnyTLS minimum idle sessions"`
 
+	// WireGuard specific fields (the server private key is never exposed)
+	WireGuardPublicKey           string `json:"wireguard_public_key,omitempty" description:"WireGuard server public key"`
+	WireGuardAddressPool         string `json:"wireguard_address_pool,omitempty" example:"10.66.0.0/16" description:"WireGuard tunnel address pool"`
+	WireGuardMTU                 int    `json:"wireguard_mtu,omitempty" example:"1420" description:"WireGuard tunnel MTU"`
+	WireGuardDNS                 string `json:"wireguard_dns,omitempty" example:"1.1.1.1" description:"WireGuard DNS servers pushed to clients"`
+	WireGuardPersistentKeepalive int    `json:"wireguard_persistent_keepalive,omitempty" example:"25" description:"WireGuard persistent keepalive in seconds"`
+
+	// ShadowTLS specific fields (Shadowsocks nodes only)
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty" example:"3" description:"ShadowTLS protocol version"`
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty" description:"ShadowTLS password"`
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty" example:"www.microsoft.com" description:"ShadowTLS handshake server"`
+	ShadowTLSHandshakePort   uint16 `json:"shadowtls_handshake_port,omitempty" example:"443" description:"ShadowTLS handshake server port"`
+
 	CreatedAt time.Time `json:"created_at" example:"2024-01-15T10:30:00Z" description:"Timestamp when the node was created"`
 	UpdatedAt time.Time `json:"updated_at" example:"2024-01-15T14:20:00Z" description:"Timestamp when the node was last updated"`
 }
@@ -425,6 +505,8 @@ func ToUserNodeDTO(n *node.Node) *UserNodeDTO {
 		dto.VLESSRealityPublicKey = n.VLESSConfig().PublicKey()
 		dto.VLESSRealityShortID = n.VLESSConfig().ShortID()
 		dto.VLESSRealitySpiderX = n.VLESSConfig().SpiderX()
+		dto.VLESSXHTTPMode = n.VLESSConfig().XHTTPMode()
+		dto.VLESSXHTTPExtra = n.VLESSConfig().XHTTPExtra()
 	}
 
 	// Map VMess specific fields
@@ -438,6 +520,8 @@ func ToUserNodeDTO(n *node.Node) *UserNodeDTO {
 		dto.VMessTLS = n.VMessConfig().TLS()
 		dto.VMessSni = n.VMessConfig().SNI()
 		dto.VMessAllowInsecure = n.VMessConfig().AllowInsecure()
+		dto.VMessXHTTPMode = n.VMessConfig().XHTTPMode()
+		dto.VMessXHTTPExtra = n.VMessConfig().XHTTPExtra()
 	}
 
 	// Map Hysteria2 specific fields
@@ -472,6 +556,23 @@ func ToUserNodeDTO(n *node.Node) *UserNodeDTO {
 		dto.AnyTLSMinIdleSession = n.AnyTLSConfig().MinIdleSession()
 	}
 
+	// Map WireGuard specific fields
+	if n.WireGuardConfig() != nil {
+		dto.WireGuardPublicKey = n.WireGuardConfig().PublicKey()
+		dto.WireGuardAddressPool = n.WireGuardConfig().AddressPool()
+		dto.WireGuardMTU = n.WireGuardConfig().MTU()
+		dto.WireGuardDNS = n.WireGuardConfig().DNS()
+		dto.WireGuardPersistentKeepalive = n.WireGuardConfig().PersistentKeepalive()
+	}
+
+	// Map ShadowTLS specific fields
+	if n.ShadowTLSConfig() != nil {
+		dto.ShadowTLSVersion = n.ShadowTLSConfig().Version()
+		dto.ShadowTLSPassword = n.ShadowTLSConfig().Password()
+		dto.ShadowTLSHandshakeServer = n.ShadowTLSConfig().HandshakeServer()
+		dto.ShadowTLSHandshakePort = n.ShadowTLSConfig().HandshakePort()
+	}
+
 	return dto
 }
 
@@ -0,0 +1,285 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
+
+// NodeManifestVersion is the current version of the node manifest format.
+const NodeManifestVersion = 1
+
+// NodeManifest is the portable document used to export and import nodes in bulk.
+type NodeManifest struct {
+	Version    int                 `json:"version" example:"1" description:"Manifest format version"`
+	ExportedAt *time.Time          `json:"exported_at,omitempty" description:"Time the manifest was exported"`
+	Nodes      []NodeManifestEntry `json:"nodes" description:"Nodes in the manifest"`
+}
+
+// NodeManifestEntry describes a single node in a manifest.
+// Field names match the create node request, so an entry can be used as a create request body and vice versa.
+type NodeManifestEntry struct {
+	Name              string   `json:"name" example:"HK-Node-01"`
+	ServerAddress     string   `json:"server_address,omitempty" example:"1.2.3.4"`
+	AgentPort         uint16   `json:"agent_port" example:"8388"`
+	SubscriptionPort  *uint16  `json:"subscription_port,omitempty" example:"8389"`
+	Region            string   `json:"region,omitempty" example:"hk"`
+	CountryCode       string   `json:"country_code,omitempty" example:"HK"`
+	TrafficMultiplier *float64 `json:"traffic_multiplier,omitempty" example:"1"`
+	Tags              []string `json:"tags,omitempty"`
+	Description       string   `json:"description,omitempty"`
+	SortOrder         int      `json:"sort_order,omitempty"`
+	GroupSIDs         []string `json:"group_sids,omitempty" example:"[\"rg_xK9mP2vL3nQ\"]" description:"Resource groups the node belongs to"`
+
+	NodeConfigSpec
+}
+
+// NodeConfigSpec is the protocol, route and DNS configuration of a node, without its
+// identity and address. It is embedded in manifest entries and held by node templates.
+type NodeConfigSpec struct {
+	Protocol         string            `json:"protocol" example:"shadowsocks"`
+	EncryptionMethod string            `json:"encryption_method,omitempty" example:"2022-blake3-aes-128-gcm"`
+	ServerKey        string            `json:"server_key,omitempty" description:"Shadowsocks 2022 server key, only exported with secrets"`
+	Plugin           string            `json:"plugin,omitempty" example:"obfs-local"`
+	PluginOpts       map[string]string `json:"plugin_opts,omitempty"`
+
+	// Trojan specific fields
+	TransportProtocol string `json:"transport_protocol,omitempty"`
+	Host              string `json:"host,omitempty"`
+	Path              string `json:"path,omitempty"`
+	SNI               string `json:"sni,omitempty"`
+	AllowInsecure     bool   `json:"allow_insecure,omitempty"`
+
+	// Route and DNS configuration
+	Route *RouteConfigDTO `json:"route,omitempty"`
+	DNS   *DnsConfigDTO   `json:"dns,omitempty"`
+
+	// VLESS specific fields
+	VLESSTransportType                string `json:"vless_transport_type,omitempty"`
+	VLESSFlow                         string `json:"vless_flow,omitempty"`
+	VLESSSecurity                     string `json:"vless_security,omitempty"`
+	VLESSSni                          string `json:"vless_sni,omitempty"`
+	VLESSFingerprint                  string `json:"vless_fingerprint,omitempty"`
+	VLESSAllowInsecure                bool   `json:"vless_allow_insecure,omitempty"`
+	VLESSHost                         string `json:"vless_host,omitempty"`
+	VLESSPath                         string `json:"vless_path,omitempty"`
+	VLESSServiceName                  string `json:"vless_service_name,omitempty"`
+	VLESSRealityPrivateKey            string `json:"vless_reality_private_key,omitempty" description:"Only exported with secrets"`
+	VLESSRealityPublicKey             string `json:"vless_reality_public_key,omitempty" description:"Only exported with secrets"`
+	VLESSRealityShortID               string `json:"vless_reality_short_id,omitempty" description:"Only exported with secrets"`
+	VLESSRealitySpiderX               string `json:"vless_reality_spider_x,omitempty"`
+	VLESSXHTTPMode                    string `json:"vless_xhttp_mode,omitempty"`
+	VLESSXHTTPExtra                   string `json:"vless_xhttp_extra,omitempty"`
+	VLESSRealityRotationIntervalHours int    `json:"vless_reality_rotation_interval_hours,omitempty"`
+	VLESSRealityRotationOverlapHours  int    `json:"vless_reality_rotation_overlap_hours,omitempty"`
+	VLESSRealityRotateKeyPair         bool   `json:"vless_reality_rotate_key_pair,omitempty"`
+
+	// VMess specific fields
+	VMessAlterID       int    `json:"vmess_alter_id,omitempty"`
+	VMessSecurity      string `json:"vmess_security,omitempty"`
+	VMessTransportType string `json:"vmess_transport_type,omitempty"`
+	VMessHost          string `json:"vmess_host,omitempty"`
+	VMessPath          string `json:"vmess_path,omitempty"`
+	VMessServiceName   string `json:"vmess_service_name,omitempty"`
+	VMessTLS           bool   `json:"vmess_tls,omitempty"`
+	VMessSni           string `json:"vmess_sni,omitempty"`
+	VMessAllowInsecure bool   `json:"vmess_allow_insecure,omitempty"`
+	VMessXHTTPMode     string `json:"vmess_xhttp_mode,omitempty"`
+	VMessXHTTPExtra    string `json:"vmess_xhttp_extra,omitempty"`
+
+	// Hysteria2 specific fields
+	Hysteria2CongestionControl string `json:"hysteria2_congestion_control,omitempty"`
+	Hysteria2Obfs              string `json:"hysteria2_obfs,omitempty"`
+	Hysteria2ObfsPassword      string `json:"hysteria2_obfs_password,omitempty"`
+	Hysteria2UpMbps            *int   `json:"hysteria2_up_mbps,omitempty"`
+	Hysteria2DownMbps          *int   `json:"hysteria2_down_mbps,omitempty"`
+	Hysteria2Sni               string `json:"hysteria2_sni,omitempty"`
+	Hysteria2AllowInsecure     bool   `json:"hysteria2_allow_insecure,omitempty"`
+	Hysteria2Fingerprint       string `json:"hysteria2_fingerprint,omitempty"`
+
+	// TUIC specific fields
+	TUICCongestionControl string `json:"tuic_congestion_control,omitempty"`
+	TUICUDPRelayMode      string `json:"tuic_udp_relay_mode,omitempty"`
+	TUICAlpn              string `json:"tuic_alpn,omitempty"`
+	TUICSni               string `json:"tuic_sni,omitempty"`
+	TUICAllowInsecure     bool   `json:"tuic_allow_insecure,omitempty"`
+	TUICDisableSNI        bool   `json:"tuic_disable_sni,omitempty"`
+
+	// AnyTLS specific fields
+	AnyTLSSni                      string `json:"anytls_sni,omitempty"`
+	AnyTLSAllowInsecure            bool   `json:"anytls_allow_insecure,omitempty"`
+	AnyTLSFingerprint              string `json:"anytls_fingerprint,omitempty"`
+	AnyTLSIdleSessionCheckInterval string `json:"anytls_idle_session_check_interval,omitempty"`
+	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty"`
+	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty"`
+
+	// WireGuard specific fields
+	WireGuardPrivateKey          string `json:"wireguard_private_key,omitempty" description:"Only exported with secrets"`
+	WireGuardAddressPool         string `json:"wireguard_address_pool,omitempty"`
+	WireGuardMTU                 int    `json:"wireguard_mtu,omitempty"`
+	WireGuardDNS                 string `json:"wireguard_dns,omitempty"`
+	WireGuardPersistentKeepalive *int   `json:"wireguard_persistent_keepalive,omitempty"`
+
+	// ShadowTLS specific fields (Shadowsocks only)
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty"`
+	ShadowTLSHandshakePort   uint16 `json:"shadowtls_handshake_port,omitempty"`
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty"`
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty"`
+}
+
+// ToNodeManifestEntry converts a node to a manifest entry.
+// Server keys (Shadowsocks 2022 server key, Reality key pair and short ID, WireGuard private key)
+// are only included with includeSecrets; without them, importing the entry generates new keys.
+// A Shadowsocks 2022 key derived from the node token is exported as well, because the imported
+// node gets a new token and would otherwise derive a different key.
+func ToNodeManifestEntry(n *node.Node, groupSIDs []string, includeSecrets bool) NodeManifestEntry {
+	entry := NodeManifestEntry{
+		Name:             n.Name(),
+		ServerAddress:    n.ServerAddress().Value(),
+		AgentPort:        n.AgentPort(),
+		SubscriptionPort: n.SubscriptionPort(),
+		SortOrder:        n.SortOrder(),
+		GroupSIDs:        groupSIDs,
+		NodeConfigSpec:   ToNodeConfigSpec(n.ConfigSnapshot(), includeSecrets),
+	}
+	if includeSecrets && entry.ServerKey == "" {
+		entry.ServerKey = n.EncryptionConfig().EffectiveServerKey(n.TokenHash())
+	}
+
+	if multiplier := n.TrafficMultiplier(); multiplier != 1 {
+		entry.TrafficMultiplier = &multiplier
+	}
+	if n.CountryCode() != nil {
+		entry.CountryCode = n.CountryCode().String()
+	}
+
+	metadata := n.Metadata()
+	entry.Region = metadata.Region()
+	entry.Description = metadata.Description()
+	if len(metadata.Tags()) > 0 {
+		entry.Tags = metadata.Tags()
+	}
+
+	return entry
+}
+
+// ToNodeConfigSpec converts a configuration snapshot to a config spec.
+// Server keys are only included with includeSecrets, as in ToNodeManifestEntry.
+func ToNodeConfigSpec(s node.ConfigSnapshot, includeSecrets bool) NodeConfigSpec {
+	spec := NodeConfigSpec{
+		Protocol: s.Protocol.String(),
+	}
+
+	if s.Protocol.IsShadowsocks() {
+		spec.EncryptionMethod = s.Encryption.Method()
+		if includeSecrets {
+			spec.ServerKey = s.Encryption.ServerKey()
+		}
+		if s.Plugin != nil {
+			spec.Plugin = s.Plugin.Plugin()
+			spec.PluginOpts = s.Plugin.Opts()
+		}
+		if st := s.ShadowTLS; st != nil {
+			spec.ShadowTLSHandshakeServer = st.HandshakeServer()
+			spec.ShadowTLSHandshakePort = st.HandshakePort()
+			spec.ShadowTLSPassword = st.Password()
+			spec.ShadowTLSVersion = st.Version()
+		}
+	}
+
+	if tc := s.Trojan; tc != nil {
+		spec.TransportProtocol = tc.TransportProtocol()
+		spec.Host = tc.Host()
+		spec.Path = tc.Path()
+		spec.SNI = tc.SNI()
+		spec.AllowInsecure = tc.AllowInsecure()
+	}
+
+	if vc := s.VLESS; vc != nil {
+		spec.VLESSTransportType = vc.TransportType()
+		spec.VLESSFlow = vc.Flow()
+		spec.VLESSSecurity = vc.Security()
+		spec.VLESSSni = vc.SNI()
+		spec.VLESSFingerprint = vc.Fingerprint()
+		spec.VLESSAllowInsecure = vc.AllowInsecure()
+		spec.VLESSHost = vc.Host()
+		spec.VLESSPath = vc.Path()
+		spec.VLESSServiceName = vc.ServiceName()
+		spec.VLESSRealitySpiderX = vc.SpiderX()
+		spec.VLESSXHTTPMode = vc.XHTTPMode()
+		spec.VLESSXHTTPExtra = vc.XHTTPExtra()
+		if includeSecrets {
+			spec.VLESSRealityPrivateKey = vc.PrivateKey()
+			spec.VLESSRealityPublicKey = vc.PublicKey()
+			spec.VLESSRealityShortID = vc.ShortID()
+		}
+
+		rotation := vc.RealityRotation()
+		spec.VLESSRealityRotationIntervalHours = rotation.IntervalHours()
+		spec.VLESSRealityRotationOverlapHours = rotation.OverlapHours()
+		spec.VLESSRealityRotateKeyPair = rotation.RotateKeyPair()
+	}
+
+	if vc := s.VMess; vc != nil {
+		spec.VMessAlterID = vc.AlterID()
+		spec.VMessSecurity = vc.Security()
+		spec.VMessTransportType = vc.TransportType()
+		spec.VMessHost = vc.Host()
+		spec.VMessPath = vc.Path()
+		spec.VMessServiceName = vc.ServiceName()
+		spec.VMessTLS = vc.TLS()
+		spec.VMessSni = vc.SNI()
+		spec.VMessAllowInsecure = vc.AllowInsecure()
+		spec.VMessXHTTPMode = vc.XHTTPMode()
+		spec.VMessXHTTPExtra = vc.XHTTPExtra()
+	}
+
+	if hc := s.Hysteria2; hc != nil {
+		spec.Hysteria2CongestionControl = hc.CongestionControl()
+		spec.Hysteria2Obfs = hc.Obfs()
+		spec.Hysteria2ObfsPassword = hc.ObfsPassword()
+		spec.Hysteria2UpMbps = hc.UpMbps()
+		spec.Hysteria2DownMbps = hc.DownMbps()
+		spec.Hysteria2Sni = hc.SNI()
+		spec.Hysteria2AllowInsecure = hc.AllowInsecure()
+		spec.Hysteria2Fingerprint = hc.Fingerprint()
+	}
+
+	if tc := s.TUIC; tc != nil {
+		spec.TUICCongestionControl = tc.CongestionControl()
+		spec.TUICUDPRelayMode = tc.UDPRelayMode()
+		spec.TUICAlpn = tc.ALPN()
+		spec.TUICSni = tc.SNI()
+		spec.TUICAllowInsecure = tc.AllowInsecure()
+		spec.TUICDisableSNI = tc.DisableSNI()
+	}
+
+	if ac := s.AnyTLS; ac != nil {
+		spec.AnyTLSSni = ac.SNI()
+		spec.AnyTLSAllowInsecure = ac.AllowInsecure()
+		spec.AnyTLSFingerprint = ac.Fingerprint()
+		spec.AnyTLSIdleSessionCheckInterval = ac.IdleSessionCheckInterval()
+		spec.AnyTLSIdleSessionTimeout = ac.IdleSessionTimeout()
+		spec.AnyTLSMinIdleSession = ac.MinIdleSession()
+	}
+
+	if wc := s.WireGuard; wc != nil {
+		spec.WireGuardAddressPool = wc.AddressPool()
+		spec.WireGuardMTU = wc.MTU()
+		spec.WireGuardDNS = wc.DNS()
+		keepalive := wc.PersistentKeepalive()
+		spec.WireGuardPersistentKeepalive = &keepalive
+		if includeSecrets {
+			spec.WireGuardPrivateKey = wc.PrivateKey()
+		}
+	}
+
+	if s.Route != nil {
+		spec.Route = ToRouteConfigDTO(s.Route)
+	}
+	if s.DNS != nil {
+		spec.DNS = ToDnsConfigDTO(s.DNS)
+	}
+
+	return spec
+}
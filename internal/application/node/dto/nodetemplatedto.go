@@ -0,0 +1,42 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
+
+// NodeTemplateDTO represents a node template for the admin API.
+// Server keys captured from the source node are never exposed.
+type NodeTemplateDTO struct {
+	ID          string         `json:"id" example:"ntpl_xK9mP2vL3nQ" description:"Node template ID"`
+	Name        string         `json:"name" example:"HK VLESS Reality"`
+	Description string         `json:"description,omitempty"`
+	Config      NodeConfigSpec `json:"config" description:"Protocol, route and DNS configuration applied to provisioned nodes"`
+	GroupSIDs   []string       `json:"group_sids,omitempty" example:"[\"rg_xK9mP2vL3nQ\"]" description:"Resource groups provisioned nodes join"`
+	CreatedAt   time.Time      `json:"created_at"`
+	UpdatedAt   time.Time      `json:"updated_at"`
+}
+
+// ToNodeTemplateDTO converts a node template entity to its DTO.
+// groupSIDs maps resource group IDs to SIDs; groups missing from it are omitted.
+func ToNodeTemplateDTO(t *node.NodeTemplate, groupSIDs map[uint]string) *NodeTemplateDTO {
+	if t == nil {
+		return nil
+	}
+
+	result := &NodeTemplateDTO{
+		ID:          t.SID(),
+		Name:        t.Name(),
+		Description: t.Description(),
+		Config:      ToNodeConfigSpec(t.Config(), false),
+		CreatedAt:   t.CreatedAt(),
+		UpdatedAt:   t.UpdatedAt(),
+	}
+	for _, gid := range t.GroupIDs() {
+		if sid, ok := groupSIDs[gid]; ok {
+			result.GroupSIDs = append(result.GroupSIDs, sid)
+		}
+	}
+	return result
+}
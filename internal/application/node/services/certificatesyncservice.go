@@ -0,0 +1,161 @@
+package services
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// CertificateSyncHub defines the hub operations needed to deliver certificates
+// to the node agents connected to this instance.
+type CertificateSyncHub interface {
+	NodeSyncHub
+	GetOnlineNodes() []uint
+}
+
+// CertificateSyncService delivers TLS certificates to node agents.
+// Each node receives the certificate covering its TLS server name when it connects,
+// and again whenever the certificate changes. Delivery state is kept per instance:
+// every instance reconciles the nodes connected to it, so certificates issued on one
+// instance reach nodes connected to another on the next reconcile pass.
+type CertificateSyncService struct {
+	certRepo node.CertificateRepository
+	nodeRepo node.NodeRepository
+	hub      CertificateSyncHub
+	logger   logger.Interface
+
+	// delivered holds the fingerprint last sent to each node (map[uint]string)
+	delivered sync.Map
+}
+
+// NewCertificateSyncService creates a new CertificateSyncService.
+func NewCertificateSyncService(
+	certRepo node.CertificateRepository,
+	nodeRepo node.NodeRepository,
+	hub CertificateSyncHub,
+	log logger.Interface,
+) *CertificateSyncService {
+	return &CertificateSyncService{
+		certRepo: certRepo,
+		nodeRepo: nodeRepo,
+		hub:      hub,
+		logger:   log,
+	}
+}
+
+// SyncToNode sends the certificate covering the node's server name, if any
+// (typically on connection). The certificate is sent even if it was delivered before.
+func (s *CertificateSyncService) SyncToNode(ctx context.Context, nodeID uint) error {
+	if !s.hub.IsNodeOnline(nodeID) {
+		return nil
+	}
+
+	n, err := s.nodeRepo.GetByID(ctx, nodeID)
+	if err != nil {
+		return fmt.Errorf("failed to get node: %w", err)
+	}
+	if n == nil {
+		return nil
+	}
+
+	certs, err := s.certRepo.ListAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	s.delivered.Delete(nodeID)
+	_, err = s.deliver(n, certs, biztime.NowUTC())
+	return err
+}
+
+// Reconcile sends changed certificates to every node connected to this instance
+// and returns the number of nodes that received one.
+func (s *CertificateSyncService) Reconcile(ctx context.Context) (int, error) {
+	nodeIDs := s.hub.GetOnlineNodes()
+	if len(nodeIDs) == 0 {
+		return 0, nil
+	}
+
+	certs, err := s.certRepo.ListAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list certificates: %w", err)
+	}
+	if len(certs) == 0 {
+		return 0, nil
+	}
+
+	nodes, err := s.nodeRepo.GetByIDs(ctx, nodeIDs)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	sent := 0
+	for _, n := range nodes {
+		ok, err := s.deliver(n, certs, now)
+		if err != nil {
+			continue
+		}
+		if ok {
+			sent++
+		}
+	}
+	return sent, nil
+}
+
+// deliver sends the certificate selected for the node unless the same certificate
+// was already delivered. Returns whether a message was sent.
+func (s *CertificateSyncService) deliver(n *node.Node, certs []*node.Certificate, now time.Time) (bool, error) {
+	serverName := n.TLSServerName()
+	if serverName == "" {
+		return false, nil
+	}
+	cert := node.SelectCertificate(certs, serverName, now)
+	if cert == nil {
+		return false, nil
+	}
+	if v, ok := s.delivered.Load(n.ID()); ok && v.(string) == cert.Fingerprint() {
+		return false, nil
+	}
+
+	msg := &dto.NodeHubMessage{
+		Type:      dto.NodeMsgTypeCertificateSync,
+		NodeID:    n.SID(),
+		Timestamp: now.Unix(),
+		Data:      dto.ToNodeCertificateSyncData(cert),
+	}
+	msgBytes, err := json.Marshal(msg)
+	if err != nil {
+		s.logger.Errorw("failed to marshal certificate sync message",
+			"node_id", n.ID(),
+			"error", err,
+		)
+		return false, err
+	}
+
+	if err := s.hub.SendMessageToNode(n.ID(), msgBytes); err != nil {
+		s.logger.Warnw("failed to send certificate to node",
+			"node_id", n.ID(),
+			"certificate_sid", cert.SID(),
+			"error", err,
+		)
+		return false, err
+	}
+
+	s.delivered.Store(n.ID(), cert.Fingerprint())
+	s.logger.Infow("certificate sent to node",
+		"node_id", n.ID(),
+		"node_sid", n.SID(),
+		"certificate_sid", cert.SID(),
+		"domain", cert.Domain(),
+		"server_name", serverName,
+	)
+	return true, nil
+}
@@ -0,0 +1,298 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/infrastructure/cache"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+const (
+	// DeviceLimitGracePeriod is how long a subscription may stay above its device limit
+	// before it is blocked. It absorbs devices that changed IP, whose old entry lingers
+	// in the online set until it goes stale.
+	DeviceLimitGracePeriod = 2 * time.Minute
+	// DeviceLimitBlockDuration is how long a subscription is removed from its nodes.
+	DeviceLimitBlockDuration = 10 * time.Minute
+)
+
+// OnlineDeviceCounter counts the distinct online IPs of subscriptions across all nodes.
+type OnlineDeviceCounter interface {
+	GetOnlineDeviceCounts(ctx context.Context, subscriptionIDs []uint) (map[uint]int, error)
+}
+
+// SubscriptionAccessNotifier removes subscriptions from, and restores them to, node agents cluster-wide.
+type SubscriptionAccessNotifier interface {
+	NotifySubscriptionActivation(ctx context.Context, sub *subscription.Subscription) error
+	NotifySubscriptionDeactivation(ctx context.Context, sub *subscription.Subscription) error
+}
+
+// SubscriptionBlockChecker reports subscriptions temporarily withheld from nodes,
+// e.g. for exceeding their device limit.
+type SubscriptionBlockChecker interface {
+	GetBlocked(ctx context.Context, subscriptionIDs []uint) (map[uint]bool, error)
+}
+
+// WithoutBlockedSubscriptions drops the subscriptions the checker reports as blocked. A nil
+// checker keeps all subscriptions, and so do lookup failures, so a Redis outage never
+// disconnects every user.
+func WithoutBlockedSubscriptions(ctx context.Context, checker SubscriptionBlockChecker, log logger.Interface, subscriptions []*subscription.Subscription) []*subscription.Subscription {
+	if checker == nil || len(subscriptions) == 0 {
+		return subscriptions
+	}
+
+	ids := make([]uint, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub != nil {
+			ids = append(ids, sub.ID())
+		}
+	}
+	blocked, err := checker.GetBlocked(ctx, ids)
+	if err != nil {
+		log.Warnw("failed to check blocked subscriptions, keeping all", "error", err)
+		return subscriptions
+	}
+	if len(blocked) == 0 {
+		return subscriptions
+	}
+
+	filtered := make([]*subscription.Subscription, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub != nil && !blocked[sub.ID()] {
+			filtered = append(filtered, sub)
+		}
+	}
+	return filtered
+}
+
+// DeviceLimitEnforcementService enforces the plan device limit across all nodes.
+// Nodes only see their own connections, so a user can exceed the limit by spreading
+// devices over several nodes. This service compares the cluster-wide online IP count
+// against the plan limit and, once the violation outlasts DeviceLimitGracePeriod,
+// removes the subscription from every node for DeviceLimitBlockDuration.
+// The subscription itself stays active; only node access is withdrawn.
+type DeviceLimitEnforcementService struct {
+	subscriptionRepo subscription.SubscriptionRepository
+	planRepo         subscription.PlanRepository
+	deviceCounter    OnlineDeviceCounter
+	store            cache.DeviceLimitStore
+	notifier         SubscriptionAccessNotifier
+	logger           logger.Interface
+}
+
+// NewDeviceLimitEnforcementService creates a new device limit enforcement service.
+func NewDeviceLimitEnforcementService(
+	subscriptionRepo subscription.SubscriptionRepository,
+	planRepo subscription.PlanRepository,
+	deviceCounter OnlineDeviceCounter,
+	store cache.DeviceLimitStore,
+	notifier SubscriptionAccessNotifier,
+	logger logger.Interface,
+) *DeviceLimitEnforcementService {
+	return &DeviceLimitEnforcementService{
+		subscriptionRepo: subscriptionRepo,
+		planRepo:         planRepo,
+		deviceCounter:    deviceCounter,
+		store:            store,
+		notifier:         notifier,
+		logger:           logger,
+	}
+}
+
+// CheckAndEnforce checks the cluster-wide device count of the given subscriptions
+// and blocks those that have exceeded their plan limit for longer than the grace period.
+func (s *DeviceLimitEnforcementService) CheckAndEnforce(ctx context.Context, subscriptionIDs []uint) error {
+	if len(subscriptionIDs) == 0 {
+		return nil
+	}
+
+	blocked, err := s.store.GetBlocked(ctx, subscriptionIDs)
+	if err != nil {
+		return err
+	}
+
+	ids := make([]uint, 0, len(subscriptionIDs))
+	for _, id := range subscriptionIDs {
+		if !blocked[id] {
+			ids = append(ids, id)
+		}
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+
+	counts, err := s.deviceCounter.GetOnlineDeviceCounts(ctx, ids)
+	if err != nil {
+		return err
+	}
+
+	// A single device can never exceed a limit, so only load subscriptions with more
+	withinLimit := make([]uint, 0, len(ids))
+	candidates := make([]uint, 0)
+	for _, id := range ids {
+		if counts[id] > 1 {
+			candidates = append(candidates, id)
+		} else {
+			withinLimit = append(withinLimit, id)
+		}
+	}
+
+	if len(candidates) > 0 {
+		subs, limits, err := s.loadSubscriptionLimits(ctx, candidates)
+		if err != nil {
+			return err
+		}
+
+		now := biztime.NowUTC()
+		for _, id := range candidates {
+			sub, ok := subs[id]
+			limit := 0
+			if ok {
+				limit = limits[sub.PlanID()]
+			}
+			if !ok || !sub.IsActive() || limit == 0 || counts[id] <= limit {
+				withinLimit = append(withinLimit, id)
+				continue
+			}
+
+			s.enforce(ctx, sub, counts[id], limit, now)
+		}
+	}
+
+	if err := s.store.ClearOverLimit(ctx, withinLimit); err != nil {
+		s.logger.Warnw("failed to clear device limit state", "error", err)
+	}
+	return nil
+}
+
+// enforce records the violation and blocks the subscription once the grace period has elapsed.
+func (s *DeviceLimitEnforcementService) enforce(ctx context.Context, sub *subscription.Subscription, deviceCount, limit int, now time.Time) {
+	since, err := s.store.MarkOverLimit(ctx, sub.ID(), now)
+	if err != nil {
+		s.logger.Errorw("failed to record device limit violation",
+			"subscription_id", sub.ID(),
+			"error", err,
+		)
+		return
+	}
+
+	if now.Sub(since) < DeviceLimitGracePeriod {
+		s.logger.Debugw("subscription over device limit, within grace period",
+			"subscription_id", sub.ID(),
+			"device_count", deviceCount,
+			"device_limit", limit,
+			"since", since,
+		)
+		return
+	}
+
+	until := now.Add(DeviceLimitBlockDuration)
+	added, err := s.store.Block(ctx, sub.ID(), until)
+	if err != nil {
+		s.logger.Errorw("failed to block subscription over device limit",
+			"subscription_id", sub.ID(),
+			"error", err,
+		)
+		return
+	}
+	if !added {
+		return // blocked concurrently by another report
+	}
+
+	if err := s.store.ClearOverLimit(ctx, []uint{sub.ID()}); err != nil {
+		s.logger.Warnw("failed to clear device limit state", "subscription_id", sub.ID(), "error", err)
+	}
+
+	s.logger.Warnw("subscription exceeded device limit, temporarily blocked",
+		"subscription_id", sub.ID(),
+		"subscription_sid", sub.SID(),
+		"device_count", deviceCount,
+		"device_limit", limit,
+		"blocked_until", until,
+	)
+
+	if err := s.notifier.NotifySubscriptionDeactivation(ctx, sub); err != nil {
+		s.logger.Errorw("failed to remove blocked subscription from nodes",
+			"subscription_id", sub.ID(),
+			"error", err,
+		)
+	}
+}
+
+// ReleaseExpiredBlocks restores subscriptions whose device limit block has expired
+// to their nodes. Returns the number of subscriptions restored.
+func (s *DeviceLimitEnforcementService) ReleaseExpiredBlocks(ctx context.Context) (int, error) {
+	ids, err := s.store.PopExpiredBlocks(ctx, biztime.NowUTC())
+	if err != nil && len(ids) == 0 {
+		return 0, err
+	}
+	if len(ids) == 0 {
+		return 0, nil
+	}
+
+	subs, getErr := s.subscriptionRepo.GetByIDs(ctx, ids)
+	if getErr != nil {
+		return 0, fmt.Errorf("failed to get subscriptions to release: %w", getErr)
+	}
+
+	released := 0
+	for _, id := range ids {
+		sub, ok := subs[id]
+		if !ok || !sub.IsActive() {
+			continue
+		}
+
+		if notifyErr := s.notifier.NotifySubscriptionActivation(ctx, sub); notifyErr != nil {
+			s.logger.Errorw("failed to restore subscription after device limit block",
+				"subscription_id", id,
+				"error", notifyErr,
+			)
+			continue
+		}
+
+		s.logger.Infow("device limit block released",
+			"subscription_id", id,
+			"subscription_sid", sub.SID(),
+		)
+		released++
+	}
+
+	return released, err
+}
+
+// GetBlocked returns which of the given subscriptions are blocked for exceeding the device limit.
+func (s *DeviceLimitEnforcementService) GetBlocked(ctx context.Context, subscriptionIDs []uint) (map[uint]bool, error) {
+	return s.store.GetBlocked(ctx, subscriptionIDs)
+}
+
+// loadSubscriptionLimits batch loads subscriptions and the device limits of their plans.
+func (s *DeviceLimitEnforcementService) loadSubscriptionLimits(ctx context.Context, ids []uint) (map[uint]*subscription.Subscription, map[uint]int, error) {
+	subs, err := s.subscriptionRepo.GetByIDs(ctx, ids)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	planIDSet := make(map[uint]struct{}, len(subs))
+	for _, sub := range subs {
+		planIDSet[sub.PlanID()] = struct{}{}
+	}
+	planIDs := make([]uint, 0, len(planIDSet))
+	for id := range planIDSet {
+		planIDs = append(planIDs, id)
+	}
+	if len(planIDs) == 0 {
+		return subs, nil, nil
+	}
+
+	plans, err := s.planRepo.GetByIDs(ctx, planIDs)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to get plans: %w", err)
+	}
+
+	return subs, dto.BuildPlanDeviceLimits(plans), nil
+}
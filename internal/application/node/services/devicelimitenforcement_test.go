@@ -0,0 +1,192 @@
+package services
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	subscriptionvo "github.com/orris-inc/orris/internal/domain/subscription/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type fakeDeviceCounter struct {
+	counts map[uint]int
+}
+
+func (c *fakeDeviceCounter) GetOnlineDeviceCounts(ctx context.Context, subscriptionIDs []uint) (map[uint]int, error) {
+	return c.counts, nil
+}
+
+// fakeDeviceLimitStore keeps over-limit streaks and blocks in memory.
+type fakeDeviceLimitStore struct {
+	overSince map[uint]time.Time
+	blocked   map[uint]time.Time
+}
+
+func newFakeDeviceLimitStore() *fakeDeviceLimitStore {
+	return &fakeDeviceLimitStore{overSince: make(map[uint]time.Time), blocked: make(map[uint]time.Time)}
+}
+
+func (s *fakeDeviceLimitStore) MarkOverLimit(ctx context.Context, subscriptionID uint, now time.Time) (time.Time, error) {
+	if since, ok := s.overSince[subscriptionID]; ok {
+		return since, nil
+	}
+	s.overSince[subscriptionID] = now
+	return now, nil
+}
+
+func (s *fakeDeviceLimitStore) ClearOverLimit(ctx context.Context, subscriptionIDs []uint) error {
+	for _, id := range subscriptionIDs {
+		delete(s.overSince, id)
+	}
+	return nil
+}
+
+func (s *fakeDeviceLimitStore) Block(ctx context.Context, subscriptionID uint, until time.Time) (bool, error) {
+	if _, ok := s.blocked[subscriptionID]; ok {
+		return false, nil
+	}
+	s.blocked[subscriptionID] = until
+	return true, nil
+}
+
+func (s *fakeDeviceLimitStore) GetBlocked(ctx context.Context, subscriptionIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	for _, id := range subscriptionIDs {
+		if _, ok := s.blocked[id]; ok {
+			result[id] = true
+		}
+	}
+	return result, nil
+}
+
+func (s *fakeDeviceLimitStore) PopExpiredBlocks(ctx context.Context, now time.Time) ([]uint, error) {
+	var ids []uint
+	for id, until := range s.blocked {
+		if !until.After(now) {
+			ids = append(ids, id)
+			delete(s.blocked, id)
+		}
+	}
+	return ids, nil
+}
+
+// fakeDeviceLimitPlanRepo only implements GetByIDs; other methods panic.
+type fakeDeviceLimitPlanRepo struct {
+	subscription.PlanRepository
+	plans []*subscription.Plan
+}
+
+func (r *fakeDeviceLimitPlanRepo) GetByIDs(ctx context.Context, ids []uint) ([]*subscription.Plan, error) {
+	return r.plans, nil
+}
+
+type recordingAccessNotifier struct {
+	activated   []uint
+	deactivated []uint
+}
+
+func (n *recordingAccessNotifier) NotifySubscriptionActivation(ctx context.Context, sub *subscription.Subscription) error {
+	n.activated = append(n.activated, sub.ID())
+	return nil
+}
+
+func (n *recordingAccessNotifier) NotifySubscriptionDeactivation(ctx context.Context, sub *subscription.Subscription) error {
+	n.deactivated = append(n.deactivated, sub.ID())
+	return nil
+}
+
+// newTestDeviceLimitService returns a service for subscription 1 on a plan allowing 2 devices.
+func newTestDeviceLimitService(t *testing.T, counts map[uint]int) (*DeviceLimitEnforcementService, *fakeDeviceLimitStore, *recordingAccessNotifier) {
+	t.Helper()
+
+	plan, err := subscription.NewPlan("Basic", "basic", "", subscriptionvo.PlanTypeNode)
+	require.NoError(t, err)
+	require.NoError(t, plan.SetID(1))
+	features := subscriptionvo.NewPlanFeatures(nil)
+	require.NoError(t, features.SetDeviceLimit(2))
+	require.NoError(t, plan.UpdateFeatures(features))
+
+	store := newFakeDeviceLimitStore()
+	notifier := &recordingAccessNotifier{}
+	svc := NewDeviceLimitEnforcementService(
+		&fakeAllocatorSubscriptionRepo{subs: map[uint]*subscription.Subscription{1: activeTestSubscription(t, 1)}},
+		&fakeDeviceLimitPlanRepo{plans: []*subscription.Plan{plan}},
+		&fakeDeviceCounter{counts: counts},
+		store, notifier, logger.NewLogger(),
+	)
+	return svc, store, notifier
+}
+
+func TestDeviceLimitEnforcementService_GracePeriod(t *testing.T) {
+	svc, store, notifier := newTestDeviceLimitService(t, map[uint]int{1: 3})
+
+	require.NoError(t, svc.CheckAndEnforce(context.Background(), []uint{1}))
+	assert.Contains(t, store.overSince, uint(1), "violation recorded")
+	assert.Empty(t, store.blocked, "not blocked within the grace period")
+	assert.Empty(t, notifier.deactivated)
+}
+
+func TestDeviceLimitEnforcementService_BlocksAfterGracePeriod(t *testing.T) {
+	svc, store, notifier := newTestDeviceLimitService(t, map[uint]int{1: 3})
+	store.overSince[1] = biztime.NowUTC().Add(-DeviceLimitGracePeriod - time.Second)
+
+	require.NoError(t, svc.CheckAndEnforce(context.Background(), []uint{1}))
+	require.Contains(t, store.blocked, uint(1))
+	assert.WithinDuration(t, biztime.NowUTC().Add(DeviceLimitBlockDuration), store.blocked[1], time.Minute)
+	assert.NotContains(t, store.overSince, uint(1), "streak cleared once blocked")
+	assert.Equal(t, []uint{1}, notifier.deactivated)
+
+	blocked, err := svc.GetBlocked(context.Background(), []uint{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]bool{1: true}, blocked)
+
+	// Blocked subscriptions are not checked again
+	require.NoError(t, svc.CheckAndEnforce(context.Background(), []uint{1}))
+	assert.Equal(t, []uint{1}, notifier.deactivated)
+}
+
+func TestDeviceLimitEnforcementService_Release(t *testing.T) {
+	t.Run("devices drop below the limit", func(t *testing.T) {
+		counter := map[uint]int{1: 3}
+		svc, store, notifier := newTestDeviceLimitService(t, counter)
+
+		require.NoError(t, svc.CheckAndEnforce(context.Background(), []uint{1}))
+		require.Contains(t, store.overSince, uint(1))
+
+		counter[1] = 2
+		require.NoError(t, svc.CheckAndEnforce(context.Background(), []uint{1}))
+		assert.NotContains(t, store.overSince, uint(1), "streak cleared, the grace period starts over")
+		assert.Empty(t, store.blocked)
+		assert.Empty(t, notifier.deactivated)
+	})
+
+	t.Run("expired blocks", func(t *testing.T) {
+		svc, store, notifier := newTestDeviceLimitService(t, nil)
+		store.blocked[1] = biztime.NowUTC().Add(-time.Second)
+		store.blocked[2] = biztime.NowUTC().Add(time.Minute)
+
+		released, err := svc.ReleaseExpiredBlocks(context.Background())
+		require.NoError(t, err)
+		assert.Equal(t, 1, released)
+		assert.Equal(t, []uint{1}, notifier.activated)
+		assert.Contains(t, store.blocked, uint(2), "blocks that have not expired are kept")
+	})
+}
+
+func TestWithoutBlockedSubscriptions(t *testing.T) {
+	store := newFakeDeviceLimitStore()
+	store.blocked[2] = biztime.NowUTC().Add(time.Minute)
+	subs := []*subscription.Subscription{activeTestSubscription(t, 1), activeTestSubscription(t, 2)}
+
+	got := WithoutBlockedSubscriptions(context.Background(), store, logger.NewLogger(), subs)
+	require.Len(t, got, 1)
+	assert.Equal(t, uint(1), got[0].ID())
+
+	assert.Equal(t, subs, WithoutBlockedSubscriptions(context.Background(), nil, logger.NewLogger(), subs))
+}
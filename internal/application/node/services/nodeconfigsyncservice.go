@@ -341,7 +341,7 @@ func (s *NodeConfigSyncService) resolveReferencedNodes(
 
 	serverKeyFunc := func(refNode *node.Node) string {
 		if refNode.Protocol().IsShadowsocks() {
-			return vo.GenerateShadowsocksServerPassword(refNode.TokenHash(), refNode.EncryptionConfig().Method())
+			return refNode.EncryptionConfig().ForwardingPassword(refNode.TokenHash())
 		}
 		if refNode.Protocol().IsTrojan() {
 			return vo.GenerateTrojanServerPassword(refNode.TokenHash())
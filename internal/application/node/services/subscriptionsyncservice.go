@@ -25,9 +25,21 @@ type SubscriptionSyncService struct {
 	resourceGroupRepo resource.Repository
 	hub               NodeSyncHub
 	eventPublisher    pubsub.SubscriptionEventPublisher
+	blockChecker      SubscriptionBlockChecker
+	peerAllocator     WireGuardPeerAllocator
 	logger            logger.Interface
 }
 
+// WireGuardPeerAllocator allocates tunnel addresses and keypairs of WireGuard nodes to subscriptions.
+type WireGuardPeerAllocator interface {
+	// AllocatePeers returns the peers of the subscriptions on a node, allocating missing
+	// ones. When the pool is full, the peers allocated so far are returned together with an
+	// error wrapping node.ErrWireGuardPoolExhausted.
+	AllocatePeers(ctx context.Context, nodeID uint, cfg *vo.WireGuardConfig, subscriptionIDs []uint) (map[uint]*node.WireGuardPeer, error)
+	// Peers returns the peers allocated on a node without allocating new ones.
+	Peers(ctx context.Context, nodeID uint) (map[uint]*node.WireGuardPeer, error)
+}
+
 // NewSubscriptionSyncService creates a new SubscriptionSyncService.
 func NewSubscriptionSyncService(
 	nodeRepo node.NodeRepository,
@@ -53,6 +65,56 @@ func (s *SubscriptionSyncService) SetEventPublisher(publisher pubsub.Subscriptio
 	s.eventPublisher = publisher
 }
 
+// SetBlockChecker sets the checker used to withhold blocked subscriptions from nodes.
+func (s *SubscriptionSyncService) SetBlockChecker(checker SubscriptionBlockChecker) {
+	s.blockChecker = checker
+}
+
+// SetWireGuardPeerAllocator sets the allocator of WireGuard peer addresses.
+// Without it WireGuard nodes get no peers.
+func (s *SubscriptionSyncService) SetWireGuardPeerAllocator(allocator WireGuardPeerAllocator) {
+	s.peerAllocator = allocator
+}
+
+// wireGuardPeers returns the peers of the subscriptions on a WireGuard node.
+// Removed subscriptions only look up their existing peer, so a removal never allocates one.
+// Allocation failures are logged rather than failing the sync, so the node still receives the
+// peers that have an address and drops the ones that are gone.
+func (s *SubscriptionSyncService) wireGuardPeers(ctx context.Context, n *node.Node, subscriptions []*subscription.Subscription, changeType string) map[uint]*node.WireGuardPeer {
+	if s.peerAllocator == nil {
+		return nil
+	}
+
+	if changeType == dto.SubscriptionChangeRemoved {
+		peers, err := s.peerAllocator.Peers(ctx, n.ID())
+		if err != nil {
+			s.logger.Errorw("failed to get wireguard peers",
+				"node_id", n.ID(),
+				"error", err,
+			)
+		}
+		return peers
+	}
+
+	subIDs := make([]uint, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub != nil {
+			subIDs = append(subIDs, sub.ID())
+		}
+	}
+
+	peers, err := s.peerAllocator.AllocatePeers(ctx, n.ID(), n.WireGuardConfig(), subIDs)
+	if err != nil {
+		s.logger.Errorw("failed to allocate wireguard peers",
+			"node_id", n.ID(),
+			"allocated", len(peers),
+			"subscriptions", len(subIDs),
+			"error", err,
+		)
+	}
+	return peers
+}
+
 // NotifyPlanFeaturesChanged handles plan features changes by re-syncing subscriptions
 // to all affected nodes. This ensures device limits and other plan-derived settings
 // are propagated to node agents.
@@ -160,6 +222,15 @@ func (s *SubscriptionSyncService) NotifySubscriptionChange(
 		"change_type", changeType,
 	)
 
+	// A blocked subscription must not be re-added until its block is released
+	if changeType != dto.SubscriptionChangeRemoved && len(WithoutBlockedSubscriptions(ctx, s.blockChecker, s.logger, []*subscription.Subscription{sub})) == 0 {
+		s.logger.Debugw("subscription is blocked, skipping notification",
+			"subscription_id", sub.ID(),
+			"change_type", changeType,
+		)
+		return nil
+	}
+
 	// Get resource groups for this plan
 	groups, err := s.resourceGroupRepo.GetByPlanID(ctx, sub.PlanID())
 	if err != nil {
@@ -244,9 +315,16 @@ func (s *SubscriptionSyncService) NotifySubscriptionChange(
 		}
 
 		// Build subscription info for this node
-		subscriptionInfo := s.buildSubscriptionInfo(sub, hmacSecret, encryptionMethod, deviceLimit)
+		subscriptionInfos := []dto.NodeSubscriptionInfo{s.buildSubscriptionInfo(sub, hmacSecret, encryptionMethod, deviceLimit)}
+		if n.Protocol().IsWireGuard() {
+			subs := []*subscription.Subscription{sub}
+			subscriptionInfos = dto.ApplyWireGuardPeers(subscriptionInfos, subs, s.wireGuardPeers(ctx, n, subs, changeType))
+			if len(subscriptionInfos) == 0 {
+				continue
+			}
+		}
 
-		if err := s.sendSubscriptionSync(n, changeType, []dto.NodeSubscriptionInfo{subscriptionInfo}); err != nil {
+		if err := s.sendSubscriptionSync(n, changeType, subscriptionInfos); err != nil {
 			s.logger.Warnw("failed to send subscription sync to node",
 				"node_id", n.ID(),
 				"node_sid", n.SID(),
@@ -411,6 +489,11 @@ func (s *SubscriptionSyncService) sendSubscriptionSync(
 		Timestamp:     biztime.NowUTC().Unix(),
 	}
 
+	// SS2022 user keys are iPSKs; include the server PSK so agents can detect a rotated key
+	if n.Protocol().IsShadowsocks() {
+		syncData.ServerKey = n.EncryptionConfig().EffectiveServerKey(n.TokenHash())
+	}
+
 	msg := &dto.NodeHubMessage{
 		Type:      dto.NodeMsgTypeSubscriptionSync,
 		NodeID:    n.SID(),
@@ -480,15 +563,19 @@ func (s *SubscriptionSyncService) FullSyncSubscriptionsToNode(
 	// Get HMAC secret
 	hmacSecret := config.Get().Auth.JWT.Secret
 
+	subscriptions = WithoutBlockedSubscriptions(ctx, s.blockChecker, s.logger, subscriptions)
+
 	// Batch load plan device limits
 	planDeviceLimits := s.loadPlanDeviceLimits(ctx, subscriptions)
 
 	// Convert subscriptions to NodeSubscriptionInfo
 	subscriptionInfos := make([]dto.NodeSubscriptionInfo, 0, len(subscriptions))
+	active := make([]*subscription.Subscription, 0, len(subscriptions))
 	for _, sub := range subscriptions {
 		if sub == nil || !sub.IsActive() {
 			continue
 		}
+		active = append(active, sub)
 		deviceLimit := 0
 		if planDeviceLimits != nil {
 			if limit, ok := planDeviceLimits[sub.PlanID()]; ok {
@@ -499,6 +586,11 @@ func (s *SubscriptionSyncService) FullSyncSubscriptionsToNode(
 		subscriptionInfos = append(subscriptionInfos, info)
 	}
 
+	if n.Protocol().IsWireGuard() {
+		peers := s.wireGuardPeers(ctx, n, active, dto.SubscriptionChangeAdded)
+		subscriptionInfos = dto.ApplyWireGuardPeers(subscriptionInfos, active, peers)
+	}
+
 	// Add node-to-node forwarding user
 	var nodeForwardingPassword string
 	if n.Protocol().IsTrojan() {
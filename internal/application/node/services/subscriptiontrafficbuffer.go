@@ -5,9 +5,11 @@ import (
 	"sync"
 	"time"
 
+	"github.com/orris-inc/orris/internal/domain/node"
 	"github.com/orris-inc/orris/internal/infrastructure/cache"
 	"github.com/orris-inc/orris/internal/shared/goroutine"
 	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/metrics"
 )
 
 const (
@@ -22,6 +24,8 @@ const (
 )
 
 // SubscriptionTrafficEntry represents a single subscription traffic record with retry tracking.
+// Upload/Download are raw bytes as reported by the node agent; the node traffic
+// multiplier is applied when the entry is flushed.
 type SubscriptionTrafficEntry struct {
 	NodeID         uint
 	SubscriptionID uint
@@ -42,6 +46,11 @@ type SubscriptionTrafficCacheWriter interface {
 	BatchIncrementSubscriptionTraffic(ctx context.Context, entries []cache.SubscriptionTrafficBatchEntry) error
 }
 
+// NodeTrafficMultiplierResolver resolves per-node billing rates for buffered traffic.
+type NodeTrafficMultiplierResolver interface {
+	GetTrafficMultipliers(ctx context.Context, nodeIDs []uint) (map[uint]float64, error)
+}
+
 // subscriptionBufferShard is a single shard containing traffic entries with its own mutex.
 type subscriptionBufferShard struct {
 	mu      sync.Mutex
@@ -52,6 +61,7 @@ type subscriptionBufferShard struct {
 type SubscriptionTrafficBuffer struct {
 	shards      [SubscriptionTrafficNumShards]*subscriptionBufferShard
 	cache       SubscriptionTrafficCacheWriter
+	multipliers NodeTrafficMultiplierResolver
 	logger      logger.Interface
 	flushTicker *time.Ticker
 	done        chan struct{}
@@ -59,9 +69,11 @@ type SubscriptionTrafficBuffer struct {
 }
 
 // NewSubscriptionTrafficBuffer creates a new SubscriptionTrafficBuffer instance.
-func NewSubscriptionTrafficBuffer(cache SubscriptionTrafficCacheWriter, log logger.Interface) *SubscriptionTrafficBuffer {
+// multipliers may be nil, in which case all traffic is billed 1:1.
+func NewSubscriptionTrafficBuffer(cache SubscriptionTrafficCacheWriter, multipliers NodeTrafficMultiplierResolver, log logger.Interface) *SubscriptionTrafficBuffer {
 	b := &SubscriptionTrafficBuffer{
 		cache:       cache,
+		multipliers: multipliers,
 		logger:      log,
 		flushTicker: time.NewTicker(SubscriptionTrafficFlushInterval),
 		done:        make(chan struct{}),
@@ -89,6 +101,8 @@ func (b *SubscriptionTrafficBuffer) AddTraffic(nodeID, subscriptionID uint, uplo
 		return
 	}
 
+	metrics.NodeTraffic.Add(nodeID, upload, download)
+
 	key := subscriptionTrafficKey{
 		NodeID:         nodeID,
 		SubscriptionID: subscriptionID,
@@ -176,6 +190,32 @@ func (b *SubscriptionTrafficBuffer) flush() {
 		return
 	}
 
+	// Resolve node billing rates once per flush. On failure, keep the entries
+	// for retry rather than billing them at the wrong rate.
+	multipliers, err := b.resolveMultipliers(ctx, allEntries)
+	if err != nil {
+		for _, entry := range allEntries {
+			entry.RetryCount++
+			if entry.RetryCount >= SubscriptionTrafficMaxRetryCount {
+				b.logger.Errorw("subscription traffic data dropped after max retries",
+					"node_id", entry.NodeID,
+					"subscription_id", entry.SubscriptionID,
+					"upload", entry.Upload,
+					"download", entry.Download,
+					"retry_count", entry.RetryCount,
+					"error", err,
+				)
+				continue
+			}
+			b.reAddEntry(entry)
+		}
+		b.logger.Warnw("failed to resolve node traffic multipliers, will retry",
+			"entry_count", len(allEntries),
+			"error", err,
+		)
+		return
+	}
+
 	// Phase 2: Batch write in chunks to avoid oversized pipelines
 	flushedCount := 0
 	failedCount := 0
@@ -190,11 +230,14 @@ func (b *SubscriptionTrafficBuffer) flush() {
 		// Convert to cache batch entry slice
 		batchValues := make([]cache.SubscriptionTrafficBatchEntry, len(batch))
 		for i, e := range batch {
+			multiplier := multiplierFor(multipliers, e.NodeID)
 			batchValues[i] = cache.SubscriptionTrafficBatchEntry{
 				NodeID:         e.NodeID,
 				SubscriptionID: e.SubscriptionID,
-				Upload:         e.Upload,
-				Download:       e.Download,
+				Upload:         node.ApplyTrafficMultiplier(e.Upload, multiplier),
+				Download:       node.ApplyTrafficMultiplier(e.Download, multiplier),
+				RawUpload:      e.Upload,
+				RawDownload:    e.Download,
 			}
 		}
 
@@ -235,6 +278,33 @@ func (b *SubscriptionTrafficBuffer) flush() {
 	}
 }
 
+// resolveMultipliers returns the billing rate for each node present in entries.
+func (b *SubscriptionTrafficBuffer) resolveMultipliers(ctx context.Context, entries []*SubscriptionTrafficEntry) (map[uint]float64, error) {
+	if b.multipliers == nil {
+		return nil, nil
+	}
+
+	seen := make(map[uint]struct{})
+	nodeIDs := make([]uint, 0)
+	for _, e := range entries {
+		if _, ok := seen[e.NodeID]; ok {
+			continue
+		}
+		seen[e.NodeID] = struct{}{}
+		nodeIDs = append(nodeIDs, e.NodeID)
+	}
+
+	return b.multipliers.GetTrafficMultipliers(ctx, nodeIDs)
+}
+
+// multiplierFor returns the billing rate for a node, defaulting to 1:1 for unknown nodes.
+func multiplierFor(multipliers map[uint]float64, nodeID uint) float64 {
+	if m, ok := multipliers[nodeID]; ok {
+		return m
+	}
+	return node.DefaultTrafficMultiplier
+}
+
 // reAddEntry re-adds a failed entry back to its shard for retry.
 func (b *SubscriptionTrafficBuffer) reAddEntry(entry *SubscriptionTrafficEntry) {
 	key := subscriptionTrafficKey{
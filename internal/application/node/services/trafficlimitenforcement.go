@@ -19,7 +19,8 @@ type SubscriptionDeactivationNotifier interface {
 
 // NodeTrafficLimitEnforcementService enforces traffic limits for node subscriptions.
 // When a subscription's traffic exceeds its plan limit, this service automatically
-// suspends the subscription.
+// suspends the subscription. Usage is compared in billed bytes, i.e. with each
+// node's traffic multiplier applied by SubscriptionTrafficBuffer.
 type NodeTrafficLimitEnforcementService struct {
 	subscriptionRepo     subscription.SubscriptionRepository
 	usageStatsRepo       subscription.SubscriptionUsageStatsRepository
@@ -0,0 +1,279 @@
+package services
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+const (
+	// wireGuardPoolAlertInterval limits pool exhaustion alerts to one per node per interval.
+	wireGuardPoolAlertInterval = time.Hour
+	// maxWireGuardAllocationAttempts bounds the retries when a concurrent allocation
+	// takes the same address.
+	maxWireGuardAllocationAttempts = 3
+)
+
+// WireGuardPoolAlert describes a WireGuard node whose address pool is exhausted.
+type WireGuardPoolAlert struct {
+	NodeID      uint
+	NodeSID     string
+	NodeName    string
+	AddressPool string
+	Capacity    int // peer addresses in the pool
+	Pending     int // subscriptions left without an address
+	At          time.Time
+}
+
+// WireGuardPoolAlerter notifies administrators about exhausted WireGuard address pools.
+type WireGuardPoolAlerter interface {
+	AlertWireGuardPoolExhausted(ctx context.Context, alert WireGuardPoolAlert) error
+}
+
+// WireGuardAddressAllocator assigns tunnel addresses from the address pool of WireGuard nodes
+// to subscriptions and stores them together with a random peer keypair, so a subscription keeps
+// its address and key on a node until they are released. Addresses of subscriptions that are no
+// longer active (or were deleted) are released when the pool runs full, and the lowest free
+// address is always handed out first, so released addresses are reused before new ones. When
+// the pool cannot hold a subscription even after that, administrators are alerted and callers
+// get node.ErrWireGuardPoolExhausted.
+type WireGuardAddressAllocator struct {
+	peerRepo         node.WireGuardPeerRepository
+	nodeRepo         node.NodeRepository
+	subscriptionRepo subscription.SubscriptionRepository
+	alerter          WireGuardPoolAlerter
+	logger           logger.Interface
+
+	alertMu     sync.Mutex
+	lastAlertAt map[uint]time.Time // node ID -> last pool exhaustion alert
+}
+
+// NewWireGuardAddressAllocator creates a new WireGuard address allocator.
+func NewWireGuardAddressAllocator(
+	peerRepo node.WireGuardPeerRepository,
+	nodeRepo node.NodeRepository,
+	subscriptionRepo subscription.SubscriptionRepository,
+	logger logger.Interface,
+) *WireGuardAddressAllocator {
+	return &WireGuardAddressAllocator{
+		peerRepo:         peerRepo,
+		nodeRepo:         nodeRepo,
+		subscriptionRepo: subscriptionRepo,
+		logger:           logger,
+		lastAlertAt:      make(map[uint]time.Time),
+	}
+}
+
+// SetAlerter sets the alerter notified when a node's address pool is exhausted (optional).
+func (a *WireGuardAddressAllocator) SetAlerter(alerter WireGuardPoolAlerter) {
+	a.alerter = alerter
+}
+
+// Peers returns the peers allocated on a node, keyed by subscription ID.
+func (a *WireGuardAddressAllocator) Peers(ctx context.Context, nodeID uint) (map[uint]*node.WireGuardPeer, error) {
+	return a.peerRepo.ListByNodeID(ctx, nodeID)
+}
+
+// AllocatePeers returns the peers of the given subscriptions on a node keyed by subscription ID,
+// allocating a free address of the node's pool and a new keypair to subscriptions that have none.
+// If the pool cannot hold every subscription, the peers allocated so far are returned together
+// with an error wrapping node.ErrWireGuardPoolExhausted.
+func (a *WireGuardAddressAllocator) AllocatePeers(ctx context.Context, nodeID uint, cfg *vo.WireGuardConfig, subscriptionIDs []uint) (map[uint]*node.WireGuardPeer, error) {
+	if cfg == nil || len(subscriptionIDs) == 0 {
+		return map[uint]*node.WireGuardPeer{}, nil
+	}
+
+	for attempt := 1; ; attempt++ {
+		result, pending, err := a.allocate(ctx, nodeID, cfg, subscriptionIDs)
+		if err == nil || !errors.IsConflictError(err) || attempt == maxWireGuardAllocationAttempts {
+			if err != nil {
+				return result, err
+			}
+			if pending > 0 {
+				a.alertPoolExhausted(nodeID, cfg, pending)
+				return result, fmt.Errorf("%w: node %d has no free address for %d subscription(s)",
+					node.ErrWireGuardPoolExhausted, nodeID, pending)
+			}
+			return result, nil
+		}
+		// Another request took the address concurrently; reload the allocations and retry
+		a.logger.Debugw("wireguard address allocation conflict, retrying",
+			"node_id", nodeID,
+			"attempt", attempt,
+		)
+	}
+}
+
+// allocate runs one allocation pass. Returns the allocated peers and the number of
+// subscriptions the pool had no room for.
+func (a *WireGuardAddressAllocator) allocate(ctx context.Context, nodeID uint, cfg *vo.WireGuardConfig, subscriptionIDs []uint) (map[uint]*node.WireGuardPeer, int, error) {
+	allocated, err := a.peerRepo.ListByNodeID(ctx, nodeID)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	// Release addresses outside the current pool, e.g. after the pool was changed
+	var stale []uint
+	for subID, peer := range allocated {
+		if !cfg.IsPeerAddress(peer.Address()) {
+			stale = append(stale, subID)
+			delete(allocated, subID)
+		}
+	}
+	if len(stale) > 0 {
+		if err := a.peerRepo.Delete(ctx, nodeID, stale); err != nil {
+			return nil, 0, err
+		}
+		a.logger.Infow("released wireguard addresses outside the address pool",
+			"node_id", nodeID,
+			"address_pool", cfg.AddressPool(),
+			"count", len(stale),
+		)
+	}
+
+	result := make(map[uint]*node.WireGuardPeer, len(subscriptionIDs))
+	requested := make(map[uint]bool, len(subscriptionIDs))
+	var pending []uint
+	for _, subID := range subscriptionIDs {
+		if subID == 0 || requested[subID] {
+			continue
+		}
+		requested[subID] = true
+		if peer, ok := allocated[subID]; ok {
+			result[subID] = peer
+		} else {
+			pending = append(pending, subID)
+		}
+	}
+	if len(pending) == 0 {
+		return result, 0, nil
+	}
+
+	used := make(map[string]bool, len(allocated))
+	for _, peer := range allocated {
+		used[peer.Address()] = true
+	}
+
+	free := cfg.FreePeerAddresses(used, len(pending))
+	if len(free) < len(pending) {
+		released, err := a.releaseInactive(ctx, nodeID, allocated, requested)
+		if err != nil {
+			return nil, 0, err
+		}
+		for _, address := range released {
+			delete(used, address)
+		}
+		free = cfg.FreePeerAddresses(used, len(pending))
+	}
+
+	for i, subID := range pending {
+		if i >= len(free) {
+			return result, len(pending) - i, nil
+		}
+		peer, err := node.NewWireGuardPeer(subID, free[i])
+		if err != nil {
+			return result, 0, err
+		}
+		if err := a.peerRepo.Create(ctx, nodeID, peer); err != nil {
+			return result, 0, err
+		}
+		result[subID] = peer
+	}
+
+	return result, 0, nil
+}
+
+// releaseInactive releases the addresses held on a node by subscriptions that were deleted or are
+// no longer active, skipping the requested ones. Returns the released addresses.
+func (a *WireGuardAddressAllocator) releaseInactive(ctx context.Context, nodeID uint, allocated map[uint]*node.WireGuardPeer, requested map[uint]bool) ([]string, error) {
+	candidates := make([]uint, 0, len(allocated))
+	for subID := range allocated {
+		if !requested[subID] {
+			candidates = append(candidates, subID)
+		}
+	}
+	if len(candidates) == 0 {
+		return nil, nil
+	}
+
+	subs, err := a.subscriptionRepo.GetByIDs(ctx, candidates)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get subscriptions: %w", err)
+	}
+
+	var releasedIDs []uint
+	var released []string
+	for _, subID := range candidates {
+		if sub := subs[subID]; sub != nil && sub.IsActive() {
+			continue
+		}
+		releasedIDs = append(releasedIDs, subID)
+		released = append(released, allocated[subID].Address())
+	}
+	if len(releasedIDs) == 0 {
+		return nil, nil
+	}
+
+	if err := a.peerRepo.Delete(ctx, nodeID, releasedIDs); err != nil {
+		return nil, err
+	}
+	a.logger.Infow("released wireguard addresses of inactive subscriptions",
+		"node_id", nodeID,
+		"count", len(releasedIDs),
+	)
+	return released, nil
+}
+
+// alertPoolExhausted logs the exhausted pool and alerts administrators, at most once per node
+// per wireGuardPoolAlertInterval.
+func (a *WireGuardAddressAllocator) alertPoolExhausted(nodeID uint, cfg *vo.WireGuardConfig, pending int) {
+	a.logger.Errorw("wireguard address pool exhausted",
+		"node_id", nodeID,
+		"address_pool", cfg.AddressPool(),
+		"capacity", cfg.PeerCapacity(),
+		"pending", pending,
+	)
+
+	if a.alerter == nil {
+		return
+	}
+
+	now := biztime.NowUTC()
+	a.alertMu.Lock()
+	if last, ok := a.lastAlertAt[nodeID]; ok && now.Sub(last) < wireGuardPoolAlertInterval {
+		a.alertMu.Unlock()
+		return
+	}
+	a.lastAlertAt[nodeID] = now
+	a.alertMu.Unlock()
+
+	alert := WireGuardPoolAlert{
+		NodeID:      nodeID,
+		AddressPool: cfg.AddressPool(),
+		Capacity:    cfg.PeerCapacity(),
+		Pending:     pending,
+		At:          now,
+	}
+	goroutine.SafeGo(a.logger, "wireguard-pool-exhausted-alert", func() {
+		ctx := context.Background()
+		if n, err := a.nodeRepo.GetByID(ctx, nodeID); err == nil && n != nil {
+			alert.NodeSID = n.SID()
+			alert.NodeName = n.Name()
+		}
+		if err := a.alerter.AlertWireGuardPoolExhausted(ctx, alert); err != nil {
+			a.logger.Warnw("failed to send wireguard pool exhausted alert",
+				"node_id", nodeID,
+				"error", err,
+			)
+		}
+	})
+}
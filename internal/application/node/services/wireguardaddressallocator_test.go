@@ -0,0 +1,206 @@
+package services
+
+import (
+	"context"
+	stderrors "errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// fakeWireGuardPeerRepo keeps the peers of a single node in memory.
+type fakeWireGuardPeerRepo struct {
+	peers map[uint]*node.WireGuardPeer
+}
+
+func (r *fakeWireGuardPeerRepo) ListByNodeID(ctx context.Context, nodeID uint) (map[uint]*node.WireGuardPeer, error) {
+	result := make(map[uint]*node.WireGuardPeer, len(r.peers))
+	for subID, peer := range r.peers {
+		result[subID] = peer
+	}
+	return result, nil
+}
+
+func (r *fakeWireGuardPeerRepo) Create(ctx context.Context, nodeID uint, peer *node.WireGuardPeer) error {
+	for subID, taken := range r.peers {
+		if subID == peer.SubscriptionID() || taken.Address() == peer.Address() {
+			return errors.NewConflictError("wireguard peer address is already allocated")
+		}
+	}
+	r.peers[peer.SubscriptionID()] = peer
+	return nil
+}
+
+func (r *fakeWireGuardPeerRepo) Delete(ctx context.Context, nodeID uint, subscriptionIDs []uint) error {
+	for _, subID := range subscriptionIDs {
+		delete(r.peers, subID)
+	}
+	return nil
+}
+
+// fakeAllocatorSubscriptionRepo only implements GetByIDs; other methods panic.
+type fakeAllocatorSubscriptionRepo struct {
+	subscription.SubscriptionRepository
+	subs map[uint]*subscription.Subscription
+}
+
+func (r *fakeAllocatorSubscriptionRepo) GetByIDs(ctx context.Context, ids []uint) (map[uint]*subscription.Subscription, error) {
+	result := make(map[uint]*subscription.Subscription)
+	for _, id := range ids {
+		if sub, ok := r.subs[id]; ok {
+			result[id] = sub
+		}
+	}
+	return result, nil
+}
+
+// fakeAllocatorNodeRepo only implements GetByID; other methods panic.
+type fakeAllocatorNodeRepo struct {
+	node.NodeRepository
+}
+
+func (r *fakeAllocatorNodeRepo) GetByID(ctx context.Context, id uint) (*node.Node, error) {
+	return nil, errors.NewNotFoundError("node not found")
+}
+
+type recordingPoolAlerter struct {
+	alerts chan WireGuardPoolAlert
+}
+
+func (a *recordingPoolAlerter) AlertWireGuardPoolExhausted(ctx context.Context, alert WireGuardPoolAlert) error {
+	a.alerts <- alert
+	return nil
+}
+
+// newTestAllocator returns an allocator for a /29 pool, which holds 5 peers (10.8.0.2-10.8.0.6).
+func newTestAllocator(t *testing.T, peers map[uint]string, subs map[uint]*subscription.Subscription) (*WireGuardAddressAllocator, *vo.WireGuardConfig, *fakeWireGuardPeerRepo) {
+	t.Helper()
+
+	key, err := vo.GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+	cfg, err := vo.NewWireGuardConfig(key, "10.8.0.0/29", 0, "", 0)
+	require.NoError(t, err)
+
+	peerRepo := &fakeWireGuardPeerRepo{peers: make(map[uint]*node.WireGuardPeer)}
+	for subID, address := range peers {
+		peer, err := node.NewWireGuardPeer(subID, address)
+		require.NoError(t, err)
+		peerRepo.peers[subID] = peer
+	}
+	allocator := NewWireGuardAddressAllocator(peerRepo, &fakeAllocatorNodeRepo{},
+		&fakeAllocatorSubscriptionRepo{subs: subs}, logger.NewLogger())
+	return allocator, &cfg, peerRepo
+}
+
+// peerAddresses maps peers to their tunnel addresses.
+func peerAddresses(peers map[uint]*node.WireGuardPeer) map[uint]string {
+	result := make(map[uint]string, len(peers))
+	for subID, peer := range peers {
+		result[subID] = peer.Address()
+	}
+	return result
+}
+
+func activeTestSubscription(t *testing.T, id uint) *subscription.Subscription {
+	t.Helper()
+
+	now := time.Now()
+	sub, err := subscription.NewSubscription(1, 1, now.Add(-time.Hour), now.Add(time.Hour), false, nil)
+	require.NoError(t, err)
+	require.NoError(t, sub.SetID(id))
+	require.NoError(t, sub.Activate())
+	return sub
+}
+
+func TestWireGuardAddressAllocator_KeepsAndAllocates(t *testing.T) {
+	allocator, cfg, peerRepo := newTestAllocator(t, map[uint]string{
+		100: "10.8.0.3",
+		// Outside the current pool, e.g. allocated before the pool was changed
+		200: "10.9.0.2",
+	}, nil)
+	existing := peerRepo.peers[100]
+
+	// Subscription IDs far beyond the pool size still get an address
+	got, err := allocator.AllocatePeers(context.Background(), 1, cfg, []uint{100, 200, 5000, 5000})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]string{100: "10.8.0.3", 200: "10.8.0.2", 5000: "10.8.0.4"}, peerAddresses(got))
+	assert.Equal(t, got, peerRepo.peers)
+	assert.Same(t, existing, got[100], "existing peers keep their keypair")
+	assert.NotEqual(t, got[200].PrivateKey(), got[5000].PrivateKey())
+
+	// Peers are stable across calls
+	again, err := allocator.AllocatePeers(context.Background(), 1, cfg, []uint{5000})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]*node.WireGuardPeer{5000: got[5000]}, again)
+}
+
+func TestWireGuardAddressAllocator_ReusesReleasedAddresses(t *testing.T) {
+	allocator, cfg, peerRepo := newTestAllocator(t, map[uint]string{
+		1: "10.8.0.2",
+		2: "10.8.0.3", // inactive
+		3: "10.8.0.4", // deleted
+		4: "10.8.0.5",
+		5: "10.8.0.6",
+	}, map[uint]*subscription.Subscription{
+		1: activeTestSubscription(t, 1),
+		2: func() *subscription.Subscription {
+			sub, err := subscription.NewSubscription(1, 1, time.Now(), time.Now().Add(time.Hour), false, nil)
+			require.NoError(t, err)
+			return sub
+		}(),
+		4: activeTestSubscription(t, 4),
+		5: activeTestSubscription(t, 5),
+	})
+
+	got, err := allocator.AllocatePeers(context.Background(), 1, cfg, []uint{6, 7})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]string{6: "10.8.0.3", 7: "10.8.0.4"}, peerAddresses(got))
+	assert.Equal(t, map[uint]string{
+		1: "10.8.0.2", 4: "10.8.0.5", 5: "10.8.0.6", 6: "10.8.0.3", 7: "10.8.0.4",
+	}, peerAddresses(peerRepo.peers))
+}
+
+func TestWireGuardAddressAllocator_PoolExhausted(t *testing.T) {
+	subs := make(map[uint]*subscription.Subscription)
+	peers := make(map[uint]string)
+	for i, address := range []string{"10.8.0.2", "10.8.0.3", "10.8.0.4", "10.8.0.5"} {
+		id := uint(i + 1)
+		subs[id] = activeTestSubscription(t, id)
+		peers[id] = address
+	}
+	allocator, cfg, _ := newTestAllocator(t, peers, subs)
+	alerter := &recordingPoolAlerter{alerts: make(chan WireGuardPoolAlert, 2)}
+	allocator.SetAlerter(alerter)
+
+	got, err := allocator.AllocatePeers(context.Background(), 1, cfg, []uint{1, 10, 11, 12})
+	require.Error(t, err)
+	assert.True(t, stderrors.Is(err, node.ErrWireGuardPoolExhausted))
+	assert.Equal(t, map[uint]string{1: "10.8.0.2", 10: "10.8.0.6"}, peerAddresses(got), "peers allocated so far are returned")
+
+	select {
+	case alert := <-alerter.alerts:
+		assert.Equal(t, uint(1), alert.NodeID)
+		assert.Equal(t, "10.8.0.0/29", alert.AddressPool)
+		assert.Equal(t, 5, alert.Capacity)
+		assert.Equal(t, 2, alert.Pending)
+	case <-time.After(time.Second):
+		t.Fatal("pool exhausted alert not sent")
+	}
+
+	// Repeated exhaustion within the alert interval is not alerted again
+	_, err = allocator.AllocatePeers(context.Background(), 1, cfg, []uint{10, 11})
+	require.ErrorIs(t, err, node.ErrWireGuardPoolExhausted)
+	select {
+	case <-alerter.alerts:
+		t.Fatal("alert should be throttled")
+	case <-time.After(50 * time.Millisecond):
+	}
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type CancelMaintenanceWindowCommand struct {
+	SID string // External API identifier
+}
+
+// CancelMaintenanceWindowUseCase cancels a scheduled or running maintenance window.
+// Running windows restore their nodes immediately; the maintenance announcement is withdrawn.
+type CancelMaintenanceWindowUseCase struct {
+	windowRepo           node.MaintenanceWindowRepository
+	nodeRepo             node.NodeRepository
+	groupRepo            resource.Repository
+	noticePublisher      MaintenanceNoticePublisher
+	configChangeNotifier NodeConfigChangeNotifier
+	logger               logger.Interface
+}
+
+func NewCancelMaintenanceWindowUseCase(
+	windowRepo node.MaintenanceWindowRepository,
+	nodeRepo node.NodeRepository,
+	groupRepo resource.Repository,
+	logger logger.Interface,
+) *CancelMaintenanceWindowUseCase {
+	return &CancelMaintenanceWindowUseCase{
+		windowRepo: windowRepo,
+		nodeRepo:   nodeRepo,
+		groupRepo:  groupRepo,
+		logger:     logger,
+	}
+}
+
+// SetNoticePublisher sets the publisher used to withdraw maintenance announcements.
+func (uc *CancelMaintenanceWindowUseCase) SetNoticePublisher(publisher MaintenanceNoticePublisher) {
+	uc.noticePublisher = publisher
+}
+
+// SetConfigChangeNotifier sets the notifier used to push restored node status to agents.
+func (uc *CancelMaintenanceWindowUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+func (uc *CancelMaintenanceWindowUseCase) Execute(ctx context.Context, cmd CancelMaintenanceWindowCommand) (*dto.MaintenanceWindowDTO, error) {
+	if cmd.SID == "" {
+		return nil, errors.NewValidationError("SID must be provided")
+	}
+
+	window, err := uc.windowRepo.GetBySID(ctx, cmd.SID)
+	if err != nil {
+		uc.logger.Errorw("failed to get maintenance window by SID", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+	if window == nil {
+		return nil, errors.NewNotFoundError("maintenance window not found")
+	}
+
+	wasActive := window.Status() == node.MaintenanceWindowStatusActive
+	if err := window.Cancel(); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if wasActive {
+		if err := exitNodesMaintenance(ctx, uc.windowRepo, uc.nodeRepo, uc.configChangeNotifier, uc.logger, window); err != nil {
+			uc.logger.Errorw("failed to restore maintenance nodes", "sid", cmd.SID, "error", err)
+			return nil, fmt.Errorf("failed to restore maintenance nodes: %w", err)
+		}
+	}
+
+	if err := uc.windowRepo.Update(ctx, window); err != nil {
+		uc.logger.Errorw("failed to update maintenance window", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to update maintenance window: %w", err)
+	}
+
+	uc.logger.Infow("maintenance window cancelled", "sid", cmd.SID, "was_active", wasActive)
+
+	withdrawMaintenanceNotice(ctx, uc.noticePublisher, uc.logger, window)
+
+	nodeSIDs, groupSIDs, err := lookupMaintenanceSIDs(ctx, uc.nodeRepo, uc.groupRepo, []*node.MaintenanceWindow{window})
+	if err != nil {
+		uc.logger.Errorw("failed to resolve maintenance window SIDs", "sid", cmd.SID, "error", err)
+		return nil, err
+	}
+
+	return dto.ToMaintenanceWindowDTO(window, nodeSIDs, groupSIDs), nil
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/acme"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// certificateOrderTimeout bounds a single ACME order, including DNS propagation.
+// It is shorter than node.CertificateIssuanceTimeout so a claim never goes stale while the order runs.
+const certificateOrderTimeout = 10 * time.Minute
+
+// CertificateIssuer obtains certificates from an ACME CA.
+type CertificateIssuer interface {
+	Issue(ctx context.Context, domain, dnsProvider string) (*acme.IssuedCertificate, error)
+	HasDNSProvider(name string) bool
+}
+
+// CertificateDeliverer pushes changed certificates to the node agents connected to this instance.
+type CertificateDeliverer interface {
+	Reconcile(ctx context.Context) (int, error)
+}
+
+// certificateIssuance runs ACME orders for certificate records. Orders are claimed in the
+// database first, so only one instance works on a certificate at a time.
+type certificateIssuance struct {
+	certRepo  node.CertificateRepository
+	issuer    CertificateIssuer
+	deliverer CertificateDeliverer
+	logger    logger.Interface
+}
+
+// claim moves the certificate into the issuing state.
+// Returns false if an issuance is already in progress here or on another instance.
+func (ci *certificateIssuance) claim(ctx context.Context, cert *node.Certificate) (bool, error) {
+	now := biztime.NowUTC()
+	if err := cert.StartIssuance(now); err != nil {
+		return false, nil
+	}
+	return ci.certRepo.ClaimIssuance(ctx, cert, now.Add(-node.CertificateIssuanceTimeout))
+}
+
+// issue runs the ACME order for a claimed certificate, stores the outcome and
+// delivers a newly issued certificate to connected nodes.
+func (ci *certificateIssuance) issue(ctx context.Context, cert *node.Certificate) error {
+	orderCtx, cancel := context.WithTimeout(ctx, certificateOrderTimeout)
+	defer cancel()
+
+	issued, err := ci.issuer.Issue(orderCtx, cert.Domain(), cert.DNSProvider())
+	if err == nil {
+		err = cert.CompleteIssuance(issued.CertificatePEM, issued.PrivateKeyPEM, issued.Fingerprint, issued.NotBefore, issued.NotAfter)
+	}
+	if err != nil {
+		cert.FailIssuance(err.Error())
+		ci.logger.Warnw("certificate issuance failed",
+			"sid", cert.SID(),
+			"domain", cert.Domain(),
+			"error", err,
+		)
+	}
+
+	if updateErr := ci.certRepo.Update(ctx, cert); updateErr != nil {
+		ci.logger.Errorw("failed to save certificate issuance result", "sid", cert.SID(), "error", updateErr)
+		return updateErr
+	}
+	if err != nil {
+		return err
+	}
+
+	ci.logger.Infow("certificate issued",
+		"sid", cert.SID(),
+		"domain", cert.Domain(),
+		"not_after", cert.NotAfter(),
+	)
+
+	if ci.deliverer != nil {
+		if _, err := ci.deliverer.Reconcile(ctx); err != nil {
+			ci.logger.Warnw("failed to deliver certificate to nodes", "sid", cert.SID(), "error", err)
+		}
+	}
+	return nil
+}
+
+// issueAsync runs the ACME order for a claimed certificate in the background,
+// so API requests return while the DNS-01 challenge propagates.
+func (ci *certificateIssuance) issueAsync(cert *node.Certificate) {
+	goroutine.SafeGo(ci.logger, "issue-node-certificate", func() {
+		_ = ci.issue(context.Background(), cert)
+	})
+}
@@ -0,0 +1,63 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// formatClash renders nodes with the Clash formatter and decodes the YAML output.
+func formatClash(t *testing.T, nodes []*Node, password string) clashConfig {
+	t.Helper()
+	out, err := NewClashFormatter().FormatWithPassword(nodes, password)
+	require.NoError(t, err)
+
+	var config clashConfig
+	require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+	return config
+}
+
+func TestClashFormatter_XHTTP(t *testing.T) {
+	vless, err := vo.NewVLESSConfig(vo.VLESSTransportXHTTP, "", vo.VLESSSecurityTLS, "x.example.com", "",
+		false, "cdn.example.com", "/xhttp", "", "", "", "", "", "stream-up",
+		`{"headers":{"X-Test":"1"},"noGRPCHeader":true,"xPaddingBytes":{"from":100,"to":1000},"xmux":{"maxConcurrency":"16-32"}}`)
+	require.NoError(t, err)
+	vmess, err := vo.NewVMessConfig(0, "auto", vo.VMessTransportXHTTP, "", "/xhttp", "", true, "v.example.com", false, "", "")
+	require.NoError(t, err)
+
+	config := formatClash(t, []*Node{
+		{Name: "VLESS", Protocol: "vless", ServerAddress: "1.1.1.1", SubscriptionPort: 443, VLESSConfig: &vless},
+		{Name: "VMess", Protocol: "vmess", ServerAddress: "2.2.2.2", SubscriptionPort: 443, VMessConfig: &vmess},
+	}, "sub-password")
+
+	require.Len(t, config.Proxies, 1, "VMess XHTTP nodes are skipped")
+	proxy := config.Proxies[0]
+	assert.Equal(t, "VLESS", proxy.Name)
+	assert.Equal(t, "xhttp", proxy.Network)
+	require.NotNil(t, proxy.XHTTPOpts)
+	assert.Equal(t, clashXHTTPOpts{
+		Host:          "cdn.example.com",
+		Path:          "/xhttp",
+		Mode:          "stream-up",
+		Headers:       map[string]string{"X-Test": "1"},
+		NoGRPCHeader:  true,
+		XPaddingBytes: "100-1000",
+	}, *proxy.XHTTPOpts)
+}
+
+func TestXHTTPRangeString(t *testing.T) {
+	tests := map[string]string{
+		`"100-1000"`:             "100-1000",
+		`256`:                    "256",
+		`{"from":100,"to":1000}`: "100-1000",
+		`{"from":0,"to":0}`:      "",
+		`[1,2]`:                  "",
+	}
+	for raw, want := range tests {
+		assert.Equal(t, want, xhttpRangeString([]byte(raw)), raw)
+	}
+}
@@ -0,0 +1,197 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// ConfigVersionRecorder records node configuration history.
+// It is shared by the use cases that change a node's protocol, route or DNS configuration.
+type ConfigVersionRecorder struct {
+	versionRepo node.ConfigVersionRepository
+	logger      logger.Interface
+}
+
+// NewConfigVersionRecorder creates a new ConfigVersionRecorder.
+func NewConfigVersionRecorder(versionRepo node.ConfigVersionRepository, logger logger.Interface) *ConfigVersionRecorder {
+	return &ConfigVersionRecorder{
+		versionRepo: versionRepo,
+		logger:      logger,
+	}
+}
+
+// Record stores the node's current configuration as a new version, together with the
+// changes against the latest recorded version. Nothing is stored (and nil is returned)
+// if the configuration did not change.
+//
+// previous is the configuration before the change, or nil for new nodes. If it does not
+// match the latest recorded version (e.g. the node predates the history) it is recorded
+// first as a baseline version, so that the change being recorded can always be rolled back.
+func (r *ConfigVersionRecorder) Record(
+	ctx context.Context,
+	n *node.Node,
+	previous *node.ConfigSnapshot,
+	authorID *uint,
+	source node.ConfigVersionSource,
+	restoredFrom *int,
+) (*node.ConfigVersion, error) {
+	latest, err := r.versionRepo.GetLatest(ctx, n.ID())
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest config version: %w", err)
+	}
+
+	var base *node.ConfigSnapshot
+	next := 1
+	if latest != nil {
+		snapshot := latest.Snapshot()
+		base = &snapshot
+		next = latest.Version() + 1
+	}
+
+	if previous != nil {
+		var changes []node.ConfigChange
+		if base != nil {
+			if changes, err = diffConfigSnapshots(*base, *previous); err != nil {
+				return nil, err
+			}
+		}
+		if base == nil || len(changes) > 0 {
+			if _, err := r.create(ctx, n.ID(), next, *previous, changes, nil, node.ConfigVersionSourceBaseline, nil); err != nil {
+				return nil, err
+			}
+			base = previous
+			next++
+		}
+	}
+
+	current := n.ConfigSnapshot()
+	var changes []node.ConfigChange
+	if base != nil {
+		if changes, err = diffConfigSnapshots(*base, current); err != nil {
+			return nil, err
+		}
+		if len(changes) == 0 {
+			return nil, nil
+		}
+	}
+
+	return r.create(ctx, n.ID(), next, current, changes, authorID, source, restoredFrom)
+}
+
+func (r *ConfigVersionRecorder) create(
+	ctx context.Context,
+	nodeID uint,
+	number int,
+	snapshot node.ConfigSnapshot,
+	changes []node.ConfigChange,
+	authorID *uint,
+	source node.ConfigVersionSource,
+	restoredFrom *int,
+) (*node.ConfigVersion, error) {
+	version, err := node.NewConfigVersion(nodeID, number, snapshot, changes, authorID, source, restoredFrom)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create config version: %w", err)
+	}
+	if err := r.versionRepo.Create(ctx, version); err != nil {
+		return nil, err
+	}
+
+	r.logger.Infow("node config version recorded",
+		"node_id", nodeID,
+		"version", number,
+		"source", source,
+		"changes", len(changes),
+	)
+	return version, nil
+}
+
+// diffConfigSnapshots compares the document form of two snapshots field by field.
+// Changes are sorted by path; list elements are compared by position.
+func diffConfigSnapshots(from, to node.ConfigSnapshot) ([]node.ConfigChange, error) {
+	fromFields, err := configSnapshotFields(from)
+	if err != nil {
+		return nil, err
+	}
+	toFields, err := configSnapshotFields(to)
+	if err != nil {
+		return nil, err
+	}
+
+	paths := make([]string, 0, len(fromFields)+len(toFields))
+	for path := range fromFields {
+		paths = append(paths, path)
+	}
+	for path := range toFields {
+		if _, ok := fromFields[path]; !ok {
+			paths = append(paths, path)
+		}
+	}
+	slices.Sort(paths)
+
+	var changes []node.ConfigChange
+	for _, path := range paths {
+		if fromFields[path] != toFields[path] {
+			changes = append(changes, node.ConfigChange{Path: path, Old: fromFields[path], New: toFields[path]})
+		}
+	}
+	return changes, nil
+}
+
+// configSnapshotFields flattens the document form of a snapshot into JSON-encoded leaf values keyed by path.
+func configSnapshotFields(s node.ConfigSnapshot) (map[string]string, error) {
+	data, err := json.Marshal(dto.ToConfigSnapshotDTO(s))
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config snapshot: %w", err)
+	}
+	var doc any
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config snapshot: %w", err)
+	}
+
+	fields := make(map[string]string)
+	if err := flattenConfigDocument("", doc, fields); err != nil {
+		return nil, err
+	}
+	return fields, nil
+}
+
+func flattenConfigDocument(path string, value any, fields map[string]string) error {
+	switch v := value.(type) {
+	case map[string]any:
+		if len(v) > 0 {
+			for key, child := range v {
+				childPath := key
+				if path != "" {
+					childPath = path + "." + key
+				}
+				if err := flattenConfigDocument(childPath, child, fields); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	case []any:
+		if len(v) > 0 {
+			for i, child := range v {
+				if err := flattenConfigDocument(path+"["+strconv.Itoa(i)+"]", child, fields); err != nil {
+					return err
+				}
+			}
+			return nil
+		}
+	}
+
+	encoded, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Errorf("failed to encode %s: %w", path, err)
+	}
+	fields[path] = string(encoded)
+	return nil
+}
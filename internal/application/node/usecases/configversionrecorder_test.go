@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/shared/routing"
+)
+
+func TestDiffConfigSnapshots(t *testing.T) {
+	enc, err := vo.NewEncryptionConfig(vo.MethodAES256GCM)
+	require.NoError(t, err)
+	from := node.ConfigSnapshot{Protocol: vo.ProtocolShadowsocks, Encryption: enc}
+
+	changes, err := diffConfigSnapshots(from, from)
+	require.NoError(t, err)
+	assert.Empty(t, changes)
+
+	to := from
+	to.Encryption, err = vo.NewEncryptionConfig(vo.MethodAES128GCM)
+	require.NoError(t, err)
+	to.Route = routing.NewGlobalProxyRouteConfig()
+
+	changes, err = diffConfigSnapshots(from, to)
+	require.NoError(t, err)
+	assert.Equal(t, []node.ConfigChange{
+		{Path: "route.final", New: `"proxy"`},
+		{Path: "route.rules[0].ip_is_private", New: `true`},
+		{Path: "route.rules[0].outbound", New: `"direct"`},
+		{Path: "shadowsocks.encryption_method", Old: `"aes-256-gcm"`, New: `"aes-128-gcm"`},
+	}, changes)
+}
@@ -0,0 +1,90 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type CreateCertificateCommand struct {
+	Domain      string
+	DNSProvider string
+	AutoRenew   bool
+}
+
+// CreateCertificateUseCase creates a certificate record for a domain and starts
+// its first issuance in the background.
+type CreateCertificateUseCase struct {
+	certRepo node.CertificateRepository
+	issuance *certificateIssuance
+	logger   logger.Interface
+}
+
+// NewCreateCertificateUseCase creates the use case. issuer is nil when ACME is disabled.
+func NewCreateCertificateUseCase(
+	certRepo node.CertificateRepository,
+	issuer CertificateIssuer,
+	logger logger.Interface,
+) *CreateCertificateUseCase {
+	return &CreateCertificateUseCase{
+		certRepo: certRepo,
+		issuance: &certificateIssuance{certRepo: certRepo, issuer: issuer, logger: logger},
+		logger:   logger,
+	}
+}
+
+// SetDeliverer sets the deliverer used to push issued certificates to node agents.
+func (uc *CreateCertificateUseCase) SetDeliverer(deliverer CertificateDeliverer) {
+	uc.issuance.deliverer = deliverer
+}
+
+func (uc *CreateCertificateUseCase) Execute(ctx context.Context, cmd CreateCertificateCommand) (*dto.CertificateDTO, error) {
+	if uc.issuance.issuer == nil {
+		return nil, errors.NewValidationError("ACME certificate issuance is not enabled")
+	}
+	if !uc.issuance.issuer.HasDNSProvider(cmd.DNSProvider) {
+		return nil, errors.NewValidationError(fmt.Sprintf("DNS provider %q is not configured", cmd.DNSProvider))
+	}
+
+	cert, err := node.NewCertificate(cmd.Domain, cmd.DNSProvider, cmd.AutoRenew, id.NewCertificateID)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	existing, err := uc.certRepo.GetByDomain(ctx, cert.Domain())
+	if err != nil {
+		uc.logger.Errorw("failed to check existing certificate", "domain", cert.Domain(), "error", err)
+		return nil, fmt.Errorf("failed to check existing certificate: %w", err)
+	}
+	if existing != nil {
+		return nil, errors.NewConflictError("certificate already exists for domain", cert.Domain())
+	}
+
+	if err := uc.certRepo.Create(ctx, cert); err != nil {
+		if errors.IsAppError(err) {
+			return nil, err
+		}
+		uc.logger.Errorw("failed to create certificate", "domain", cert.Domain(), "error", err)
+		return nil, fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	uc.logger.Infow("certificate created",
+		"sid", cert.SID(),
+		"domain", cert.Domain(),
+		"dns_provider", cert.DNSProvider(),
+	)
+
+	claimed, err := uc.issuance.claim(ctx, cert)
+	if err != nil {
+		uc.logger.Warnw("failed to claim certificate issuance", "sid", cert.SID(), "error", err)
+	} else if claimed {
+		uc.issuance.issueAsync(cert)
+	}
+
+	return dto.ToCertificateDTO(cert), nil
+}
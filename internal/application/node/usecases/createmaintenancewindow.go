@@ -0,0 +1,184 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type CreateMaintenanceWindowCommand struct {
+	Reason            string
+	StartsAt          time.Time
+	EndsAt            time.Time
+	NodeSIDs          []string
+	ResourceGroupSIDs []string
+	CreatedBy         uint
+}
+
+// CreateMaintenanceWindowUseCase schedules a maintenance window and publishes the user-facing
+// notice right away, so subscribers of the affected nodes are warned before the window starts.
+type CreateMaintenanceWindowUseCase struct {
+	windowRepo       node.MaintenanceWindowRepository
+	nodeRepo         node.NodeRepository
+	groupRepo        resource.Repository
+	subscriptionRepo subscription.SubscriptionRepository
+	noticePublisher  MaintenanceNoticePublisher
+	logger           logger.Interface
+}
+
+func NewCreateMaintenanceWindowUseCase(
+	windowRepo node.MaintenanceWindowRepository,
+	nodeRepo node.NodeRepository,
+	groupRepo resource.Repository,
+	subscriptionRepo subscription.SubscriptionRepository,
+	logger logger.Interface,
+) *CreateMaintenanceWindowUseCase {
+	return &CreateMaintenanceWindowUseCase{
+		windowRepo:       windowRepo,
+		nodeRepo:         nodeRepo,
+		groupRepo:        groupRepo,
+		subscriptionRepo: subscriptionRepo,
+		logger:           logger,
+	}
+}
+
+// SetNoticePublisher sets the publisher used for maintenance announcements.
+func (uc *CreateMaintenanceWindowUseCase) SetNoticePublisher(publisher MaintenanceNoticePublisher) {
+	uc.noticePublisher = publisher
+}
+
+func (uc *CreateMaintenanceWindowUseCase) Execute(ctx context.Context, cmd CreateMaintenanceWindowCommand) (*dto.MaintenanceWindowDTO, error) {
+	nodeIDs, nodeSIDs, err := uc.resolveNodes(ctx, cmd.NodeSIDs)
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, groupSIDs, err := uc.resolveGroups(ctx, cmd.ResourceGroupSIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	window, err := node.NewMaintenanceWindow(cmd.Reason, cmd.StartsAt, cmd.EndsAt, nodeIDs, groupIDs, cmd.CreatedBy, id.NewMaintenanceWindowID)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := uc.windowRepo.Create(ctx, window); err != nil {
+		uc.logger.Errorw("failed to create maintenance window", "error", err)
+		return nil, fmt.Errorf("failed to create maintenance window: %w", err)
+	}
+
+	uc.logger.Infow("maintenance window scheduled",
+		"sid", window.SID(),
+		"starts_at", window.StartsAt(),
+		"ends_at", window.EndsAt(),
+		"node_count", len(nodeIDs),
+		"group_count", len(groupIDs),
+	)
+
+	uc.publishNotice(ctx, window)
+
+	return dto.ToMaintenanceWindowDTO(window, nodeSIDs, groupSIDs), nil
+}
+
+// publishNotice publishes the maintenance announcement and notifies affected subscribers.
+// Failures are logged but do not undo the scheduled window.
+func (uc *CreateMaintenanceWindowUseCase) publishNotice(ctx context.Context, window *node.MaintenanceWindow) {
+	if uc.noticePublisher == nil {
+		return
+	}
+
+	affectedIDs, err := resolveMaintenanceNodeIDs(ctx, uc.nodeRepo, window)
+	if err != nil {
+		uc.logger.Warnw("failed to resolve maintenance nodes for notice", "sid", window.SID(), "error", err)
+		return
+	}
+	nodes, err := uc.nodeRepo.GetByIDs(ctx, affectedIDs)
+	if err != nil {
+		uc.logger.Warnw("failed to get maintenance nodes for notice", "sid", window.SID(), "error", err)
+		return
+	}
+	userIDs, err := maintenanceSubscriberIDs(ctx, uc.subscriptionRepo, affectedIDs)
+	if err != nil {
+		uc.logger.Warnw("failed to get maintenance subscribers", "sid", window.SID(), "error", err)
+		return
+	}
+
+	announcementID, err := uc.noticePublisher.PublishMaintenanceNotice(ctx, buildMaintenanceNotice(window, nodes, userIDs))
+	if err != nil {
+		uc.logger.Warnw("failed to publish maintenance notice", "sid", window.SID(), "error", err)
+		return
+	}
+
+	window.SetAnnouncementID(announcementID)
+	if err := uc.windowRepo.Update(ctx, window); err != nil {
+		uc.logger.Warnw("failed to record maintenance announcement", "sid", window.SID(), "error", err)
+		return
+	}
+
+	uc.logger.Infow("maintenance notice published",
+		"sid", window.SID(),
+		"announcement_id", announcementID,
+		"notified_users", len(userIDs),
+	)
+}
+
+func (uc *CreateMaintenanceWindowUseCase) resolveNodes(ctx context.Context, sids []string) ([]uint, map[uint]string, error) {
+	idToSID := make(map[uint]string, len(sids))
+	if len(sids) == 0 {
+		return nil, idToSID, nil
+	}
+
+	nodes, err := uc.nodeRepo.GetBySIDs(ctx, sids)
+	if err != nil {
+		uc.logger.Errorw("failed to get nodes by SIDs", "error", err)
+		return nil, nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+
+	found := make(map[string]struct{}, len(nodes))
+	ids := make([]uint, 0, len(nodes))
+	for _, n := range nodes {
+		ids = append(ids, n.ID())
+		idToSID[n.ID()] = n.SID()
+		found[n.SID()] = struct{}{}
+	}
+	for _, sid := range sids {
+		if _, ok := found[sid]; !ok {
+			return nil, nil, errors.NewNotFoundError("node not found", sid)
+		}
+	}
+
+	return ids, idToSID, nil
+}
+
+func (uc *CreateMaintenanceWindowUseCase) resolveGroups(ctx context.Context, sids []string) ([]uint, map[uint]string, error) {
+	idToSID := make(map[uint]string, len(sids))
+	if len(sids) == 0 {
+		return nil, idToSID, nil
+	}
+
+	groups, err := uc.groupRepo.GetBySIDs(ctx, sids)
+	if err != nil {
+		uc.logger.Errorw("failed to get resource groups by SIDs", "error", err)
+		return nil, nil, fmt.Errorf("failed to get resource groups: %w", err)
+	}
+
+	ids := make([]uint, 0, len(groups))
+	for _, sid := range sids {
+		group, ok := groups[sid]
+		if !ok {
+			return nil, nil, errors.NewNotFoundError("resource group not found", sid)
+		}
+		ids = append(ids, group.ID())
+		idToSID[group.ID()] = group.SID()
+	}
+
+	return ids, idToSID, nil
+}
@@ -16,19 +16,23 @@ import (
 )
 
 type CreateNodeCommand struct {
-	Name             string
-	ServerAddress    string
-	AgentPort        uint16  // port for agent connections (required)
-	SubscriptionPort *uint16 // port for client subscriptions (optional, defaults to AgentPort)
-	Protocol         string
-	Method           string
-	Plugin           *string
-	PluginOpts       map[string]string
-	Region           string
-	Tags             []string
-	Description      string
-	SortOrder        int
-	GroupSIDs        []string // Resource group SIDs to associate with (empty means no association)
+	Name              string
+	ServerAddress     string
+	AgentPort         uint16  // port for agent connections (required)
+	SubscriptionPort  *uint16 // port for client subscriptions (optional, defaults to AgentPort)
+	Protocol          string
+	Method            string
+	ServerKey         string // SS2022 server key (base64), derived from the node token when empty
+	Plugin            *string
+	PluginOpts        map[string]string
+	Region            string
+	CountryCode       string   // ISO 3166-1 alpha-2 node location (optional)
+	TrafficMultiplier *float64 // billing rate for subscription usage (nil = 1.0)
+	Tags              []string
+	Description       string
+	SortOrder         int
+	GroupSIDs         []string // Resource group SIDs to associate with (empty means no association)
+	CreatedBy         uint     // User creating the node, recorded in the configuration history
 	// Trojan specific fields
 	TransportProtocol string
 	Host              string
@@ -54,6 +58,12 @@ type CreateNodeCommand struct {
 	VLESSRealityPublicKey  string // Optional: auto-generated if empty for Reality security
 	VLESSRealityShortID    string // Optional: auto-generated if empty for Reality security
 	VLESSRealitySpiderX    string
+	VLESSXHTTPMode         string // XHTTP only: auto, packet-up, stream-up, stream-one (default auto)
+	VLESSXHTTPExtra        string // XHTTP only: extra options as a JSON object
+	// Reality only: scheduled key/short ID rotation (interval 0 = disabled)
+	VLESSRealityRotationIntervalHours int
+	VLESSRealityRotationOverlapHours  int
+	VLESSRealityRotateKeyPair         bool
 
 	// VMess specific fields
 	VMessAlterID       int
@@ -65,6 +75,8 @@ type CreateNodeCommand struct {
 	VMessTLS           bool
 	VMessSni           string
 	VMessAllowInsecure bool
+	VMessXHTTPMode     string // XHTTP only: auto, packet-up, stream-up, stream-one (default auto)
+	VMessXHTTPExtra    string // XHTTP only: extra options as a JSON object
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl string
@@ -91,10 +103,24 @@ type CreateNodeCommand struct {
 	AnyTLSIdleSessionCheckInterval string
 	AnyTLSIdleSessionTimeout       string
 	AnyTLSMinIdleSession           int
+
+	// WireGuard specific fields
+	WireGuardPrivateKey          string // Server private key (base64), generated when empty
+	WireGuardAddressPool         string // Tunnel address pool CIDR, defaults to 10.66.0.0/16
+	WireGuardMTU                 int
+	WireGuardDNS                 string
+	WireGuardPersistentKeepalive *int // Defaults to 25 seconds when nil
+
+	// ShadowTLS fields (Shadowsocks only); ShadowTLS is enabled when a handshake server is set
+	ShadowTLSHandshakeServer string
+	ShadowTLSHandshakePort   uint16 // Defaults to 443
+	ShadowTLSPassword        string // Generated when empty
+	ShadowTLSVersion         int    // Defaults to 3
 }
 
 type CreateNodeResult struct {
 	NodeID           uint
+	NodeSID          string
 	APIToken         string
 	TokenPrefix      string
 	ServerAddress    string
@@ -109,6 +135,7 @@ type CreateNodeUseCase struct {
 	nodeRepo          node.NodeRepository
 	resourceGroupRepo resource.Repository
 	logger            logger.Interface
+	versionRecorder   NodeConfigVersionRecorder
 }
 
 func NewCreateNodeUseCase(
@@ -123,7 +150,69 @@ func NewCreateNodeUseCase(
 	}
 }
 
+// SetConfigVersionRecorder sets the recorder for the node configuration history.
+func (uc *CreateNodeUseCase) SetConfigVersionRecorder(recorder NodeConfigVersionRecorder) {
+	uc.versionRecorder = recorder
+}
+
 func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand) (*CreateNodeResult, error) {
+	nodeEntity, err := uc.buildNode(ctx, cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// Get the API token before persisting (it will be cleared after)
+	apiToken := nodeEntity.GetAPIToken()
+
+	// Persist the node
+	if err := uc.nodeRepo.Create(ctx, nodeEntity); err != nil {
+		uc.logger.Errorw("failed to persist node", "error", err, "name", cmd.Name)
+		return nil, fmt.Errorf("failed to save node: %w", err)
+	}
+
+	// Record the initial configuration as the first version
+	if uc.versionRecorder != nil {
+		var authorID *uint
+		if cmd.CreatedBy != 0 {
+			authorID = &cmd.CreatedBy
+		}
+		if _, err := uc.versionRecorder.Record(ctx, nodeEntity, nil, authorID, node.ConfigVersionSourceCreate, nil); err != nil {
+			uc.logger.Warnw("failed to record node config version", "error", err, "id", nodeEntity.ID())
+		}
+	}
+
+	// Extract token prefix for display (first 8 characters)
+	tokenPrefix := ""
+	if len(apiToken) >= 8 {
+		tokenPrefix = apiToken[:8] + "..."
+	}
+
+	// Map to result
+	result := &CreateNodeResult{
+		NodeID:           nodeEntity.ID(),
+		NodeSID:          nodeEntity.SID(),
+		APIToken:         apiToken,
+		TokenPrefix:      tokenPrefix,
+		ServerAddress:    nodeEntity.ServerAddress().Value(),
+		AgentPort:        nodeEntity.AgentPort(),
+		SubscriptionPort: nodeEntity.SubscriptionPort(),
+		Protocol:         nodeEntity.Protocol().String(),
+		Status:           nodeEntity.Status().String(),
+		CreatedAt:        nodeEntity.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
+	}
+
+	uc.logger.Infow("node created successfully", "id", result.NodeID, "name", cmd.Name)
+	return result, nil
+}
+
+// Validate checks the command the same way Execute does, without creating the node.
+func (uc *CreateNodeUseCase) Validate(ctx context.Context, cmd CreateNodeCommand) error {
+	_, err := uc.buildNode(ctx, cmd)
+	return err
+}
+
+// buildNode validates the command and builds the node aggregate without persisting it.
+func (uc *CreateNodeUseCase) buildNode(ctx context.Context, cmd CreateNodeCommand) (*node.Node, error) {
 	// Validate command
 	if err := uc.validateCommand(cmd); err != nil {
 		return nil, err
@@ -169,15 +258,17 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 	// Create encryption config for Shadowsocks
 	var encryptionConfig vo.EncryptionConfig
 	var pluginConfig *vo.PluginConfig
+	var shadowTLSConfig *vo.ShadowTLSConfig
 	var trojanConfig *vo.TrojanConfig
 	var vlessConfig *vo.VLESSConfig
 	var vmessConfig *vo.VMessConfig
 	var hysteria2Config *vo.Hysteria2Config
 	var tuicConfig *vo.TUICConfig
 	var anytlsConfig *vo.AnyTLSConfig
+	var wireguardConfig *vo.WireGuardConfig
 
 	if protocol.IsShadowsocks() {
-		encryptionConfig, err = vo.NewEncryptionConfig(cmd.Method)
+		encryptionConfig, err = vo.NewEncryptionConfigWithServerKey(cmd.Method, cmd.ServerKey)
 		if err != nil {
 			return nil, err
 		}
@@ -189,6 +280,22 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 				return nil, err
 			}
 		}
+
+		// Create ShadowTLS config if a handshake server is specified
+		if cmd.ShadowTLSHandshakeServer != "" {
+			password := cmd.ShadowTLSPassword
+			if password == "" {
+				password, err = vo.GenerateShadowTLSPassword()
+				if err != nil {
+					return nil, err
+				}
+			}
+			stc, err := vo.NewShadowTLSConfig(cmd.ShadowTLSVersion, password, cmd.ShadowTLSHandshakeServer, cmd.ShadowTLSHandshakePort)
+			if err != nil {
+				return nil, errors.NewValidationError("invalid ShadowTLS configuration: " + err.Error())
+			}
+			shadowTLSConfig = &stc
+		}
 	} else if protocol.IsTrojan() {
 		// Create Trojan config
 		// Default transport protocol to tcp if not specified
@@ -264,10 +371,23 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 			publicKey,
 			shortID,
 			cmd.VLESSRealitySpiderX,
+			cmd.VLESSXHTTPMode,
+			cmd.VLESSXHTTPExtra,
 		)
 		if err != nil {
 			return nil, err
 		}
+		if security == vo.VLESSSecurityReality {
+			rotation, err := vo.NewRealityRotationPolicy(
+				cmd.VLESSRealityRotationIntervalHours,
+				cmd.VLESSRealityRotationOverlapHours,
+				cmd.VLESSRealityRotateKeyPair,
+			)
+			if err != nil {
+				return nil, errors.NewValidationError(err.Error())
+			}
+			vc = vc.WithRealityRotation(rotation)
+		}
 		vlessConfig = &vc
 	} else if protocol.IsVMess() {
 		// Create VMess config
@@ -292,6 +412,8 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 			cmd.VMessTLS,
 			cmd.VMessSni,
 			cmd.VMessAllowInsecure,
+			cmd.VMessXHTTPMode,
+			cmd.VMessXHTTPExtra,
 		)
 		if err != nil {
 			return nil, err
@@ -362,11 +484,45 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 			return nil, err
 		}
 		anytlsConfig = &ac
+	} else if protocol.IsWireGuard() {
+		// Create WireGuard config, generating the server keypair if not provided
+		privateKey := cmd.WireGuardPrivateKey
+		if privateKey == "" {
+			privateKey, err = vo.GenerateWireGuardPrivateKey()
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate wireguard key: %w", err)
+			}
+		}
+		keepalive := vo.DefaultWireGuardKeepalive
+		if cmd.WireGuardPersistentKeepalive != nil {
+			keepalive = *cmd.WireGuardPersistentKeepalive
+		}
+		wc, err := vo.NewWireGuardConfig(
+			privateKey,
+			cmd.WireGuardAddressPool,
+			cmd.WireGuardMTU,
+			cmd.WireGuardDNS,
+			keepalive,
+		)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		wireguardConfig = &wc
 	}
 
 	// Create metadata
 	metadata := vo.NewNodeMetadata(cmd.Region, cmd.Tags, cmd.Description)
 
+	// Validate country code if provided
+	var countryCode *vo.CountryCode
+	if cmd.CountryCode != "" {
+		code, err := vo.NewCountryCode(cmd.CountryCode)
+		if err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+		countryCode = &code
+	}
+
 	// Convert route config from DTO if provided
 	var routeConfig *routing.RouteConfig
 	if cmd.Route != nil {
@@ -413,12 +569,14 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 		protocol,
 		encryptionConfig,
 		pluginConfig,
+		shadowTLSConfig,
 		trojanConfig,
 		vlessConfig,
 		vmessConfig,
 		hysteria2Config,
 		tuicConfig,
 		anytlsConfig,
+		wireguardConfig,
 		metadata,
 		cmd.SortOrder,
 		routeConfig,
@@ -429,6 +587,16 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 		return nil, err
 	}
 
+	if countryCode != nil {
+		nodeEntity.SetCountryCode(countryCode)
+	}
+
+	if cmd.TrafficMultiplier != nil {
+		if err := nodeEntity.UpdateTrafficMultiplier(*cmd.TrafficMultiplier); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+
 	// Handle GroupSIDs (resolve SIDs to internal IDs)
 	if len(cmd.GroupSIDs) > 0 {
 		// Deduplicate and filter empty SIDs
@@ -466,36 +634,7 @@ func (uc *CreateNodeUseCase) Execute(ctx context.Context, cmd CreateNodeCommand)
 		}
 	}
 
-	// Get the API token before persisting (it will be cleared after)
-	apiToken := nodeEntity.GetAPIToken()
-
-	// Persist the node
-	if err := uc.nodeRepo.Create(ctx, nodeEntity); err != nil {
-		uc.logger.Errorw("failed to persist node", "error", err, "name", cmd.Name)
-		return nil, fmt.Errorf("failed to save node: %w", err)
-	}
-
-	// Extract token prefix for display (first 8 characters)
-	tokenPrefix := ""
-	if len(apiToken) >= 8 {
-		tokenPrefix = apiToken[:8] + "..."
-	}
-
-	// Map to result
-	result := &CreateNodeResult{
-		NodeID:           nodeEntity.ID(),
-		APIToken:         apiToken,
-		TokenPrefix:      tokenPrefix,
-		ServerAddress:    nodeEntity.ServerAddress().Value(),
-		AgentPort:        nodeEntity.AgentPort(),
-		SubscriptionPort: nodeEntity.SubscriptionPort(),
-		Protocol:         nodeEntity.Protocol().String(),
-		Status:           nodeEntity.Status().String(),
-		CreatedAt:        nodeEntity.CreatedAt().Format("2006-01-02T15:04:05Z07:00"),
-	}
-
-	uc.logger.Infow("node created successfully", "id", result.NodeID, "name", cmd.Name)
-	return result, nil
+	return nodeEntity, nil
 }
 
 func (uc *CreateNodeUseCase) validateCommand(cmd CreateNodeCommand) error {
@@ -518,6 +657,16 @@ func (uc *CreateNodeUseCase) validateCommand(cmd CreateNodeCommand) error {
 		return errors.NewValidationError("encryption method is required for Shadowsocks protocol")
 	}
 
+	// ShadowTLS wraps the Shadowsocks stream and replaces any plugin
+	if cmd.ShadowTLSHandshakeServer != "" {
+		if cmd.Protocol != "shadowsocks" {
+			return errors.NewValidationError("ShadowTLS is only supported for Shadowsocks protocol")
+		}
+		if cmd.Plugin != nil && *cmd.Plugin != "" {
+			return errors.NewValidationError("ShadowTLS cannot be combined with a Shadowsocks plugin")
+		}
+	}
+
 	// Validate Trojan-specific requirements
 	if cmd.Protocol == "trojan" {
 		// Validate transport protocol if specified
@@ -585,9 +734,9 @@ func (uc *CreateNodeUseCase) validateCommand(cmd CreateNodeCommand) error {
 // validateVLESSCommand validates VLESS protocol specific requirements
 func (uc *CreateNodeUseCase) validateVLESSCommand(cmd CreateNodeCommand) error {
 	// Validate transport type
-	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "h2": true}
+	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "h2": true, "xhttp": true, "httpupgrade": true}
 	if cmd.VLESSTransportType != "" && !validTransports[cmd.VLESSTransportType] {
-		return errors.NewValidationError("invalid VLESS transport type (must be tcp, ws, grpc, or h2)")
+		return errors.NewValidationError("invalid VLESS transport type (must be tcp, ws, grpc, h2, xhttp, or httpupgrade)")
 	}
 
 	// Validate security type
@@ -629,6 +778,11 @@ func (uc *CreateNodeUseCase) validateVLESSCommand(cmd CreateNodeCommand) error {
 		return errors.NewValidationError("service name is required for VLESS gRPC transport")
 	}
 
+	// XHTTP/HTTPUpgrade require path
+	if (cmd.VLESSTransportType == "xhttp" || cmd.VLESSTransportType == "httpupgrade") && cmd.VLESSPath == "" {
+		return errors.NewValidationError("path is required for VLESS XHTTP/HTTPUpgrade transport")
+	}
+
 	return nil
 }
 
@@ -646,9 +800,9 @@ func (uc *CreateNodeUseCase) validateVMessCommand(cmd CreateNodeCommand) error {
 	}
 
 	// Validate transport type
-	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "http": true, "quic": true}
+	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "http": true, "quic": true, "xhttp": true, "httpupgrade": true}
 	if cmd.VMessTransportType != "" && !validTransports[cmd.VMessTransportType] {
-		return errors.NewValidationError("invalid VMess transport type (must be tcp, ws, grpc, http, or quic)")
+		return errors.NewValidationError("invalid VMess transport type (must be tcp, ws, grpc, http, quic, xhttp, or httpupgrade)")
 	}
 
 	// WebSocket requires path
@@ -666,6 +820,11 @@ func (uc *CreateNodeUseCase) validateVMessCommand(cmd CreateNodeCommand) error {
 		return errors.NewValidationError("service name is required for VMess gRPC transport")
 	}
 
+	// XHTTP/HTTPUpgrade require path
+	if (cmd.VMessTransportType == "xhttp" || cmd.VMessTransportType == "httpupgrade") && cmd.VMessPath == "" {
+		return errors.NewValidationError("path is required for VMess XHTTP/HTTPUpgrade transport")
+	}
+
 	return nil
 }
 
@@ -759,6 +918,10 @@ func (uc *CreateNodeUseCase) validateProtocolMethodCompatibility(protocol vo.Pro
 		if !ssMethods[method] {
 			return errors.NewValidationError(fmt.Sprintf("encryption method '%s' is not compatible with Shadowsocks protocol", method))
 		}
+		// Legacy ciphers are kept readable for existing nodes but can no longer be configured
+		if vo.IsLegacyMethod(method) {
+			return errors.NewValidationError(fmt.Sprintf("encryption method '%s' is retired, use a 2022-blake3-* method", method))
+		}
 	}
 	// Trojan doesn't require encryption method validation - it uses TLS
 
@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type CreateNodeTemplateCommand struct {
+	Name          string
+	Description   string
+	SourceNodeSID string    // Node whose protocol, route and DNS configuration is captured
+	GroupSIDs     *[]string // Resource groups provisioned nodes join (nil copies the source node's groups)
+	CreatedBy     uint
+}
+
+// CreateNodeTemplateUseCase saves the configuration of an existing node as a template.
+type CreateNodeTemplateUseCase struct {
+	templateRepo      node.NodeTemplateRepository
+	nodeRepo          node.NodeRepository
+	resourceGroupRepo resource.Repository
+	logger            logger.Interface
+}
+
+func NewCreateNodeTemplateUseCase(
+	templateRepo node.NodeTemplateRepository,
+	nodeRepo node.NodeRepository,
+	resourceGroupRepo resource.Repository,
+	logger logger.Interface,
+) *CreateNodeTemplateUseCase {
+	return &CreateNodeTemplateUseCase{
+		templateRepo:      templateRepo,
+		nodeRepo:          nodeRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		logger:            logger,
+	}
+}
+
+func (uc *CreateNodeTemplateUseCase) Execute(ctx context.Context, cmd CreateNodeTemplateCommand) (*dto.NodeTemplateDTO, error) {
+	if cmd.SourceNodeSID == "" {
+		return nil, errors.NewValidationError("source node is required")
+	}
+	source, err := getTemplateSourceNode(ctx, uc.nodeRepo, uc.logger, cmd.SourceNodeSID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := source.GroupIDs()
+	if cmd.GroupSIDs != nil {
+		if groupIDs, err = resolveTemplateGroupIDs(ctx, uc.resourceGroupRepo, uc.logger, *cmd.GroupSIDs); err != nil {
+			return nil, err
+		}
+	}
+
+	var createdBy *uint
+	if cmd.CreatedBy != 0 {
+		createdBy = &cmd.CreatedBy
+	}
+
+	template, err := node.NewNodeTemplate(cmd.Name, cmd.Description, source.ConfigSnapshot(), groupIDs, createdBy, id.NewNodeTemplateID)
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := uc.templateRepo.Create(ctx, template); err != nil {
+		if errors.IsAppError(err) {
+			return nil, err
+		}
+		uc.logger.Errorw("failed to create node template", "name", template.Name(), "error", err)
+		return nil, fmt.Errorf("failed to create node template: %w", err)
+	}
+
+	uc.logger.Infow("node template created",
+		"sid", template.SID(),
+		"name", template.Name(),
+		"source_node_sid", cmd.SourceNodeSID,
+		"protocol", template.Config().Protocol.String(),
+	)
+
+	groupSIDs, err := getTemplateGroupSIDs(ctx, uc.resourceGroupRepo, uc.logger, template)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToNodeTemplateDTO(template, groupSIDs), nil
+}
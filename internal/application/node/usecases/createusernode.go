@@ -43,6 +43,8 @@ type CreateUserNodeCommand struct {
 	VLESSRealityPublicKey  string // Optional: auto-generated if empty for Reality security
 	VLESSRealityShortID    string // Optional: auto-generated if empty for Reality security
 	VLESSRealitySpiderX    string
+	VLESSXHTTPMode         string // XHTTP only: auto, packet-up, stream-up, stream-one (default auto)
+	VLESSXHTTPExtra        string // XHTTP only: extra options as a JSON object
 
 	// VMess specific fields
 	VMessAlterID       int
@@ -54,6 +56,8 @@ type CreateUserNodeCommand struct {
 	VMessTLS           bool
 	VMessSni           string
 	VMessAllowInsecure bool
+	VMessXHTTPMode     string // XHTTP only: auto, packet-up, stream-up, stream-one (default auto)
+	VMessXHTTPExtra    string // XHTTP only: extra options as a JSON object
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl string
@@ -80,6 +84,19 @@ type CreateUserNodeCommand struct {
 	AnyTLSIdleSessionCheckInterval string
 	AnyTLSIdleSessionTimeout       string
 	AnyTLSMinIdleSession           int
+
+	// WireGuard specific fields
+	WireGuardPrivateKey          string // Server private key (base64), generated when empty
+	WireGuardAddressPool         string // Tunnel address pool CIDR, defaults to 10.66.0.0/16
+	WireGuardMTU                 int
+	WireGuardDNS                 string
+	WireGuardPersistentKeepalive *int // Defaults to 25 seconds when nil
+
+	// ShadowTLS fields (Shadowsocks only); ShadowTLS is enabled when a handshake server is set
+	ShadowTLSHandshakeServer string
+	ShadowTLSHandshakePort   uint16 // Defaults to 443
+	ShadowTLSPassword        string // Generated when empty
+	ShadowTLSVersion         int    // Defaults to 3
 }
 
 type CreateUserNodeResult struct {
@@ -168,12 +185,14 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 	// Create encryption config for Shadowsocks
 	var encryptionConfig vo.EncryptionConfig
 	var pluginConfig *vo.PluginConfig
+	var shadowTLSConfig *vo.ShadowTLSConfig
 	var trojanConfig *vo.TrojanConfig
 	var vlessConfig *vo.VLESSConfig
 	var vmessConfig *vo.VMessConfig
 	var hysteria2Config *vo.Hysteria2Config
 	var tuicConfig *vo.TUICConfig
 	var anytlsConfig *vo.AnyTLSConfig
+	var wireguardConfig *vo.WireGuardConfig
 
 	if protocol.IsShadowsocks() {
 		encryptionConfig, err = vo.NewEncryptionConfig(cmd.Method)
@@ -190,6 +209,23 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 				return nil, err
 			}
 		}
+
+		// Create ShadowTLS config if a handshake server is specified
+		if cmd.ShadowTLSHandshakeServer != "" {
+			password := cmd.ShadowTLSPassword
+			if password == "" {
+				password, err = vo.GenerateShadowTLSPassword()
+				if err != nil {
+					return nil, err
+				}
+			}
+			stc, err := vo.NewShadowTLSConfig(cmd.ShadowTLSVersion, password, cmd.ShadowTLSHandshakeServer, cmd.ShadowTLSHandshakePort)
+			if err != nil {
+				uc.logger.Errorw("invalid shadowtls config", "error", err)
+				return nil, errors.NewValidationError("invalid ShadowTLS configuration: " + err.Error())
+			}
+			shadowTLSConfig = &stc
+		}
 	} else if protocol.IsTrojan() {
 		// Create Trojan config
 		// Default transport protocol to tcp if not specified
@@ -269,6 +305,8 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 			publicKey,
 			shortID,
 			cmd.VLESSRealitySpiderX,
+			cmd.VLESSXHTTPMode,
+			cmd.VLESSXHTTPExtra,
 		)
 		if err != nil {
 			uc.logger.Errorw("invalid VLESS config", "error", err)
@@ -298,6 +336,8 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 			cmd.VMessTLS,
 			cmd.VMessSni,
 			cmd.VMessAllowInsecure,
+			cmd.VMessXHTTPMode,
+			cmd.VMessXHTTPExtra,
 		)
 		if err != nil {
 			uc.logger.Errorw("invalid VMess config", "error", err)
@@ -372,6 +412,31 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 			return nil, err
 		}
 		anytlsConfig = &ac
+	} else if protocol.IsWireGuard() {
+		// Create WireGuard config, generating the server keypair if not provided
+		privateKey := cmd.WireGuardPrivateKey
+		if privateKey == "" {
+			privateKey, err = vo.GenerateWireGuardPrivateKey()
+			if err != nil {
+				return nil, fmt.Errorf("failed to generate wireguard key: %w", err)
+			}
+		}
+		keepalive := vo.DefaultWireGuardKeepalive
+		if cmd.WireGuardPersistentKeepalive != nil {
+			keepalive = *cmd.WireGuardPersistentKeepalive
+		}
+		wc, err := vo.NewWireGuardConfig(
+			privateKey,
+			cmd.WireGuardAddressPool,
+			cmd.WireGuardMTU,
+			cmd.WireGuardDNS,
+			keepalive,
+		)
+		if err != nil {
+			uc.logger.Errorw("invalid WireGuard config", "error", err)
+			return nil, errors.NewValidationError(err.Error())
+		}
+		wireguardConfig = &wc
 	}
 
 	// Create metadata (user nodes don't have region/tags/description)
@@ -386,12 +451,14 @@ func (uc *CreateUserNodeUseCase) Execute(ctx context.Context, cmd CreateUserNode
 		protocol,
 		encryptionConfig,
 		pluginConfig,
+		shadowTLSConfig,
 		trojanConfig,
 		vlessConfig,
 		vmessConfig,
 		hysteria2Config,
 		tuicConfig,
 		anytlsConfig,
+		wireguardConfig,
 		metadata,
 		0,   // sortOrder not used for user nodes
 		nil, // routeConfig - can be set later via UpdateRouteConfig
@@ -459,6 +526,16 @@ func (uc *CreateUserNodeUseCase) validateCommand(cmd CreateUserNodeCommand) erro
 		return errors.NewValidationError("encryption method is required for Shadowsocks protocol")
 	}
 
+	// ShadowTLS wraps the Shadowsocks stream and replaces any plugin
+	if cmd.ShadowTLSHandshakeServer != "" {
+		if cmd.Protocol != "shadowsocks" {
+			return errors.NewValidationError("ShadowTLS is only supported for Shadowsocks protocol")
+		}
+		if cmd.Plugin != nil && *cmd.Plugin != "" {
+			return errors.NewValidationError("ShadowTLS cannot be combined with a Shadowsocks plugin")
+		}
+	}
+
 	// Validate Trojan-specific requirements
 	if cmd.Protocol == "trojan" {
 		// Validate transport protocol if specified
@@ -526,9 +603,9 @@ func (uc *CreateUserNodeUseCase) validateCommand(cmd CreateUserNodeCommand) erro
 // validateVLESSCommand validates VLESS protocol specific requirements
 func (uc *CreateUserNodeUseCase) validateVLESSCommand(cmd CreateUserNodeCommand) error {
 	// Validate transport type
-	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "h2": true}
+	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "h2": true, "xhttp": true, "httpupgrade": true}
 	if cmd.VLESSTransportType != "" && !validTransports[cmd.VLESSTransportType] {
-		return errors.NewValidationError("invalid VLESS transport type (must be tcp, ws, grpc, or h2)")
+		return errors.NewValidationError("invalid VLESS transport type (must be tcp, ws, grpc, h2, xhttp, or httpupgrade)")
 	}
 
 	// Validate security type
@@ -570,6 +647,11 @@ func (uc *CreateUserNodeUseCase) validateVLESSCommand(cmd CreateUserNodeCommand)
 		return errors.NewValidationError("service name is required for VLESS gRPC transport")
 	}
 
+	// XHTTP/HTTPUpgrade require path
+	if (cmd.VLESSTransportType == "xhttp" || cmd.VLESSTransportType == "httpupgrade") && cmd.VLESSPath == "" {
+		return errors.NewValidationError("path is required for VLESS XHTTP/HTTPUpgrade transport")
+	}
+
 	return nil
 }
 
@@ -587,9 +669,9 @@ func (uc *CreateUserNodeUseCase) validateVMessCommand(cmd CreateUserNodeCommand)
 	}
 
 	// Validate transport type
-	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "http": true, "quic": true}
+	validTransports := map[string]bool{"tcp": true, "ws": true, "grpc": true, "http": true, "quic": true, "xhttp": true, "httpupgrade": true}
 	if cmd.VMessTransportType != "" && !validTransports[cmd.VMessTransportType] {
-		return errors.NewValidationError("invalid VMess transport type (must be tcp, ws, grpc, http, or quic)")
+		return errors.NewValidationError("invalid VMess transport type (must be tcp, ws, grpc, http, quic, xhttp, or httpupgrade)")
 	}
 
 	// WebSocket requires path
@@ -607,6 +689,11 @@ func (uc *CreateUserNodeUseCase) validateVMessCommand(cmd CreateUserNodeCommand)
 		return errors.NewValidationError("service name is required for VMess gRPC transport")
 	}
 
+	// XHTTP/HTTPUpgrade require path
+	if (cmd.VMessTransportType == "xhttp" || cmd.VMessTransportType == "httpupgrade") && cmd.VMessPath == "" {
+		return errors.NewValidationError("path is required for VMess XHTTP/HTTPUpgrade transport")
+	}
+
 	return nil
 }
 
@@ -700,6 +787,10 @@ func (uc *CreateUserNodeUseCase) validateProtocolMethodCompatibility(protocol vo
 		if !ssMethods[method] {
 			return errors.NewValidationError(fmt.Sprintf("encryption method '%s' is not compatible with Shadowsocks protocol", method))
 		}
+		// Legacy ciphers are kept readable for existing nodes but can no longer be configured
+		if vo.IsLegacyMethod(method) {
+			return errors.NewValidationError(fmt.Sprintf("encryption method '%s' is retired, use a 2022-blake3-* method", method))
+		}
 	}
 	// Trojan doesn't require encryption method validation - it uses TLS
 
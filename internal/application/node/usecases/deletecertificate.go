@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type DeleteCertificateCommand struct {
+	SID string // External API identifier
+}
+
+// DeleteCertificateUseCase removes a certificate record. Nodes keep the certificate
+// they already received until it is replaced by another one covering their server name.
+type DeleteCertificateUseCase struct {
+	certRepo node.CertificateRepository
+	logger   logger.Interface
+}
+
+func NewDeleteCertificateUseCase(
+	certRepo node.CertificateRepository,
+	logger logger.Interface,
+) *DeleteCertificateUseCase {
+	return &DeleteCertificateUseCase{
+		certRepo: certRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *DeleteCertificateUseCase) Execute(ctx context.Context, cmd DeleteCertificateCommand) error {
+	cert, err := getCertificateBySID(ctx, uc.certRepo, uc.logger, cmd.SID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.certRepo.Delete(ctx, cert.ID()); err != nil {
+		uc.logger.Errorw("failed to delete certificate", "sid", cmd.SID, "error", err)
+		return fmt.Errorf("failed to delete certificate: %w", err)
+	}
+
+	uc.logger.Infow("certificate deleted", "sid", cmd.SID, "domain", cert.Domain())
+	return nil
+}
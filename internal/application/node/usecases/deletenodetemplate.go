@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type DeleteNodeTemplateCommand struct {
+	SID string // External API identifier
+}
+
+// DeleteNodeTemplateUseCase removes a node template. Nodes provisioned from it keep their configuration.
+type DeleteNodeTemplateUseCase struct {
+	templateRepo node.NodeTemplateRepository
+	logger       logger.Interface
+}
+
+func NewDeleteNodeTemplateUseCase(
+	templateRepo node.NodeTemplateRepository,
+	logger logger.Interface,
+) *DeleteNodeTemplateUseCase {
+	return &DeleteNodeTemplateUseCase{
+		templateRepo: templateRepo,
+		logger:       logger,
+	}
+}
+
+func (uc *DeleteNodeTemplateUseCase) Execute(ctx context.Context, cmd DeleteNodeTemplateCommand) error {
+	template, err := getNodeTemplateBySID(ctx, uc.templateRepo, uc.logger, cmd.SID)
+	if err != nil {
+		return err
+	}
+
+	if err := uc.templateRepo.Delete(ctx, template.ID()); err != nil {
+		uc.logger.Errorw("failed to delete node template", "sid", cmd.SID, "error", err)
+		return fmt.Errorf("failed to delete node template: %w", err)
+	}
+
+	uc.logger.Infow("node template deleted", "sid", cmd.SID, "name", template.Name())
+	return nil
+}
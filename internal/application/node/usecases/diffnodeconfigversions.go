@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type DiffNodeConfigVersionsQuery struct {
+	NodeSID string
+	From    int
+	To      *int // nil: compare against the latest version
+}
+
+type DiffNodeConfigVersionsUseCase struct {
+	nodeRepo    node.NodeRepository
+	versionRepo node.ConfigVersionRepository
+	logger      logger.Interface
+}
+
+func NewDiffNodeConfigVersionsUseCase(
+	nodeRepo node.NodeRepository,
+	versionRepo node.ConfigVersionRepository,
+	logger logger.Interface,
+) *DiffNodeConfigVersionsUseCase {
+	return &DiffNodeConfigVersionsUseCase{
+		nodeRepo:    nodeRepo,
+		versionRepo: versionRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *DiffNodeConfigVersionsUseCase) Execute(ctx context.Context, query DiffNodeConfigVersionsQuery) (*dto.ConfigVersionDiffDTO, error) {
+	n, err := uc.nodeRepo.GetBySID(ctx, query.NodeSID)
+	if err != nil {
+		uc.logger.Errorw("failed to get node by SID", "sid", query.NodeSID, "error", err)
+		return nil, errors.NewNotFoundError("node not found")
+	}
+
+	from, err := uc.getVersion(ctx, n.ID(), &query.From)
+	if err != nil {
+		return nil, err
+	}
+	to, err := uc.getVersion(ctx, n.ID(), query.To)
+	if err != nil {
+		return nil, err
+	}
+
+	changes, err := diffConfigSnapshots(from.Snapshot(), to.Snapshot())
+	if err != nil {
+		uc.logger.Errorw("failed to diff node config versions", "sid", query.NodeSID, "error", err)
+		return nil, fmt.Errorf("failed to diff node config versions: %w", err)
+	}
+
+	return &dto.ConfigVersionDiffDTO{
+		From:    from.Version(),
+		To:      to.Version(),
+		Changes: dto.ToConfigChangeDTOs(changes),
+	}, nil
+}
+
+// getVersion loads the given version, or the latest one if number is nil.
+func (uc *DiffNodeConfigVersionsUseCase) getVersion(ctx context.Context, nodeID uint, number *int) (*node.ConfigVersion, error) {
+	var (
+		version *node.ConfigVersion
+		err     error
+	)
+	if number != nil {
+		version, err = uc.versionRepo.GetByVersion(ctx, nodeID, *number)
+	} else {
+		version, err = uc.versionRepo.GetLatest(ctx, nodeID)
+	}
+	if err != nil {
+		uc.logger.Errorw("failed to get node config version", "node_id", nodeID, "error", err)
+		return nil, fmt.Errorf("failed to get node config version: %w", err)
+	}
+	if version == nil {
+		if number != nil {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("config version %d not found", *number))
+		}
+		return nil, errors.NewNotFoundError("node has no recorded config versions")
+	}
+	return version, nil
+}
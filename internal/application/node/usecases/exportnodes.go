@@ -0,0 +1,162 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/constants"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	sharedquery "github.com/orris-inc/orris/internal/shared/query"
+)
+
+type ExportNodesQuery struct {
+	NodeSIDs       []string // Export only these nodes (empty means all admin nodes)
+	GroupSID       string   // Export only nodes in this resource group
+	Tag            string   // Export only nodes with this tag
+	IncludeSecrets bool     // Include server keys (SS2022 server key, Reality key pair and short ID, WireGuard private key)
+}
+
+type ExportNodesUseCase struct {
+	nodeRepo          node.NodeRepository
+	resourceGroupRepo resource.Repository
+	logger            logger.Interface
+}
+
+func NewExportNodesUseCase(
+	nodeRepo node.NodeRepository,
+	resourceGroupRepo resource.Repository,
+	logger logger.Interface,
+) *ExportNodesUseCase {
+	return &ExportNodesUseCase{
+		nodeRepo:          nodeRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		logger:            logger,
+	}
+}
+
+// Execute builds a portable manifest of admin nodes. User-owned nodes are not exported.
+func (uc *ExportNodesUseCase) Execute(ctx context.Context, query ExportNodesQuery) (*dto.NodeManifest, error) {
+	uc.logger.Infow("executing export nodes use case",
+		"node_count", len(query.NodeSIDs),
+		"group_sid", query.GroupSID,
+		"tag", query.Tag,
+		"include_secrets", query.IncludeSecrets,
+	)
+
+	var (
+		nodes []*node.Node
+		err   error
+	)
+	if len(query.NodeSIDs) > 0 {
+		nodes, err = uc.getNodesBySIDs(ctx, query.NodeSIDs)
+	} else {
+		nodes, err = uc.listNodes(ctx, query)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	groupIDs := make([]uint, 0)
+	for _, n := range nodes {
+		groupIDs = append(groupIDs, n.GroupIDs()...)
+	}
+	groupSIDs := make(map[uint]string)
+	if len(groupIDs) > 0 {
+		groupSIDs, err = uc.resourceGroupRepo.GetSIDsByIDs(ctx, groupIDs)
+		if err != nil {
+			uc.logger.Errorw("failed to get resource group SIDs", "error", err)
+			return nil, fmt.Errorf("failed to get resource groups: %w", err)
+		}
+	}
+
+	exportedAt := biztime.NowUTC()
+	manifest := &dto.NodeManifest{
+		Version:    dto.NodeManifestVersion,
+		ExportedAt: &exportedAt,
+		Nodes:      make([]dto.NodeManifestEntry, 0, len(nodes)),
+	}
+	for _, n := range nodes {
+		var sids []string
+		for _, gid := range n.GroupIDs() {
+			if sid, ok := groupSIDs[gid]; ok {
+				sids = append(sids, sid)
+			}
+		}
+		manifest.Nodes = append(manifest.Nodes, dto.ToNodeManifestEntry(n, sids, query.IncludeSecrets))
+	}
+
+	uc.logger.Infow("nodes exported", "count", len(manifest.Nodes))
+	return manifest, nil
+}
+
+// getNodesBySIDs loads the requested nodes, keeping the requested order.
+func (uc *ExportNodesUseCase) getNodesBySIDs(ctx context.Context, sids []string) ([]*node.Node, error) {
+	found, err := uc.nodeRepo.GetBySIDs(ctx, sids)
+	if err != nil {
+		uc.logger.Errorw("failed to get nodes by SIDs", "error", err)
+		return nil, fmt.Errorf("failed to get nodes: %w", err)
+	}
+	bySID := make(map[string]*node.Node, len(found))
+	for _, n := range found {
+		bySID[n.SID()] = n
+	}
+
+	nodes := make([]*node.Node, 0, len(sids))
+	seen := make(map[string]struct{}, len(sids))
+	for _, sid := range sids {
+		if _, ok := seen[sid]; ok {
+			continue
+		}
+		seen[sid] = struct{}{}
+
+		n, ok := bySID[sid]
+		if !ok || n.IsUserOwned() {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("node not found: %s", sid))
+		}
+		nodes = append(nodes, n)
+	}
+	return nodes, nil
+}
+
+// listNodes pages through all admin nodes matching the query.
+func (uc *ExportNodesUseCase) listNodes(ctx context.Context, query ExportNodesQuery) ([]*node.Node, error) {
+	adminOnly := true
+	filter := node.NodeFilter{AdminOnly: &adminOnly}
+	if query.Tag != "" {
+		filter.Tag = &query.Tag
+	}
+	if query.GroupSID != "" {
+		group, err := uc.resourceGroupRepo.GetBySID(ctx, query.GroupSID)
+		if err != nil {
+			uc.logger.Errorw("failed to get resource group", "sid", query.GroupSID, "error", err)
+			return nil, fmt.Errorf("failed to get resource group: %w", err)
+		}
+		if group == nil {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("resource group not found: %s", query.GroupSID))
+		}
+		filter.GroupIDs = []uint{group.ID()}
+	}
+
+	var nodes []*node.Node
+	for page := 1; ; page++ {
+		// Order by ID so pages are stable and the manifest follows creation order
+		filter.BaseFilter = sharedquery.NewBaseFilter(
+			sharedquery.WithPage(page, constants.MaxPageSize),
+			sharedquery.WithSort("id", "asc"),
+		)
+		batch, total, err := uc.nodeRepo.List(ctx, filter)
+		if err != nil {
+			uc.logger.Errorw("failed to list nodes", "page", page, "error", err)
+			return nil, fmt.Errorf("failed to list nodes: %w", err)
+		}
+		nodes = append(nodes, batch...)
+		if len(batch) < constants.MaxPageSize || int64(len(nodes)) >= total {
+			return nodes, nil
+		}
+	}
+}
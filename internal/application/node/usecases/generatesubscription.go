@@ -5,24 +5,30 @@ import (
 	"crypto/hmac"
 	"crypto/sha256"
 	"encoding/hex"
+	stderrors "errors"
 	"fmt"
 	"math"
+	"slices"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/orris-inc/orris/internal/domain/node"
 	"github.com/orris-inc/orris/internal/domain/node/valueobjects"
 	"github.com/orris-inc/orris/internal/domain/subscription"
 	subvo "github.com/orris-inc/orris/internal/domain/subscription/valueobjects"
-	"github.com/orris-inc/orris/internal/infrastructure/cache"
 	"github.com/orris-inc/orris/internal/infrastructure/config"
 	"github.com/orris-inc/orris/internal/infrastructure/template"
 	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
 	"github.com/orris-inc/orris/internal/shared/logger"
 )
 
 type GenerateSubscriptionCommand struct {
 	SubscriptionToken string
 	Format            string
-	NodeMode          string // "all" | "forward" | "origin", defaults to "all"
+	NodeMode          string                  // "all" | "forward" | "origin", defaults to "all"
+	Filter            *SubscriptionNodeFilter // Optional include/exclude/protocol/limit/rename parameters
 }
 
 type GenerateSubscriptionResult struct {
@@ -30,6 +36,7 @@ type GenerateSubscriptionResult struct {
 	ContentType string
 	Format      string
 	UserInfo    *SubscriptionUserInfo
+	Profile     *SubscriptionProfileInfo
 }
 
 // SubscriptionUserInfo contains traffic usage and subscription expiration info
@@ -41,6 +48,14 @@ type SubscriptionUserInfo struct {
 	Expire   int64  // Subscription end time as Unix timestamp
 }
 
+// SubscriptionProfileInfo contains profile metadata for the Profile-Update-Interval,
+// Profile-Web-Page-Url and Content-Disposition response headers.
+type SubscriptionProfileInfo struct {
+	Title               string // Profile name shown by clients (site name)
+	UpdateIntervalHours int    // Suggested auto-update interval in hours (0 = omit header)
+	WebPageURL          string // User portal URL (empty = omit header)
+}
+
 type SubscriptionValidationResult struct {
 	SubscriptionID        uint
 	SubscriptionUUID      string
@@ -75,15 +90,45 @@ type SubscriptionFormatter interface {
 type SubscriptionSettingProvider interface {
 	// IsShowInfoNodesEnabled returns whether to show info nodes (expire/traffic) in subscription.
 	IsShowInfoNodesEnabled(ctx context.Context) bool
+	// GetProfileInfo returns the profile metadata sent alongside the subscription content.
+	GetProfileInfo(ctx context.Context) *SubscriptionProfileInfo
 }
 
+// SubscriptionTrafficReader reads raw traffic usage for a subscription within a traffic period.
+type SubscriptionTrafficReader interface {
+	// GetCurrentPeriodTraffic returns upload and download bytes for the given period.
+	GetCurrentPeriodTraffic(ctx context.Context, subscriptionID uint, periodStart, periodEnd time.Time) (uint64, uint64, error)
+}
+
+// UserAgentFormatRule maps a User-Agent substring to a subscription format.
+// Pattern matching is case-insensitive; rules are evaluated in order and the first match wins.
+type UserAgentFormatRule struct {
+	Pattern string `json:"pattern" binding:"required,max=100"`
+	Format  string `json:"format" binding:"required"`
+}
+
+// Validate checks that the rule has a pattern and a format listed in SubscriptionFormats.
+func (r UserAgentFormatRule) Validate() error {
+	if strings.TrimSpace(r.Pattern) == "" {
+		return errors.NewValidationError("user agent rule pattern is required")
+	}
+	if !slices.Contains(SubscriptionFormats, r.Format) {
+		return errors.NewValidationError(fmt.Sprintf("unsupported subscription format %q, must be one of: %s",
+			r.Format, strings.Join(SubscriptionFormats, ", ")))
+	}
+	return nil
+}
+
+// SubscriptionFormats lists all formats supported by GenerateSubscriptionUseCase.
+var SubscriptionFormats = []string{"base64", "clash", "surge", "quanx", "loon", "v2ray", "sip008", "singbox", "wireguard"}
+
 type GenerateSubscriptionUseCase struct {
 	nodeRepo        NodeRepository
 	tokenValidator  SubscriptionTokenValidator
 	planRepo        subscription.PlanRepository
-	usageStatsRepo  subscription.SubscriptionUsageStatsRepository
-	hourlyCache     cache.HourlyTrafficCache
+	trafficReader   SubscriptionTrafficReader // optional, nil-safe
 	settingProvider SubscriptionSettingProvider
+	peerAllocator   WireGuardPeerAllocator // optional, WireGuard nodes are omitted without it
 	formatters      map[string]SubscriptionFormatter
 	logger          logger.Interface
 }
@@ -93,8 +138,6 @@ func NewGenerateSubscriptionUseCase(
 	tokenValidator SubscriptionTokenValidator,
 	templateLoader *template.SubscriptionTemplateLoader,
 	planRepo subscription.PlanRepository,
-	usageStatsRepo subscription.SubscriptionUsageStatsRepository,
-	hourlyCache cache.HourlyTrafficCache,
 	settingProvider SubscriptionSettingProvider,
 	logger logger.Interface,
 ) *GenerateSubscriptionUseCase {
@@ -102,8 +145,6 @@ func NewGenerateSubscriptionUseCase(
 		nodeRepo:        nodeRepo,
 		tokenValidator:  tokenValidator,
 		planRepo:        planRepo,
-		usageStatsRepo:  usageStatsRepo,
-		hourlyCache:     hourlyCache,
 		settingProvider: settingProvider,
 		formatters:      make(map[string]SubscriptionFormatter),
 		logger:          logger,
@@ -112,18 +153,32 @@ func NewGenerateSubscriptionUseCase(
 	// Create template renderer
 	renderer := NewTemplateRenderer(templateLoader)
 
-	// Use template-aware formatters for clash and surge
+	// Use template-aware formatters for clash, surge, quanx and loon
 	uc.formatters["clash"] = NewTemplateClashFormatter(renderer)
 	uc.formatters["surge"] = NewTemplateSurgeFormatter(renderer)
+	uc.formatters["quanx"] = NewTemplateQuantumultXFormatter(renderer)
+	uc.formatters["loon"] = NewTemplateLoonFormatter(renderer)
 
 	// Keep original formatters for other formats
 	uc.formatters["base64"] = NewBase64Formatter()
 	uc.formatters["v2ray"] = NewV2RayFormatter()
 	uc.formatters["sip008"] = NewSIP008Formatter()
+	uc.formatters["singbox"] = NewSingBoxFormatter()
+	uc.formatters["wireguard"] = NewWireGuardFormatter()
 
 	return uc
 }
 
+// SetTrafficReader sets the reader used to report traffic usage in the Subscription-Userinfo header (optional).
+func (uc *GenerateSubscriptionUseCase) SetTrafficReader(reader SubscriptionTrafficReader) {
+	uc.trafficReader = reader
+}
+
+// SetWireGuardPeerAllocator sets the allocator of the subscription's WireGuard tunnel addresses (optional).
+func (uc *GenerateSubscriptionUseCase) SetWireGuardPeerAllocator(allocator WireGuardPeerAllocator) {
+	uc.peerAllocator = allocator
+}
+
 func (uc *GenerateSubscriptionUseCase) Execute(ctx context.Context, cmd GenerateSubscriptionCommand) (*GenerateSubscriptionResult, error) {
 	// Validate subscription token and get subscription info
 	validationResult, err := uc.tokenValidator.ValidateAndGetSubscription(ctx, cmd.SubscriptionToken)
@@ -141,12 +196,31 @@ func (uc *GenerateSubscriptionUseCase) Execute(ctx context.Context, cmd Generate
 		nodeMode = NodeModeAll
 	}
 
+	var nodeFilter *compiledNodeFilter
+	if !cmd.Filter.IsEmpty() {
+		nodeFilter, err = compileNodeFilter(cmd.Filter)
+		if err != nil {
+			return nil, err
+		}
+	}
+
 	nodes, err := uc.nodeRepo.GetBySubscriptionToken(ctx, cmd.SubscriptionToken, nodeMode)
 	if err != nil {
 		uc.logger.Errorw("failed to get nodes", "error", err)
 		return nil, fmt.Errorf("failed to get nodes: %w", err)
 	}
 
+	// Apply per-request filters before info nodes are added and any formatter runs
+	if nodeFilter != nil {
+		nodes = nodeFilter.apply(nodes)
+	}
+
+	// Allocate this subscription's WireGuard tunnel address on each WireGuard node
+	nodes = uc.assignWireGuardPeers(ctx, nodes, validationResult.SubscriptionID)
+
+	// Show non-default billing rates in node names (e.g. "HK IEPL [3x]")
+	nodes = labelTrafficMultipliers(nodes)
+
 	if len(nodes) == 0 {
 		uc.logger.Warnw("no available nodes found, returning empty subscription", "token", cmd.SubscriptionToken, "mode", nodeMode)
 	}
@@ -198,6 +272,7 @@ func (uc *GenerateSubscriptionUseCase) Execute(ctx context.Context, cmd Generate
 		ContentType: formatter.ContentType(),
 		Format:      cmd.Format,
 		UserInfo:    userInfo,
+		Profile:     uc.buildProfileInfo(ctx),
 	}, nil
 }
 
@@ -246,6 +321,7 @@ func (uc *GenerateSubscriptionUseCase) createInfoNode(template *Node, name strin
 		Protocol:          template.Protocol,
 		EncryptionMethod:  template.EncryptionMethod,
 		TokenHash:         template.TokenHash,
+		ServerKey:         template.ServerKey,
 		Password:          template.Password,
 		Plugin:            template.Plugin,
 		PluginOpts:        pluginOpts,
@@ -255,13 +331,81 @@ func (uc *GenerateSubscriptionUseCase) createInfoNode(template *Node, name strin
 		SNI:               template.SNI,
 		AllowInsecure:     template.AllowInsecure,
 		// Pointer fields are shared (shallow copy) - safe for read-only usage in formatters
+		ShadowTLSConfig: template.ShadowTLSConfig,
 		VLESSConfig:     template.VLESSConfig,
 		VMessConfig:     template.VMessConfig,
 		Hysteria2Config: template.Hysteria2Config,
 		TUICConfig:      template.TUICConfig,
 		AnyTLSConfig:    template.AnyTLSConfig,
+		WireGuardConfig: template.WireGuardConfig,
 		SortOrder:       sortOrder,
+		// Info nodes are display-only entries and reuse the template node's WireGuard peer
+		WireGuardPeerAddress:    template.WireGuardPeerAddress,
+		WireGuardPeerPrivateKey: template.WireGuardPeerPrivateKey,
+	}
+}
+
+// assignWireGuardPeers sets the WireGuard peer address and private key on WireGuard nodes for the
+// given subscription, allocating a peer on nodes where the subscription has none yet. Nodes are
+// copied before modification since repository results may be shared. WireGuard nodes that cannot
+// get a peer (e.g. a full address pool, which the allocator reports to admins) are left out, so
+// the rest of the subscription still works.
+func (uc *GenerateSubscriptionUseCase) assignWireGuardPeers(ctx context.Context, nodes []*Node, subscriptionID uint) []*Node {
+	result := make([]*Node, 0, len(nodes))
+	for _, n := range nodes {
+		if n.Protocol != string(valueobjects.ProtocolWireGuard) {
+			result = append(result, n)
+			continue
+		}
+		if n.WireGuardConfig == nil || uc.peerAllocator == nil {
+			continue
+		}
+
+		peers, err := uc.peerAllocator.AllocatePeers(ctx, n.ID, n.WireGuardConfig, []uint{subscriptionID})
+		if err != nil {
+			if stderrors.Is(err, node.ErrWireGuardPoolExhausted) {
+				uc.logger.Warnw("wireguard address pool exhausted, skipping node",
+					"node_id", n.ID,
+					"subscription_id", subscriptionID,
+					"address_pool", n.WireGuardConfig.AddressPool(),
+				)
+			} else {
+				uc.logger.Errorw("failed to allocate wireguard peer, skipping node",
+					"error", err,
+					"node_id", n.ID,
+					"subscription_id", subscriptionID,
+				)
+			}
+			continue
+		}
+		subPeer, ok := peers[subscriptionID]
+		if !ok {
+			continue
+		}
+
+		peer := *n
+		peer.WireGuardPeerAddress = subPeer.Address()
+		peer.WireGuardPeerPrivateKey = subPeer.PrivateKey()
+		result = append(result, &peer)
 	}
+	return result
+}
+
+// labelTrafficMultipliers appends the billing rate to names of nodes whose usage is not billed 1:1.
+// Nodes are copied before modification since repository results may be shared.
+func labelTrafficMultipliers(nodes []*Node) []*Node {
+	result := make([]*Node, 0, len(nodes))
+	for _, node := range nodes {
+		if node.TrafficMultiplier == nil {
+			result = append(result, node)
+			continue
+		}
+
+		labeled := *node
+		labeled.Name = fmt.Sprintf("%s [%sx]", node.Name, strconv.FormatFloat(*node.TrafficMultiplier, 'f', -1, 64))
+		result = append(result, &labeled)
+	}
+	return result
 }
 
 // formatExpireInfo formats the expiration time for display in node name.
@@ -310,7 +454,6 @@ func formatBytes(bytes uint64) string {
 }
 
 // buildUserInfo constructs SubscriptionUserInfo with traffic usage and expiration info.
-// Uses a hybrid approach: Redis for recent traffic (last 24h) and MySQL stats for historical data.
 func (uc *GenerateSubscriptionUseCase) buildUserInfo(ctx context.Context, validation *SubscriptionValidationResult) *SubscriptionUserInfo {
 	// Get plan to determine traffic limit
 	plan, err := uc.planRepo.GetByID(ctx, validation.PlanID)
@@ -387,91 +530,41 @@ func (uc *GenerateSubscriptionUseCase) buildUserInfo(ctx context.Context, valida
 }
 
 // calculatePeriodTraffic calculates upload and download traffic for the current traffic period.
-// Uses the plan's traffic_reset_mode to determine period boundaries:
-// - lifetime subscriptions: always the full subscription period, regardless of plan mode
-// - billing_cycle: subscription's CurrentPeriodStart/CurrentPeriodEnd
-// - calendar_month: business timezone month boundaries (default, backward compatible)
-// Mirrors the domain's subscription.ResolveTrafficPeriod so the
+// The period is resolved by the domain's subscription.ResolveTrafficPeriodWithBounds so the
 // Subscription-Userinfo header stays consistent with quota/dashboard views.
-// Uses Redis for recent data (last 24h) and MySQL stats for historical data.
 func (uc *GenerateSubscriptionUseCase) calculatePeriodTraffic(ctx context.Context, validation *SubscriptionValidationResult, plan *subscription.Plan) (upload, download uint64) {
-	now := biztime.NowUTC()
-
-	// Determine period based on billing cycle and plan's traffic reset mode.
-	var periodStart, periodEnd time.Time
-	mode := subscription.GetTrafficResetMode(plan)
-	if validation.IsLifetime() || mode == subscription.TrafficResetBillingCycle {
-		periodStart = validation.CurrentPeriodStart
-		periodEnd = validation.CurrentPeriodEnd
-	} else {
-		bizNow := biztime.ToBizTimezone(now)
-		periodStart = biztime.StartOfMonthUTC(bizNow.Year(), bizNow.Month())
-		periodEnd = biztime.EndOfMonthUTC(bizNow.Year(), bizNow.Month())
+	if uc.trafficReader == nil {
+		return 0, 0
 	}
 
-	// If period end is in the future, use current time
-	if periodEnd.After(now) {
-		periodEnd = now
-	}
-
-	// Use start of yesterday's business day as batch/speed boundary (Lambda architecture)
-	// MySQL: complete days before yesterday; Redis: yesterday + today (within 48h TTL)
-	recentBoundary := biztime.StartOfDayUTC(now.AddDate(0, 0, -1))
+	period := subscription.ResolveTrafficPeriodWithBounds(
+		plan, validation.IsLifetime(), validation.CurrentPeriodStart, validation.CurrentPeriodEnd,
+	)
 
-	// Get historical traffic from MySQL stats (complete days before yesterday)
-	if periodStart.Before(recentBoundary) {
-		historicalTo := recentBoundary.Add(-time.Second)
-		if historicalTo.After(periodEnd) {
-			historicalTo = periodEnd
-		}
-		// Aggregate all resource types (nil = no filter)
-		historicalTraffic, err := uc.usageStatsRepo.GetTotalBySubscriptionIDs(
-			ctx, []uint{validation.SubscriptionID}, nil, subscription.GranularityDaily, periodStart, historicalTo,
+	upload, download, err := uc.trafficReader.GetCurrentPeriodTraffic(ctx, validation.SubscriptionID, period.Start, period.End)
+	if err != nil {
+		uc.logger.Warnw("failed to get current period traffic",
+			"subscription_id", validation.SubscriptionID,
+			"period_start", period.Start,
+			"period_end", period.End,
+			"error", err,
 		)
-		if err != nil {
-			uc.logger.Warnw("failed to get historical traffic from stats",
-				"subscription_id", validation.SubscriptionID,
-				"from", periodStart,
-				"to", historicalTo,
-				"error", err,
-			)
-		} else if historicalTraffic != nil {
-			upload += historicalTraffic.Upload
-			download += historicalTraffic.Download
-		}
+		return 0, 0
 	}
 
-	// Get recent traffic from Redis (yesterday + today)
-	recentFrom := periodStart
-	if recentFrom.Before(recentBoundary) {
-		recentFrom = recentBoundary
-	}
+	return upload, download
+}
 
-	if recentFrom.Before(periodEnd) && recentFrom.Before(now) {
-		recentTo := periodEnd
-		if recentTo.After(now) {
-			recentTo = now
-		}
-		// Aggregate all resource types (empty string = no filter)
-		recentTraffic, err := uc.hourlyCache.GetTotalTrafficBySubscriptionIDs(
-			ctx, []uint{validation.SubscriptionID}, "", recentFrom, recentTo,
-		)
-		if err != nil {
-			uc.logger.Warnw("failed to get recent traffic from Redis",
-				"subscription_id", validation.SubscriptionID,
-				"from", recentFrom,
-				"to", recentTo,
-				"error", err,
-			)
-		} else {
-			for _, t := range recentTraffic {
-				upload += t.Upload
-				download += t.Download
-			}
-		}
+// buildProfileInfo returns the profile metadata for response headers.
+// Falls back to an empty profile when no setting provider is configured.
+func (uc *GenerateSubscriptionUseCase) buildProfileInfo(ctx context.Context) *SubscriptionProfileInfo {
+	if uc.settingProvider == nil {
+		return &SubscriptionProfileInfo{}
 	}
-
-	return upload, download
+	if profile := uc.settingProvider.GetProfileInfo(ctx); profile != nil {
+		return profile
+	}
+	return &SubscriptionProfileInfo{}
 }
 
 type Node struct {
@@ -479,12 +572,15 @@ type Node struct {
 	Name             string
 	ServerAddress    string
 	SubscriptionPort uint16 // port for client subscriptions (effective port)
-	Protocol         string // shadowsocks, trojan, vless, vmess, hysteria2, tuic, anytls
+	Protocol         string // shadowsocks, trojan, vless, vmess, hysteria2, tuic, anytls, wireguard
 	EncryptionMethod string // for shadowsocks
 	TokenHash        string // Node token hash for SS2022 ServerKey derivation
+	ServerKey        string // Stored SS2022 server PSK; empty means derived from TokenHash
 	Password         string
 	Plugin           string
 	PluginOpts       map[string]string
+	// Optional ShadowTLS layer in front of a Shadowsocks node (nil = plain Shadowsocks)
+	ShadowTLSConfig *valueobjects.ShadowTLSConfig
 	// Trojan specific fields
 	TransportProtocol string // tcp, ws, grpc
 	Host              string // WebSocket host / gRPC service name
@@ -497,6 +593,14 @@ type Node struct {
 	Hysteria2Config *valueobjects.Hysteria2Config
 	TUICConfig      *valueobjects.TUICConfig
 	AnyTLSConfig    *valueobjects.AnyTLSConfig
+	WireGuardConfig *valueobjects.WireGuardConfig
+	// Tunnel address and peer private key of the subscription on a WireGuard node (set per request)
+	WireGuardPeerAddress    string
+	WireGuardPeerPrivateKey string
+	// ISO 3166-1 alpha-2 location used to build region proxy groups (empty = unknown)
+	CountryCode string
+	// Billing rate shown in the node name (nil = billed 1:1 or not applicable)
+	TrafficMultiplier *float64
 	// Sorting field for subscription output ordering
 	SortOrder int
 }
@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// stubPeerAllocator allocates a fixed address per node, or fails for the nodes in errs.
+type stubPeerAllocator struct {
+	addresses map[uint]string
+	errs      map[uint]error
+}
+
+func (a *stubPeerAllocator) AllocatePeers(ctx context.Context, nodeID uint, cfg *vo.WireGuardConfig, subscriptionIDs []uint) (map[uint]*node.WireGuardPeer, error) {
+	if err := a.errs[nodeID]; err != nil {
+		return map[uint]*node.WireGuardPeer{}, err
+	}
+	peer, err := node.NewWireGuardPeer(subscriptionIDs[0], a.addresses[nodeID])
+	if err != nil {
+		return nil, err
+	}
+	return map[uint]*node.WireGuardPeer{subscriptionIDs[0]: peer}, nil
+}
+
+func TestAssignWireGuardPeers_SkipsNodesWithoutAddress(t *testing.T) {
+	key, err := vo.GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+	wg, err := vo.NewWireGuardConfig(key, "", 0, "", 0)
+	require.NoError(t, err)
+
+	nodes := []*Node{
+		{ID: 1, Name: "WG-Full", Protocol: "wireguard", WireGuardConfig: &wg},
+		{ID: 2, Name: "Trojan", Protocol: "trojan"},
+		{ID: 3, Name: "WG", Protocol: "wireguard", WireGuardConfig: &wg},
+		{ID: 4, Name: "WG-Broken", Protocol: "wireguard", WireGuardConfig: &wg},
+	}
+	uc := &GenerateSubscriptionUseCase{logger: logger.NewLogger()}
+	uc.SetWireGuardPeerAllocator(&stubPeerAllocator{
+		addresses: map[uint]string{3: "10.66.0.9"},
+		errs: map[uint]error{
+			1: fmt.Errorf("%w: node 1 has no free address", node.ErrWireGuardPoolExhausted),
+			4: fmt.Errorf("database unavailable"),
+		},
+	})
+
+	got := uc.assignWireGuardPeers(context.Background(), nodes, 42)
+
+	// Only the WireGuard nodes without a peer are dropped, other nodes are kept
+	assert.Equal(t, []string{"Trojan", "WG"}, nodeNames(got))
+	assert.Equal(t, "10.66.0.9", got[1].WireGuardPeerAddress)
+	assert.NotEmpty(t, got[1].WireGuardPeerPrivateKey)
+	assert.Empty(t, nodes[2].WireGuardPeerAddress, "input nodes are not modified")
+}
+
+func TestAssignWireGuardPeers_NoAllocator(t *testing.T) {
+	key, err := vo.GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+	wg, err := vo.NewWireGuardConfig(key, "", 0, "", 0)
+	require.NoError(t, err)
+
+	uc := &GenerateSubscriptionUseCase{logger: logger.NewLogger()}
+	got := uc.assignWireGuardPeers(context.Background(), []*Node{
+		{ID: 1, Name: "WG", Protocol: "wireguard", WireGuardConfig: &wg},
+		{ID: 2, Name: "SS", Protocol: "shadowsocks"},
+	}, 42)
+	assert.Equal(t, []string{"SS"}, nodeNames(got))
+}
+
+func TestUserAgentFormatRule_Validate(t *testing.T) {
+	for _, format := range SubscriptionFormats {
+		assert.NoError(t, UserAgentFormatRule{Pattern: "client", Format: format}.Validate(), format)
+	}
+
+	assert.Error(t, UserAgentFormatRule{Pattern: " ", Format: "clash"}.Validate())
+	assert.Error(t, UserAgentFormatRule{Pattern: "client", Format: "json"}.Validate())
+}
@@ -0,0 +1,55 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type GetCertificateQuery struct {
+	SID string // External API identifier
+}
+
+type GetCertificateUseCase struct {
+	certRepo node.CertificateRepository
+	logger   logger.Interface
+}
+
+func NewGetCertificateUseCase(
+	certRepo node.CertificateRepository,
+	logger logger.Interface,
+) *GetCertificateUseCase {
+	return &GetCertificateUseCase{
+		certRepo: certRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *GetCertificateUseCase) Execute(ctx context.Context, query GetCertificateQuery) (*dto.CertificateDTO, error) {
+	cert, err := getCertificateBySID(ctx, uc.certRepo, uc.logger, query.SID)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToCertificateDTO(cert), nil
+}
+
+// getCertificateBySID loads a certificate, returning a not-found error if it does not exist.
+func getCertificateBySID(ctx context.Context, certRepo node.CertificateRepository, log logger.Interface, sid string) (*node.Certificate, error) {
+	if sid == "" {
+		return nil, errors.NewValidationError("SID must be provided")
+	}
+
+	cert, err := certRepo.GetBySID(ctx, sid)
+	if err != nil {
+		log.Errorw("failed to get certificate by SID", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+	if cert == nil {
+		return nil, errors.NewNotFoundError("certificate not found")
+	}
+	return cert, nil
+}
@@ -134,7 +134,7 @@ func (uc *GetNodeConfigUseCase) Execute(ctx context.Context, cmd GetNodeConfigCo
 	// Server key function for referenced nodes
 	serverKeyFunc := func(refNode *node.Node) string {
 		if refNode.Protocol().IsShadowsocks() {
-			return vo.GenerateShadowsocksServerPassword(refNode.TokenHash(), refNode.EncryptionConfig().Method())
+			return refNode.EncryptionConfig().ForwardingPassword(refNode.TokenHash())
 		}
 		// For Trojan, generate password from token hash for node-to-node forwarding
 		if refNode.Protocol().IsTrojan() {
@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// NodeMetricsHistoryQuerier defines the interface for querying node system metrics history
+type NodeMetricsHistoryQuerier interface {
+	GetNodeMetrics(ctx context.Context, nodeID uint, rng commondto.SystemMetricsRange) ([]commondto.SystemMetricsPoint, error)
+}
+
+// GetNodeMetricsQuery represents the query for a node's system metrics history
+type GetNodeMetricsQuery struct {
+	SID        string
+	From       time.Time
+	To         time.Time
+	Resolution string // "minute", "hour", or empty to pick by range
+}
+
+// GetNodeMetricsUseCase returns the downsampled CPU, memory, disk, network and connection history of a node
+type GetNodeMetricsUseCase struct {
+	nodeRepo       node.NodeRepository
+	historyQuerier NodeMetricsHistoryQuerier
+	logger         logger.Interface
+}
+
+// NewGetNodeMetricsUseCase creates a new get node metrics use case
+func NewGetNodeMetricsUseCase(
+	nodeRepo node.NodeRepository,
+	historyQuerier NodeMetricsHistoryQuerier,
+	logger logger.Interface,
+) *GetNodeMetricsUseCase {
+	return &GetNodeMetricsUseCase{
+		nodeRepo:       nodeRepo,
+		historyQuerier: historyQuerier,
+		logger:         logger,
+	}
+}
+
+// Execute retrieves the metrics time series of a node
+func (uc *GetNodeMetricsUseCase) Execute(ctx context.Context, query GetNodeMetricsQuery) (*commondto.SystemMetricsSeries, error) {
+	rng, err := commondto.NewSystemMetricsRange(query.Resolution, query.From, query.To, biztime.NowUTC())
+	if err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	nodeEntity, err := uc.nodeRepo.GetBySID(ctx, query.SID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	if nodeEntity == nil {
+		return nil, errors.NewNotFoundError("node not found", query.SID)
+	}
+
+	points, err := uc.historyQuerier.GetNodeMetrics(ctx, nodeEntity.ID(), rng)
+	if err != nil {
+		uc.logger.Errorw("failed to get node metrics history", "node_id", nodeEntity.ID(), "error", err)
+		return nil, errors.NewInternalError("failed to get node metrics")
+	}
+
+	return &commondto.SystemMetricsSeries{
+		Resolution: rng.Resolution,
+		From:       rng.From,
+		To:         rng.To,
+		Points:     points,
+	}, nil
+}
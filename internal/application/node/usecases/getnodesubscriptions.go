@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/application/node/services"
 	"github.com/orris-inc/orris/internal/domain/node"
 	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
 	"github.com/orris-inc/orris/internal/domain/subscription"
@@ -27,9 +28,18 @@ type GetNodeSubscriptionsUseCase struct {
 	subscriptionRepo subscription.SubscriptionRepository
 	planRepo         subscription.PlanRepository
 	nodeRepo         node.NodeRepository
+	blockChecker     services.SubscriptionBlockChecker
+	peerAllocator    WireGuardPeerAllocator
 	logger           logger.Interface
 }
 
+// WireGuardPeerAllocator allocates tunnel addresses and keypairs of WireGuard nodes to subscriptions.
+// When the node's address pool is full, the peers allocated so far are returned together
+// with an error wrapping node.ErrWireGuardPoolExhausted.
+type WireGuardPeerAllocator interface {
+	AllocatePeers(ctx context.Context, nodeID uint, cfg *vo.WireGuardConfig, subscriptionIDs []uint) (map[uint]*node.WireGuardPeer, error)
+}
+
 // NewGetNodeSubscriptionsUseCase creates a new instance of GetNodeSubscriptionsUseCase
 func NewGetNodeSubscriptionsUseCase(
 	subscriptionRepo subscription.SubscriptionRepository,
@@ -45,6 +55,17 @@ func NewGetNodeSubscriptionsUseCase(
 	}
 }
 
+// SetBlockChecker sets the checker used to withhold blocked subscriptions (optional).
+func (uc *GetNodeSubscriptionsUseCase) SetBlockChecker(checker services.SubscriptionBlockChecker) {
+	uc.blockChecker = checker
+}
+
+// SetWireGuardPeerAllocator sets the allocator of WireGuard peer addresses (optional).
+// Without it WireGuard nodes get no peers.
+func (uc *GetNodeSubscriptionsUseCase) SetWireGuardPeerAllocator(allocator WireGuardPeerAllocator) {
+	uc.peerAllocator = allocator
+}
+
 // Execute retrieves the list of subscriptions authorized to use the node
 func (uc *GetNodeSubscriptionsUseCase) Execute(ctx context.Context, cmd GetNodeSubscriptionsCommand) (*GetNodeSubscriptionsResult, error) {
 	if cmd.NodeID == 0 {
@@ -77,6 +98,8 @@ func (uc *GetNodeSubscriptionsUseCase) Execute(ctx context.Context, cmd GetNodeS
 		return nil, fmt.Errorf("failed to retrieve subscriptions for node")
 	}
 
+	subscriptions = services.WithoutBlockedSubscriptions(ctx, uc.blockChecker, uc.logger, subscriptions)
+
 	// Collect unique plan IDs and batch load device limits
 	planDeviceLimits := uc.loadPlanDeviceLimits(ctx, subscriptions)
 
@@ -86,6 +109,12 @@ func (uc *GetNodeSubscriptionsUseCase) Execute(ctx context.Context, cmd GetNodeS
 	// Convert subscriptions to agent subscriptions response
 	subscriptionInfos := dto.ToNodeSubscriptionsResponse(subscriptions, hmacSecret, encryptionMethod, planDeviceLimits)
 
+	// WireGuard peers need a public key and tunnel address per subscription
+	if nodeEntity.Protocol().IsWireGuard() {
+		peers := uc.allocateWireGuardPeers(ctx, nodeEntity, subscriptions)
+		subscriptionInfos.Subscriptions = dto.ApplyWireGuardPeers(subscriptionInfos.Subscriptions, subscriptions, peers)
+	}
+
 	// Add a special node-to-node forwarding user
 	// This allows other nodes to forward traffic to this node using a derived password
 	var nodeForwardingPassword string
@@ -150,3 +179,30 @@ func (uc *GetNodeSubscriptionsUseCase) loadPlanDeviceLimits(ctx context.Context,
 
 	return dto.BuildPlanDeviceLimits(plans)
 }
+
+// allocateWireGuardPeers returns the peers of the subscriptions on a WireGuard node.
+// Allocation failures are logged rather than failing the sync, so the node still receives the
+// peers that have an address and drops the ones that are gone.
+func (uc *GetNodeSubscriptionsUseCase) allocateWireGuardPeers(ctx context.Context, n *node.Node, subscriptions []*subscription.Subscription) map[uint]*node.WireGuardPeer {
+	if uc.peerAllocator == nil {
+		return nil
+	}
+
+	subIDs := make([]uint, 0, len(subscriptions))
+	for _, sub := range subscriptions {
+		if sub != nil {
+			subIDs = append(subIDs, sub.ID())
+		}
+	}
+
+	peers, err := uc.peerAllocator.AllocatePeers(ctx, n.ID(), n.WireGuardConfig(), subIDs)
+	if err != nil {
+		uc.logger.Errorw("failed to allocate wireguard peers",
+			"error", err,
+			"node_id", n.ID(),
+			"allocated", len(peers),
+			"subscriptions", len(subIDs),
+		)
+	}
+	return peers
+}
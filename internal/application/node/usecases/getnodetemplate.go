@@ -0,0 +1,119 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type GetNodeTemplateQuery struct {
+	SID string // External API identifier
+}
+
+type GetNodeTemplateUseCase struct {
+	templateRepo      node.NodeTemplateRepository
+	resourceGroupRepo resource.Repository
+	logger            logger.Interface
+}
+
+func NewGetNodeTemplateUseCase(
+	templateRepo node.NodeTemplateRepository,
+	resourceGroupRepo resource.Repository,
+	logger logger.Interface,
+) *GetNodeTemplateUseCase {
+	return &GetNodeTemplateUseCase{
+		templateRepo:      templateRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		logger:            logger,
+	}
+}
+
+func (uc *GetNodeTemplateUseCase) Execute(ctx context.Context, query GetNodeTemplateQuery) (*dto.NodeTemplateDTO, error) {
+	template, err := getNodeTemplateBySID(ctx, uc.templateRepo, uc.logger, query.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	groupSIDs, err := getTemplateGroupSIDs(ctx, uc.resourceGroupRepo, uc.logger, template)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToNodeTemplateDTO(template, groupSIDs), nil
+}
+
+// getNodeTemplateBySID loads a node template, returning a not-found error if it does not exist.
+func getNodeTemplateBySID(ctx context.Context, templateRepo node.NodeTemplateRepository, log logger.Interface, sid string) (*node.NodeTemplate, error) {
+	if sid == "" {
+		return nil, errors.NewValidationError("SID must be provided")
+	}
+
+	template, err := templateRepo.GetBySID(ctx, sid)
+	if err != nil {
+		log.Errorw("failed to get node template by SID", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to get node template: %w", err)
+	}
+	if template == nil {
+		return nil, errors.NewNotFoundError("node template not found")
+	}
+	return template, nil
+}
+
+// getTemplateSourceNode loads the admin node a template is captured from or a node is cloned from.
+func getTemplateSourceNode(ctx context.Context, nodeRepo node.NodeRepository, log logger.Interface, sid string) (*node.Node, error) {
+	n, err := nodeRepo.GetBySID(ctx, sid)
+	if err != nil {
+		log.Errorw("failed to get node by SID", "sid", sid, "error", err)
+		return nil, errors.NewNotFoundError("node not found")
+	}
+	if n == nil || n.IsUserOwned() {
+		return nil, errors.NewNotFoundError("node not found")
+	}
+	return n, nil
+}
+
+// getTemplateGroupSIDs maps the resource groups of templates to their SIDs.
+func getTemplateGroupSIDs(ctx context.Context, resourceGroupRepo resource.Repository, log logger.Interface, templates ...*node.NodeTemplate) (map[uint]string, error) {
+	var groupIDs []uint
+	for _, t := range templates {
+		groupIDs = append(groupIDs, t.GroupIDs()...)
+	}
+	if len(groupIDs) == 0 {
+		return nil, nil
+	}
+
+	groupSIDs, err := resourceGroupRepo.GetSIDsByIDs(ctx, groupIDs)
+	if err != nil {
+		log.Errorw("failed to get resource group SIDs", "error", err)
+		return nil, fmt.Errorf("failed to get resource groups: %w", err)
+	}
+	return groupSIDs, nil
+}
+
+// resolveTemplateGroupIDs resolves resource group SIDs to IDs, rejecting unknown groups.
+func resolveTemplateGroupIDs(ctx context.Context, resourceGroupRepo resource.Repository, log logger.Interface, sids []string) ([]uint, error) {
+	sids = deduplicateSIDs(sids)
+	if len(sids) == 0 {
+		return nil, nil
+	}
+
+	groups, err := resourceGroupRepo.GetBySIDs(ctx, sids)
+	if err != nil {
+		log.Errorw("failed to get resource groups by SIDs", "error", err)
+		return nil, fmt.Errorf("failed to get resource groups: %w", err)
+	}
+
+	groupIDs := make([]uint, 0, len(sids))
+	for _, sid := range sids {
+		group, ok := groups[sid]
+		if !ok {
+			return nil, errors.NewNotFoundError(fmt.Sprintf("resource group not found: %s", sid))
+		}
+		groupIDs = append(groupIDs, group.ID())
+	}
+	return groupIDs, nil
+}
@@ -0,0 +1,247 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// Import entry statuses.
+const (
+	ImportNodeStatusValid   = "valid"
+	ImportNodeStatusInvalid = "invalid"
+	ImportNodeStatusCreated = "created"
+	ImportNodeStatusFailed  = "failed"
+)
+
+type ImportNodesCommand struct {
+	Nodes      []dto.NodeManifestEntry
+	DryRun     bool // Only validate, nothing is created
+	ImportedBy uint // User importing the nodes, recorded in the configuration history
+}
+
+// ImportNodeResult is the outcome for a single manifest entry.
+type ImportNodeResult struct {
+	Index    int    `json:"index" example:"0" description:"Position of the entry in the manifest"`
+	Name     string `json:"name" example:"HK-Node-01"`
+	Status   string `json:"status" example:"created" enums:"valid,invalid,created,failed"`
+	NodeSID  string `json:"node_id,omitempty" example:"node_xK9mP2vL3nQ"`
+	APIToken string `json:"api_token,omitempty" description:"Agent API token, only returned once"`
+	Error    string `json:"error,omitempty"`
+}
+
+type ImportNodesResult struct {
+	DryRun  bool               `json:"dry_run"`
+	Valid   bool               `json:"valid" description:"Whether every entry passed validation"`
+	Created int                `json:"created" description:"Number of nodes created"`
+	Nodes   []ImportNodeResult `json:"nodes"`
+}
+
+// nodeCreator validates and creates a single node.
+type nodeCreator interface {
+	Validate(ctx context.Context, cmd CreateNodeCommand) error
+	Execute(ctx context.Context, cmd CreateNodeCommand) (*CreateNodeResult, error)
+}
+
+type ImportNodesUseCase struct {
+	creator nodeCreator
+	logger  logger.Interface
+}
+
+func NewImportNodesUseCase(
+	creator *CreateNodeUseCase,
+	logger logger.Interface,
+) *ImportNodesUseCase {
+	return &ImportNodesUseCase{
+		creator: creator,
+		logger:  logger,
+	}
+}
+
+// Execute validates every entry first and only creates nodes when all of them are valid,
+// so a manifest with mistakes never results in a partial import. Failures while creating
+// (e.g. a concurrent insert of the same name) are reported per entry.
+func (uc *ImportNodesUseCase) Execute(ctx context.Context, cmd ImportNodesCommand) (*ImportNodesResult, error) {
+	if len(cmd.Nodes) == 0 {
+		return nil, errors.NewValidationError("no nodes to import")
+	}
+	if len(cmd.Nodes) > MaxImportNodes {
+		return nil, errors.NewValidationError(fmt.Sprintf("at most %d nodes can be imported at once", MaxImportNodes))
+	}
+
+	uc.logger.Infow("executing import nodes use case", "count", len(cmd.Nodes), "dry_run", cmd.DryRun)
+
+	result := &ImportNodesResult{
+		DryRun: cmd.DryRun,
+		Valid:  true,
+		Nodes:  make([]ImportNodeResult, len(cmd.Nodes)),
+	}
+
+	commands := make([]CreateNodeCommand, len(cmd.Nodes))
+	seenNames := make(map[string]int, len(cmd.Nodes))
+	seenAddresses := make(map[string]int, len(cmd.Nodes))
+	for i, entry := range cmd.Nodes {
+		commands[i] = manifestEntryToCommand(entry, cmd.ImportedBy)
+		result.Nodes[i] = ImportNodeResult{Index: i, Name: entry.Name, Status: ImportNodeStatusValid}
+
+		// Entries are validated against the database one by one, so duplicates within
+		// the manifest itself have to be checked here
+		err := uc.creator.Validate(ctx, commands[i])
+		if err == nil {
+			if first, ok := seenNames[entry.Name]; ok {
+				err = errors.NewConflictError(fmt.Sprintf("duplicate node name, same as entry %d", first))
+			}
+		}
+		if err == nil && entry.ServerAddress != "" {
+			address := fmt.Sprintf("%s:%d", entry.ServerAddress, entry.AgentPort)
+			if first, ok := seenAddresses[address]; ok {
+				err = errors.NewConflictError(fmt.Sprintf("duplicate server address and port, same as entry %d", first))
+			}
+			seenAddresses[address] = i
+		}
+		if _, ok := seenNames[entry.Name]; !ok {
+			seenNames[entry.Name] = i
+		}
+
+		if err != nil {
+			result.Valid = false
+			result.Nodes[i].Status = ImportNodeStatusInvalid
+			result.Nodes[i].Error = importErrorMessage(err)
+		}
+	}
+
+	if !result.Valid || cmd.DryRun {
+		uc.logger.Infow("node import validated", "count", len(cmd.Nodes), "valid", result.Valid, "dry_run", cmd.DryRun)
+		return result, nil
+	}
+
+	for i := range commands {
+		created, err := uc.creator.Execute(ctx, commands[i])
+		if err != nil {
+			uc.logger.Warnw("failed to import node", "index", i, "name", commands[i].Name, "error", err)
+			result.Nodes[i].Status = ImportNodeStatusFailed
+			result.Nodes[i].Error = importErrorMessage(err)
+			continue
+		}
+		result.Created++
+		result.Nodes[i].Status = ImportNodeStatusCreated
+		result.Nodes[i].NodeSID = created.NodeSID
+		result.Nodes[i].APIToken = created.APIToken
+	}
+
+	uc.logger.Infow("nodes imported", "count", len(cmd.Nodes), "created", result.Created)
+	return result, nil
+}
+
+// importErrorMessage returns the message of an application error without its type prefix.
+func importErrorMessage(err error) string {
+	if appErr := errors.GetAppError(err); appErr != nil {
+		if appErr.Details != "" {
+			return fmt.Sprintf("%s (%s)", appErr.Message, appErr.Details)
+		}
+		return appErr.Message
+	}
+	return err.Error()
+}
+
+// manifestEntryToCommand maps a manifest entry to a create node command.
+func manifestEntryToCommand(entry dto.NodeManifestEntry, createdBy uint) CreateNodeCommand {
+	cmd := CreateNodeCommand{
+		Name:              entry.Name,
+		ServerAddress:     entry.ServerAddress,
+		AgentPort:         entry.AgentPort,
+		SubscriptionPort:  entry.SubscriptionPort,
+		Protocol:          entry.Protocol,
+		Method:            entry.EncryptionMethod,
+		ServerKey:         entry.ServerKey,
+		PluginOpts:        entry.PluginOpts,
+		Region:            entry.Region,
+		CountryCode:       entry.CountryCode,
+		TrafficMultiplier: entry.TrafficMultiplier,
+		Tags:              entry.Tags,
+		Description:       entry.Description,
+		SortOrder:         entry.SortOrder,
+		GroupSIDs:         entry.GroupSIDs,
+		CreatedBy:         createdBy,
+		TransportProtocol: entry.TransportProtocol,
+		Host:              entry.Host,
+		Path:              entry.Path,
+		SNI:               entry.SNI,
+		AllowInsecure:     entry.AllowInsecure,
+		Route:             entry.Route,
+		DNS:               entry.DNS,
+
+		VLESSTransportType:                entry.VLESSTransportType,
+		VLESSFlow:                         entry.VLESSFlow,
+		VLESSSecurity:                     entry.VLESSSecurity,
+		VLESSSni:                          entry.VLESSSni,
+		VLESSFingerprint:                  entry.VLESSFingerprint,
+		VLESSAllowInsecure:                entry.VLESSAllowInsecure,
+		VLESSHost:                         entry.VLESSHost,
+		VLESSPath:                         entry.VLESSPath,
+		VLESSServiceName:                  entry.VLESSServiceName,
+		VLESSRealityPrivateKey:            entry.VLESSRealityPrivateKey,
+		VLESSRealityPublicKey:             entry.VLESSRealityPublicKey,
+		VLESSRealityShortID:               entry.VLESSRealityShortID,
+		VLESSRealitySpiderX:               entry.VLESSRealitySpiderX,
+		VLESSXHTTPMode:                    entry.VLESSXHTTPMode,
+		VLESSXHTTPExtra:                   entry.VLESSXHTTPExtra,
+		VLESSRealityRotationIntervalHours: entry.VLESSRealityRotationIntervalHours,
+		VLESSRealityRotationOverlapHours:  entry.VLESSRealityRotationOverlapHours,
+		VLESSRealityRotateKeyPair:         entry.VLESSRealityRotateKeyPair,
+
+		VMessAlterID:       entry.VMessAlterID,
+		VMessSecurity:      entry.VMessSecurity,
+		VMessTransportType: entry.VMessTransportType,
+		VMessHost:          entry.VMessHost,
+		VMessPath:          entry.VMessPath,
+		VMessServiceName:   entry.VMessServiceName,
+		VMessTLS:           entry.VMessTLS,
+		VMessSni:           entry.VMessSni,
+		VMessAllowInsecure: entry.VMessAllowInsecure,
+		VMessXHTTPMode:     entry.VMessXHTTPMode,
+		VMessXHTTPExtra:    entry.VMessXHTTPExtra,
+
+		Hysteria2CongestionControl: entry.Hysteria2CongestionControl,
+		Hysteria2Obfs:              entry.Hysteria2Obfs,
+		Hysteria2ObfsPassword:      entry.Hysteria2ObfsPassword,
+		Hysteria2UpMbps:            entry.Hysteria2UpMbps,
+		Hysteria2DownMbps:          entry.Hysteria2DownMbps,
+		Hysteria2Sni:               entry.Hysteria2Sni,
+		Hysteria2AllowInsecure:     entry.Hysteria2AllowInsecure,
+		Hysteria2Fingerprint:       entry.Hysteria2Fingerprint,
+
+		TUICCongestionControl: entry.TUICCongestionControl,
+		TUICUDPRelayMode:      entry.TUICUDPRelayMode,
+		TUICAlpn:              entry.TUICAlpn,
+		TUICSni:               entry.TUICSni,
+		TUICAllowInsecure:     entry.TUICAllowInsecure,
+		TUICDisableSNI:        entry.TUICDisableSNI,
+
+		AnyTLSSni:                      entry.AnyTLSSni,
+		AnyTLSAllowInsecure:            entry.AnyTLSAllowInsecure,
+		AnyTLSFingerprint:              entry.AnyTLSFingerprint,
+		AnyTLSIdleSessionCheckInterval: entry.AnyTLSIdleSessionCheckInterval,
+		AnyTLSIdleSessionTimeout:       entry.AnyTLSIdleSessionTimeout,
+		AnyTLSMinIdleSession:           entry.AnyTLSMinIdleSession,
+
+		WireGuardPrivateKey:          entry.WireGuardPrivateKey,
+		WireGuardAddressPool:         entry.WireGuardAddressPool,
+		WireGuardMTU:                 entry.WireGuardMTU,
+		WireGuardDNS:                 entry.WireGuardDNS,
+		WireGuardPersistentKeepalive: entry.WireGuardPersistentKeepalive,
+
+		ShadowTLSHandshakeServer: entry.ShadowTLSHandshakeServer,
+		ShadowTLSHandshakePort:   entry.ShadowTLSHandshakePort,
+		ShadowTLSPassword:        entry.ShadowTLSPassword,
+		ShadowTLSVersion:         entry.ShadowTLSVersion,
+	}
+	if entry.Plugin != "" {
+		plugin := entry.Plugin
+		cmd.Plugin = &plugin
+	}
+	return cmd
+}
@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// fakeImportNodeRepo reports every name and address as free; other methods panic.
+type fakeImportNodeRepo struct {
+	node.NodeRepository
+}
+
+func (r *fakeImportNodeRepo) ExistsByName(ctx context.Context, name string) (bool, error) {
+	return false, nil
+}
+
+func (r *fakeImportNodeRepo) ExistsByAddress(ctx context.Context, address string, port int) (bool, error) {
+	return false, nil
+}
+
+// roundTripManifestEntry exports a node with secrets, encodes and decodes the entry and
+// builds the node an import would create from it.
+func roundTripManifestEntry(t *testing.T, uc *CreateNodeUseCase, n *node.Node) *node.Node {
+	t.Helper()
+
+	data, err := json.Marshal(dto.ToNodeManifestEntry(n, nil, true))
+	require.NoError(t, err)
+	var entry dto.NodeManifestEntry
+	require.NoError(t, json.Unmarshal(data, &entry))
+
+	imported, err := uc.buildNode(context.Background(), manifestEntryToCommand(entry, 1))
+	require.NoError(t, err)
+	return imported
+}
+
+func TestManifestRoundTrip_SS2022ServerKey(t *testing.T) {
+	uc := NewCreateNodeUseCase(&fakeImportNodeRepo{}, nil, logger.NewLogger())
+	source, err := uc.buildNode(context.Background(), CreateNodeCommand{
+		Name:      "SS-01",
+		AgentPort: 8388,
+		Protocol:  "shadowsocks",
+		Method:    vo.Method2022Blake3AES256GCM,
+	})
+	require.NoError(t, err)
+
+	t.Run("derived key", func(t *testing.T) {
+		imported := roundTripManifestEntry(t, uc, source)
+		assert.NotEqual(t, source.TokenHash(), imported.TokenHash())
+		assert.Equal(t,
+			source.EncryptionConfig().EffectiveServerKey(source.TokenHash()),
+			imported.EncryptionConfig().EffectiveServerKey(imported.TokenHash()))
+	})
+
+	t.Run("rotated key", func(t *testing.T) {
+		require.NoError(t, source.RotateServerKey())
+		imported := roundTripManifestEntry(t, uc, source)
+		assert.Equal(t, source.EncryptionConfig().ServerKey(), imported.EncryptionConfig().ServerKey())
+	})
+
+	t.Run("without secrets", func(t *testing.T) {
+		entry := dto.ToNodeManifestEntry(source, nil, false)
+		assert.Empty(t, entry.ServerKey)
+	})
+}
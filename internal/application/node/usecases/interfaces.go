@@ -1,6 +1,11 @@
 package usecases
 
-import "context"
+import (
+	"context"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
 
 type CreateNodeExecutor interface {
 	Execute(ctx context.Context, cmd CreateNodeCommand) (*CreateNodeResult, error)
@@ -38,3 +43,37 @@ type NodeConfigChangeNotifier interface {
 	// This is called when a node's configuration (including route config) is updated.
 	NotifyConfigChange(ctx context.Context, nodeID uint) error
 }
+
+// NodeConfigVersionRecorder records versions of a node's protocol, route and DNS configuration.
+type NodeConfigVersionRecorder interface {
+	// Record stores the node's current configuration as a new version.
+	// previous is the configuration before the change, or nil for new nodes.
+	// Returns nil if the configuration did not change.
+	Record(
+		ctx context.Context,
+		n *node.Node,
+		previous *node.ConfigSnapshot,
+		authorID *uint,
+		source node.ConfigVersionSource,
+		restoredFrom *int,
+	) (*node.ConfigVersion, error)
+}
+
+// MaintenanceNotice is a user-facing notice about scheduled node maintenance.
+type MaintenanceNotice struct {
+	Title     string
+	Content   string
+	CreatorID uint
+	ExpiresAt time.Time
+	UserIDs   []uint // subscribers of the affected nodes, notified individually
+}
+
+// MaintenanceNoticePublisher publishes and withdraws maintenance notices.
+// The notice is published as a maintenance announcement; affected subscribers
+// additionally receive an in-app notification.
+type MaintenanceNoticePublisher interface {
+	// PublishMaintenanceNotice publishes the notice and returns the announcement ID.
+	PublishMaintenanceNotice(ctx context.Context, notice MaintenanceNotice) (uint, error)
+	// WithdrawMaintenanceNotice archives a published maintenance announcement.
+	WithdrawMaintenanceNotice(ctx context.Context, announcementID uint) error
+}
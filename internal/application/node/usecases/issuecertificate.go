@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type IssueCertificateCommand struct {
+	SID string // External API identifier
+}
+
+// IssueCertificateUseCase starts an issuance (or renewal) of a certificate on demand.
+// The ACME order runs in the background; the returned certificate is in the issuing state.
+type IssueCertificateUseCase struct {
+	certRepo node.CertificateRepository
+	issuance *certificateIssuance
+	logger   logger.Interface
+}
+
+// NewIssueCertificateUseCase creates the use case. issuer is nil when ACME is disabled.
+func NewIssueCertificateUseCase(
+	certRepo node.CertificateRepository,
+	issuer CertificateIssuer,
+	logger logger.Interface,
+) *IssueCertificateUseCase {
+	return &IssueCertificateUseCase{
+		certRepo: certRepo,
+		issuance: &certificateIssuance{certRepo: certRepo, issuer: issuer, logger: logger},
+		logger:   logger,
+	}
+}
+
+// SetDeliverer sets the deliverer used to push issued certificates to node agents.
+func (uc *IssueCertificateUseCase) SetDeliverer(deliverer CertificateDeliverer) {
+	uc.issuance.deliverer = deliverer
+}
+
+func (uc *IssueCertificateUseCase) Execute(ctx context.Context, cmd IssueCertificateCommand) (*dto.CertificateDTO, error) {
+	if uc.issuance.issuer == nil {
+		return nil, errors.NewValidationError("ACME certificate issuance is not enabled")
+	}
+
+	cert, err := getCertificateBySID(ctx, uc.certRepo, uc.logger, cmd.SID)
+	if err != nil {
+		return nil, err
+	}
+	if !uc.issuance.issuer.HasDNSProvider(cert.DNSProvider()) {
+		return nil, errors.NewValidationError(fmt.Sprintf("DNS provider %q is not configured", cert.DNSProvider()))
+	}
+
+	claimed, err := uc.issuance.claim(ctx, cert)
+	if err != nil {
+		uc.logger.Errorw("failed to claim certificate issuance", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to start certificate issuance: %w", err)
+	}
+	if !claimed {
+		return nil, errors.NewConflictError("certificate issuance is already in progress")
+	}
+
+	uc.logger.Infow("certificate issuance started", "sid", cmd.SID, "domain", cert.Domain())
+	uc.issuance.issueAsync(cert)
+
+	return dto.ToCertificateDTO(cert), nil
+}
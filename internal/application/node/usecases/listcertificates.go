@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type ListCertificatesQuery struct {
+	Status   *string
+	Page     int
+	PageSize int
+}
+
+type ListCertificatesResult struct {
+	Certificates []*dto.CertificateDTO `json:"items"`
+	Total        int64                 `json:"total"`
+}
+
+type ListCertificatesUseCase struct {
+	certRepo node.CertificateRepository
+	logger   logger.Interface
+}
+
+func NewListCertificatesUseCase(
+	certRepo node.CertificateRepository,
+	logger logger.Interface,
+) *ListCertificatesUseCase {
+	return &ListCertificatesUseCase{
+		certRepo: certRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *ListCertificatesUseCase) Execute(ctx context.Context, query ListCertificatesQuery) (*ListCertificatesResult, error) {
+	filter := node.CertificateFilter{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	if query.Status != nil {
+		status := node.CertificateStatus(*query.Status)
+		if !status.IsValid() {
+			return nil, errors.NewValidationError("invalid certificate status: " + *query.Status)
+		}
+		filter.Status = &status
+	}
+
+	certs, total, err := uc.certRepo.List(ctx, filter)
+	if err != nil {
+		uc.logger.Errorw("failed to list certificates", "error", err)
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	result := &ListCertificatesResult{
+		Certificates: make([]*dto.CertificateDTO, 0, len(certs)),
+		Total:        total,
+	}
+	for _, c := range certs {
+		result.Certificates = append(result.Certificates, dto.ToCertificateDTO(c))
+	}
+
+	return result, nil
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type ListMaintenanceWindowsQuery struct {
+	Status   *string
+	Page     int
+	PageSize int
+}
+
+type ListMaintenanceWindowsResult struct {
+	Windows []*dto.MaintenanceWindowDTO `json:"items"`
+	Total   int64                       `json:"total"`
+}
+
+type ListMaintenanceWindowsUseCase struct {
+	windowRepo node.MaintenanceWindowRepository
+	nodeRepo   node.NodeRepository
+	groupRepo  resource.Repository
+	logger     logger.Interface
+}
+
+func NewListMaintenanceWindowsUseCase(
+	windowRepo node.MaintenanceWindowRepository,
+	nodeRepo node.NodeRepository,
+	groupRepo resource.Repository,
+	logger logger.Interface,
+) *ListMaintenanceWindowsUseCase {
+	return &ListMaintenanceWindowsUseCase{
+		windowRepo: windowRepo,
+		nodeRepo:   nodeRepo,
+		groupRepo:  groupRepo,
+		logger:     logger,
+	}
+}
+
+func (uc *ListMaintenanceWindowsUseCase) Execute(ctx context.Context, query ListMaintenanceWindowsQuery) (*ListMaintenanceWindowsResult, error) {
+	filter := node.MaintenanceWindowFilter{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	}
+	if query.Status != nil {
+		status := node.MaintenanceWindowStatus(*query.Status)
+		if !status.IsValid() {
+			return nil, errors.NewValidationError("invalid maintenance window status: " + *query.Status)
+		}
+		filter.Status = &status
+	}
+
+	windows, total, err := uc.windowRepo.List(ctx, filter)
+	if err != nil {
+		uc.logger.Errorw("failed to list maintenance windows", "error", err)
+		return nil, fmt.Errorf("failed to list maintenance windows: %w", err)
+	}
+
+	nodeSIDs, groupSIDs, err := lookupMaintenanceSIDs(ctx, uc.nodeRepo, uc.groupRepo, windows)
+	if err != nil {
+		uc.logger.Errorw("failed to resolve maintenance window SIDs", "error", err)
+		return nil, err
+	}
+
+	result := &ListMaintenanceWindowsResult{
+		Windows: make([]*dto.MaintenanceWindowDTO, 0, len(windows)),
+		Total:   total,
+	}
+	for _, w := range windows {
+		result.Windows = append(result.Windows, dto.ToMaintenanceWindowDTO(w, nodeSIDs, groupSIDs))
+	}
+
+	return result, nil
+}
+
+// lookupMaintenanceSIDs builds the node and resource group ID-to-SID maps needed to render the windows.
+func lookupMaintenanceSIDs(
+	ctx context.Context,
+	nodeRepo node.NodeRepository,
+	groupRepo resource.Repository,
+	windows []*node.MaintenanceWindow,
+) (map[uint]string, map[uint]string, error) {
+	var nodeIDs, groupIDs []uint
+	for _, w := range windows {
+		nodeIDs = append(nodeIDs, w.NodeIDs()...)
+		nodeIDs = append(nodeIDs, w.AffectedNodeIDs()...)
+		groupIDs = append(groupIDs, w.ResourceGroupIDs()...)
+	}
+
+	nodeSIDs := make(map[uint]string)
+	if len(nodeIDs) > 0 {
+		nodes, err := nodeRepo.GetByIDs(ctx, nodeIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get nodes: %w", err)
+		}
+		for _, n := range nodes {
+			nodeSIDs[n.ID()] = n.SID()
+		}
+	}
+
+	groupSIDs := make(map[uint]string)
+	if len(groupIDs) > 0 {
+		sids, err := groupRepo.GetSIDsByIDs(ctx, groupIDs)
+		if err != nil {
+			return nil, nil, fmt.Errorf("failed to get resource groups: %w", err)
+		}
+		groupSIDs = sids
+	}
+
+	return nodeSIDs, groupSIDs, nil
+}
@@ -0,0 +1,123 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/user"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type ListNodeConfigVersionsQuery struct {
+	NodeSID  string
+	Page     int
+	PageSize int
+}
+
+type ListNodeConfigVersionsResult struct {
+	Versions []*dto.ConfigVersionDTO `json:"items"`
+	Total    int64                   `json:"total"`
+}
+
+type ListNodeConfigVersionsUseCase struct {
+	nodeRepo    node.NodeRepository
+	versionRepo node.ConfigVersionRepository
+	userRepo    user.Repository
+	logger      logger.Interface
+}
+
+func NewListNodeConfigVersionsUseCase(
+	nodeRepo node.NodeRepository,
+	versionRepo node.ConfigVersionRepository,
+	userRepo user.Repository,
+	logger logger.Interface,
+) *ListNodeConfigVersionsUseCase {
+	return &ListNodeConfigVersionsUseCase{
+		nodeRepo:    nodeRepo,
+		versionRepo: versionRepo,
+		userRepo:    userRepo,
+		logger:      logger,
+	}
+}
+
+func (uc *ListNodeConfigVersionsUseCase) Execute(ctx context.Context, query ListNodeConfigVersionsQuery) (*ListNodeConfigVersionsResult, error) {
+	n, err := uc.nodeRepo.GetBySID(ctx, query.NodeSID)
+	if err != nil {
+		uc.logger.Errorw("failed to get node by SID", "sid", query.NodeSID, "error", err)
+		return nil, errors.NewNotFoundError("node not found")
+	}
+
+	versions, total, err := uc.versionRepo.ListByNodeID(ctx, n.ID(), node.ConfigVersionFilter{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		uc.logger.Errorw("failed to list node config versions", "sid", query.NodeSID, "error", err)
+		return nil, fmt.Errorf("failed to list node config versions: %w", err)
+	}
+
+	authors := lookupConfigVersionAuthors(ctx, uc.userRepo, uc.logger, versions)
+
+	result := &ListNodeConfigVersionsResult{
+		Versions: make([]*dto.ConfigVersionDTO, 0, len(versions)),
+		Total:    total,
+	}
+	for _, v := range versions {
+		var author *dto.NodeOwnerDTO
+		if v.AuthorID() != nil {
+			author = authors[*v.AuthorID()]
+		}
+		result.Versions = append(result.Versions, dto.ToConfigVersionDTO(v, author))
+	}
+
+	return result, nil
+}
+
+// lookupConfigVersionAuthors builds the user ID-to-author map needed to render the versions.
+// Authors are informational, so lookup failures are logged and the authors are omitted.
+func lookupConfigVersionAuthors(
+	ctx context.Context,
+	userRepo user.Repository,
+	log logger.Interface,
+	versions []*node.ConfigVersion,
+) map[uint]*dto.NodeOwnerDTO {
+	authors := make(map[uint]*dto.NodeOwnerDTO)
+
+	var userIDs []uint
+	seen := make(map[uint]struct{})
+	for _, v := range versions {
+		if v.AuthorID() == nil {
+			continue
+		}
+		if _, ok := seen[*v.AuthorID()]; ok {
+			continue
+		}
+		seen[*v.AuthorID()] = struct{}{}
+		userIDs = append(userIDs, *v.AuthorID())
+	}
+	if len(userIDs) == 0 || userRepo == nil {
+		return authors
+	}
+
+	users, err := userRepo.GetByIDs(ctx, userIDs)
+	if err != nil {
+		log.Warnw("failed to batch get users, skipping config version authors", "user_ids", userIDs, "error", err)
+		return authors
+	}
+
+	for _, u := range users {
+		author := &dto.NodeOwnerDTO{ID: u.SID()}
+		if u.Email() != nil {
+			author.Email = u.Email().String()
+		}
+		if u.Name() != nil {
+			author.Name = u.Name().String()
+		}
+		authors[u.ID()] = author
+	}
+
+	return authors
+}
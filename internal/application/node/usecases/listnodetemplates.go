@@ -0,0 +1,65 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type ListNodeTemplatesQuery struct {
+	Page     int
+	PageSize int
+}
+
+type ListNodeTemplatesResult struct {
+	Templates []*dto.NodeTemplateDTO `json:"items"`
+	Total     int64                  `json:"total"`
+}
+
+type ListNodeTemplatesUseCase struct {
+	templateRepo      node.NodeTemplateRepository
+	resourceGroupRepo resource.Repository
+	logger            logger.Interface
+}
+
+func NewListNodeTemplatesUseCase(
+	templateRepo node.NodeTemplateRepository,
+	resourceGroupRepo resource.Repository,
+	logger logger.Interface,
+) *ListNodeTemplatesUseCase {
+	return &ListNodeTemplatesUseCase{
+		templateRepo:      templateRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		logger:            logger,
+	}
+}
+
+func (uc *ListNodeTemplatesUseCase) Execute(ctx context.Context, query ListNodeTemplatesQuery) (*ListNodeTemplatesResult, error) {
+	templates, total, err := uc.templateRepo.List(ctx, node.NodeTemplateFilter{
+		Page:     query.Page,
+		PageSize: query.PageSize,
+	})
+	if err != nil {
+		uc.logger.Errorw("failed to list node templates", "error", err)
+		return nil, fmt.Errorf("failed to list node templates: %w", err)
+	}
+
+	groupSIDs, err := getTemplateGroupSIDs(ctx, uc.resourceGroupRepo, uc.logger, templates...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ListNodeTemplatesResult{
+		Templates: make([]*dto.NodeTemplateDTO, 0, len(templates)),
+		Total:     total,
+	}
+	for _, t := range templates {
+		result.Templates = append(result.Templates, dto.ToNodeTemplateDTO(t, groupSIDs))
+	}
+
+	return result, nil
+}
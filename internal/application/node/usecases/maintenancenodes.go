@@ -0,0 +1,255 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/subscription"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+const (
+	// maintenanceNoticeTimeLayout is the layout used for window times in user-facing notices
+	maintenanceNoticeTimeLayout = "2006-01-02 15:04 MST"
+	// maintenanceNoticeMaxNodeNames caps the node list so the notice stays within notification size limits
+	maintenanceNoticeMaxNodeNames = 50
+)
+
+// resolveMaintenanceNodeIDs returns the explicit target nodes of a window plus the
+// current members of its target resource groups.
+func resolveMaintenanceNodeIDs(ctx context.Context, nodeRepo node.NodeRepository, w *node.MaintenanceWindow) ([]uint, error) {
+	ids := slices.Clone(w.NodeIDs())
+	for _, groupID := range w.ResourceGroupIDs() {
+		groupNodeIDs, err := nodeRepo.GetIDsByGroupID(ctx, groupID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get nodes of resource group %d: %w", groupID, err)
+		}
+		ids = append(ids, groupNodeIDs...)
+	}
+	slices.Sort(ids)
+	return slices.Compact(ids), nil
+}
+
+// enterNodesMaintenance puts the given active nodes into maintenance and returns the IDs that changed.
+// Nodes that are inactive or already in maintenance are skipped, so the window never reactivates them.
+func enterNodesMaintenance(
+	ctx context.Context,
+	nodeRepo node.NodeRepository,
+	notifier NodeConfigChangeNotifier,
+	log logger.Interface,
+	nodeIDs []uint,
+	reason string,
+) ([]uint, error) {
+	if len(nodeIDs) == 0 {
+		return nil, nil
+	}
+
+	nodes, err := nodeRepo.GetByIDs(ctx, nodeIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get maintenance nodes: %w", err)
+	}
+
+	entered := make([]uint, 0, len(nodes))
+	for _, n := range nodes {
+		if !n.Status().IsActive() {
+			continue
+		}
+		if err := n.EnterMaintenance(reason); err != nil {
+			log.Warnw("failed to enter maintenance", "node_id", n.ID(), "error", err)
+			continue
+		}
+		if err := nodeRepo.Update(ctx, n); err != nil {
+			log.Errorw("failed to update node entering maintenance", "node_id", n.ID(), "error", err)
+			continue
+		}
+		entered = append(entered, n.ID())
+		notifyMaintenanceStatusChange(ctx, notifier, log, n.ID())
+	}
+
+	return entered, nil
+}
+
+// exitNodesMaintenance returns the nodes a window put into maintenance to active status.
+// Nodes an admin changed manually during the window are left as they are, and nodes another
+// active window still covers are handed over to that window, which restores them when it ends.
+func exitNodesMaintenance(
+	ctx context.Context,
+	windowRepo node.MaintenanceWindowRepository,
+	nodeRepo node.NodeRepository,
+	notifier NodeConfigChangeNotifier,
+	log logger.Interface,
+	w *node.MaintenanceWindow,
+) error {
+	if len(w.EnteredNodeIDs()) == 0 {
+		return nil
+	}
+
+	restoreIDs, err := handOverMaintenanceNodes(ctx, windowRepo, log, w)
+	if err != nil {
+		return err
+	}
+	if len(restoreIDs) == 0 {
+		return nil
+	}
+
+	nodes, err := nodeRepo.GetByIDs(ctx, restoreIDs)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance nodes: %w", err)
+	}
+
+	for _, n := range nodes {
+		if !n.Status().IsMaintenance() {
+			continue
+		}
+		if err := n.ExitMaintenance(); err != nil {
+			log.Warnw("failed to exit maintenance", "node_id", n.ID(), "error", err)
+			continue
+		}
+		if err := nodeRepo.Update(ctx, n); err != nil {
+			log.Errorw("failed to update node exiting maintenance", "node_id", n.ID(), "error", err)
+			continue
+		}
+		notifyMaintenanceStatusChange(ctx, notifier, log, n.ID())
+	}
+
+	return nil
+}
+
+// handOverMaintenanceNodes hands the nodes of an ending window that another active window still
+// covers over to that window and returns the remaining nodes, which can be restored.
+func handOverMaintenanceNodes(
+	ctx context.Context,
+	windowRepo node.MaintenanceWindowRepository,
+	log logger.Interface,
+	w *node.MaintenanceWindow,
+) ([]uint, error) {
+	active, err := windowRepo.ListActive(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list active maintenance windows: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	covering := make(map[uint]*node.MaintenanceWindow)
+	for _, other := range active {
+		// Windows that are ending as well restore their own nodes and take over none
+		if other.ID() == w.ID() || other.IsDueToEnd(now) {
+			continue
+		}
+		for _, nodeID := range other.AffectedNodeIDs() {
+			if _, ok := covering[nodeID]; !ok {
+				covering[nodeID] = other
+			}
+		}
+	}
+
+	restoreIDs := make([]uint, 0, len(w.EnteredNodeIDs()))
+	handedOver := make(map[*node.MaintenanceWindow][]uint)
+	for _, nodeID := range w.EnteredNodeIDs() {
+		if other, ok := covering[nodeID]; ok {
+			handedOver[other] = append(handedOver[other], nodeID)
+			continue
+		}
+		restoreIDs = append(restoreIDs, nodeID)
+	}
+
+	for _, other := range active {
+		nodeIDs, ok := handedOver[other]
+		if !ok {
+			continue
+		}
+		if err := other.AdoptNodes(nodeIDs); err != nil {
+			return nil, err
+		}
+		if err := windowRepo.Update(ctx, other); err != nil {
+			return nil, fmt.Errorf("failed to update maintenance window %s: %w", other.SID(), err)
+		}
+		log.Infow("maintenance nodes handed over to overlapping window",
+			"sid", w.SID(),
+			"to_sid", other.SID(),
+			"nodes", len(nodeIDs),
+		)
+	}
+
+	return restoreIDs, nil
+}
+
+func notifyMaintenanceStatusChange(ctx context.Context, notifier NodeConfigChangeNotifier, log logger.Interface, nodeID uint) {
+	if notifier == nil {
+		return
+	}
+	if err := notifier.NotifyConfigChange(ctx, nodeID); err != nil {
+		log.Warnw("failed to notify node agent of maintenance status change",
+			"error", err,
+			"node_id", nodeID,
+		)
+	}
+}
+
+// maintenanceSubscriberIDs returns the distinct users holding an active subscription to any of the nodes.
+func maintenanceSubscriberIDs(ctx context.Context, subscriptionRepo subscription.SubscriptionRepository, nodeIDs []uint) ([]uint, error) {
+	seen := make(map[uint]struct{})
+	userIDs := make([]uint, 0)
+	for _, nodeID := range nodeIDs {
+		subs, err := subscriptionRepo.GetActiveSubscriptionsByNodeID(ctx, nodeID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get subscriptions of node %d: %w", nodeID, err)
+		}
+		for _, sub := range subs {
+			if _, ok := seen[sub.UserID()]; ok {
+				continue
+			}
+			seen[sub.UserID()] = struct{}{}
+			userIDs = append(userIDs, sub.UserID())
+		}
+	}
+	return userIDs, nil
+}
+
+// buildMaintenanceNotice renders the user-facing notice for a maintenance window.
+func buildMaintenanceNotice(w *node.MaintenanceWindow, nodes []*node.Node, userIDs []uint) MaintenanceNotice {
+	names := make([]string, 0, min(len(nodes), maintenanceNoticeMaxNodeNames+1))
+	for i, n := range nodes {
+		if i == maintenanceNoticeMaxNodeNames {
+			names = append(names, fmt.Sprintf("and %d more", len(nodes)-i))
+			break
+		}
+		names = append(names, n.Name())
+	}
+
+	var content strings.Builder
+	content.WriteString(w.Reason())
+	content.WriteString("\n\n")
+	fmt.Fprintf(&content, "**Time:** %s - %s\n",
+		biztime.FormatInBizTimezone(w.StartsAt(), maintenanceNoticeTimeLayout),
+		biztime.FormatInBizTimezone(w.EndsAt(), maintenanceNoticeTimeLayout),
+	)
+	if len(names) > 0 {
+		fmt.Fprintf(&content, "\n**Affected nodes:** %s\n", strings.Join(names, ", "))
+	}
+
+	return MaintenanceNotice{
+		Title:     fmt.Sprintf("Scheduled maintenance on %s", biztime.FormatInBizTimezone(w.StartsAt(), "2006-01-02")),
+		Content:   content.String(),
+		CreatorID: w.CreatedBy(),
+		ExpiresAt: w.EndsAt(),
+		UserIDs:   userIDs,
+	}
+}
+
+// withdrawMaintenanceNotice archives the announcement of a finished window, if one was published.
+func withdrawMaintenanceNotice(ctx context.Context, publisher MaintenanceNoticePublisher, log logger.Interface, w *node.MaintenanceWindow) {
+	if publisher == nil || w.AnnouncementID() == nil {
+		return
+	}
+	if err := publisher.WithdrawMaintenanceNotice(ctx, *w.AnnouncementID()); err != nil {
+		log.Warnw("failed to withdraw maintenance notice",
+			"error", err,
+			"sid", w.SID(),
+			"announcement_id", *w.AnnouncementID(),
+		)
+	}
+}
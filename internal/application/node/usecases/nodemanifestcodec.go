@@ -0,0 +1,206 @@
+package usecases
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/shared/errors"
+)
+
+// Node manifest formats.
+const (
+	NodeManifestFormatJSON = "json"
+	NodeManifestFormatYAML = "yaml"
+	NodeManifestFormatURI  = "uri"
+)
+
+// MaxImportNodes is the maximum number of nodes accepted in a single import.
+const MaxImportNodes = 500
+
+// ParseNodeManifest parses a manifest document into node entries.
+// The format is one of json, yaml or uri (share URIs, one per line, optionally base64
+// encoded as a whole like a subscription); it is detected from the content when empty.
+// JSON and YAML documents may be a manifest object or a bare list of nodes.
+func ParseNodeManifest(data []byte, format string) ([]dto.NodeManifestEntry, error) {
+	data = bytes.TrimSpace(bytes.TrimPrefix(data, []byte("\xef\xbb\xbf")))
+	if len(data) == 0 {
+		return nil, errors.NewValidationError("manifest is empty")
+	}
+
+	if format == "" {
+		format = detectNodeManifestFormat(data)
+	}
+
+	var (
+		entries []dto.NodeManifestEntry
+		err     error
+	)
+	switch strings.ToLower(format) {
+	case NodeManifestFormatJSON:
+		entries, err = parseJSONNodeManifest(data)
+	case NodeManifestFormatYAML, "yml":
+		entries, err = parseYAMLNodeManifest(data)
+	case NodeManifestFormatURI:
+		entries, err = parseURINodeManifest(data)
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported manifest format: %s", format))
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if len(entries) == 0 {
+		return nil, errors.NewValidationError("manifest contains no nodes")
+	}
+	if len(entries) > MaxImportNodes {
+		return nil, errors.NewValidationError(fmt.Sprintf("manifest contains %d nodes, at most %d can be imported at once", len(entries), MaxImportNodes))
+	}
+	return entries, nil
+}
+
+// EncodeNodeManifest encodes a manifest as json or yaml.
+func EncodeNodeManifest(manifest *dto.NodeManifest, format string) ([]byte, error) {
+	data, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+
+	switch strings.ToLower(format) {
+	case NodeManifestFormatJSON:
+		return append(data, '\n'), nil
+	case NodeManifestFormatYAML, "yml":
+		// Go through JSON so the YAML keys match the JSON field names and order
+		var doc yaml.Node
+		if err := yaml.Unmarshal(data, &doc); err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %w", err)
+		}
+		clearYAMLStyle(&doc)
+		out, err := yaml.Marshal(&doc)
+		if err != nil {
+			return nil, fmt.Errorf("failed to encode manifest: %w", err)
+		}
+		return out, nil
+	default:
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported manifest format: %s", format))
+	}
+}
+
+// detectNodeManifestFormat guesses the format of a manifest from its content.
+func detectNodeManifestFormat(data []byte) string {
+	switch data[0] {
+	case '{', '[':
+		return NodeManifestFormatJSON
+	}
+
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		if isShareURILine(line) {
+			return NodeManifestFormatURI
+		}
+		break
+	}
+
+	// A base64 encoded subscription
+	if decoded, err := decodeBase64(string(data)); err == nil && bytes.Contains(decoded, []byte("://")) {
+		return NodeManifestFormatURI
+	}
+	return NodeManifestFormatYAML
+}
+
+// isShareURILine reports whether the line starts with a share URI scheme.
+func isShareURILine(line string) bool {
+	scheme, _, ok := strings.Cut(line, "://")
+	return ok && scheme != "" && !strings.ContainsAny(scheme, " :-\t")
+}
+
+func parseJSONNodeManifest(data []byte) ([]dto.NodeManifestEntry, error) {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	dec.DisallowUnknownFields()
+
+	if data[0] == '[' {
+		var entries []dto.NodeManifestEntry
+		if err := dec.Decode(&entries); err != nil {
+			return nil, errors.NewValidationError("invalid manifest: " + err.Error())
+		}
+		return entries, nil
+	}
+
+	var manifest dto.NodeManifest
+	if err := dec.Decode(&manifest); err != nil {
+		return nil, errors.NewValidationError("invalid manifest: " + err.Error())
+	}
+	if manifest.Version > dto.NodeManifestVersion {
+		return nil, errors.NewValidationError(fmt.Sprintf("unsupported manifest version %d (latest supported is %d)", manifest.Version, dto.NodeManifestVersion))
+	}
+	return manifest.Nodes, nil
+}
+
+func parseYAMLNodeManifest(data []byte) ([]dto.NodeManifestEntry, error) {
+	var doc any
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, errors.NewValidationError("invalid manifest: " + err.Error())
+	}
+
+	// Re-encode as JSON so YAML documents are decoded with the same field names and checks
+	converted, err := json.Marshal(doc)
+	if err != nil {
+		return nil, errors.NewValidationError("invalid manifest: " + err.Error())
+	}
+	if len(converted) == 0 || (converted[0] != '{' && converted[0] != '[') {
+		return nil, errors.NewValidationError("invalid manifest: expected a manifest object or a list of nodes")
+	}
+	return parseJSONNodeManifest(converted)
+}
+
+func parseURINodeManifest(data []byte) ([]dto.NodeManifestEntry, error) {
+	if !bytes.Contains(data, []byte("://")) {
+		decoded, err := decodeBase64(string(data))
+		if err != nil {
+			return nil, errors.NewValidationError("invalid manifest: expected share URIs")
+		}
+		data = decoded
+	}
+
+	var entries []dto.NodeManifestEntry
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	line := 0
+	for scanner.Scan() {
+		line++
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		entry, err := parseNodeShareURI(text)
+		if err != nil {
+			msg := err.Error()
+			if appErr := errors.GetAppError(err); appErr != nil {
+				msg = appErr.Message
+			}
+			return nil, errors.NewValidationError(fmt.Sprintf("line %d: %s", line, msg))
+		}
+		entries = append(entries, entry)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.NewValidationError("invalid manifest: " + err.Error())
+	}
+	return entries, nil
+}
+
+// clearYAMLStyle resets node styles so JSON derived nodes are emitted as block YAML.
+func clearYAMLStyle(n *yaml.Node) {
+	n.Style = 0
+	for _, child := range n.Content {
+		clearYAMLStyle(child)
+	}
+}
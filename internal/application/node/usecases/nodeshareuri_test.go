@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+func TestParseNodeShareURI_RoundTrip(t *testing.T) {
+	enc, err := vo.NewEncryptionConfig(vo.MethodAES256GCM)
+	require.NoError(t, err)
+	plugin, err := vo.NewPluginConfig("obfs-local", map[string]string{"obfs": "http"})
+	require.NoError(t, err)
+	trojan, err := vo.NewTrojanConfig("password", vo.TransportGRPC, "svc", "", true, "t.example.com")
+	require.NoError(t, err)
+	keys, err := vo.GenerateRealityKeyPair()
+	require.NoError(t, err)
+	vless, err := vo.NewVLESSConfig("tcp", "xtls-rprx-vision", vo.VLESSSecurityReality, "www.example.com", "chrome",
+		false, "", "", "", keys.PrivateKey, keys.PublicKey, "abcd", "/", "", "")
+	require.NoError(t, err)
+	vmess, err := vo.NewVMessConfig(0, "auto", vo.VMessTransportWS, "ws.example.com", "/ws", "", true, "v.example.com", false, "", "")
+	require.NoError(t, err)
+	up, down := 100, 200
+	hy2, err := vo.NewHysteria2Config("password", "bbr", "salamander", "obfs-secret", &up, &down, "h.example.com", true, "")
+	require.NoError(t, err)
+	tuic, err := vo.NewTUICConfig("uuid", "password", "bbr", "native", "h3", "q.example.com", false, true)
+	require.NoError(t, err)
+	vmessURI, err := vmess.ToURI("1.2.3.4", 443, "uuid", "VMess")
+	require.NoError(t, err)
+
+	tests := []struct {
+		name string
+		uri  string
+		want dto.NodeManifestEntry
+	}{
+		{
+			name: "shadowsocks",
+			uri:  vo.NewShadowsocksProtocolConfig(enc, plugin).ToSubscriptionURI("1.2.3.4", 8388, "uuid", "SS"),
+			want: dto.NodeManifestEntry{Name: "SS", ServerAddress: "1.2.3.4", AgentPort: 8388,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "shadowsocks", EncryptionMethod: vo.MethodAES256GCM,
+					Plugin: "obfs-local", PluginOpts: map[string]string{"obfs": "http"}}},
+		},
+		{
+			name: "trojan grpc",
+			uri:  trojan.ToURI("t.example.com", 443, "Trojan"),
+			want: dto.NodeManifestEntry{Name: "Trojan", ServerAddress: "t.example.com", AgentPort: 443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "trojan",
+					TransportProtocol: vo.TransportGRPC, Host: "svc", SNI: "t.example.com", AllowInsecure: true}},
+		},
+		{
+			name: "vless reality",
+			uri:  vless.ToURI("uuid", "1.2.3.4", 443, "VLESS"),
+			want: dto.NodeManifestEntry{Name: "VLESS", ServerAddress: "1.2.3.4", AgentPort: 443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "vless",
+					VLESSTransportType: "tcp", VLESSSecurity: vo.VLESSSecurityReality, VLESSFlow: "xtls-rprx-vision",
+					VLESSSni: "www.example.com", VLESSFingerprint: "chrome", VLESSRealitySpiderX: "/"}},
+		},
+		{
+			name: "vmess v2rayN",
+			uri:  vmessURI,
+			want: dto.NodeManifestEntry{Name: "VMess", ServerAddress: "1.2.3.4", AgentPort: 443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "vmess",
+					VMessSecurity: "auto", VMessTransportType: vo.VMessTransportWS, VMessHost: "ws.example.com", VMessPath: "/ws",
+					VMessTLS: true, VMessSni: "v.example.com"}},
+		},
+		{
+			name: "vmess standard",
+			uri:  vmess.ToStandardURI("1.2.3.4", 443, "uuid", "VMess"),
+			want: dto.NodeManifestEntry{Name: "VMess", ServerAddress: "1.2.3.4", AgentPort: 443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "vmess",
+					VMessTransportType: vo.VMessTransportWS, VMessHost: "ws.example.com", VMessPath: "/ws",
+					VMessTLS: true, VMessSni: "v.example.com"}},
+		},
+		{
+			name: "hysteria2",
+			uri:  hy2.ToURI("h.example.com", 8443, "Hy2", "pw"),
+			want: dto.NodeManifestEntry{Name: "Hy2", ServerAddress: "h.example.com", AgentPort: 8443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "hysteria2",
+					Hysteria2Sni: "h.example.com", Hysteria2AllowInsecure: true, Hysteria2Obfs: "salamander",
+					Hysteria2ObfsPassword: "obfs-secret", Hysteria2UpMbps: &up, Hysteria2DownMbps: &down}},
+		},
+		{
+			name: "tuic",
+			uri:  tuic.ToURI("q.example.com", 8443, "", "u", "p"),
+			want: dto.NodeManifestEntry{Name: "q.example.com:8443", ServerAddress: "q.example.com", AgentPort: 8443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "tuic",
+					TUICCongestionControl: "bbr", TUICUDPRelayMode: "native", TUICAlpn: "h3", TUICSni: "q.example.com", TUICDisableSNI: true}},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseNodeShareURI(tt.uri)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestParseNodeShareURI_Invalid(t *testing.T) {
+	for _, uri := range []string{
+		"1.2.3.4:443",
+		"socks5://1.2.3.4:1080",
+		"trojan://pw@1.2.3.4",
+		"vmess://not-base64!",
+	} {
+		_, err := parseNodeShareURI(uri)
+		assert.Error(t, err, uri)
+	}
+}
+
+func TestNodeManifest_RoundTrip(t *testing.T) {
+	multiplier := 1.5
+	manifest := &dto.NodeManifest{
+		Version: dto.NodeManifestVersion,
+		Nodes: []dto.NodeManifestEntry{
+			{Name: "true", ServerAddress: "1.2.3.4", AgentPort: 8388, Tags: []string{"hk", "8080"}, TrafficMultiplier: &multiplier,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "shadowsocks", EncryptionMethod: vo.MethodAES256GCM}},
+			{Name: "Trojan", AgentPort: 443,
+				NodeConfigSpec: dto.NodeConfigSpec{Protocol: "trojan", TransportProtocol: "ws", Host: "t.example.com", Path: "/ws"}},
+		},
+	}
+
+	for _, format := range []string{NodeManifestFormatYAML, NodeManifestFormatJSON} {
+		data, err := EncodeNodeManifest(manifest, format)
+		require.NoError(t, err)
+
+		entries, err := ParseNodeManifest(data, "")
+		require.NoError(t, err, format)
+		assert.Equal(t, manifest.Nodes, entries, format)
+	}
+
+	_, err := ParseNodeManifest([]byte("nodes:\n  - name: a\n    unknown_field: 1\n"), "")
+	assert.Error(t, err)
+	_, err = ParseNodeManifest([]byte(`{"version": 99, "nodes": [{"name": "a"}]}`), "")
+	assert.Error(t, err)
+}
+
+func TestParseNodeManifest_URIs(t *testing.T) {
+	uris := "# exported nodes\n" +
+		"trojan://pw@1.2.3.4:443?type=tcp&security=tls#A\n\n" +
+		"hy2://pw@5.6.7.8:8443?sni=h.example.com#B\n"
+
+	entries, err := ParseNodeManifest([]byte(uris), "")
+	require.NoError(t, err)
+	require.Len(t, entries, 2)
+	assert.Equal(t, "A", entries[0].Name)
+	assert.Equal(t, "hysteria2", entries[1].Protocol)
+
+	// Base64 encoded subscription
+	encoded := base64.StdEncoding.EncodeToString([]byte(strings.TrimPrefix(uris, "# exported nodes\n")))
+	entries, err = ParseNodeManifest([]byte(encoded), "")
+	require.NoError(t, err)
+	assert.Len(t, entries, 2)
+
+	_, err = ParseNodeManifest([]byte("trojan://pw@1.2.3.4:443#A\nbogus://x\n"), "")
+	assert.ErrorContains(t, err, "line 2")
+}
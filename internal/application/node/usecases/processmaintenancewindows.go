@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// ProcessMaintenanceWindowsUseCase drives scheduled maintenance windows: it puts the affected
+// nodes into maintenance when a window starts and restores them when it ends.
+type ProcessMaintenanceWindowsUseCase struct {
+	windowRepo           node.MaintenanceWindowRepository
+	nodeRepo             node.NodeRepository
+	noticePublisher      MaintenanceNoticePublisher
+	configChangeNotifier NodeConfigChangeNotifier
+	logger               logger.Interface
+}
+
+func NewProcessMaintenanceWindowsUseCase(
+	windowRepo node.MaintenanceWindowRepository,
+	nodeRepo node.NodeRepository,
+	logger logger.Interface,
+) *ProcessMaintenanceWindowsUseCase {
+	return &ProcessMaintenanceWindowsUseCase{
+		windowRepo: windowRepo,
+		nodeRepo:   nodeRepo,
+		logger:     logger,
+	}
+}
+
+// SetNoticePublisher sets the publisher used to withdraw maintenance announcements.
+func (uc *ProcessMaintenanceWindowsUseCase) SetNoticePublisher(publisher MaintenanceNoticePublisher) {
+	uc.noticePublisher = publisher
+}
+
+// SetConfigChangeNotifier sets the notifier used to push node status changes to agents.
+func (uc *ProcessMaintenanceWindowsUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+// Execute starts and finishes due windows and returns the number of windows that changed state.
+func (uc *ProcessMaintenanceWindowsUseCase) Execute(ctx context.Context) (int, error) {
+	windows, err := uc.windowRepo.ListUnfinished(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list unfinished maintenance windows: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	changed := 0
+	for _, w := range windows {
+		switch {
+		case w.IsDueToEnd(now):
+			// Also covers windows that were never started because the scheduler was down
+			if err := uc.finish(ctx, w); err != nil {
+				uc.logger.Errorw("failed to finish maintenance window", "sid", w.SID(), "error", err)
+				continue
+			}
+		case w.IsDueToStart(now):
+			if err := uc.start(ctx, w); err != nil {
+				uc.logger.Errorw("failed to start maintenance window", "sid", w.SID(), "error", err)
+				continue
+			}
+		default:
+			continue
+		}
+		changed++
+	}
+
+	return changed, nil
+}
+
+func (uc *ProcessMaintenanceWindowsUseCase) start(ctx context.Context, w *node.MaintenanceWindow) error {
+	affectedIDs, err := resolveMaintenanceNodeIDs(ctx, uc.nodeRepo, w)
+	if err != nil {
+		return err
+	}
+
+	entered, err := enterNodesMaintenance(ctx, uc.nodeRepo, uc.configChangeNotifier, uc.logger, affectedIDs, w.Reason())
+	if err != nil {
+		return err
+	}
+
+	if err := w.Start(affectedIDs, entered); err != nil {
+		return err
+	}
+	if err := uc.windowRepo.Update(ctx, w); err != nil {
+		return fmt.Errorf("failed to update maintenance window: %w", err)
+	}
+
+	uc.logger.Infow("maintenance window started",
+		"sid", w.SID(),
+		"affected_nodes", len(affectedIDs),
+		"entered_nodes", len(entered),
+	)
+	return nil
+}
+
+func (uc *ProcessMaintenanceWindowsUseCase) finish(ctx context.Context, w *node.MaintenanceWindow) error {
+	if err := exitNodesMaintenance(ctx, uc.windowRepo, uc.nodeRepo, uc.configChangeNotifier, uc.logger, w); err != nil {
+		return err
+	}
+
+	if err := w.Complete(); err != nil {
+		return err
+	}
+	if err := uc.windowRepo.Update(ctx, w); err != nil {
+		return fmt.Errorf("failed to update maintenance window: %w", err)
+	}
+
+	uc.logger.Infow("maintenance window completed", "sid", w.SID(), "entered_nodes", len(w.EnteredNodeIDs()))
+
+	withdrawMaintenanceNotice(ctx, uc.noticePublisher, uc.logger, w)
+	return nil
+}
@@ -0,0 +1,126 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// fakeMaintenanceWindowRepo keeps windows in memory; other methods panic.
+type fakeMaintenanceWindowRepo struct {
+	node.MaintenanceWindowRepository
+	windows []*node.MaintenanceWindow
+}
+
+func (r *fakeMaintenanceWindowRepo) ListUnfinished(ctx context.Context) ([]*node.MaintenanceWindow, error) {
+	var result []*node.MaintenanceWindow
+	for _, w := range r.windows {
+		if !w.Status().IsFinished() {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeMaintenanceWindowRepo) ListActive(ctx context.Context) ([]*node.MaintenanceWindow, error) {
+	var result []*node.MaintenanceWindow
+	for _, w := range r.windows {
+		if w.Status() == node.MaintenanceWindowStatusActive {
+			result = append(result, w)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeMaintenanceWindowRepo) Update(ctx context.Context, w *node.MaintenanceWindow) error {
+	return nil
+}
+
+// fakeMaintenanceNodeRepo keeps nodes in memory; other methods panic.
+type fakeMaintenanceNodeRepo struct {
+	node.NodeRepository
+	nodes map[uint]*node.Node
+}
+
+func (r *fakeMaintenanceNodeRepo) GetByIDs(ctx context.Context, ids []uint) ([]*node.Node, error) {
+	var result []*node.Node
+	for _, id := range ids {
+		if n, ok := r.nodes[id]; ok {
+			result = append(result, n)
+		}
+	}
+	return result, nil
+}
+
+func (r *fakeMaintenanceNodeRepo) Update(ctx context.Context, n *node.Node) error {
+	return nil
+}
+
+func newMaintenanceTestNode(t *testing.T, id uint) *node.Node {
+	t.Helper()
+
+	n, err := NewCreateNodeUseCase(&fakeImportNodeRepo{}, nil, logger.NewLogger()).buildNode(context.Background(), CreateNodeCommand{
+		Name:      fmt.Sprintf("node-%d", id),
+		AgentPort: 8388,
+		Protocol:  "shadowsocks",
+		Method:    "2022-blake3-aes-128-gcm",
+	})
+	require.NoError(t, err)
+	require.NoError(t, n.SetID(id))
+	require.NoError(t, n.Activate())
+	require.NoError(t, n.EnterMaintenance("kernel upgrade"))
+	return n
+}
+
+func activeMaintenanceWindow(t *testing.T, id uint, endsAt time.Time, affected, entered []uint) *node.MaintenanceWindow {
+	t.Helper()
+
+	now := biztime.NowUTC()
+	w, err := node.ReconstructMaintenanceWindow(id, fmt.Sprintf("mw_test%d", id), "kernel upgrade",
+		now.Add(-time.Hour), endsAt, affected, nil, node.MaintenanceWindowStatusActive.String(),
+		affected, entered, nil, 1, now, now)
+	require.NoError(t, err)
+	return w
+}
+
+func TestProcessMaintenanceWindows_OverlappingWindows(t *testing.T) {
+	nodeRepo := &fakeMaintenanceNodeRepo{nodes: map[uint]*node.Node{
+		1: newMaintenanceTestNode(t, 1),
+		2: newMaintenanceTestNode(t, 2),
+		3: newMaintenanceTestNode(t, 3),
+	}}
+	now := biztime.NowUTC()
+	// first entered nodes 1 and 2; second also covers node 1, which was already in maintenance
+	first := activeMaintenanceWindow(t, 1, now.Add(-time.Minute), []uint{1, 2}, []uint{1, 2})
+	second := activeMaintenanceWindow(t, 2, now.Add(time.Hour), []uint{1, 3}, []uint{3})
+	windowRepo := &fakeMaintenanceWindowRepo{windows: []*node.MaintenanceWindow{first, second}}
+	uc := NewProcessMaintenanceWindowsUseCase(windowRepo, nodeRepo, logger.NewLogger())
+
+	changed, err := uc.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	assert.Equal(t, node.MaintenanceWindowStatusCompleted, first.Status())
+	assert.True(t, nodeRepo.nodes[1].Status().IsMaintenance(), "node 1 is still covered by the second window")
+	assert.True(t, nodeRepo.nodes[2].Status().IsActive())
+	assert.True(t, nodeRepo.nodes[3].Status().IsMaintenance())
+	assert.Equal(t, []uint{1, 3}, second.EnteredNodeIDs(), "second window takes over node 1")
+
+	// When the second window ends, it restores the node it took over as well
+	ending := activeMaintenanceWindow(t, 2, now.Add(-time.Minute), second.AffectedNodeIDs(), second.EnteredNodeIDs())
+	windowRepo.windows = []*node.MaintenanceWindow{first, ending}
+
+	changed, err = uc.Execute(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, 1, changed)
+	for id, n := range nodeRepo.nodes {
+		assert.True(t, n.Status().IsActive(), "node %d restored", id)
+	}
+}
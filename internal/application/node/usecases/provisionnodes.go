@@ -0,0 +1,260 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strconv"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// ProvisionNodeSpec is the per-node input when provisioning from a template or cloning a node.
+// Empty fields fall back to the source: the source node's values when cloning, a generated
+// name when provisioning from a template.
+type ProvisionNodeSpec struct {
+	Name             string
+	ServerAddress    string
+	AgentPort        uint16
+	SubscriptionPort *uint16
+	Region           string
+	CountryCode      string
+	Tags             []string
+}
+
+type ProvisionNodesCommand struct {
+	TemplateSID   string // Provision from this template
+	SourceNodeSID string // Or clone this node (exactly one source must be given)
+	Nodes         []ProvisionNodeSpec
+	DryRun        bool   // Only validate, nothing is created
+	CreatedBy     uint   // User provisioning the nodes, recorded in the configuration history
+	APIURL        string // API server URL used in the install command
+}
+
+type ProvisionNodesResult struct {
+	ImportNodesResult
+	Install *GenerateBatchInstallScriptResult `json:"install,omitempty" description:"Install command for the created nodes"`
+}
+
+// ProvisionNodesUseCase creates nodes from a template or by cloning an existing node and
+// returns a single install command for all of them. Nodes are created through the import
+// flow, so every node is validated before any is created.
+type ProvisionNodesUseCase struct {
+	templateRepo      node.NodeTemplateRepository
+	nodeRepo          node.NodeRepository
+	resourceGroupRepo resource.Repository
+	importer          *ImportNodesUseCase
+	installScript     GenerateBatchInstallScriptExecutor
+	logger            logger.Interface
+}
+
+func NewProvisionNodesUseCase(
+	templateRepo node.NodeTemplateRepository,
+	nodeRepo node.NodeRepository,
+	resourceGroupRepo resource.Repository,
+	importer *ImportNodesUseCase,
+	installScript GenerateBatchInstallScriptExecutor,
+	logger logger.Interface,
+) *ProvisionNodesUseCase {
+	return &ProvisionNodesUseCase{
+		templateRepo:      templateRepo,
+		nodeRepo:          nodeRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		importer:          importer,
+		installScript:     installScript,
+		logger:            logger,
+	}
+}
+
+func (uc *ProvisionNodesUseCase) Execute(ctx context.Context, cmd ProvisionNodesCommand) (*ProvisionNodesResult, error) {
+	if err := uc.validateCommand(cmd); err != nil {
+		return nil, err
+	}
+
+	uc.logger.Infow("executing provision nodes use case",
+		"template_sid", cmd.TemplateSID,
+		"source_node_sid", cmd.SourceNodeSID,
+		"count", len(cmd.Nodes),
+		"dry_run", cmd.DryRun,
+	)
+
+	var (
+		base     dto.NodeManifestEntry
+		baseName string
+		err      error
+	)
+	if cmd.TemplateSID != "" {
+		base, baseName, err = uc.templateSource(ctx, cmd.TemplateSID)
+	} else {
+		base, baseName, err = uc.cloneSource(ctx, cmd.SourceNodeSID)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := buildProvisionEntries(base, baseName, cmd.Nodes)
+	if err != nil {
+		return nil, err
+	}
+
+	imported, err := uc.importer.Execute(ctx, ImportNodesCommand{
+		Nodes:      entries,
+		DryRun:     cmd.DryRun,
+		ImportedBy: cmd.CreatedBy,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	result := &ProvisionNodesResult{ImportNodesResult: *imported}
+	if !imported.Valid || imported.DryRun || imported.Created == 0 {
+		return result, nil
+	}
+
+	sids := make([]string, 0, imported.Created)
+	for _, n := range imported.Nodes {
+		if n.Status == ImportNodeStatusCreated {
+			sids = append(sids, n.NodeSID)
+		}
+	}
+
+	// The nodes exist at this point, so a failure here is not returned as an error:
+	// the API tokens are in the per-node results and the install command can be requested again
+	install, err := uc.installScript.Execute(ctx, GenerateBatchInstallScriptQuery{SIDs: sids, APIURL: cmd.APIURL})
+	if err != nil {
+		uc.logger.Warnw("failed to generate install command for provisioned nodes", "count", len(sids), "error", err)
+	} else {
+		result.Install = install
+	}
+
+	uc.logger.Infow("nodes provisioned", "count", len(cmd.Nodes), "created", result.Created)
+	return result, nil
+}
+
+func (uc *ProvisionNodesUseCase) validateCommand(cmd ProvisionNodesCommand) error {
+	if (cmd.TemplateSID == "") == (cmd.SourceNodeSID == "") {
+		return errors.NewValidationError("exactly one of template or source node is required")
+	}
+	if len(cmd.Nodes) == 0 {
+		return errors.NewValidationError("at least one node is required")
+	}
+	// Limited by the batch install command
+	if len(cmd.Nodes) > MaxBatchSize {
+		return errors.NewValidationError(fmt.Sprintf("at most %d nodes can be provisioned at once", MaxBatchSize))
+	}
+	if !cmd.DryRun {
+		// Checked up front so nodes are not created when the install command cannot be generated
+		if cmd.APIURL == "" {
+			return errors.NewValidationError("API URL is required")
+		}
+		if err := utils.ValidateAPIURL(cmd.APIURL); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// templateSource returns the base entry and name for nodes provisioned from a template.
+func (uc *ProvisionNodesUseCase) templateSource(ctx context.Context, sid string) (dto.NodeManifestEntry, string, error) {
+	template, err := getNodeTemplateBySID(ctx, uc.templateRepo, uc.logger, sid)
+	if err != nil {
+		return dto.NodeManifestEntry{}, "", err
+	}
+
+	groupSIDs, err := getTemplateGroupSIDs(ctx, uc.resourceGroupRepo, uc.logger, template)
+	if err != nil {
+		return dto.NodeManifestEntry{}, "", err
+	}
+
+	base := dto.NodeManifestEntry{
+		NodeConfigSpec: dto.ToNodeConfigSpec(template.Config(), false),
+	}
+	// Groups deleted since the template was saved are skipped
+	for _, gid := range template.GroupIDs() {
+		if groupSID, ok := groupSIDs[gid]; ok {
+			base.GroupSIDs = append(base.GroupSIDs, groupSID)
+		}
+	}
+	return base, template.Name(), nil
+}
+
+// cloneSource returns the base entry and name for clones of a node. Clones keep the
+// node's ports, region, tags and other settings unless they are given per node.
+func (uc *ProvisionNodesUseCase) cloneSource(ctx context.Context, sid string) (dto.NodeManifestEntry, string, error) {
+	source, err := getTemplateSourceNode(ctx, uc.nodeRepo, uc.logger, sid)
+	if err != nil {
+		return dto.NodeManifestEntry{}, "", err
+	}
+
+	var groupSIDs []string
+	if len(source.GroupIDs()) > 0 {
+		sids, err := uc.resourceGroupRepo.GetSIDsByIDs(ctx, source.GroupIDs())
+		if err != nil {
+			uc.logger.Errorw("failed to get resource group SIDs", "error", err)
+			return dto.NodeManifestEntry{}, "", fmt.Errorf("failed to get resource groups: %w", err)
+		}
+		for _, gid := range source.GroupIDs() {
+			if groupSID, ok := sids[gid]; ok {
+				groupSIDs = append(groupSIDs, groupSID)
+			}
+		}
+	}
+
+	base := dto.ToNodeManifestEntry(source, groupSIDs, false)
+	base.ServerAddress = ""
+	return base, source.Name(), nil
+}
+
+// buildProvisionEntries applies the per-node specs to the base entry. The base never
+// carries server keys, so Reality key pairs and short IDs and WireGuard keys are generated
+// when each node is created; ShadowTLS and Hysteria2 obfuscation passwords are replaced here.
+func buildProvisionEntries(base dto.NodeManifestEntry, baseName string, specs []ProvisionNodeSpec) ([]dto.NodeManifestEntry, error) {
+	entries := make([]dto.NodeManifestEntry, len(specs))
+	for i, spec := range specs {
+		entry := base
+		entry.ServerAddress = spec.ServerAddress
+		if spec.AgentPort != 0 {
+			entry.AgentPort = spec.AgentPort
+		}
+		if spec.SubscriptionPort != nil {
+			entry.SubscriptionPort = spec.SubscriptionPort
+		}
+		if spec.Region != "" {
+			entry.Region = spec.Region
+		}
+		if spec.CountryCode != "" {
+			entry.CountryCode = spec.CountryCode
+		}
+		if spec.Tags != nil {
+			entry.Tags = spec.Tags
+		}
+
+		entry.Name = spec.Name
+		if entry.Name == "" {
+			if entry.ServerAddress != "" {
+				entry.Name = fmt.Sprintf("%s %s", baseName, net.JoinHostPort(entry.ServerAddress, strconv.Itoa(int(entry.AgentPort))))
+			} else {
+				entry.Name = fmt.Sprintf("%s #%d", baseName, i+1)
+			}
+		}
+
+		// Generated by the create node use case when empty
+		entry.ShadowTLSPassword = ""
+		if entry.Hysteria2Obfs != "" {
+			password, err := vo.GenerateHysteria2ObfsPassword()
+			if err != nil {
+				return nil, err
+			}
+			entry.Hysteria2ObfsPassword = password
+		}
+
+		entries[i] = entry
+	}
+	return entries, nil
+}
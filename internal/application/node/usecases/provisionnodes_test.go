@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+func TestBuildProvisionEntries_RegeneratesServerSecrets(t *testing.T) {
+	keys, err := vo.GenerateRealityKeyPair()
+	require.NoError(t, err)
+	vless, err := vo.NewVLESSConfig("tcp", "xtls-rprx-vision", vo.VLESSSecurityReality, "www.example.com", "chrome",
+		false, "", "", "", keys.PrivateKey, keys.PublicKey, "abcd", "/", "", "")
+	require.NoError(t, err)
+
+	base := dto.NodeManifestEntry{
+		GroupSIDs:      []string{"rg_xK9mP2vL3nQ"},
+		NodeConfigSpec: dto.ToNodeConfigSpec(node.ConfigSnapshot{Protocol: vo.ProtocolVLESS, VLESS: &vless}, false),
+	}
+	subPort := uint16(8443)
+	entries, err := buildProvisionEntries(base, "HK Reality", []ProvisionNodeSpec{
+		{ServerAddress: "1.2.3.4", AgentPort: 443},
+		{Name: "HK-02", ServerAddress: "5.6.7.8", AgentPort: 443, SubscriptionPort: &subPort, Tags: []string{"hk"}},
+		{AgentPort: 443},
+	})
+	require.NoError(t, err)
+	require.Len(t, entries, 3)
+
+	assert.Equal(t, "HK Reality 1.2.3.4:443", entries[0].Name)
+	assert.Equal(t, "HK-02", entries[1].Name)
+	assert.Equal(t, "HK Reality #3", entries[2].Name)
+	assert.Equal(t, &subPort, entries[1].SubscriptionPort)
+	assert.Equal(t, []string{"hk"}, entries[1].Tags)
+	for _, e := range entries {
+		assert.Equal(t, vo.VLESSSecurityReality, e.VLESSSecurity)
+		assert.Equal(t, "www.example.com", e.VLESSSni)
+		assert.Equal(t, []string{"rg_xK9mP2vL3nQ"}, e.GroupSIDs)
+		// Reality keys are left empty so every node gets its own
+		assert.Empty(t, e.VLESSRealityPrivateKey)
+		assert.Empty(t, e.VLESSRealityPublicKey)
+		assert.Empty(t, e.VLESSRealityShortID)
+	}
+}
+
+func TestBuildProvisionEntries_RegeneratesObfsPassword(t *testing.T) {
+	hy2, err := vo.NewHysteria2Config("password", "bbr", vo.ObfsSalamander, "obfs-secret", nil, nil, "h.example.com", false, "")
+	require.NoError(t, err)
+
+	base := dto.NodeManifestEntry{
+		NodeConfigSpec: dto.ToNodeConfigSpec(node.ConfigSnapshot{Protocol: vo.ProtocolHysteria2, Hysteria2: &hy2}, false),
+	}
+	entries, err := buildProvisionEntries(base, "Hy2", []ProvisionNodeSpec{
+		{ServerAddress: "1.2.3.4", AgentPort: 8443},
+		{ServerAddress: "5.6.7.8", AgentPort: 8443},
+	})
+	require.NoError(t, err)
+
+	assert.NotEmpty(t, entries[0].Hysteria2ObfsPassword)
+	assert.NotEqual(t, "obfs-secret", entries[0].Hysteria2ObfsPassword)
+	assert.NotEqual(t, entries[0].Hysteria2ObfsPassword, entries[1].Hysteria2ObfsPassword)
+}
@@ -0,0 +1,110 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// proxyLineTestNodes returns one node per protocol, followed by nodes of protocols
+// Quantumult X and Loon cannot use.
+func proxyLineTestNodes(t *testing.T) []*Node {
+	t.Helper()
+
+	vless, err := vo.NewVLESSConfig("tcp", "xtls-rprx-vision", vo.VLESSSecurityReality, "www.example.com", "",
+		false, "", "", "", "private-key", "public-key", "abcd1234", "", "", "")
+	require.NoError(t, err)
+	vmess, err := vo.NewVMessConfig(0, "auto", vo.VMessTransportWS, "ws.example.com", "/ws", "", true, "v.example.com", false, "", "")
+	require.NoError(t, err)
+	vmessZero, err := vo.NewVMessConfig(0, "zero", vo.VMessTransportTCP, "", "", "", false, "", false, "", "")
+	require.NoError(t, err)
+	up, down := 100, 200
+	hy2, err := vo.NewHysteria2Config("password", "bbr", "salamander", "obfs-secret", &up, &down, "h.example.com", true, "")
+	require.NoError(t, err)
+	tuic, err := vo.NewTUICConfig("uuid", "password", "bbr", "native", "h3", "q.example.com", false, false)
+	require.NoError(t, err)
+	anytls, err := vo.NewAnyTLSConfig("anytls-password", "a.example.com", false, "", "", "", 0)
+	require.NoError(t, err)
+
+	return []*Node{
+		{Name: "SS", Protocol: "shadowsocks", ServerAddress: "1.1.1.1", SubscriptionPort: 8388, EncryptionMethod: "aes-256-gcm"},
+		{Name: "Trojan", Protocol: "trojan", ServerAddress: "2.2.2.2", SubscriptionPort: 443, TransportProtocol: vo.TransportWS, Host: "cdn.example.com", Path: "/ws", SNI: "t.example.com"},
+		{Name: "VLESS", Protocol: "vless", ServerAddress: "3.3.3.3", SubscriptionPort: 443, VLESSConfig: &vless},
+		{Name: "VMess", Protocol: "vmess", ServerAddress: "4.4.4.4", SubscriptionPort: 443, VMessConfig: &vmess},
+		{Name: "VMess, Zero", Protocol: "vmess", ServerAddress: "5.5.5.5", SubscriptionPort: 80, VMessConfig: &vmessZero},
+		{Name: "Hy2", Protocol: "hysteria2", ServerAddress: "6.6.6.6", SubscriptionPort: 443, Hysteria2Config: &hy2},
+		{Name: "TUIC", Protocol: "tuic", ServerAddress: "7.7.7.7", SubscriptionPort: 443, TUICConfig: &tuic},
+		{Name: "AnyTLS", Protocol: "anytls", ServerAddress: "8.8.8.8", SubscriptionPort: 443, AnyTLSConfig: &anytls},
+		{Name: "WG", Protocol: "wireguard", ServerAddress: "9.9.9.9", SubscriptionPort: 51820},
+	}
+}
+
+func TestQuantumultXFormatter_Protocols(t *testing.T) {
+	out, err := NewQuantumultXFormatter().FormatWithPassword(proxyLineTestNodes(t), "sub-password")
+	require.NoError(t, err)
+	lines := strings.Split(out, "\n")
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"shadowsocks", "shadowsocks=1.1.1.1:8388, method=aes-256-gcm, password=sub-password, udp-relay=true, fast-open=false, tag=SS"},
+		{"trojan ws", "trojan=2.2.2.2:443, password=sub-password, obfs=wss, obfs-host=cdn.example.com, obfs-uri=/ws, tls-verification=true, udp-relay=true, fast-open=false, tag=Trojan"},
+		{"vless reality", "vless=3.3.3.3:443, method=none, password=sub-password, obfs=over-tls, obfs-host=www.example.com, reality-base64-pubkey=public-key, reality-hex-shortid=abcd1234, vless-flow=xtls-rprx-vision, udp-relay=false, fast-open=false, tag=VLESS"},
+		{"vmess auto", "vmess=4.4.4.4:443, method=auto, password=sub-password, obfs=wss, obfs-host=ws.example.com, obfs-uri=/ws, tls-verification=true, udp-relay=false, fast-open=false, tag=VMess"},
+		{"vmess zero", "vmess=5.5.5.5:80, method=none, password=sub-password, udp-relay=false, fast-open=false, tag=VMess  Zero"},
+	}
+
+	require.Len(t, lines, len(tests), "Hysteria2, TUIC, AnyTLS and WireGuard nodes are skipped")
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lines[i])
+		})
+	}
+}
+
+func TestLoonFormatter_Protocols(t *testing.T) {
+	out, err := NewLoonFormatter().FormatWithPassword(proxyLineTestNodes(t), "sub-password")
+	require.NoError(t, err)
+	lines := strings.Split(out, "\n")
+	require.NotEmpty(t, lines)
+	assert.Equal(t, "[Proxy]", lines[0])
+	lines = lines[1:]
+
+	tests := []struct {
+		name string
+		want string
+	}{
+		{"shadowsocks", `SS = Shadowsocks,1.1.1.1,8388,aes-256-gcm,"sub-password",udp=true`},
+		{"trojan ws", `Trojan = trojan,2.2.2.2,443,"sub-password",transport=ws,path=/ws,host=cdn.example.com,sni=t.example.com,skip-cert-verify=false,udp=true`},
+		{"vless reality", `VLESS = VLESS,3.3.3.3,443,"sub-password",transport=tcp,flow=xtls-rprx-vision,over-tls=true,public-key="public-key",short-id=abcd1234,sni=www.example.com,udp=true`},
+		{"vmess auto", `VMess = vmess,4.4.4.4,443,auto,"sub-password",transport=ws,path=/ws,host=ws.example.com,alterId=0,over-tls=true,sni=v.example.com,skip-cert-verify=false`},
+		{"vmess zero", `VMess  Zero = vmess,5.5.5.5,80,none,"sub-password",transport=tcp,alterId=0`},
+		{"hysteria2", `Hy2 = Hysteria2,6.6.6.6,443,"sub-password",sni=h.example.com,skip-cert-verify=true,salamander-password=obfs-secret,download-bandwidth=200,udp=true`},
+	}
+
+	require.Len(t, lines, len(tests), "TUIC, AnyTLS and WireGuard nodes are skipped")
+	for i, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lines[i])
+		})
+	}
+}
+
+func TestProxyLineVMessSecurity(t *testing.T) {
+	tests := map[string]string{
+		"":                  "auto",
+		"auto":              "auto",
+		"zero":              "none",
+		"none":              "none",
+		"aes-128-gcm":       "aes-128-gcm",
+		"chacha20-poly1305": "chacha20-poly1305",
+	}
+	for security, want := range tests {
+		assert.Equal(t, want, proxyLineVMessSecurity(security), security)
+	}
+}
@@ -0,0 +1,117 @@
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// Health check settings for generated region url-test and fallback groups.
+const (
+	regionGroupTestURL  = "http://www.gstatic.com/generate_204"
+	regionGroupInterval = 300
+)
+
+// regionProxiesPlaceholder matches {{REGION_PROXIES:XX}} template variables.
+var regionProxiesPlaceholder = regexp.MustCompile(`\{\{REGION_PROXIES:([A-Za-z]{2})\}\}`)
+
+// regionGroupKind is a proxy group type generated for every region.
+type regionGroupKind struct {
+	Type        string // Clash/Surge group type
+	NameSuffix  string // Appended to the region display name
+	Variable    string // Template variable listing the group names of this kind
+	HealthCheck bool   // Whether the group probes regionGroupTestURL
+}
+
+// regionGroupKinds lists the groups generated per region, in output order.
+// The url-test group keeps the plain region name so existing templates referencing it still work.
+var regionGroupKinds = []regionGroupKind{
+	{Type: "url-test", Variable: "{{REGION_NAMES}}", HealthCheck: true},
+	{Type: "fallback", NameSuffix: " Fallback", Variable: "{{REGION_FALLBACK_NAMES}}", HealthCheck: true},
+	{Type: "select", NameSuffix: " Select", Variable: "{{REGION_SELECT_NAMES}}"},
+}
+
+// regionGroup is a set of proxies located in the same country.
+type regionGroup struct {
+	Code    string   // ISO 3166-1 alpha-2 code, e.g. "JP"
+	Name    string   // Display name used as group name, e.g. "🇯🇵 Japan"
+	Proxies []string // Proxy names in subscription order
+}
+
+// buildRegionGroups groups nodes by country code in order of first appearance.
+// Callers pass only the nodes their format actually emitted, so groups never
+// reference skipped proxies. Nodes without a valid country code are not grouped.
+func buildRegionGroups(nodes []*Node) []regionGroup {
+	index := make(map[vo.CountryCode]int)
+	var groups []regionGroup
+
+	for _, node := range nodes {
+		if node.CountryCode == "" {
+			continue
+		}
+		code, err := vo.NewCountryCode(node.CountryCode)
+		if err != nil {
+			continue
+		}
+
+		i, ok := index[code]
+		if !ok {
+			i = len(groups)
+			index[code] = i
+			groups = append(groups, regionGroup{Code: code.String(), Name: code.DisplayName()})
+		}
+		groups[i].Proxies = append(groups[i].Proxies, node.Name)
+	}
+
+	return groups
+}
+
+// groupName returns the name of the region's group of the given kind.
+func (g regionGroup) groupName(kind regionGroupKind) string {
+	return g.Name + kind.NameSuffix
+}
+
+// regionGroupNames returns the names of the groups of the given kind in order.
+func regionGroupNames(groups []regionGroup, kind regionGroupKind) []string {
+	names := make([]string, len(groups))
+	for i, g := range groups {
+		names[i] = g.groupName(kind)
+	}
+	return names
+}
+
+// replaceRegionProxies substitutes {{REGION_PROXIES:XX}} with the proxy names of that
+// region, each passed through quote and joined with ", ". Unknown regions render empty.
+func replaceRegionProxies(tmpl string, groups []regionGroup, quote func(string) string) string {
+	return regionProxiesPlaceholder.ReplaceAllStringFunc(tmpl, func(match string) string {
+		code := strings.ToUpper(regionProxiesPlaceholder.FindStringSubmatch(match)[1])
+		for _, g := range groups {
+			if g.Code == code {
+				quoted := make([]string, len(g.Proxies))
+				for i, name := range g.Proxies {
+					quoted[i] = quote(name)
+				}
+				return strings.Join(quoted, ", ")
+			}
+		}
+		return ""
+	})
+}
+
+// surgeRegionGroupLines renders region groups as Surge [Proxy Group] lines,
+// one line per region and group kind.
+func surgeRegionGroupLines(groups []regionGroup) []string {
+	lines := make([]string, 0, len(groups)*len(regionGroupKinds))
+	for _, g := range groups {
+		for _, kind := range regionGroupKinds {
+			line := fmt.Sprintf("%s = %s, %s", g.groupName(kind), kind.Type, strings.Join(g.Proxies, ", "))
+			if kind.HealthCheck {
+				line += fmt.Sprintf(", url=%s, interval=%d", regionGroupTestURL, regionGroupInterval)
+			}
+			lines = append(lines, line)
+		}
+	}
+	return lines
+}
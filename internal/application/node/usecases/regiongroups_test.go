@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gopkg.in/yaml.v3"
+)
+
+func regionTestNodes() []*Node {
+	return []*Node{
+		{Name: "JP-01", Protocol: "trojan", ServerAddress: "1.1.1.1", SubscriptionPort: 443, CountryCode: "jp"},
+		{Name: "US-01", Protocol: "trojan", ServerAddress: "2.2.2.2", SubscriptionPort: 443, CountryCode: "US"},
+		{Name: "JP-02", Protocol: "trojan", ServerAddress: "3.3.3.3", SubscriptionPort: 443, CountryCode: "JP"},
+		{Name: "NoRegion", Protocol: "trojan", ServerAddress: "4.4.4.4", SubscriptionPort: 443},
+	}
+}
+
+func TestClashFormatter_RegionGroups(t *testing.T) {
+	out, err := NewClashFormatter().FormatWithPassword(regionTestNodes(), "password")
+	require.NoError(t, err)
+
+	var config clashConfig
+	require.NoError(t, yaml.Unmarshal([]byte(out), &config))
+
+	jp := []string{"JP-01", "JP-02"}
+	us := []string{"US-01"}
+	want := []clashProxyGroup{
+		{Name: "🇯🇵 Japan", Type: "url-test", Proxies: jp, URL: regionGroupTestURL, Interval: regionGroupInterval},
+		{Name: "🇯🇵 Japan Fallback", Type: "fallback", Proxies: jp, URL: regionGroupTestURL, Interval: regionGroupInterval},
+		{Name: "🇯🇵 Japan Select", Type: "select", Proxies: jp},
+		{Name: "🇺🇸 US", Type: "url-test", Proxies: us, URL: regionGroupTestURL, Interval: regionGroupInterval},
+		{Name: "🇺🇸 US Fallback", Type: "fallback", Proxies: us, URL: regionGroupTestURL, Interval: regionGroupInterval},
+		{Name: "🇺🇸 US Select", Type: "select", Proxies: us},
+	}
+	assert.Equal(t, want, config.ProxyGroups)
+}
+
+func TestSurgeFormatter_RegionGroups(t *testing.T) {
+	out, err := NewSurgeFormatter().FormatWithPassword(regionTestNodes(), "password")
+	require.NoError(t, err)
+
+	_, groupSection, ok := strings.Cut(out, "[Proxy Group]\n")
+	require.True(t, ok)
+	assert.Equal(t, []string{
+		"🇯🇵 Japan = url-test, JP-01, JP-02, url=http://www.gstatic.com/generate_204, interval=300",
+		"🇯🇵 Japan Fallback = fallback, JP-01, JP-02, url=http://www.gstatic.com/generate_204, interval=300",
+		"🇯🇵 Japan Select = select, JP-01, JP-02",
+		"🇺🇸 US = url-test, US-01, url=http://www.gstatic.com/generate_204, interval=300",
+		"🇺🇸 US Fallback = fallback, US-01, url=http://www.gstatic.com/generate_204, interval=300",
+		"🇺🇸 US Select = select, US-01",
+	}, strings.Split(groupSection, "\n"))
+}
+
+func TestReplaceRegionVariables(t *testing.T) {
+	groups := buildRegionGroups(regionTestNodes())
+	tmpl := "[{{REGION_NAMES}}] [{{REGION_FALLBACK_NAMES}}] [{{REGION_SELECT_NAMES}}] [{{REGION_PROXIES:jp}}] [{{REGION_PROXIES:DE}}]"
+
+	got := replaceRegionVariables(tmpl, groups, quoteYAMLString)
+	assert.Equal(t, "['🇯🇵 Japan', '🇺🇸 US'] ['🇯🇵 Japan Fallback', '🇺🇸 US Fallback'] "+
+		"['🇯🇵 Japan Select', '🇺🇸 US Select'] ['JP-01', 'JP-02'] []", got)
+}
+
+func TestClashRegionGroupLines(t *testing.T) {
+	groups := buildRegionGroups(regionTestNodes()[:1])
+
+	assert.Equal(t, strings.Join([]string{
+		"  - { name: '🇯🇵 Japan', type: url-test, proxies: ['JP-01'], url: 'http://www.gstatic.com/generate_204', interval: 300 }",
+		"  - { name: '🇯🇵 Japan Fallback', type: fallback, proxies: ['JP-01'], url: 'http://www.gstatic.com/generate_204', interval: 300 }",
+		"  - { name: '🇯🇵 Japan Select', type: select, proxies: ['JP-01'] }",
+	}, "\n"), clashRegionGroupLines(groups))
+}
@@ -0,0 +1,81 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// RenewCertificatesUseCase is the scheduled counterpart of IssueCertificateUseCase.
+// It issues certificates that were never issued, renews auto-renewing ones that are close
+// to expiry and retries failed issuances after node.CertificateRetryInterval.
+type RenewCertificatesUseCase struct {
+	certRepo node.CertificateRepository
+	issuance *certificateIssuance
+	logger   logger.Interface
+}
+
+func NewRenewCertificatesUseCase(
+	certRepo node.CertificateRepository,
+	issuer CertificateIssuer,
+	logger logger.Interface,
+) *RenewCertificatesUseCase {
+	return &RenewCertificatesUseCase{
+		certRepo: certRepo,
+		issuance: &certificateIssuance{certRepo: certRepo, issuer: issuer, logger: logger},
+		logger:   logger,
+	}
+}
+
+// SetDeliverer sets the deliverer used to push renewed certificates to node agents.
+func (uc *RenewCertificatesUseCase) SetDeliverer(deliverer CertificateDeliverer) {
+	uc.issuance.deliverer = deliverer
+}
+
+// Execute issues every due certificate in turn and returns the number issued successfully.
+func (uc *RenewCertificatesUseCase) Execute(ctx context.Context) (int, error) {
+	if uc.issuance.issuer == nil {
+		return 0, nil
+	}
+
+	certs, err := uc.certRepo.ListAll(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	issued := 0
+	for _, cert := range certs {
+		if ctx.Err() != nil {
+			return issued, ctx.Err()
+		}
+		if !cert.IsDueForIssuance(biztime.NowUTC()) {
+			continue
+		}
+		if !uc.issuance.issuer.HasDNSProvider(cert.DNSProvider()) {
+			uc.logger.Warnw("skipping certificate renewal, DNS provider not configured",
+				"sid", cert.SID(),
+				"dns_provider", cert.DNSProvider(),
+			)
+			continue
+		}
+
+		claimed, err := uc.issuance.claim(ctx, cert)
+		if err != nil {
+			uc.logger.Errorw("failed to claim certificate issuance", "sid", cert.SID(), "error", err)
+			continue
+		}
+		if !claimed {
+			continue
+		}
+
+		if err := uc.issuance.issue(ctx, cert); err != nil {
+			continue
+		}
+		issued++
+	}
+
+	return issued, nil
+}
@@ -34,10 +34,16 @@ type OnlineSubscriptionInfo struct {
 	IP             string
 }
 
+// DeviceLimitEnforcer enforces plan device limits across all nodes
+type DeviceLimitEnforcer interface {
+	CheckAndEnforce(ctx context.Context, subscriptionIDs []uint) error
+}
+
 // ReportOnlineSubscriptionsUseCase handles reporting online subscriptions from node agents
 type ReportOnlineSubscriptionsUseCase struct {
 	subscriptionTracker    OnlineSubscriptionTracker
 	subscriptionIDResolver SubscriptionIDResolver
+	deviceLimitEnforcer    DeviceLimitEnforcer
 	logger                 logger.Interface
 }
 
@@ -54,6 +60,11 @@ func NewReportOnlineSubscriptionsUseCase(
 	}
 }
 
+// SetDeviceLimitEnforcer sets the cluster-wide device limit enforcer (optional)
+func (uc *ReportOnlineSubscriptionsUseCase) SetDeviceLimitEnforcer(enforcer DeviceLimitEnforcer) {
+	uc.deviceLimitEnforcer = enforcer
+}
+
 // Execute processes online subscriptions report from node agent
 func (uc *ReportOnlineSubscriptionsUseCase) Execute(ctx context.Context, cmd ReportOnlineSubscriptionsCommand) (*ReportOnlineSubscriptionsResult, error) {
 	if cmd.NodeID == 0 {
@@ -147,6 +158,20 @@ func (uc *ReportOnlineSubscriptionsUseCase) Execute(ctx context.Context, cmd Rep
 		return nil, fmt.Errorf("failed to update online subscriptions")
 	}
 
+	// The tracker now holds this node's IPs, so device counts are cluster-wide
+	if uc.deviceLimitEnforcer != nil {
+		subscriptionIDs := make([]uint, 0, len(sidToID))
+		for _, internalID := range sidToID {
+			subscriptionIDs = append(subscriptionIDs, internalID)
+		}
+		if err := uc.deviceLimitEnforcer.CheckAndEnforce(ctx, subscriptionIDs); err != nil {
+			uc.logger.Warnw("failed to enforce device limits",
+				"error", err,
+				"node_id", cmd.NodeID,
+			)
+		}
+	}
+
 	uc.logger.Debugw("online subscriptions reported",
 		"node_id", cmd.NodeID,
 		"online_count", len(subscriptions),
@@ -0,0 +1,164 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/user"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type RollbackNodeConfigVersionCommand struct {
+	NodeSID     string
+	Version     int
+	RequestedBy uint
+	// RestoreSecrets also restores the server keys of the version instead of keeping the current ones
+	RestoreSecrets bool
+}
+
+type RollbackNodeConfigVersionUseCase struct {
+	nodeRepo             node.NodeRepository
+	versionRepo          node.ConfigVersionRepository
+	userRepo             user.Repository
+	recorder             NodeConfigVersionRecorder
+	configChangeNotifier NodeConfigChangeNotifier
+	logger               logger.Interface
+}
+
+func NewRollbackNodeConfigVersionUseCase(
+	nodeRepo node.NodeRepository,
+	versionRepo node.ConfigVersionRepository,
+	userRepo user.Repository,
+	recorder NodeConfigVersionRecorder,
+	logger logger.Interface,
+) *RollbackNodeConfigVersionUseCase {
+	return &RollbackNodeConfigVersionUseCase{
+		nodeRepo:    nodeRepo,
+		versionRepo: versionRepo,
+		userRepo:    userRepo,
+		recorder:    recorder,
+		logger:      logger,
+	}
+}
+
+// SetConfigChangeNotifier sets the notifier for node configuration changes.
+func (uc *RollbackNodeConfigVersionUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+// Execute restores the configuration of the given version and records the rollback
+// as a new version. Returns the new version, or the latest one if the node already
+// has the restored configuration.
+func (uc *RollbackNodeConfigVersionUseCase) Execute(ctx context.Context, cmd RollbackNodeConfigVersionCommand) (*dto.ConfigVersionDTO, error) {
+	uc.logger.Infow("executing rollback node config version use case",
+		"sid", cmd.NodeSID, "version", cmd.Version, "restore_secrets", cmd.RestoreSecrets)
+
+	n, err := uc.nodeRepo.GetBySID(ctx, cmd.NodeSID)
+	if err != nil {
+		uc.logger.Errorw("failed to get node by SID", "sid", cmd.NodeSID, "error", err)
+		return nil, errors.NewNotFoundError("node not found")
+	}
+
+	target, err := uc.versionRepo.GetByVersion(ctx, n.ID(), cmd.Version)
+	if err != nil {
+		uc.logger.Errorw("failed to get node config version", "sid", cmd.NodeSID, "version", cmd.Version, "error", err)
+		return nil, fmt.Errorf("failed to get node config version: %w", err)
+	}
+	if target == nil {
+		return nil, errors.NewNotFoundError(fmt.Sprintf("config version %d not found", cmd.Version))
+	}
+
+	snapshot := target.Snapshot()
+	if err := uc.validateNodeReferences(ctx, n, snapshot); err != nil {
+		return nil, err
+	}
+
+	previousConfig := n.ConfigSnapshot()
+	if err := n.RestoreConfig(snapshot, cmd.RestoreSecrets); err != nil {
+		return nil, errors.NewValidationError(err.Error())
+	}
+
+	if err := uc.nodeRepo.Update(ctx, n); err != nil {
+		uc.logger.Errorw("failed to update node", "sid", cmd.NodeSID, "error", err)
+		return nil, err
+	}
+
+	var authorID *uint
+	if cmd.RequestedBy != 0 {
+		authorID = &cmd.RequestedBy
+	}
+	restoredFrom := target.Version()
+	version, err := uc.recorder.Record(ctx, n, &previousConfig, authorID, node.ConfigVersionSourceRollback, &restoredFrom)
+	if err != nil {
+		// The node is already restored, so report the failure without undoing it
+		uc.logger.Errorw("failed to record node config rollback", "sid", cmd.NodeSID, "version", cmd.Version, "error", err)
+		return nil, fmt.Errorf("node configuration restored but failed to record version: %w", err)
+	}
+	if version == nil {
+		// Nothing changed, the node already has the restored configuration
+		if version, err = uc.versionRepo.GetLatest(ctx, n.ID()); err != nil {
+			uc.logger.Errorw("failed to get latest node config version", "sid", cmd.NodeSID, "error", err)
+			return nil, fmt.Errorf("failed to get latest node config version: %w", err)
+		}
+	}
+
+	uc.logger.Infow("node config rolled back successfully", "sid", cmd.NodeSID, "restored_from", restoredFrom, "version", version.Version())
+
+	if uc.configChangeNotifier != nil {
+		nodeID := n.ID()
+		goroutine.SafeGo(uc.logger, "rollback-node-config-notify-config-change", func() {
+			if err := uc.configChangeNotifier.NotifyConfigChange(context.Background(), nodeID); err != nil {
+				uc.logger.Warnw("failed to notify node agent of config change",
+					"error", err,
+					"node_id", nodeID,
+				)
+			}
+		})
+	}
+
+	authors := lookupConfigVersionAuthors(ctx, uc.userRepo, uc.logger, []*node.ConfigVersion{version})
+	var author *dto.NodeOwnerDTO
+	if version.AuthorID() != nil {
+		author = authors[*version.AuthorID()]
+	}
+	return dto.ToConfigVersionDTO(version, author), nil
+}
+
+// validateNodeReferences checks that the nodes referenced by the restored route and DNS
+// configuration still exist (and belong to the owner, for user nodes).
+func (uc *RollbackNodeConfigVersionUseCase) validateNodeReferences(ctx context.Context, n *node.Node, snapshot node.ConfigSnapshot) error {
+	var referencedSIDs []string
+	if snapshot.Route != nil {
+		referencedSIDs = append(referencedSIDs, snapshot.Route.GetReferencedNodeSIDs()...)
+	}
+	if snapshot.DNS != nil {
+		referencedSIDs = append(referencedSIDs, snapshot.DNS.GetReferencedNodeSIDs()...)
+	}
+	if len(referencedSIDs) == 0 {
+		return nil
+	}
+
+	var (
+		invalidSIDs []string
+		err         error
+	)
+	if n.IsUserOwned() {
+		invalidSIDs, err = uc.nodeRepo.ValidateNodeSIDsForUser(ctx, referencedSIDs, *n.UserID())
+	} else {
+		invalidSIDs, err = uc.nodeRepo.ValidateNodeSIDsExist(ctx, referencedSIDs)
+	}
+	if err != nil {
+		uc.logger.Errorw("failed to validate config node references", "sid", n.SID(), "error", err)
+		return errors.NewInternalError("failed to validate config node references")
+	}
+	if len(invalidSIDs) > 0 {
+		return errors.NewValidationError(
+			fmt.Sprintf("cannot restore version, referenced nodes no longer exist: %v", invalidSIDs))
+	}
+
+	return nil
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// RotateDueRealityKeysUseCase is the scheduled counterpart of RotateRealityKeysUseCase.
+// It rotates VLESS Reality nodes whose rotation interval has elapsed and drops previous
+// short IDs whose overlap window has closed, pushing the new config to each affected agent.
+type RotateDueRealityKeysUseCase struct {
+	nodeRepo             node.NodeRepository
+	configChangeNotifier NodeConfigChangeNotifier
+	versionRecorder      NodeConfigVersionRecorder
+	logger               logger.Interface
+}
+
+func NewRotateDueRealityKeysUseCase(
+	nodeRepo node.NodeRepository,
+	logger logger.Interface,
+) *RotateDueRealityKeysUseCase {
+	return &RotateDueRealityKeysUseCase{
+		nodeRepo: nodeRepo,
+		logger:   logger,
+	}
+}
+
+// SetConfigChangeNotifier sets the notifier used to push rotated configs to node agents.
+func (uc *RotateDueRealityKeysUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+// SetConfigVersionRecorder sets the recorder for the node configuration history.
+func (uc *RotateDueRealityKeysUseCase) SetConfigVersionRecorder(recorder NodeConfigVersionRecorder) {
+	uc.versionRecorder = recorder
+}
+
+// Execute processes all rotation candidates and returns the number of nodes that changed.
+func (uc *RotateDueRealityKeysUseCase) Execute(ctx context.Context) (int, error) {
+	nodes, err := uc.nodeRepo.ListRealityRotationNodes(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("failed to list reality rotation nodes: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	changed := 0
+	for _, n := range nodes {
+		previousConfig := n.ConfigSnapshot()
+		rotated := false
+		if n.IsRealityRotationDue(now) {
+			if err := n.RotateRealityKeys(n.VLESSConfig().RealityRotation().RotateKeyPair()); err != nil {
+				uc.logger.Errorw("failed to rotate reality keys", "node_id", n.ID(), "error", err)
+				continue
+			}
+			rotated = true
+		} else if !n.ExpireRealityShortID(now) {
+			continue
+		}
+
+		if err := uc.nodeRepo.Update(ctx, n); err != nil {
+			uc.logger.Errorw("failed to update node after reality rotation", "node_id", n.ID(), "error", err)
+			continue
+		}
+
+		if rotated {
+			uc.logger.Infow("node reality keys rotated by schedule", "node_id", n.ID(), "sid", n.SID())
+			recordRotation(ctx, uc.logger, uc.versionRecorder, n, previousConfig, 0)
+		} else {
+			uc.logger.Infow("previous reality short ID expired", "node_id", n.ID(), "sid", n.SID())
+		}
+
+		if uc.configChangeNotifier != nil {
+			if err := uc.configChangeNotifier.NotifyConfigChange(ctx, n.ID()); err != nil {
+				uc.logger.Warnw("failed to notify node agent of reality key rotation",
+					"error", err,
+					"node_id", n.ID(),
+				)
+			}
+		}
+		changed++
+	}
+
+	return changed, nil
+}
@@ -0,0 +1,107 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type RotateNodeServerKeyCommand struct {
+	SID       string // External API identifier
+	RotatedBy uint   // User rotating the key, recorded in the configuration history
+}
+
+type RotateNodeServerKeyResult struct {
+	NodeSID          string    `json:"node_id"`
+	EncryptionMethod string    `json:"encryption_method"`
+	RotatedAt        time.Time `json:"rotated_at"`
+}
+
+// RotateNodeServerKeyUseCase replaces the SS2022 server PSK of a Shadowsocks node with a
+// random key and pushes the new key to the node agent. Existing client subscriptions keep
+// working only after they are refreshed, since the server key is part of every user password.
+type RotateNodeServerKeyUseCase struct {
+	nodeRepo             node.NodeRepository
+	configChangeNotifier NodeConfigChangeNotifier
+	versionRecorder      NodeConfigVersionRecorder
+	logger               logger.Interface
+}
+
+func NewRotateNodeServerKeyUseCase(
+	nodeRepo node.NodeRepository,
+	logger logger.Interface,
+) *RotateNodeServerKeyUseCase {
+	return &RotateNodeServerKeyUseCase{
+		nodeRepo: nodeRepo,
+		logger:   logger,
+	}
+}
+
+// SetConfigChangeNotifier sets the notifier used to push the new key to the node agent.
+func (uc *RotateNodeServerKeyUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+// SetConfigVersionRecorder sets the recorder for the node configuration history.
+func (uc *RotateNodeServerKeyUseCase) SetConfigVersionRecorder(recorder NodeConfigVersionRecorder) {
+	uc.versionRecorder = recorder
+}
+
+func (uc *RotateNodeServerKeyUseCase) Execute(ctx context.Context, cmd RotateNodeServerKeyCommand) (*RotateNodeServerKeyResult, error) {
+	if cmd.SID == "" {
+		return nil, errors.NewValidationError("SID must be provided")
+	}
+
+	n, err := uc.nodeRepo.GetBySID(ctx, cmd.SID)
+	if err != nil {
+		uc.logger.Errorw("failed to get node by SID", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	if n == nil {
+		return nil, errors.NewNotFoundError("node not found")
+	}
+
+	if !n.Protocol().IsShadowsocks() || !vo.IsSS2022Method(n.EncryptionConfig().Method()) {
+		return nil, errors.NewValidationError("server key rotation requires a Shadowsocks node using a 2022-blake3-* method")
+	}
+
+	previousConfig := n.ConfigSnapshot()
+	if err := n.RotateServerKey(); err != nil {
+		uc.logger.Errorw("failed to rotate server key", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to rotate server key: %w", err)
+	}
+
+	if err := uc.nodeRepo.Update(ctx, n); err != nil {
+		uc.logger.Errorw("failed to update node", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	uc.logger.Infow("node server key rotated", "sid", cmd.SID, "node_id", n.ID())
+
+	recordRotation(ctx, uc.logger, uc.versionRecorder, n, previousConfig, cmd.RotatedBy)
+
+	if uc.configChangeNotifier != nil {
+		nodeID := n.ID()
+		goroutine.SafeGo(uc.logger, "rotate-server-key-notify-config-change", func() {
+			if err := uc.configChangeNotifier.NotifyConfigChange(context.Background(), nodeID); err != nil {
+				uc.logger.Warnw("failed to notify node agent of server key rotation",
+					"error", err,
+					"node_id", nodeID,
+				)
+			}
+		})
+	}
+
+	return &RotateNodeServerKeyResult{
+		NodeSID:          n.SID(),
+		EncryptionMethod: n.EncryptionConfig().Method(),
+		RotatedAt:        biztime.NowUTC(),
+	}, nil
+}
@@ -0,0 +1,74 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// fakeRotationNodeRepo holds a single node; other methods panic.
+type fakeRotationNodeRepo struct {
+	node.NodeRepository
+	node *node.Node
+}
+
+func (r *fakeRotationNodeRepo) GetBySID(ctx context.Context, sid string) (*node.Node, error) {
+	return r.node, nil
+}
+
+func (r *fakeRotationNodeRepo) Update(ctx context.Context, n *node.Node) error {
+	return nil
+}
+
+type recordedConfigVersion struct {
+	previous node.ConfigSnapshot
+	current  node.ConfigSnapshot
+	authorID *uint
+	source   node.ConfigVersionSource
+}
+
+type recordingVersionRecorder struct {
+	versions []recordedConfigVersion
+}
+
+func (r *recordingVersionRecorder) Record(ctx context.Context, n *node.Node, previous *node.ConfigSnapshot, authorID *uint, source node.ConfigVersionSource, restoredFrom *int) (*node.ConfigVersion, error) {
+	r.versions = append(r.versions, recordedConfigVersion{
+		previous: *previous,
+		current:  n.ConfigSnapshot(),
+		authorID: authorID,
+		source:   source,
+	})
+	return nil, nil
+}
+
+func TestRotateNodeServerKeyUseCase_RecordsConfigVersion(t *testing.T) {
+	n, err := NewCreateNodeUseCase(&fakeImportNodeRepo{}, nil, logger.NewLogger()).buildNode(context.Background(), CreateNodeCommand{
+		Name:      "SS-01",
+		AgentPort: 8388,
+		Protocol:  "shadowsocks",
+		Method:    vo.Method2022Blake3AES128GCM,
+	})
+	require.NoError(t, err)
+
+	recorder := &recordingVersionRecorder{}
+	uc := NewRotateNodeServerKeyUseCase(&fakeRotationNodeRepo{node: n}, logger.NewLogger())
+	uc.SetConfigVersionRecorder(recorder)
+
+	_, err = uc.Execute(context.Background(), RotateNodeServerKeyCommand{SID: n.SID(), RotatedBy: 7})
+	require.NoError(t, err)
+
+	require.Len(t, recorder.versions, 1)
+	version := recorder.versions[0]
+	assert.Equal(t, node.ConfigVersionSourceRotation, version.source)
+	require.NotNil(t, version.authorID)
+	assert.Equal(t, uint(7), *version.authorID)
+	assert.Empty(t, version.previous.Encryption.ServerKey())
+	assert.Equal(t, n.EncryptionConfig().ServerKey(), version.current.Encryption.ServerKey())
+	assert.NotEmpty(t, version.current.Encryption.ServerKey())
+}
@@ -0,0 +1,140 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/goroutine"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type RotateRealityKeysCommand struct {
+	SID       string // External API identifier
+	RotatedBy uint   // User rotating the keys, recorded in the configuration history
+}
+
+type RotateRealityKeysResult struct {
+	NodeSID                  string     `json:"node_id"`
+	PublicKey                string     `json:"public_key"`
+	ShortID                  string     `json:"short_id"`
+	PreviousShortIDExpiresAt *time.Time `json:"previous_short_id_expires_at,omitempty"`
+	RotatedAt                time.Time  `json:"rotated_at"`
+}
+
+// RotateRealityKeysUseCase replaces the Reality key pair and short ID of a VLESS node on demand
+// and pushes the new config to the node agent. The previous short ID stays accepted for the
+// overlap window of the node's rotation policy; the old public key stops working immediately.
+type RotateRealityKeysUseCase struct {
+	nodeRepo             node.NodeRepository
+	configChangeNotifier NodeConfigChangeNotifier
+	versionRecorder      NodeConfigVersionRecorder
+	logger               logger.Interface
+}
+
+func NewRotateRealityKeysUseCase(
+	nodeRepo node.NodeRepository,
+	logger logger.Interface,
+) *RotateRealityKeysUseCase {
+	return &RotateRealityKeysUseCase{
+		nodeRepo: nodeRepo,
+		logger:   logger,
+	}
+}
+
+// SetConfigChangeNotifier sets the notifier used to push the new keys to the node agent.
+func (uc *RotateRealityKeysUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNotifier) {
+	uc.configChangeNotifier = notifier
+}
+
+// SetConfigVersionRecorder sets the recorder for the node configuration history.
+func (uc *RotateRealityKeysUseCase) SetConfigVersionRecorder(recorder NodeConfigVersionRecorder) {
+	uc.versionRecorder = recorder
+}
+
+func (uc *RotateRealityKeysUseCase) Execute(ctx context.Context, cmd RotateRealityKeysCommand) (*RotateRealityKeysResult, error) {
+	if cmd.SID == "" {
+		return nil, errors.NewValidationError("SID must be provided")
+	}
+
+	n, err := uc.nodeRepo.GetBySID(ctx, cmd.SID)
+	if err != nil {
+		uc.logger.Errorw("failed to get node by SID", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to get node: %w", err)
+	}
+	if n == nil {
+		return nil, errors.NewNotFoundError("node not found")
+	}
+
+	if !n.Protocol().IsVLESS() || n.VLESSConfig() == nil || n.VLESSConfig().Security() != vo.VLESSSecurityReality {
+		return nil, errors.NewValidationError("reality key rotation requires a VLESS node using reality security")
+	}
+
+	previousConfig := n.ConfigSnapshot()
+
+	// Manual rotations always replace the key pair, since they are typically triggered by a suspected leak
+	if err := n.RotateRealityKeys(true); err != nil {
+		uc.logger.Errorw("failed to rotate reality keys", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to rotate reality keys: %w", err)
+	}
+
+	if err := uc.nodeRepo.Update(ctx, n); err != nil {
+		uc.logger.Errorw("failed to update node", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to update node: %w", err)
+	}
+
+	uc.logger.Infow("node reality keys rotated", "sid", cmd.SID, "node_id", n.ID())
+
+	recordRotation(ctx, uc.logger, uc.versionRecorder, n, previousConfig, cmd.RotatedBy)
+
+	notifyRealityRotation(uc.logger, uc.configChangeNotifier, n.ID())
+
+	vc := n.VLESSConfig()
+	rotation := vc.RealityRotation()
+	rotatedAt := biztime.NowUTC()
+	if rotation.RotatedAt() != nil {
+		rotatedAt = *rotation.RotatedAt()
+	}
+
+	return &RotateRealityKeysResult{
+		NodeSID:                  n.SID(),
+		PublicKey:                vc.PublicKey(),
+		ShortID:                  vc.ShortID(),
+		PreviousShortIDExpiresAt: rotation.PreviousShortIDExpiresAt(),
+		RotatedAt:                rotatedAt,
+	}, nil
+}
+
+// recordRotation records a key rotation in the node configuration history.
+// A failure is only logged, since the rotation itself has already been saved.
+func recordRotation(ctx context.Context, log logger.Interface, recorder NodeConfigVersionRecorder, n *node.Node, previous node.ConfigSnapshot, rotatedBy uint) {
+	if recorder == nil {
+		return
+	}
+	var authorID *uint
+	if rotatedBy != 0 {
+		authorID = &rotatedBy
+	}
+	if _, err := recorder.Record(ctx, n, &previous, authorID, node.ConfigVersionSourceRotation, nil); err != nil {
+		log.Warnw("failed to record node config version", "error", err, "node_id", n.ID())
+	}
+}
+
+// notifyRealityRotation pushes a rotated Reality config to the node agent asynchronously.
+func notifyRealityRotation(log logger.Interface, notifier NodeConfigChangeNotifier, nodeID uint) {
+	if notifier == nil {
+		return
+	}
+	goroutine.SafeGo(log, "rotate-reality-keys-notify-config-change", func() {
+		if err := notifier.NotifyConfigChange(context.Background(), nodeID); err != nil {
+			log.Warnw("failed to notify node agent of reality key rotation",
+				"error", err,
+				"node_id", nodeID,
+			)
+		}
+	})
+}
@@ -0,0 +1,217 @@
+package usecases
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// formatSingBox renders nodes with the sing-box formatter and decodes the JSON output.
+func formatSingBox(t *testing.T, nodes []*Node, password string) map[string]any {
+	t.Helper()
+	out, err := NewSingBoxFormatter().FormatWithPassword(nodes, password)
+	require.NoError(t, err)
+
+	var config map[string]any
+	require.NoError(t, json.Unmarshal([]byte(out), &config))
+	return config
+}
+
+// singBoxOutboundByTag returns the outbound with the given tag from a decoded config.
+func singBoxOutboundByTag(t *testing.T, config map[string]any, tag string) map[string]any {
+	t.Helper()
+	for _, o := range config["outbounds"].([]any) {
+		outbound := o.(map[string]any)
+		if outbound["tag"] == tag {
+			return outbound
+		}
+	}
+	t.Fatalf("outbound %q not found", tag)
+	return nil
+}
+
+func singBoxTags(config map[string]any) []string {
+	var tags []string
+	for _, o := range config["outbounds"].([]any) {
+		tags = append(tags, o.(map[string]any)["tag"].(string))
+	}
+	return tags
+}
+
+func singBoxTestNodes(t *testing.T) []*Node {
+	t.Helper()
+
+	keys, err := vo.GenerateRealityKeyPair()
+	require.NoError(t, err)
+	vless, err := vo.NewVLESSConfig("tcp", "xtls-rprx-vision", vo.VLESSSecurityReality, "www.example.com", "",
+		false, "", "", "", keys.PrivateKey, keys.PublicKey, "abcd1234", "", "", "")
+	require.NoError(t, err)
+	vmess, err := vo.NewVMessConfig(0, "auto", vo.VMessTransportWS, "ws.example.com", "/ws", "", true, "v.example.com", false, "", "")
+	require.NoError(t, err)
+	up, down := 100, 200
+	hy2, err := vo.NewHysteria2Config("password", "bbr", "salamander", "obfs-secret", &up, &down, "h.example.com", true, "")
+	require.NoError(t, err)
+	tuic, err := vo.NewTUICConfig("uuid", "password", "bbr", "native", "h3,spdy/3.1", "q.example.com", false, true)
+	require.NoError(t, err)
+	anytls, err := vo.NewAnyTLSConfig("anytls-password", "a.example.com", false, "firefox", "30s", "60s", 2)
+	require.NoError(t, err)
+
+	return []*Node{
+		{Name: "SS", Protocol: "shadowsocks", ServerAddress: "1.1.1.1", SubscriptionPort: 8388, EncryptionMethod: "aes-256-gcm"},
+		{Name: "Trojan", Protocol: "trojan", ServerAddress: "2.2.2.2", SubscriptionPort: 443, TransportProtocol: vo.TransportGRPC, Host: "svc", SNI: "t.example.com"},
+		{Name: "VLESS", Protocol: "vless", ServerAddress: "3.3.3.3", SubscriptionPort: 443, VLESSConfig: &vless},
+		{Name: "VMess", Protocol: "vmess", ServerAddress: "4.4.4.4", SubscriptionPort: 443, VMessConfig: &vmess},
+		{Name: "Hy2", Protocol: "hysteria2", ServerAddress: "5.5.5.5", SubscriptionPort: 443, Hysteria2Config: &hy2},
+		{Name: "TUIC", Protocol: "tuic", ServerAddress: "6.6.6.6", SubscriptionPort: 443, TUICConfig: &tuic},
+		{Name: "AnyTLS", Protocol: "anytls", ServerAddress: "7.7.7.7", SubscriptionPort: 443, AnyTLSConfig: &anytls},
+	}
+}
+
+func TestSingBoxFormatter_ProtocolOutbounds(t *testing.T) {
+	nodes := singBoxTestNodes(t)
+	config := formatSingBox(t, nodes, "sub-password")
+
+	t.Run("shadowsocks", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "SS")
+		assert.Equal(t, "shadowsocks", o["type"])
+		assert.Equal(t, "1.1.1.1", o["server"])
+		assert.EqualValues(t, 8388, o["server_port"])
+		assert.Equal(t, "aes-256-gcm", o["method"])
+		assert.Equal(t, "sub-password", o["password"])
+	})
+
+	t.Run("trojan", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "Trojan")
+		assert.Equal(t, "trojan", o["type"])
+		assert.Equal(t, "sub-password", o["password"])
+		tls := o["tls"].(map[string]any)
+		assert.Equal(t, true, tls["enabled"])
+		assert.Equal(t, "t.example.com", tls["server_name"])
+		transport := o["transport"].(map[string]any)
+		assert.Equal(t, "grpc", transport["type"])
+		assert.Equal(t, "svc", transport["service_name"])
+	})
+
+	t.Run("vless reality", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "VLESS")
+		assert.Equal(t, "vless", o["type"])
+		assert.Equal(t, "sub-password", o["uuid"])
+		assert.Equal(t, "xtls-rprx-vision", o["flow"])
+		assert.Equal(t, "xudp", o["packet_encoding"])
+		tls := o["tls"].(map[string]any)
+		assert.Equal(t, "www.example.com", tls["server_name"])
+		utls := tls["utls"].(map[string]any)
+		assert.Equal(t, "chrome", utls["fingerprint"], "reality defaults to the chrome fingerprint")
+		reality := tls["reality"].(map[string]any)
+		assert.Equal(t, true, reality["enabled"])
+		assert.Equal(t, nodes[2].VLESSConfig.PublicKey(), reality["public_key"])
+		assert.Equal(t, "abcd1234", reality["short_id"])
+		assert.NotContains(t, reality, "private_key")
+	})
+
+	t.Run("vmess", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "VMess")
+		assert.Equal(t, "vmess", o["type"])
+		assert.Equal(t, "sub-password", o["uuid"])
+		assert.Equal(t, "auto", o["security"])
+		assert.Equal(t, "v.example.com", o["tls"].(map[string]any)["server_name"])
+		transport := o["transport"].(map[string]any)
+		assert.Equal(t, "ws", transport["type"])
+		assert.Equal(t, "/ws", transport["path"])
+		assert.Equal(t, map[string]any{"Host": "ws.example.com"}, transport["headers"])
+	})
+
+	t.Run("hysteria2 obfs", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "Hy2")
+		assert.Equal(t, "hysteria2", o["type"])
+		assert.Equal(t, "sub-password", o["password"])
+		assert.EqualValues(t, 100, o["up_mbps"])
+		assert.EqualValues(t, 200, o["down_mbps"])
+		obfs := o["obfs"].(map[string]any)
+		assert.Equal(t, "salamander", obfs["type"])
+		assert.Equal(t, "obfs-secret", obfs["password"])
+		tls := o["tls"].(map[string]any)
+		assert.Equal(t, true, tls["insecure"])
+		assert.Equal(t, []any{"h3"}, tls["alpn"])
+	})
+
+	t.Run("tuic", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "TUIC")
+		assert.Equal(t, "tuic", o["type"])
+		assert.Equal(t, "sub-password", o["uuid"])
+		assert.Equal(t, "sub-password", o["password"])
+		assert.Equal(t, "bbr", o["congestion_control"])
+		assert.Equal(t, "native", o["udp_relay_mode"])
+		tls := o["tls"].(map[string]any)
+		assert.Equal(t, true, tls["disable_sni"])
+		assert.Equal(t, []any{"h3", "spdy/3.1"}, tls["alpn"])
+	})
+
+	t.Run("anytls", func(t *testing.T) {
+		o := singBoxOutboundByTag(t, config, "AnyTLS")
+		assert.Equal(t, "anytls", o["type"])
+		assert.Equal(t, "sub-password", o["password"])
+		assert.Equal(t, "30s", o["idle_session_check_interval"])
+		assert.Equal(t, "60s", o["idle_session_timeout"])
+		assert.EqualValues(t, 2, o["min_idle_session"])
+		tls := o["tls"].(map[string]any)
+		assert.Equal(t, "a.example.com", tls["server_name"])
+		assert.Equal(t, "firefox", tls["utls"].(map[string]any)["fingerprint"])
+	})
+}
+
+func TestSingBoxFormatter_Groups(t *testing.T) {
+	config := formatSingBox(t, singBoxTestNodes(t), "sub-password")
+	nodeTags := []any{"SS", "Trojan", "VLESS", "VMess", "Hy2", "TUIC", "AnyTLS"}
+
+	selector := singBoxOutboundByTag(t, config, singBoxSelectorTag)
+	assert.Equal(t, "selector", selector["type"])
+	assert.Equal(t, singBoxURLTestTag, selector["default"])
+	assert.Equal(t, append([]any{singBoxURLTestTag}, nodeTags...), selector["outbounds"])
+
+	urltest := singBoxOutboundByTag(t, config, singBoxURLTestTag)
+	assert.Equal(t, "urltest", urltest["type"])
+	assert.Equal(t, nodeTags, urltest["outbounds"])
+	assert.Equal(t, singBoxURLTestURL, urltest["url"])
+
+	assert.Equal(t, "direct", singBoxOutboundByTag(t, config, singBoxDirectTag)["type"])
+	assert.Equal(t, singBoxSelectorTag, config["route"].(map[string]any)["final"])
+}
+
+func TestSingBoxFormatter_NoNodes(t *testing.T) {
+	config := formatSingBox(t, nil, "sub-password")
+
+	selector := singBoxOutboundByTag(t, config, singBoxSelectorTag)
+	assert.Equal(t, []any{singBoxDirectTag}, selector["outbounds"], "empty selector falls back to direct")
+	assert.Equal(t, []string{singBoxSelectorTag, singBoxDirectTag}, singBoxTags(config))
+}
+
+func TestSingBoxFormatter_DuplicateTags(t *testing.T) {
+	nodes := []*Node{
+		{Name: "HK", Protocol: "shadowsocks", ServerAddress: "1.1.1.1", SubscriptionPort: 1, EncryptionMethod: "aes-256-gcm"},
+		{Name: "HK", Protocol: "trojan", ServerAddress: "2.2.2.2", SubscriptionPort: 2},
+		{Name: "HK", Protocol: "shadowsocks", ServerAddress: "3.3.3.3", SubscriptionPort: 3, EncryptionMethod: "aes-256-gcm"},
+		// Node names colliding with the built-in group tags
+		{Name: singBoxSelectorTag, Protocol: "trojan", ServerAddress: "4.4.4.4", SubscriptionPort: 4},
+		{Name: singBoxDirectTag, Protocol: "trojan", ServerAddress: "5.5.5.5", SubscriptionPort: 5},
+	}
+	config := formatSingBox(t, nodes, "sub-password")
+
+	tags := singBoxTags(config)
+	seen := make(map[string]bool)
+	for _, tag := range tags {
+		assert.False(t, seen[tag], "duplicate tag %q", tag)
+		seen[tag] = true
+	}
+
+	assert.Equal(t, "1.1.1.1", singBoxOutboundByTag(t, config, "HK")["server"])
+	assert.Equal(t, "2.2.2.2", singBoxOutboundByTag(t, config, "HK 2")["server"])
+	assert.Equal(t, "3.3.3.3", singBoxOutboundByTag(t, config, "HK 3")["server"])
+	assert.Equal(t, "4.4.4.4", singBoxOutboundByTag(t, config, singBoxSelectorTag+" 2")["server"])
+	assert.Equal(t, "5.5.5.5", singBoxOutboundByTag(t, config, singBoxDirectTag+" 2")["server"])
+	assert.Equal(t, "selector", singBoxOutboundByTag(t, config, singBoxSelectorTag)["type"])
+}
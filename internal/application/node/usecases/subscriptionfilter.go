@@ -0,0 +1,155 @@
+package usecases
+
+import (
+	"fmt"
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/orris-inc/orris/internal/shared/errors"
+)
+
+const (
+	// maxFilterPatternLength caps user-supplied regular expressions in subscription URLs.
+	maxFilterPatternLength = 256
+	// maxRenameRules caps the number of rename rules per subscription request.
+	maxRenameRules = 20
+)
+
+// subscriptionProtocols lists the node protocols that can appear in a subscription.
+var subscriptionProtocols = []string{"shadowsocks", "trojan", "vless", "vmess", "hysteria2", "tuic", "anytls", "wireguard"}
+
+// protocolAliases maps short protocol names accepted in the protocol= parameter to node protocols.
+var protocolAliases = map[string]string{
+	"ss":  "shadowsocks",
+	"hy2": "hysteria2",
+	"wg":  "wireguard",
+}
+
+// SubscriptionNodeFilter holds the per-request node selection parameters of a subscription URL.
+// All fields are optional; the zero value keeps every node unchanged.
+type SubscriptionNodeFilter struct {
+	Include   string   // Regex; only nodes whose name matches are kept
+	Exclude   string   // Regex; nodes whose name matches are dropped
+	Protocols []string // Allowed protocols (shadowsocks, trojan, vless, vmess, hysteria2, tuic, anytls; aliases ss, hy2)
+	Limit     int      // Maximum number of nodes after filtering (0 = unlimited)
+	Rename    []string // Rename rules in "pattern@replacement" form, applied in order
+}
+
+// IsEmpty reports whether the filter leaves the node list untouched.
+func (f *SubscriptionNodeFilter) IsEmpty() bool {
+	return f == nil ||
+		(f.Include == "" && f.Exclude == "" && len(f.Protocols) == 0 && f.Limit <= 0 && len(f.Rename) == 0)
+}
+
+type renameRule struct {
+	pattern     *regexp.Regexp
+	replacement string
+}
+
+// compiledNodeFilter is a validated SubscriptionNodeFilter ready to be applied.
+type compiledNodeFilter struct {
+	include   *regexp.Regexp
+	exclude   *regexp.Regexp
+	protocols []string
+	limit     int
+	rename    []renameRule
+}
+
+// compileNodeFilter validates the filter parameters and compiles their regular expressions.
+// Returns a validation error for malformed patterns, unknown protocols or a negative limit.
+func compileNodeFilter(f *SubscriptionNodeFilter) (*compiledNodeFilter, error) {
+	compiled := &compiledNodeFilter{limit: f.Limit}
+
+	if f.Limit < 0 {
+		return nil, errors.NewValidationError("limit must not be negative")
+	}
+
+	var err error
+	if compiled.include, err = compileFilterPattern("include", f.Include); err != nil {
+		return nil, err
+	}
+	if compiled.exclude, err = compileFilterPattern("exclude", f.Exclude); err != nil {
+		return nil, err
+	}
+
+	for _, p := range f.Protocols {
+		protocol := strings.ToLower(strings.TrimSpace(p))
+		if protocol == "" {
+			continue
+		}
+		if alias, ok := protocolAliases[protocol]; ok {
+			protocol = alias
+		}
+		if !slices.Contains(subscriptionProtocols, protocol) {
+			return nil, errors.NewValidationError(fmt.Sprintf("unsupported protocol filter: %s", p))
+		}
+		compiled.protocols = append(compiled.protocols, protocol)
+	}
+
+	if len(f.Rename) > maxRenameRules {
+		return nil, errors.NewValidationError(fmt.Sprintf("too many rename rules (max %d)", maxRenameRules))
+	}
+	for _, raw := range f.Rename {
+		pattern, replacement, ok := strings.Cut(raw, "@")
+		if !ok || pattern == "" {
+			return nil, errors.NewValidationError("rename rule must be in pattern@replacement form", raw)
+		}
+		re, err := compileFilterPattern("rename", pattern)
+		if err != nil {
+			return nil, err
+		}
+		compiled.rename = append(compiled.rename, renameRule{pattern: re, replacement: replacement})
+	}
+
+	return compiled, nil
+}
+
+// compileFilterPattern compiles a user-supplied regular expression. Empty patterns yield nil.
+func compileFilterPattern(param, pattern string) (*regexp.Regexp, error) {
+	if pattern == "" {
+		return nil, nil
+	}
+	if len(pattern) > maxFilterPatternLength {
+		return nil, errors.NewValidationError(fmt.Sprintf("%s pattern is too long (max %d characters)", param, maxFilterPatternLength))
+	}
+	re, err := regexp.Compile(pattern)
+	if err != nil {
+		return nil, errors.NewValidationError(fmt.Sprintf("invalid %s pattern", param), err.Error())
+	}
+	return re, nil
+}
+
+// apply returns the nodes that pass the filter, renamed and truncated to the limit.
+// Matching uses the original node name; renamed nodes are shallow copies so repository
+// results are never mutated. Names that collide after renaming get a numeric suffix.
+func (f *compiledNodeFilter) apply(nodes []*Node) []*Node {
+	result := make([]*Node, 0, len(nodes))
+	usedNames := make(map[string]bool, len(nodes))
+	for _, node := range nodes {
+		if len(f.protocols) > 0 && !slices.Contains(f.protocols, node.Protocol) {
+			continue
+		}
+		if f.include != nil && !f.include.MatchString(node.Name) {
+			continue
+		}
+		if f.exclude != nil && f.exclude.MatchString(node.Name) {
+			continue
+		}
+
+		if len(f.rename) > 0 {
+			renamed := *node
+			for _, rule := range f.rename {
+				renamed.Name = rule.pattern.ReplaceAllString(renamed.Name, rule.replacement)
+			}
+			renamed.Name = uniqueNodeName(renamed.Name, usedNames)
+			node = &renamed
+		}
+
+		result = append(result, node)
+		if f.limit > 0 && len(result) >= f.limit {
+			break
+		}
+	}
+	return result
+}
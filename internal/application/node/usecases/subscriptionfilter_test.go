@@ -0,0 +1,167 @@
+package usecases
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/shared/errors"
+)
+
+func filterTestNodes() []*Node {
+	return []*Node{
+		{ID: 1, Name: "HK-01", Protocol: "shadowsocks"},
+		{ID: 2, Name: "HK-02 IPLC", Protocol: "hysteria2"},
+		{ID: 3, Name: "JP-01", Protocol: "trojan"},
+		{ID: 4, Name: "US-01 IPLC", Protocol: "vless"},
+		{ID: 5, Name: "US-02", Protocol: "wireguard"},
+	}
+}
+
+func nodeNames(nodes []*Node) []string {
+	names := make([]string, 0, len(nodes))
+	for _, n := range nodes {
+		names = append(names, n.Name)
+	}
+	return names
+}
+
+func TestSubscriptionNodeFilter_Apply(t *testing.T) {
+	tests := []struct {
+		name   string
+		filter SubscriptionNodeFilter
+		want   []string
+	}{
+		{
+			name:   "empty filter keeps all nodes",
+			filter: SubscriptionNodeFilter{},
+			want:   []string{"HK-01", "HK-02 IPLC", "JP-01", "US-01 IPLC", "US-02"},
+		},
+		{
+			name:   "include regex",
+			filter: SubscriptionNodeFilter{Include: "^(HK|JP)"},
+			want:   []string{"HK-01", "HK-02 IPLC", "JP-01"},
+		},
+		{
+			name:   "exclude regex",
+			filter: SubscriptionNodeFilter{Exclude: "IPLC"},
+			want:   []string{"HK-01", "JP-01", "US-02"},
+		},
+		{
+			name:   "include and exclude combined",
+			filter: SubscriptionNodeFilter{Include: "HK|US", Exclude: "IPLC"},
+			want:   []string{"HK-01", "US-02"},
+		},
+		{
+			name:   "protocol aliases hy2 and ss",
+			filter: SubscriptionNodeFilter{Protocols: []string{"hy2", "SS"}},
+			want:   []string{"HK-01", "HK-02 IPLC"},
+		},
+		{
+			name:   "protocol alias wg and full name",
+			filter: SubscriptionNodeFilter{Protocols: []string{"wg", "trojan", " "}},
+			want:   []string{"JP-01", "US-02"},
+		},
+		{
+			name:   "limit applied after filtering",
+			filter: SubscriptionNodeFilter{Exclude: "^HK", Limit: 2},
+			want:   []string{"JP-01", "US-01 IPLC"},
+		},
+		{
+			name:   "limit larger than result",
+			filter: SubscriptionNodeFilter{Include: "JP", Limit: 10},
+			want:   []string{"JP-01"},
+		},
+		{
+			name:   "rename rules applied in order",
+			filter: SubscriptionNodeFilter{Rename: []string{"HK@Hong Kong", "Hong Kong-0@HongKong#"}},
+			want:   []string{"HongKong#1", "HongKong#2 IPLC", "JP-01", "US-01 IPLC", "US-02"},
+		},
+		{
+			name:   "include matches original name before rename",
+			filter: SubscriptionNodeFilter{Include: "^US", Rename: []string{"US@United States"}},
+			want:   []string{"United States-01 IPLC", "United States-02"},
+		},
+		{
+			name:   "rename with capture group and empty replacement",
+			filter: SubscriptionNodeFilter{Rename: []string{`^(\w+)-0(\d)@$2-$1`, ` IPLC@`}},
+			want:   []string{"1-HK", "2-HK", "1-JP", "1-US", "2-US"},
+		},
+		{
+			name:   "duplicate names after rename get a numeric suffix",
+			filter: SubscriptionNodeFilter{Rename: []string{`-\d+.*@`}},
+			want:   []string{"HK", "HK 2", "JP", "US", "US 2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			nodes := filterTestNodes()
+			compiled, err := compileNodeFilter(&tt.filter)
+			require.NoError(t, err)
+
+			got := compiled.apply(nodes)
+			assert.Equal(t, tt.want, nodeNames(got))
+			// Renaming must not mutate the input nodes
+			assert.Equal(t, []string{"HK-01", "HK-02 IPLC", "JP-01", "US-01 IPLC", "US-02"}, nodeNames(nodes))
+		})
+	}
+}
+
+func TestCompileNodeFilter_InvalidParams(t *testing.T) {
+	tooManyRules := make([]string, maxRenameRules+1)
+	for i := range tooManyRules {
+		tooManyRules[i] = fmt.Sprintf("a%d@b", i)
+	}
+	longPattern := strings.Repeat("a", maxFilterPatternLength+1)
+
+	tests := []struct {
+		name    string
+		filter  SubscriptionNodeFilter
+		wantMsg string
+	}{
+		{"negative limit", SubscriptionNodeFilter{Limit: -1}, "limit must not be negative"},
+		{"invalid include regex", SubscriptionNodeFilter{Include: "(HK"}, "invalid include pattern"},
+		{"invalid exclude regex", SubscriptionNodeFilter{Exclude: "[a-"}, "invalid exclude pattern"},
+		{"include over length cap", SubscriptionNodeFilter{Include: longPattern}, "include pattern is too long"},
+		{"exclude over length cap", SubscriptionNodeFilter{Exclude: longPattern}, "exclude pattern is too long"},
+		{"unknown protocol", SubscriptionNodeFilter{Protocols: []string{"socks5"}}, "unsupported protocol filter: socks5"},
+		{"too many rename rules", SubscriptionNodeFilter{Rename: tooManyRules}, "too many rename rules"},
+		{"rename without separator", SubscriptionNodeFilter{Rename: []string{"HK"}}, "rename rule must be in pattern@replacement form"},
+		{"rename with empty pattern", SubscriptionNodeFilter{Rename: []string{"@HK"}}, "rename rule must be in pattern@replacement form"},
+		{"rename with invalid regex", SubscriptionNodeFilter{Rename: []string{"(HK@x"}}, "invalid rename pattern"},
+		{"rename pattern over length cap", SubscriptionNodeFilter{Rename: []string{longPattern + "@x"}}, "rename pattern is too long"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			compiled, err := compileNodeFilter(&tt.filter)
+			require.Error(t, err)
+			assert.Nil(t, compiled)
+			assert.True(t, errors.IsValidationError(err))
+			assert.Contains(t, err.Error(), tt.wantMsg)
+		})
+	}
+}
+
+func TestCompileNodeFilter_Limits(t *testing.T) {
+	rules := make([]string, maxRenameRules)
+	for i := range rules {
+		rules[i] = fmt.Sprintf("a%d@b", i)
+	}
+	_, err := compileNodeFilter(&SubscriptionNodeFilter{
+		Include: strings.Repeat("a", maxFilterPatternLength),
+		Rename:  rules,
+	})
+	assert.NoError(t, err, "pattern at the length cap and rules at the count cap are accepted")
+}
+
+func TestSubscriptionNodeFilter_IsEmpty(t *testing.T) {
+	assert.True(t, (*SubscriptionNodeFilter)(nil).IsEmpty())
+	assert.True(t, (&SubscriptionNodeFilter{}).IsEmpty())
+	assert.False(t, (&SubscriptionNodeFilter{Limit: 1}).IsEmpty())
+	assert.False(t, (&SubscriptionNodeFilter{Protocols: []string{"ss"}}).IsEmpty())
+}
@@ -5,18 +5,33 @@ import (
 	"encoding/hex"
 	"encoding/json"
 	"fmt"
+	"net"
 	"net/url"
+	"strconv"
 	"strings"
 
 	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/errors"
 	"gopkg.in/yaml.v3"
 )
 
+// EffectiveServerKey returns the SS2022 server PSK clients must use for this node:
+// the stored key if one has been generated, otherwise the key derived from TokenHash.
+func (n *Node) EffectiveServerKey() string {
+	if !vo.IsSS2022Method(n.EncryptionMethod) {
+		return ""
+	}
+	if n.ServerKey != "" {
+		return n.ServerKey
+	}
+	return vo.GenerateSS2022ServerKey(n.TokenHash, n.EncryptionMethod)
+}
+
 // adjustPasswordForMethod adjusts password format based on encryption method.
 // The input password is expected to be hex-encoded 32-byte HMAC key material.
 // For SS2022 methods, it converts to base64 with proper key length and combines with serverKey.
 // For traditional SS methods, it keeps the hex format.
-func adjustPasswordForMethod(password string, method string, tokenHash string) string {
+func adjustPasswordForMethod(password string, method string, serverKey string) string {
 	if password == "" {
 		return password
 	}
@@ -40,15 +55,8 @@ func adjustPasswordForMethod(password string, method string, tokenHash string) s
 	// Generate user key (base64)
 	userKey := base64.StdEncoding.EncodeToString(keyMaterial[:keySize])
 
-	// Generate server key from tokenHash
-	serverKey := vo.GenerateSS2022ServerKey(tokenHash, method)
-	if serverKey == "" {
-		// Fallback to user key only if no server key available
-		return userKey
-	}
-
-	// SS2022 password format: serverKey:userKey
-	return serverKey + ":" + userKey
+	// SS2022 multi-user password format: serverKey:userKey (user key only if no server key)
+	return vo.SS2022Password(serverKey, userKey)
 }
 
 type Base64Formatter struct{}
@@ -97,9 +105,17 @@ func (f *Base64Formatter) FormatWithPassword(nodes []*Node, password string) (st
 			if node.AnyTLSConfig != nil {
 				link = node.AnyTLSConfig.ToURI(node.ServerAddress, node.SubscriptionPort, node.Name, password)
 			}
+		case vo.ProtocolWireGuard:
+			// WireGuard has no share link format, use clash, singbox or wireguard format instead
+			continue
 		default:
+			// SIP002 links cannot carry ShadowTLS, use clash, surge or singbox format instead
+			if node.ShadowTLSConfig != nil {
+				continue
+			}
+
 			// Shadowsocks: adjust password for SS2022 methods
-			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.TokenHash)
+			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
 
 			// Shadowsocks URI format: ss://base64(method:password)@host:port#remarks
 			auth := fmt.Sprintf("%s:%s", node.EncryptionMethod, nodePassword)
@@ -142,21 +158,22 @@ func NewClashFormatter() *ClashFormatter {
 }
 
 type clashProxy struct {
-	Name           string            `yaml:"name"`
-	Type           string            `yaml:"type"`
-	Server         string            `yaml:"server"`
-	Port           uint16            `yaml:"port"`
-	Cipher         string            `yaml:"cipher,omitempty"`
-	Password       string            `yaml:"password,omitempty"`
-	UDP            bool              `yaml:"udp,omitempty"`
-	Plugin         string            `yaml:"plugin,omitempty"`
-	PluginOpts     map[string]string `yaml:"plugin-opts,omitempty"`
-	SNI            string            `yaml:"sni,omitempty"`
-	SkipCertVerify bool              `yaml:"skip-cert-verify,omitempty"`
-	Network        string            `yaml:"network,omitempty"`
-	WSOpts         *clashWSOpts      `yaml:"ws-opts,omitempty"`
-	GRPCOpts       *clashGRPCOpts    `yaml:"grpc-opts,omitempty"`
-	H2Opts         *clashH2Opts      `yaml:"h2-opts,omitempty"`
+	Name           string          `yaml:"name"`
+	Type           string          `yaml:"type"`
+	Server         string          `yaml:"server"`
+	Port           uint16          `yaml:"port"`
+	Cipher         string          `yaml:"cipher,omitempty"`
+	Password       string          `yaml:"password,omitempty"`
+	UDP            bool            `yaml:"udp,omitempty"`
+	Plugin         string          `yaml:"plugin,omitempty"`
+	PluginOpts     map[string]any  `yaml:"plugin-opts,omitempty"`
+	SNI            string          `yaml:"sni,omitempty"`
+	SkipCertVerify bool            `yaml:"skip-cert-verify,omitempty"`
+	Network        string          `yaml:"network,omitempty"`
+	WSOpts         *clashWSOpts    `yaml:"ws-opts,omitempty"`
+	GRPCOpts       *clashGRPCOpts  `yaml:"grpc-opts,omitempty"`
+	H2Opts         *clashH2Opts    `yaml:"h2-opts,omitempty"`
+	XHTTPOpts      *clashXHTTPOpts `yaml:"xhttp-opts,omitempty"`
 	// VLESS/VMess specific fields
 	UUID        string            `yaml:"uuid,omitempty"`
 	Flow        string            `yaml:"flow,omitempty"`
@@ -178,11 +195,28 @@ type clashProxy struct {
 	IdleSessionCheckInterval string `yaml:"idle-session-check-interval,omitempty"`
 	IdleSessionTimeout       string `yaml:"idle-session-timeout,omitempty"`
 	MinIdleSession           int    `yaml:"min-idle-session,omitempty"`
+	// WireGuard specific fields
+	IP                  string   `yaml:"ip,omitempty"`
+	PrivateKey          string   `yaml:"private-key,omitempty"`
+	PublicKey           string   `yaml:"public-key,omitempty"`
+	MTU                 int      `yaml:"mtu,omitempty"`
+	DNS                 []string `yaml:"dns,omitempty"`
+	PersistentKeepalive int      `yaml:"persistent-keepalive,omitempty"`
 }
 
 type clashWSOpts struct {
-	Path    string            `yaml:"path,omitempty"`
-	Headers map[string]string `yaml:"headers,omitempty"`
+	Path             string            `yaml:"path,omitempty"`
+	Headers          map[string]string `yaml:"headers,omitempty"`
+	V2rayHTTPUpgrade bool              `yaml:"v2ray-http-upgrade,omitempty"`
+}
+
+type clashXHTTPOpts struct {
+	Host          string            `yaml:"host,omitempty"`
+	Path          string            `yaml:"path,omitempty"`
+	Mode          string            `yaml:"mode,omitempty"`
+	Headers       map[string]string `yaml:"headers,omitempty"`
+	NoGRPCHeader  bool              `yaml:"no-grpc-header,omitempty"`
+	XPaddingBytes string            `yaml:"x-padding-bytes,omitempty"`
 }
 
 type clashH2Opts struct {
@@ -200,7 +234,36 @@ type clashRealityOpts struct {
 }
 
 type clashConfig struct {
-	Proxies []clashProxy `yaml:"proxies"`
+	Proxies     []clashProxy      `yaml:"proxies"`
+	ProxyGroups []clashProxyGroup `yaml:"proxy-groups,omitempty"`
+}
+
+type clashProxyGroup struct {
+	Name     string   `yaml:"name"`
+	Type     string   `yaml:"type"`
+	Proxies  []string `yaml:"proxies"`
+	URL      string   `yaml:"url,omitempty"`
+	Interval int      `yaml:"interval,omitempty"`
+}
+
+// clashRegionGroups converts region groups into Clash proxy groups, one per region and group kind.
+func clashRegionGroups(groups []regionGroup) []clashProxyGroup {
+	result := make([]clashProxyGroup, 0, len(groups)*len(regionGroupKinds))
+	for _, g := range groups {
+		for _, kind := range regionGroupKinds {
+			group := clashProxyGroup{
+				Name:    g.groupName(kind),
+				Type:    kind.Type,
+				Proxies: g.Proxies,
+			}
+			if kind.HealthCheck {
+				group.URL = regionGroupTestURL
+				group.Interval = regionGroupInterval
+			}
+			result = append(result, group)
+		}
+	}
+	return result
 }
 
 func (f *ClashFormatter) Format(nodes []*Node) (string, error) {
@@ -211,6 +274,7 @@ func (f *ClashFormatter) FormatWithPassword(nodes []*Node, password string) (str
 	config := clashConfig{
 		Proxies: make([]clashProxy, 0, len(nodes)),
 	}
+	emitted := make([]*Node, 0, len(nodes))
 
 	for _, node := range nodes {
 		var proxy clashProxy
@@ -253,7 +317,8 @@ func (f *ClashFormatter) FormatWithPassword(nodes []*Node, password string) (str
 			}
 
 		case vo.ProtocolVMess:
-			if node.VMessConfig != nil {
+			// Clash Meta only supports XHTTP on VLESS, skip VMess XHTTP nodes
+			if node.VMessConfig != nil && node.VMessConfig.TransportType() != vo.VMessTransportXHTTP {
 				proxy = f.buildVMessProxy(node, password)
 			}
 
@@ -272,9 +337,14 @@ func (f *ClashFormatter) FormatWithPassword(nodes []*Node, password string) (str
 				proxy = f.buildAnyTLSProxy(node, password)
 			}
 
+		case vo.ProtocolWireGuard:
+			if node.WireGuardConfig != nil && node.WireGuardPeerPrivateKey != "" {
+				proxy = f.buildWireGuardProxy(node)
+			}
+
 		default:
 			// Shadowsocks: adjust password for SS2022 methods
-			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.TokenHash)
+			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
 
 			proxy = clashProxy{
 				Name:     node.Name,
@@ -286,18 +356,33 @@ func (f *ClashFormatter) FormatWithPassword(nodes []*Node, password string) (str
 				UDP:      true,
 			}
 
-			if node.Plugin != "" {
+			if st := node.ShadowTLSConfig; st != nil {
+				proxy.Plugin = "shadow-tls"
+				proxy.Fingerprint = "chrome"
+				proxy.PluginOpts = map[string]any{
+					"host":     st.HandshakeServer(),
+					"password": st.Password(),
+					"version":  st.Version(),
+				}
+			} else if node.Plugin != "" {
 				proxy.Plugin = node.Plugin
-				proxy.PluginOpts = node.PluginOpts
+				proxy.PluginOpts = make(map[string]any, len(node.PluginOpts))
+				for k, v := range node.PluginOpts {
+					proxy.PluginOpts[k] = v
+				}
 			}
 		}
 
 		// Only append non-empty proxy
 		if proxy.Type != "" {
 			config.Proxies = append(config.Proxies, proxy)
+			emitted = append(emitted, node)
 		}
 	}
 
+	// Group proxies by node country into per-region url-test, fallback and select groups
+	config.ProxyGroups = clashRegionGroups(buildRegionGroups(emitted))
+
 	yamlBytes, err := yaml.Marshal(config)
 	if err != nil {
 		return "", fmt.Errorf("failed to marshal clash config: %w", err)
@@ -371,11 +456,72 @@ func (f *ClashFormatter) buildVLESSProxy(node *Node, uuid string) clashProxy {
 		if cfg.Host() != "" {
 			proxy.H2Opts.Host = []string{cfg.Host()}
 		}
+	case vo.VLESSTransportHTTPUpgrade:
+		proxy.Network = "ws"
+		proxy.WSOpts = newClashHTTPUpgradeOpts(cfg.Path(), cfg.Host())
+	case vo.VLESSTransportXHTTP:
+		proxy.XHTTPOpts = &clashXHTTPOpts{
+			Host: cfg.Host(),
+			Path: cfg.Path(),
+			Mode: cfg.XHTTPMode(),
+		}
+		applyClashXHTTPExtra(proxy.XHTTPOpts, cfg.XHTTPExtra())
 	}
 
 	return proxy
 }
 
+// xhttpExtraOptions are the XHTTP extra options (Xray format) that Clash Meta supports
+type xhttpExtraOptions struct {
+	Headers       map[string]string `json:"headers"`
+	NoGRPCHeader  bool              `json:"noGRPCHeader"`
+	XPaddingBytes json.RawMessage   `json:"xPaddingBytes"`
+}
+
+// applyClashXHTTPExtra maps the XHTTP extra JSON to Clash Meta xhttp-opts.
+// Only headers, noGRPCHeader and xPaddingBytes have a Clash Meta equivalent; other options
+// (e.g. xmux, downloadSettings, scMaxEachPostBytes) differ in shape or are unsupported and are
+// left out, so clients fall back to their defaults for them.
+func applyClashXHTTPExtra(opts *clashXHTTPOpts, extra string) {
+	if extra == "" {
+		return
+	}
+
+	var options xhttpExtraOptions
+	if err := json.Unmarshal([]byte(extra), &options); err != nil {
+		return
+	}
+
+	opts.Headers = options.Headers
+	opts.NoGRPCHeader = options.NoGRPCHeader
+	opts.XPaddingBytes = xhttpRangeString(options.XPaddingBytes)
+}
+
+// xhttpRangeString converts an Xray range ("100-1000", 100 or {"from":100,"to":1000})
+// to the "from-to" string form used by Clash Meta. Returns "" for unsupported values.
+func xhttpRangeString(raw json.RawMessage) string {
+	if len(raw) == 0 {
+		return ""
+	}
+
+	var s string
+	if err := json.Unmarshal(raw, &s); err == nil {
+		return s
+	}
+	var n int64
+	if err := json.Unmarshal(raw, &n); err == nil {
+		return strconv.FormatInt(n, 10)
+	}
+	var r struct {
+		From int64 `json:"from"`
+		To   int64 `json:"to"`
+	}
+	if err := json.Unmarshal(raw, &r); err == nil && r.To > 0 {
+		return fmt.Sprintf("%d-%d", r.From, r.To)
+	}
+	return ""
+}
+
 // buildVMessProxy builds a Clash Meta VMess proxy configuration
 func (f *ClashFormatter) buildVMessProxy(node *Node, uuid string) clashProxy {
 	cfg := node.VMessConfig
@@ -419,11 +565,27 @@ func (f *ClashFormatter) buildVMessProxy(node *Node, uuid string) clashProxy {
 		proxy.GRPCOpts = &clashGRPCOpts{
 			GRPCServiceName: cfg.ServiceName(),
 		}
+	case vo.VMessTransportHTTPUpgrade:
+		proxy.Network = "ws"
+		proxy.WSOpts = newClashHTTPUpgradeOpts(cfg.Path(), cfg.Host())
 	}
 
 	return proxy
 }
 
+// newClashHTTPUpgradeOpts builds ws-opts for HTTPUpgrade, which Clash Meta
+// expresses as a ws network with v2ray-http-upgrade enabled
+func newClashHTTPUpgradeOpts(path, host string) *clashWSOpts {
+	opts := &clashWSOpts{
+		Path:             path,
+		V2rayHTTPUpgrade: true,
+	}
+	if host != "" {
+		opts.Headers = map[string]string{"Host": host}
+	}
+	return opts
+}
+
 // buildHysteria2Proxy builds a Clash Meta Hysteria2 proxy configuration
 // password is the subscription-derived credential
 func (f *ClashFormatter) buildHysteria2Proxy(node *Node, password string) clashProxy {
@@ -542,6 +704,25 @@ func (f *ClashFormatter) buildAnyTLSProxy(node *Node, password string) clashProx
 	return proxy
 }
 
+// buildWireGuardProxy builds a Clash Meta WireGuard proxy configuration
+// using the peer allocated to the subscription on the node
+func (f *ClashFormatter) buildWireGuardProxy(node *Node) clashProxy {
+	cfg := node.WireGuardConfig
+	return clashProxy{
+		Name:                node.Name,
+		Type:                "wireguard",
+		Server:              node.ServerAddress,
+		Port:                node.SubscriptionPort,
+		IP:                  node.WireGuardPeerAddress,
+		PrivateKey:          node.WireGuardPeerPrivateKey,
+		PublicKey:           cfg.PublicKey(),
+		UDP:                 true,
+		MTU:                 cfg.MTU(),
+		DNS:                 cfg.DNSServers(),
+		PersistentKeepalive: cfg.PersistentKeepalive(),
+	}
+}
+
 func (f *ClashFormatter) ContentType() string {
 	return "text/yaml; charset=utf-8"
 }
@@ -571,14 +752,14 @@ func (f *V2RayFormatter) FormatWithPassword(nodes []*Node, password string) (str
 	skippedCount := 0
 
 	for _, node := range nodes {
-		// V2Ray format only supports Shadowsocks, skip other protocol nodes
-		if vo.Protocol(node.Protocol) != vo.ProtocolShadowsocks {
+		// V2Ray format only supports plain Shadowsocks, skip other protocol and ShadowTLS nodes
+		if vo.Protocol(node.Protocol) != vo.ProtocolShadowsocks || node.ShadowTLSConfig != nil {
 			skippedCount++
 			continue
 		}
 
 		// Adjust password for SS2022 methods
-		nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.TokenHash)
+		nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
 
 		v2rayNode := v2rayNode{
 			Remarks:    node.Name,
@@ -647,14 +828,14 @@ func (f *SIP008Formatter) FormatWithPassword(nodes []*Node, password string) (st
 	skippedCount := 0
 
 	for _, node := range nodes {
-		// SIP008 format only supports Shadowsocks, skip other protocol nodes
-		if vo.Protocol(node.Protocol) != vo.ProtocolShadowsocks {
+		// SIP008 format only supports plain Shadowsocks, skip other protocol and ShadowTLS nodes
+		if vo.Protocol(node.Protocol) != vo.ProtocolShadowsocks || node.ShadowTLSConfig != nil {
 			skippedCount++
 			continue
 		}
 
 		// Adjust password for SS2022 methods
-		nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.TokenHash)
+		nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
 
 		server := sip008Server{
 			ID:         fmt.Sprintf("node_%d", node.ID),
@@ -701,9 +882,22 @@ func (f *SurgeFormatter) Format(nodes []*Node) (string, error) {
 }
 
 func (f *SurgeFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
-	var lines []string
-	lines = append(lines, "[Proxy]")
+	proxyLines, emitted := f.buildLines(nodes, password)
+
+	lines := append([]string{"[Proxy]"}, proxyLines...)
+
+	// Group proxies by node country into per-region url-test, fallback and select groups
+	if groupLines := surgeRegionGroupLines(buildRegionGroups(emitted)); len(groupLines) > 0 {
+		lines = append(lines, "", "[Proxy Group]")
+		lines = append(lines, groupLines...)
+	}
 
+	return strings.Join(lines, "\n"), nil
+}
+
+// buildLines builds Surge proxy lines and returns the nodes that produced a line.
+// Protocols Surge cannot express (VLESS, AnyTLS) are skipped.
+func (f *SurgeFormatter) buildLines(nodes []*Node, password string) (lines []string, emitted []*Node) {
 	for _, node := range nodes {
 		nodeName := node.Name
 		var line string
@@ -755,13 +949,13 @@ func (f *SurgeFormatter) FormatWithPassword(nodes []*Node, password string) (str
 				line = f.buildTUICLine(node, password)
 			}
 
-		case vo.ProtocolAnyTLS:
-			// Surge does not natively support AnyTLS, skip
+		case vo.ProtocolAnyTLS, vo.ProtocolWireGuard:
+			// Surge does not natively support AnyTLS, and WireGuard needs a dedicated section, skip
 			continue
 
 		default:
 			// Shadowsocks: adjust password for SS2022 methods
-			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.TokenHash)
+			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
 
 			// Shadowsocks format
 			line = fmt.Sprintf("%s = ss, %s, %d, encrypt-method=%s, password=%s, udp-relay=true",
@@ -771,7 +965,10 @@ func (f *SurgeFormatter) FormatWithPassword(nodes []*Node, password string) (str
 				node.EncryptionMethod,
 				nodePassword)
 
-			if node.Plugin == "obfs-local" && len(node.PluginOpts) > 0 {
+			if st := node.ShadowTLSConfig; st != nil {
+				line += fmt.Sprintf(", shadow-tls-password=%s, shadow-tls-sni=%s, shadow-tls-version=%d",
+					st.Password(), st.HandshakeServer(), st.Version())
+			} else if node.Plugin == "obfs-local" && len(node.PluginOpts) > 0 {
 				if obfsMode, ok := node.PluginOpts["obfs"]; ok {
 					line += fmt.Sprintf(", obfs=%s", obfsMode)
 					if obfsHost, ok := node.PluginOpts["obfs-host"]; ok {
@@ -783,10 +980,11 @@ func (f *SurgeFormatter) FormatWithPassword(nodes []*Node, password string) (str
 
 		if line != "" {
 			lines = append(lines, line)
+			emitted = append(emitted, node)
 		}
 	}
 
-	return strings.Join(lines, "\n"), nil
+	return lines, emitted
 }
 
 // buildVMessLine builds a Surge 5 VMess proxy line
@@ -822,6 +1020,9 @@ func (f *SurgeFormatter) buildVMessLine(node *Node, uuid string) string {
 		if cfg.Host() != "" {
 			line += fmt.Sprintf(", ws-headers=Host:%s", cfg.Host())
 		}
+	case vo.VMessTransportXHTTP, vo.VMessTransportHTTPUpgrade:
+		// Surge does not support XHTTP or HTTPUpgrade, skip the node
+		return ""
 	}
 
 	return line
@@ -905,92 +1106,1383 @@ func (f *SurgeFormatter) ContentType() string {
 	return "text/plain; charset=utf-8"
 }
 
-func formatPluginOpts(opts map[string]string) string {
-	if len(opts) == 0 {
-		return ""
-	}
+type SingBoxFormatter struct{}
 
-	var parts []string
-	for k, v := range opts {
-		parts = append(parts, fmt.Sprintf("%s=%s", k, v))
-	}
-	return strings.Join(parts, ";")
+func NewSingBoxFormatter() *SingBoxFormatter {
+	return &SingBoxFormatter{}
 }
 
-// TemplateClashFormatter wraps ClashFormatter with template support
-// It will use custom template if available, otherwise fall back to default formatter
-type TemplateClashFormatter struct {
-	renderer         *TemplateRenderer
-	defaultFormatter *ClashFormatter
+const (
+	// singBoxSelectorTag is the tag of the manual selection group
+	singBoxSelectorTag = "proxy"
+	// singBoxURLTestTag is the tag of the automatic latency-based group
+	singBoxURLTestTag = "auto"
+	// singBoxDirectTag is the tag of the direct outbound
+	singBoxDirectTag = "direct"
+	// singBoxURLTestURL is the probe URL used by the urltest group
+	singBoxURLTestURL = "https://www.gstatic.com/generate_204"
+)
+
+type singBoxConfig struct {
+	Log       singBoxLog        `json:"log"`
+	DNS       singBoxDNS        `json:"dns"`
+	Inbounds  []singBoxInbound  `json:"inbounds"`
+	Outbounds []singBoxOutbound `json:"outbounds"`
+	Endpoints []singBoxEndpoint `json:"endpoints,omitempty"`
+	Route     singBoxRoute      `json:"route"`
 }
 
-// NewTemplateClashFormatter creates a new template-aware Clash formatter
-func NewTemplateClashFormatter(renderer *TemplateRenderer) *TemplateClashFormatter {
-	return &TemplateClashFormatter{
-		renderer:         renderer,
-		defaultFormatter: NewClashFormatter(),
-	}
+type singBoxLog struct {
+	Level     string `json:"level"`
+	Timestamp bool   `json:"timestamp"`
 }
 
-func (f *TemplateClashFormatter) Format(nodes []*Node) (string, error) {
+type singBoxDNS struct {
+	Servers []singBoxDNSServer `json:"servers"`
+	Final   string             `json:"final"`
+}
+
+type singBoxDNSServer struct {
+	Tag     string `json:"tag"`
+	Address string `json:"address"`
+	Detour  string `json:"detour,omitempty"`
+}
+
+type singBoxInbound struct {
+	Type        string   `json:"type"`
+	Tag         string   `json:"tag"`
+	Listen      string   `json:"listen,omitempty"`
+	ListenPort  uint16   `json:"listen_port,omitempty"`
+	Address     []string `json:"address,omitempty"`
+	AutoRoute   bool     `json:"auto_route,omitempty"`
+	StrictRoute bool     `json:"strict_route,omitempty"`
+}
+
+type singBoxRoute struct {
+	Rules               []singBoxRouteRule `json:"rules"`
+	Final               string             `json:"final"`
+	AutoDetectInterface bool               `json:"auto_detect_interface"`
+}
+
+type singBoxRouteRule struct {
+	Protocol string `json:"protocol,omitempty"`
+	Action   string `json:"action"`
+}
+
+type singBoxOutbound struct {
+	Type       string `json:"type"`
+	Tag        string `json:"tag"`
+	Server     string `json:"server,omitempty"`
+	ServerPort uint16 `json:"server_port,omitempty"`
+	// Shadowsocks specific fields
+	Method     string `json:"method,omitempty"`
+	Plugin     string `json:"plugin,omitempty"`
+	PluginOpts string `json:"plugin_opts,omitempty"`
+	Detour     string `json:"detour,omitempty"`
+	// ShadowTLS specific fields
+	Version int `json:"version,omitempty"`
+	// Credentials
+	UUID     string `json:"uuid,omitempty"`
+	Password string `json:"password,omitempty"`
+	// VLESS/VMess specific fields
+	Flow           string `json:"flow,omitempty"`
+	Security       string `json:"security,omitempty"`
+	AlterID        int    `json:"alter_id,omitempty"`
+	PacketEncoding string `json:"packet_encoding,omitempty"`
+	// Hysteria2 specific fields
+	UpMbps   int          `json:"up_mbps,omitempty"`
+	DownMbps int          `json:"down_mbps,omitempty"`
+	Obfs     *singBoxObfs `json:"obfs,omitempty"`
+	// TUIC specific fields
+	CongestionControl string `json:"congestion_control,omitempty"`
+	UDPRelayMode      string `json:"udp_relay_mode,omitempty"`
+	// AnyTLS specific fields
+	IdleSessionCheckInterval string `json:"idle_session_check_interval,omitempty"`
+	IdleSessionTimeout       string `json:"idle_session_timeout,omitempty"`
+	MinIdleSession           int    `json:"min_idle_session,omitempty"`
+	// Common TLS and transport settings
+	TLS       *singBoxTLS       `json:"tls,omitempty"`
+	Transport *singBoxTransport `json:"transport,omitempty"`
+	// Selector/URLTest group fields
+	Outbounds []string `json:"outbounds,omitempty"`
+	Default   string   `json:"default,omitempty"`
+	URL       string   `json:"url,omitempty"`
+	Interval  string   `json:"interval,omitempty"`
+	Tolerance int      `json:"tolerance,omitempty"`
+}
+
+// singBoxEndpoint is a sing-box endpoint, used for WireGuard since sing-box 1.11
+type singBoxEndpoint struct {
+	Type       string                 `json:"type"`
+	Tag        string                 `json:"tag"`
+	MTU        int                    `json:"mtu,omitempty"`
+	Address    []string               `json:"address"`
+	PrivateKey string                 `json:"private_key"`
+	Peers      []singBoxWireGuardPeer `json:"peers"`
+}
+
+type singBoxWireGuardPeer struct {
+	Address                     string   `json:"address"`
+	Port                        uint16   `json:"port"`
+	PublicKey                   string   `json:"public_key"`
+	AllowedIPs                  []string `json:"allowed_ips"`
+	PersistentKeepaliveInterval int      `json:"persistent_keepalive_interval,omitempty"`
+}
+
+type singBoxObfs struct {
+	Type     string `json:"type"`
+	Password string `json:"password,omitempty"`
+}
+
+type singBoxTLS struct {
+	Enabled    bool            `json:"enabled"`
+	ServerName string          `json:"server_name,omitempty"`
+	Insecure   bool            `json:"insecure,omitempty"`
+	DisableSNI bool            `json:"disable_sni,omitempty"`
+	ALPN       []string        `json:"alpn,omitempty"`
+	UTLS       *singBoxUTLS    `json:"utls,omitempty"`
+	Reality    *singBoxReality `json:"reality,omitempty"`
+}
+
+type singBoxUTLS struct {
+	Enabled     bool   `json:"enabled"`
+	Fingerprint string `json:"fingerprint,omitempty"`
+}
+
+type singBoxReality struct {
+	Enabled   bool   `json:"enabled"`
+	PublicKey string `json:"public_key"`
+	ShortID   string `json:"short_id,omitempty"`
+}
+
+type singBoxTransport struct {
+	Type        string            `json:"type"`
+	Host        []string          `json:"host,omitempty"`
+	Path        string            `json:"path,omitempty"`
+	Headers     map[string]string `json:"headers,omitempty"`
+	ServiceName string            `json:"service_name,omitempty"`
+}
+
+func (f *SingBoxFormatter) Format(nodes []*Node) (string, error) {
 	return f.FormatWithPassword(nodes, "")
 }
 
-func (f *TemplateClashFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
-	// Try template rendering first
-	if f.renderer.HasTemplate("clash") {
-		content, err := f.renderer.RenderClash(nodes, password)
-		if err != nil {
-			// Log error but fall back to default formatter
-			// (error logging handled by caller)
-			return f.defaultFormatter.FormatWithPassword(nodes, password)
+func (f *SingBoxFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	proxies, endpoints := f.buildOutbounds(nodes, password)
+
+	tags := make([]string, 0, len(proxies)+len(endpoints))
+	for _, proxy := range proxies {
+		// ShadowTLS outbounds are detours of their Shadowsocks outbound, not selectable proxies
+		if proxy.Type == "shadowtls" {
+			continue
 		}
-		return content, nil
+		tags = append(tags, proxy.Tag)
+	}
+	for _, endpoint := range endpoints {
+		tags = append(tags, endpoint.Tag)
 	}
 
-	// Fall back to default formatter
-	return f.defaultFormatter.FormatWithPassword(nodes, password)
-}
+	outbounds := make([]singBoxOutbound, 0, len(proxies)+3)
+	if len(tags) > 0 {
+		outbounds = append(outbounds,
+			singBoxOutbound{
+				Type:      "selector",
+				Tag:       singBoxSelectorTag,
+				Outbounds: append([]string{singBoxURLTestTag}, tags...),
+				Default:   singBoxURLTestTag,
+			},
+			singBoxOutbound{
+				Type:      "urltest",
+				Tag:       singBoxURLTestTag,
+				Outbounds: tags,
+				URL:       singBoxURLTestURL,
+				Interval:  "3m",
+				Tolerance: 50,
+			},
+		)
+	} else {
+		// Selector groups must not be empty, fall back to direct when no nodes are available
+		outbounds = append(outbounds, singBoxOutbound{
+			Type:      "selector",
+			Tag:       singBoxSelectorTag,
+			Outbounds: []string{singBoxDirectTag},
+		})
+	}
+	outbounds = append(outbounds, proxies...)
+	outbounds = append(outbounds, singBoxOutbound{Type: "direct", Tag: singBoxDirectTag})
+
+	config := singBoxConfig{
+		Log: singBoxLog{Level: "info", Timestamp: true},
+		DNS: singBoxDNS{
+			Servers: []singBoxDNSServer{
+				{Tag: "remote", Address: "https://1.1.1.1/dns-query", Detour: singBoxSelectorTag},
+				{Tag: "local", Address: "local"},
+			},
+			Final: "remote",
+		},
+		Inbounds: []singBoxInbound{
+			{
+				Type:        "tun",
+				Tag:         "tun-in",
+				Address:     []string{"172.19.0.1/30", "fdfe:dcba:9876::1/126"},
+				AutoRoute:   true,
+				StrictRoute: true,
+			},
+			{
+				Type:       "mixed",
+				Tag:        "mixed-in",
+				Listen:     "127.0.0.1",
+				ListenPort: 2080,
+			},
+		},
+		Outbounds: outbounds,
+		Endpoints: endpoints,
+		Route: singBoxRoute{
+			Rules: []singBoxRouteRule{
+				{Action: "sniff"},
+				{Protocol: "dns", Action: "hijack-dns"},
+			},
+			Final:               singBoxSelectorTag,
+			AutoDetectInterface: true,
+		},
+	}
 
-func (f *TemplateClashFormatter) ContentType() string {
-	return f.defaultFormatter.ContentType()
+	jsonBytes, err := json.MarshalIndent(config, "", "  ")
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal sing-box config: %w", err)
+	}
+
+	return string(jsonBytes), nil
 }
 
-// TemplateSurgeFormatter wraps SurgeFormatter with template support
-// It will use custom template if available, otherwise fall back to default formatter
-type TemplateSurgeFormatter struct {
-	renderer         *TemplateRenderer
-	defaultFormatter *SurgeFormatter
+// buildOutbounds converts nodes to sing-box proxy outbounds with unique tags.
+// WireGuard nodes are returned as endpoints, which share the tag namespace with outbounds.
+func (f *SingBoxFormatter) buildOutbounds(nodes []*Node, password string) ([]singBoxOutbound, []singBoxEndpoint) {
+	outbounds := make([]singBoxOutbound, 0, len(nodes))
+	var endpoints []singBoxEndpoint
+	usedTags := map[string]bool{
+		singBoxSelectorTag: true,
+		singBoxURLTestTag:  true,
+		singBoxDirectTag:   true,
+	}
+
+	for _, node := range nodes {
+		var outbound singBoxOutbound
+
+		switch vo.Protocol(node.Protocol) {
+		case vo.ProtocolTrojan:
+			outbound = singBoxOutbound{
+				Type:       "trojan",
+				Server:     node.ServerAddress,
+				ServerPort: node.SubscriptionPort,
+				Password:   password,
+				TLS: &singBoxTLS{
+					Enabled:    true,
+					ServerName: node.SNI,
+					Insecure:   node.AllowInsecure,
+				},
+			}
+
+			switch node.TransportProtocol {
+			case vo.TransportWS:
+				outbound.Transport = &singBoxTransport{Type: "ws", Path: node.Path}
+				if node.Host != "" {
+					outbound.Transport.Headers = map[string]string{"Host": node.Host}
+				}
+			case vo.TransportGRPC:
+				outbound.Transport = &singBoxTransport{Type: "grpc", ServiceName: node.Host}
+			}
+
+		case vo.ProtocolVLESS:
+			// sing-box has no XHTTP transport, skip such nodes
+			if node.VLESSConfig != nil && node.VLESSConfig.TransportType() != vo.VLESSTransportXHTTP {
+				outbound = f.buildVLESSOutbound(node, password)
+			}
+
+		case vo.ProtocolVMess:
+			if node.VMessConfig != nil && node.VMessConfig.TransportType() != vo.VMessTransportXHTTP {
+				outbound = f.buildVMessOutbound(node, password)
+			}
+
+		case vo.ProtocolHysteria2:
+			if node.Hysteria2Config != nil {
+				outbound = f.buildHysteria2Outbound(node, password)
+			}
+
+		case vo.ProtocolTUIC:
+			if node.TUICConfig != nil {
+				outbound = f.buildTUICOutbound(node, password)
+			}
+
+		case vo.ProtocolAnyTLS:
+			if node.AnyTLSConfig != nil {
+				outbound = f.buildAnyTLSOutbound(node, password)
+			}
+
+		case vo.ProtocolWireGuard:
+			if node.WireGuardConfig != nil && node.WireGuardPeerPrivateKey != "" {
+				endpoint := f.buildWireGuardEndpoint(node)
+				endpoint.Tag = uniqueNodeName(node.Name, usedTags)
+				endpoints = append(endpoints, endpoint)
+			}
+			continue
+
+		default:
+			// Shadowsocks: adjust password for SS2022 methods
+			nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
+
+			outbound = singBoxOutbound{
+				Type:       "shadowsocks",
+				Server:     node.ServerAddress,
+				ServerPort: node.SubscriptionPort,
+				Method:     node.EncryptionMethod,
+				Password:   nodePassword,
+			}
+
+			if node.ShadowTLSConfig != nil {
+				// The Shadowsocks outbound dials through a dedicated shadowtls outbound
+				outbound.Tag = uniqueNodeName(node.Name, usedTags)
+				shadowTLS := f.buildShadowTLSOutbound(node)
+				shadowTLS.Tag = uniqueNodeName(node.Name+" shadowtls", usedTags)
+				outbound.Server = ""
+				outbound.ServerPort = 0
+				outbound.Detour = shadowTLS.Tag
+				outbounds = append(outbounds, outbound, shadowTLS)
+				continue
+			}
+
+			if node.Plugin != "" {
+				outbound.Plugin = node.Plugin
+				outbound.PluginOpts = formatPluginOpts(node.PluginOpts)
+			}
+		}
+
+		// Only append non-empty outbound
+		if outbound.Type == "" {
+			continue
+		}
+
+		outbound.Tag = uniqueNodeName(node.Name, usedTags)
+		outbounds = append(outbounds, outbound)
+	}
+
+	return outbounds, endpoints
 }
 
-// NewTemplateSurgeFormatter creates a new template-aware Surge formatter
-func NewTemplateSurgeFormatter(renderer *TemplateRenderer) *TemplateSurgeFormatter {
-	return &TemplateSurgeFormatter{
-		renderer:         renderer,
-		defaultFormatter: NewSurgeFormatter(),
+// uniqueNodeName returns a name derived from name that is not yet in used, e.g. "HK 2".
+// sing-box rejects configs with duplicate outbound tags, and clients key proxies by name.
+func uniqueNodeName(name string, used map[string]bool) string {
+	unique := name
+	for i := 2; used[unique]; i++ {
+		unique = fmt.Sprintf("%s %d", name, i)
 	}
+	used[unique] = true
+	return unique
 }
 
-func (f *TemplateSurgeFormatter) Format(nodes []*Node) (string, error) {
-	return f.FormatWithPassword(nodes, "")
+// buildVLESSOutbound builds a sing-box VLESS outbound
+func (f *SingBoxFormatter) buildVLESSOutbound(node *Node, uuid string) singBoxOutbound {
+	cfg := node.VLESSConfig
+	outbound := singBoxOutbound{
+		Type:           "vless",
+		Server:         node.ServerAddress,
+		ServerPort:     node.SubscriptionPort,
+		UUID:           uuid,
+		Flow:           cfg.Flow(),
+		PacketEncoding: "xudp",
+	}
+
+	switch cfg.Security() {
+	case vo.VLESSSecurityTLS:
+		outbound.TLS = &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			Insecure:   cfg.AllowInsecure(),
+			UTLS:       singBoxUTLSFor(cfg.Fingerprint()),
+		}
+	case vo.VLESSSecurityReality:
+		// Reality requires uTLS, default to chrome fingerprint when not configured
+		fingerprint := cfg.Fingerprint()
+		if fingerprint == "" {
+			fingerprint = "chrome"
+		}
+		outbound.TLS = &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			UTLS:       singBoxUTLSFor(fingerprint),
+			Reality: &singBoxReality{
+				Enabled:   true,
+				PublicKey: cfg.PublicKey(),
+				ShortID:   cfg.ShortID(),
+			},
+		}
+	}
+
+	switch cfg.TransportType() {
+	case vo.VLESSTransportWS:
+		outbound.Transport = &singBoxTransport{Type: "ws", Path: cfg.Path()}
+		if cfg.Host() != "" {
+			outbound.Transport.Headers = map[string]string{"Host": cfg.Host()}
+		}
+	case vo.VLESSTransportGRPC:
+		outbound.Transport = &singBoxTransport{Type: "grpc", ServiceName: cfg.ServiceName()}
+	case vo.VLESSTransportH2:
+		outbound.Transport = &singBoxTransport{Type: "http", Path: cfg.Path()}
+		if cfg.Host() != "" {
+			outbound.Transport.Host = []string{cfg.Host()}
+		}
+	case vo.VLESSTransportHTTPUpgrade:
+		outbound.Transport = newSingBoxHTTPUpgradeTransport(cfg.Path(), cfg.Host())
+	}
+
+	return outbound
 }
 
-func (f *TemplateSurgeFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
-	// Try template rendering first
-	if f.renderer.HasTemplate("surge") {
-		content, err := f.renderer.RenderSurge(nodes, password)
-		if err != nil {
-			// Log error but fall back to default formatter
-			// (error logging handled by caller)
-			return f.defaultFormatter.FormatWithPassword(nodes, password)
+// buildVMessOutbound builds a sing-box VMess outbound
+func (f *SingBoxFormatter) buildVMessOutbound(node *Node, uuid string) singBoxOutbound {
+	cfg := node.VMessConfig
+	outbound := singBoxOutbound{
+		Type:           "vmess",
+		Server:         node.ServerAddress,
+		ServerPort:     node.SubscriptionPort,
+		UUID:           uuid,
+		Security:       cfg.Security(),
+		AlterID:        cfg.AlterID(),
+		PacketEncoding: "xudp",
+	}
+
+	if cfg.TLS() {
+		outbound.TLS = &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			Insecure:   cfg.AllowInsecure(),
 		}
-		return content, nil
 	}
 
-	// Fall back to default formatter
-	return f.defaultFormatter.FormatWithPassword(nodes, password)
+	switch cfg.TransportType() {
+	case vo.VMessTransportWS:
+		outbound.Transport = &singBoxTransport{Type: "ws", Path: cfg.Path()}
+		if cfg.Host() != "" {
+			outbound.Transport.Headers = map[string]string{"Host": cfg.Host()}
+		}
+	case vo.VMessTransportHTTP:
+		outbound.Transport = &singBoxTransport{Type: "http", Path: cfg.Path()}
+		if cfg.Host() != "" {
+			outbound.Transport.Host = []string{cfg.Host()}
+		}
+	case vo.VMessTransportGRPC:
+		outbound.Transport = &singBoxTransport{Type: "grpc", ServiceName: cfg.ServiceName()}
+	case vo.VMessTransportQUIC:
+		outbound.Transport = &singBoxTransport{Type: "quic"}
+	case vo.VMessTransportHTTPUpgrade:
+		outbound.Transport = newSingBoxHTTPUpgradeTransport(cfg.Path(), cfg.Host())
+	}
+
+	return outbound
 }
 
-func (f *TemplateSurgeFormatter) ContentType() string {
+// newSingBoxHTTPUpgradeTransport builds a sing-box httpupgrade transport
+func newSingBoxHTTPUpgradeTransport(path, host string) *singBoxTransport {
+	transport := &singBoxTransport{Type: "httpupgrade", Path: path}
+	if host != "" {
+		transport.Headers = map[string]string{"Host": host}
+	}
+	return transport
+}
+
+// buildHysteria2Outbound builds a sing-box Hysteria2 outbound
+// password is the subscription-derived credential
+func (f *SingBoxFormatter) buildHysteria2Outbound(node *Node, password string) singBoxOutbound {
+	cfg := node.Hysteria2Config
+	// Use subscription-derived password, fallback to config password if empty
+	pwd := password
+	if pwd == "" {
+		pwd = cfg.Password()
+	}
+	outbound := singBoxOutbound{
+		Type:       "hysteria2",
+		Server:     node.ServerAddress,
+		ServerPort: node.SubscriptionPort,
+		Password:   pwd,
+		TLS: &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			Insecure:   cfg.AllowInsecure(),
+			ALPN:       []string{"h3"},
+		},
+	}
+
+	if cfg.Obfs() != "" {
+		outbound.Obfs = &singBoxObfs{
+			Type:     cfg.Obfs(),
+			Password: cfg.ObfsPassword(),
+		}
+	}
+
+	if cfg.UpMbps() != nil {
+		outbound.UpMbps = *cfg.UpMbps()
+	}
+	if cfg.DownMbps() != nil {
+		outbound.DownMbps = *cfg.DownMbps()
+	}
+
+	return outbound
+}
+
+// buildTUICOutbound builds a sing-box TUIC outbound
+// password is the subscription-derived credential (used as both uuid and password)
+func (f *SingBoxFormatter) buildTUICOutbound(node *Node, password string) singBoxOutbound {
+	cfg := node.TUICConfig
+	// Use subscription-derived password as both uuid and password,
+	// fallback to config values if empty
+	uuid := password
+	if uuid == "" {
+		uuid = cfg.UUID()
+	}
+	pwd := password
+	if pwd == "" {
+		pwd = cfg.Password()
+	}
+	outbound := singBoxOutbound{
+		Type:              "tuic",
+		Server:            node.ServerAddress,
+		ServerPort:        node.SubscriptionPort,
+		UUID:              uuid,
+		Password:          pwd,
+		CongestionControl: cfg.CongestionControl(),
+		UDPRelayMode:      cfg.UDPRelayMode(),
+		TLS: &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			Insecure:   cfg.AllowInsecure(),
+			DisableSNI: cfg.DisableSNI(),
+		},
+	}
+
+	if cfg.ALPN() != "" {
+		outbound.TLS.ALPN = strings.Split(cfg.ALPN(), ",")
+	}
+
+	return outbound
+}
+
+// buildAnyTLSOutbound builds a sing-box AnyTLS outbound
+// password is the subscription-derived credential
+func (f *SingBoxFormatter) buildAnyTLSOutbound(node *Node, password string) singBoxOutbound {
+	cfg := node.AnyTLSConfig
+	return singBoxOutbound{
+		Type:                     "anytls",
+		Server:                   node.ServerAddress,
+		ServerPort:               node.SubscriptionPort,
+		Password:                 password,
+		IdleSessionCheckInterval: cfg.IdleSessionCheckInterval(),
+		IdleSessionTimeout:       cfg.IdleSessionTimeout(),
+		MinIdleSession:           cfg.MinIdleSession(),
+		TLS: &singBoxTLS{
+			Enabled:    true,
+			ServerName: cfg.SNI(),
+			Insecure:   cfg.AllowInsecure(),
+			UTLS:       singBoxUTLSFor(cfg.Fingerprint()),
+		},
+	}
+}
+
+// buildWireGuardEndpoint builds a sing-box WireGuard endpoint
+// using the peer allocated to the subscription on the node
+func (f *SingBoxFormatter) buildWireGuardEndpoint(node *Node) singBoxEndpoint {
+	cfg := node.WireGuardConfig
+	return singBoxEndpoint{
+		Type:       "wireguard",
+		MTU:        cfg.MTU(),
+		Address:    []string{node.WireGuardPeerAddress + "/32"},
+		PrivateKey: node.WireGuardPeerPrivateKey,
+		Peers: []singBoxWireGuardPeer{
+			{
+				Address:                     node.ServerAddress,
+				Port:                        node.SubscriptionPort,
+				PublicKey:                   cfg.PublicKey(),
+				AllowedIPs:                  []string{"0.0.0.0/0", "::/0"},
+				PersistentKeepaliveInterval: cfg.PersistentKeepalive(),
+			},
+		},
+	}
+}
+
+// buildShadowTLSOutbound builds the sing-box shadowtls outbound a Shadowsocks node dials through
+func (f *SingBoxFormatter) buildShadowTLSOutbound(node *Node) singBoxOutbound {
+	st := node.ShadowTLSConfig
+	return singBoxOutbound{
+		Type:       "shadowtls",
+		Server:     node.ServerAddress,
+		ServerPort: node.SubscriptionPort,
+		Version:    st.Version(),
+		Password:   st.Password(),
+		TLS: &singBoxTLS{
+			Enabled:    true,
+			ServerName: st.HandshakeServer(),
+			UTLS:       singBoxUTLSFor("chrome"),
+		},
+	}
+}
+
+// singBoxUTLSFor returns uTLS settings for the given fingerprint, or nil if none is configured
+func singBoxUTLSFor(fingerprint string) *singBoxUTLS {
+	if fingerprint == "" {
+		return nil
+	}
+	return &singBoxUTLS{Enabled: true, Fingerprint: fingerprint}
+}
+
+func (f *SingBoxFormatter) ContentType() string {
+	return "application/json; charset=utf-8"
+}
+
+type QuantumultXFormatter struct{}
+
+func NewQuantumultXFormatter() *QuantumultXFormatter {
+	return &QuantumultXFormatter{}
+}
+
+func (f *QuantumultXFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+// FormatWithPassword outputs server_local lines, which Quantumult X accepts
+// directly as a server_remote resource
+func (f *QuantumultXFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	lines, _ := f.buildLines(nodes, password)
+	return strings.Join(lines, "\n"), nil
+}
+
+// buildLines builds server_local lines and the tags of the emitted proxies.
+// Nodes using protocols or transports Quantumult X does not support are skipped.
+func (f *QuantumultXFormatter) buildLines(nodes []*Node, password string) (lines []string, names []string) {
+	for _, node := range nodes {
+		tag := sanitizeProxyLineName(node.Name)
+		var params []string
+
+		switch vo.Protocol(node.Protocol) {
+		case vo.ProtocolTrojan:
+			params = f.buildTrojanParams(node, password)
+
+		case vo.ProtocolVLESS:
+			if node.VLESSConfig != nil {
+				params = f.buildVLESSParams(node, password)
+			}
+
+		case vo.ProtocolVMess:
+			if node.VMessConfig != nil {
+				params = f.buildVMessParams(node, password)
+			}
+
+		case vo.ProtocolHysteria2, vo.ProtocolTUIC, vo.ProtocolAnyTLS, vo.ProtocolWireGuard:
+			// Quantumult X does not support these protocols, skip
+			continue
+
+		default:
+			// ShadowTLS is not supported by this client, skip such Shadowsocks nodes
+			if node.ShadowTLSConfig != nil {
+				continue
+			}
+			params = f.buildShadowsocksParams(node, password)
+		}
+
+		if len(params) == 0 {
+			continue
+		}
+
+		params = append(params, "fast-open=false", "tag="+tag)
+		lines = append(lines, strings.Join(params, ", "))
+		names = append(names, tag)
+	}
+
+	return lines, names
+}
+
+// buildShadowsocksParams builds a Quantumult X shadowsocks line
+func (f *QuantumultXFormatter) buildShadowsocksParams(node *Node, password string) []string {
+	// Shadowsocks: adjust password for SS2022 methods
+	nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
+
+	params := []string{
+		fmt.Sprintf("shadowsocks=%s:%d", node.ServerAddress, node.SubscriptionPort),
+		"method=" + node.EncryptionMethod,
+		"password=" + nodePassword,
+	}
+
+	switch node.Plugin {
+	case "":
+	case "obfs-local", "obfs", "simple-obfs":
+		obfsMode := node.PluginOpts["obfs"]
+		if obfsMode != "http" && obfsMode != "tls" {
+			return nil
+		}
+		params = append(params, "obfs="+obfsMode)
+		if host := node.PluginOpts["obfs-host"]; host != "" {
+			params = append(params, "obfs-host="+host)
+		}
+	case "v2ray-plugin":
+		if node.PluginOpts["mode"] != "websocket" {
+			return nil
+		}
+		obfs := "ws"
+		if _, ok := node.PluginOpts["tls"]; ok {
+			obfs = "wss"
+		}
+		params = append(params, "obfs="+obfs)
+		if host := node.PluginOpts["host"]; host != "" {
+			params = append(params, "obfs-host="+host)
+		}
+		if path := node.PluginOpts["path"]; path != "" {
+			params = append(params, "obfs-uri="+path)
+		}
+	default:
+		// Unsupported plugin, the node would not be usable without it
+		return nil
+	}
+
+	return append(params, "udp-relay=true")
+}
+
+// buildTrojanParams builds a Quantumult X trojan line
+func (f *QuantumultXFormatter) buildTrojanParams(node *Node, password string) []string {
+	params := []string{
+		fmt.Sprintf("trojan=%s:%d", node.ServerAddress, node.SubscriptionPort),
+		"password=" + password,
+	}
+
+	switch node.TransportProtocol {
+	case "", vo.TransportTCP:
+		params = append(params, "over-tls=true")
+		if node.SNI != "" {
+			params = append(params, "tls-host="+node.SNI)
+		}
+	case vo.TransportWS:
+		params = append(params, "obfs=wss")
+		params = append(params, "obfs-host="+firstNonEmpty(node.Host, node.SNI))
+		if node.Path != "" {
+			params = append(params, "obfs-uri="+node.Path)
+		}
+	default:
+		// gRPC is not supported by Quantumult X
+		return nil
+	}
+
+	return append(params,
+		fmt.Sprintf("tls-verification=%t", !node.AllowInsecure),
+		"udp-relay=true",
+	)
+}
+
+// buildVMessParams builds a Quantumult X vmess line
+func (f *QuantumultXFormatter) buildVMessParams(node *Node, uuid string) []string {
+	cfg := node.VMessConfig
+
+	params := []string{
+		fmt.Sprintf("vmess=%s:%d", node.ServerAddress, node.SubscriptionPort),
+		"method=" + proxyLineVMessSecurity(cfg.Security()),
+		"password=" + uuid,
+	}
+
+	switch cfg.TransportType() {
+	case vo.VMessTransportTCP:
+		if cfg.TLS() {
+			params = append(params, "obfs=over-tls")
+			if cfg.SNI() != "" {
+				params = append(params, "obfs-host="+cfg.SNI())
+			}
+		}
+	case vo.VMessTransportWS:
+		obfs := "ws"
+		if cfg.TLS() {
+			obfs = "wss"
+		}
+		params = append(params, "obfs="+obfs)
+		if host := firstNonEmpty(cfg.Host(), cfg.SNI()); host != "" {
+			params = append(params, "obfs-host="+host)
+		}
+		if cfg.Path() != "" {
+			params = append(params, "obfs-uri="+cfg.Path())
+		}
+	default:
+		// gRPC, HTTP/2, QUIC, XHTTP and HTTPUpgrade transports are not supported by Quantumult X
+		return nil
+	}
+
+	if cfg.TLS() {
+		params = append(params, fmt.Sprintf("tls-verification=%t", !cfg.AllowInsecure()))
+	}
+
+	return append(params, "udp-relay=false")
+}
+
+// buildVLESSParams builds a Quantumult X vless line
+func (f *QuantumultXFormatter) buildVLESSParams(node *Node, uuid string) []string {
+	cfg := node.VLESSConfig
+	params := []string{
+		fmt.Sprintf("vless=%s:%d", node.ServerAddress, node.SubscriptionPort),
+		"method=none",
+		"password=" + uuid,
+	}
+
+	switch cfg.TransportType() {
+	case vo.VLESSTransportTCP:
+		if cfg.Security() != vo.VLESSSecurityNone {
+			params = append(params, "obfs=over-tls")
+			if cfg.SNI() != "" {
+				params = append(params, "obfs-host="+cfg.SNI())
+			}
+		}
+	case vo.VLESSTransportWS:
+		if cfg.Security() == vo.VLESSSecurityReality {
+			return nil
+		}
+		obfs := "ws"
+		if cfg.Security() == vo.VLESSSecurityTLS {
+			obfs = "wss"
+		}
+		params = append(params, "obfs="+obfs)
+		if host := firstNonEmpty(cfg.Host(), cfg.SNI()); host != "" {
+			params = append(params, "obfs-host="+host)
+		}
+		if cfg.Path() != "" {
+			params = append(params, "obfs-uri="+cfg.Path())
+		}
+	default:
+		// gRPC, HTTP/2, XHTTP and HTTPUpgrade transports are not supported by Quantumult X
+		return nil
+	}
+
+	if cfg.Security() == vo.VLESSSecurityReality {
+		params = append(params,
+			"reality-base64-pubkey="+cfg.PublicKey(),
+			"reality-hex-shortid="+cfg.ShortID(),
+		)
+	}
+
+	if cfg.Flow() != "" {
+		params = append(params, "vless-flow="+cfg.Flow())
+	}
+
+	if cfg.Security() == vo.VLESSSecurityTLS {
+		params = append(params, fmt.Sprintf("tls-verification=%t", !cfg.AllowInsecure()))
+	}
+
+	return append(params, "udp-relay=false")
+}
+
+func (f *QuantumultXFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+type LoonFormatter struct{}
+
+func NewLoonFormatter() *LoonFormatter {
+	return &LoonFormatter{}
+}
+
+func (f *LoonFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *LoonFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	lines, _ := f.buildLines(nodes, password)
+	return strings.Join(append([]string{"[Proxy]"}, lines...), "\n"), nil
+}
+
+// buildLines builds [Proxy] lines and the names of the emitted proxies.
+// Nodes using protocols or transports Loon does not support are skipped.
+func (f *LoonFormatter) buildLines(nodes []*Node, password string) (lines []string, names []string) {
+	for _, node := range nodes {
+		name := sanitizeProxyLineName(node.Name)
+		var params []string
+
+		switch vo.Protocol(node.Protocol) {
+		case vo.ProtocolTrojan:
+			params = f.buildTrojanParams(node, password)
+
+		case vo.ProtocolVLESS:
+			if node.VLESSConfig != nil {
+				params = f.buildVLESSParams(node, password)
+			}
+
+		case vo.ProtocolVMess:
+			if node.VMessConfig != nil {
+				params = f.buildVMessParams(node, password)
+			}
+
+		case vo.ProtocolHysteria2:
+			if node.Hysteria2Config != nil {
+				params = f.buildHysteria2Params(node, password)
+			}
+
+		case vo.ProtocolTUIC, vo.ProtocolAnyTLS, vo.ProtocolWireGuard:
+			// Loon does not support these protocols, skip
+			continue
+
+		default:
+			// ShadowTLS is not supported by this client, skip such Shadowsocks nodes
+			if node.ShadowTLSConfig != nil {
+				continue
+			}
+			params = f.buildShadowsocksParams(node, password)
+		}
+
+		if len(params) == 0 {
+			continue
+		}
+
+		lines = append(lines, name+" = "+strings.Join(params, ","))
+		names = append(names, name)
+	}
+
+	return lines, names
+}
+
+// buildShadowsocksParams builds a Loon Shadowsocks line
+func (f *LoonFormatter) buildShadowsocksParams(node *Node, password string) []string {
+	// Shadowsocks: adjust password for SS2022 methods
+	nodePassword := adjustPasswordForMethod(password, node.EncryptionMethod, node.EffectiveServerKey())
+
+	params := []string{
+		"Shadowsocks",
+		node.ServerAddress,
+		fmt.Sprintf("%d", node.SubscriptionPort),
+		node.EncryptionMethod,
+		quoteLoonValue(nodePassword),
+	}
+
+	switch node.Plugin {
+	case "":
+	case "obfs-local", "obfs", "simple-obfs":
+		obfsMode := node.PluginOpts["obfs"]
+		if obfsMode != "http" && obfsMode != "tls" {
+			return nil
+		}
+		params = append(params, "obfs-name="+obfsMode)
+		if host := node.PluginOpts["obfs-host"]; host != "" {
+			params = append(params, "obfs-host="+host)
+		}
+		params = append(params, "obfs-uri=/")
+	default:
+		// Loon only supports simple-obfs, the node would not be usable without its plugin
+		return nil
+	}
+
+	return append(params, "udp=true")
+}
+
+// buildTrojanParams builds a Loon trojan line
+func (f *LoonFormatter) buildTrojanParams(node *Node, password string) []string {
+	params := []string{
+		"trojan",
+		node.ServerAddress,
+		fmt.Sprintf("%d", node.SubscriptionPort),
+		quoteLoonValue(password),
+	}
+
+	switch node.TransportProtocol {
+	case "", vo.TransportTCP:
+	case vo.TransportWS:
+		params = append(params, "transport=ws")
+		if node.Path != "" {
+			params = append(params, "path="+node.Path)
+		}
+		if node.Host != "" {
+			params = append(params, "host="+node.Host)
+		}
+	default:
+		// gRPC is not supported by Loon
+		return nil
+	}
+
+	if node.SNI != "" {
+		params = append(params, "sni="+node.SNI)
+	}
+
+	return append(params,
+		fmt.Sprintf("skip-cert-verify=%t", node.AllowInsecure),
+		"udp=true",
+	)
+}
+
+// buildVMessParams builds a Loon vmess line
+func (f *LoonFormatter) buildVMessParams(node *Node, uuid string) []string {
+	cfg := node.VMessConfig
+	params := []string{
+		"vmess",
+		node.ServerAddress,
+		fmt.Sprintf("%d", node.SubscriptionPort),
+		proxyLineVMessSecurity(cfg.Security()),
+		quoteLoonValue(uuid),
+	}
+
+	switch cfg.TransportType() {
+	case vo.VMessTransportTCP:
+		params = append(params, "transport=tcp")
+	case vo.VMessTransportWS, vo.VMessTransportHTTP:
+		params = append(params, "transport="+cfg.TransportType())
+		if cfg.Path() != "" {
+			params = append(params, "path="+cfg.Path())
+		}
+		if cfg.Host() != "" {
+			params = append(params, "host="+cfg.Host())
+		}
+	default:
+		// gRPC, QUIC, XHTTP and HTTPUpgrade transports are not supported by Loon
+		return nil
+	}
+
+	params = append(params, fmt.Sprintf("alterId=%d", cfg.AlterID()))
+
+	if cfg.TLS() {
+		params = append(params, "over-tls=true")
+		if cfg.SNI() != "" {
+			params = append(params, "sni="+cfg.SNI())
+		}
+		params = append(params, fmt.Sprintf("skip-cert-verify=%t", cfg.AllowInsecure()))
+	}
+
+	return params
+}
+
+// buildVLESSParams builds a Loon VLESS line
+func (f *LoonFormatter) buildVLESSParams(node *Node, uuid string) []string {
+	cfg := node.VLESSConfig
+	params := []string{
+		"VLESS",
+		node.ServerAddress,
+		fmt.Sprintf("%d", node.SubscriptionPort),
+		quoteLoonValue(uuid),
+	}
+
+	switch cfg.TransportType() {
+	case vo.VLESSTransportTCP:
+		params = append(params, "transport=tcp")
+	case vo.VLESSTransportWS:
+		params = append(params, "transport=ws")
+		if cfg.Path() != "" {
+			params = append(params, "path="+cfg.Path())
+		}
+		if cfg.Host() != "" {
+			params = append(params, "host="+cfg.Host())
+		}
+	default:
+		// gRPC, HTTP/2, XHTTP and HTTPUpgrade transports are not supported by Loon
+		return nil
+	}
+
+	if cfg.Flow() != "" {
+		params = append(params, "flow="+cfg.Flow())
+	}
+
+	switch cfg.Security() {
+	case vo.VLESSSecurityTLS:
+		params = append(params, "over-tls=true")
+		if cfg.SNI() != "" {
+			params = append(params, "sni="+cfg.SNI())
+		}
+		params = append(params, fmt.Sprintf("skip-cert-verify=%t", cfg.AllowInsecure()))
+	case vo.VLESSSecurityReality:
+		params = append(params,
+			"over-tls=true",
+			"public-key="+quoteLoonValue(cfg.PublicKey()),
+			"short-id="+cfg.ShortID(),
+		)
+		if cfg.SNI() != "" {
+			params = append(params, "sni="+cfg.SNI())
+		}
+	}
+
+	return append(params, "udp=true")
+}
+
+// buildHysteria2Params builds a Loon Hysteria2 line
+// password is the subscription-derived credential
+func (f *LoonFormatter) buildHysteria2Params(node *Node, password string) []string {
+	cfg := node.Hysteria2Config
+	// Use subscription-derived password, fallback to config password if empty
+	pwd := password
+	if pwd == "" {
+		pwd = cfg.Password()
+	}
+
+	params := []string{
+		"Hysteria2",
+		node.ServerAddress,
+		fmt.Sprintf("%d", node.SubscriptionPort),
+		quoteLoonValue(pwd),
+	}
+
+	if cfg.SNI() != "" {
+		params = append(params, "sni="+cfg.SNI())
+	}
+	params = append(params, fmt.Sprintf("skip-cert-verify=%t", cfg.AllowInsecure()))
+
+	if cfg.Obfs() == vo.ObfsSalamander {
+		params = append(params, "salamander-password="+cfg.ObfsPassword())
+	}
+
+	if cfg.DownMbps() != nil {
+		params = append(params, fmt.Sprintf("download-bandwidth=%d", *cfg.DownMbps()))
+	}
+
+	return append(params, "udp=true")
+}
+
+func (f *LoonFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// quoteLoonValue wraps a value in double quotes as required by Loon for credentials
+func quoteLoonValue(s string) string {
+	return `"` + s + `"`
+}
+
+// proxyLineVMessSecurity maps the VMess security of a node to a method Quantumult X and Loon
+// accept. Neither supports "zero", which is sent as "none" (also unencrypted), and an unset
+// security means "auto".
+func proxyLineVMessSecurity(security string) string {
+	switch security {
+	case "":
+		return vo.SecurityAuto
+	case vo.SecurityZero:
+		return vo.SecurityNone
+	default:
+		return security
+	}
+}
+
+// sanitizeProxyLineName removes characters that would break comma-separated
+// proxy line formats (Quantumult X, Loon)
+func sanitizeProxyLineName(name string) string {
+	name = strings.NewReplacer(",", " ", "=", " ", "\n", " ", "\r", " ").Replace(name)
+	return strings.TrimSpace(name)
+}
+
+// firstNonEmpty returns the first non-empty string
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
+
+func formatPluginOpts(opts map[string]string) string {
+	if len(opts) == 0 {
+		return ""
+	}
+
+	var parts []string
+	for k, v := range opts {
+		parts = append(parts, fmt.Sprintf("%s=%s", k, v))
+	}
+	return strings.Join(parts, ";")
+}
+
+// WireGuardFormatter renders a wg-quick .conf file for routers and native WireGuard clients.
+// A .conf file describes a single tunnel, so only the first WireGuard node is used;
+// clients pick another node with the subscription name filter.
+type WireGuardFormatter struct{}
+
+func NewWireGuardFormatter() *WireGuardFormatter {
+	return &WireGuardFormatter{}
+}
+
+func (f *WireGuardFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *WireGuardFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	for _, node := range nodes {
+		// Info nodes (negative sort order) duplicate a real node and are not tunnels
+		if vo.Protocol(node.Protocol) != vo.ProtocolWireGuard || node.SortOrder < 0 {
+			continue
+		}
+		if node.WireGuardConfig == nil || node.WireGuardPeerPrivateKey == "" {
+			continue
+		}
+
+		cfg := node.WireGuardConfig
+
+		var b strings.Builder
+		fmt.Fprintf(&b, "# %s\n", node.Name)
+		b.WriteString("[Interface]\n")
+		fmt.Fprintf(&b, "PrivateKey = %s\n", node.WireGuardPeerPrivateKey)
+		fmt.Fprintf(&b, "Address = %s/32\n", node.WireGuardPeerAddress)
+		fmt.Fprintf(&b, "DNS = %s\n", strings.Join(cfg.DNSServers(), ", "))
+		fmt.Fprintf(&b, "MTU = %d\n", cfg.MTU())
+		b.WriteString("\n[Peer]\n")
+		fmt.Fprintf(&b, "PublicKey = %s\n", cfg.PublicKey())
+		fmt.Fprintf(&b, "Endpoint = %s\n", net.JoinHostPort(node.ServerAddress, strconv.Itoa(int(node.SubscriptionPort))))
+		b.WriteString("AllowedIPs = 0.0.0.0/0, ::/0\n")
+		if cfg.PersistentKeepalive() > 0 {
+			fmt.Fprintf(&b, "PersistentKeepalive = %d\n", cfg.PersistentKeepalive())
+		}
+		return b.String(), nil
+	}
+
+	return "", errors.NewNotFoundError("wireguard format requires a WireGuard node, please use base64 or clash format instead")
+}
+
+func (f *WireGuardFormatter) ContentType() string {
+	return "text/plain; charset=utf-8"
+}
+
+// TemplateClashFormatter wraps ClashFormatter with template support
+// It will use custom template if available, otherwise fall back to default formatter
+type TemplateClashFormatter struct {
+	renderer         *TemplateRenderer
+	defaultFormatter *ClashFormatter
+}
+
+// NewTemplateClashFormatter creates a new template-aware Clash formatter
+func NewTemplateClashFormatter(renderer *TemplateRenderer) *TemplateClashFormatter {
+	return &TemplateClashFormatter{
+		renderer:         renderer,
+		defaultFormatter: NewClashFormatter(),
+	}
+}
+
+func (f *TemplateClashFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *TemplateClashFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	// Try template rendering first
+	if f.renderer.HasTemplate("clash") {
+		content, err := f.renderer.RenderClash(nodes, password)
+		if err != nil {
+			// Log error but fall back to default formatter
+			// (error logging handled by caller)
+			return f.defaultFormatter.FormatWithPassword(nodes, password)
+		}
+		return content, nil
+	}
+
+	// Fall back to default formatter
+	return f.defaultFormatter.FormatWithPassword(nodes, password)
+}
+
+func (f *TemplateClashFormatter) ContentType() string {
+	return f.defaultFormatter.ContentType()
+}
+
+// TemplateSurgeFormatter wraps SurgeFormatter with template support
+// It will use custom template if available, otherwise fall back to default formatter
+type TemplateSurgeFormatter struct {
+	renderer         *TemplateRenderer
+	defaultFormatter *SurgeFormatter
+}
+
+// NewTemplateSurgeFormatter creates a new template-aware Surge formatter
+func NewTemplateSurgeFormatter(renderer *TemplateRenderer) *TemplateSurgeFormatter {
+	return &TemplateSurgeFormatter{
+		renderer:         renderer,
+		defaultFormatter: NewSurgeFormatter(),
+	}
+}
+
+func (f *TemplateSurgeFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *TemplateSurgeFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	// Try template rendering first
+	if f.renderer.HasTemplate("surge") {
+		content, err := f.renderer.RenderSurge(nodes, password)
+		if err != nil {
+			// Log error but fall back to default formatter
+			// (error logging handled by caller)
+			return f.defaultFormatter.FormatWithPassword(nodes, password)
+		}
+		return content, nil
+	}
+
+	// Fall back to default formatter
+	return f.defaultFormatter.FormatWithPassword(nodes, password)
+}
+
+func (f *TemplateSurgeFormatter) ContentType() string {
+	return f.defaultFormatter.ContentType()
+}
+
+// TemplateQuantumultXFormatter wraps QuantumultXFormatter with template support
+// It will use custom template if available, otherwise fall back to default formatter
+type TemplateQuantumultXFormatter struct {
+	renderer         *TemplateRenderer
+	defaultFormatter *QuantumultXFormatter
+}
+
+// NewTemplateQuantumultXFormatter creates a new template-aware Quantumult X formatter
+func NewTemplateQuantumultXFormatter(renderer *TemplateRenderer) *TemplateQuantumultXFormatter {
+	return &TemplateQuantumultXFormatter{
+		renderer:         renderer,
+		defaultFormatter: NewQuantumultXFormatter(),
+	}
+}
+
+func (f *TemplateQuantumultXFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *TemplateQuantumultXFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	// Try template rendering first
+	if f.renderer.HasTemplate("quanx") {
+		content, err := f.renderer.RenderQuantumultX(nodes, password)
+		if err != nil {
+			// Log error but fall back to default formatter
+			// (error logging handled by caller)
+			return f.defaultFormatter.FormatWithPassword(nodes, password)
+		}
+		return content, nil
+	}
+
+	// Fall back to default formatter
+	return f.defaultFormatter.FormatWithPassword(nodes, password)
+}
+
+func (f *TemplateQuantumultXFormatter) ContentType() string {
+	return f.defaultFormatter.ContentType()
+}
+
+// TemplateLoonFormatter wraps LoonFormatter with template support
+// It will use custom template if available, otherwise fall back to default formatter
+type TemplateLoonFormatter struct {
+	renderer         *TemplateRenderer
+	defaultFormatter *LoonFormatter
+}
+
+// NewTemplateLoonFormatter creates a new template-aware Loon formatter
+func NewTemplateLoonFormatter(renderer *TemplateRenderer) *TemplateLoonFormatter {
+	return &TemplateLoonFormatter{
+		renderer:         renderer,
+		defaultFormatter: NewLoonFormatter(),
+	}
+}
+
+func (f *TemplateLoonFormatter) Format(nodes []*Node) (string, error) {
+	return f.FormatWithPassword(nodes, "")
+}
+
+func (f *TemplateLoonFormatter) FormatWithPassword(nodes []*Node, password string) (string, error) {
+	// Try template rendering first
+	if f.renderer.HasTemplate("loon") {
+		content, err := f.renderer.RenderLoon(nodes, password)
+		if err != nil {
+			// Log error but fall back to default formatter
+			// (error logging handled by caller)
+			return f.defaultFormatter.FormatWithPassword(nodes, password)
+		}
+		return content, nil
+	}
+
+	// Fall back to default formatter
+	return f.defaultFormatter.FormatWithPassword(nodes, password)
+}
+
+func (f *TemplateLoonFormatter) ContentType() string {
 	return f.defaultFormatter.ContentType()
 }
@@ -26,7 +26,10 @@ func (r *TemplateRenderer) HasTemplate(formatType string) bool {
 	return r.loader.HasTemplate(formatType)
 }
 
-// RenderClash renders Clash template with node data
+// RenderClash renders Clash template with node data.
+// Besides {{PROXIES}} and {{PROXY_NAMES}}, templates may use the region variables
+// "# {{REGION_GROUPS}}", {{REGION_NAMES}}, {{REGION_FALLBACK_NAMES}}, {{REGION_SELECT_NAMES}}
+// and {{REGION_PROXIES:XX}}.
 func (r *TemplateRenderer) RenderClash(nodes []*Node, password string) (string, error) {
 	tmpl, ok := r.loader.Get("clash")
 	if !ok {
@@ -34,7 +37,7 @@ func (r *TemplateRenderer) RenderClash(nodes []*Node, password string) (string,
 	}
 
 	// Generate proxies YAML
-	proxiesYAML, err := r.generateClashProxies(nodes, password)
+	proxiesYAML, groups, err := r.generateClashProxies(nodes, password)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate proxies YAML: %w", err)
 	}
@@ -45,30 +48,68 @@ func (r *TemplateRenderer) RenderClash(nodes []*Node, password string) (string,
 	// Replace placeholders
 	result := strings.Replace(tmpl, "# {{PROXIES}}", proxiesYAML, 1)
 	result = strings.ReplaceAll(result, "{{PROXY_NAMES}}", proxyNames)
+	result = strings.Replace(result, "# {{REGION_GROUPS}}", clashRegionGroupLines(groups), 1)
+	result = replaceRegionVariables(result, groups, quoteYAMLString)
 
 	return result, nil
 }
 
-// generateClashProxies generates YAML for proxies section
+// clashRegionGroupLines renders region groups as flow-style proxy-groups entries
+// indented to match the "proxy-groups:" list of the template.
+func clashRegionGroupLines(groups []regionGroup) string {
+	lines := make([]string, 0, len(groups)*len(regionGroupKinds))
+	for _, g := range groups {
+		proxies := make([]string, len(g.Proxies))
+		for i, name := range g.Proxies {
+			proxies[i] = quoteYAMLString(name)
+		}
+		for _, kind := range regionGroupKinds {
+			line := fmt.Sprintf("  - { name: %s, type: %s, proxies: [%s]",
+				quoteYAMLString(g.groupName(kind)), kind.Type, strings.Join(proxies, ", "))
+			if kind.HealthCheck {
+				line += fmt.Sprintf(", url: '%s', interval: %d", regionGroupTestURL, regionGroupInterval)
+			}
+			lines = append(lines, line+" }")
+		}
+	}
+	return strings.Join(lines, "\n")
+}
+
+// replaceRegionVariables fills the per-kind name variables ({{REGION_NAMES}},
+// {{REGION_FALLBACK_NAMES}}, {{REGION_SELECT_NAMES}}) with the region group names of that
+// kind and {{REGION_PROXIES:XX}} with the proxy names of region XX, each passed through quote.
+func replaceRegionVariables(tmpl string, groups []regionGroup, quote func(string) string) string {
+	result := tmpl
+	for _, kind := range regionGroupKinds {
+		names := regionGroupNames(groups, kind)
+		for i, name := range names {
+			names[i] = quote(name)
+		}
+		result = strings.ReplaceAll(result, kind.Variable, strings.Join(names, ", "))
+	}
+	return replaceRegionProxies(result, groups, quote)
+}
+
+// generateClashProxies generates YAML for proxies section and the region groups of the emitted proxies.
 // Delegates to ClashFormatter for consistent proxy generation across all protocols
-func (r *TemplateRenderer) generateClashProxies(nodes []*Node, password string) (string, error) {
+func (r *TemplateRenderer) generateClashProxies(nodes []*Node, password string) (string, []regionGroup, error) {
 	// Use ClashFormatter to generate proxies with full protocol support
 	formatter := NewClashFormatter()
 	content, err := formatter.FormatWithPassword(nodes, password)
 	if err != nil {
-		return "", fmt.Errorf("failed to generate proxies: %w", err)
+		return "", nil, fmt.Errorf("failed to generate proxies: %w", err)
 	}
 
 	// Parse the generated YAML to extract just the proxies array
 	var config clashConfig
 	if err := yaml.Unmarshal([]byte(content), &config); err != nil {
-		return "", fmt.Errorf("failed to parse proxies YAML: %w", err)
+		return "", nil, fmt.Errorf("failed to parse proxies YAML: %w", err)
 	}
 
 	// Marshal proxies back to YAML
 	yamlBytes, err := yaml.Marshal(config.Proxies)
 	if err != nil {
-		return "", fmt.Errorf("failed to marshal proxies: %w", err)
+		return "", nil, fmt.Errorf("failed to marshal proxies: %w", err)
 	}
 
 	// Indent each line with 2 spaces (to match Clash format under "proxies:")
@@ -82,7 +123,19 @@ func (r *TemplateRenderer) generateClashProxies(nodes []*Node, password string)
 		}
 	}
 
-	return strings.Join(indentedLines, "\n"), nil
+	// Only group nodes that produced a proxy (e.g. nodes with missing configs are skipped)
+	emittedNames := make(map[string]bool, len(config.Proxies))
+	for _, proxy := range config.Proxies {
+		emittedNames[proxy.Name] = true
+	}
+	emitted := make([]*Node, 0, len(nodes))
+	for _, node := range nodes {
+		if emittedNames[node.Name] {
+			emitted = append(emitted, node)
+		}
+	}
+
+	return strings.Join(indentedLines, "\n"), buildRegionGroups(emitted), nil
 }
 
 // extractProxyNames returns comma-separated node names with proper YAML escaping
@@ -106,16 +159,50 @@ func quoteYAMLString(s string) string {
 	return "'" + escaped + "'"
 }
 
-// RenderSurge renders Surge template with node data (placeholder for future implementation)
+// RenderSurge renders Surge template with node data.
+// {{PROXIES}} is replaced with [Proxy] lines, {{PROXY_NAMES}} with comma-separated names,
+// {{REGION_GROUPS}} with [Proxy Group] lines, plus the same region variables as RenderClash.
 func (r *TemplateRenderer) RenderSurge(nodes []*Node, password string) (string, error) {
-	tmpl, ok := r.loader.Get("surge")
+	lines, emitted := NewSurgeFormatter().buildLines(nodes, password)
+
+	names := make([]string, len(emitted))
+	for i, node := range emitted {
+		names[i] = node.Name
+	}
+
+	result, err := r.renderProxyLines("surge", lines, names)
+	if err != nil {
+		return "", err
+	}
+
+	groups := buildRegionGroups(emitted)
+	result = strings.Replace(result, "{{REGION_GROUPS}}", strings.Join(surgeRegionGroupLines(groups), "\n"), 1)
+	return replaceRegionVariables(result, groups, func(name string) string { return name }), nil
+}
+
+// RenderQuantumultX renders Quantumult X template with node data.
+// {{PROXIES}} is replaced with server_local lines and {{PROXY_NAMES}} with comma-separated tags.
+func (r *TemplateRenderer) RenderQuantumultX(nodes []*Node, password string) (string, error) {
+	lines, names := NewQuantumultXFormatter().buildLines(nodes, password)
+	return r.renderProxyLines("quanx", lines, names)
+}
+
+// RenderLoon renders Loon template with node data.
+// {{PROXIES}} is replaced with [Proxy] lines and {{PROXY_NAMES}} with comma-separated names.
+func (r *TemplateRenderer) RenderLoon(nodes []*Node, password string) (string, error) {
+	lines, names := NewLoonFormatter().buildLines(nodes, password)
+	return r.renderProxyLines("loon", lines, names)
+}
+
+// renderProxyLines fills line-based (INI style) templates with proxy lines and names
+func (r *TemplateRenderer) renderProxyLines(formatType string, lines []string, names []string) (string, error) {
+	tmpl, ok := r.loader.Get(formatType)
 	if !ok {
-		return "", fmt.Errorf("no surge template found")
+		return "", fmt.Errorf("no %s template found", formatType)
 	}
 
-	// TODO: Implement Surge template rendering
-	// For now, just return the template as-is (no placeholder replacement)
-	_ = nodes
-	_ = password
-	return tmpl, nil
+	result := strings.Replace(tmpl, "{{PROXIES}}", strings.Join(lines, "\n"), 1)
+	result = strings.ReplaceAll(result, "{{PROXY_NAMES}}", strings.Join(names, ", "))
+
+	return result, nil
 }
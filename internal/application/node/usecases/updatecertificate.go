@@ -0,0 +1,50 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type UpdateCertificateCommand struct {
+	SID       string // External API identifier
+	AutoRenew *bool
+}
+
+type UpdateCertificateUseCase struct {
+	certRepo node.CertificateRepository
+	logger   logger.Interface
+}
+
+func NewUpdateCertificateUseCase(
+	certRepo node.CertificateRepository,
+	logger logger.Interface,
+) *UpdateCertificateUseCase {
+	return &UpdateCertificateUseCase{
+		certRepo: certRepo,
+		logger:   logger,
+	}
+}
+
+func (uc *UpdateCertificateUseCase) Execute(ctx context.Context, cmd UpdateCertificateCommand) (*dto.CertificateDTO, error) {
+	cert, err := getCertificateBySID(ctx, uc.certRepo, uc.logger, cmd.SID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cmd.AutoRenew != nil {
+		cert.SetAutoRenew(*cmd.AutoRenew)
+	}
+
+	if err := uc.certRepo.Update(ctx, cert); err != nil {
+		uc.logger.Errorw("failed to update certificate", "sid", cmd.SID, "error", err)
+		return nil, fmt.Errorf("failed to update certificate: %w", err)
+	}
+
+	uc.logger.Infow("certificate updated", "sid", cmd.SID, "auto_renew", cert.AutoRenew())
+
+	return dto.ToCertificateDTO(cert), nil
+}
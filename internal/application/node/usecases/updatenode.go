@@ -16,6 +16,7 @@ import (
 
 type UpdateNodeCommand struct {
 	SID              string // External API identifier
+	UpdatedBy        uint   // User performing the update, recorded in the configuration history
 	Name             *string
 	ServerAddress    *string
 	AgentPort        *uint16
@@ -57,6 +58,12 @@ type UpdateNodeCommand struct {
 	VLESSRealityPublicKey  *string // Optional: auto-generated if empty when switching to Reality
 	VLESSRealityShortID    *string // Optional: auto-generated if empty when switching to Reality
 	VLESSRealitySpiderX    *string
+	VLESSXHTTPMode         *string
+	VLESSXHTTPExtra        *string
+	// Reality rotation policy (nil: keep current value)
+	VLESSRealityRotationIntervalHours *int // 0 disables scheduled rotation
+	VLESSRealityRotationOverlapHours  *int
+	VLESSRealityRotateKeyPair         *bool
 
 	// VMess specific fields
 	VMessAlterID       *int
@@ -68,6 +75,8 @@ type UpdateNodeCommand struct {
 	VMessTLS           *bool
 	VMessSni           *string
 	VMessAllowInsecure *bool
+	VMessXHTTPMode     *string
+	VMessXHTTPExtra    *string
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl *string
@@ -95,11 +104,29 @@ type UpdateNodeCommand struct {
 	AnyTLSIdleSessionTimeout       *string
 	AnyTLSMinIdleSession           *int
 
+	// WireGuard specific fields
+	WireGuardPrivateKey          *string
+	WireGuardAddressPool         *string
+	WireGuardMTU                 *int
+	WireGuardDNS                 *string
+	WireGuardPersistentKeepalive *int
+
+	// ShadowTLS fields (Shadowsocks only); an empty handshake server disables ShadowTLS
+	ShadowTLSHandshakeServer *string
+	ShadowTLSHandshakePort   *uint16
+	ShadowTLSPassword        *string // Empty string generates a new password
+	ShadowTLSVersion         *int
+
 	// Expiration and cost label fields
 	ExpiresAt      *time.Time // nil: no update, set to update expiration time
 	ClearExpiresAt bool       // true: clear expiration time
 	CostLabel      *string    // nil: no update, set to update cost label
 	ClearCostLabel bool       // true: clear cost label
+	// Country code field
+	CountryCode      *string // nil: no update, set to update country code (ISO 3166-1 alpha-2)
+	ClearCountryCode bool    // true: clear country code
+	// Billing rate applied to subscription usage on this node
+	TrafficMultiplier *float64 // nil: no update
 }
 
 type UpdateNodeResult struct {
@@ -119,6 +146,7 @@ type UpdateNodeUseCase struct {
 	resourceGroupRepo     resource.Repository
 	addressChangeNotifier NodeAddressChangeNotifier
 	configChangeNotifier  NodeConfigChangeNotifier
+	versionRecorder       NodeConfigVersionRecorder
 }
 
 func NewUpdateNodeUseCase(
@@ -145,6 +173,11 @@ func (uc *UpdateNodeUseCase) SetConfigChangeNotifier(notifier NodeConfigChangeNo
 	uc.configChangeNotifier = notifier
 }
 
+// SetConfigVersionRecorder sets the recorder for the node configuration history.
+func (uc *UpdateNodeUseCase) SetConfigVersionRecorder(recorder NodeConfigVersionRecorder) {
+	uc.versionRecorder = recorder
+}
+
 func (uc *UpdateNodeUseCase) Execute(ctx context.Context, cmd UpdateNodeCommand) (*UpdateNodeResult, error) {
 	uc.logger.Infow("executing update node use case", "sid", cmd.SID)
 
@@ -280,6 +313,9 @@ func (uc *UpdateNodeUseCase) Execute(ctx context.Context, cmd UpdateNodeCommand)
 		}
 	}
 
+	// Capture the configuration before the change for the configuration history
+	previousConfig := existingNode.ConfigSnapshot()
+
 	// Apply updates based on command fields
 	if err := uc.applyUpdates(existingNode, cmd); err != nil {
 		uc.logger.Errorw("failed to apply updates", "error", err, "sid", cmd.SID)
@@ -294,6 +330,17 @@ func (uc *UpdateNodeUseCase) Execute(ctx context.Context, cmd UpdateNodeCommand)
 
 	uc.logger.Infow("node updated successfully", "sid", cmd.SID)
 
+	// Record the configuration history; a failure here must not fail the update itself
+	if uc.versionRecorder != nil {
+		var authorID *uint
+		if cmd.UpdatedBy != 0 {
+			authorID = &cmd.UpdatedBy
+		}
+		if _, err := uc.versionRecorder.Record(ctx, existingNode, &previousConfig, authorID, node.ConfigVersionSourceUpdate, nil); err != nil {
+			uc.logger.Warnw("failed to record node config version", "error", err, "sid", cmd.SID)
+		}
+	}
+
 	// Check if address or port changed and notify forward agents
 	addressChanged := originalAddress != newAddress || originalPort != newPort
 
@@ -381,14 +428,16 @@ func (uc *UpdateNodeUseCase) applyUpdates(n *node.Node, cmd UpdateNodeCommand) e
 
 	// Update encryption config (method only)
 	// Note: Protocol type cannot be changed after node creation
-	// Only the encryption method within the same protocol can be updated
-	if cmd.Method != nil {
+	// Only the encryption method within the same protocol can be updated.
+	// Resubmitting the current method is a no-op so nodes on retired ciphers stay editable.
+	if cmd.Method != nil && *cmd.Method != n.EncryptionConfig().Method() {
 		// Validate that the new method is compatible with the existing protocol
 		if err := uc.validateProtocolMethodCompatibility(n.Protocol(), *cmd.Method); err != nil {
 			return err
 		}
 
-		encryptionConfig, err := vo.NewEncryptionConfig(*cmd.Method)
+		// Keeps a stored SS2022 server key when the key size is unchanged
+		encryptionConfig, err := n.EncryptionConfig().WithMethod(*cmd.Method)
 		if err != nil {
 			return errors.NewValidationError("invalid encryption config: " + err.Error())
 		}
@@ -412,6 +461,11 @@ func (uc *UpdateNodeUseCase) applyUpdates(n *node.Node, cmd UpdateNodeCommand) e
 		}
 	}
 
+	// Update ShadowTLS layer (only for Shadowsocks protocol nodes)
+	if err := uc.applyShadowTLSUpdates(n, cmd); err != nil {
+		return err
+	}
+
 	// Update metadata (region, tags, description)
 	needMetadataUpdate := cmd.Region != nil || cmd.Tags != nil || cmd.Description != nil
 	if needMetadataUpdate {
@@ -484,6 +538,9 @@ func (uc *UpdateNodeUseCase) applyUpdates(n *node.Node, cmd UpdateNodeCommand) e
 	if err := uc.applyVLESSUpdates(n, cmd); err != nil {
 		return err
 	}
+	if err := uc.applyRealityRotationUpdates(n, cmd); err != nil {
+		return err
+	}
 
 	// Update VMess config (only for VMess protocol nodes)
 	if err := uc.applyVMessUpdates(n, cmd); err != nil {
@@ -505,6 +562,11 @@ func (uc *UpdateNodeUseCase) applyUpdates(n *node.Node, cmd UpdateNodeCommand) e
 		return err
 	}
 
+	// Update WireGuard config (only for WireGuard protocol nodes)
+	if err := uc.applyWireGuardUpdates(n, cmd); err != nil {
+		return err
+	}
+
 	// Update route config
 	if cmd.ClearRoute {
 		n.ClearRouteConfig()
@@ -545,6 +607,24 @@ func (uc *UpdateNodeUseCase) applyUpdates(n *node.Node, cmd UpdateNodeCommand) e
 		n.SetCostLabel(cmd.CostLabel)
 	}
 
+	// Update country_code
+	if cmd.ClearCountryCode {
+		n.SetCountryCode(nil)
+	} else if cmd.CountryCode != nil {
+		code, err := vo.NewCountryCode(*cmd.CountryCode)
+		if err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+		n.SetCountryCode(&code)
+	}
+
+	// Update traffic_multiplier
+	if cmd.TrafficMultiplier != nil {
+		if err := n.UpdateTrafficMultiplier(*cmd.TrafficMultiplier); err != nil {
+			return errors.NewValidationError(err.Error())
+		}
+	}
+
 	return nil
 }
 
@@ -634,7 +714,8 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 		cmd.VLESSAllowInsecure != nil || cmd.VLESSHost != nil || cmd.VLESSPath != nil ||
 		cmd.VLESSServiceName != nil || cmd.VLESSRealityPrivateKey != nil ||
 		cmd.VLESSRealityPublicKey != nil || cmd.VLESSRealityShortID != nil ||
-		cmd.VLESSRealitySpiderX != nil
+		cmd.VLESSRealitySpiderX != nil || cmd.VLESSXHTTPMode != nil ||
+		cmd.VLESSXHTTPExtra != nil
 
 	if !hasVLESSUpdate {
 		return nil
@@ -651,6 +732,7 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 	// Build new config with updated values
 	var transportType, flow, security, sni, fingerprint, host, path, serviceName string
 	var privateKey, publicKey, shortID, spiderX string
+	var xhttpMode, xhttpExtra string
 	var allowInsecure bool
 
 	if currentConfig != nil {
@@ -667,6 +749,8 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 		publicKey = currentConfig.PublicKey()
 		shortID = currentConfig.ShortID()
 		spiderX = currentConfig.SpiderX()
+		xhttpMode = currentConfig.XHTTPMode()
+		xhttpExtra = currentConfig.XHTTPExtra()
 	} else {
 		// Default values for VLESS nodes without config
 		transportType = "tcp"
@@ -712,6 +796,12 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 	if cmd.VLESSRealitySpiderX != nil {
 		spiderX = *cmd.VLESSRealitySpiderX
 	}
+	if cmd.VLESSXHTTPMode != nil {
+		xhttpMode = *cmd.VLESSXHTTPMode
+	}
+	if cmd.VLESSXHTTPExtra != nil {
+		xhttpExtra = *cmd.VLESSXHTTPExtra
+	}
 
 	// Auto-generate Reality key pair and short ID if security is reality and all keys are empty
 	if security == vo.VLESSSecurityReality && privateKey == "" && publicKey == "" && shortID == "" {
@@ -750,11 +840,18 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 		publicKey,
 		shortID,
 		spiderX,
+		xhttpMode,
+		xhttpExtra,
 	)
 	if err != nil {
 		return errors.NewValidationError("invalid VLESS configuration: " + err.Error())
 	}
 
+	// Keep the Reality rotation policy and overlap state across config edits
+	if currentConfig != nil {
+		newConfig = newConfig.WithRealityRotation(currentConfig.RealityRotation())
+	}
+
 	// Update the node with new config
 	if err := n.UpdateVLESSConfig(&newConfig); err != nil {
 		return errors.NewValidationError("failed to update VLESS config: " + err.Error())
@@ -763,13 +860,50 @@ func (uc *UpdateNodeUseCase) applyVLESSUpdates(n *node.Node, cmd UpdateNodeComma
 	return nil
 }
 
+// applyRealityRotationUpdates applies the scheduled Reality rotation policy of a VLESS node
+func (uc *UpdateNodeUseCase) applyRealityRotationUpdates(n *node.Node, cmd UpdateNodeCommand) error {
+	if cmd.VLESSRealityRotationIntervalHours == nil && cmd.VLESSRealityRotationOverlapHours == nil &&
+		cmd.VLESSRealityRotateKeyPair == nil {
+		return nil
+	}
+
+	if !n.Protocol().IsVLESS() || n.VLESSConfig() == nil || n.VLESSConfig().Security() != vo.VLESSSecurityReality {
+		return errors.NewValidationError("reality rotation policy requires a VLESS node using reality security")
+	}
+
+	current := n.VLESSConfig().RealityRotation()
+	intervalHours := current.IntervalHours()
+	overlapHours := current.OverlapHours()
+	rotateKeyPair := current.RotateKeyPair()
+	if cmd.VLESSRealityRotationIntervalHours != nil {
+		intervalHours = *cmd.VLESSRealityRotationIntervalHours
+	}
+	if cmd.VLESSRealityRotationOverlapHours != nil {
+		overlapHours = *cmd.VLESSRealityRotationOverlapHours
+	}
+	if cmd.VLESSRealityRotateKeyPair != nil {
+		rotateKeyPair = *cmd.VLESSRealityRotateKeyPair
+	}
+
+	policy, err := vo.NewRealityRotationPolicy(intervalHours, overlapHours, rotateKeyPair)
+	if err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	if err := n.UpdateRealityRotationPolicy(policy); err != nil {
+		return errors.NewValidationError(err.Error())
+	}
+
+	return nil
+}
+
 // applyVMessUpdates applies VMess-specific configuration updates
 func (uc *UpdateNodeUseCase) applyVMessUpdates(n *node.Node, cmd UpdateNodeCommand) error {
 	// Check if any VMess fields need updating
 	hasVMessUpdate := cmd.VMessAlterID != nil || cmd.VMessSecurity != nil ||
 		cmd.VMessTransportType != nil || cmd.VMessHost != nil || cmd.VMessPath != nil ||
 		cmd.VMessServiceName != nil || cmd.VMessTLS != nil || cmd.VMessSni != nil ||
-		cmd.VMessAllowInsecure != nil
+		cmd.VMessAllowInsecure != nil || cmd.VMessXHTTPMode != nil || cmd.VMessXHTTPExtra != nil
 
 	if !hasVMessUpdate {
 		return nil
@@ -786,6 +920,7 @@ func (uc *UpdateNodeUseCase) applyVMessUpdates(n *node.Node, cmd UpdateNodeComma
 	// Build new config with updated values
 	var alterID int
 	var security, transportType, host, path, serviceName, sni string
+	var xhttpMode, xhttpExtra string
 	var tls, allowInsecure bool
 
 	if currentConfig != nil {
@@ -798,6 +933,8 @@ func (uc *UpdateNodeUseCase) applyVMessUpdates(n *node.Node, cmd UpdateNodeComma
 		tls = currentConfig.TLS()
 		sni = currentConfig.SNI()
 		allowInsecure = currentConfig.AllowInsecure()
+		xhttpMode = currentConfig.XHTTPMode()
+		xhttpExtra = currentConfig.XHTTPExtra()
 	} else {
 		// Default values for VMess nodes without config
 		security = "auto"
@@ -831,6 +968,12 @@ func (uc *UpdateNodeUseCase) applyVMessUpdates(n *node.Node, cmd UpdateNodeComma
 	if cmd.VMessAllowInsecure != nil {
 		allowInsecure = *cmd.VMessAllowInsecure
 	}
+	if cmd.VMessXHTTPMode != nil {
+		xhttpMode = *cmd.VMessXHTTPMode
+	}
+	if cmd.VMessXHTTPExtra != nil {
+		xhttpExtra = *cmd.VMessXHTTPExtra
+	}
 
 	// Create new VMess config
 	newConfig, err := vo.NewVMessConfig(
@@ -843,6 +986,8 @@ func (uc *UpdateNodeUseCase) applyVMessUpdates(n *node.Node, cmd UpdateNodeComma
 		tls,
 		sni,
 		allowInsecure,
+		xhttpMode,
+		xhttpExtra,
 	)
 	if err != nil {
 		return errors.NewValidationError("invalid VMess configuration: " + err.Error())
@@ -1103,6 +1248,127 @@ func (uc *UpdateNodeUseCase) applyAnyTLSUpdates(n *node.Node, cmd UpdateNodeComm
 	return nil
 }
 
+// applyWireGuardUpdates applies WireGuard-specific configuration updates.
+// Changing the private key or address pool changes every peer's configuration,
+// so existing client subscriptions must be refreshed afterwards.
+func (uc *UpdateNodeUseCase) applyWireGuardUpdates(n *node.Node, cmd UpdateNodeCommand) error {
+	// Check if any WireGuard fields need updating
+	hasWireGuardUpdate := cmd.WireGuardPrivateKey != nil || cmd.WireGuardAddressPool != nil ||
+		cmd.WireGuardMTU != nil || cmd.WireGuardDNS != nil || cmd.WireGuardPersistentKeepalive != nil
+
+	if !hasWireGuardUpdate {
+		return nil
+	}
+
+	// Validate protocol is WireGuard
+	if !n.Protocol().IsWireGuard() {
+		return errors.NewValidationError("cannot update WireGuard config for non-WireGuard protocol node")
+	}
+
+	currentConfig := n.WireGuardConfig()
+	if currentConfig == nil {
+		return errors.NewValidationError("WireGuard config is missing for this node")
+	}
+
+	privateKey := currentConfig.PrivateKey()
+	addressPool := currentConfig.AddressPool()
+	mtu := currentConfig.MTU()
+	dns := currentConfig.DNS()
+	keepalive := currentConfig.PersistentKeepalive()
+
+	if cmd.WireGuardPrivateKey != nil {
+		privateKey = *cmd.WireGuardPrivateKey
+	}
+	if cmd.WireGuardAddressPool != nil {
+		addressPool = *cmd.WireGuardAddressPool
+	}
+	if cmd.WireGuardMTU != nil {
+		mtu = *cmd.WireGuardMTU
+	}
+	if cmd.WireGuardDNS != nil {
+		dns = *cmd.WireGuardDNS
+	}
+	if cmd.WireGuardPersistentKeepalive != nil {
+		keepalive = *cmd.WireGuardPersistentKeepalive
+	}
+
+	newConfig, err := vo.NewWireGuardConfig(privateKey, addressPool, mtu, dns, keepalive)
+	if err != nil {
+		return errors.NewValidationError("invalid WireGuard configuration: " + err.Error())
+	}
+
+	if err := n.UpdateWireGuardConfig(&newConfig); err != nil {
+		return errors.NewValidationError("failed to update WireGuard config: " + err.Error())
+	}
+
+	return nil
+}
+
+// applyShadowTLSUpdates enables, updates or disables the ShadowTLS layer of a Shadowsocks node.
+// It must run after the plugin update so the plugin conflict check sees the final state.
+func (uc *UpdateNodeUseCase) applyShadowTLSUpdates(n *node.Node, cmd UpdateNodeCommand) error {
+	hasShadowTLSUpdate := cmd.ShadowTLSHandshakeServer != nil || cmd.ShadowTLSHandshakePort != nil ||
+		cmd.ShadowTLSPassword != nil || cmd.ShadowTLSVersion != nil
+
+	if hasShadowTLSUpdate {
+		if !n.Protocol().IsShadowsocks() {
+			return errors.NewValidationError("ShadowTLS is only supported for Shadowsocks protocol nodes")
+		}
+
+		if cmd.ShadowTLSHandshakeServer != nil && *cmd.ShadowTLSHandshakeServer == "" {
+			if err := n.UpdateShadowTLSConfig(nil); err != nil {
+				return errors.NewValidationError("failed to disable ShadowTLS: " + err.Error())
+			}
+			return nil
+		}
+
+		var version int
+		var password, handshakeServer string
+		var handshakePort uint16
+		if current := n.ShadowTLSConfig(); current != nil {
+			version = current.Version()
+			password = current.Password()
+			handshakeServer = current.HandshakeServer()
+			handshakePort = current.HandshakePort()
+		}
+
+		if cmd.ShadowTLSHandshakeServer != nil {
+			handshakeServer = *cmd.ShadowTLSHandshakeServer
+		}
+		if cmd.ShadowTLSHandshakePort != nil {
+			handshakePort = *cmd.ShadowTLSHandshakePort
+		}
+		if cmd.ShadowTLSPassword != nil {
+			password = *cmd.ShadowTLSPassword
+		}
+		if cmd.ShadowTLSVersion != nil {
+			version = *cmd.ShadowTLSVersion
+		}
+		if password == "" {
+			generated, err := vo.GenerateShadowTLSPassword()
+			if err != nil {
+				return fmt.Errorf("failed to generate shadowtls password: %w", err)
+			}
+			password = generated
+		}
+
+		newConfig, err := vo.NewShadowTLSConfig(version, password, handshakeServer, handshakePort)
+		if err != nil {
+			return errors.NewValidationError("invalid ShadowTLS configuration: " + err.Error())
+		}
+		if err := n.UpdateShadowTLSConfig(&newConfig); err != nil {
+			return errors.NewValidationError("failed to update ShadowTLS config: " + err.Error())
+		}
+	}
+
+	// A plugin and ShadowTLS both wrap the Shadowsocks stream and cannot be stacked
+	if n.ShadowTLSConfig() != nil && n.PluginConfig() != nil {
+		return errors.NewValidationError("ShadowTLS cannot be combined with a Shadowsocks plugin")
+	}
+
+	return nil
+}
+
 // validateCommand validates the update node command
 func (uc *UpdateNodeUseCase) validateCommand(cmd UpdateNodeCommand) error {
 	if cmd.SID == "" {
@@ -1125,10 +1391,14 @@ func (uc *UpdateNodeUseCase) validateCommand(cmd UpdateNodeCommand) error {
 		cmd.VLESSHost != nil || cmd.VLESSPath != nil || cmd.VLESSServiceName != nil ||
 		cmd.VLESSRealityPrivateKey != nil || cmd.VLESSRealityPublicKey != nil ||
 		cmd.VLESSRealityShortID != nil || cmd.VLESSRealitySpiderX != nil ||
+		cmd.VLESSXHTTPMode != nil || cmd.VLESSXHTTPExtra != nil ||
+		cmd.VLESSRealityRotationIntervalHours != nil || cmd.VLESSRealityRotationOverlapHours != nil ||
+		cmd.VLESSRealityRotateKeyPair != nil ||
 		// VMess fields
 		cmd.VMessAlterID != nil || cmd.VMessSecurity != nil || cmd.VMessTransportType != nil ||
 		cmd.VMessHost != nil || cmd.VMessPath != nil || cmd.VMessServiceName != nil ||
 		cmd.VMessTLS != nil || cmd.VMessSni != nil || cmd.VMessAllowInsecure != nil ||
+		cmd.VMessXHTTPMode != nil || cmd.VMessXHTTPExtra != nil ||
 		// Hysteria2 fields
 		cmd.Hysteria2CongestionControl != nil || cmd.Hysteria2Obfs != nil || cmd.Hysteria2ObfsPassword != nil ||
 		cmd.Hysteria2UpMbps != nil || cmd.Hysteria2DownMbps != nil || cmd.Hysteria2Sni != nil ||
@@ -1140,8 +1410,15 @@ func (uc *UpdateNodeUseCase) validateCommand(cmd UpdateNodeCommand) error {
 		cmd.AnyTLSSni != nil || cmd.AnyTLSAllowInsecure != nil || cmd.AnyTLSFingerprint != nil ||
 		cmd.AnyTLSIdleSessionCheckInterval != nil || cmd.AnyTLSIdleSessionTimeout != nil ||
 		cmd.AnyTLSMinIdleSession != nil ||
+		// WireGuard fields
+		cmd.WireGuardPrivateKey != nil || cmd.WireGuardAddressPool != nil || cmd.WireGuardMTU != nil ||
+		cmd.WireGuardDNS != nil || cmd.WireGuardPersistentKeepalive != nil ||
+		// ShadowTLS fields
+		cmd.ShadowTLSHandshakeServer != nil || cmd.ShadowTLSHandshakePort != nil ||
+		cmd.ShadowTLSPassword != nil || cmd.ShadowTLSVersion != nil ||
 		// Expiration and cost label fields
-		cmd.ExpiresAt != nil || cmd.ClearExpiresAt || cmd.CostLabel != nil || cmd.ClearCostLabel
+		cmd.ExpiresAt != nil || cmd.ClearExpiresAt || cmd.CostLabel != nil || cmd.ClearCostLabel ||
+		cmd.CountryCode != nil || cmd.ClearCountryCode || cmd.TrafficMultiplier != nil
 
 	if !hasUpdate {
 		return errors.NewValidationError("at least one field must be provided for update")
@@ -1197,6 +1474,10 @@ func (uc *UpdateNodeUseCase) validateProtocolMethodCompatibility(protocol vo.Pro
 		if !ssMethods[method] {
 			return errors.NewValidationError("encryption method '" + method + "' is not compatible with Shadowsocks protocol")
 		}
+		// Legacy ciphers are kept readable for existing nodes but can no longer be configured
+		if vo.IsLegacyMethod(method) {
+			return errors.NewValidationError("encryption method '" + method + "' is retired, use a 2022-blake3-* method")
+		}
 	} else if protocol.IsTrojan() {
 		// Trojan doesn't use these encryption methods, it uses TLS
 		if ssMethods[method] {
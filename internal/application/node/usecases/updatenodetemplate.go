@@ -0,0 +1,94 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/application/node/dto"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/resource"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+type UpdateNodeTemplateCommand struct {
+	SID           string // External API identifier
+	Name          *string
+	Description   *string
+	SourceNodeSID *string   // Re-capture the configuration from this node
+	GroupSIDs     *[]string // Replace the resource groups (empty removes all)
+}
+
+type UpdateNodeTemplateUseCase struct {
+	templateRepo      node.NodeTemplateRepository
+	nodeRepo          node.NodeRepository
+	resourceGroupRepo resource.Repository
+	logger            logger.Interface
+}
+
+func NewUpdateNodeTemplateUseCase(
+	templateRepo node.NodeTemplateRepository,
+	nodeRepo node.NodeRepository,
+	resourceGroupRepo resource.Repository,
+	logger logger.Interface,
+) *UpdateNodeTemplateUseCase {
+	return &UpdateNodeTemplateUseCase{
+		templateRepo:      templateRepo,
+		nodeRepo:          nodeRepo,
+		resourceGroupRepo: resourceGroupRepo,
+		logger:            logger,
+	}
+}
+
+func (uc *UpdateNodeTemplateUseCase) Execute(ctx context.Context, cmd UpdateNodeTemplateCommand) (*dto.NodeTemplateDTO, error) {
+	template, err := getNodeTemplateBySID(ctx, uc.templateRepo, uc.logger, cmd.SID)
+	if err != nil {
+		return nil, err
+	}
+	updatedAt := template.UpdatedAt()
+
+	if cmd.Name != nil {
+		if err := template.Rename(*cmd.Name); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+	if cmd.Description != nil {
+		if err := template.UpdateDescription(*cmd.Description); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+	if cmd.SourceNodeSID != nil {
+		source, err := getTemplateSourceNode(ctx, uc.nodeRepo, uc.logger, *cmd.SourceNodeSID)
+		if err != nil {
+			return nil, err
+		}
+		if err := template.UpdateConfig(source.ConfigSnapshot()); err != nil {
+			return nil, errors.NewValidationError(err.Error())
+		}
+	}
+	if cmd.GroupSIDs != nil {
+		groupIDs, err := resolveTemplateGroupIDs(ctx, uc.resourceGroupRepo, uc.logger, *cmd.GroupSIDs)
+		if err != nil {
+			return nil, err
+		}
+		template.SetGroupIDs(groupIDs)
+	}
+
+	// Setters only touch the update time when something changed
+	if !template.UpdatedAt().Equal(updatedAt) {
+		if err := uc.templateRepo.Update(ctx, template); err != nil {
+			if errors.IsAppError(err) {
+				return nil, err
+			}
+			uc.logger.Errorw("failed to update node template", "sid", cmd.SID, "error", err)
+			return nil, fmt.Errorf("failed to update node template: %w", err)
+		}
+		uc.logger.Infow("node template updated", "sid", cmd.SID, "name", template.Name())
+	}
+
+	groupSIDs, err := getTemplateGroupSIDs(ctx, uc.resourceGroupRepo, uc.logger, template)
+	if err != nil {
+		return nil, err
+	}
+	return dto.ToNodeTemplateDTO(template, groupSIDs), nil
+}
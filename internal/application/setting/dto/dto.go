@@ -2,6 +2,8 @@ package dto
 
 import (
 	"time"
+
+	nodeUsecases "github.com/orris-inc/orris/internal/application/node/usecases"
 )
 
 // SystemSettingResponse represents a single setting response
@@ -192,12 +194,22 @@ type SubscriptionSettingsResponse struct {
 	// - "📅 到期: YYYY-MM-DD" (expiration date)
 	// - "📊 流量: X.XXG / Y.YYG" (traffic usage)
 	ShowInfoNodes SettingWithSource `json:"show_info_nodes"`
+	// UserAgentRules is the ordered User-Agent to format mapping used by GET /s/:token.
+	// Custom rules are evaluated before the built-in client detection.
+	UserAgentRules SettingWithSource `json:"user_agent_rules"`
+	// ProfileUpdateInterval is the suggested client auto-update interval in hours
+	// sent as the Profile-Update-Interval header (0 = header omitted)
+	ProfileUpdateInterval SettingWithSource `json:"profile_update_interval"`
 }
 
 // UpdateSubscriptionSettingsRequest represents the request to update subscription settings
 type UpdateSubscriptionSettingsRequest struct {
 	// ShowInfoNodes enables/disables info nodes in subscription output
 	ShowInfoNodes *bool `json:"show_info_nodes"`
+	// UserAgentRules replaces the custom User-Agent to format mapping (empty array clears it)
+	UserAgentRules *[]nodeUsecases.UserAgentFormatRule `json:"user_agent_rules" binding:"omitempty,max=50,dive"`
+	// ProfileUpdateInterval sets the Profile-Update-Interval header in hours (0 disables it)
+	ProfileUpdateInterval *int `json:"profile_update_interval" binding:"omitempty,min=0,max=720"`
 }
 
 // BrandingSettingsResponse represents branding settings response (admin)
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 
+	nodeUsecases "github.com/orris-inc/orris/internal/application/node/usecases"
 	"github.com/orris-inc/orris/internal/application/setting/dto"
 	paymentVO "github.com/orris-inc/orris/internal/domain/payment/valueobjects"
 )
@@ -143,7 +144,9 @@ func (s *ServiceDDD) UpdateUSDTSettings(ctx context.Context, req dto.UpdateUSDTS
 // GetSubscriptionSettings retrieves subscription settings
 func (s *ServiceDDD) GetSubscriptionSettings(ctx context.Context) (*dto.SubscriptionSettingsResponse, error) {
 	return &dto.SubscriptionSettingsResponse{
-		ShowInfoNodes: s.getSettingWithSourceBool(ctx, "subscription", "show_info_nodes"),
+		ShowInfoNodes:         s.getSettingWithSourceBool(ctx, "subscription", "show_info_nodes"),
+		UserAgentRules:        s.getSettingWithSourceUserAgentRules(ctx),
+		ProfileUpdateInterval: s.getSettingWithSourceInt(ctx, "subscription", "profile_update_interval", 24),
 	}, nil
 }
 
@@ -158,6 +161,26 @@ func (s *ServiceDDD) UpdateSubscriptionSettings(ctx context.Context, req dto.Upd
 		changes["show_info_nodes"] = *req.ShowInfoNodes
 	}
 
+	if req.UserAgentRules != nil {
+		rules := *req.UserAgentRules
+		for _, rule := range rules {
+			if err := rule.Validate(); err != nil {
+				return err
+			}
+		}
+		if err := s.upsertSettingJSON(ctx, "subscription", "user_agent_rules", rules, updatedBy); err != nil {
+			return err
+		}
+		changes["user_agent_rules"] = rules
+	}
+
+	if req.ProfileUpdateInterval != nil {
+		if err := s.upsertSettingInt(ctx, "subscription", "profile_update_interval", *req.ProfileUpdateInterval, updatedBy); err != nil {
+			return err
+		}
+		changes["profile_update_interval"] = *req.ProfileUpdateInterval
+	}
+
 	if len(changes) > 0 {
 		if err := s.settingProvider.NotifyChange(ctx, "subscription", changes); err != nil {
 			s.logger.Warnw("failed to notify subscription setting changes", "error", err)
@@ -165,3 +188,21 @@ func (s *ServiceDDD) UpdateSubscriptionSettings(ctx context.Context, req dto.Upd
 	}
 	return nil
 }
+
+// getSettingWithSourceUserAgentRules retrieves the custom User-Agent format rules with their source
+func (s *ServiceDDD) getSettingWithSourceUserAgentRules(ctx context.Context) dto.SettingWithSource {
+	existing, err := s.getSettingsUC.GetSettingByKey(ctx, "subscription", "user_agent_rules")
+	if err == nil && existing != nil && existing.HasValue() {
+		var rules []nodeUsecases.UserAgentFormatRule
+		if err := existing.GetJSONValue(&rules); err == nil {
+			return dto.SettingWithSource{
+				Value:  rules,
+				Source: dto.SourceDatabase,
+			}
+		}
+	}
+	return dto.SettingWithSource{
+		Value:  []nodeUsecases.UserAgentFormatRule{},
+		Source: dto.SourceDefault,
+	}
+}
@@ -337,6 +337,25 @@ func (s *ServiceDDD) upsertSettingStringArray(ctx context.Context, category, key
 	return s.updateSettingsUC.UpsertSetting(ctx, existing)
 }
 
+// upsertSettingJSON creates or updates a JSON setting
+func (s *ServiceDDD) upsertSettingJSON(ctx context.Context, category, key string, value any, updatedBy uint) error {
+	existing, err := s.getSettingsUC.GetSettingByKey(ctx, category, key)
+	if err != nil || existing == nil {
+		newSetting, err := setting.NewSystemSetting(category, key, setting.ValueTypeJSON, "")
+		if err != nil {
+			return err
+		}
+		if err := newSetting.SetJSONValue(value, updatedBy); err != nil {
+			return err
+		}
+		return s.updateSettingsUC.UpsertSetting(ctx, newSetting)
+	}
+	if err := existing.SetJSONValue(value, updatedBy); err != nil {
+		return err
+	}
+	return s.updateSettingsUC.UpsertSetting(ctx, existing)
+}
+
 // getSettingWithSourceStringArray retrieves a string array setting value with its source
 func (s *ServiceDDD) getSettingWithSourceStringArray(ctx context.Context, category, key string) dto.SettingWithSource {
 	existing, err := s.getSettingsUC.GetSettingByKey(ctx, category, key)
@@ -180,6 +180,15 @@ func (uc *AggregateUsageUseCase) aggregateHourlyData(
 				"resource_id", d.ResourceID,
 			)
 		}
+
+		var rawUpload, rawDownload uint64
+		if d.RawUpload > 0 {
+			rawUpload = uint64(d.RawUpload)
+		}
+		if d.RawDownload > 0 {
+			rawDownload = uint64(d.RawDownload)
+		}
+		aggregated[key].AccumulateRaw(rawUpload, rawDownload)
 	}
 }
 
@@ -375,5 +384,6 @@ func (uc *AggregateUsageUseCase) aggregateStatsRecords(
 				"resource_id", record.ResourceID(),
 			)
 		}
+		aggregated[key].AccumulateRaw(record.RawUpload(), record.RawDownload())
 	}
 }
@@ -32,9 +32,16 @@ type ChangePlanCommand struct {
 }
 
 type ChangePlanUseCase struct {
-	subscriptionRepo subscription.SubscriptionRepository
-	planRepo         subscription.PlanRepository
-	logger           logger.Interface
+	subscriptionRepo   subscription.SubscriptionRepository
+	planRepo           subscription.PlanRepository
+	ruleLimitsNotifier ForwardRuleLimitsNotifier
+	logger             logger.Interface
+}
+
+// SetForwardRuleLimitsNotifier sets the notifier that re-syncs the forward rules of a subscription
+// when its plan changes, so they enforce the new plan's limits.
+func (uc *ChangePlanUseCase) SetForwardRuleLimitsNotifier(notifier ForwardRuleLimitsNotifier) {
+	uc.ruleLimitsNotifier = notifier
 }
 
 func NewChangePlanUseCase(
@@ -133,6 +140,16 @@ func (uc *ChangePlanUseCase) Execute(ctx context.Context, cmd ChangePlanCommand)
 		return fmt.Errorf("failed to update subscription: %w", err)
 	}
 
+	if cmd.EffectiveDate != EffectiveDatePeriodEnd && uc.ruleLimitsNotifier != nil {
+		if err := uc.ruleLimitsNotifier.NotifySubscriptionRuleLimitsChange(ctx, []uint{sub.ID()}); err != nil {
+			uc.logger.Warnw("failed to sync forward rule limits after plan change",
+				"subscription_id", sub.ID(),
+				"error", err,
+			)
+			// Don't fail the plan change, rules are synced again on the next full sync
+		}
+	}
+
 	return nil
 }
 
@@ -21,6 +21,13 @@ type SubscriptionChangeNotifier interface {
 	NotifySubscriptionUpdate(ctx context.Context, sub *subscription.Subscription) error
 }
 
+// ForwardRuleLimitsNotifier re-syncs the forward rules of subscriptions to forward agents.
+// Subscription-bound forward rules inherit the speed and connection limits they don't set
+// from the subscription's plan.
+type ForwardRuleLimitsNotifier interface {
+	NotifySubscriptionRuleLimitsChange(ctx context.Context, subscriptionIDs []uint) error
+}
+
 // QuotaCacheManager defines the interface for managing subscription quota cache.
 // This is used to invalidate or update cache when subscription status changes.
 type QuotaCacheManager interface {
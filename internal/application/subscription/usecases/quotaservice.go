@@ -56,6 +56,9 @@ type QuotaService interface {
 	// GetCurrentPeriodUsage returns the total usage for the current billing period.
 	// This method is used for real-time traffic limit checking.
 	GetCurrentPeriodUsage(ctx context.Context, subscriptionID uint, periodStart, periodEnd time.Time) (int64, error)
+
+	// GetCurrentPeriodTraffic returns the upload/download breakdown of GetCurrentPeriodUsage.
+	GetCurrentPeriodTraffic(ctx context.Context, subscriptionID uint, periodStart, periodEnd time.Time) (uint64, uint64, error)
 }
 
 // QuotaServiceImpl implements the QuotaService interface.
@@ -392,6 +395,23 @@ func (s *QuotaServiceImpl) GetCurrentPeriodUsage(
 	return int64(usage), nil
 }
 
+// GetCurrentPeriodTraffic returns the upload and download usage for the current billing period.
+// Like GetCurrentPeriodUsage it aggregates all resource types and applies no usage adjustment.
+// It is used for the Subscription-Userinfo header, which reports upload and download separately.
+func (s *QuotaServiceImpl) GetCurrentPeriodTraffic(
+	ctx context.Context,
+	subscriptionID uint,
+	periodStart time.Time,
+	periodEnd time.Time,
+) (uint64, uint64, error) {
+	// Aggregate all resource types (nil = no filter)
+	_, upload, download, err := s.calculatePeriodUsage(ctx, []uint{subscriptionID}, nil, periodStart, periodEnd)
+	if err != nil {
+		return 0, 0, err
+	}
+	return upload, download, nil
+}
+
 // calculatePeriodUsage calculates total/upload/download usage for subscriptions
 // within a traffic cycle.
 // Uses Redis HourlyTrafficCache for recent data (last 24h) and MySQL subscription_usage_stats
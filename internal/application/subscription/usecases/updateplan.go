@@ -33,6 +33,7 @@ type UpdatePlanUseCase struct {
 	planChangeNotifier   PlanChangeNotifier
 	quotaCacheManager    QuotaCacheManager
 	subscriptionNotifier SubscriptionChangeNotifier
+	ruleLimitsNotifier   ForwardRuleLimitsNotifier
 	logger               logger.Interface
 }
 
@@ -56,6 +57,12 @@ func (uc *UpdatePlanUseCase) SetSubscriptionNotifier(notifier SubscriptionChange
 	uc.subscriptionNotifier = notifier
 }
 
+// SetForwardRuleLimitsNotifier sets the notifier that re-syncs the forward rules of the plan's
+// subscriptions when its speed or connection limit changes.
+func (uc *UpdatePlanUseCase) SetForwardRuleLimitsNotifier(notifier ForwardRuleLimitsNotifier) {
+	uc.ruleLimitsNotifier = notifier
+}
+
 func NewUpdatePlanUseCase(
 	planRepo subscription.PlanRepository,
 	pricingRepo subscription.PlanPricingRepository,
@@ -83,6 +90,7 @@ func (uc *UpdatePlanUseCase) Execute(
 
 	// Capture old traffic reset mode before updating features
 	oldTrafficResetMode := subscription.GetTrafficResetMode(plan)
+	oldSpeedLimit, oldConnectionLimit := planRuleLimits(plan)
 
 	if cmd.Description != nil {
 		plan.UpdateDescription(*cmd.Description)
@@ -135,6 +143,14 @@ func (uc *UpdatePlanUseCase) Execute(
 		uc.invalidateQuotaCacheForPlan(ctx, planID)
 	}
 
+	// Re-sync forward rules inheriting the plan's speed and connection limits
+	if cmd.Limits != nil {
+		speedLimit, connectionLimit := planRuleLimits(plan)
+		if speedLimit != oldSpeedLimit || connectionLimit != oldConnectionLimit {
+			uc.syncForwardRuleLimitsForPlan(ctx, planID)
+		}
+	}
+
 	// Reset subscription usage when traffic reset mode changes
 	if cmd.Limits != nil {
 		newTrafficResetMode := subscription.GetTrafficResetMode(plan)
@@ -222,6 +238,49 @@ func (uc *UpdatePlanUseCase) Execute(
 	return dto.ToPlanDTOWithPricings(updatedPlan, pricings), nil
 }
 
+// planRuleLimits returns the plan's speed and connection limits, which subscription-bound
+// forward rules inherit. Invalid values count as unlimited.
+func planRuleLimits(plan *subscription.Plan) (speedLimit, connectionLimit int) {
+	if plan.Features() == nil {
+		return 0, 0
+	}
+	speedLimit, _ = plan.Features().GetSpeedLimit()
+	connectionLimit, _ = plan.Features().GetConnectionLimit()
+	return speedLimit, connectionLimit
+}
+
+// syncForwardRuleLimitsForPlan re-syncs the forward rules of all active subscriptions on the
+// given plan so entry agents enforce the updated limits.
+func (uc *UpdatePlanUseCase) syncForwardRuleLimitsForPlan(ctx context.Context, planID uint) {
+	if uc.subscriptionRepo == nil || uc.ruleLimitsNotifier == nil {
+		return
+	}
+
+	subs, _, err := uc.subscriptionRepo.List(ctx, subscription.SubscriptionFilter{
+		PlanID:   &planID,
+		Statuses: []string{string(vo.StatusActive), string(vo.StatusTrialing)},
+		Page:     1,
+		PageSize: 10000,
+	})
+	if err != nil {
+		uc.logger.Warnw("failed to list subscriptions for forward rule limits sync",
+			"plan_id", planID, "error", err)
+		return
+	}
+	if len(subs) == 0 {
+		return
+	}
+
+	subIDs := make([]uint, 0, len(subs))
+	for _, sub := range subs {
+		subIDs = append(subIDs, sub.ID())
+	}
+	if err := uc.ruleLimitsNotifier.NotifySubscriptionRuleLimitsChange(ctx, subIDs); err != nil {
+		uc.logger.Warnw("failed to sync forward rule limits for plan",
+			"plan_id", planID, "error", err)
+	}
+}
+
 // invalidateQuotaCacheForPlan invalidates Redis quota cache for all active
 // subscriptions on the given plan so enforcement uses updated limits.
 func (uc *UpdatePlanUseCase) invalidateQuotaCacheForPlan(ctx context.Context, planID uint) {
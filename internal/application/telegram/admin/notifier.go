@@ -35,6 +35,17 @@ type AdminNotifier interface {
 	// NotifyAgentRecovery sends an agent recovery notification to admins
 	// This is called when an agent transitions from Firing state back to Normal
 	NotifyAgentRecovery(ctx context.Context, cmd NotifyAgentRecoveryCommand) error
+
+	// NotifyTunnelUnhealthy sends a forward tunnel unhealthy notification to admins
+	NotifyTunnelUnhealthy(ctx context.Context, cmd NotifyTunnelUnhealthyCommand) error
+
+	// NotifyTunnelRecovery sends a forward tunnel recovery notification to admins
+	// This is called when an unhealthy tunnel reports healthy again
+	NotifyTunnelRecovery(ctx context.Context, cmd NotifyTunnelRecoveryCommand) error
+
+	// NotifyWireGuardPoolExhausted sends a WireGuard address pool exhausted notification to admins
+	// This is called when subscriptions cannot get a tunnel address on a WireGuard node
+	NotifyWireGuardPoolExhausted(ctx context.Context, cmd NotifyWireGuardPoolExhaustedCommand) error
 }
 
 // NotifyNewUserCommand contains data for new user notification
@@ -110,6 +121,16 @@ type NotifyNodeRecoveryCommand struct {
 	MuteNotification bool // if true, skip sending notification
 }
 
+// NotifyWireGuardPoolExhaustedCommand contains data for WireGuard address pool exhausted notification
+type NotifyWireGuardPoolExhaustedCommand struct {
+	NodeSID     string
+	NodeName    string
+	AddressPool string
+	Capacity    int // peer addresses in the pool
+	Pending     int // subscriptions left without an address
+	DetectedAt  time.Time
+}
+
 // NotifyAgentRecoveryCommand contains data for agent recovery notification
 // This is sent when an agent transitions from Firing state back to Normal
 type NotifyAgentRecoveryCommand struct {
@@ -121,6 +142,36 @@ type NotifyAgentRecoveryCommand struct {
 	MuteNotification bool // if true, skip sending notification
 }
 
+// NotifyTunnelUnhealthyCommand contains data for forward tunnel unhealthy notification
+// The tunnel is from the rule's entry agent to one of its exit agents
+type NotifyTunnelUnhealthyCommand struct {
+	RuleSID          string
+	RuleName         string
+	EntryAgentSID    string
+	EntryAgentName   string
+	ExitAgentSID     string
+	ExitAgentName    string
+	FailCount        int
+	Error            string
+	Demoted          bool // whether the exit agent was demoted to a backup
+	UnhealthyAt      time.Time
+	MuteNotification bool // if true, skip sending notification
+}
+
+// NotifyTunnelRecoveryCommand contains data for forward tunnel recovery notification
+type NotifyTunnelRecoveryCommand struct {
+	RuleSID          string
+	RuleName         string
+	EntryAgentSID    string
+	EntryAgentName   string
+	ExitAgentSID     string
+	ExitAgentName    string
+	Restored         bool // whether the exit agent's demotion was lifted
+	RecoveredAt      time.Time
+	DowntimeMinutes  int64
+	MuteNotification bool // if true, skip sending notification
+}
+
 // NoopAdminNotifier is a no-op implementation of AdminNotifier
 // Used when admin notification is not configured
 type NoopAdminNotifier struct {
@@ -171,3 +222,18 @@ func (n *NoopAdminNotifier) NotifyAgentRecovery(ctx context.Context, cmd NotifyA
 	n.logger.Debugw("admin notification skipped (not configured)", "type", "agent_recovery", "agent_sid", cmd.AgentSID)
 	return nil
 }
+
+func (n *NoopAdminNotifier) NotifyTunnelUnhealthy(ctx context.Context, cmd NotifyTunnelUnhealthyCommand) error {
+	n.logger.Debugw("admin notification skipped (not configured)", "type", "tunnel_unhealthy", "rule_sid", cmd.RuleSID, "exit_agent_sid", cmd.ExitAgentSID)
+	return nil
+}
+
+func (n *NoopAdminNotifier) NotifyTunnelRecovery(ctx context.Context, cmd NotifyTunnelRecoveryCommand) error {
+	n.logger.Debugw("admin notification skipped (not configured)", "type", "tunnel_recovery", "rule_sid", cmd.RuleSID, "exit_agent_sid", cmd.ExitAgentSID)
+	return nil
+}
+
+func (n *NoopAdminNotifier) NotifyWireGuardPoolExhausted(ctx context.Context, cmd NotifyWireGuardPoolExhaustedCommand) error {
+	n.logger.Debugw("admin notification skipped (not configured)", "type", "wireguard_pool_exhausted", "node_sid", cmd.NodeSID)
+	return nil
+}
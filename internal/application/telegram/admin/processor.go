@@ -93,6 +93,16 @@ func NewAdminNotificationProcessor(
 	}
 }
 
+// SetNodeMaintenanceChecker mutes node offline alerts for nodes inside a running maintenance window
+func (p *AdminNotificationProcessor) SetNodeMaintenanceChecker(checker usecases.NodeMaintenanceChecker) {
+	p.checkOfflineUC.SetMaintenanceChecker(checker)
+}
+
+// SetCertificateExpiryFinder adds expiring node TLS certificates to the expiring resource alerts
+func (p *AdminNotificationProcessor) SetCertificateExpiryFinder(finder usecases.CertificateExpiryFinder) {
+	p.checkExpiringUC.SetCertificateExpiryFinder(finder)
+}
+
 // CheckOffline checks for offline nodes and agents, sends alerts
 func (p *AdminNotificationProcessor) CheckOffline(ctx context.Context) error {
 	return p.checkOfflineUC.CheckAndNotify(ctx)
@@ -431,3 +431,164 @@ func (s *ServiceDDD) NotifyAgentRecovery(ctx context.Context, cmd NotifyAgentRec
 
 	return nil
 }
+
+// NotifyTunnelUnhealthy implements AdminNotifier interface
+func (s *ServiceDDD) NotifyTunnelUnhealthy(ctx context.Context, cmd NotifyTunnelUnhealthyCommand) error {
+	if s.botService == nil {
+		s.logger.Debugw("admin notification skipped: bot service not available", "type", "tunnel_unhealthy")
+		return nil
+	}
+
+	// Skip if notification is muted for the exit agent
+	if cmd.MuteNotification {
+		s.logger.Debugw("tunnel unhealthy notification skipped: muted",
+			"rule_sid", cmd.RuleSID,
+			"exit_agent_sid", cmd.ExitAgentSID,
+		)
+		return nil
+	}
+
+	// Tunnel alerts go to the admins subscribed to forward agent alerts
+	bindings, err := s.bindingRepo.FindBindingsForAgentOfflineNotification(ctx)
+	if err != nil {
+		s.logger.Errorw("failed to find bindings for tunnel unhealthy notification", "error", err)
+		return err
+	}
+
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	for i, binding := range bindings {
+		lang := i18n.ParseLang(binding.Language())
+		message := i18n.BuildTunnelUnhealthyMessage(lang, i18n.TunnelAlertInfo{
+			RuleSID:        cmd.RuleSID,
+			RuleName:       cmd.RuleName,
+			EntryAgentSID:  cmd.EntryAgentSID,
+			EntryAgentName: cmd.EntryAgentName,
+			ExitAgentSID:   cmd.ExitAgentSID,
+			ExitAgentName:  cmd.ExitAgentName,
+		}, cmd.FailCount, cmd.Error, cmd.Demoted, cmd.UnhealthyAt)
+		keyboard := i18n.BuildMuteKeyboard(lang, "agent", cmd.ExitAgentSID)
+		if err := s.botService.SendMessageWithInlineKeyboard(binding.TelegramUserID(), message, keyboard); err != nil {
+			if telegram.IsBotBlocked(err) {
+				s.logger.Warnw("bot blocked by user, skipping notification",
+					"telegram_user_id", binding.TelegramUserID())
+				continue
+			}
+			s.logger.Errorw("failed to send tunnel unhealthy notification",
+				"telegram_user_id", binding.TelegramUserID(),
+				"error", err,
+			)
+			continue
+		}
+		// Rate limiting: add delay between messages to avoid Telegram API throttling
+		if i < len(bindings)-1 {
+			time.Sleep(messageSendDelay)
+		}
+	}
+
+	return nil
+}
+
+// NotifyTunnelRecovery implements AdminNotifier interface
+// This is called when an unhealthy tunnel reports healthy again
+func (s *ServiceDDD) NotifyTunnelRecovery(ctx context.Context, cmd NotifyTunnelRecoveryCommand) error {
+	if s.botService == nil {
+		s.logger.Debugw("admin notification skipped: bot service not available", "type", "tunnel_recovery")
+		return nil
+	}
+
+	// Skip if notification is muted for the exit agent
+	if cmd.MuteNotification {
+		s.logger.Debugw("tunnel recovery notification skipped: muted",
+			"rule_sid", cmd.RuleSID,
+			"exit_agent_sid", cmd.ExitAgentSID,
+		)
+		return nil
+	}
+
+	// Use the same bindings as unhealthy notification (recovery is the counterpart)
+	bindings, err := s.bindingRepo.FindBindingsForAgentOfflineNotification(ctx)
+	if err != nil {
+		s.logger.Errorw("failed to find bindings for tunnel recovery notification", "error", err)
+		return err
+	}
+
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	for i, binding := range bindings {
+		lang := i18n.ParseLang(binding.Language())
+		message := i18n.BuildTunnelRecoveryMessage(lang, i18n.TunnelAlertInfo{
+			RuleSID:        cmd.RuleSID,
+			RuleName:       cmd.RuleName,
+			EntryAgentSID:  cmd.EntryAgentSID,
+			EntryAgentName: cmd.EntryAgentName,
+			ExitAgentSID:   cmd.ExitAgentSID,
+			ExitAgentName:  cmd.ExitAgentName,
+		}, cmd.Restored, cmd.RecoveredAt, cmd.DowntimeMinutes)
+		if err := s.botService.SendMessageSilent(binding.TelegramUserID(), message); err != nil {
+			if telegram.IsBotBlocked(err) {
+				s.logger.Warnw("bot blocked by user, skipping notification",
+					"telegram_user_id", binding.TelegramUserID())
+				continue
+			}
+			s.logger.Errorw("failed to send tunnel recovery notification",
+				"telegram_user_id", binding.TelegramUserID(),
+				"error", err,
+			)
+			continue
+		}
+		// Rate limiting: add delay between messages to avoid Telegram API throttling
+		if i < len(bindings)-1 {
+			time.Sleep(messageSendDelay)
+		}
+	}
+
+	return nil
+}
+
+// NotifyWireGuardPoolExhausted implements AdminNotifier interface
+// This is called when subscriptions cannot get a tunnel address on a WireGuard node
+func (s *ServiceDDD) NotifyWireGuardPoolExhausted(ctx context.Context, cmd NotifyWireGuardPoolExhaustedCommand) error {
+	if s.botService == nil {
+		s.logger.Debugw("admin notification skipped: bot service not available", "type", "wireguard_pool_exhausted")
+		return nil
+	}
+
+	// Pool alerts go to the admins subscribed to node alerts
+	bindings, err := s.bindingRepo.FindBindingsForNodeOfflineNotification(ctx)
+	if err != nil {
+		s.logger.Errorw("failed to find bindings for wireguard pool exhausted notification", "error", err)
+		return err
+	}
+
+	if len(bindings) == 0 {
+		return nil
+	}
+
+	for i, binding := range bindings {
+		lang := i18n.ParseLang(binding.Language())
+		message := i18n.BuildWireGuardPoolExhaustedMessage(lang, cmd.NodeSID, cmd.NodeName, cmd.AddressPool, cmd.Capacity, cmd.Pending, cmd.DetectedAt)
+		if err := s.botService.SendMessage(binding.TelegramUserID(), message); err != nil {
+			if telegram.IsBotBlocked(err) {
+				s.logger.Warnw("bot blocked by user, skipping notification",
+					"telegram_user_id", binding.TelegramUserID())
+				continue
+			}
+			s.logger.Errorw("failed to send wireguard pool exhausted notification",
+				"telegram_user_id", binding.TelegramUserID(),
+				"error", err,
+			)
+			continue
+		}
+		// Rate limiting: add delay between messages to avoid Telegram API throttling
+		if i < len(bindings)-1 {
+			time.Sleep(messageSendDelay)
+		}
+	}
+
+	return nil
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"time"
+	"unicode/utf8"
 
 	"github.com/orris-inc/orris/internal/domain/forward"
 	"github.com/orris-inc/orris/internal/domain/node"
@@ -14,13 +15,22 @@ import (
 	"github.com/orris-inc/orris/internal/shared/logger"
 )
 
+// maxCertificateErrorInAlert limits the issuance error shown per certificate in an alert
+const maxCertificateErrorInAlert = 200
+
+// CertificateExpiryFinder finds node TLS certificates that expire within a number of days.
+type CertificateExpiryFinder interface {
+	FindExpiring(ctx context.Context, days int) ([]*node.Certificate, error)
+}
+
 // CheckExpiringUseCase handles resource expiration detection and notification
 type CheckExpiringUseCase struct {
-	bindingRepo admin.AdminTelegramBindingRepository
-	nodeRepo    node.NodeRepository
-	agentRepo   forward.AgentRepository
-	botService  TelegramMessageSender
-	logger      logger.Interface
+	bindingRepo       admin.AdminTelegramBindingRepository
+	nodeRepo          node.NodeRepository
+	agentRepo         forward.AgentRepository
+	botService        TelegramMessageSender
+	certificateFinder CertificateExpiryFinder
+	logger            logger.Interface
 }
 
 // NewCheckExpiringUseCase creates a new CheckExpiringUseCase
@@ -40,6 +50,11 @@ func NewCheckExpiringUseCase(
 	}
 }
 
+// SetCertificateExpiryFinder enables alerts for expiring node TLS certificates.
+func (uc *CheckExpiringUseCase) SetCertificateExpiryFinder(finder CertificateExpiryFinder) {
+	uc.certificateFinder = finder
+}
+
 // CheckAndNotify checks for expiring resources and sends notifications
 func (uc *CheckExpiringUseCase) CheckAndNotify(ctx context.Context) error {
 	if uc.botService == nil {
@@ -84,8 +99,19 @@ func (uc *CheckExpiringUseCase) CheckAndNotify(ctx context.Context) error {
 			continue
 		}
 
+		// Find expiring node certificates within the configured days threshold
+		var expiringCerts []*node.Certificate
+		if uc.certificateFinder != nil {
+			expiringCerts, err = uc.certificateFinder.FindExpiring(ctx, binding.ResourceExpiringDays())
+			if err != nil {
+				uc.logger.Errorw("failed to find expiring certificates", "error", err)
+				errors++
+				continue
+			}
+		}
+
 		// Skip if no expiring resources
-		if len(expiringAgents) == 0 && len(expiringNodes) == 0 {
+		if len(expiringAgents) == 0 && len(expiringNodes) == 0 && len(expiringCerts) == 0 {
 			uc.logger.Debugw("no expiring resources found",
 				"binding_id", binding.ID(),
 				"threshold_days", binding.ResourceExpiringDays(),
@@ -119,14 +145,39 @@ func (uc *CheckExpiringUseCase) CheckAndNotify(ctx context.Context) error {
 			})
 		}
 
-		// Build and send message
+		certInfos := make([]i18n.ExpiringCertificateInfo, 0, len(expiringCerts))
+		for _, c := range expiringCerts {
+			certInfos = append(certInfos, i18n.ExpiringCertificateInfo{
+				SID:           c.SID(),
+				Domain:        c.Domain(),
+				ExpiresAt:     *c.NotAfter(),
+				DaysRemaining: calculateDaysRemaining(now, *c.NotAfter()),
+				Expired:       c.IsExpired(now),
+				AutoRenew:     c.AutoRenew(),
+				LastError:     truncateAlertText(c.LastError(), maxCertificateErrorInAlert),
+			})
+		}
+
+		// Build and send messages
 		lang := i18n.ParseLang(binding.Language())
-		message := i18n.BuildResourceExpiringMessage(lang, agentInfos, nodeInfos)
-		if message == "" {
+		var messages []string
+		if message := i18n.BuildResourceExpiringMessage(lang, agentInfos, nodeInfos); message != "" {
+			messages = append(messages, message)
+		}
+		if message := i18n.BuildCertificateExpiringMessage(lang, certInfos); message != "" {
+			messages = append(messages, message)
+		}
+		if len(messages) == 0 {
 			continue
 		}
 
-		if err := uc.botService.SendMessage(binding.TelegramUserID(), message); err != nil {
+		var sendErr error
+		for _, message := range messages {
+			if sendErr = uc.botService.SendMessage(binding.TelegramUserID(), message); sendErr != nil {
+				break
+			}
+		}
+		if err := sendErr; err != nil {
 			if telegram.IsBotBlocked(err) {
 				uc.logger.Warnw("bot blocked by user, skipping notification",
 					"telegram_user_id", binding.TelegramUserID())
@@ -160,6 +211,7 @@ func (uc *CheckExpiringUseCase) CheckAndNotify(ctx context.Context) error {
 			"telegram_user_id", binding.TelegramUserID(),
 			"expiring_agents", len(agentInfos),
 			"expiring_nodes", len(nodeInfos),
+			"expiring_certificates", len(certInfos),
 		)
 	}
 
@@ -191,3 +243,15 @@ func calculateDaysRemaining(now, expiresAt time.Time) int {
 	}
 	return days
 }
+
+// truncateAlertText shortens s to at most maxLen bytes without splitting a UTF-8 character.
+func truncateAlertText(s string, maxLen int) string {
+	if len(s) <= maxLen {
+		return s
+	}
+	cut := maxLen
+	for cut > 0 && !utf8.RuneStart(s[cut]) {
+		cut--
+	}
+	return s[:cut] + "..."
+}
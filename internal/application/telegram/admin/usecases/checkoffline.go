@@ -38,14 +38,21 @@ type AgentOfflineChecker interface {
 	FindOfflineAgents(ctx context.Context, threshold time.Duration) ([]*forward.ForwardAgent, error)
 }
 
+// NodeMaintenanceChecker reports the nodes covered by a running maintenance window.
+// Offline alerts for these nodes are muted until the window ends.
+type NodeMaintenanceChecker interface {
+	MaintenanceNodeIDs(ctx context.Context) (map[uint]struct{}, error)
+}
+
 // CheckOfflineUseCase handles offline detection and alerting for nodes and agents
 type CheckOfflineUseCase struct {
-	bindingRepo       admin.AdminTelegramBindingRepository
-	nodeRepo          node.NodeRepository
-	agentRepo         forward.AgentRepository
-	alertStateManager *cache.AlertStateManager
-	botService        TelegramMessageSender
-	logger            logger.Interface
+	bindingRepo        admin.AdminTelegramBindingRepository
+	nodeRepo           node.NodeRepository
+	agentRepo          forward.AgentRepository
+	alertStateManager  *cache.AlertStateManager
+	botService         TelegramMessageSender
+	maintenanceChecker NodeMaintenanceChecker
+	logger             logger.Interface
 }
 
 // NewCheckOfflineUseCase creates a new CheckOfflineUseCase
@@ -67,6 +74,11 @@ func NewCheckOfflineUseCase(
 	}
 }
 
+// SetMaintenanceChecker sets the checker used to mute node alerts during maintenance windows.
+func (uc *CheckOfflineUseCase) SetMaintenanceChecker(checker NodeMaintenanceChecker) {
+	uc.maintenanceChecker = checker
+}
+
 // CheckAndNotify checks for offline nodes and agents, then sends alerts.
 // Uses per-binding OfflineCheckIntervalMinutes for repeated notifications
 // while a resource remains offline.
@@ -127,6 +139,7 @@ func (uc *CheckOfflineUseCase) checkNodeOffline(ctx context.Context) (int, int)
 	}
 
 	now := biztime.NowUTC()
+	maintenanceNodeIDs := uc.findMaintenanceNodeIDs(ctx)
 
 	for _, nodeInfo := range offlineNodes {
 		// Skip if notification is muted for this node
@@ -138,6 +151,15 @@ func (uc *CheckOfflineUseCase) checkNodeOffline(ctx context.Context) (int, int)
 			continue
 		}
 
+		// Skip nodes inside a running maintenance window
+		if _, ok := maintenanceNodeIDs[nodeInfo.ID]; ok {
+			uc.logger.Debugw("node offline notification skipped: under maintenance",
+				"node_sid", nodeInfo.SID,
+				"node_name", nodeInfo.Name,
+			)
+			continue
+		}
+
 		// Atomically transition to Firing state
 		// Returns true only if this is a new firing (state changed from Normal to Firing)
 		isNewFiring, err := uc.alertStateManager.TransitionToFiring(ctx, cache.AlertResourceTypeNode, nodeInfo.ID, now)
@@ -387,6 +409,20 @@ func (uc *CheckOfflineUseCase) findOfflineNodes(ctx context.Context, threshold t
 	return offlineNodes, nil
 }
 
+// findMaintenanceNodeIDs returns the nodes covered by a running maintenance window.
+// Lookup errors are logged and treated as "no maintenance" so alerts are never silently lost.
+func (uc *CheckOfflineUseCase) findMaintenanceNodeIDs(ctx context.Context) map[uint]struct{} {
+	if uc.maintenanceChecker == nil {
+		return nil
+	}
+	ids, err := uc.maintenanceChecker.MaintenanceNodeIDs(ctx)
+	if err != nil {
+		uc.logger.Warnw("failed to get maintenance nodes, offline alerts are not muted", "error", err)
+		return nil
+	}
+	return ids
+}
+
 func (uc *CheckOfflineUseCase) findOfflineAgents(ctx context.Context, threshold time.Duration) ([]dto.OfflineAgentInfo, error) {
 	now := biztime.NowUTC()
 	cutoff := now.Add(-threshold)
@@ -523,6 +523,15 @@ func (a *ForwardAgent) IsPortAllowed(port uint16) bool {
 	return a.allowedPortRange.Contains(port)
 }
 
+// PortPool returns the ports listen ports are auto-assigned from: the allowed port
+// range, or the default pool if all ports are allowed.
+func (a *ForwardAgent) PortPool() *vo.PortRange {
+	if a.allowedPortRange.IsEmpty() {
+		return vo.DefaultPortPool()
+	}
+	return a.allowedPortRange
+}
+
 // BlockedProtocols returns the blocked protocols configuration
 func (a *ForwardAgent) BlockedProtocols() vo.BlockedProtocols {
 	return a.blockedProtocols
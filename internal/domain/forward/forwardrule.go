@@ -44,6 +44,8 @@ type ForwardRule struct {
 	groupIDs            []uint               // resource group IDs for access control
 	routeConfig         *routing.RouteConfig  // per-rule routing configuration (sing-box route rules)
 	addressPreference   vo.AddressPreference  // which address to use for next hop: auto, public, tunnel
+	limits              vo.RuleLimits         // bandwidth and connection limits (zero = inherited from plan or unlimited)
+	acl                 *vo.RuleACL           // source IP access control list (nil = all sources accepted)
 	// External rule fields (used when ruleType = external)
 	serverAddress  string // server address for external rules (replaces agent's public address)
 	externalSource string // external source identifier (required for external rules)
@@ -259,6 +261,8 @@ func ReconstructForwardRule(
 	groupIDs []uint,
 	routeConfig *routing.RouteConfig,
 	addressPreference vo.AddressPreference,
+	limits vo.RuleLimits,
+	acl *vo.RuleACL,
 	serverAddress string,
 	externalSource string,
 	externalRuleID string,
@@ -353,6 +357,8 @@ func ReconstructForwardRule(
 		groupIDs:            groupIDs,
 		routeConfig:         routeConfig,
 		addressPreference:   addressPreference,
+		limits:              limits,
+		acl:                 acl,
 		serverAddress:       serverAddress,
 		externalSource:      externalSource,
 		externalRuleID:      externalRuleID,
@@ -527,6 +533,16 @@ func (r *ForwardRule) AddressPreference() vo.AddressPreference {
 	return r.addressPreference
 }
 
+// Limits returns the bandwidth and connection limits configured on the rule.
+func (r *ForwardRule) Limits() vo.RuleLimits {
+	return r.limits
+}
+
+// ACL returns the source IP access control list, or nil if all sources are accepted.
+func (r *ForwardRule) ACL() *vo.RuleACL {
+	return r.acl
+}
+
 // ServerAddress returns the server address for external rules.
 func (r *ForwardRule) ServerAddress() string {
 	return r.serverAddress
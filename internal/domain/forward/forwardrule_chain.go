@@ -37,6 +37,43 @@ func (r *ForwardRule) GetAllExitAgentIDs() []uint {
 	return nil
 }
 
+// CanDemoteExitAgent returns true if the exit agent can be demoted to a backup because
+// its tunnel is unhealthy. An exit can only be demoted when it belongs to a multi-exit rule,
+// is not already a backup, and at least one other exit still takes traffic.
+func (r *ForwardRule) CanDemoteExitAgent(exitAgentID uint, demoted map[uint]bool) bool {
+	found := false
+	remaining := 0
+	for _, aw := range r.exitAgents {
+		if aw.AgentID() == exitAgentID {
+			found = aw.Weight() > 0
+			continue
+		}
+		if aw.Weight() > 0 && !demoted[aw.AgentID()] {
+			remaining++
+		}
+	}
+	return found && remaining > 0
+}
+
+// EffectiveExitAgents returns the exit agents with demoted exits as backups (weight 0).
+func (r *ForwardRule) EffectiveExitAgents(demoted map[uint]bool) []vo.AgentWeight {
+	if len(demoted) == 0 {
+		return r.exitAgents
+	}
+
+	result := make([]vo.AgentWeight, len(r.exitAgents))
+	for i, aw := range r.exitAgents {
+		result[i] = aw
+		if demoted[aw.AgentID()] {
+			// Only fails for a zero agent ID, which a stored rule never has
+			if backup, err := vo.NewAgentWeight(aw.AgentID(), 0); err == nil {
+				result[i] = backup
+			}
+		}
+	}
+	return result
+}
+
 // ChainAgentIDs returns the chain agent IDs (for chain type rules).
 func (r *ForwardRule) ChainAgentIDs() []uint {
 	return r.chainAgentIDs
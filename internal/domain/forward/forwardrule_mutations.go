@@ -252,8 +252,8 @@ func (r *ForwardRule) UpdateExitAgents(exitAgents []vo.AgentWeight) error {
 			return fmt.Errorf("exit agent cannot be the same as entry agent")
 		}
 	}
-	// Validate weighted strategy requires at least one non-backup agent
-	if r.loadBalanceStrategy.IsWeighted() {
+	// Validate strategies that spread traffic require at least one non-backup agent
+	if r.loadBalanceStrategy.RequiresActiveAgent() {
 		hasNonBackup := false
 		for _, aw := range exitAgents {
 			if !aw.IsBackup() {
@@ -262,7 +262,7 @@ func (r *ForwardRule) UpdateExitAgents(exitAgents []vo.AgentWeight) error {
 			}
 		}
 		if !hasNonBackup {
-			return fmt.Errorf("weighted strategy requires at least one exit agent with non-zero weight")
+			return fmt.Errorf("%s strategy requires at least one exit agent with non-zero weight", r.loadBalanceStrategy)
 		}
 	}
 	// Clear single exitAgentID when switching to multiple exit agents
@@ -283,8 +283,8 @@ func (r *ForwardRule) UpdateLoadBalanceStrategy(strategy vo.LoadBalanceStrategy)
 	if r.loadBalanceStrategy == strategy {
 		return nil
 	}
-	// Validate weighted strategy requires at least one non-backup agent
-	if strategy.IsWeighted() && len(r.exitAgents) > 0 {
+	// Validate strategies that spread traffic require at least one non-backup agent
+	if strategy.RequiresActiveAgent() && len(r.exitAgents) > 0 {
 		hasNonBackup := false
 		for _, aw := range r.exitAgents {
 			if !aw.IsBackup() {
@@ -293,7 +293,7 @@ func (r *ForwardRule) UpdateLoadBalanceStrategy(strategy vo.LoadBalanceStrategy)
 			}
 		}
 		if !hasNonBackup {
-			return fmt.Errorf("weighted strategy requires at least one exit agent with non-zero weight")
+			return fmt.Errorf("%s strategy requires at least one exit agent with non-zero weight", strategy)
 		}
 	}
 	r.loadBalanceStrategy = strategy
@@ -593,6 +593,36 @@ func (r *ForwardRule) UpdateAddressPreference(pref vo.AddressPreference) error {
 	return nil
 }
 
+// UpdateLimits updates the bandwidth and connection limits.
+func (r *ForwardRule) UpdateLimits(limits vo.RuleLimits) error {
+	if err := limits.Validate(); err != nil {
+		return err
+	}
+	if r.limits == limits {
+		return nil
+	}
+	r.limits = limits
+	r.updatedAt = biztime.NowUTC()
+	return nil
+}
+
+// UpdateACL validates and updates the source IP access control list.
+// An empty ACL is stored as nil (all sources accepted).
+func (r *ForwardRule) UpdateACL(acl *vo.RuleACL) error {
+	if acl.IsEmpty() {
+		acl = nil
+	}
+	if err := acl.Validate(); err != nil {
+		return fmt.Errorf("invalid acl: %w", err)
+	}
+	if r.acl.Equals(acl) {
+		return nil
+	}
+	r.acl = acl
+	r.updatedAt = biztime.NowUTC()
+	return nil
+}
+
 // SetGroupIDs sets the resource group IDs.
 func (r *ForwardRule) SetGroupIDs(groupIDs []uint) {
 	r.groupIDs = groupIDs
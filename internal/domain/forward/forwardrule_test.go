@@ -1734,6 +1734,8 @@ func TestForwardRule_Validate_RejectsInvalidRuleType(t *testing.T) {
 		nil,        // groupIDs
 		nil,                          // routeConfig
 		vo.AddressPreferenceAuto,     // addressPreference
+		vo.RuleLimits{},              // limits
+		nil,                          // acl
 		"", "", "",                   // serverAddress, externalSource, externalRuleID
 		time.Now(), time.Now(),
 	)
@@ -2356,7 +2358,127 @@ func TestGetRawBytes(t *testing.T) {
 	}
 }
 
+// =============================================================================
+// Tunnel Health Failover Tests
+// =============================================================================
+
+// newMultiExitRule creates an entry rule with exits 2 (weight 50), 3 (weight 50) and 4 (backup).
+func newMultiExitRule(t *testing.T) *ForwardRule {
+	t.Helper()
+	var exits []vo.AgentWeight
+	for _, w := range []struct {
+		id     uint
+		weight uint16
+	}{{2, 50}, {3, 50}, {4, 0}} {
+		aw, err := vo.NewAgentWeight(w.id, w.weight)
+		if err != nil {
+			t.Fatalf("NewAgentWeight() unexpected error = %v", err)
+		}
+		exits = append(exits, aw)
+	}
+
+	params := validEntryRuleParams()
+	params.ExitAgentID = 0
+	params.ExitAgents = exits
+	rule, err := newTestForwardRule(params)
+	if err != nil {
+		t.Fatalf("NewForwardRule() unexpected error = %v", err)
+	}
+	return rule
+}
+
+// TestForwardRule_CanDemoteExitAgent verifies that an exit is only demoted while
+// another exit still takes traffic.
+func TestForwardRule_CanDemoteExitAgent(t *testing.T) {
+	rule := newMultiExitRule(t)
+
+	tests := []struct {
+		name    string
+		exitID  uint
+		demoted map[uint]bool
+		want    bool
+	}{
+		{"other exit active", 2, nil, true},
+		{"other exit demoted", 2, map[uint]bool{3: true}, false},
+		{"backup exit", 4, nil, false},
+		{"unknown exit", 9, nil, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := rule.CanDemoteExitAgent(tt.exitID, tt.demoted); got != tt.want {
+				t.Errorf("CanDemoteExitAgent() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+
+	single, err := newTestForwardRule(validEntryRuleParams())
+	if err != nil {
+		t.Fatalf("NewForwardRule() unexpected error = %v", err)
+	}
+	if single.CanDemoteExitAgent(single.ExitAgentID(), nil) {
+		t.Error("CanDemoteExitAgent() = true for a single-exit rule, want false")
+	}
+}
+
+// TestForwardRule_EffectiveExitAgents verifies that demoted exits become backups
+// without changing the configured weights.
+func TestForwardRule_EffectiveExitAgents(t *testing.T) {
+	rule := newMultiExitRule(t)
+
+	effective := rule.EffectiveExitAgents(map[uint]bool{2: true})
+	want := map[uint]uint16{2: 0, 3: 50, 4: 0}
+	for _, aw := range effective {
+		if aw.Weight() != want[aw.AgentID()] {
+			t.Errorf("EffectiveExitAgents() weight of %d = %d, want %d", aw.AgentID(), aw.Weight(), want[aw.AgentID()])
+		}
+	}
+	if rule.ExitAgents()[0].Weight() != 50 {
+		t.Errorf("ExitAgents() weight changed to %d, want 50", rule.ExitAgents()[0].Weight())
+	}
+}
+
 // floatPtr is a helper function to create a pointer to a float64.
 func floatPtr(f float64) *float64 {
 	return &f
 }
+
+// TestLoadBalanceMetrics_SelectPreferredExit verifies metric-based exit selection,
+// including the tolerance that keeps the current exit on small differences.
+func TestLoadBalanceMetrics_SelectPreferredExit(t *testing.T) {
+	exits := newMultiExitRule(t).ExitAgents() // 2:50, 3:50, 4:0 (backup)
+	latency := func(ms int64) *int64 { return &ms }
+	conns := func(n int) *int { return &n }
+
+	metrics := &LoadBalanceMetrics{Exits: map[uint]ExitMetrics{
+		2: {LatencyMs: latency(120), Connections: conns(40)},
+		3: {LatencyMs: latency(90), Connections: conns(35)},
+		4: {LatencyMs: latency(10), Connections: conns(0)},
+	}}
+
+	tests := []struct {
+		name     string
+		strategy vo.LoadBalanceStrategy
+		current  uint
+		want     uint
+	}{
+		{"lowest latency ignores backups", vo.LoadBalanceStrategyLowestLatency, 0, 3},
+		{"lowest latency switches beyond tolerance", vo.LoadBalanceStrategyLowestLatency, 2, 3},
+		{"least connections keeps current within tolerance", vo.LoadBalanceStrategyLeastConnections, 2, 2},
+		{"least connections picks fewest without current", vo.LoadBalanceStrategyLeastConnections, 0, 3},
+		{"weighted does not use metrics", vo.LoadBalanceStrategyWeighted, 2, 0},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := metrics.SelectPreferredExit(tt.strategy, exits, tt.current); got != tt.want {
+				t.Errorf("SelectPreferredExit() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+
+	// Exits without metrics are skipped
+	partial := &LoadBalanceMetrics{Exits: map[uint]ExitMetrics{3: {}}}
+	if got := partial.SelectPreferredExit(vo.LoadBalanceStrategyLowestLatency, exits, 3); got != 0 {
+		t.Errorf("SelectPreferredExit() without metrics = %d, want 0", got)
+	}
+}
@@ -37,6 +37,11 @@ func (r *ForwardRule) Validate() error {
 		}
 	}
 
+	// Validate limits
+	if err := r.limits.Validate(); err != nil {
+		return err
+	}
+
 	// Validate IP version
 	if !r.ipVersion.IsValid() {
 		return fmt.Errorf("invalid IP version: %s", r.ipVersion)
@@ -57,6 +62,11 @@ func (r *ForwardRule) Validate() error {
 		return fmt.Errorf("invalid address preference: %s", r.addressPreference)
 	}
 
+	// Validate ACL if present
+	if err := r.acl.Validate(); err != nil {
+		return fmt.Errorf("invalid acl: %w", err)
+	}
+
 	// Validate route config if present
 	if r.routeConfig != nil {
 		if err := r.routeConfig.Validate(); err != nil {
@@ -112,8 +122,8 @@ func (r *ForwardRule) Validate() error {
 					return fmt.Errorf("exit agent cannot be the same as entry agent")
 				}
 			}
-			// Validate weighted strategy requires at least one non-backup agent
-			if r.loadBalanceStrategy.IsWeighted() {
+			// Validate strategies that spread traffic require at least one non-backup agent
+			if r.loadBalanceStrategy.RequiresActiveAgent() {
 				hasNonBackup := false
 				for _, aw := range r.exitAgents {
 					if !aw.IsBackup() {
@@ -122,7 +132,7 @@ func (r *ForwardRule) Validate() error {
 					}
 				}
 				if !hasNonBackup {
-					return fmt.Errorf("weighted strategy requires at least one exit agent with non-zero weight")
+					return fmt.Errorf("%s strategy requires at least one exit agent with non-zero weight", r.loadBalanceStrategy)
 				}
 			}
 		}
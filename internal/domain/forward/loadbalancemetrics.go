@@ -0,0 +1,101 @@
+package forward
+
+import (
+	"time"
+
+	vo "github.com/orris-inc/orris/internal/domain/forward/valueobjects"
+)
+
+const (
+	// LoadBalanceLatencyToleranceMs is how much lower another exit's latency must be before
+	// the lowest_latency strategy moves traffic away from the current exit.
+	LoadBalanceLatencyToleranceMs int64 = 20
+
+	// LoadBalanceConnectionTolerance is how many fewer connections another exit must have
+	// before the least_connections strategy moves traffic away from the current exit.
+	LoadBalanceConnectionTolerance = 10
+
+	// LoadBalanceMetricsMaxAge is how long collected exit metrics stay usable. Entry agents
+	// fall back to weights once metrics are older.
+	LoadBalanceMetricsMaxAge = 15 * time.Minute
+)
+
+// ExitMetrics holds the metrics of one exit agent used by metric-based load balancing.
+type ExitMetrics struct {
+	LatencyMs   *int64 // Probed entry-to-target latency through the exit, nil if the probe failed
+	Connections *int   // Active connections on the exit agent across all rules, nil if unknown
+}
+
+// LoadBalanceMetrics holds the exit metrics of a multi-exit rule collected by the server,
+// together with the exit the rule's strategy currently prefers.
+type LoadBalanceMetrics struct {
+	Exits         map[uint]ExitMetrics
+	PreferredExit uint      // 0 when no exit has usable metrics
+	UpdatedAt     time.Time // When the metrics were collected
+	SyncedAt      time.Time // When the entry agent was last re-synced because of the metrics
+}
+
+// NeedsResync returns true if the entry agent should be re-synced so that the metrics it
+// holds do not go stale. Agents are re-synced halfway through LoadBalanceMetricsMaxAge.
+func (m *LoadBalanceMetrics) NeedsResync(now time.Time) bool {
+	return now.Sub(m.SyncedAt) >= LoadBalanceMetricsMaxAge/2
+}
+
+// SelectPreferredExit returns the exit the strategy prefers among the non-backup exit agents.
+// The current exit is kept unless another exit is better by more than the strategy's tolerance,
+// so small fluctuations do not move traffic back and forth. Returns 0 if no non-backup exit
+// has a usable metric.
+func (m *LoadBalanceMetrics) SelectPreferredExit(strategy vo.LoadBalanceStrategy, exitAgents []vo.AgentWeight, current uint) uint {
+	var (
+		tolerance int64
+		metric    func(ExitMetrics) (int64, bool)
+	)
+	switch {
+	case strategy.IsLowestLatency():
+		tolerance = LoadBalanceLatencyToleranceMs
+		metric = func(e ExitMetrics) (int64, bool) {
+			if e.LatencyMs == nil {
+				return 0, false
+			}
+			return *e.LatencyMs, true
+		}
+	case strategy.IsLeastConnections():
+		tolerance = LoadBalanceConnectionTolerance
+		metric = func(e ExitMetrics) (int64, bool) {
+			if e.Connections == nil {
+				return 0, false
+			}
+			return int64(*e.Connections), true
+		}
+	default:
+		return 0
+	}
+
+	var (
+		best         uint
+		bestValue    int64
+		currentValue int64
+		hasCurrent   bool
+	)
+	for _, aw := range exitAgents {
+		if aw.IsBackup() {
+			continue
+		}
+		value, ok := metric(m.Exits[aw.AgentID()])
+		if !ok {
+			continue
+		}
+		if aw.AgentID() == current {
+			currentValue, hasCurrent = value, true
+		}
+		// Ties go to the first exit in configured order
+		if best == 0 || value < bestValue {
+			best, bestValue = aw.AgentID(), value
+		}
+	}
+
+	if hasCurrent && currentValue-bestValue <= tolerance {
+		return current
+	}
+	return best
+}
@@ -40,6 +40,10 @@ type RuleReader interface {
 	// - Rules where chain_port_config contains the agent with the specified port
 	// The excludeRuleID parameter can be used to exclude a specific rule from the check (useful for updates).
 	IsPortInUseByAgent(ctx context.Context, agentID uint, port uint16, excludeRuleID uint) (bool, error)
+
+	// ListPortUsageByAgent returns the ports the specified agent listens on across all rules,
+	// covering the same ports as IsPortInUseByAgent.
+	ListPortUsageByAgent(ctx context.Context, agentID uint) ([]AgentPortUsage, error)
 }
 
 // RuleQuerier defines list, count, and aggregate query operations for forward rules.
@@ -193,6 +197,15 @@ type ListFilter struct {
 	GroupIDs         []uint // Filter by resource group IDs (uses JSON_OVERLAPS on group_ids column)
 }
 
+// AgentPortUsage holds lightweight info about a port an agent listens on for a rule.
+type AgentPortUsage struct {
+	Port     uint16
+	RuleID   uint
+	RuleSID  string
+	RuleName string
+	ChainHop bool // true if the port comes from the rule's chain_port_config
+}
+
 // OfflineAgentInfo holds lightweight agent info for offline detection.
 // This avoids loading full agent entities.
 type OfflineAgentInfo struct {
@@ -306,3 +319,26 @@ type ExpiringAgentInfo struct {
 	ExpiresAt time.Time
 	CostLabel *string
 }
+
+// TunnelHealthRepository defines the interface for the tunnel health timeline.
+type TunnelHealthRepository interface {
+	// Create stores a tunnel health record.
+	Create(ctx context.Context, record *TunnelHealthRecord) error
+
+	// List returns the records of a rule matching the filter, newest first.
+	List(ctx context.Context, filter TunnelHealthFilter) ([]*TunnelHealthRecord, int64, error)
+
+	// DeleteBefore removes records checked before the cutoff.
+	// Returns the number of records removed.
+	DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error)
+}
+
+// TunnelHealthFilter defines the filtering options for listing tunnel health records.
+type TunnelHealthFilter struct {
+	RuleID      uint
+	ExitAgentID uint // 0 for all exit agents
+	From        *time.Time
+	To          *time.Time
+	Page        int
+	PageSize    int
+}
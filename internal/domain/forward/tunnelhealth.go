@@ -0,0 +1,163 @@
+package forward
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+// MaxTunnelHealthErrorLength is the maximum stored length of a tunnel health check error
+const MaxTunnelHealthErrorLength = 500
+
+// TunnelHealthRecord is one entry of the health timeline of the tunnel from a rule's
+// entry agent to one of its exit agents, as reported by the entry agent.
+type TunnelHealthRecord struct {
+	id           uint
+	ruleID       uint
+	entryAgentID uint
+	exitAgentID  uint
+	healthy      bool
+	failCount    int
+	latencyMs    *int64
+	errorMessage string
+	checkedAt    time.Time
+	createdAt    time.Time
+}
+
+// NewTunnelHealthRecord creates a tunnel health record from an entry agent report
+func NewTunnelHealthRecord(
+	ruleID, entryAgentID, exitAgentID uint,
+	healthy bool,
+	failCount int,
+	latencyMs *int64,
+	errorMessage string,
+	checkedAt time.Time,
+) (*TunnelHealthRecord, error) {
+	if ruleID == 0 {
+		return nil, fmt.Errorf("rule ID is required")
+	}
+	if entryAgentID == 0 || exitAgentID == 0 {
+		return nil, fmt.Errorf("entry and exit agent IDs are required")
+	}
+	if failCount < 0 {
+		return nil, fmt.Errorf("fail count cannot be negative")
+	}
+	if checkedAt.IsZero() {
+		return nil, fmt.Errorf("check time is required")
+	}
+
+	if runes := []rune(errorMessage); len(runes) > MaxTunnelHealthErrorLength {
+		errorMessage = string(runes[:MaxTunnelHealthErrorLength])
+	}
+
+	return &TunnelHealthRecord{
+		ruleID:       ruleID,
+		entryAgentID: entryAgentID,
+		exitAgentID:  exitAgentID,
+		healthy:      healthy,
+		failCount:    failCount,
+		latencyMs:    latencyMs,
+		errorMessage: errorMessage,
+		checkedAt:    checkedAt.UTC(),
+		createdAt:    biztime.NowUTC(),
+	}, nil
+}
+
+// ReconstructTunnelHealthRecord rebuilds a tunnel health record from persistence
+func ReconstructTunnelHealthRecord(
+	id, ruleID, entryAgentID, exitAgentID uint,
+	healthy bool,
+	failCount int,
+	latencyMs *int64,
+	errorMessage string,
+	checkedAt, createdAt time.Time,
+) (*TunnelHealthRecord, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("tunnel health record ID cannot be zero")
+	}
+
+	return &TunnelHealthRecord{
+		id:           id,
+		ruleID:       ruleID,
+		entryAgentID: entryAgentID,
+		exitAgentID:  exitAgentID,
+		healthy:      healthy,
+		failCount:    failCount,
+		latencyMs:    latencyMs,
+		errorMessage: errorMessage,
+		checkedAt:    checkedAt,
+		createdAt:    createdAt,
+	}, nil
+}
+
+// ID returns the record ID
+func (r *TunnelHealthRecord) ID() uint {
+	return r.id
+}
+
+// RuleID returns the forward rule the tunnel belongs to
+func (r *TunnelHealthRecord) RuleID() uint {
+	return r.ruleID
+}
+
+// EntryAgentID returns the agent that checked the tunnel
+func (r *TunnelHealthRecord) EntryAgentID() uint {
+	return r.entryAgentID
+}
+
+// ExitAgentID returns the exit agent at the other end of the tunnel
+func (r *TunnelHealthRecord) ExitAgentID() uint {
+	return r.exitAgentID
+}
+
+// Healthy returns whether the tunnel was healthy
+func (r *TunnelHealthRecord) Healthy() bool {
+	return r.healthy
+}
+
+// FailCount returns the number of consecutive failed checks
+func (r *TunnelHealthRecord) FailCount() int {
+	return r.failCount
+}
+
+// LatencyMs returns the measured latency, nil when unhealthy
+func (r *TunnelHealthRecord) LatencyMs() *int64 {
+	return r.latencyMs
+}
+
+// ErrorMessage returns the check error, empty when healthy
+func (r *TunnelHealthRecord) ErrorMessage() string {
+	return r.errorMessage
+}
+
+// CheckedAt returns when the entry agent checked the tunnel
+func (r *TunnelHealthRecord) CheckedAt() time.Time {
+	return r.checkedAt
+}
+
+// CreatedAt returns when the record was stored
+func (r *TunnelHealthRecord) CreatedAt() time.Time {
+	return r.createdAt
+}
+
+// SetID sets the ID after persistence
+func (r *TunnelHealthRecord) SetID(id uint) error {
+	if r.id != 0 {
+		return fmt.Errorf("tunnel health record ID is already set")
+	}
+	if id == 0 {
+		return fmt.Errorf("tunnel health record ID cannot be zero")
+	}
+	r.id = id
+	return nil
+}
+
+// TunnelHealthState is the latest known health of the tunnel from a rule's entry agent
+// to one of its exit agents.
+type TunnelHealthState struct {
+	Healthy    bool
+	Since      time.Time // When the tunnel entered its current state
+	RecordedAt time.Time // When the last timeline record was stored
+	Demoted    bool      // Whether the exit is demoted to a backup because of this tunnel
+}
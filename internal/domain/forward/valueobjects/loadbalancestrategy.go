@@ -12,6 +12,17 @@ const (
 	// LoadBalanceStrategyWeighted distributes traffic based on weight ratios.
 	LoadBalanceStrategyWeighted LoadBalanceStrategy = "weighted"
 
+	// LoadBalanceStrategyRoundRobin rotates connections evenly across non-backup agents.
+	LoadBalanceStrategyRoundRobin LoadBalanceStrategy = "round_robin"
+
+	// LoadBalanceStrategyLeastConnections sends connections to the non-backup agent
+	// with the fewest active connections.
+	LoadBalanceStrategyLeastConnections LoadBalanceStrategy = "least_connections"
+
+	// LoadBalanceStrategyLowestLatency sends connections to the non-backup agent
+	// with the lowest probed latency.
+	LoadBalanceStrategyLowestLatency LoadBalanceStrategy = "lowest_latency"
+
 	// DefaultLoadBalanceStrategy is the default strategy if not specified.
 	DefaultLoadBalanceStrategy = LoadBalanceStrategyFailover
 )
@@ -19,7 +30,8 @@ const (
 // IsValid checks if the load balance strategy is valid.
 func (s LoadBalanceStrategy) IsValid() bool {
 	switch s {
-	case LoadBalanceStrategyFailover, LoadBalanceStrategyWeighted:
+	case LoadBalanceStrategyFailover, LoadBalanceStrategyWeighted, LoadBalanceStrategyRoundRobin,
+		LoadBalanceStrategyLeastConnections, LoadBalanceStrategyLowestLatency:
 		return true
 	default:
 		return false
@@ -41,6 +53,32 @@ func (s LoadBalanceStrategy) IsWeighted() bool {
 	return s == LoadBalanceStrategyWeighted
 }
 
+// IsRoundRobin returns true if the strategy is round robin.
+func (s LoadBalanceStrategy) IsRoundRobin() bool {
+	return s == LoadBalanceStrategyRoundRobin
+}
+
+// IsLeastConnections returns true if the strategy is least connections.
+func (s LoadBalanceStrategy) IsLeastConnections() bool {
+	return s == LoadBalanceStrategyLeastConnections
+}
+
+// IsLowestLatency returns true if the strategy is lowest latency.
+func (s LoadBalanceStrategy) IsLowestLatency() bool {
+	return s == LoadBalanceStrategyLowestLatency
+}
+
+// RequiresActiveAgent returns true if the strategy spreads traffic across non-backup agents
+// and therefore needs at least one agent with non-zero weight. Failover can run on backups only.
+func (s LoadBalanceStrategy) RequiresActiveAgent() bool {
+	return s.IsValid() && !s.IsFailover()
+}
+
+// UsesExitMetrics returns true if the strategy selects agents by metrics collected by the server.
+func (s LoadBalanceStrategy) UsesExitMetrics() bool {
+	return s.IsLeastConnections() || s.IsLowestLatency()
+}
+
 // ParseLoadBalanceStrategy parses a string to LoadBalanceStrategy.
 // Returns DefaultLoadBalanceStrategy for empty or invalid input.
 func ParseLoadBalanceStrategy(s string) LoadBalanceStrategy {
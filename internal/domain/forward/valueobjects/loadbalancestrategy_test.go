@@ -0,0 +1,65 @@
+package valueobjects
+
+import "testing"
+
+// TestLoadBalanceStrategy_IsValid tests the IsValid method for all strategies.
+func TestLoadBalanceStrategy_IsValid(t *testing.T) {
+	testCases := []struct {
+		name     string
+		strategy LoadBalanceStrategy
+		want     bool
+	}{
+		{"failover is valid", LoadBalanceStrategyFailover, true},
+		{"weighted is valid", LoadBalanceStrategyWeighted, true},
+		{"round_robin is valid", LoadBalanceStrategyRoundRobin, true},
+		{"least_connections is valid", LoadBalanceStrategyLeastConnections, true},
+		{"lowest_latency is valid", LoadBalanceStrategyLowestLatency, true},
+		{"empty string is invalid", LoadBalanceStrategy(""), false},
+		{"unknown strategy is invalid", LoadBalanceStrategy("random"), false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.strategy.IsValid(); got != tc.want {
+				t.Errorf("IsValid() = %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+// TestLoadBalanceStrategy_Capabilities tests which strategies need active agents and exit metrics.
+func TestLoadBalanceStrategy_Capabilities(t *testing.T) {
+	testCases := []struct {
+		strategy            LoadBalanceStrategy
+		requiresActiveAgent bool
+		usesExitMetrics     bool
+	}{
+		{LoadBalanceStrategyFailover, false, false},
+		{LoadBalanceStrategyWeighted, true, false},
+		{LoadBalanceStrategyRoundRobin, true, false},
+		{LoadBalanceStrategyLeastConnections, true, true},
+		{LoadBalanceStrategyLowestLatency, true, true},
+		{LoadBalanceStrategy("random"), false, false},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.strategy.String(), func(t *testing.T) {
+			if got := tc.strategy.RequiresActiveAgent(); got != tc.requiresActiveAgent {
+				t.Errorf("RequiresActiveAgent() = %v, want %v", got, tc.requiresActiveAgent)
+			}
+			if got := tc.strategy.UsesExitMetrics(); got != tc.usesExitMetrics {
+				t.Errorf("UsesExitMetrics() = %v, want %v", got, tc.usesExitMetrics)
+			}
+		})
+	}
+}
+
+// TestParseLoadBalanceStrategy tests parsing with fallback to the default strategy.
+func TestParseLoadBalanceStrategy(t *testing.T) {
+	if got := ParseLoadBalanceStrategy("lowest_latency"); got != LoadBalanceStrategyLowestLatency {
+		t.Errorf("ParseLoadBalanceStrategy(lowest_latency) = %v", got)
+	}
+	if got := ParseLoadBalanceStrategy("random"); got != DefaultLoadBalanceStrategy {
+		t.Errorf("ParseLoadBalanceStrategy(random) = %v, want %v", got, DefaultLoadBalanceStrategy)
+	}
+}
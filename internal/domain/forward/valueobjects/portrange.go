@@ -56,6 +56,30 @@ func (p *PortRange) TotalPorts() int {
 	return total
 }
 
+// DefaultPortPool returns the pool ports are auto-assigned from on agents without
+// allowed port ranges.
+func DefaultPortPool() *PortRange {
+	return &PortRange{Ranges: []PortRangeEntry{{Start: 10000, End: 60000}}}
+}
+
+// PortAt returns the port at the given index across all ranges, in configured order.
+// Returns 0 if the index is out of bounds.
+func (p *PortRange) PortAt(index int) uint16 {
+	if p.IsEmpty() || index < 0 {
+		return 0
+	}
+
+	for _, r := range p.Ranges {
+		rangeSize := int(r.End-r.Start) + 1
+		if index < rangeSize {
+			// #nosec G115 -- index is bounded by port range size (max 65535)
+			return r.Start + uint16(index)
+		}
+		index -= rangeSize
+	}
+	return 0
+}
+
 // RandomPort returns a random port from the allowed ranges.
 // Returns 0 if the port range is empty (caller should use default range).
 func (p *PortRange) RandomPort() uint16 {
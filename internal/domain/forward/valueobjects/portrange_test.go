@@ -0,0 +1,45 @@
+package valueobjects
+
+import "testing"
+
+// TestPortRange_PortAt tests indexing ports across multiple ranges.
+func TestPortRange_PortAt(t *testing.T) {
+	pr := &PortRange{Ranges: []PortRangeEntry{{Start: 8000, End: 8002}, {Start: 443, End: 443}, {Start: 9000, End: 9001}}}
+
+	testCases := []struct {
+		name  string
+		index int
+		want  uint16
+	}{
+		{"first port", 0, 8000},
+		{"end of first range", 2, 8002},
+		{"single port range", 3, 443},
+		{"last port", 5, 9001},
+		{"out of bounds", 6, 0},
+		{"negative index", -1, 0},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := pr.PortAt(tc.index); got != tc.want {
+				t.Errorf("PortAt(%d) = %d, want %d", tc.index, got, tc.want)
+			}
+		})
+	}
+
+	if got := (*PortRange)(nil).PortAt(0); got != 0 {
+		t.Errorf("PortAt on empty range = %d, want 0", got)
+	}
+}
+
+// TestDefaultPortPool tests that every port of the default pool is indexable.
+func TestDefaultPortPool(t *testing.T) {
+	pool := DefaultPortPool()
+	total := pool.TotalPorts()
+	if total != 50001 {
+		t.Fatalf("TotalPorts() = %d, want 50001", total)
+	}
+	if got := pool.PortAt(total - 1); got != 60000 {
+		t.Errorf("PortAt(last) = %d, want 60000", got)
+	}
+}
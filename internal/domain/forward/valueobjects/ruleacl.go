@@ -0,0 +1,134 @@
+package valueobjects
+
+import (
+	"fmt"
+	"net"
+	"slices"
+	"strings"
+
+	"github.com/orris-inc/orris/internal/domain/shared/routing"
+)
+
+// MaxACLCIDRs is the maximum number of CIDRs in each list of a rule ACL.
+const MaxACLCIDRs = 1000
+
+// RuleACL represents the source IP access control list of a forward rule, enforced by
+// the entry agent. Deny entries take precedence over allow entries. When any allow
+// list is set, only sources matching an allow entry are accepted.
+// Countries are GeoIP codes following the routing package conventions (e.g., "cn", "us").
+type RuleACL struct {
+	AllowCIDRs     []string `json:"allow_cidrs,omitempty"`
+	DenyCIDRs      []string `json:"deny_cidrs,omitempty"`
+	AllowCountries []string `json:"allow_countries,omitempty"`
+	DenyCountries  []string `json:"deny_countries,omitempty"`
+}
+
+// NewRuleACL creates a validated rule ACL. Single IP addresses are converted to
+// host CIDRs and country codes are lowercased.
+// Returns nil if all lists are empty.
+func NewRuleACL(allowCIDRs, denyCIDRs, allowCountries, denyCountries []string) (*RuleACL, error) {
+	acl := &RuleACL{
+		AllowCIDRs:     normalizeCIDRs(allowCIDRs),
+		DenyCIDRs:      normalizeCIDRs(denyCIDRs),
+		AllowCountries: normalizeCountries(allowCountries),
+		DenyCountries:  normalizeCountries(denyCountries),
+	}
+	if acl.IsEmpty() {
+		return nil, nil
+	}
+	if err := acl.Validate(); err != nil {
+		return nil, err
+	}
+	return acl, nil
+}
+
+// IsEmpty returns true if no list is configured (all sources are accepted).
+func (a *RuleACL) IsEmpty() bool {
+	return a == nil ||
+		(len(a.AllowCIDRs) == 0 && len(a.DenyCIDRs) == 0 &&
+			len(a.AllowCountries) == 0 && len(a.DenyCountries) == 0)
+}
+
+// Validate validates the CIDRs and country codes of the ACL.
+func (a *RuleACL) Validate() error {
+	if a == nil {
+		return nil
+	}
+	if err := validateACLCIDRs("allow_cidrs", a.AllowCIDRs); err != nil {
+		return err
+	}
+	if err := validateACLCIDRs("deny_cidrs", a.DenyCIDRs); err != nil {
+		return err
+	}
+	if err := validateACLCountries("allow_countries", a.AllowCountries); err != nil {
+		return err
+	}
+	return validateACLCountries("deny_countries", a.DenyCountries)
+}
+
+// Equals compares two ACLs for equality.
+func (a *RuleACL) Equals(other *RuleACL) bool {
+	if a.IsEmpty() || other.IsEmpty() {
+		return a.IsEmpty() && other.IsEmpty()
+	}
+	return slices.Equal(a.AllowCIDRs, other.AllowCIDRs) &&
+		slices.Equal(a.DenyCIDRs, other.DenyCIDRs) &&
+		slices.Equal(a.AllowCountries, other.AllowCountries) &&
+		slices.Equal(a.DenyCountries, other.DenyCountries)
+}
+
+func validateACLCIDRs(name string, cidrs []string) error {
+	if len(cidrs) > MaxACLCIDRs {
+		return fmt.Errorf("%s has too many items: %d (max %d)", name, len(cidrs), MaxACLCIDRs)
+	}
+	for i, cidr := range cidrs {
+		if _, _, err := net.ParseCIDR(cidr); err != nil {
+			return fmt.Errorf("%s[%d] is invalid CIDR: %w", name, i, err)
+		}
+	}
+	return nil
+}
+
+func validateACLCountries(name string, codes []string) error {
+	if len(codes) > routing.MaxGeoIPItems {
+		return fmt.Errorf("%s has too many items: %d (max %d)", name, len(codes), routing.MaxGeoIPItems)
+	}
+	for i, code := range codes {
+		if !routing.IsValidGeoIPCode(code) {
+			return fmt.Errorf("%s[%d] is invalid GeoIP country code: %s", name, i, code)
+		}
+	}
+	return nil
+}
+
+// normalizeCIDRs trims entries and converts single IP addresses to host CIDRs.
+func normalizeCIDRs(cidrs []string) []string {
+	if len(cidrs) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(cidrs))
+	for _, cidr := range cidrs {
+		cidr = strings.TrimSpace(cidr)
+		if ip := net.ParseIP(cidr); ip != nil {
+			if ip.To4() != nil {
+				cidr += "/32"
+			} else {
+				cidr += "/128"
+			}
+		}
+		result = append(result, cidr)
+	}
+	return result
+}
+
+// normalizeCountries trims and lowercases country codes.
+func normalizeCountries(codes []string) []string {
+	if len(codes) == 0 {
+		return nil
+	}
+	result := make([]string, 0, len(codes))
+	for _, code := range codes {
+		result = append(result, strings.ToLower(strings.TrimSpace(code)))
+	}
+	return result
+}
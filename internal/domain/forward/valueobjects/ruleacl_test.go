@@ -0,0 +1,65 @@
+package valueobjects
+
+import (
+	"slices"
+	"testing"
+)
+
+// TestNewRuleACL tests normalization and validation of rule ACLs.
+func TestNewRuleACL(t *testing.T) {
+	testCases := []struct {
+		name           string
+		allowCIDRs     []string
+		denyCIDRs      []string
+		allowCountries []string
+		denyCountries  []string
+		wantErr        bool
+	}{
+		{"office network", []string{"203.0.113.0/24"}, nil, nil, nil, false},
+		{"deny single IPv6", nil, []string{"2001:db8::1"}, nil, nil, false},
+		{"countries", nil, nil, []string{"US", " de "}, []string{"private"}, false},
+		{"invalid CIDR", []string{"203.0.113.0/33"}, nil, nil, nil, true},
+		{"invalid address", nil, []string{"office"}, nil, nil, true},
+		{"invalid country", nil, nil, []string{"usa"}, nil, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			_, err := NewRuleACL(tc.allowCIDRs, tc.denyCIDRs, tc.allowCountries, tc.denyCountries)
+			if (err != nil) != tc.wantErr {
+				t.Errorf("NewRuleACL() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestNewRuleACL_Normalize tests that single IPs become host CIDRs and countries are lowercased.
+func TestNewRuleACL_Normalize(t *testing.T) {
+	acl, err := NewRuleACL([]string{"203.0.113.7"}, []string{"2001:db8::1"}, []string{"US"}, nil)
+	if err != nil {
+		t.Fatalf("NewRuleACL() unexpected error: %v", err)
+	}
+	if !slices.Equal(acl.AllowCIDRs, []string{"203.0.113.7/32"}) {
+		t.Errorf("AllowCIDRs = %v, want [203.0.113.7/32]", acl.AllowCIDRs)
+	}
+	if !slices.Equal(acl.DenyCIDRs, []string{"2001:db8::1/128"}) {
+		t.Errorf("DenyCIDRs = %v, want [2001:db8::1/128]", acl.DenyCIDRs)
+	}
+	if !slices.Equal(acl.AllowCountries, []string{"us"}) {
+		t.Errorf("AllowCountries = %v, want [us]", acl.AllowCountries)
+	}
+}
+
+// TestNewRuleACL_Empty tests that an ACL without entries is nil.
+func TestNewRuleACL_Empty(t *testing.T) {
+	acl, err := NewRuleACL(nil, []string{}, nil, nil)
+	if err != nil {
+		t.Fatalf("NewRuleACL() unexpected error: %v", err)
+	}
+	if acl != nil {
+		t.Errorf("NewRuleACL() = %+v, want nil", acl)
+	}
+	if !acl.IsEmpty() || !acl.Equals(&RuleACL{}) {
+		t.Error("nil ACL should be empty and equal to an empty ACL")
+	}
+}
@@ -0,0 +1,52 @@
+package valueobjects
+
+import "fmt"
+
+const (
+	// MaxRuleBandwidthMbps is the highest upload or download limit a rule accepts.
+	MaxRuleBandwidthMbps = 100000
+	// MaxRuleConnections is the highest concurrent connection limit a rule accepts.
+	MaxRuleConnections = 1000000
+)
+
+// RuleLimits represents the bandwidth and concurrent connection limits of a forward rule,
+// enforced by the agents. A zero limit is not set: subscription-bound rules inherit it
+// from the subscription's plan, other rules are unlimited.
+type RuleLimits struct {
+	UploadMbps     uint32
+	DownloadMbps   uint32
+	MaxConnections uint32
+}
+
+// IsZero returns true if no limit is set.
+func (l RuleLimits) IsZero() bool {
+	return l.UploadMbps == 0 && l.DownloadMbps == 0 && l.MaxConnections == 0
+}
+
+// Validate checks that the limits are within the accepted bounds.
+func (l RuleLimits) Validate() error {
+	if l.UploadMbps > MaxRuleBandwidthMbps {
+		return fmt.Errorf("upload limit cannot exceed %d Mbps", MaxRuleBandwidthMbps)
+	}
+	if l.DownloadMbps > MaxRuleBandwidthMbps {
+		return fmt.Errorf("download limit cannot exceed %d Mbps", MaxRuleBandwidthMbps)
+	}
+	if l.MaxConnections > MaxRuleConnections {
+		return fmt.Errorf("connection limit cannot exceed %d", MaxRuleConnections)
+	}
+	return nil
+}
+
+// WithDefaults returns the limits with each limit that is not set taken from defaults.
+func (l RuleLimits) WithDefaults(defaults RuleLimits) RuleLimits {
+	if l.UploadMbps == 0 {
+		l.UploadMbps = defaults.UploadMbps
+	}
+	if l.DownloadMbps == 0 {
+		l.DownloadMbps = defaults.DownloadMbps
+	}
+	if l.MaxConnections == 0 {
+		l.MaxConnections = defaults.MaxConnections
+	}
+	return l
+}
@@ -0,0 +1,43 @@
+package valueobjects
+
+import "testing"
+
+// TestRuleLimits_Validate tests the bounds of rule limits.
+func TestRuleLimits_Validate(t *testing.T) {
+	testCases := []struct {
+		name    string
+		limits  RuleLimits
+		wantErr bool
+	}{
+		{"unlimited", RuleLimits{}, false},
+		{"within bounds", RuleLimits{UploadMbps: 100, DownloadMbps: MaxRuleBandwidthMbps, MaxConnections: MaxRuleConnections}, false},
+		{"upload too high", RuleLimits{UploadMbps: MaxRuleBandwidthMbps + 1}, true},
+		{"download too high", RuleLimits{DownloadMbps: MaxRuleBandwidthMbps + 1}, true},
+		{"connections too high", RuleLimits{MaxConnections: MaxRuleConnections + 1}, true},
+	}
+
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.limits.Validate()
+			if (err != nil) != tc.wantErr {
+				t.Errorf("Validate() error = %v, wantErr %v", err, tc.wantErr)
+			}
+		})
+	}
+}
+
+// TestRuleLimits_WithDefaults tests that only unset limits are taken from defaults.
+func TestRuleLimits_WithDefaults(t *testing.T) {
+	limits := RuleLimits{UploadMbps: 50}
+	defaults := RuleLimits{UploadMbps: 100, DownloadMbps: 100, MaxConnections: 200}
+
+	got := limits.WithDefaults(defaults)
+	want := RuleLimits{UploadMbps: 50, DownloadMbps: 100, MaxConnections: 200}
+	if got != want {
+		t.Errorf("WithDefaults() = %+v, want %+v", got, want)
+	}
+
+	if !(RuleLimits{}).WithDefaults(RuleLimits{}).IsZero() {
+		t.Error("WithDefaults() of unset limits and defaults should be zero")
+	}
+}
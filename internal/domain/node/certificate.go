@@ -0,0 +1,376 @@
+package node
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+const (
+	// CertificateRenewBefore is how long before expiry an auto-renewing certificate is renewed
+	CertificateRenewBefore = 30 * 24 * time.Hour
+	// CertificateRetryInterval is how long to wait before retrying a failed issuance
+	CertificateRetryInterval = 6 * time.Hour
+	// CertificateIssuanceTimeout is how long an issuance may run before its claim is considered stale
+	CertificateIssuanceTimeout = 30 * time.Minute
+	// MaxCertificateErrorLength is the maximum stored length of the last issuance error
+	MaxCertificateErrorLength = 1000
+)
+
+var certificateDomainRegex = regexp.MustCompile(`^(\*\.)?([a-z0-9]([a-z0-9-]{0,61}[a-z0-9])?\.)+[a-z]([a-z0-9-]{0,61}[a-z0-9])?$`)
+
+// CertificateStatus represents the issuance state of a certificate
+type CertificateStatus string
+
+const (
+	// CertificateStatusPending indicates no certificate has been issued yet
+	CertificateStatusPending CertificateStatus = "pending"
+	// CertificateStatusIssuing indicates an ACME order is in progress
+	CertificateStatusIssuing CertificateStatus = "issuing"
+	// CertificateStatusActive indicates the latest issuance succeeded
+	CertificateStatusActive CertificateStatus = "active"
+	// CertificateStatusFailed indicates the latest issuance failed.
+	// A previously issued certificate is kept and still delivered until it expires.
+	CertificateStatusFailed CertificateStatus = "failed"
+)
+
+// IsValid checks if the status is valid
+func (s CertificateStatus) IsValid() bool {
+	switch s {
+	case CertificateStatusPending, CertificateStatusIssuing,
+		CertificateStatusActive, CertificateStatusFailed:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the status
+func (s CertificateStatus) String() string {
+	return string(s)
+}
+
+// Certificate is a TLS certificate for a single domain, obtained from an ACME CA
+// through a DNS-01 challenge. Nodes whose TLS server name is covered by the domain
+// receive the certificate and key through the node hub.
+type Certificate struct {
+	id             uint
+	sid            string // Stripe-style ID: cert_xxxxxxxx
+	domain         string // exact name or single-level wildcard (*.example.com)
+	dnsProvider    string // configured DNS provider used for the DNS-01 challenge
+	autoRenew      bool
+	status         CertificateStatus
+	certificatePEM string // leaf certificate followed by the issuer chain
+	privateKeyPEM  string
+	fingerprint    string // SHA-256 of the leaf certificate (hex)
+	notBefore      *time.Time
+	notAfter       *time.Time
+	lastError      string
+	lastAttemptAt  *time.Time
+	createdAt      time.Time
+	updatedAt      time.Time
+}
+
+// NewCertificate creates a pending certificate record for a domain
+func NewCertificate(domain, dnsProvider string, autoRenew bool, sidGenerator func() (string, error)) (*Certificate, error) {
+	domain = NormalizeCertificateDomain(domain)
+	if err := ValidateCertificateDomain(domain); err != nil {
+		return nil, err
+	}
+	if dnsProvider == "" {
+		return nil, fmt.Errorf("DNS provider is required")
+	}
+
+	sid, err := sidGenerator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SID: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	return &Certificate{
+		sid:         sid,
+		domain:      domain,
+		dnsProvider: dnsProvider,
+		autoRenew:   autoRenew,
+		status:      CertificateStatusPending,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructCertificate rebuilds a certificate from persistence
+func ReconstructCertificate(
+	id uint,
+	sid string,
+	domain string,
+	dnsProvider string,
+	autoRenew bool,
+	status string,
+	certificatePEM, privateKeyPEM, fingerprint string,
+	notBefore, notAfter *time.Time,
+	lastError string,
+	lastAttemptAt *time.Time,
+	createdAt, updatedAt time.Time,
+) (*Certificate, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("certificate ID cannot be zero")
+	}
+	if sid == "" {
+		return nil, fmt.Errorf("certificate SID is required")
+	}
+
+	certStatus := CertificateStatus(status)
+	if !certStatus.IsValid() {
+		return nil, fmt.Errorf("invalid certificate status: %s", status)
+	}
+
+	return &Certificate{
+		id:             id,
+		sid:            sid,
+		domain:         domain,
+		dnsProvider:    dnsProvider,
+		autoRenew:      autoRenew,
+		status:         certStatus,
+		certificatePEM: certificatePEM,
+		privateKeyPEM:  privateKeyPEM,
+		fingerprint:    fingerprint,
+		notBefore:      notBefore,
+		notAfter:       notAfter,
+		lastError:      lastError,
+		lastAttemptAt:  lastAttemptAt,
+		createdAt:      createdAt,
+		updatedAt:      updatedAt,
+	}, nil
+}
+
+// NormalizeCertificateDomain lowercases a domain and strips surrounding whitespace and the trailing dot
+func NormalizeCertificateDomain(domain string) string {
+	return strings.TrimSuffix(strings.ToLower(strings.TrimSpace(domain)), ".")
+}
+
+// ValidateCertificateDomain checks that a normalized domain is a valid DNS name or single-level wildcard
+func ValidateCertificateDomain(domain string) error {
+	if domain == "" {
+		return fmt.Errorf("certificate domain is required")
+	}
+	if len(domain) > 253 || !certificateDomainRegex.MatchString(domain) {
+		return fmt.Errorf("invalid certificate domain: %s", domain)
+	}
+	return nil
+}
+
+// ID returns the internal ID
+func (c *Certificate) ID() uint {
+	return c.id
+}
+
+// SID returns the Stripe-style short ID
+func (c *Certificate) SID() string {
+	return c.sid
+}
+
+// Domain returns the certificate domain
+func (c *Certificate) Domain() string {
+	return c.domain
+}
+
+// DNSProvider returns the name of the DNS provider used for the DNS-01 challenge
+func (c *Certificate) DNSProvider() string {
+	return c.dnsProvider
+}
+
+// AutoRenew returns whether the certificate is renewed automatically before it expires
+func (c *Certificate) AutoRenew() bool {
+	return c.autoRenew
+}
+
+// Status returns the issuance status
+func (c *Certificate) Status() CertificateStatus {
+	return c.status
+}
+
+// CertificatePEM returns the PEM-encoded certificate chain (empty until first issued)
+func (c *Certificate) CertificatePEM() string {
+	return c.certificatePEM
+}
+
+// PrivateKeyPEM returns the PEM-encoded private key (empty until first issued)
+func (c *Certificate) PrivateKeyPEM() string {
+	return c.privateKeyPEM
+}
+
+// Fingerprint returns the SHA-256 fingerprint of the leaf certificate
+func (c *Certificate) Fingerprint() string {
+	return c.fingerprint
+}
+
+// NotBefore returns the start of the validity period of the issued certificate
+func (c *Certificate) NotBefore() *time.Time {
+	return c.notBefore
+}
+
+// NotAfter returns the expiry of the issued certificate
+func (c *Certificate) NotAfter() *time.Time {
+	return c.notAfter
+}
+
+// LastError returns the error of the latest failed issuance
+func (c *Certificate) LastError() string {
+	return c.lastError
+}
+
+// LastAttemptAt returns when issuance was last started
+func (c *Certificate) LastAttemptAt() *time.Time {
+	return c.lastAttemptAt
+}
+
+// CreatedAt returns the creation time
+func (c *Certificate) CreatedAt() time.Time {
+	return c.createdAt
+}
+
+// UpdatedAt returns the last update time
+func (c *Certificate) UpdatedAt() time.Time {
+	return c.updatedAt
+}
+
+// SetID sets the ID after persistence
+func (c *Certificate) SetID(id uint) error {
+	if c.id != 0 {
+		return fmt.Errorf("certificate ID is already set")
+	}
+	if id == 0 {
+		return fmt.Errorf("certificate ID cannot be zero")
+	}
+	c.id = id
+	return nil
+}
+
+// SetAutoRenew enables or disables automatic renewal
+func (c *Certificate) SetAutoRenew(autoRenew bool) {
+	if c.autoRenew == autoRenew {
+		return
+	}
+	c.autoRenew = autoRenew
+	c.updatedAt = biztime.NowUTC()
+}
+
+// HasCertificate reports whether a certificate has been issued
+func (c *Certificate) HasCertificate() bool {
+	return c.certificatePEM != "" && c.privateKeyPEM != "" && c.notAfter != nil
+}
+
+// IsExpired reports whether the issued certificate has expired at the given time
+func (c *Certificate) IsExpired(now time.Time) bool {
+	return c.notAfter != nil && !now.Before(*c.notAfter)
+}
+
+// IsUsable reports whether the certificate can be delivered to nodes at the given time
+func (c *Certificate) IsUsable(now time.Time) bool {
+	return c.HasCertificate() && !c.IsExpired(now)
+}
+
+// IsIssuing reports whether an issuance is in progress and its claim has not gone stale
+func (c *Certificate) IsIssuing(now time.Time) bool {
+	return c.status == CertificateStatusIssuing &&
+		c.lastAttemptAt != nil && now.Sub(*c.lastAttemptAt) < CertificateIssuanceTimeout
+}
+
+// IsDueForIssuance reports whether the renewal scheduler should (re)issue the certificate.
+// Certificates that were never issued are always due; issued ones are due within
+// CertificateRenewBefore of expiry when auto-renew is on. Failed attempts are retried
+// after CertificateRetryInterval.
+func (c *Certificate) IsDueForIssuance(now time.Time) bool {
+	if c.IsIssuing(now) {
+		return false
+	}
+	if c.status == CertificateStatusFailed && c.lastAttemptAt != nil &&
+		now.Sub(*c.lastAttemptAt) < CertificateRetryInterval {
+		return false
+	}
+	if !c.HasCertificate() {
+		return true
+	}
+	return c.autoRenew && now.Add(CertificateRenewBefore).After(*c.notAfter)
+}
+
+// StartIssuance marks an issuance as in progress
+func (c *Certificate) StartIssuance(now time.Time) error {
+	if c.IsIssuing(now) {
+		return fmt.Errorf("certificate issuance is already in progress")
+	}
+	c.status = CertificateStatusIssuing
+	c.lastAttemptAt = &now
+	c.updatedAt = now
+	return nil
+}
+
+// CompleteIssuance stores a newly issued certificate and key
+func (c *Certificate) CompleteIssuance(certificatePEM, privateKeyPEM, fingerprint string, notBefore, notAfter time.Time) error {
+	if certificatePEM == "" || privateKeyPEM == "" {
+		return fmt.Errorf("certificate and private key are required")
+	}
+	if !notAfter.After(notBefore) {
+		return fmt.Errorf("certificate must expire after it becomes valid")
+	}
+
+	notBefore, notAfter = notBefore.UTC(), notAfter.UTC()
+	c.status = CertificateStatusActive
+	c.certificatePEM = certificatePEM
+	c.privateKeyPEM = privateKeyPEM
+	c.fingerprint = fingerprint
+	c.notBefore = &notBefore
+	c.notAfter = &notAfter
+	c.lastError = ""
+	c.updatedAt = biztime.NowUTC()
+	return nil
+}
+
+// FailIssuance records a failed issuance. A previously issued certificate is kept.
+func (c *Certificate) FailIssuance(reason string) {
+	if len(reason) > MaxCertificateErrorLength {
+		reason = reason[:MaxCertificateErrorLength]
+	}
+	c.status = CertificateStatusFailed
+	c.lastError = reason
+	c.updatedAt = biztime.NowUTC()
+}
+
+// Covers reports whether the certificate domain matches a TLS server name.
+// A wildcard domain covers exactly one additional label, as in TLS hostname verification.
+func (c *Certificate) Covers(serverName string) bool {
+	serverName = NormalizeCertificateDomain(serverName)
+	if serverName == "" {
+		return false
+	}
+	if base, ok := strings.CutPrefix(c.domain, "*."); ok {
+		label, rest, found := strings.Cut(serverName, ".")
+		return found && label != "" && rest == base
+	}
+	return serverName == c.domain
+}
+
+// IsWildcard reports whether the certificate is a wildcard certificate
+func (c *Certificate) IsWildcard() bool {
+	return strings.HasPrefix(c.domain, "*.")
+}
+
+// SelectCertificate returns the usable certificate that covers serverName, preferring an
+// exact domain match over a wildcard. Returns nil if no certificate covers the name.
+func SelectCertificate(certs []*Certificate, serverName string, now time.Time) *Certificate {
+	var wildcard *Certificate
+	for _, c := range certs {
+		if !c.IsUsable(now) || !c.Covers(serverName) {
+			continue
+		}
+		if !c.IsWildcard() {
+			return c
+		}
+		if wildcard == nil {
+			wildcard = c
+		}
+	}
+	return wildcard
+}
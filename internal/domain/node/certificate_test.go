@@ -0,0 +1,104 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestCertificate(t *testing.T, domain string) *Certificate {
+	t.Helper()
+	c, err := NewCertificate(domain, "cloudflare", true, fakeSIDGenerator("cert_test123"))
+	require.NoError(t, err)
+	return c
+}
+
+func issueTestCertificate(t *testing.T, c *Certificate, notAfter time.Time) {
+	t.Helper()
+	require.NoError(t, c.CompleteIssuance("cert", "key", "fp-"+c.Domain(), notAfter.Add(-90*24*time.Hour), notAfter))
+}
+
+func TestNewCertificate_Validation(t *testing.T) {
+	c := newTestCertificate(t, " Node1.Example.COM. ")
+	assert.Equal(t, "node1.example.com", c.Domain())
+	assert.Equal(t, CertificateStatusPending, c.Status())
+	assert.False(t, c.HasCertificate())
+
+	for _, domain := range []string{"", "localhost", "*.*.example.com", "node.*.example.com", "-bad.example.com", "1.2.3.4"} {
+		_, err := NewCertificate(domain, "cloudflare", true, fakeSIDGenerator("cert_test123"))
+		assert.Error(t, err, domain)
+	}
+
+	_, err := NewCertificate("example.com", "", true, fakeSIDGenerator("cert_test123"))
+	assert.Error(t, err, "DNS provider required")
+}
+
+func TestCertificate_Covers(t *testing.T) {
+	exact := newTestCertificate(t, "node1.example.com")
+	assert.True(t, exact.Covers("node1.example.com"))
+	assert.True(t, exact.Covers("NODE1.example.com."))
+	assert.False(t, exact.Covers("node2.example.com"))
+	assert.False(t, exact.Covers(""))
+
+	wildcard := newTestCertificate(t, "*.example.com")
+	assert.True(t, wildcard.IsWildcard())
+	assert.True(t, wildcard.Covers("node1.example.com"))
+	assert.False(t, wildcard.Covers("example.com"), "wildcard does not cover the base domain")
+	assert.False(t, wildcard.Covers("a.node1.example.com"), "wildcard covers a single label")
+	assert.False(t, wildcard.Covers("node1.example.org"))
+}
+
+func TestCertificate_IssuanceLifecycle(t *testing.T) {
+	now := time.Now().UTC()
+	c := newTestCertificate(t, "node1.example.com")
+	assert.True(t, c.IsDueForIssuance(now), "never issued")
+
+	require.NoError(t, c.StartIssuance(now))
+	assert.True(t, c.IsIssuing(now))
+	assert.False(t, c.IsDueForIssuance(now))
+	assert.Error(t, c.StartIssuance(now), "already issuing")
+	assert.False(t, c.IsIssuing(now.Add(CertificateIssuanceTimeout)), "claim goes stale")
+
+	c.FailIssuance("dns timeout")
+	assert.Equal(t, CertificateStatusFailed, c.Status())
+	assert.False(t, c.IsDueForIssuance(now.Add(time.Hour)), "retry waits")
+	assert.True(t, c.IsDueForIssuance(now.Add(CertificateRetryInterval)))
+
+	require.NoError(t, c.StartIssuance(now))
+	issueTestCertificate(t, c, now.Add(90*24*time.Hour))
+	assert.Equal(t, CertificateStatusActive, c.Status())
+	assert.Empty(t, c.LastError())
+	assert.True(t, c.IsUsable(now))
+	assert.False(t, c.IsDueForIssuance(now))
+	assert.True(t, c.IsDueForIssuance(now.Add(61*24*time.Hour)), "within renewal window")
+
+	c.SetAutoRenew(false)
+	assert.False(t, c.IsDueForIssuance(now.Add(61*24*time.Hour)), "auto-renew off")
+	assert.False(t, c.IsUsable(now.Add(90*24*time.Hour)), "expired")
+
+	// A failed renewal keeps the issued certificate
+	c.FailIssuance("rate limited")
+	assert.True(t, c.IsUsable(now))
+}
+
+func TestSelectCertificate(t *testing.T) {
+	now := time.Now().UTC()
+	wildcard := newTestCertificate(t, "*.example.com")
+	issueTestCertificate(t, wildcard, now.Add(30*24*time.Hour))
+	exact := newTestCertificate(t, "node1.example.com")
+	issueTestCertificate(t, exact, now.Add(30*24*time.Hour))
+	pending := newTestCertificate(t, "node2.example.com")
+
+	certs := []*Certificate{wildcard, exact, pending}
+	assert.Same(t, exact, SelectCertificate(certs, "node1.example.com", now), "exact match preferred")
+	assert.Same(t, wildcard, SelectCertificate(certs, "node2.example.com", now), "pending certificate skipped")
+	assert.Nil(t, SelectCertificate(certs, "example.org", now))
+	assert.Nil(t, SelectCertificate(certs, "node1.example.com", now.Add(31*24*time.Hour)), "expired certificates skipped")
+}
+
+func TestNode_TLSServerName(t *testing.T) {
+	assert.Equal(t, "example.com", newTrojanNode(t).TLSServerName())
+	assert.Empty(t, newShadowsocksNode(t).TLSServerName(), "no TLS certificate for Shadowsocks")
+}
@@ -0,0 +1,310 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/shared/routing"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+// ConfigVersionSource describes what produced a configuration version
+type ConfigVersionSource string
+
+const (
+	// ConfigVersionSourceCreate is the configuration the node was created with
+	ConfigVersionSourceCreate ConfigVersionSource = "create"
+	// ConfigVersionSourceUpdate is a configuration saved through a node update
+	ConfigVersionSourceUpdate ConfigVersionSource = "update"
+	// ConfigVersionSourceRollback is a configuration restored from an earlier version
+	ConfigVersionSourceRollback ConfigVersionSource = "rollback"
+	// ConfigVersionSourceRotation is a configuration changed by a server key or Reality key rotation
+	ConfigVersionSourceRotation ConfigVersionSource = "rotation"
+	// ConfigVersionSourceBaseline is a configuration captured before a change because it was
+	// not the latest recorded version (e.g. nodes created before history was kept)
+	ConfigVersionSourceBaseline ConfigVersionSource = "baseline"
+)
+
+// IsValid checks if the source is valid
+func (s ConfigVersionSource) IsValid() bool {
+	switch s {
+	case ConfigVersionSourceCreate, ConfigVersionSourceUpdate, ConfigVersionSourceRollback,
+		ConfigVersionSourceRotation, ConfigVersionSourceBaseline:
+		return true
+	}
+	return false
+}
+
+// String returns the string representation of the source
+func (s ConfigVersionSource) String() string {
+	return string(s)
+}
+
+// ConfigSnapshot is a copy of the configuration a node agent runs with:
+// the protocol configs, the route config and the DNS config.
+type ConfigSnapshot struct {
+	Protocol   vo.Protocol
+	Encryption vo.EncryptionConfig
+	Plugin     *vo.PluginConfig
+	ShadowTLS  *vo.ShadowTLSConfig
+	Trojan     *vo.TrojanConfig
+	VLESS      *vo.VLESSConfig
+	VMess      *vo.VMessConfig
+	Hysteria2  *vo.Hysteria2Config
+	TUIC       *vo.TUICConfig
+	AnyTLS     *vo.AnyTLSConfig
+	WireGuard  *vo.WireGuardConfig
+	Route      *routing.RouteConfig
+	DNS        *vo.DnsConfig
+}
+
+// ConfigChange is a single field that differs between two configuration versions.
+// Values are JSON encoded; Old is empty for added fields and New is empty for removed ones.
+type ConfigChange struct {
+	Path string
+	Old  string
+	New  string
+}
+
+// ConfigVersion is an immutable, numbered snapshot of a node's configuration
+// together with its author and the changes against the previous version.
+type ConfigVersion struct {
+	id           uint
+	nodeID       uint
+	version      int // sequence number per node, starting at 1
+	snapshot     ConfigSnapshot
+	changes      []ConfigChange
+	authorID     *uint // nil for versions recorded by the system
+	source       ConfigVersionSource
+	restoredFrom *int // version a rollback restored
+	createdAt    time.Time
+}
+
+// NewConfigVersion creates a configuration version
+func NewConfigVersion(
+	nodeID uint,
+	version int,
+	snapshot ConfigSnapshot,
+	changes []ConfigChange,
+	authorID *uint,
+	source ConfigVersionSource,
+	restoredFrom *int,
+) (*ConfigVersion, error) {
+	if nodeID == 0 {
+		return nil, fmt.Errorf("node ID is required")
+	}
+	if version < 1 {
+		return nil, fmt.Errorf("config version must be positive")
+	}
+	if !source.IsValid() {
+		return nil, fmt.Errorf("invalid config version source: %s", source)
+	}
+	if source == ConfigVersionSourceRollback && restoredFrom == nil {
+		return nil, fmt.Errorf("rollback version must reference the restored version")
+	}
+	if !snapshot.Protocol.IsValid() {
+		return nil, fmt.Errorf("invalid protocol: %s", snapshot.Protocol)
+	}
+
+	return &ConfigVersion{
+		nodeID:       nodeID,
+		version:      version,
+		snapshot:     snapshot,
+		changes:      changes,
+		authorID:     authorID,
+		source:       source,
+		restoredFrom: restoredFrom,
+		createdAt:    biztime.NowUTC(),
+	}, nil
+}
+
+// ReconstructConfigVersion rebuilds a configuration version from persistence
+func ReconstructConfigVersion(
+	id uint,
+	nodeID uint,
+	version int,
+	snapshot ConfigSnapshot,
+	changes []ConfigChange,
+	authorID *uint,
+	source string,
+	restoredFrom *int,
+	createdAt time.Time,
+) (*ConfigVersion, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("config version ID cannot be zero")
+	}
+
+	versionSource := ConfigVersionSource(source)
+	if !versionSource.IsValid() {
+		return nil, fmt.Errorf("invalid config version source: %s", source)
+	}
+
+	return &ConfigVersion{
+		id:           id,
+		nodeID:       nodeID,
+		version:      version,
+		snapshot:     snapshot,
+		changes:      changes,
+		authorID:     authorID,
+		source:       versionSource,
+		restoredFrom: restoredFrom,
+		createdAt:    createdAt,
+	}, nil
+}
+
+// ID returns the internal ID
+func (v *ConfigVersion) ID() uint {
+	return v.id
+}
+
+// SetID sets the ID after persistence
+func (v *ConfigVersion) SetID(id uint) error {
+	if v.id != 0 {
+		return fmt.Errorf("config version ID is already set")
+	}
+	if id == 0 {
+		return fmt.Errorf("config version ID cannot be zero")
+	}
+	v.id = id
+	return nil
+}
+
+// NodeID returns the ID of the node the configuration belongs to
+func (v *ConfigVersion) NodeID() uint {
+	return v.nodeID
+}
+
+// Version returns the per-node version number
+func (v *ConfigVersion) Version() int {
+	return v.version
+}
+
+// Snapshot returns the recorded configuration
+func (v *ConfigVersion) Snapshot() ConfigSnapshot {
+	return v.snapshot
+}
+
+// Changes returns the differences against the previous version (empty for the first one)
+func (v *ConfigVersion) Changes() []ConfigChange {
+	return v.changes
+}
+
+// AuthorID returns the ID of the user who made the change (nil if recorded by the system)
+func (v *ConfigVersion) AuthorID() *uint {
+	return v.authorID
+}
+
+// Source returns what produced the version
+func (v *ConfigVersion) Source() ConfigVersionSource {
+	return v.source
+}
+
+// RestoredFrom returns the version restored by a rollback (nil otherwise)
+func (v *ConfigVersion) RestoredFrom() *int {
+	return v.restoredFrom
+}
+
+// CreatedAt returns the creation time
+func (v *ConfigVersion) CreatedAt() time.Time {
+	return v.createdAt
+}
+
+// ConfigSnapshot returns a copy of the node's current protocol, route and DNS configuration
+func (n *Node) ConfigSnapshot() ConfigSnapshot {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	return ConfigSnapshot{
+		Protocol:   n.protocol,
+		Encryption: n.encryptionConfig,
+		Plugin:     n.pluginConfig,
+		ShadowTLS:  n.shadowTLSConfig,
+		Trojan:     n.trojanConfig,
+		VLESS:      n.vlessConfig,
+		VMess:      n.vmessConfig,
+		Hysteria2:  n.hysteria2Config,
+		TUIC:       n.tuicConfig,
+		AnyTLS:     n.anytlsConfig,
+		WireGuard:  n.wireguardConfig,
+		Route:      n.routeConfig,
+		DNS:        n.dnsConfig,
+	}
+}
+
+// RestoreConfig replaces the node's protocol, route and DNS configuration with a snapshot.
+// The snapshot must use the node's protocol, which cannot change after creation.
+// Unless restoreSecrets is set, the node keeps its current secret material (SS2022 server key,
+// Reality key pair and short ID, WireGuard private key) so that a rollback cannot bring back a
+// rotated key; a snapshot the current secrets cannot be carried into is refused.
+func (n *Node) RestoreConfig(snapshot ConfigSnapshot, restoreSecrets bool) error {
+	if !snapshot.Protocol.Equals(n.protocol) {
+		return fmt.Errorf("cannot restore %s configuration on a %s node", snapshot.Protocol, n.protocol)
+	}
+	if snapshot.Route != nil {
+		if err := snapshot.Route.Validate(); err != nil {
+			return fmt.Errorf("invalid route config: %w", err)
+		}
+	}
+	if snapshot.DNS != nil {
+		if err := snapshot.DNS.Validate(); err != nil {
+			return fmt.Errorf("invalid dns config: %w", err)
+		}
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !restoreSecrets {
+		var err error
+		if snapshot, err = n.withCurrentSecrets(snapshot); err != nil {
+			return err
+		}
+	}
+
+	n.encryptionConfig = snapshot.Encryption
+	n.pluginConfig = snapshot.Plugin
+	n.shadowTLSConfig = snapshot.ShadowTLS
+	n.trojanConfig = snapshot.Trojan
+	n.vlessConfig = snapshot.VLESS
+	n.vmessConfig = snapshot.VMess
+	n.hysteria2Config = snapshot.Hysteria2
+	n.tuicConfig = snapshot.TUIC
+	n.anytlsConfig = snapshot.AnyTLS
+	n.wireguardConfig = snapshot.WireGuard
+	n.routeConfig = snapshot.Route
+	n.dnsConfig = snapshot.DNS
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return nil
+}
+
+// withCurrentSecrets returns the snapshot with the node's current secret material.
+// Caller must hold the lock.
+func (n *Node) withCurrentSecrets(snapshot ConfigSnapshot) (ConfigSnapshot, error) {
+	encryption, ok := snapshot.Encryption.WithServerKeyOf(n.encryptionConfig)
+	if !ok {
+		return ConfigSnapshot{}, fmt.Errorf("the current server key cannot be used with encryption method %s; restore secrets explicitly to roll back", encryption.Method())
+	}
+	snapshot.Encryption = encryption
+
+	if snapshot.VLESS != nil {
+		var current vo.VLESSConfig
+		if n.vlessConfig != nil {
+			current = *n.vlessConfig
+		}
+		vless, ok := snapshot.VLESS.WithRealityKeysOf(current)
+		if !ok {
+			return ConfigSnapshot{}, fmt.Errorf("the node has no current reality keys to keep; restore secrets explicitly to roll back")
+		}
+		snapshot.VLESS = &vless
+	}
+
+	if snapshot.WireGuard != nil && n.wireguardConfig != nil {
+		wireguard := snapshot.WireGuard.WithKeyPairOf(*n.wireguardConfig)
+		snapshot.WireGuard = &wireguard
+	}
+
+	return snapshot, nil
+}
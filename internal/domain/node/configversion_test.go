@@ -0,0 +1,95 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/domain/shared/routing"
+)
+
+func TestNode_RestoreConfig(t *testing.T) {
+	n := newShadowsocksNode(t)
+	snapshot := n.ConfigSnapshot()
+	assert.Equal(t, vo.ProtocolShadowsocks, snapshot.Protocol)
+
+	enc, err := vo.NewEncryptionConfig(vo.MethodAES128GCM)
+	require.NoError(t, err)
+	require.NoError(t, n.UpdateEncryption(enc))
+	require.NoError(t, n.UpdateRouteConfig(routing.NewGlobalProxyRouteConfig()))
+	assert.NotEqual(t, snapshot, n.ConfigSnapshot())
+
+	version := n.Version()
+	require.NoError(t, n.RestoreConfig(snapshot, false))
+	assert.Equal(t, snapshot, n.ConfigSnapshot())
+	assert.Nil(t, n.RouteConfig())
+	assert.Equal(t, version+1, n.Version())
+}
+
+func TestNode_RestoreConfig_ProtocolMismatch(t *testing.T) {
+	trojan := newTrojanNode(t)
+	err := newShadowsocksNode(t).RestoreConfig(trojan.ConfigSnapshot(), false)
+	assert.Error(t, err)
+}
+
+func TestNode_RestoreConfig_KeepsCurrentServerKey(t *testing.T) {
+	n := newShadowsocksNode(t)
+	enc, err := vo.NewEncryptionConfig(vo.Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+	require.NoError(t, n.UpdateEncryption(enc))
+	require.NoError(t, n.RotateServerKey())
+	snapshot := n.ConfigSnapshot()
+
+	require.NoError(t, n.RotateServerKey())
+	currentKey := n.EncryptionConfig().ServerKey()
+	require.NotEqual(t, snapshot.Encryption.ServerKey(), currentKey)
+
+	require.NoError(t, n.RestoreConfig(snapshot, false))
+	assert.Equal(t, currentKey, n.EncryptionConfig().ServerKey())
+
+	require.NoError(t, n.RestoreConfig(snapshot, true))
+	assert.Equal(t, snapshot.Encryption.ServerKey(), n.EncryptionConfig().ServerKey(), "secrets restored on request")
+}
+
+func TestNode_RestoreConfig_RefusesUnkeepableSecrets(t *testing.T) {
+	n := newShadowsocksNode(t)
+	enc, err := vo.NewEncryptionConfig(vo.Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+	require.NoError(t, n.UpdateEncryption(enc))
+	require.NoError(t, n.RotateServerKey())
+
+	smaller, err := vo.NewEncryptionConfig(vo.Method2022Blake3AES128GCM)
+	require.NoError(t, err)
+	snapshot := n.ConfigSnapshot()
+	snapshot.Encryption = smaller
+
+	version := n.Version()
+	assert.Error(t, n.RestoreConfig(snapshot, false))
+	assert.Equal(t, version, n.Version(), "refused rollback leaves the node unchanged")
+	assert.NoError(t, n.RestoreConfig(snapshot, true))
+}
+
+func TestNewConfigVersion_Validation(t *testing.T) {
+	snapshot := newShadowsocksNode(t).ConfigSnapshot()
+
+	v, err := NewConfigVersion(1, 1, snapshot, nil, nil, ConfigVersionSourceCreate, nil)
+	require.NoError(t, err)
+	assert.Equal(t, 1, v.Version())
+	assert.Nil(t, v.AuthorID())
+
+	_, err = NewConfigVersion(0, 1, snapshot, nil, nil, ConfigVersionSourceCreate, nil)
+	assert.Error(t, err, "node ID required")
+	_, err = NewConfigVersion(1, 0, snapshot, nil, nil, ConfigVersionSourceCreate, nil)
+	assert.Error(t, err, "version must be positive")
+	_, err = NewConfigVersion(1, 2, snapshot, nil, nil, ConfigVersionSource("unknown"), nil)
+	assert.Error(t, err, "invalid source")
+	_, err = NewConfigVersion(1, 2, snapshot, nil, nil, ConfigVersionSourceRollback, nil)
+	assert.Error(t, err, "rollback requires the restored version")
+
+	restoredFrom := 1
+	v, err = NewConfigVersion(1, 2, snapshot, nil, nil, ConfigVersionSourceRollback, &restoredFrom)
+	require.NoError(t, err)
+	assert.Equal(t, &restoredFrom, v.RestoredFrom())
+}
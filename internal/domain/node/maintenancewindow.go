@@ -0,0 +1,326 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+const (
+	// MaxMaintenanceWindowDuration is the longest maintenance window that can be scheduled
+	MaxMaintenanceWindowDuration = 7 * 24 * time.Hour
+	// MaxMaintenanceReasonLength is the maximum length of a maintenance reason
+	MaxMaintenanceReasonLength = 500
+)
+
+// MaintenanceWindowStatus represents the lifecycle state of a maintenance window
+type MaintenanceWindowStatus string
+
+const (
+	// MaintenanceWindowStatusScheduled indicates the window has not started yet
+	MaintenanceWindowStatusScheduled MaintenanceWindowStatus = "scheduled"
+	// MaintenanceWindowStatusActive indicates the affected nodes are currently in maintenance
+	MaintenanceWindowStatusActive MaintenanceWindowStatus = "active"
+	// MaintenanceWindowStatusCompleted indicates the window ended and nodes were restored
+	MaintenanceWindowStatusCompleted MaintenanceWindowStatus = "completed"
+	// MaintenanceWindowStatusCancelled indicates the window was cancelled by an admin
+	MaintenanceWindowStatusCancelled MaintenanceWindowStatus = "cancelled"
+)
+
+// IsValid checks if the status is valid
+func (s MaintenanceWindowStatus) IsValid() bool {
+	switch s {
+	case MaintenanceWindowStatusScheduled, MaintenanceWindowStatusActive,
+		MaintenanceWindowStatusCompleted, MaintenanceWindowStatusCancelled:
+		return true
+	}
+	return false
+}
+
+// IsFinished returns true if the window can no longer change
+func (s MaintenanceWindowStatus) IsFinished() bool {
+	return s == MaintenanceWindowStatusCompleted || s == MaintenanceWindowStatusCancelled
+}
+
+// String returns the string representation of the status
+func (s MaintenanceWindowStatus) String() string {
+	return string(s)
+}
+
+// MaintenanceWindow is a scheduled maintenance period for a set of nodes.
+// Targets are given as explicit node IDs and/or resource groups; groups are resolved
+// to node IDs when the window starts, so nodes added to a group before then are included.
+type MaintenanceWindow struct {
+	id               uint
+	sid              string // Stripe-style ID: mw_xxxxxxxx
+	reason           string
+	startsAt         time.Time
+	endsAt           time.Time
+	nodeIDs          []uint
+	resourceGroupIDs []uint
+	status           MaintenanceWindowStatus
+	affectedNodeIDs  []uint // all target nodes, resolved when the window starts
+	enteredNodeIDs   []uint // nodes this window moved into maintenance and must restore
+	announcementID   *uint
+	createdBy        uint
+	createdAt        time.Time
+	updatedAt        time.Time
+}
+
+// NewMaintenanceWindow creates a scheduled maintenance window
+func NewMaintenanceWindow(
+	reason string,
+	startsAt, endsAt time.Time,
+	nodeIDs, resourceGroupIDs []uint,
+	createdBy uint,
+	sidGenerator func() (string, error),
+) (*MaintenanceWindow, error) {
+	if reason == "" {
+		return nil, fmt.Errorf("maintenance reason is required")
+	}
+	if len(reason) > MaxMaintenanceReasonLength {
+		return nil, fmt.Errorf("maintenance reason exceeds maximum length of %d characters", MaxMaintenanceReasonLength)
+	}
+	if !endsAt.After(startsAt) {
+		return nil, fmt.Errorf("maintenance window must end after it starts")
+	}
+	if endsAt.Sub(startsAt) > MaxMaintenanceWindowDuration {
+		return nil, fmt.Errorf("maintenance window cannot be longer than %s", MaxMaintenanceWindowDuration)
+	}
+
+	now := biztime.NowUTC()
+	if !endsAt.After(now) {
+		return nil, fmt.Errorf("maintenance window must end in the future")
+	}
+
+	nodeIDs = uniqueIDs(nodeIDs)
+	resourceGroupIDs = uniqueIDs(resourceGroupIDs)
+	if len(nodeIDs) == 0 && len(resourceGroupIDs) == 0 {
+		return nil, fmt.Errorf("maintenance window must target at least one node or resource group")
+	}
+
+	sid, err := sidGenerator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SID: %w", err)
+	}
+
+	return &MaintenanceWindow{
+		sid:              sid,
+		reason:           reason,
+		startsAt:         startsAt.UTC(),
+		endsAt:           endsAt.UTC(),
+		nodeIDs:          nodeIDs,
+		resourceGroupIDs: resourceGroupIDs,
+		status:           MaintenanceWindowStatusScheduled,
+		createdBy:        createdBy,
+		createdAt:        now,
+		updatedAt:        now,
+	}, nil
+}
+
+// ReconstructMaintenanceWindow rebuilds a maintenance window from persistence
+func ReconstructMaintenanceWindow(
+	id uint,
+	sid string,
+	reason string,
+	startsAt, endsAt time.Time,
+	nodeIDs, resourceGroupIDs []uint,
+	status string,
+	affectedNodeIDs, enteredNodeIDs []uint,
+	announcementID *uint,
+	createdBy uint,
+	createdAt, updatedAt time.Time,
+) (*MaintenanceWindow, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("maintenance window ID cannot be zero")
+	}
+	if sid == "" {
+		return nil, fmt.Errorf("maintenance window SID is required")
+	}
+
+	windowStatus := MaintenanceWindowStatus(status)
+	if !windowStatus.IsValid() {
+		return nil, fmt.Errorf("invalid maintenance window status: %s", status)
+	}
+
+	return &MaintenanceWindow{
+		id:               id,
+		sid:              sid,
+		reason:           reason,
+		startsAt:         startsAt,
+		endsAt:           endsAt,
+		nodeIDs:          nodeIDs,
+		resourceGroupIDs: resourceGroupIDs,
+		status:           windowStatus,
+		affectedNodeIDs:  affectedNodeIDs,
+		enteredNodeIDs:   enteredNodeIDs,
+		announcementID:   announcementID,
+		createdBy:        createdBy,
+		createdAt:        createdAt,
+		updatedAt:        updatedAt,
+	}, nil
+}
+
+// ID returns the internal ID
+func (w *MaintenanceWindow) ID() uint {
+	return w.id
+}
+
+// SID returns the Stripe-style short ID
+func (w *MaintenanceWindow) SID() string {
+	return w.sid
+}
+
+// Reason returns the maintenance reason shown to users and set on the nodes
+func (w *MaintenanceWindow) Reason() string {
+	return w.reason
+}
+
+// StartsAt returns when the affected nodes enter maintenance
+func (w *MaintenanceWindow) StartsAt() time.Time {
+	return w.startsAt
+}
+
+// EndsAt returns when the affected nodes are restored
+func (w *MaintenanceWindow) EndsAt() time.Time {
+	return w.endsAt
+}
+
+// NodeIDs returns the explicitly targeted node IDs
+func (w *MaintenanceWindow) NodeIDs() []uint {
+	return w.nodeIDs
+}
+
+// ResourceGroupIDs returns the targeted resource group IDs
+func (w *MaintenanceWindow) ResourceGroupIDs() []uint {
+	return w.resourceGroupIDs
+}
+
+// Status returns the window status
+func (w *MaintenanceWindow) Status() MaintenanceWindowStatus {
+	return w.status
+}
+
+// AffectedNodeIDs returns every node covered by the window, resolved when it started
+func (w *MaintenanceWindow) AffectedNodeIDs() []uint {
+	return w.affectedNodeIDs
+}
+
+// EnteredNodeIDs returns the nodes this window put into maintenance.
+// Nodes that were already in maintenance or inactive are not included and are left untouched at the end.
+func (w *MaintenanceWindow) EnteredNodeIDs() []uint {
+	return w.enteredNodeIDs
+}
+
+// AnnouncementID returns the ID of the published maintenance announcement (nil if none)
+func (w *MaintenanceWindow) AnnouncementID() *uint {
+	return w.announcementID
+}
+
+// CreatedBy returns the ID of the admin who scheduled the window
+func (w *MaintenanceWindow) CreatedBy() uint {
+	return w.createdBy
+}
+
+// CreatedAt returns the creation time
+func (w *MaintenanceWindow) CreatedAt() time.Time {
+	return w.createdAt
+}
+
+// UpdatedAt returns the last update time
+func (w *MaintenanceWindow) UpdatedAt() time.Time {
+	return w.updatedAt
+}
+
+// SetID sets the ID after persistence
+func (w *MaintenanceWindow) SetID(id uint) error {
+	if w.id != 0 {
+		return fmt.Errorf("maintenance window ID is already set")
+	}
+	if id == 0 {
+		return fmt.Errorf("maintenance window ID cannot be zero")
+	}
+	w.id = id
+	return nil
+}
+
+// SetAnnouncementID records the maintenance announcement published for this window
+func (w *MaintenanceWindow) SetAnnouncementID(announcementID uint) {
+	w.announcementID = &announcementID
+	w.updatedAt = biztime.NowUTC()
+}
+
+// IsDueToStart reports whether a scheduled window should start at the given time
+func (w *MaintenanceWindow) IsDueToStart(now time.Time) bool {
+	return w.status == MaintenanceWindowStatusScheduled && !now.Before(w.startsAt)
+}
+
+// IsDueToEnd reports whether the window should be finished at the given time
+func (w *MaintenanceWindow) IsDueToEnd(now time.Time) bool {
+	return !w.status.IsFinished() && !now.Before(w.endsAt)
+}
+
+// Start marks the window as active with the resolved target nodes and the subset it put into maintenance
+func (w *MaintenanceWindow) Start(affectedNodeIDs, enteredNodeIDs []uint) error {
+	if w.status != MaintenanceWindowStatusScheduled {
+		return fmt.Errorf("cannot start maintenance window with status %s", w.status)
+	}
+
+	w.status = MaintenanceWindowStatusActive
+	w.affectedNodeIDs = uniqueIDs(affectedNodeIDs)
+	w.enteredNodeIDs = uniqueIDs(enteredNodeIDs)
+	w.updatedAt = biztime.NowUTC()
+
+	return nil
+}
+
+// AdoptNodes adds nodes handed over by an overlapping window that ended while this window
+// still covers them, so that this window restores them when it ends
+func (w *MaintenanceWindow) AdoptNodes(nodeIDs []uint) error {
+	if w.status != MaintenanceWindowStatusActive {
+		return fmt.Errorf("cannot adopt nodes into maintenance window with status %s", w.status)
+	}
+
+	w.enteredNodeIDs = uniqueIDs(append(slices.Clone(w.enteredNodeIDs), nodeIDs...))
+	w.updatedAt = biztime.NowUTC()
+
+	return nil
+}
+
+// Complete marks the window as finished after its nodes were restored
+func (w *MaintenanceWindow) Complete() error {
+	if w.status.IsFinished() {
+		return fmt.Errorf("maintenance window is already %s", w.status)
+	}
+
+	w.status = MaintenanceWindowStatusCompleted
+	w.updatedAt = biztime.NowUTC()
+
+	return nil
+}
+
+// Cancel cancels the window. Active windows must have their nodes restored by the caller.
+func (w *MaintenanceWindow) Cancel() error {
+	if w.status.IsFinished() {
+		return fmt.Errorf("maintenance window is already %s", w.status)
+	}
+
+	w.status = MaintenanceWindowStatusCancelled
+	w.updatedAt = biztime.NowUTC()
+
+	return nil
+}
+
+// uniqueIDs returns the non-zero IDs in ascending order without duplicates
+func uniqueIDs(ids []uint) []uint {
+	result := make([]uint, 0, len(ids))
+	for _, v := range ids {
+		if v != 0 {
+			result = append(result, v)
+		}
+	}
+	slices.Sort(result)
+	return slices.Compact(result)
+}
@@ -0,0 +1,85 @@
+package node
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+func newTestMaintenanceWindow(t *testing.T, startsAt, endsAt time.Time) *MaintenanceWindow {
+	t.Helper()
+	w, err := NewMaintenanceWindow("kernel upgrade", startsAt, endsAt, []uint{3, 1, 3, 0}, []uint{7}, 1, fakeSIDGenerator("mw_test123"))
+	require.NoError(t, err)
+	return w
+}
+
+func TestNewMaintenanceWindow_Validation(t *testing.T) {
+	start := biztime.NowUTC().Add(time.Hour)
+	sid := fakeSIDGenerator("mw_test123")
+
+	_, err := NewMaintenanceWindow("", start, start.Add(time.Hour), []uint{1}, nil, 1, sid)
+	assert.Error(t, err, "reason required")
+
+	_, err = NewMaintenanceWindow("upgrade", start, start, []uint{1}, nil, 1, sid)
+	assert.Error(t, err, "must end after start")
+
+	_, err = NewMaintenanceWindow("upgrade", start, start.Add(MaxMaintenanceWindowDuration+time.Minute), []uint{1}, nil, 1, sid)
+	assert.Error(t, err, "too long")
+
+	_, err = NewMaintenanceWindow("upgrade", start.Add(-3*time.Hour), start.Add(-2*time.Hour), []uint{1}, nil, 1, sid)
+	assert.Error(t, err, "already over")
+
+	_, err = NewMaintenanceWindow("upgrade", start, start.Add(time.Hour), []uint{0}, nil, 1, sid)
+	assert.Error(t, err, "no targets")
+
+	w := newTestMaintenanceWindow(t, start, start.Add(time.Hour))
+	assert.Equal(t, []uint{1, 3}, w.NodeIDs(), "targets deduplicated")
+	assert.Equal(t, MaintenanceWindowStatusScheduled, w.Status())
+}
+
+func TestMaintenanceWindow_AdoptNodes(t *testing.T) {
+	start := biztime.NowUTC().Add(time.Hour)
+	w := newTestMaintenanceWindow(t, start, start.Add(time.Hour))
+	assert.Error(t, w.AdoptNodes([]uint{2}), "only active windows adopt nodes")
+
+	require.NoError(t, w.Start([]uint{1, 2, 3}, []uint{3}))
+	require.NoError(t, w.AdoptNodes([]uint{2, 3}))
+	assert.Equal(t, []uint{2, 3}, w.EnteredNodeIDs())
+}
+
+func TestMaintenanceWindow_Lifecycle(t *testing.T) {
+	start := biztime.NowUTC().Add(time.Hour)
+	end := start.Add(2 * time.Hour)
+	w := newTestMaintenanceWindow(t, start, end)
+
+	assert.False(t, w.IsDueToStart(start.Add(-time.Minute)))
+	assert.True(t, w.IsDueToStart(start))
+	assert.False(t, w.IsDueToEnd(start))
+
+	require.NoError(t, w.Start([]uint{1, 3, 5}, []uint{3, 1}))
+	assert.Equal(t, MaintenanceWindowStatusActive, w.Status())
+	assert.Equal(t, []uint{1, 3}, w.EnteredNodeIDs())
+	assert.False(t, w.IsDueToStart(start), "already started")
+	assert.Error(t, w.Start(nil, nil))
+
+	assert.True(t, w.IsDueToEnd(end))
+	require.NoError(t, w.Complete())
+	assert.False(t, w.IsDueToEnd(end.Add(time.Hour)), "finished windows are never due")
+	assert.Error(t, w.Cancel())
+}
+
+func TestMaintenanceWindow_EndsWithoutStarting(t *testing.T) {
+	start := biztime.NowUTC().Add(time.Hour)
+	w := newTestMaintenanceWindow(t, start, start.Add(time.Hour))
+
+	// A window missed entirely (e.g. scheduler down) is due to end without ever starting
+	late := start.Add(2 * time.Hour)
+	assert.True(t, w.IsDueToStart(late))
+	assert.True(t, w.IsDueToEnd(late))
+	require.NoError(t, w.Complete())
+	assert.Empty(t, w.EnteredNodeIDs())
+}
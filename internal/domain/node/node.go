@@ -26,12 +26,14 @@ type Node struct {
 	protocol          vo.Protocol
 	encryptionConfig  vo.EncryptionConfig
 	pluginConfig      *vo.PluginConfig
+	shadowTLSConfig   *vo.ShadowTLSConfig // optional ShadowTLS layer for Shadowsocks nodes
 	trojanConfig      *vo.TrojanConfig
 	vlessConfig       *vo.VLESSConfig
 	vmessConfig       *vo.VMessConfig
 	hysteria2Config   *vo.Hysteria2Config
 	tuicConfig        *vo.TUICConfig
 	anytlsConfig      *vo.AnyTLSConfig
+	wireguardConfig   *vo.WireGuardConfig
 	status            vo.NodeStatus
 	metadata          vo.NodeMetadata
 	groupIDs          []uint // resource group IDs
@@ -51,6 +53,8 @@ type Node struct {
 	arch              *string              // CPU architecture (amd64, arm64, arm, 386)
 	expiresAt         *time.Time           // expiration time (nil = never expires)
 	costLabel         *string              // cost label for display (e.g., "35$/m", "35¥/y")
+	countryCode       *vo.CountryCode      // ISO 3166-1 alpha-2 location used for subscription region groups
+	trafficMultiplier float64              // billing rate applied to subscription usage reported by this node
 	version           int
 	originalVersion   int // version when loaded from database, for optimistic locking
 	createdAt         time.Time
@@ -67,12 +71,14 @@ func NewNode(
 	protocol vo.Protocol,
 	encryptionConfig vo.EncryptionConfig,
 	pluginConfig *vo.PluginConfig,
+	shadowTLSConfig *vo.ShadowTLSConfig,
 	trojanConfig *vo.TrojanConfig,
 	vlessConfig *vo.VLESSConfig,
 	vmessConfig *vo.VMessConfig,
 	hysteria2Config *vo.Hysteria2Config,
 	tuicConfig *vo.TUICConfig,
 	anytlsConfig *vo.AnyTLSConfig,
+	wireguardConfig *vo.WireGuardConfig,
 	metadata vo.NodeMetadata,
 	sortOrder int,
 	routeConfig *routing.RouteConfig,
@@ -111,6 +117,15 @@ func NewNode(
 	if protocol.IsAnyTLS() && anytlsConfig == nil {
 		return nil, fmt.Errorf("anytls config is required for AnyTLS protocol")
 	}
+	if protocol.IsWireGuard() && wireguardConfig == nil {
+		return nil, fmt.Errorf("wireguard config is required for WireGuard protocol")
+	}
+	if shadowTLSConfig != nil && !protocol.IsShadowsocks() {
+		return nil, fmt.Errorf("shadowtls is only supported for Shadowsocks protocol")
+	}
+	if shadowTLSConfig != nil && pluginConfig != nil {
+		return nil, fmt.Errorf("shadowtls cannot be combined with a Shadowsocks plugin")
+	}
 
 	// Validate route config if provided
 	if routeConfig != nil {
@@ -140,31 +155,34 @@ func NewNode(
 
 	now := biztime.NowUTC()
 	n := &Node{
-		sid:              sid,
-		name:             name,
-		serverAddress:    serverAddress,
-		agentPort:        agentPort,
-		subscriptionPort: subscriptionPort,
-		protocol:         protocol,
-		encryptionConfig: encryptionConfig,
-		pluginConfig:     pluginConfig,
-		trojanConfig:     trojanConfig,
-		vlessConfig:      vlessConfig,
-		vmessConfig:      vmessConfig,
-		hysteria2Config:  hysteria2Config,
-		tuicConfig:       tuicConfig,
-		anytlsConfig:     anytlsConfig,
-		status:           vo.NodeStatusInactive,
-		metadata:         metadata,
-		apiToken:         plainToken,
-		tokenHash:        tokenHash,
-		sortOrder:        sortOrder,
-		routeConfig:      routeConfig,
-		dnsConfig:        dnsConfig,
-		version:          1,
-		createdAt:        now,
-		updatedAt:        now,
-		tokenGenerator:   tokenGen,
+		sid:               sid,
+		name:              name,
+		serverAddress:     serverAddress,
+		agentPort:         agentPort,
+		subscriptionPort:  subscriptionPort,
+		protocol:          protocol,
+		encryptionConfig:  encryptionConfig,
+		pluginConfig:      pluginConfig,
+		shadowTLSConfig:   shadowTLSConfig,
+		trojanConfig:      trojanConfig,
+		vlessConfig:       vlessConfig,
+		vmessConfig:       vmessConfig,
+		hysteria2Config:   hysteria2Config,
+		tuicConfig:        tuicConfig,
+		anytlsConfig:      anytlsConfig,
+		wireguardConfig:   wireguardConfig,
+		status:            vo.NodeStatusInactive,
+		metadata:          metadata,
+		apiToken:          plainToken,
+		tokenHash:         tokenHash,
+		sortOrder:         sortOrder,
+		routeConfig:       routeConfig,
+		dnsConfig:         dnsConfig,
+		trafficMultiplier: DefaultTrafficMultiplier,
+		version:           1,
+		createdAt:         now,
+		updatedAt:         now,
+		tokenGenerator:    tokenGen,
 	}
 
 	return n, nil
@@ -181,12 +199,14 @@ func ReconstructNode(
 	protocol vo.Protocol,
 	encryptionConfig vo.EncryptionConfig,
 	pluginConfig *vo.PluginConfig,
+	shadowTLSConfig *vo.ShadowTLSConfig,
 	trojanConfig *vo.TrojanConfig,
 	vlessConfig *vo.VLESSConfig,
 	vmessConfig *vo.VMessConfig,
 	hysteria2Config *vo.Hysteria2Config,
 	tuicConfig *vo.TUICConfig,
 	anytlsConfig *vo.AnyTLSConfig,
+	wireguardConfig *vo.WireGuardConfig,
 	status vo.NodeStatus,
 	metadata vo.NodeMetadata,
 	groupIDs []uint,
@@ -206,6 +226,8 @@ func ReconstructNode(
 	arch *string,
 	expiresAt *time.Time,
 	costLabel *string,
+	countryCode *vo.CountryCode,
+	trafficMultiplier float64,
 	version int,
 	createdAt, updatedAt time.Time,
 ) (*Node, error) {
@@ -238,12 +260,14 @@ func ReconstructNode(
 		protocol:          protocol,
 		encryptionConfig:  encryptionConfig,
 		pluginConfig:      pluginConfig,
+		shadowTLSConfig:   shadowTLSConfig,
 		trojanConfig:      trojanConfig,
 		vlessConfig:       vlessConfig,
 		vmessConfig:       vmessConfig,
 		hysteria2Config:   hysteria2Config,
 		tuicConfig:        tuicConfig,
 		anytlsConfig:      anytlsConfig,
+		wireguardConfig:   wireguardConfig,
 		status:            status,
 		metadata:          metadata,
 		groupIDs:          groupIDs,
@@ -263,6 +287,8 @@ func ReconstructNode(
 		arch:              arch,
 		expiresAt:         expiresAt,
 		costLabel:         costLabel,
+		countryCode:       countryCode,
+		trafficMultiplier: trafficMultiplier,
 		version:           version,
 		originalVersion:   version, // preserve original version for optimistic locking
 		createdAt:         createdAt,
@@ -327,6 +353,11 @@ func (n *Node) PluginConfig() *vo.PluginConfig {
 	return n.pluginConfig
 }
 
+// ShadowTLSConfig returns the ShadowTLS configuration (nil when not enabled)
+func (n *Node) ShadowTLSConfig() *vo.ShadowTLSConfig {
+	return n.shadowTLSConfig
+}
+
 // TrojanConfig returns the trojan configuration
 func (n *Node) TrojanConfig() *vo.TrojanConfig {
 	return n.trojanConfig
@@ -357,6 +388,11 @@ func (n *Node) AnyTLSConfig() *vo.AnyTLSConfig {
 	return n.anytlsConfig
 }
 
+// WireGuardConfig returns the WireGuard configuration
+func (n *Node) WireGuardConfig() *vo.WireGuardConfig {
+	return n.wireguardConfig
+}
+
 // Status returns the node status
 func (n *Node) Status() vo.NodeStatus {
 	return n.status
@@ -446,6 +482,16 @@ func (n *Node) CostLabel() *string {
 	return n.costLabel
 }
 
+// CountryCode returns the node's country code (nil means not set)
+func (n *Node) CountryCode() *vo.CountryCode {
+	return n.countryCode
+}
+
+// TrafficMultiplier returns the billing rate applied to subscription usage on this node
+func (n *Node) TrafficMultiplier() float64 {
+	return n.trafficMultiplier
+}
+
 // MuteNotification returns whether notifications are muted for this node
 func (n *Node) MuteNotification() bool {
 	return n.muteNotification
@@ -523,6 +569,15 @@ func (n *Node) Validate() error {
 	if n.protocol.IsAnyTLS() && n.anytlsConfig == nil {
 		return fmt.Errorf("anytls config is required for AnyTLS protocol")
 	}
+	if n.protocol.IsWireGuard() && n.wireguardConfig == nil {
+		return fmt.Errorf("wireguard config is required for WireGuard protocol")
+	}
+	if n.shadowTLSConfig != nil && !n.protocol.IsShadowsocks() {
+		return fmt.Errorf("shadowtls is only supported for Shadowsocks protocol")
+	}
+	if n.shadowTLSConfig != nil && n.pluginConfig != nil {
+		return fmt.Errorf("shadowtls cannot be combined with a Shadowsocks plugin")
+	}
 	if n.status == vo.NodeStatusMaintenance && n.maintenanceReason == nil {
 		return fmt.Errorf("maintenance reason is required when in maintenance mode")
 	}
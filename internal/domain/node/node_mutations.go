@@ -84,6 +84,28 @@ func (n *Node) UpdateEncryption(config vo.EncryptionConfig) error {
 	return nil
 }
 
+// RotateServerKey replaces the SS2022 server key with a freshly generated one.
+// Clients must refresh their subscriptions afterwards since the key is part of their password.
+func (n *Node) RotateServerKey() error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.protocol.IsShadowsocks() {
+		return fmt.Errorf("server key rotation is only supported for shadowsocks nodes")
+	}
+
+	rotated, err := n.encryptionConfig.RotateServerKey()
+	if err != nil {
+		return err
+	}
+
+	n.encryptionConfig = rotated
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return nil
+}
+
 // UpdatePlugin updates the plugin configuration
 func (n *Node) UpdatePlugin(config *vo.PluginConfig) error {
 	n.mu.Lock()
@@ -96,6 +118,22 @@ func (n *Node) UpdatePlugin(config *vo.PluginConfig) error {
 	return nil
 }
 
+// UpdateShadowTLSConfig enables, replaces or (with nil) disables the ShadowTLS layer
+func (n *Node) UpdateShadowTLSConfig(config *vo.ShadowTLSConfig) error {
+	if config != nil && !n.protocol.IsShadowsocks() {
+		return fmt.Errorf("cannot update shadowtls config for non-shadowsocks protocol")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.shadowTLSConfig = config
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return nil
+}
+
 // UpdateTrojanConfig updates the trojan configuration
 func (n *Node) UpdateTrojanConfig(config *vo.TrojanConfig) error {
 	if !n.protocol.IsTrojan() {
@@ -192,6 +230,22 @@ func (n *Node) UpdateAnyTLSConfig(config *vo.AnyTLSConfig) error {
 	return nil
 }
 
+// UpdateWireGuardConfig updates the WireGuard configuration
+func (n *Node) UpdateWireGuardConfig(config *vo.WireGuardConfig) error {
+	if !n.protocol.IsWireGuard() {
+		return fmt.Errorf("cannot update wireguard config for non-wireguard protocol")
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.wireguardConfig = config
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return nil
+}
+
 // UpdateMetadata updates the node metadata
 func (n *Node) UpdateMetadata(metadata vo.NodeMetadata) error {
 	n.mu.Lock()
@@ -322,6 +376,16 @@ func (n *Node) SetCostLabel(label *string) {
 	n.version++
 }
 
+// SetCountryCode sets the country code (nil to clear)
+func (n *Node) SetCountryCode(code *vo.CountryCode) {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	n.countryCode = code
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+}
+
 // --- Group and ownership mutations ---
 
 // SetUserID sets the owner user ID
@@ -0,0 +1,89 @@
+package node
+
+import (
+	"fmt"
+	"time"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+// UpdateRealityRotationPolicy sets the scheduled Reality rotation policy, keeping the rotation state
+func (n *Node) UpdateRealityRotationPolicy(policy vo.RealityRotation) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.protocol.IsVLESS() || n.vlessConfig == nil {
+		return fmt.Errorf("reality rotation policy is only supported for vless nodes")
+	}
+
+	current := n.vlessConfig.RealityRotation()
+	next := current.WithPolicy(policy)
+	if next.Equals(current) {
+		return nil
+	}
+
+	updated := n.vlessConfig.WithRealityRotation(next)
+	n.vlessConfig = &updated
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return nil
+}
+
+// RotateRealityKeys replaces the Reality short ID (and the key pair if rotateKeyPair is set).
+// The previous short ID stays accepted for the overlap window configured in the rotation policy.
+func (n *Node) RotateRealityKeys(rotateKeyPair bool) error {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.protocol.IsVLESS() || n.vlessConfig == nil || n.vlessConfig.Security() != vo.VLESSSecurityReality {
+		return fmt.Errorf("reality rotation is only supported for vless nodes using reality security")
+	}
+
+	now := biztime.NowUTC()
+	rotated, err := n.vlessConfig.RotateReality(rotateKeyPair, n.vlessConfig.RealityRotation().EffectiveOverlap(), now)
+	if err != nil {
+		return err
+	}
+
+	n.vlessConfig = &rotated
+	n.updatedAt = now
+	n.version++
+
+	return nil
+}
+
+// IsRealityRotationDue reports whether the scheduled Reality rotation should run at the given time.
+// Nodes that were never rotated are measured from their creation time.
+func (n *Node) IsRealityRotationDue(now time.Time) bool {
+	n.mu.RLock()
+	defer n.mu.RUnlock()
+
+	if !n.protocol.IsVLESS() || n.vlessConfig == nil {
+		return false
+	}
+	return n.vlessConfig.IsRealityRotationDue(now, n.createdAt)
+}
+
+// ExpireRealityShortID drops the previous Reality short ID once its overlap window has closed.
+// Returns true if the node changed and the new config needs to be pushed to the agent.
+func (n *Node) ExpireRealityShortID(now time.Time) bool {
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if !n.protocol.IsVLESS() || n.vlessConfig == nil {
+		return false
+	}
+
+	updated, changed := n.vlessConfig.ExpirePreviousShortID(now)
+	if !changed {
+		return false
+	}
+
+	n.vlessConfig = &updated
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+
+	return true
+}
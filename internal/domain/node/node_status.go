@@ -75,3 +75,48 @@ func (n *Node) GenerateSubscriptionURI(password string, remarks string) (string,
 		return "", fmt.Errorf("unsupported protocol: %s", n.protocol)
 	}
 }
+
+// TLSServerName returns the server name the node presents a TLS certificate for.
+// Only protocols that terminate TLS with a regular certificate (Trojan, VLESS with TLS
+// security, Hysteria2, TUIC and AnyTLS) have one. The configured SNI is used, falling back
+// to the server address when it is a domain. Returns empty string if there is none.
+func (n *Node) TLSServerName() string {
+	var sni string
+	switch n.protocol {
+	case vo.ProtocolTrojan:
+		if n.trojanConfig == nil {
+			return ""
+		}
+		sni = n.trojanConfig.SNI()
+	case vo.ProtocolVLESS:
+		if n.vlessConfig == nil || n.vlessConfig.Security() != vo.VLESSSecurityTLS {
+			return ""
+		}
+		sni = n.vlessConfig.SNI()
+	case vo.ProtocolHysteria2:
+		if n.hysteria2Config == nil {
+			return ""
+		}
+		sni = n.hysteria2Config.SNI()
+	case vo.ProtocolTUIC:
+		if n.tuicConfig == nil {
+			return ""
+		}
+		sni = n.tuicConfig.SNI()
+	case vo.ProtocolAnyTLS:
+		if n.anytlsConfig == nil {
+			return ""
+		}
+		sni = n.anytlsConfig.SNI()
+	default:
+		return ""
+	}
+
+	if sni != "" {
+		return NormalizeCertificateDomain(sni)
+	}
+	if n.serverAddress.IsDomain() {
+		return NormalizeCertificateDomain(n.serverAddress.Value())
+	}
+	return ""
+}
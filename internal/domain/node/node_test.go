@@ -1,6 +1,7 @@
 package node
 
 import (
+	"math"
 	"testing"
 	"time"
 
@@ -34,17 +35,19 @@ func newShadowsocksNode(t *testing.T) *Node {
 	n, err := NewNode(
 		"test-ss-node",
 		addr,
-		8388,  // agentPort
-		nil,   // subscriptionPort
+		8388, // agentPort
+		nil,  // subscriptionPort
 		vo.ProtocolShadowsocks,
 		enc,
 		nil, // pluginConfig
+		nil, // shadowTLSConfig
 		nil, // trojanConfig
 		nil, // vlessConfig
 		nil, // vmessConfig
 		nil, // hysteria2Config
 		nil, // tuicConfig
 		nil, // anytlsConfig
+		nil, // wireguardConfig
 		meta,
 		0,   // sortOrder
 		nil, // routeConfig
@@ -74,12 +77,14 @@ func newTrojanNode(t *testing.T) *Node {
 		vo.ProtocolTrojan,
 		vo.EncryptionConfig{}, // not needed for trojan
 		nil,
+		nil,
 		&trojanCfg,
 		nil,
 		nil,
 		nil,
 		nil,
 		nil, // anytlsConfig
+		nil, // wireguardConfig
 		meta,
 		0,
 		nil, // routeConfig
@@ -109,25 +114,27 @@ func reconstructedNode(t *testing.T, status vo.NodeStatus) *Node {
 	}
 
 	n, err := ReconstructNode(
-		1,                // id
-		"node_recon001",  // sid
-		"recon-node",     // name
-		addr,             // serverAddress
-		8388,             // agentPort
-		nil,              // subscriptionPort
+		1,               // id
+		"node_recon001", // sid
+		"recon-node",    // name
+		addr,            // serverAddress
+		8388,            // agentPort
+		nil,             // subscriptionPort
 		vo.ProtocolShadowsocks,
 		enc,
 		nil,    // pluginConfig
+		nil,    // shadowTLSConfig
 		nil,    // trojanConfig
 		nil,    // vlessConfig
 		nil,    // vmessConfig
 		nil,    // hysteria2Config
 		nil,    // tuicConfig
 		nil,    // anytlsConfig
+		nil,    // wireguardConfig
 		status, // status
 		meta,
-		[]uint{1, 2},    // groupIDs
-		nil,              // userID
+		[]uint{1, 2}, // groupIDs
+		nil,          // userID
 		"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234", // tokenHash (64 hex chars)
 		"",     // apiToken (cleared)
 		0,      // sortOrder
@@ -143,6 +150,8 @@ func reconstructedNode(t *testing.T, status vo.NodeStatus) *Node {
 		nil,    // arch
 		nil,    // expiresAt
 		nil,    // costLabel
+		nil,    // countryCode
+		1.0,    // trafficMultiplier
 		1,      // version
 		now,    // createdAt
 		now,    // updatedAt
@@ -169,7 +178,7 @@ func TestNewNode_ValidInput_Shadowsocks(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		meta,
 		10,
 		nil, // routeConfig
@@ -218,7 +227,7 @@ func TestNewNode_ValidInput_Trojan(t *testing.T) {
 		&subPort,
 		vo.ProtocolTrojan,
 		vo.EncryptionConfig{},
-		nil, &trojanCfg, nil, nil, nil, nil, nil,
+		nil, nil, &trojanCfg, nil, nil, nil, nil, nil, nil,
 		meta,
 		0,
 		nil, // routeConfig
@@ -253,6 +262,8 @@ func TestNewNode_ValidInput_VLESS(t *testing.T) {
 		"pubkey456",        // publicKey
 		"abcd1234",         // shortID
 		"",                 // spiderX
+		"",                 // xhttpMode
+		"",                 // xhttpExtra
 	)
 	require.NoError(t, err)
 
@@ -265,7 +276,7 @@ func TestNewNode_ValidInput_VLESS(t *testing.T) {
 		nil,
 		vo.ProtocolVLESS,
 		vo.EncryptionConfig{},
-		nil, nil, &vlessCfg, nil, nil, nil, nil,
+		nil, nil, nil, &vlessCfg, nil, nil, nil, nil, nil,
 		meta,
 		0,
 		nil, // routeConfig
@@ -284,15 +295,17 @@ func TestNewNode_ValidInput_VMess(t *testing.T) {
 	require.NoError(t, err)
 
 	vmessCfg, err := vo.NewVMessConfig(
-		0,          // alterID
-		"auto",     // security
-		"ws",       // transportType
-		"cdn.com",  // host
-		"/vmess",   // path
-		"",         // serviceName
-		true,       // tls
-		"cdn.com",  // sni
-		false,      // allowInsecure
+		0,         // alterID
+		"auto",    // security
+		"ws",      // transportType
+		"cdn.com", // host
+		"/vmess",  // path
+		"",        // serviceName
+		true,      // tls
+		"cdn.com", // sni
+		false,     // allowInsecure
+		"",        // xhttpMode
+		"",        // xhttpExtra
 	)
 	require.NoError(t, err)
 
@@ -305,7 +318,7 @@ func TestNewNode_ValidInput_VMess(t *testing.T) {
 		nil,
 		vo.ProtocolVMess,
 		vo.EncryptionConfig{},
-		nil, nil, nil, &vmessCfg, nil, nil, nil,
+		nil, nil, nil, nil, &vmessCfg, nil, nil, nil, nil,
 		meta,
 		0,
 		nil, // routeConfig
@@ -324,15 +337,15 @@ func TestNewNode_ValidInput_Hysteria2(t *testing.T) {
 	require.NoError(t, err)
 
 	hy2Cfg, err := vo.NewHysteria2Config(
-		"securepass123",  // password
-		"bbr",            // congestionControl
-		"",               // obfs
-		"",               // obfsPassword
-		nil,              // upMbps
-		nil,              // downMbps
+		"securepass123",   // password
+		"bbr",             // congestionControl
+		"",                // obfs
+		"",                // obfsPassword
+		nil,               // upMbps
+		nil,               // downMbps
 		"hy2.example.com", // sni
-		false,            // allowInsecure
-		"chrome",         // fingerprint
+		false,             // allowInsecure
+		"chrome",          // fingerprint
 	)
 	require.NoError(t, err)
 
@@ -345,7 +358,7 @@ func TestNewNode_ValidInput_Hysteria2(t *testing.T) {
 		nil,
 		vo.ProtocolHysteria2,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, &hy2Cfg, nil, nil,
+		nil, nil, nil, nil, nil, &hy2Cfg, nil, nil, nil,
 		meta,
 		0,
 		nil, // routeConfig
@@ -364,14 +377,14 @@ func TestNewNode_ValidInput_TUIC(t *testing.T) {
 	require.NoError(t, err)
 
 	tuicCfg, err := vo.NewTUICConfig(
-		"some-uuid-value",    // uuid
-		"some-password",      // password
-		"bbr",                // congestionControl
-		"native",             // udpRelayMode
-		"h3",                 // alpn
-		"tuic.example.com",   // sni
-		false,                // allowInsecure
-		false,                // disableSNI
+		"some-uuid-value",  // uuid
+		"some-password",    // password
+		"bbr",              // congestionControl
+		"native",           // udpRelayMode
+		"h3",               // alpn
+		"tuic.example.com", // sni
+		false,              // allowInsecure
+		false,              // disableSNI
 	)
 	require.NoError(t, err)
 
@@ -384,7 +397,7 @@ func TestNewNode_ValidInput_TUIC(t *testing.T) {
 		nil,
 		vo.ProtocolTUIC,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, &tuicCfg, nil,
+		nil, nil, nil, nil, nil, nil, &tuicCfg, nil, nil,
 		meta,
 		0,
 		nil, // routeConfig
@@ -407,9 +420,9 @@ func TestNewNode_InvalidProtocol(t *testing.T) {
 		addr,
 		8388,
 		nil,
-		vo.Protocol("wireguard"), // not a valid protocol
+		vo.Protocol("openvpn"), // not a valid protocol
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -435,7 +448,7 @@ func TestNewNode_MissingName(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -461,7 +474,7 @@ func TestNewNode_MissingAgentPort(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -484,7 +497,7 @@ func TestNewNode_ShadowsocksMissingEncryption(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		vo.EncryptionConfig{}, // empty encryption config
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -507,7 +520,7 @@ func TestNewNode_TrojanMissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolTrojan,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil, // trojanConfig = nil, anytlsConfig = nil
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, // trojanConfig = nil, anytlsConfig = nil
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -530,7 +543,7 @@ func TestNewNode_VLESSMissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolVLESS,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil, // vlessConfig = nil, anytlsConfig = nil
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, // vlessConfig = nil, anytlsConfig = nil
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -553,7 +566,7 @@ func TestNewNode_VMessMissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolVMess,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -576,7 +589,7 @@ func TestNewNode_Hysteria2MissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolHysteria2,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -599,7 +612,7 @@ func TestNewNode_TUICMissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolTUIC,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -627,13 +640,15 @@ func TestNewNode_ValidInput_AnyTLS(t *testing.T) {
 		nil,
 		vo.ProtocolAnyTLS,
 		vo.EncryptionConfig{},
-		nil,          // pluginConfig
-		nil,          // trojanConfig
-		nil,          // vlessConfig
-		nil,          // vmessConfig
-		nil,          // hysteria2Config
-		nil,          // tuicConfig
-		&anytlsCfg,   // anytlsConfig
+		nil,        // pluginConfig
+		nil,        // shadowTLSConfig
+		nil,        // trojanConfig
+		nil,        // vlessConfig
+		nil,        // vmessConfig
+		nil,        // hysteria2Config
+		nil,        // tuicConfig
+		&anytlsCfg, // anytlsConfig
+		nil,        // wireguardConfig
 		meta,
 		0,
 		nil, // routeConfig
@@ -661,7 +676,7 @@ func TestNewNode_AnyTLSMissingConfig(t *testing.T) {
 		nil,
 		vo.ProtocolAnyTLS,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, nil, // anytlsConfig = nil
+		nil, nil, nil, nil, nil, nil, nil, nil, nil, // anytlsConfig = nil
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -704,12 +719,12 @@ func TestReconstructNode_ZeroID(t *testing.T) {
 	_, err = ReconstructNode(
 		0, "node_x", "name", addr, 8388, nil,
 		vo.ProtocolShadowsocks, enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NodeStatusActive,
 		vo.NewNodeMetadata("", nil, ""),
 		nil, nil,
 		"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
-		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 	)
 
 	require.Error(t, err)
@@ -726,12 +741,12 @@ func TestReconstructNode_EmptySID(t *testing.T) {
 	_, err = ReconstructNode(
 		1, "", "name", addr, 8388, nil,
 		vo.ProtocolShadowsocks, enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NodeStatusActive,
 		vo.NewNodeMetadata("", nil, ""),
 		nil, nil,
 		"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
-		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 	)
 
 	require.Error(t, err)
@@ -748,12 +763,12 @@ func TestReconstructNode_EmptyTokenHash(t *testing.T) {
 	_, err = ReconstructNode(
 		1, "node_x", "name", addr, 8388, nil,
 		vo.ProtocolShadowsocks, enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NodeStatusActive,
 		vo.NewNodeMetadata("", nil, ""),
 		nil, nil,
 		"", // empty tokenHash
-		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+		"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 	)
 
 	require.Error(t, err)
@@ -1130,6 +1145,25 @@ func TestNode_SetCostLabel(t *testing.T) {
 	})
 }
 
+func TestNode_SetCountryCode(t *testing.T) {
+	n := newShadowsocksNode(t)
+	assert.Nil(t, n.CountryCode())
+
+	code, err := vo.NewCountryCode("jp")
+	require.NoError(t, err)
+	n.SetCountryCode(&code)
+
+	require.NotNil(t, n.CountryCode())
+	assert.Equal(t, "JP", n.CountryCode().String())
+	assert.Equal(t, "🇯🇵 Japan", n.CountryCode().DisplayName())
+
+	n.SetCountryCode(nil)
+	assert.Nil(t, n.CountryCode())
+
+	_, err = vo.NewCountryCode("JPN")
+	assert.Error(t, err)
+}
+
 func TestNode_IsExpired(t *testing.T) {
 	t.Run("no expiration", func(t *testing.T) {
 		n := newShadowsocksNode(t)
@@ -1201,14 +1235,14 @@ func TestNode_IsOnline(t *testing.T) {
 		n2, err := ReconstructNode(
 			2, "node_online001", "online-node", addr, 8388, nil,
 			vo.ProtocolShadowsocks, enc,
-			nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil,
 			vo.NodeStatusActive,
 			vo.NewNodeMetadata("", nil, ""),
 			nil, nil,
 			"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
 			"", 0, false, nil, nil, nil,
 			&recentTime, // lastSeenAt = 1 minute ago
-			nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+			nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 		)
 		require.NoError(t, err)
 		assert.True(t, n2.IsOnline())
@@ -1225,14 +1259,14 @@ func TestNode_IsOnline(t *testing.T) {
 		n, err := ReconstructNode(
 			3, "node_stale001", "stale-node", addr, 8388, nil,
 			vo.ProtocolShadowsocks, enc,
-			nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil,
 			vo.NodeStatusActive,
 			vo.NewNodeMetadata("", nil, ""),
 			nil, nil,
 			"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
 			"", 0, false, nil, nil, nil,
 			&staleTime, // lastSeenAt = 10 minutes ago
-			nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+			nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 		)
 		require.NoError(t, err)
 		assert.False(t, n.IsOnline())
@@ -1406,14 +1440,14 @@ func TestNode_EffectiveServerAddress(t *testing.T) {
 		n, err := ReconstructNode(
 			4, "node_fb001", "fallback-node", addr, 8388, nil,
 			vo.ProtocolShadowsocks, enc,
-			nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil,
 			vo.NodeStatusActive,
 			vo.NewNodeMetadata("", nil, ""),
 			nil, nil,
 			"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
 			"", 0, false, nil, nil, nil, nil,
 			&ipv4, // publicIPv4
-			nil, nil, nil, nil, nil, nil, 1, now, now,
+			nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 		)
 		require.NoError(t, err)
 		assert.Equal(t, "203.0.113.1", n.EffectiveServerAddress())
@@ -1429,12 +1463,12 @@ func TestNode_EffectiveServerAddress(t *testing.T) {
 		n, err := ReconstructNode(
 			5, "node_empty001", "empty-addr-node", addr, 8388, nil,
 			vo.ProtocolShadowsocks, enc,
-			nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil,
 			vo.NodeStatusActive,
 			vo.NewNodeMetadata("", nil, ""),
 			nil, nil,
 			"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
-			"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+			"", 0, false, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 		)
 		require.NoError(t, err)
 		assert.Equal(t, "", n.EffectiveServerAddress())
@@ -1515,7 +1549,7 @@ func TestNode_UpdateTrojanConfig_ProtocolMismatch(t *testing.T) {
 func TestNode_UpdateVLESSConfig_ProtocolMismatch(t *testing.T) {
 	n := newShadowsocksNode(t)
 
-	vlessCfg, err := vo.NewVLESSConfig("tcp", "", "none", "", "", false, "", "", "", "", "", "", "")
+	vlessCfg, err := vo.NewVLESSConfig("tcp", "", "none", "", "", false, "", "", "", "", "", "", "", "", "")
 	require.NoError(t, err)
 
 	err = n.UpdateVLESSConfig(&vlessCfg)
@@ -1526,7 +1560,7 @@ func TestNode_UpdateVLESSConfig_ProtocolMismatch(t *testing.T) {
 func TestNode_UpdateVMessConfig_ProtocolMismatch(t *testing.T) {
 	n := newShadowsocksNode(t)
 
-	vmessCfg, err := vo.NewVMessConfig(0, "auto", "tcp", "", "", "", false, "", false)
+	vmessCfg, err := vo.NewVMessConfig(0, "auto", "tcp", "", "", "", false, "", false, "", "")
 	require.NoError(t, err)
 
 	err = n.UpdateVMessConfig(&vmessCfg)
@@ -1581,7 +1615,7 @@ func TestNode_UpdateAnyTLSConfig_CorrectProtocol(t *testing.T) {
 		nil,
 		vo.ProtocolAnyTLS,
 		vo.EncryptionConfig{},
-		nil, nil, nil, nil, nil, nil, &anytlsCfg,
+		nil, nil, nil, nil, nil, nil, nil, &anytlsCfg, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -1638,14 +1672,14 @@ func TestNode_Validate(t *testing.T) {
 		n, err := ReconstructNode(
 			10, "node_val001", "val-node", addr, 8388, nil,
 			vo.ProtocolShadowsocks, enc,
-			nil, nil, nil, nil, nil, nil, nil,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil,
 			vo.NodeStatusMaintenance, // maintenance status
 			vo.NewNodeMetadata("", nil, ""),
 			nil, nil,
 			"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
 			"", 0, false,
 			nil, // maintenanceReason = nil (invalid!)
-			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1, now, now,
+			nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, nil, 1.0, 1, now, now,
 		)
 		require.NoError(t, err, "ReconstructNode does not validate maintenance reason")
 
@@ -1698,14 +1732,14 @@ func TestNode_AgentInfo(t *testing.T) {
 	n, err := ReconstructNode(
 		6, "node_agent001", "agent-info-node", addr, 8388, nil,
 		vo.ProtocolShadowsocks, enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NodeStatusActive,
 		vo.NewNodeMetadata("", nil, ""),
 		nil, nil,
 		"abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234abcd1234",
 		"", 0, false, nil, nil, nil, nil,
 		&ipv4, &ipv6, &agentVersion, &platform, &arch,
-		nil, nil, 1, now, now,
+		nil, nil, nil, 1.0, 1, now, now,
 	)
 	require.NoError(t, err)
 
@@ -1779,7 +1813,7 @@ func TestNewNode_EmptyServerAddress(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil, // routeConfig
@@ -1833,7 +1867,7 @@ func TestNewNode_WithDnsConfig(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("us-west", nil, ""),
 		0,
 		nil,       // routeConfig
@@ -1872,7 +1906,7 @@ func TestNewNode_WithInvalidDnsConfig(t *testing.T) {
 		nil,
 		vo.ProtocolShadowsocks,
 		enc,
-		nil, nil, nil, nil, nil, nil, nil,
+		nil, nil, nil, nil, nil, nil, nil, nil, nil,
 		vo.NewNodeMetadata("", nil, ""),
 		0,
 		nil,
@@ -1995,6 +2029,49 @@ func TestNode_UpdatePlugin(t *testing.T) {
 	assert.Equal(t, initialVersion+1, n.Version())
 }
 
+func TestNode_UpdateShadowTLSConfig(t *testing.T) {
+	n := newShadowsocksNode(t)
+
+	st, err := vo.NewShadowTLSConfig(3, "password123", "www.microsoft.com", 443)
+	require.NoError(t, err)
+
+	require.NoError(t, n.UpdateShadowTLSConfig(&st))
+	require.NotNil(t, n.ShadowTLSConfig())
+	assert.Equal(t, "www.microsoft.com", n.ShadowTLSConfig().HandshakeServer())
+
+	require.NoError(t, n.UpdateShadowTLSConfig(nil))
+	assert.Nil(t, n.ShadowTLSConfig())
+
+	trojan := newTrojanNode(t)
+	assert.Error(t, trojan.UpdateShadowTLSConfig(&st))
+}
+
+func TestNode_UpdateTrafficMultiplier(t *testing.T) {
+	n := newShadowsocksNode(t)
+	assert.Equal(t, DefaultTrafficMultiplier, n.TrafficMultiplier())
+	assert.False(t, n.HasCustomTrafficMultiplier())
+
+	v := n.Version()
+	require.NoError(t, n.UpdateTrafficMultiplier(0.5))
+	assert.Equal(t, 0.5, n.TrafficMultiplier())
+	assert.True(t, n.HasCustomTrafficMultiplier())
+	assert.Equal(t, v+1, n.Version())
+
+	require.NoError(t, n.UpdateTrafficMultiplier(0))
+	assert.Error(t, n.UpdateTrafficMultiplier(-1))
+	assert.Error(t, n.UpdateTrafficMultiplier(MaxTrafficMultiplier+1))
+	assert.Equal(t, 0.0, n.TrafficMultiplier())
+}
+
+func TestApplyTrafficMultiplier(t *testing.T) {
+	assert.Equal(t, int64(1000), ApplyTrafficMultiplier(1000, 1))
+	assert.Equal(t, int64(500), ApplyTrafficMultiplier(1000, 0.5))
+	assert.Equal(t, int64(3000), ApplyTrafficMultiplier(1000, 3))
+	assert.Equal(t, int64(0), ApplyTrafficMultiplier(1000, 0))
+	assert.Equal(t, int64(0), ApplyTrafficMultiplier(-1, 2))
+	assert.Equal(t, int64(math.MaxInt64), ApplyTrafficMultiplier(math.MaxInt64/2, 100))
+}
+
 // --- CreatedAt/UpdatedAt Tests ---
 
 func TestNode_Timestamps(t *testing.T) {
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+const (
+	// DefaultTrafficMultiplier bills subscription usage 1:1
+	DefaultTrafficMultiplier = 1.0
+	// MaxTrafficMultiplier is the upper bound for a node billing rate
+	MaxTrafficMultiplier = 100.0
+)
+
+// ValidateTrafficMultiplier checks that a node billing rate is within [0, MaxTrafficMultiplier].
+// Zero is allowed and makes the node free (usage is still recorded as raw bytes).
+func ValidateTrafficMultiplier(multiplier float64) error {
+	if math.IsNaN(multiplier) || math.IsInf(multiplier, 0) {
+		return fmt.Errorf("traffic multiplier must be a finite number")
+	}
+	if multiplier < 0 {
+		return fmt.Errorf("traffic multiplier cannot be negative: %g", multiplier)
+	}
+	if multiplier > MaxTrafficMultiplier {
+		return fmt.Errorf("traffic multiplier exceeds maximum (%g): %g", MaxTrafficMultiplier, multiplier)
+	}
+	return nil
+}
+
+// UpdateTrafficMultiplier sets the billing rate applied to subscription usage on this node
+func (n *Node) UpdateTrafficMultiplier(multiplier float64) error {
+	if err := ValidateTrafficMultiplier(multiplier); err != nil {
+		return err
+	}
+
+	n.mu.Lock()
+	defer n.mu.Unlock()
+
+	if n.trafficMultiplier == multiplier {
+		return nil
+	}
+
+	n.trafficMultiplier = multiplier
+	n.updatedAt = biztime.NowUTC()
+	n.version++
+	return nil
+}
+
+// HasCustomTrafficMultiplier reports whether usage on this node is not billed 1:1
+func (n *Node) HasCustomTrafficMultiplier() bool {
+	return n.trafficMultiplier != DefaultTrafficMultiplier
+}
+
+// ApplyTrafficMultiplier converts raw bytes into billed bytes using the given rate.
+// The result is rounded down and saturates at math.MaxInt64 to avoid overflow.
+func ApplyTrafficMultiplier(bytes int64, multiplier float64) int64 {
+	if bytes <= 0 || multiplier <= 0 {
+		return 0
+	}
+	if multiplier == DefaultTrafficMultiplier {
+		return bytes
+	}
+
+	billed := float64(bytes) * multiplier
+	if billed >= math.MaxInt64 {
+		return math.MaxInt64
+	}
+	return int64(billed)
+}
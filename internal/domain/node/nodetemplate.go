@@ -0,0 +1,226 @@
+package node
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/biztime"
+)
+
+const (
+	// MaxTemplateNameLength is the maximum length of a node template name
+	MaxTemplateNameLength = 100
+	// MaxTemplateDescriptionLength is the maximum length of a node template description
+	MaxTemplateDescriptionLength = 500
+)
+
+// NodeTemplate is a reusable node configuration: a protocol, route and DNS configuration
+// snapshot plus the resource groups new nodes join. Nodes provisioned from a template
+// only add a server address and ports. Server keys held in the snapshot (Reality key pair
+// and short ID, WireGuard private key) are never copied; every node gets its own.
+type NodeTemplate struct {
+	id          uint
+	sid         string // Stripe-style ID: ntpl_xxxxxxxx
+	name        string
+	description string
+	config      ConfigSnapshot
+	groupIDs    []uint
+	createdBy   *uint
+	createdAt   time.Time
+	updatedAt   time.Time
+}
+
+// NewNodeTemplate creates a node template
+func NewNodeTemplate(
+	name string,
+	description string,
+	config ConfigSnapshot,
+	groupIDs []uint,
+	createdBy *uint,
+	sidGenerator func() (string, error),
+) (*NodeTemplate, error) {
+	name = strings.TrimSpace(name)
+	if err := validateTemplateName(name); err != nil {
+		return nil, err
+	}
+	if err := validateTemplateDescription(description); err != nil {
+		return nil, err
+	}
+	if !config.Protocol.IsValid() {
+		return nil, fmt.Errorf("invalid protocol: %s", config.Protocol)
+	}
+
+	sid, err := sidGenerator()
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate SID: %w", err)
+	}
+
+	now := biztime.NowUTC()
+	return &NodeTemplate{
+		sid:         sid,
+		name:        name,
+		description: description,
+		config:      config,
+		groupIDs:    uniqueIDs(groupIDs),
+		createdBy:   createdBy,
+		createdAt:   now,
+		updatedAt:   now,
+	}, nil
+}
+
+// ReconstructNodeTemplate rebuilds a node template from persistence
+func ReconstructNodeTemplate(
+	id uint,
+	sid string,
+	name string,
+	description string,
+	config ConfigSnapshot,
+	groupIDs []uint,
+	createdBy *uint,
+	createdAt, updatedAt time.Time,
+) (*NodeTemplate, error) {
+	if id == 0 {
+		return nil, fmt.Errorf("node template ID cannot be zero")
+	}
+	if sid == "" {
+		return nil, fmt.Errorf("node template SID is required")
+	}
+	if !config.Protocol.IsValid() {
+		return nil, fmt.Errorf("invalid protocol: %s", config.Protocol)
+	}
+
+	return &NodeTemplate{
+		id:          id,
+		sid:         sid,
+		name:        name,
+		description: description,
+		config:      config,
+		groupIDs:    groupIDs,
+		createdBy:   createdBy,
+		createdAt:   createdAt,
+		updatedAt:   updatedAt,
+	}, nil
+}
+
+// ID returns the template ID
+func (t *NodeTemplate) ID() uint {
+	return t.id
+}
+
+// SID returns the Stripe-style ID
+func (t *NodeTemplate) SID() string {
+	return t.sid
+}
+
+// Name returns the template name
+func (t *NodeTemplate) Name() string {
+	return t.name
+}
+
+// Description returns the template description
+func (t *NodeTemplate) Description() string {
+	return t.description
+}
+
+// Config returns the configuration snapshot, including the server keys of the source node
+func (t *NodeTemplate) Config() ConfigSnapshot {
+	return t.config
+}
+
+// GroupIDs returns the resource groups provisioned nodes join
+func (t *NodeTemplate) GroupIDs() []uint {
+	return t.groupIDs
+}
+
+// CreatedBy returns the user who created the template
+func (t *NodeTemplate) CreatedBy() *uint {
+	return t.createdBy
+}
+
+// CreatedAt returns the creation time
+func (t *NodeTemplate) CreatedAt() time.Time {
+	return t.createdAt
+}
+
+// UpdatedAt returns the last update time
+func (t *NodeTemplate) UpdatedAt() time.Time {
+	return t.updatedAt
+}
+
+// SetID sets the ID after persistence
+func (t *NodeTemplate) SetID(id uint) error {
+	if t.id != 0 {
+		return fmt.Errorf("node template ID is already set")
+	}
+	if id == 0 {
+		return fmt.Errorf("node template ID cannot be zero")
+	}
+	t.id = id
+	return nil
+}
+
+// Rename changes the template name
+func (t *NodeTemplate) Rename(name string) error {
+	name = strings.TrimSpace(name)
+	if err := validateTemplateName(name); err != nil {
+		return err
+	}
+	if t.name == name {
+		return nil
+	}
+	t.name = name
+	t.updatedAt = biztime.NowUTC()
+	return nil
+}
+
+// UpdateDescription changes the template description
+func (t *NodeTemplate) UpdateDescription(description string) error {
+	if err := validateTemplateDescription(description); err != nil {
+		return err
+	}
+	if t.description == description {
+		return nil
+	}
+	t.description = description
+	t.updatedAt = biztime.NowUTC()
+	return nil
+}
+
+// UpdateConfig replaces the configuration snapshot, e.g. when re-capturing it from a node
+func (t *NodeTemplate) UpdateConfig(config ConfigSnapshot) error {
+	if !config.Protocol.IsValid() {
+		return fmt.Errorf("invalid protocol: %s", config.Protocol)
+	}
+	t.config = config
+	t.updatedAt = biztime.NowUTC()
+	return nil
+}
+
+// SetGroupIDs replaces the resource groups provisioned nodes join
+func (t *NodeTemplate) SetGroupIDs(groupIDs []uint) {
+	groupIDs = uniqueIDs(groupIDs)
+	if slices.Equal(t.groupIDs, groupIDs) {
+		return
+	}
+	t.groupIDs = groupIDs
+	t.updatedAt = biztime.NowUTC()
+}
+
+func validateTemplateName(name string) error {
+	if name == "" {
+		return fmt.Errorf("template name is required")
+	}
+	if len([]rune(name)) > MaxTemplateNameLength {
+		return fmt.Errorf("template name cannot exceed %d characters", MaxTemplateNameLength)
+	}
+	return nil
+}
+
+func validateTemplateDescription(description string) error {
+	if len([]rune(description)) > MaxTemplateDescriptionLength {
+		return fmt.Errorf("template description cannot exceed %d characters", MaxTemplateDescriptionLength)
+	}
+	return nil
+}
@@ -0,0 +1,44 @@
+package node
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+func TestNewNodeTemplate(t *testing.T) {
+	config := ConfigSnapshot{Protocol: vo.ProtocolTrojan}
+
+	tpl, err := NewNodeTemplate(" HK Trojan ", "", config, []uint{3, 0, 1, 3}, nil, fakeSIDGenerator("ntpl_test123"))
+	require.NoError(t, err)
+	assert.Equal(t, "HK Trojan", tpl.Name())
+	assert.Equal(t, []uint{1, 3}, tpl.GroupIDs())
+
+	_, err = NewNodeTemplate(" ", "", config, nil, nil, fakeSIDGenerator("ntpl_test123"))
+	assert.Error(t, err)
+	_, err = NewNodeTemplate(strings.Repeat("a", MaxTemplateNameLength+1), "", config, nil, nil, fakeSIDGenerator("ntpl_test123"))
+	assert.Error(t, err)
+	_, err = NewNodeTemplate("HK", "", ConfigSnapshot{Protocol: "unknown"}, nil, nil, fakeSIDGenerator("ntpl_test123"))
+	assert.Error(t, err)
+}
+
+func TestNodeTemplate_Updates(t *testing.T) {
+	tpl, err := NewNodeTemplate("HK", "", ConfigSnapshot{Protocol: vo.ProtocolTrojan}, []uint{1}, nil, fakeSIDGenerator("ntpl_test123"))
+	require.NoError(t, err)
+	updatedAt := tpl.UpdatedAt()
+
+	// Unchanged values leave the update time alone
+	require.NoError(t, tpl.Rename("HK"))
+	tpl.SetGroupIDs([]uint{1, 1})
+	assert.Equal(t, updatedAt, tpl.UpdatedAt())
+
+	require.NoError(t, tpl.Rename("JP"))
+	tpl.SetGroupIDs(nil)
+	assert.Equal(t, "JP", tpl.Name())
+	assert.Empty(t, tpl.GroupIDs())
+	assert.Error(t, tpl.UpdateDescription(strings.Repeat("a", MaxTemplateDescriptionLength+1)))
+}
@@ -2,6 +2,7 @@ package node
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"github.com/orris-inc/orris/internal/shared/query"
@@ -108,6 +109,128 @@ type NodeRepository interface {
 
 	// CountAll returns the total number of nodes.
 	CountAll(ctx context.Context) (int64, error)
+
+	// GetTrafficMultipliers returns the billing rate for each of the given node IDs.
+	// Nodes that do not exist are omitted from the result.
+	// This is a lightweight query used on the traffic recording hot path.
+	GetTrafficMultipliers(ctx context.Context, nodeIDs []uint) (map[uint]float64, error)
+
+	// ListRealityRotationNodes returns VLESS Reality nodes that have scheduled rotation enabled
+	// or a previous short ID still waiting to expire.
+	ListRealityRotationNodes(ctx context.Context) ([]*Node, error)
+}
+
+// MaintenanceWindowRepository defines persistence operations for scheduled maintenance windows
+type MaintenanceWindowRepository interface {
+	Create(ctx context.Context, window *MaintenanceWindow) error
+	Update(ctx context.Context, window *MaintenanceWindow) error
+	GetBySID(ctx context.Context, sid string) (*MaintenanceWindow, error)
+
+	// List returns maintenance windows ordered by start time (newest first)
+	List(ctx context.Context, filter MaintenanceWindowFilter) ([]*MaintenanceWindow, int64, error)
+
+	// ListUnfinished returns all scheduled and active windows, used by the maintenance scheduler
+	ListUnfinished(ctx context.Context) ([]*MaintenanceWindow, error)
+
+	// ListActive returns windows whose nodes are currently in maintenance
+	ListActive(ctx context.Context) ([]*MaintenanceWindow, error)
+}
+
+// MaintenanceWindowFilter defines the filter options for listing maintenance windows
+type MaintenanceWindowFilter struct {
+	Status   *MaintenanceWindowStatus
+	Page     int
+	PageSize int
+}
+
+// CertificateRepository defines persistence operations for node TLS certificates
+type CertificateRepository interface {
+	Create(ctx context.Context, cert *Certificate) error
+	Update(ctx context.Context, cert *Certificate) error
+	Delete(ctx context.Context, id uint) error
+	GetBySID(ctx context.Context, sid string) (*Certificate, error)
+	GetByDomain(ctx context.Context, domain string) (*Certificate, error)
+
+	// List returns certificates ordered by domain
+	List(ctx context.Context, filter CertificateFilter) ([]*Certificate, int64, error)
+
+	// ListAll returns every certificate, used by the renewal scheduler and node delivery.
+	// Deployments hold one record per domain, so the set stays small.
+	ListAll(ctx context.Context) ([]*Certificate, error)
+
+	// FindExpiring returns issued certificates that expire within the given number of days
+	// (including already expired ones), ordered by expiry
+	FindExpiring(ctx context.Context, days int) ([]*Certificate, error)
+
+	// ClaimIssuance atomically moves a certificate into the issuing state unless another
+	// instance holds a claim newer than staleBefore. Returns false if the claim was not acquired.
+	ClaimIssuance(ctx context.Context, cert *Certificate, staleBefore time.Time) (bool, error)
+}
+
+// CertificateFilter defines the filter options for listing certificates
+type CertificateFilter struct {
+	Status   *CertificateStatus
+	Page     int
+	PageSize int
+}
+
+// ConfigVersionRepository defines persistence operations for node configuration history.
+// Versions are immutable once created.
+type ConfigVersionRepository interface {
+	// Create stores a new version. Returns a conflict error if the node already has
+	// a version with the same number.
+	Create(ctx context.Context, version *ConfigVersion) error
+
+	// GetByVersion returns the given version of a node's configuration (nil if not found)
+	GetByVersion(ctx context.Context, nodeID uint, version int) (*ConfigVersion, error)
+
+	// GetLatest returns the most recent version of a node's configuration (nil if none)
+	GetLatest(ctx context.Context, nodeID uint) (*ConfigVersion, error)
+
+	// ListByNodeID returns a node's configuration versions, newest first
+	ListByNodeID(ctx context.Context, nodeID uint, filter ConfigVersionFilter) ([]*ConfigVersion, int64, error)
+}
+
+// ConfigVersionFilter defines the filter options for listing configuration versions
+type ConfigVersionFilter struct {
+	Page     int
+	PageSize int
+}
+
+// ErrWireGuardPoolExhausted is returned when a WireGuard node's address pool has no free address
+// left for a subscription.
+var ErrWireGuardPoolExhausted = errors.New("wireguard address pool exhausted")
+
+// WireGuardPeerRepository persists the tunnel addresses and keypairs of subscriptions on WireGuard nodes
+type WireGuardPeerRepository interface {
+	// ListByNodeID returns the peers of a node, keyed by subscription ID
+	ListByNodeID(ctx context.Context, nodeID uint) (map[uint]*WireGuardPeer, error)
+
+	// Create stores a peer. Returns a conflict error if the node already has a
+	// peer for the subscription or the address is taken.
+	Create(ctx context.Context, nodeID uint, peer *WireGuardPeer) error
+
+	// Delete releases the peers of the given subscriptions on a node
+	Delete(ctx context.Context, nodeID uint, subscriptionIDs []uint) error
+}
+
+// NodeTemplateRepository defines persistence operations for node templates
+type NodeTemplateRepository interface {
+	// Create stores a new template. Returns a conflict error if the name is taken.
+	Create(ctx context.Context, template *NodeTemplate) error
+	// Update saves a template. Returns a conflict error if the name is taken.
+	Update(ctx context.Context, template *NodeTemplate) error
+	Delete(ctx context.Context, id uint) error
+	GetBySID(ctx context.Context, sid string) (*NodeTemplate, error)
+
+	// List returns templates ordered by name
+	List(ctx context.Context, filter NodeTemplateFilter) ([]*NodeTemplate, int64, error)
+}
+
+// NodeTemplateFilter defines the filter options for listing node templates
+type NodeTemplateFilter struct {
+	Page     int
+	PageSize int
 }
 
 type NodeFilter struct {
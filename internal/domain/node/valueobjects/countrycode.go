@@ -0,0 +1,92 @@
+package valueobjects
+
+import (
+	"fmt"
+	"strings"
+)
+
+// countryNames maps ISO 3166-1 alpha-2 codes to the English names used in
+// client-facing region groups. Codes outside this table are still accepted and
+// displayed by their code.
+var countryNames = map[string]string{
+	"AE": "UAE",
+	"AR": "Argentina",
+	"AU": "Australia",
+	"BR": "Brazil",
+	"CA": "Canada",
+	"CH": "Switzerland",
+	"CN": "China",
+	"DE": "Germany",
+	"ES": "Spain",
+	"FI": "Finland",
+	"FR": "France",
+	"GB": "UK",
+	"HK": "Hong Kong",
+	"ID": "Indonesia",
+	"IE": "Ireland",
+	"IN": "India",
+	"IT": "Italy",
+	"JP": "Japan",
+	"KR": "Korea",
+	"MO": "Macau",
+	"MX": "Mexico",
+	"MY": "Malaysia",
+	"NL": "Netherlands",
+	"PH": "Philippines",
+	"PL": "Poland",
+	"RU": "Russia",
+	"SE": "Sweden",
+	"SG": "Singapore",
+	"TH": "Thailand",
+	"TR": "Turkey",
+	"TW": "Taiwan",
+	"UA": "Ukraine",
+	"US": "US",
+	"VN": "Vietnam",
+	"ZA": "South Africa",
+}
+
+// CountryCode is an ISO 3166-1 alpha-2 country code describing where a node is located.
+type CountryCode string
+
+// NewCountryCode normalizes and validates a country code (case-insensitive, e.g. "jp" -> "JP").
+func NewCountryCode(code string) (CountryCode, error) {
+	normalized := strings.ToUpper(strings.TrimSpace(code))
+	if len(normalized) != 2 || normalized[0] < 'A' || normalized[0] > 'Z' || normalized[1] < 'A' || normalized[1] > 'Z' {
+		return "", fmt.Errorf("invalid country code %q: must be an ISO 3166-1 alpha-2 code", code)
+	}
+	return CountryCode(normalized), nil
+}
+
+// String returns the country code.
+func (c CountryCode) String() string {
+	return string(c)
+}
+
+// Flag returns the flag emoji built from the code's regional indicator symbols.
+func (c CountryCode) Flag() string {
+	if len(c) != 2 {
+		return ""
+	}
+	const regionalIndicatorA = 0x1F1E6
+	return string([]rune{
+		rune(regionalIndicatorA + int(c[0]-'A')),
+		rune(regionalIndicatorA + int(c[1]-'A')),
+	})
+}
+
+// Name returns the English country name, falling back to the code itself.
+func (c CountryCode) Name() string {
+	if name, ok := countryNames[string(c)]; ok {
+		return name
+	}
+	return string(c)
+}
+
+// DisplayName returns the flag and name, e.g. "🇯🇵 Japan".
+func (c CountryCode) DisplayName() string {
+	if flag := c.Flag(); flag != "" {
+		return flag + " " + c.Name()
+	}
+	return c.Name()
+}
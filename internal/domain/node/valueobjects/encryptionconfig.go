@@ -2,6 +2,7 @@ package valueobjects
 
 import (
 	"crypto/hmac"
+	"crypto/rand"
 	"crypto/sha256"
 	"encoding/base64"
 	"fmt"
@@ -47,8 +48,12 @@ var validMethods = map[string]bool{
 	Method2022Blake3Chacha20Poly1305: true,
 }
 
+// EncryptionConfig holds the Shadowsocks cipher and, for SS2022 methods, an optional
+// explicitly managed server PSK. When no server key is stored the PSK is derived from
+// the node token hash (see GenerateSS2022ServerKey).
 type EncryptionConfig struct {
-	method string
+	method    string
+	serverKey string
 }
 
 func NewEncryptionConfig(method string) (EncryptionConfig, error) {
@@ -61,10 +66,89 @@ func NewEncryptionConfig(method string) (EncryptionConfig, error) {
 	}, nil
 }
 
+// NewEncryptionConfigWithServerKey creates an encryption config with a stored SS2022 server key.
+// An empty serverKey is equivalent to NewEncryptionConfig.
+func NewEncryptionConfigWithServerKey(method string, serverKey string) (EncryptionConfig, error) {
+	ec, err := NewEncryptionConfig(method)
+	if err != nil {
+		return EncryptionConfig{}, err
+	}
+	if serverKey == "" {
+		return ec, nil
+	}
+
+	if err := validateSS2022Key(method, serverKey); err != nil {
+		return EncryptionConfig{}, err
+	}
+	ec.serverKey = serverKey
+
+	return ec, nil
+}
+
 func (ec EncryptionConfig) Method() string {
 	return ec.method
 }
 
+// ServerKey returns the stored SS2022 server key, or empty if the key is derived from the node token.
+func (ec EncryptionConfig) ServerKey() string {
+	return ec.serverKey
+}
+
+// EffectiveServerKey returns the SS2022 server PSK used by the node: the stored key if present,
+// otherwise the key derived from tokenHash. Returns empty string for non-SS2022 methods.
+func (ec EncryptionConfig) EffectiveServerKey(tokenHash string) string {
+	if !IsSS2022Method(ec.method) {
+		return ""
+	}
+	if ec.serverKey != "" {
+		return ec.serverKey
+	}
+	return GenerateSS2022ServerKey(tokenHash, ec.method)
+}
+
+// RotateServerKey returns a copy of the config with a freshly generated random server key.
+// Only SS2022 methods have a server key.
+func (ec EncryptionConfig) RotateServerKey() (EncryptionConfig, error) {
+	if !IsSS2022Method(ec.method) {
+		return EncryptionConfig{}, fmt.Errorf("encryption method %s does not use a server key", ec.method)
+	}
+
+	key, err := GenerateRandomSS2022Key(ec.method)
+	if err != nil {
+		return EncryptionConfig{}, err
+	}
+
+	return EncryptionConfig{method: ec.method, serverKey: key}, nil
+}
+
+// WithMethod returns a config using the given method. The stored server key is kept when
+// the new method is SS2022 with the same key size, otherwise it is dropped.
+func (ec EncryptionConfig) WithMethod(method string) (EncryptionConfig, error) {
+	next, err := NewEncryptionConfig(method)
+	if err != nil {
+		return EncryptionConfig{}, err
+	}
+	if ec.serverKey != "" && IsSS2022Method(method) && GetSS2022KeySize(method) == GetSS2022KeySize(ec.method) {
+		next.serverKey = ec.serverKey
+	}
+	return next, nil
+}
+
+// WithServerKeyOf returns a copy of the config using the server key of current instead of its own,
+// so that restoring an older configuration does not bring back a replaced key. An empty current key
+// falls back to the per-token derived keys. Returns false if current's key does not fit this
+// method (different SS2022 key size).
+func (ec EncryptionConfig) WithServerKeyOf(current EncryptionConfig) (EncryptionConfig, bool) {
+	if !IsSS2022Method(ec.method) {
+		return ec, true
+	}
+	if current.serverKey != "" && GetSS2022KeySize(current.method) != GetSS2022KeySize(ec.method) {
+		return ec, false
+	}
+	ec.serverKey = current.serverKey
+	return ec, true
+}
+
 // ToShadowsocksURI generates the Shadowsocks URI with the given password
 // The password parameter should be the subscription UUID
 func (ec EncryptionConfig) ToShadowsocksURI(password string) string {
@@ -72,8 +156,18 @@ func (ec EncryptionConfig) ToShadowsocksURI(password string) string {
 	return base64.URLEncoding.EncodeToString([]byte(auth))
 }
 
+// ForwardingPassword returns the password other nodes use to reach this node through its
+// node-forwarding user. For SS2022 it is serverKey:userKey so it works with multi-user inbounds.
+func (ec EncryptionConfig) ForwardingPassword(tokenHash string) string {
+	userKey := GenerateShadowsocksServerPassword(tokenHash, ec.method)
+	if userKey == "" || !IsSS2022Method(ec.method) {
+		return userKey
+	}
+	return SS2022Password(ec.EffectiveServerKey(tokenHash), userKey)
+}
+
 func (ec EncryptionConfig) Equals(other EncryptionConfig) bool {
-	return ec.method == other.method
+	return ec.method == other.method && ec.serverKey == other.serverKey
 }
 
 func isValidMethod(method string) bool {
@@ -92,6 +186,12 @@ func IsSS2022Method(method string) bool {
 	}
 }
 
+// IsLegacyMethod checks if the encryption method is a supported pre-2022 Shadowsocks cipher.
+// Legacy ciphers are still accepted for existing nodes but can no longer be configured.
+func IsLegacyMethod(method string) bool {
+	return isValidMethod(method) && !IsSS2022Method(method)
+}
+
 // GetSS2022KeySize returns the required key size in bytes for SS2022 methods
 // Returns 0 for non-SS2022 methods
 func GetSS2022KeySize(method string) int {
@@ -128,6 +228,48 @@ func GenerateSS2022ServerKey(tokenHash string, method string) string {
 	return base64.StdEncoding.EncodeToString(keyMaterial[:keySize])
 }
 
+// GenerateRandomSS2022Key generates a random base64-encoded key with the proper length for the method.
+func GenerateRandomSS2022Key(method string) (string, error) {
+	keySize := GetSS2022KeySize(method)
+	if keySize == 0 {
+		return "", fmt.Errorf("encryption method %s is not a SS2022 method", method)
+	}
+
+	key := make([]byte, keySize)
+	if _, err := rand.Read(key); err != nil {
+		return "", fmt.Errorf("failed to generate server key: %w", err)
+	}
+
+	return base64.StdEncoding.EncodeToString(key), nil
+}
+
+// SS2022Password joins a server PSK and a user iPSK into the multi-user SS2022
+// password format (serverKey:userKey). Returns userKey alone if serverKey is empty.
+func SS2022Password(serverKey, userKey string) string {
+	if serverKey == "" {
+		return userKey
+	}
+	return serverKey + ":" + userKey
+}
+
+// validateSS2022Key checks that key is valid base64 of the length required by method.
+func validateSS2022Key(method string, key string) error {
+	keySize := GetSS2022KeySize(method)
+	if keySize == 0 {
+		return fmt.Errorf("encryption method %s does not use a server key", method)
+	}
+
+	decoded, err := base64.StdEncoding.DecodeString(key)
+	if err != nil {
+		return fmt.Errorf("server key must be base64-encoded: %w", err)
+	}
+	if len(decoded) != keySize {
+		return fmt.Errorf("server key for %s must be %d bytes, got %d", method, keySize, len(decoded))
+	}
+
+	return nil
+}
+
 // GenerateShadowsocksServerPassword derives a Shadowsocks password from node token hash.
 // For SS2022 methods, returns base64-encoded key with proper length.
 // For traditional SS methods, returns hex-encoded 32-byte password.
@@ -0,0 +1,114 @@
+package valueobjects
+
+import (
+	"encoding/base64"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEncryptionConfig_EffectiveServerKey_DerivedByDefault(t *testing.T) {
+	ec, err := NewEncryptionConfig(Method2022Blake3AES128GCM)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", ec.ServerKey())
+	assert.Equal(t, GenerateSS2022ServerKey("hash", Method2022Blake3AES128GCM), ec.EffectiveServerKey("hash"))
+}
+
+func TestEncryptionConfig_EffectiveServerKey_LegacyMethod(t *testing.T) {
+	ec, err := NewEncryptionConfig(MethodAES256GCM)
+	require.NoError(t, err)
+
+	assert.Equal(t, "", ec.EffectiveServerKey("hash"))
+	assert.True(t, IsLegacyMethod(MethodAES256GCM))
+	assert.False(t, IsLegacyMethod(Method2022Blake3AES256GCM))
+}
+
+func TestEncryptionConfig_RotateServerKey(t *testing.T) {
+	ec, err := NewEncryptionConfig(Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+
+	rotated, err := ec.RotateServerKey()
+	require.NoError(t, err)
+
+	key, err := base64.StdEncoding.DecodeString(rotated.ServerKey())
+	require.NoError(t, err)
+	assert.Len(t, key, 32)
+	assert.Equal(t, rotated.ServerKey(), rotated.EffectiveServerKey("hash"))
+	assert.False(t, rotated.Equals(ec))
+
+	legacy, err := NewEncryptionConfig(MethodAES256GCM)
+	require.NoError(t, err)
+	_, err = legacy.RotateServerKey()
+	assert.Error(t, err)
+}
+
+func TestNewEncryptionConfigWithServerKey_InvalidLength(t *testing.T) {
+	key32, err := GenerateRandomSS2022Key(Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+
+	_, err = NewEncryptionConfigWithServerKey(Method2022Blake3AES128GCM, key32)
+	assert.Error(t, err)
+
+	ec, err := NewEncryptionConfigWithServerKey(Method2022Blake3AES256GCM, key32)
+	require.NoError(t, err)
+	assert.Equal(t, key32, ec.ServerKey())
+}
+
+func TestEncryptionConfig_WithMethod(t *testing.T) {
+	ec, err := NewEncryptionConfig(Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+	ec, err = ec.RotateServerKey()
+	require.NoError(t, err)
+
+	sameSize, err := ec.WithMethod(Method2022Blake3Chacha20Poly1305)
+	require.NoError(t, err)
+	assert.Equal(t, ec.ServerKey(), sameSize.ServerKey())
+
+	smaller, err := ec.WithMethod(Method2022Blake3AES128GCM)
+	require.NoError(t, err)
+	assert.Equal(t, "", smaller.ServerKey())
+}
+
+func TestEncryptionConfig_ForwardingPassword(t *testing.T) {
+	ec, err := NewEncryptionConfig(Method2022Blake3AES128GCM)
+	require.NoError(t, err)
+
+	userKey := GenerateShadowsocksServerPassword("hash", Method2022Blake3AES128GCM)
+	assert.Equal(t, ec.EffectiveServerKey("hash")+":"+userKey, ec.ForwardingPassword("hash"))
+
+	legacy, err := NewEncryptionConfig(MethodAES256GCM)
+	require.NoError(t, err)
+	assert.Equal(t, GenerateShadowsocksServerPassword("hash", MethodAES256GCM), legacy.ForwardingPassword("hash"))
+}
+
+func TestEncryptionConfig_WithServerKeyOf(t *testing.T) {
+	oldKey, err := GenerateRandomSS2022Key(Method2022Blake3AES256GCM)
+	require.NoError(t, err)
+	old, err := NewEncryptionConfigWithServerKey(Method2022Blake3AES256GCM, oldKey)
+	require.NoError(t, err)
+	current, err := old.RotateServerKey()
+	require.NoError(t, err)
+
+	kept, ok := old.WithServerKeyOf(current)
+	assert.True(t, ok)
+	assert.Equal(t, current.ServerKey(), kept.ServerKey())
+
+	derived, err := NewEncryptionConfig(Method2022Blake3Chacha20Poly1305)
+	require.NoError(t, err)
+	kept, ok = old.WithServerKeyOf(derived)
+	assert.True(t, ok)
+	assert.Equal(t, "", kept.ServerKey(), "current derived keys are kept")
+
+	smaller, err := NewEncryptionConfig(Method2022Blake3AES128GCM)
+	require.NoError(t, err)
+	_, ok = smaller.WithServerKeyOf(current)
+	assert.False(t, ok, "current key does not fit the method")
+
+	legacy, err := NewEncryptionConfig(MethodAES256GCM)
+	require.NoError(t, err)
+	kept, ok = legacy.WithServerKeyOf(current)
+	assert.True(t, ok)
+	assert.Equal(t, "", kept.ServerKey())
+}
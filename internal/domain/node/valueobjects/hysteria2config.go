@@ -1,6 +1,8 @@
 package valueobjects
 
 import (
+	"crypto/rand"
+	"encoding/base64"
 	"fmt"
 	"net/url"
 	"strconv"
@@ -265,3 +267,12 @@ func (hc Hysteria2Config) Equals(other Hysteria2Config) bool {
 func isValidHysteria2CongestionControl(cc string) bool {
 	return validHysteria2CongestionControls[cc]
 }
+
+// GenerateHysteria2ObfsPassword generates a random Salamander obfuscation password.
+func GenerateHysteria2ObfsPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate hysteria2 obfs password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
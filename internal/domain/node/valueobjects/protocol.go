@@ -18,6 +18,8 @@ const (
 	ProtocolTUIC Protocol = "tuic"
 	// ProtocolAnyTLS represents the AnyTLS protocol
 	ProtocolAnyTLS Protocol = "anytls"
+	// ProtocolWireGuard represents the WireGuard protocol
+	ProtocolWireGuard Protocol = "wireguard"
 )
 
 var validProtocols = map[Protocol]bool{
@@ -28,6 +30,7 @@ var validProtocols = map[Protocol]bool{
 	ProtocolHysteria2:   true,
 	ProtocolTUIC:        true,
 	ProtocolAnyTLS:      true,
+	ProtocolWireGuard:   true,
 }
 
 // String returns the string representation of the protocol
@@ -79,3 +82,8 @@ func (p Protocol) IsTUIC() bool {
 func (p Protocol) IsAnyTLS() bool {
 	return p == ProtocolAnyTLS
 }
+
+// IsWireGuard checks if the protocol is WireGuard
+func (p Protocol) IsWireGuard() bool {
+	return p == ProtocolWireGuard
+}
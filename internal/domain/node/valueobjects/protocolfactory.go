@@ -252,11 +252,14 @@ func (f *ProtocolConfigFactory) CreateVLESSConfig(
 	realityPublicKey string,
 	realityShortID string,
 	realitySpiderX string,
+	xhttpMode string,
+	xhttpExtra string,
 ) (VLESSProtocolConfig, error) {
 	config, err := NewVLESSConfig(
 		transportType, flow, security, sni, fingerprint, allowInsecure,
 		host, path, serviceName,
 		realityPrivateKey, realityPublicKey, realityShortID, realitySpiderX,
+		xhttpMode, xhttpExtra,
 	)
 	if err != nil {
 		return VLESSProtocolConfig{}, fmt.Errorf("failed to create VLESS config: %w", err)
@@ -275,9 +278,12 @@ func (f *ProtocolConfigFactory) CreateVMessConfig(
 	tls bool,
 	sni string,
 	allowInsecure bool,
+	xhttpMode string,
+	xhttpExtra string,
 ) (VMessProtocolConfig, error) {
 	config, err := NewVMessConfig(
 		alterID, security, transportType, host, path, serviceName, tls, sni, allowInsecure,
+		xhttpMode, xhttpExtra,
 	)
 	if err != nil {
 		return VMessProtocolConfig{}, fmt.Errorf("failed to create VMess config: %w", err)
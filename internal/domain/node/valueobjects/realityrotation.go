@@ -0,0 +1,248 @@
+package valueobjects
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// MaxRealityRotationIntervalHours is the upper bound for scheduled Reality rotation (one year)
+	MaxRealityRotationIntervalHours = 8760
+	// MaxRealityRotationOverlapHours is the upper bound for the short ID overlap window (30 days)
+	MaxRealityRotationOverlapHours = 720
+	// DefaultRealityRotationOverlapHours is the overlap used for manual rotations when no policy is set
+	DefaultRealityRotationOverlapHours = 24
+)
+
+// RealityRotation holds the rotation policy and rotation state of a VLESS Reality node.
+// During the overlap window after a rotation the previous short ID is still accepted by
+// the node agent so that clients with a stale subscription keep connecting until they refresh.
+// The private key cannot overlap (Reality servers accept a single key), so rotating the key
+// pair takes effect for clients only once they fetch the new public key.
+type RealityRotation struct {
+	// Policy
+	intervalHours int  // 0 disables scheduled rotation
+	overlapHours  int  // how long the previous short ID stays valid
+	rotateKeyPair bool // scheduled rotations also replace the X25519 key pair
+
+	// State
+	previousShortID          string
+	previousShortIDExpiresAt *time.Time
+	rotatedAt                *time.Time
+}
+
+// NewRealityRotationPolicy creates a rotation policy with validation.
+// intervalHours = 0 disables scheduled rotation.
+func NewRealityRotationPolicy(intervalHours, overlapHours int, rotateKeyPair bool) (RealityRotation, error) {
+	if intervalHours < 0 || intervalHours > MaxRealityRotationIntervalHours {
+		return RealityRotation{}, fmt.Errorf("reality rotation interval must be between 0 and %d hours", MaxRealityRotationIntervalHours)
+	}
+	if overlapHours < 0 || overlapHours > MaxRealityRotationOverlapHours {
+		return RealityRotation{}, fmt.Errorf("reality rotation overlap must be between 0 and %d hours", MaxRealityRotationOverlapHours)
+	}
+	if intervalHours > 0 && overlapHours >= intervalHours {
+		return RealityRotation{}, fmt.Errorf("reality rotation overlap (%dh) must be shorter than the interval (%dh)", overlapHours, intervalHours)
+	}
+
+	return RealityRotation{
+		intervalHours: intervalHours,
+		overlapHours:  overlapHours,
+		rotateKeyPair: rotateKeyPair,
+	}, nil
+}
+
+// ReconstructRealityRotation rebuilds rotation policy and state from persistence
+func ReconstructRealityRotation(
+	intervalHours, overlapHours int,
+	rotateKeyPair bool,
+	previousShortID string,
+	previousShortIDExpiresAt, rotatedAt *time.Time,
+) RealityRotation {
+	if previousShortID == "" {
+		previousShortIDExpiresAt = nil
+	}
+	return RealityRotation{
+		intervalHours:            intervalHours,
+		overlapHours:             overlapHours,
+		rotateKeyPair:            rotateKeyPair,
+		previousShortID:          previousShortID,
+		previousShortIDExpiresAt: previousShortIDExpiresAt,
+		rotatedAt:                rotatedAt,
+	}
+}
+
+// WithPolicy returns a copy with the given policy and the current rotation state
+func (r RealityRotation) WithPolicy(policy RealityRotation) RealityRotation {
+	r.intervalHours = policy.intervalHours
+	r.overlapHours = policy.overlapHours
+	r.rotateKeyPair = policy.rotateKeyPair
+	return r
+}
+
+// IntervalHours returns the scheduled rotation interval (0 = disabled)
+func (r RealityRotation) IntervalHours() int {
+	return r.intervalHours
+}
+
+// OverlapHours returns how long the previous short ID stays valid after a rotation
+func (r RealityRotation) OverlapHours() int {
+	return r.overlapHours
+}
+
+// RotateKeyPair returns whether scheduled rotations also replace the key pair
+func (r RealityRotation) RotateKeyPair() bool {
+	return r.rotateKeyPair
+}
+
+// IsScheduled returns true if scheduled rotation is enabled
+func (r RealityRotation) IsScheduled() bool {
+	return r.intervalHours > 0
+}
+
+// EffectiveOverlap returns the overlap window applied to a rotation.
+// Nodes without a schedule fall back to DefaultRealityRotationOverlapHours.
+func (r RealityRotation) EffectiveOverlap() time.Duration {
+	if !r.IsScheduled() {
+		return DefaultRealityRotationOverlapHours * time.Hour
+	}
+	return time.Duration(r.overlapHours) * time.Hour
+}
+
+// PreviousShortID returns the short ID replaced by the last rotation (empty if none)
+func (r RealityRotation) PreviousShortID() string {
+	return r.previousShortID
+}
+
+// PreviousShortIDExpiresAt returns when the previous short ID stops being accepted
+func (r RealityRotation) PreviousShortIDExpiresAt() *time.Time {
+	return r.previousShortIDExpiresAt
+}
+
+// RotatedAt returns when the Reality credentials were last rotated (nil if never)
+func (r RealityRotation) RotatedAt() *time.Time {
+	return r.rotatedAt
+}
+
+// IsPreviousShortIDActive returns true if the previous short ID is still inside its overlap window
+func (r RealityRotation) IsPreviousShortIDActive(now time.Time) bool {
+	return r.previousShortID != "" && r.previousShortIDExpiresAt != nil && now.Before(*r.previousShortIDExpiresAt)
+}
+
+// Equals checks if two RealityRotation instances are equal
+func (r RealityRotation) Equals(other RealityRotation) bool {
+	return r.intervalHours == other.intervalHours &&
+		r.overlapHours == other.overlapHours &&
+		r.rotateKeyPair == other.rotateKeyPair &&
+		r.previousShortID == other.previousShortID &&
+		timePtrEqual(r.previousShortIDExpiresAt, other.previousShortIDExpiresAt) &&
+		timePtrEqual(r.rotatedAt, other.rotatedAt)
+}
+
+func timePtrEqual(a, b *time.Time) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+	return a.Equal(*b)
+}
+
+// RealityRotation returns the Reality rotation policy and state
+func (vc VLESSConfig) RealityRotation() RealityRotation {
+	return vc.realityRotation
+}
+
+// WithRealityRotation returns a copy of the config carrying the given rotation policy and state
+func (vc VLESSConfig) WithRealityRotation(rotation RealityRotation) VLESSConfig {
+	vc.realityRotation = rotation
+	return vc
+}
+
+// WithRealityKeysOf returns a copy of the config using the Reality key pair, short ID and rotation
+// state of current, keeping its own rotation policy. Returns false if the config uses Reality
+// with keys but current has none to carry over.
+func (vc VLESSConfig) WithRealityKeysOf(current VLESSConfig) (VLESSConfig, bool) {
+	if vc.security != VLESSSecurityReality {
+		return vc, true
+	}
+	if current.privateKey == "" {
+		return vc, vc.privateKey == ""
+	}
+	vc.privateKey = current.privateKey
+	vc.publicKey = current.publicKey
+	vc.shortID = current.shortID
+	vc.realityRotation = current.realityRotation.WithPolicy(vc.realityRotation)
+	return vc, true
+}
+
+// AcceptedShortIDs returns the short IDs the node agent should accept at the given time:
+// the current short ID first, followed by the previous one while its overlap window is open.
+func (vc VLESSConfig) AcceptedShortIDs(now time.Time) []string {
+	if vc.shortID == "" {
+		return nil
+	}
+	ids := []string{vc.shortID}
+	if vc.realityRotation.IsPreviousShortIDActive(now) && vc.realityRotation.previousShortID != vc.shortID {
+		ids = append(ids, vc.realityRotation.previousShortID)
+	}
+	return ids
+}
+
+// IsRealityRotationDue reports whether a scheduled rotation should run at the given time.
+// since is used as the reference point when the config has never been rotated.
+func (vc VLESSConfig) IsRealityRotationDue(now, since time.Time) bool {
+	if vc.security != VLESSSecurityReality || vc.shortID == "" || !vc.realityRotation.IsScheduled() {
+		return false
+	}
+	if last := vc.realityRotation.rotatedAt; last != nil {
+		since = *last
+	}
+	return !now.Before(since.Add(time.Duration(vc.realityRotation.intervalHours) * time.Hour))
+}
+
+// RotateReality returns a copy of the config with a new short ID and, if rotateKeyPair is set,
+// a new X25519 key pair. The replaced short ID stays accepted for the given overlap window.
+func (vc VLESSConfig) RotateReality(rotateKeyPair bool, overlap time.Duration, now time.Time) (VLESSConfig, error) {
+	if vc.security != VLESSSecurityReality {
+		return VLESSConfig{}, fmt.Errorf("reality rotation requires reality security, got %s", vc.security)
+	}
+
+	shortID, err := GenerateRealityShortID()
+	if err != nil {
+		return VLESSConfig{}, err
+	}
+
+	next := vc
+	if rotateKeyPair || vc.privateKey == "" {
+		keyPair, err := GenerateRealityKeyPair()
+		if err != nil {
+			return VLESSConfig{}, err
+		}
+		next.privateKey = keyPair.PrivateKey
+		next.publicKey = keyPair.PublicKey
+	}
+	next.shortID = shortID
+
+	rotation := vc.realityRotation
+	rotation.previousShortID = ""
+	rotation.previousShortIDExpiresAt = nil
+	if overlap > 0 && vc.shortID != "" {
+		expiresAt := now.Add(overlap)
+		rotation.previousShortID = vc.shortID
+		rotation.previousShortIDExpiresAt = &expiresAt
+	}
+	rotatedAt := now
+	rotation.rotatedAt = &rotatedAt
+	next.realityRotation = rotation
+
+	return next, nil
+}
+
+// ExpirePreviousShortID drops the previous short ID once its overlap window has closed.
+// The second return value reports whether anything changed.
+func (vc VLESSConfig) ExpirePreviousShortID(now time.Time) (VLESSConfig, bool) {
+	if vc.realityRotation.previousShortID == "" || vc.realityRotation.IsPreviousShortIDActive(now) {
+		return vc, false
+	}
+	vc.realityRotation.previousShortID = ""
+	vc.realityRotation.previousShortIDExpiresAt = nil
+	return vc, true
+}
@@ -0,0 +1,111 @@
+package valueobjects
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newRealityTestConfig(t *testing.T) VLESSConfig {
+	t.Helper()
+	vc, err := NewVLESSConfig(VLESSTransportTCP, VLESSFlowVision, VLESSSecurityReality, "www.example.com", "chrome", false,
+		"", "", "", "priv", "pub", "0123456789abcdef", "", "", "")
+	require.NoError(t, err)
+	return vc
+}
+
+func TestNewRealityRotationPolicy(t *testing.T) {
+	_, err := NewRealityRotationPolicy(0, 0, false)
+	assert.NoError(t, err, "disabled policy")
+
+	_, err = NewRealityRotationPolicy(168, 24, true)
+	assert.NoError(t, err)
+
+	_, err = NewRealityRotationPolicy(-1, 0, false)
+	assert.Error(t, err, "negative interval")
+
+	_, err = NewRealityRotationPolicy(MaxRealityRotationIntervalHours+1, 0, false)
+	assert.Error(t, err, "interval too long")
+
+	_, err = NewRealityRotationPolicy(24, 24, false)
+	assert.Error(t, err, "overlap must be shorter than interval")
+}
+
+func TestVLESSConfig_RotateReality(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	vc := newRealityTestConfig(t)
+
+	rotated, err := vc.RotateReality(false, 24*time.Hour, now)
+	require.NoError(t, err)
+
+	assert.NotEqual(t, vc.ShortID(), rotated.ShortID())
+	assert.Equal(t, vc.PrivateKey(), rotated.PrivateKey(), "key pair kept")
+	assert.Equal(t, []string{rotated.ShortID(), vc.ShortID()}, rotated.AcceptedShortIDs(now.Add(time.Hour)))
+	assert.Equal(t, []string{rotated.ShortID()}, rotated.AcceptedShortIDs(now.Add(24*time.Hour)), "overlap closed")
+
+	expired, changed := rotated.ExpirePreviousShortID(now.Add(time.Hour))
+	assert.False(t, changed)
+	expired, changed = expired.ExpirePreviousShortID(now.Add(25 * time.Hour))
+	assert.True(t, changed)
+	assert.Empty(t, expired.RealityRotation().PreviousShortID())
+
+	withKeys, err := vc.RotateReality(true, 0, now)
+	require.NoError(t, err)
+	assert.NotEqual(t, vc.PrivateKey(), withKeys.PrivateKey())
+	assert.NotEqual(t, vc.PublicKey(), withKeys.PublicKey())
+	assert.Equal(t, []string{withKeys.ShortID()}, withKeys.AcceptedShortIDs(now), "no overlap")
+
+	tlsConfig, err := NewVLESSConfig(VLESSTransportTCP, "", VLESSSecurityTLS, "", "", false, "", "", "", "", "", "", "", "", "")
+	require.NoError(t, err)
+	_, err = tlsConfig.RotateReality(true, 0, now)
+	assert.Error(t, err)
+}
+
+func TestVLESSConfig_WithRealityKeysOf(t *testing.T) {
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	old := newRealityTestConfig(t)
+	policy, err := NewRealityRotationPolicy(168, 24, false)
+	require.NoError(t, err)
+	old = old.WithRealityRotation(policy)
+
+	current, err := old.RotateReality(true, time.Hour, now)
+	require.NoError(t, err)
+	current = current.WithRealityRotation(current.RealityRotation().WithPolicy(RealityRotation{}))
+
+	kept, ok := old.WithRealityKeysOf(current)
+	require.True(t, ok)
+	assert.Equal(t, current.PrivateKey(), kept.PrivateKey())
+	assert.Equal(t, current.PublicKey(), kept.PublicKey())
+	assert.Equal(t, current.ShortID(), kept.ShortID())
+	assert.Equal(t, old.ShortID(), kept.RealityRotation().PreviousShortID(), "rotation state kept")
+	assert.Equal(t, 168, kept.RealityRotation().IntervalHours(), "restored policy kept")
+
+	tlsConfig, err := NewVLESSConfig(VLESSTransportTCP, "", VLESSSecurityTLS, "", "", false, "", "", "", "", "", "", "", "", "")
+	require.NoError(t, err)
+	_, ok = old.WithRealityKeysOf(tlsConfig)
+	assert.False(t, ok, "no current reality keys to keep")
+	_, ok = tlsConfig.WithRealityKeysOf(current)
+	assert.True(t, ok)
+}
+
+func TestVLESSConfig_IsRealityRotationDue(t *testing.T) {
+	createdAt := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	vc := newRealityTestConfig(t)
+	assert.False(t, vc.IsRealityRotationDue(createdAt.Add(1000*time.Hour), createdAt), "no policy")
+
+	policy, err := NewRealityRotationPolicy(48, 12, false)
+	require.NoError(t, err)
+	vc = vc.WithRealityRotation(vc.RealityRotation().WithPolicy(policy))
+
+	assert.False(t, vc.IsRealityRotationDue(createdAt.Add(47*time.Hour), createdAt))
+	assert.True(t, vc.IsRealityRotationDue(createdAt.Add(48*time.Hour), createdAt))
+
+	rotatedAt := createdAt.Add(48 * time.Hour)
+	rotated, err := vc.RotateReality(false, vc.RealityRotation().EffectiveOverlap(), rotatedAt)
+	require.NoError(t, err)
+	assert.Equal(t, 48, rotated.RealityRotation().IntervalHours(), "policy kept")
+	assert.False(t, rotated.IsRealityRotationDue(rotatedAt.Add(47*time.Hour), createdAt), "measured from last rotation")
+	assert.True(t, rotated.IsRealityRotationDue(rotatedAt.Add(48*time.Hour), createdAt))
+}
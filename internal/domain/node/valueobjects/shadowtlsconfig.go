@@ -0,0 +1,106 @@
+package valueobjects
+
+import (
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// ShadowTLS-specific constants
+const (
+	// DefaultShadowTLSVersion is the protocol version used when none is given
+	DefaultShadowTLSVersion = 3
+	// DefaultShadowTLSHandshakePort is the handshake server port used when none is given
+	DefaultShadowTLSHandshakePort uint16 = 443
+
+	minShadowTLSPasswordLength = 8
+)
+
+// shadowTLSHostnamePattern validates the handshake server hostname (RFC 1123).
+var shadowTLSHostnamePattern = regexp.MustCompile(`^([a-zA-Z0-9]([a-zA-Z0-9-]{0,61}[a-zA-Z0-9])?\.)+[a-zA-Z]{2,}$`)
+
+// ShadowTLSConfig represents an optional ShadowTLS layer in front of a Shadowsocks node.
+// The agent terminates ShadowTLS on the node port and relays the TLS handshake to
+// handshakeServer, so the traffic looks like a regular TLS session to that site.
+// This is an immutable value object following DDD principles
+type ShadowTLSConfig struct {
+	version         int
+	password        string
+	handshakeServer string
+	handshakePort   uint16
+}
+
+// NewShadowTLSConfig creates a new ShadowTLSConfig with validation.
+// Version defaults to 3 and handshakePort to 443 when zero.
+func NewShadowTLSConfig(version int, password string, handshakeServer string, handshakePort uint16) (ShadowTLSConfig, error) {
+	if version == 0 {
+		version = DefaultShadowTLSVersion
+	}
+	// Version 1 has no authentication and is not offered
+	if version != 2 && version != 3 {
+		return ShadowTLSConfig{}, fmt.Errorf("unsupported shadowtls version: %d (must be 2 or 3)", version)
+	}
+
+	if len(password) < minShadowTLSPasswordLength {
+		return ShadowTLSConfig{}, fmt.Errorf("shadowtls password must be at least %d characters long", minShadowTLSPasswordLength)
+	}
+
+	handshakeServer = strings.ToLower(strings.TrimSpace(handshakeServer))
+	if handshakeServer == "" {
+		return ShadowTLSConfig{}, fmt.Errorf("shadowtls handshake server is required")
+	}
+	if !shadowTLSHostnamePattern.MatchString(handshakeServer) {
+		return ShadowTLSConfig{}, fmt.Errorf("invalid shadowtls handshake server: %s", handshakeServer)
+	}
+
+	if handshakePort == 0 {
+		handshakePort = DefaultShadowTLSHandshakePort
+	}
+
+	return ShadowTLSConfig{
+		version:         version,
+		password:        password,
+		handshakeServer: handshakeServer,
+		handshakePort:   handshakePort,
+	}, nil
+}
+
+// Version returns the ShadowTLS protocol version
+func (c ShadowTLSConfig) Version() int {
+	return c.version
+}
+
+// Password returns the ShadowTLS password shared by the node and its clients
+func (c ShadowTLSConfig) Password() string {
+	return c.password
+}
+
+// HandshakeServer returns the hostname of the TLS site used for the handshake.
+// Clients use it as SNI.
+func (c ShadowTLSConfig) HandshakeServer() string {
+	return c.handshakeServer
+}
+
+// HandshakePort returns the port of the handshake server
+func (c ShadowTLSConfig) HandshakePort() uint16 {
+	return c.handshakePort
+}
+
+// Equals checks if two ShadowTLSConfig instances are equal
+func (c ShadowTLSConfig) Equals(other ShadowTLSConfig) bool {
+	return c.version == other.version &&
+		c.password == other.password &&
+		c.handshakeServer == other.handshakeServer &&
+		c.handshakePort == other.handshakePort
+}
+
+// GenerateShadowTLSPassword generates a random ShadowTLS password.
+func GenerateShadowTLSPassword() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", fmt.Errorf("failed to generate shadowtls password: %w", err)
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
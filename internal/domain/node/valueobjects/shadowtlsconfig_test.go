@@ -0,0 +1,32 @@
+package valueobjects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewShadowTLSConfig_Defaults(t *testing.T) {
+	password, err := GenerateShadowTLSPassword()
+	require.NoError(t, err)
+
+	sc, err := NewShadowTLSConfig(0, password, "WWW.Microsoft.com", 0)
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultShadowTLSVersion, sc.Version())
+	assert.Equal(t, DefaultShadowTLSHandshakePort, sc.HandshakePort())
+	assert.Equal(t, "www.microsoft.com", sc.HandshakeServer())
+	assert.Equal(t, password, sc.Password())
+}
+
+func TestNewShadowTLSConfig_Invalid(t *testing.T) {
+	_, err := NewShadowTLSConfig(1, "password123", "www.microsoft.com", 443)
+	assert.Error(t, err)
+	_, err = NewShadowTLSConfig(3, "short", "www.microsoft.com", 443)
+	assert.Error(t, err)
+	_, err = NewShadowTLSConfig(3, "password123", "", 443)
+	assert.Error(t, err)
+	_, err = NewShadowTLSConfig(3, "password123", "1.2.3.4", 443)
+	assert.Error(t, err)
+}
@@ -15,6 +15,10 @@ const (
 	VLESSTransportGRPC = "grpc"
 	// VLESSTransportH2 represents HTTP/2 transport protocol for VLESS
 	VLESSTransportH2 = "h2"
+	// VLESSTransportXHTTP represents XHTTP (SplitHTTP) transport protocol for VLESS
+	VLESSTransportXHTTP = "xhttp"
+	// VLESSTransportHTTPUpgrade represents HTTPUpgrade transport protocol for VLESS
+	VLESSTransportHTTPUpgrade = "httpupgrade"
 
 	// VLESSSecurityNone represents no security
 	VLESSSecurityNone = "none"
@@ -28,10 +32,12 @@ const (
 )
 
 var validVLESSTransports = map[string]bool{
-	VLESSTransportTCP:  true,
-	VLESSTransportWS:   true,
-	VLESSTransportGRPC: true,
-	VLESSTransportH2:   true,
+	VLESSTransportTCP:         true,
+	VLESSTransportWS:          true,
+	VLESSTransportGRPC:        true,
+	VLESSTransportH2:          true,
+	VLESSTransportXHTTP:       true,
+	VLESSTransportHTTPUpgrade: true,
 }
 
 var validVLESSSecurity = map[string]bool{
@@ -55,18 +61,25 @@ type VLESSConfig struct {
 	fingerprint   string
 	allowInsecure bool
 
-	// WebSocket/H2 specific
+	// WebSocket/H2/XHTTP/HTTPUpgrade specific
 	host string
 	path string
 
 	// gRPC specific
 	serviceName string
 
+	// XHTTP specific
+	xhttpMode  string // auto, packet-up, stream-up, stream-one
+	xhttpExtra string // Raw JSON object of extra XHTTP options
+
 	// Reality specific
 	privateKey string // Server-side private key (for inbound configuration)
 	publicKey  string // Client-side public key (for outbound/subscription)
 	shortID    string
 	spiderX    string
+
+	// Reality key and short ID rotation policy/state
+	realityRotation RealityRotation
 }
 
 // NewVLESSConfig creates a new VLESSConfig with validation
@@ -84,10 +97,12 @@ func NewVLESSConfig(
 	publicKey string,
 	shortID string,
 	spiderX string,
+	xhttpMode string,
+	xhttpExtra string,
 ) (VLESSConfig, error) {
 	// Validate transport type
 	if !validVLESSTransports[transportType] {
-		return VLESSConfig{}, fmt.Errorf("unsupported transport type: %s (must be tcp, ws, grpc, or h2)", transportType)
+		return VLESSConfig{}, fmt.Errorf("unsupported transport type: %s (must be tcp, ws, grpc, h2, xhttp, or httpupgrade)", transportType)
 	}
 
 	// Validate security
@@ -140,6 +155,24 @@ func NewVLESSConfig(
 		}
 	}
 
+	// Validate XHTTP/HTTPUpgrade-specific requirements (host is optional, defaults to the server address)
+	if transportType == VLESSTransportXHTTP || transportType == VLESSTransportHTTPUpgrade {
+		if path == "" {
+			return VLESSConfig{}, fmt.Errorf("path is required for %s transport", transportType)
+		}
+	}
+
+	// XHTTP options only apply to the xhttp transport
+	if transportType == VLESSTransportXHTTP {
+		var err error
+		xhttpMode, xhttpExtra, err = normalizeXHTTPOptions(xhttpMode, xhttpExtra)
+		if err != nil {
+			return VLESSConfig{}, err
+		}
+	} else {
+		xhttpMode, xhttpExtra = "", ""
+	}
+
 	return VLESSConfig{
 		transportType: transportType,
 		flow:          flow,
@@ -154,6 +187,8 @@ func NewVLESSConfig(
 		publicKey:     publicKey,
 		shortID:       shortID,
 		spiderX:       spiderX,
+		xhttpMode:     xhttpMode,
+		xhttpExtra:    xhttpExtra,
 	}, nil
 }
 
@@ -187,16 +222,26 @@ func (vc VLESSConfig) AllowInsecure() bool {
 	return vc.allowInsecure
 }
 
-// Host returns the host for WebSocket/H2
+// Host returns the host for WebSocket/H2/XHTTP/HTTPUpgrade
 func (vc VLESSConfig) Host() string {
 	return vc.host
 }
 
-// Path returns the path for WebSocket/H2
+// Path returns the path for WebSocket/H2/XHTTP/HTTPUpgrade
 func (vc VLESSConfig) Path() string {
 	return vc.path
 }
 
+// XHTTPMode returns the XHTTP upload mode (empty for other transports)
+func (vc VLESSConfig) XHTTPMode() string {
+	return vc.xhttpMode
+}
+
+// XHTTPExtra returns the raw JSON extra options for XHTTP (empty if not set)
+func (vc VLESSConfig) XHTTPExtra() string {
+	return vc.xhttpExtra
+}
+
 // ServiceName returns the gRPC service name
 func (vc VLESSConfig) ServiceName() string {
 	return vc.serviceName
@@ -270,13 +315,22 @@ func (vc VLESSConfig) ToURI(uuid string, serverAddr string, serverPort uint16, r
 
 	// Add transport-specific parameters
 	switch vc.transportType {
-	case VLESSTransportWS, VLESSTransportH2:
+	case VLESSTransportWS, VLESSTransportH2, VLESSTransportHTTPUpgrade:
 		if vc.host != "" {
 			params = append(params, "host="+url.QueryEscape(vc.host))
 		}
 		if vc.path != "" {
 			params = append(params, "path="+url.QueryEscape(vc.path))
 		}
+	case VLESSTransportXHTTP:
+		if vc.host != "" {
+			params = append(params, "host="+url.QueryEscape(vc.host))
+		}
+		params = append(params, "path="+url.QueryEscape(vc.path))
+		params = append(params, "mode="+url.QueryEscape(vc.xhttpMode))
+		if vc.xhttpExtra != "" {
+			params = append(params, "extra="+url.QueryEscape(vc.xhttpExtra))
+		}
 	case VLESSTransportGRPC:
 		if vc.serviceName != "" {
 			params = append(params, "serviceName="+url.QueryEscape(vc.serviceName))
@@ -326,6 +380,10 @@ func (vc VLESSConfig) String() string {
 		parts = append(parts, fmt.Sprintf("serviceName=%s", vc.serviceName))
 	}
 
+	if vc.xhttpMode != "" {
+		parts = append(parts, fmt.Sprintf("xhttpMode=%s", vc.xhttpMode))
+	}
+
 	if vc.security == VLESSSecurityReality {
 		// Note: privateKey is intentionally NOT included in String() output for security reasons
 		if vc.privateKey != "" {
@@ -359,5 +417,8 @@ func (vc VLESSConfig) Equals(other VLESSConfig) bool {
 		vc.privateKey == other.privateKey &&
 		vc.publicKey == other.publicKey &&
 		vc.shortID == other.shortID &&
-		vc.spiderX == other.spiderX
+		vc.spiderX == other.spiderX &&
+		vc.xhttpMode == other.xhttpMode &&
+		vc.xhttpExtra == other.xhttpExtra &&
+		vc.realityRotation.Equals(other.realityRotation)
 }
@@ -31,6 +31,10 @@ const (
 	VMessTransportGRPC = "grpc"
 	VMessTransportHTTP = "http"
 	VMessTransportQUIC = "quic"
+	// VMessTransportXHTTP represents XHTTP (SplitHTTP) transport
+	VMessTransportXHTTP = "xhttp"
+	// VMessTransportHTTPUpgrade represents HTTPUpgrade transport
+	VMessTransportHTTPUpgrade = "httpupgrade"
 )
 
 var validVMessSecurities = map[string]bool{
@@ -42,11 +46,13 @@ var validVMessSecurities = map[string]bool{
 }
 
 var validVMessTransports = map[string]bool{
-	VMessTransportTCP:  true,
-	VMessTransportWS:   true,
-	VMessTransportGRPC: true,
-	VMessTransportHTTP: true,
-	VMessTransportQUIC: true,
+	VMessTransportTCP:         true,
+	VMessTransportWS:          true,
+	VMessTransportGRPC:        true,
+	VMessTransportHTTP:        true,
+	VMessTransportQUIC:        true,
+	VMessTransportXHTTP:       true,
+	VMessTransportHTTPUpgrade: true,
 }
 
 // VMessConfig represents the VMess protocol configuration
@@ -54,13 +60,15 @@ var validVMessTransports = map[string]bool{
 type VMessConfig struct {
 	alterID       int    // Usually 0 for modern clients
 	security      string // auto, aes-128-gcm, chacha20-poly1305, none, zero
-	transportType string // tcp, ws, grpc, http, quic
-	host          string // WebSocket/HTTP host header
-	path          string // WebSocket/HTTP path
+	transportType string // tcp, ws, grpc, http, quic, xhttp, httpupgrade
+	host          string // WebSocket/HTTP/XHTTP/HTTPUpgrade host header
+	path          string // WebSocket/HTTP/XHTTP/HTTPUpgrade path
 	serviceName   string // gRPC service name
 	tls           bool   // Enable TLS
 	sni           string // TLS Server Name Indication
 	allowInsecure bool   // Allow insecure TLS connection
+	xhttpMode     string // XHTTP upload mode (auto, packet-up, stream-up, stream-one)
+	xhttpExtra    string // Raw JSON object of extra XHTTP options
 }
 
 // NewVMessConfig creates a new VMessConfig with validation
@@ -74,6 +82,8 @@ func NewVMessConfig(
 	tls bool,
 	sni string,
 	allowInsecure bool,
+	xhttpMode string,
+	xhttpExtra string,
 ) (VMessConfig, error) {
 	// Validate alterID
 	if alterID < 0 {
@@ -87,7 +97,7 @@ func NewVMessConfig(
 
 	// Validate transport type
 	if !isValidVMessTransport(transportType) {
-		return VMessConfig{}, fmt.Errorf("unsupported transport type: %s (must be tcp, ws, grpc, http, or quic)", transportType)
+		return VMessConfig{}, fmt.Errorf("unsupported transport type: %s (must be tcp, ws, grpc, http, quic, xhttp, or httpupgrade)", transportType)
 	}
 
 	// Validate WebSocket-specific requirements
@@ -111,6 +121,24 @@ func NewVMessConfig(
 		}
 	}
 
+	// Validate XHTTP/HTTPUpgrade-specific requirements
+	if transportType == VMessTransportXHTTP || transportType == VMessTransportHTTPUpgrade {
+		if path == "" {
+			return VMessConfig{}, fmt.Errorf("path is required for %s transport", transportType)
+		}
+	}
+
+	// XHTTP options only apply to the xhttp transport
+	if transportType == VMessTransportXHTTP {
+		var err error
+		xhttpMode, xhttpExtra, err = normalizeXHTTPOptions(xhttpMode, xhttpExtra)
+		if err != nil {
+			return VMessConfig{}, err
+		}
+	} else {
+		xhttpMode, xhttpExtra = "", ""
+	}
+
 	return VMessConfig{
 		alterID:       alterID,
 		security:      security,
@@ -121,6 +149,8 @@ func NewVMessConfig(
 		tls:           tls,
 		sni:           sni,
 		allowInsecure: allowInsecure,
+		xhttpMode:     xhttpMode,
+		xhttpExtra:    xhttpExtra,
 	}, nil
 }
 
@@ -139,16 +169,26 @@ func (vc VMessConfig) TransportType() string {
 	return vc.transportType
 }
 
-// Host returns the host for WebSocket/HTTP
+// Host returns the host for WebSocket/HTTP/XHTTP/HTTPUpgrade
 func (vc VMessConfig) Host() string {
 	return vc.host
 }
 
-// Path returns the path for WebSocket/HTTP
+// Path returns the path for WebSocket/HTTP/XHTTP/HTTPUpgrade
 func (vc VMessConfig) Path() string {
 	return vc.path
 }
 
+// XHTTPMode returns the XHTTP upload mode (empty for other transports)
+func (vc VMessConfig) XHTTPMode() string {
+	return vc.xhttpMode
+}
+
+// XHTTPExtra returns the raw JSON extra options for XHTTP (empty if not set)
+func (vc VMessConfig) XHTTPExtra() string {
+	return vc.xhttpExtra
+}
+
 // ServiceName returns the gRPC service name
 func (vc VMessConfig) ServiceName() string {
 	return vc.serviceName
@@ -215,9 +255,13 @@ func (vc VMessConfig) ToURI(serverAddr string, serverPort uint16, uuid string, r
 	case VMessTransportWS:
 		config.Host = vc.host
 		config.Path = vc.path
-	case VMessTransportHTTP:
+	case VMessTransportHTTP, VMessTransportHTTPUpgrade:
 		config.Host = vc.host
 		config.Path = vc.path
+	case VMessTransportXHTTP:
+		config.Host = vc.host
+		config.Path = vc.path
+		config.Type = vc.xhttpMode // v2rayN carries the XHTTP mode in the header type field
 	case VMessTransportGRPC:
 		config.Path = vc.serviceName // gRPC service name goes in path field
 	}
@@ -264,13 +308,22 @@ func (vc VMessConfig) ToStandardURI(serverAddr string, serverPort uint16, uuid s
 
 	// Add transport-specific parameters
 	switch vc.transportType {
-	case VMessTransportWS, VMessTransportHTTP:
+	case VMessTransportWS, VMessTransportHTTP, VMessTransportHTTPUpgrade:
 		if vc.host != "" {
 			params = append(params, "host="+url.QueryEscape(vc.host))
 		}
 		if vc.path != "" {
 			params = append(params, "path="+url.QueryEscape(vc.path))
 		}
+	case VMessTransportXHTTP:
+		if vc.host != "" {
+			params = append(params, "host="+url.QueryEscape(vc.host))
+		}
+		params = append(params, "path="+url.QueryEscape(vc.path))
+		params = append(params, "mode="+url.QueryEscape(vc.xhttpMode))
+		if vc.xhttpExtra != "" {
+			params = append(params, "extra="+url.QueryEscape(vc.xhttpExtra))
+		}
 	case VMessTransportGRPC:
 		if vc.serviceName != "" {
 			params = append(params, "serviceName="+url.QueryEscape(vc.serviceName))
@@ -317,6 +370,10 @@ func (vc VMessConfig) String() string {
 		parts = append(parts, fmt.Sprintf("sni=%s", vc.sni))
 	}
 
+	if vc.xhttpMode != "" {
+		parts = append(parts, fmt.Sprintf("xhttpMode=%s", vc.xhttpMode))
+	}
+
 	if vc.allowInsecure {
 		parts = append(parts, "allowInsecure=true")
 	}
@@ -334,7 +391,9 @@ func (vc VMessConfig) Equals(other VMessConfig) bool {
 		vc.serviceName == other.serviceName &&
 		vc.tls == other.tls &&
 		vc.sni == other.sni &&
-		vc.allowInsecure == other.allowInsecure
+		vc.allowInsecure == other.allowInsecure &&
+		vc.xhttpMode == other.xhttpMode &&
+		vc.xhttpExtra == other.xhttpExtra
 }
 
 // isValidVMessSecurity validates the security type
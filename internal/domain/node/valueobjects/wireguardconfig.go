@@ -0,0 +1,231 @@
+package valueobjects
+
+import (
+	"crypto/ecdh"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+	"net/netip"
+	"strings"
+)
+
+// WireGuard-specific constants
+const (
+	// DefaultWireGuardMTU is the default tunnel MTU
+	DefaultWireGuardMTU = 1420
+	// DefaultWireGuardKeepalive is the default persistent keepalive interval in seconds
+	DefaultWireGuardKeepalive = 25
+	// DefaultWireGuardDNS is the default DNS server pushed to clients
+	DefaultWireGuardDNS = "1.1.1.1"
+	// DefaultWireGuardAddressPool is the default tunnel address pool
+	DefaultWireGuardAddressPool = "10.66.0.0/16"
+
+	minWireGuardMTU = 1280
+	maxWireGuardMTU = 1500
+	// maxWireGuardPoolPrefix keeps room for the server address and at least one peer
+	maxWireGuardPoolPrefix = 30
+)
+
+// WireGuardConfig represents the WireGuard protocol configuration of a node.
+// The server keypair is stored here; each subscription gets a random peer keypair and a
+// tunnel address allocated from the address pool (see FreePeerAddresses), stored per node.
+// This is an immutable value object following DDD principles
+type WireGuardConfig struct {
+	privateKey          string
+	publicKey           string
+	addressPool         netip.Prefix
+	mtu                 int
+	dns                 string
+	persistentKeepalive int
+}
+
+// NewWireGuardConfig creates a new WireGuardConfig with validation.
+// addressPool is an IPv4 CIDR (default 10.66.0.0/16); the server takes the first host address
+// and peers the following ones.
+func NewWireGuardConfig(
+	privateKey string,
+	addressPool string,
+	mtu int,
+	dns string,
+	persistentKeepalive int,
+) (WireGuardConfig, error) {
+	publicKey, err := WireGuardPublicKey(privateKey)
+	if err != nil {
+		return WireGuardConfig{}, err
+	}
+
+	addressPool = strings.TrimSpace(addressPool)
+	if addressPool == "" {
+		addressPool = DefaultWireGuardAddressPool
+	}
+	pool, err := netip.ParsePrefix(addressPool)
+	if err != nil {
+		return WireGuardConfig{}, fmt.Errorf("invalid address pool %q: %w", addressPool, err)
+	}
+	if !pool.Addr().Is4() {
+		return WireGuardConfig{}, fmt.Errorf("address pool must be an IPv4 CIDR")
+	}
+	if pool.Bits() > maxWireGuardPoolPrefix {
+		return WireGuardConfig{}, fmt.Errorf("address pool /%d is too small (max /%d)", pool.Bits(), maxWireGuardPoolPrefix)
+	}
+
+	if mtu == 0 {
+		mtu = DefaultWireGuardMTU
+	}
+	if mtu < minWireGuardMTU || mtu > maxWireGuardMTU {
+		return WireGuardConfig{}, fmt.Errorf("mtu must be between %d and %d", minWireGuardMTU, maxWireGuardMTU)
+	}
+
+	dns = strings.TrimSpace(dns)
+	if dns == "" {
+		dns = DefaultWireGuardDNS
+	}
+	for _, server := range strings.Split(dns, ",") {
+		if _, err := netip.ParseAddr(strings.TrimSpace(server)); err != nil {
+			return WireGuardConfig{}, fmt.Errorf("invalid dns server %q", server)
+		}
+	}
+
+	if persistentKeepalive < 0 || persistentKeepalive > 65535 {
+		return WireGuardConfig{}, fmt.Errorf("persistent keepalive must be between 0 and 65535")
+	}
+
+	return WireGuardConfig{
+		privateKey:          privateKey,
+		publicKey:           publicKey,
+		addressPool:         pool.Masked(),
+		mtu:                 mtu,
+		dns:                 dns,
+		persistentKeepalive: persistentKeepalive,
+	}, nil
+}
+
+// PrivateKey returns the server private key (base64)
+func (wc WireGuardConfig) PrivateKey() string {
+	return wc.privateKey
+}
+
+// PublicKey returns the server public key (base64)
+func (wc WireGuardConfig) PublicKey() string {
+	return wc.publicKey
+}
+
+// AddressPool returns the tunnel address pool in CIDR notation
+func (wc WireGuardConfig) AddressPool() string {
+	return wc.addressPool.String()
+}
+
+// MTU returns the tunnel MTU
+func (wc WireGuardConfig) MTU() int {
+	return wc.mtu
+}
+
+// DNS returns the comma-separated DNS servers pushed to clients
+func (wc WireGuardConfig) DNS() string {
+	return wc.dns
+}
+
+// DNSServers returns the DNS servers pushed to clients as a list
+func (wc WireGuardConfig) DNSServers() []string {
+	parts := strings.Split(wc.dns, ",")
+	servers := make([]string, 0, len(parts))
+	for _, p := range parts {
+		if p = strings.TrimSpace(p); p != "" {
+			servers = append(servers, p)
+		}
+	}
+	return servers
+}
+
+// PersistentKeepalive returns the persistent keepalive interval in seconds (0 = disabled)
+func (wc WireGuardConfig) PersistentKeepalive() int {
+	return wc.persistentKeepalive
+}
+
+// ServerAddress returns the server interface address with the pool prefix length, e.g. "10.66.0.1/16"
+func (wc WireGuardConfig) ServerAddress() string {
+	return netip.PrefixFrom(wc.addressPool.Addr().Next(), wc.addressPool.Bits()).String()
+}
+
+// PeerCapacity returns how many peer addresses the pool holds
+// (all host addresses except the network, server and broadcast addresses).
+func (wc WireGuardConfig) PeerCapacity() int {
+	if !wc.addressPool.IsValid() {
+		return 0
+	}
+	return 1<<(32-wc.addressPool.Bits()) - 3
+}
+
+// IsPeerAddress reports whether address is a peer address inside the pool,
+// e.g. to detect allocations made before the pool was changed.
+func (wc WireGuardConfig) IsPeerAddress(address string) bool {
+	addr, err := netip.ParseAddr(address)
+	if err != nil || !addr.Is4() || !wc.addressPool.Contains(addr) {
+		return false
+	}
+	offset := ipv4ToUint32(addr) - ipv4ToUint32(wc.addressPool.Addr())
+	return offset >= 2 && offset < uint32(1)<<(32-wc.addressPool.Bits())-1
+}
+
+// FreePeerAddresses returns up to limit peer addresses of the pool not contained in used, lowest
+// first, e.g. ["10.66.0.2", "10.66.0.5"]. The server takes the first host address and peers the
+// following ones. Fewer addresses are returned when the pool runs out.
+func (wc WireGuardConfig) FreePeerAddresses(used map[string]bool, limit int) []string {
+	if !wc.addressPool.IsValid() || limit <= 0 {
+		return nil
+	}
+
+	var free []string
+	base := ipv4ToUint32(wc.addressPool.Addr())
+	broadcast := base + uint32(1)<<(32-wc.addressPool.Bits()) - 1
+	for n := base + 2; n < broadcast && len(free) < limit; n++ {
+		address := netip.AddrFrom4([4]byte{byte(n >> 24), byte(n >> 16), byte(n >> 8), byte(n)}).String()
+		if !used[address] {
+			free = append(free, address)
+		}
+	}
+	return free
+}
+
+func ipv4ToUint32(addr netip.Addr) uint32 {
+	b := addr.As4()
+	return uint32(b[0])<<24 | uint32(b[1])<<16 | uint32(b[2])<<8 | uint32(b[3])
+}
+
+// WithKeyPairOf returns a copy of the config using the server key pair of current
+func (wc WireGuardConfig) WithKeyPairOf(current WireGuardConfig) WireGuardConfig {
+	wc.privateKey = current.privateKey
+	wc.publicKey = current.publicKey
+	return wc
+}
+
+// Equals checks if two WireGuardConfig instances are equal
+func (wc WireGuardConfig) Equals(other WireGuardConfig) bool {
+	return wc.privateKey == other.privateKey &&
+		wc.addressPool == other.addressPool &&
+		wc.mtu == other.mtu &&
+		wc.dns == other.dns &&
+		wc.persistentKeepalive == other.persistentKeepalive
+}
+
+// GenerateWireGuardPrivateKey generates a random X25519 private key (base64).
+func GenerateWireGuardPrivateKey() (string, error) {
+	key, err := ecdh.X25519().GenerateKey(rand.Reader)
+	if err != nil {
+		return "", fmt.Errorf("failed to generate wireguard key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key.Bytes()), nil
+}
+
+// WireGuardPublicKey computes the public key (base64) for a base64 X25519 private key.
+func WireGuardPublicKey(privateKey string) (string, error) {
+	raw, err := base64.StdEncoding.DecodeString(privateKey)
+	if err != nil {
+		return "", fmt.Errorf("wireguard private key must be base64-encoded: %w", err)
+	}
+	key, err := ecdh.X25519().NewPrivateKey(raw)
+	if err != nil {
+		return "", fmt.Errorf("invalid wireguard private key: %w", err)
+	}
+	return base64.StdEncoding.EncodeToString(key.PublicKey().Bytes()), nil
+}
@@ -0,0 +1,75 @@
+package valueobjects
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewWireGuardConfig_Defaults(t *testing.T) {
+	key, err := GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+
+	wc, err := NewWireGuardConfig(key, "", 0, "", DefaultWireGuardKeepalive)
+	require.NoError(t, err)
+
+	assert.Equal(t, DefaultWireGuardAddressPool, wc.AddressPool())
+	assert.Equal(t, DefaultWireGuardMTU, wc.MTU())
+	assert.Equal(t, []string{DefaultWireGuardDNS}, wc.DNSServers())
+	assert.Equal(t, "10.66.0.1/16", wc.ServerAddress())
+	assert.NotEmpty(t, wc.PublicKey())
+}
+
+func TestNewWireGuardConfig_Invalid(t *testing.T) {
+	key, err := GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+
+	_, err = NewWireGuardConfig("not-base64", "", 0, "", 0)
+	assert.Error(t, err)
+	_, err = NewWireGuardConfig(key, "fd00::/64", 0, "", 0)
+	assert.Error(t, err)
+	_, err = NewWireGuardConfig(key, "10.0.0.0/31", 0, "", 0)
+	assert.Error(t, err)
+	_, err = NewWireGuardConfig(key, "", 9000, "", 0)
+	assert.Error(t, err)
+	_, err = NewWireGuardConfig(key, "", 0, "dns.google", 0)
+	assert.Error(t, err)
+}
+
+func TestWireGuardConfig_FreePeerAddresses(t *testing.T) {
+	key, err := GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+
+	wc, err := NewWireGuardConfig(key, "10.8.0.0/29", 0, "", 0)
+	require.NoError(t, err)
+	assert.Equal(t, 5, wc.PeerCapacity())
+
+	// 10.8.0.1 is the server address
+	assert.Equal(t, []string{"10.8.0.2"}, wc.FreePeerAddresses(nil, 1))
+
+	// Released addresses below taken ones are reused first
+	used := map[string]bool{"10.8.0.2": true, "10.8.0.4": true}
+	assert.Equal(t, []string{"10.8.0.3", "10.8.0.5"}, wc.FreePeerAddresses(used, 2))
+
+	// 10.8.0.7 is the broadcast address
+	assert.Equal(t, []string{"10.8.0.3", "10.8.0.5", "10.8.0.6"}, wc.FreePeerAddresses(used, 10))
+	used["10.8.0.3"], used["10.8.0.5"], used["10.8.0.6"] = true, true, true
+	assert.Empty(t, wc.FreePeerAddresses(used, 1))
+}
+
+func TestWireGuardConfig_IsPeerAddress(t *testing.T) {
+	key, err := GenerateWireGuardPrivateKey()
+	require.NoError(t, err)
+
+	wc, err := NewWireGuardConfig(key, "10.8.0.0/29", 0, "", 0)
+	require.NoError(t, err)
+
+	assert.True(t, wc.IsPeerAddress("10.8.0.2"))
+	assert.True(t, wc.IsPeerAddress("10.8.0.6"))
+	assert.False(t, wc.IsPeerAddress("10.8.0.0"), "network address")
+	assert.False(t, wc.IsPeerAddress("10.8.0.1"), "server address")
+	assert.False(t, wc.IsPeerAddress("10.8.0.7"), "broadcast address")
+	assert.False(t, wc.IsPeerAddress("10.9.0.2"), "outside the pool")
+	assert.False(t, wc.IsPeerAddress("invalid"))
+}
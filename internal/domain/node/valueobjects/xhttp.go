@@ -0,0 +1,48 @@
+package valueobjects
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// XHTTP (SplitHTTP) upload modes shared by VLESS and VMess
+const (
+	// XHTTPModeAuto lets the client pick the mode
+	XHTTPModeAuto = "auto"
+	// XHTTPModePacketUp uploads with one POST per packet (most CDN friendly)
+	XHTTPModePacketUp = "packet-up"
+	// XHTTPModeStreamUp uploads with a single streaming POST
+	XHTTPModeStreamUp = "stream-up"
+	// XHTTPModeStreamOne carries upload and download in a single request
+	XHTTPModeStreamOne = "stream-one"
+)
+
+var validXHTTPModes = map[string]bool{
+	XHTTPModeAuto:      true,
+	XHTTPModePacketUp:  true,
+	XHTTPModeStreamUp:  true,
+	XHTTPModeStreamOne: true,
+}
+
+// normalizeXHTTPOptions validates the XHTTP mode and extra options.
+// An empty mode defaults to auto; extra must be empty or a JSON object
+// (xray "extra" settings such as xPaddingBytes or xmux).
+func normalizeXHTTPOptions(mode string, extra string) (string, string, error) {
+	if mode == "" {
+		mode = XHTTPModeAuto
+	}
+	if !validXHTTPModes[mode] {
+		return "", "", fmt.Errorf("unsupported xhttp mode: %s (must be auto, packet-up, stream-up, or stream-one)", mode)
+	}
+
+	extra = strings.TrimSpace(extra)
+	if extra != "" {
+		var obj map[string]any
+		if err := json.Unmarshal([]byte(extra), &obj); err != nil {
+			return "", "", fmt.Errorf("xhttp extra must be a JSON object: %w", err)
+		}
+	}
+
+	return mode, extra, nil
+}
@@ -0,0 +1,45 @@
+package valueobjects
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewVLESSConfig_XHTTP(t *testing.T) {
+	vc, err := NewVLESSConfig(VLESSTransportXHTTP, "", VLESSSecurityTLS, "cdn.example.com", "", false,
+		"cdn.example.com", "/xh", "", "", "", "", "", "", `{"xPaddingBytes":"100-1000"}`)
+	require.NoError(t, err)
+
+	assert.Equal(t, XHTTPModeAuto, vc.XHTTPMode())
+	uri := vc.ToURI("uuid", "1.2.3.4", 443, "node")
+	assert.True(t, strings.Contains(uri, "type=xhttp"))
+	assert.True(t, strings.Contains(uri, "mode=auto"))
+	assert.True(t, strings.Contains(uri, "extra="))
+}
+
+func TestNewVLESSConfig_XHTTPInvalid(t *testing.T) {
+	_, err := NewVLESSConfig(VLESSTransportXHTTP, "", VLESSSecurityTLS, "", "", false,
+		"", "", "", "", "", "", "", "", "")
+	assert.Error(t, err, "path is required")
+
+	_, err = NewVLESSConfig(VLESSTransportXHTTP, "", VLESSSecurityTLS, "", "", false,
+		"", "/xh", "", "", "", "", "", "stream", "")
+	assert.Error(t, err, "unknown mode")
+
+	_, err = NewVLESSConfig(VLESSTransportXHTTP, "", VLESSSecurityTLS, "", "", false,
+		"", "/xh", "", "", "", "", "", "", "[1,2]")
+	assert.Error(t, err, "extra must be an object")
+}
+
+func TestNewVMessConfig_HTTPUpgradeDropsXHTTPOptions(t *testing.T) {
+	vc, err := NewVMessConfig(0, SecurityAuto, VMessTransportHTTPUpgrade, "cdn.example.com", "/up",
+		"", true, "cdn.example.com", false, XHTTPModePacketUp, `{"a":1}`)
+	require.NoError(t, err)
+
+	assert.Empty(t, vc.XHTTPMode())
+	assert.Empty(t, vc.XHTTPExtra())
+	assert.Contains(t, vc.ToStandardURI("1.2.3.4", 443, "uuid", ""), "type=httpupgrade")
+}
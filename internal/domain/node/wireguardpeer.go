@@ -0,0 +1,78 @@
+package node
+
+import (
+	"fmt"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+// WireGuardPeer is the tunnel address and keypair of a subscription on a WireGuard node.
+// The keypair is random and generated per node, so a node agent, which only receives the
+// public key, cannot compute the private key of any subscriber.
+type WireGuardPeer struct {
+	subscriptionID uint
+	address        string // IPv4 tunnel address without prefix length
+	privateKey     string // base64 X25519 private key
+	publicKey      string
+}
+
+// NewWireGuardPeer creates a peer with a freshly generated keypair
+func NewWireGuardPeer(subscriptionID uint, address string) (*WireGuardPeer, error) {
+	if subscriptionID == 0 {
+		return nil, fmt.Errorf("subscription ID is required")
+	}
+	if address == "" {
+		return nil, fmt.Errorf("peer address is required")
+	}
+
+	privateKey, err := vo.GenerateWireGuardPrivateKey()
+	if err != nil {
+		return nil, err
+	}
+	publicKey, err := vo.WireGuardPublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WireGuardPeer{
+		subscriptionID: subscriptionID,
+		address:        address,
+		privateKey:     privateKey,
+		publicKey:      publicKey,
+	}, nil
+}
+
+// ReconstructWireGuardPeer reconstructs a peer from persistence
+func ReconstructWireGuardPeer(subscriptionID uint, address, privateKey string) (*WireGuardPeer, error) {
+	publicKey, err := vo.WireGuardPublicKey(privateKey)
+	if err != nil {
+		return nil, err
+	}
+
+	return &WireGuardPeer{
+		subscriptionID: subscriptionID,
+		address:        address,
+		privateKey:     privateKey,
+		publicKey:      publicKey,
+	}, nil
+}
+
+// SubscriptionID returns the subscription the peer belongs to
+func (p *WireGuardPeer) SubscriptionID() uint {
+	return p.subscriptionID
+}
+
+// Address returns the tunnel address, e.g. "10.66.0.7"
+func (p *WireGuardPeer) Address() string {
+	return p.address
+}
+
+// PrivateKey returns the peer private key; it is only handed to the subscriber
+func (p *WireGuardPeer) PrivateKey() string {
+	return p.privateKey
+}
+
+// PublicKey returns the peer public key sent to the node agent
+func (p *WireGuardPeer) PublicKey() string {
+	return p.publicKey
+}
@@ -0,0 +1,43 @@
+package node
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+)
+
+func TestNewWireGuardPeer(t *testing.T) {
+	peer, err := NewWireGuardPeer(7, "10.66.0.2")
+	require.NoError(t, err)
+	assert.Equal(t, uint(7), peer.SubscriptionID())
+	assert.Equal(t, "10.66.0.2", peer.Address())
+
+	publicKey, err := vo.WireGuardPublicKey(peer.PrivateKey())
+	require.NoError(t, err)
+	assert.Equal(t, publicKey, peer.PublicKey())
+
+	// Keypairs are random, never derived from the subscription
+	other, err := NewWireGuardPeer(7, "10.66.0.2")
+	require.NoError(t, err)
+	assert.NotEqual(t, peer.PrivateKey(), other.PrivateKey())
+
+	_, err = NewWireGuardPeer(0, "10.66.0.2")
+	assert.Error(t, err)
+	_, err = NewWireGuardPeer(7, "")
+	assert.Error(t, err)
+}
+
+func TestReconstructWireGuardPeer(t *testing.T) {
+	peer, err := NewWireGuardPeer(7, "10.66.0.2")
+	require.NoError(t, err)
+
+	restored, err := ReconstructWireGuardPeer(7, "10.66.0.2", peer.PrivateKey())
+	require.NoError(t, err)
+	assert.Equal(t, peer, restored)
+
+	_, err = ReconstructWireGuardPeer(7, "10.66.0.2", "not-a-key")
+	assert.Error(t, err)
+}
@@ -1,6 +1,10 @@
 package payment
 
-import "context"
+import (
+	"context"
+
+	vo "github.com/orris-inc/orris/internal/domain/payment/valueobjects"
+)
 
 type PaymentRepository interface {
 	Create(ctx context.Context, payment *Payment) error
@@ -25,4 +29,6 @@ type PaymentRepository interface {
 	GetPaidPaymentsNeedingActivation(ctx context.Context) ([]*Payment, error)
 	// CountPendingUSDTPaymentsByUser returns the count of pending USDT payments for a user
 	CountPendingUSDTPaymentsByUser(ctx context.Context, userID uint) (int, error)
+	// CountByStatus returns the number of payments in each status
+	CountByStatus(ctx context.Context) (map[vo.PaymentStatus]int64, error)
 }
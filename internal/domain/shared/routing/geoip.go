@@ -0,0 +1,25 @@
+package routing
+
+// MaxGeoIPItems is the maximum number of GeoIP country codes in a single condition.
+const MaxGeoIPItems = 100
+
+// GeoIPPrivate is the GeoIP code matching private/LAN addresses.
+const GeoIPPrivate = "private"
+
+// IsValidGeoIPCode checks if code is a GeoIP code as used by route rules and
+// sing-geoip rule sets: a lowercase ISO 3166-1 alpha-2 country code (e.g., "cn", "us")
+// or "private".
+func IsValidGeoIPCode(code string) bool {
+	if code == GeoIPPrivate {
+		return true
+	}
+	if len(code) != 2 {
+		return false
+	}
+	for i := 0; i < len(code); i++ {
+		if code[i] < 'a' || code[i] > 'z' {
+			return false
+		}
+	}
+	return true
+}
@@ -184,7 +184,7 @@ func (r *RouteRule) Validate() error {
 		{"domain_regex", len(r.domainRegex), maxConditionItems},
 		{"ip_cidr", len(r.ipCIDR), maxConditionItems},
 		{"source_ip_cidr", len(r.sourceIPCIDR), maxConditionItems},
-		{"geo_ip", len(r.geoIP), 100},
+		{"geo_ip", len(r.geoIP), MaxGeoIPItems},
 		{"geo_site", len(r.geoSite), 100},
 		{"port", len(r.port), maxConditionItems},
 		{"source_port", len(r.sourcePort), maxConditionItems},
@@ -52,36 +52,43 @@ func GetTrafficResetMode(plan *Plan) TrafficResetMode {
 // In both modes, if the subscription's CurrentPeriodStart is after the resolved period start
 // (e.g. after a manual usage reset), it is used as a floor to exclude pre-reset traffic.
 func ResolveTrafficPeriod(plan *Plan, sub *Subscription) TrafficPeriod {
-	mode := GetTrafficResetMode(plan)
+	if sub == nil {
+		return calendarMonthPeriod()
+	}
+
+	lifetime := sub.BillingCycle() != nil && sub.BillingCycle().IsLifetime()
+	return ResolveTrafficPeriodWithBounds(plan, lifetime, sub.CurrentPeriodStart(), sub.CurrentPeriodEnd())
+}
 
+// ResolveTrafficPeriodWithBounds is ResolveTrafficPeriod for callers that only hold the
+// subscription's billing cycle and current period bounds (e.g. read models built from a
+// subscription link token) instead of the Subscription aggregate.
+func ResolveTrafficPeriodWithBounds(plan *Plan, lifetime bool, currentPeriodStart, currentPeriodEnd time.Time) TrafficPeriod {
 	// Lifetime subscriptions always accumulate traffic from their start date.
 	// They must not be subject to calendar-month resets regardless of the plan's reset mode.
-	if sub != nil && sub.BillingCycle() != nil && sub.BillingCycle().IsLifetime() {
+	if lifetime || GetTrafficResetMode(plan) == TrafficResetBillingCycle {
 		return TrafficPeriod{
-			Start: sub.CurrentPeriodStart(),
-			End:   sub.CurrentPeriodEnd(),
+			Start: currentPeriodStart,
+			End:   currentPeriodEnd,
 		}
 	}
 
-	if mode == TrafficResetBillingCycle && sub != nil {
-		return TrafficPeriod{
-			Start: sub.CurrentPeriodStart(),
-			End:   sub.CurrentPeriodEnd(),
-		}
-	}
-
-	// Fallback: calendar month (default, or billing_cycle with nil sub)
-	bizNow := biztime.ToBizTimezone(biztime.NowUTC())
-	period := TrafficPeriod{
-		Start: biztime.StartOfMonthUTC(bizNow.Year(), bizNow.Month()),
-		End:   biztime.EndOfMonthUTC(bizNow.Year(), bizNow.Month()),
-	}
+	period := calendarMonthPeriod()
 
 	// If subscription's period start is after the calendar month start (e.g. after manual
 	// usage reset), use it as the floor so pre-reset traffic is excluded.
-	if sub != nil && sub.CurrentPeriodStart().After(period.Start) {
-		period.Start = sub.CurrentPeriodStart()
+	if currentPeriodStart.After(period.Start) {
+		period.Start = currentPeriodStart
 	}
 
 	return period
 }
+
+// calendarMonthPeriod returns the current calendar month in business timezone.
+func calendarMonthPeriod() TrafficPeriod {
+	bizNow := biztime.ToBizTimezone(biztime.NowUTC())
+	return TrafficPeriod{
+		Start: biztime.StartOfMonthUTC(bizNow.Year(), bizNow.Month()),
+		End:   biztime.EndOfMonthUTC(bizNow.Year(), bizNow.Month()),
+	}
+}
@@ -38,6 +38,8 @@ type SubscriptionUsageStats struct {
 	upload         uint64
 	download       uint64
 	total          uint64
+	rawUpload      uint64 // upload before node traffic multiplier (operator statistics)
+	rawDownload    uint64 // download before node traffic multiplier (operator statistics)
 	granularity    Granularity
 	period         time.Time // date for daily, first day of month for monthly
 	createdAt      time.Time
@@ -92,6 +94,7 @@ func ReconstructSubscriptionUsageStats(
 	resourceID uint,
 	subscriptionID *uint,
 	upload, download, total uint64,
+	rawUpload, rawDownload uint64,
 	granularity Granularity,
 	period, createdAt, updatedAt time.Time,
 ) (*SubscriptionUsageStats, error) {
@@ -120,6 +123,8 @@ func ReconstructSubscriptionUsageStats(
 		upload:         upload,
 		download:       download,
 		total:          total,
+		rawUpload:      rawUpload,
+		rawDownload:    rawDownload,
 		granularity:    granularity,
 		period:         period,
 		createdAt:      createdAt,
@@ -172,6 +177,16 @@ func (s *SubscriptionUsageStats) Total() uint64 {
 	return s.total
 }
 
+// RawUpload returns the upload traffic in bytes before the node traffic multiplier
+func (s *SubscriptionUsageStats) RawUpload() uint64 {
+	return s.rawUpload
+}
+
+// RawDownload returns the download traffic in bytes before the node traffic multiplier
+func (s *SubscriptionUsageStats) RawDownload() uint64 {
+	return s.rawDownload
+}
+
 // Granularity returns the time granularity (daily or monthly)
 func (s *SubscriptionUsageStats) Granularity() Granularity {
 	return s.granularity
@@ -226,6 +241,18 @@ func (s *SubscriptionUsageStats) Accumulate(upload, download uint64) error {
 	return nil
 }
 
+// AccumulateRaw adds upload and download traffic before the node traffic multiplier.
+// Billed usage (Accumulate) drives quotas; raw usage is kept for operator statistics.
+func (s *SubscriptionUsageStats) AccumulateRaw(upload, download uint64) {
+	if upload == 0 && download == 0 {
+		return
+	}
+
+	s.rawUpload += upload
+	s.rawDownload += download
+	s.updatedAt = biztime.NowUTC()
+}
+
 // TotalTraffic returns the total traffic (upload + download)
 func (s *SubscriptionUsageStats) TotalTraffic() uint64 {
 	return s.total
@@ -0,0 +1,64 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// ChallTestSrvProvider implements DNSProvider using the management API of
+// pebble-challtestsrv, the DNS server that a local Pebble CA resolves challenges against.
+// It lets the full issuance flow run against Pebble without a real DNS zone.
+type ChallTestSrvProvider struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewChallTestSrvProvider creates a provider for the pebble-challtestsrv API at baseURL
+// (e.g. http://localhost:8055).
+func NewChallTestSrvProvider(baseURL string) *ChallTestSrvProvider {
+	return &ChallTestSrvProvider{
+		baseURL:    strings.TrimSuffix(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+// Present implements DNSProvider.
+func (p *ChallTestSrvProvider) Present(ctx context.Context, fqdn, value string) error {
+	return p.post(ctx, "/set-txt", map[string]string{"host": fqdn + ".", "value": value})
+}
+
+// CleanUp implements DNSProvider.
+func (p *ChallTestSrvProvider) CleanUp(ctx context.Context, fqdn, _ string) error {
+	return p.post(ctx, "/clear-txt", map[string]string{"host": fqdn + "."})
+}
+
+func (p *ChallTestSrvProvider) post(ctx context.Context, path string, body any) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, p.baseURL+path, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("challtestsrv request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	_, _ = io.Copy(io.Discard, resp.Body)
+
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("challtestsrv %s returned HTTP %d", path, resp.StatusCode)
+	}
+	return nil
+}
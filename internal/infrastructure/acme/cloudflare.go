@@ -0,0 +1,198 @@
+package acme
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	cloudflareAPIURL = "https://api.cloudflare.com/client/v4"
+	// cloudflareRecordTTL is the TTL of challenge records (Cloudflare's minimum for non-proxied records)
+	cloudflareRecordTTL = 120
+	// cloudflareRequestTimeout bounds each Cloudflare API call
+	cloudflareRequestTimeout = 30 * time.Second
+	// maxCloudflareResponseSize limits the Cloudflare API response body
+	maxCloudflareResponseSize = 1 << 20
+)
+
+// cloudflareResponse is the common Cloudflare API v4 response envelope.
+type cloudflareResponse struct {
+	Success bool `json:"success"`
+	Errors  []struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"errors"`
+	Result json.RawMessage `json:"result"`
+}
+
+type cloudflareZone struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type cloudflareRecord struct {
+	ID string `json:"id"`
+}
+
+// CloudflareProvider implements DNSProvider using the Cloudflare API.
+// The API token needs the Zone.DNS edit permission on the zones it issues for.
+type CloudflareProvider struct {
+	apiToken         string
+	baseURL          string
+	httpClient       *http.Client
+	propagationDelay time.Duration
+
+	mu        sync.Mutex
+	zoneIDs   map[string]string // zone name -> zone ID
+	recordIDs map[string]string // fqdn + value -> record ID, for CleanUp
+}
+
+// NewCloudflareProvider creates a new Cloudflare DNS provider.
+func NewCloudflareProvider(apiToken string, propagationDelay time.Duration) *CloudflareProvider {
+	return &CloudflareProvider{
+		apiToken:         apiToken,
+		baseURL:          cloudflareAPIURL,
+		httpClient:       &http.Client{Timeout: cloudflareRequestTimeout},
+		propagationDelay: propagationDelay,
+		zoneIDs:          make(map[string]string),
+		recordIDs:        make(map[string]string),
+	}
+}
+
+// Present implements DNSProvider.
+func (p *CloudflareProvider) Present(ctx context.Context, fqdn, value string) error {
+	zoneID, err := p.findZoneID(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+
+	body := map[string]any{
+		"type":    "TXT",
+		"name":    fqdn,
+		"content": value,
+		"ttl":     cloudflareRecordTTL,
+	}
+	var record cloudflareRecord
+	if err := p.do(ctx, http.MethodPost, "/zones/"+zoneID+"/dns_records", body, &record); err != nil {
+		return fmt.Errorf("failed to create TXT record %s: %w", fqdn, err)
+	}
+
+	p.mu.Lock()
+	p.recordIDs[fqdn+" "+value] = record.ID
+	p.mu.Unlock()
+	return nil
+}
+
+// CleanUp implements DNSProvider.
+func (p *CloudflareProvider) CleanUp(ctx context.Context, fqdn, value string) error {
+	key := fqdn + " " + value
+	p.mu.Lock()
+	recordID, ok := p.recordIDs[key]
+	delete(p.recordIDs, key)
+	p.mu.Unlock()
+	if !ok {
+		return nil
+	}
+
+	zoneID, err := p.findZoneID(ctx, fqdn)
+	if err != nil {
+		return err
+	}
+	if err := p.do(ctx, http.MethodDelete, "/zones/"+zoneID+"/dns_records/"+recordID, nil, nil); err != nil {
+		return fmt.Errorf("failed to delete TXT record %s: %w", fqdn, err)
+	}
+	return nil
+}
+
+// WaitForPropagation implements PropagationWaiter.
+// Cloudflare serves new records from its edge within seconds; a fixed delay covers the rest.
+func (p *CloudflareProvider) WaitForPropagation(ctx context.Context, _, _ string) error {
+	return sleepContext(ctx, p.propagationDelay)
+}
+
+// findZoneID finds the most specific zone in the account that contains fqdn.
+func (p *CloudflareProvider) findZoneID(ctx context.Context, fqdn string) (string, error) {
+	labels := strings.Split(strings.TrimSuffix(fqdn, "."), ".")
+	// Skip the first label (_acme-challenge) and never query a bare TLD
+	for i := 1; i < len(labels)-1; i++ {
+		name := strings.Join(labels[i:], ".")
+
+		p.mu.Lock()
+		id, ok := p.zoneIDs[name]
+		p.mu.Unlock()
+		if ok {
+			return id, nil
+		}
+
+		var zones []cloudflareZone
+		query := url.Values{"name": {name}, "status": {"active"}}
+		if err := p.do(ctx, http.MethodGet, "/zones?"+query.Encode(), nil, &zones); err != nil {
+			return "", fmt.Errorf("failed to look up zone %s: %w", name, err)
+		}
+		if len(zones) > 0 {
+			p.mu.Lock()
+			p.zoneIDs[name] = zones[0].ID
+			p.mu.Unlock()
+			return zones[0].ID, nil
+		}
+	}
+	return "", fmt.Errorf("no Cloudflare zone found for %s", fqdn)
+}
+
+// do sends a Cloudflare API request and decodes the result into out (if non-nil).
+func (p *CloudflareProvider) do(ctx context.Context, method, path string, body, out any) error {
+	var reader io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return err
+		}
+		reader = bytes.NewReader(data)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, p.baseURL+path, reader)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Authorization", "Bearer "+p.apiToken)
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+
+	resp, err := p.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := io.ReadAll(io.LimitReader(resp.Body, maxCloudflareResponseSize))
+	if err != nil {
+		return fmt.Errorf("failed to read response: %w", err)
+	}
+
+	var envelope cloudflareResponse
+	if err := json.Unmarshal(data, &envelope); err != nil {
+		return fmt.Errorf("unexpected response (HTTP %d)", resp.StatusCode)
+	}
+	if !envelope.Success {
+		if len(envelope.Errors) > 0 {
+			return fmt.Errorf("cloudflare API error %d: %s", envelope.Errors[0].Code, envelope.Errors[0].Message)
+		}
+		return fmt.Errorf("cloudflare API request failed (HTTP %d)", resp.StatusCode)
+	}
+
+	if out != nil && len(envelope.Result) > 0 {
+		if err := json.Unmarshal(envelope.Result, out); err != nil {
+			return fmt.Errorf("failed to decode result: %w", err)
+		}
+	}
+	return nil
+}
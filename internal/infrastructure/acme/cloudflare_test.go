@@ -0,0 +1,93 @@
+package acme
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudflareProvider(t *testing.T) {
+	var (
+		mu      sync.Mutex
+		created []map[string]any
+		deleted []string
+	)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /zones", func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "Bearer token", r.Header.Get("Authorization"))
+		result := []cloudflareZone{}
+		if r.URL.Query().Get("name") == "example.com" {
+			result = append(result, cloudflareZone{ID: "zone1", Name: "example.com"})
+		}
+		writeCloudflare(w, true, result)
+	})
+	mux.HandleFunc("POST /zones/zone1/dns_records", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]any
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&body))
+		mu.Lock()
+		created = append(created, body)
+		mu.Unlock()
+		writeCloudflare(w, true, cloudflareRecord{ID: "rec1"})
+	})
+	mux.HandleFunc("DELETE /zones/zone1/dns_records/{id}", func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		deleted = append(deleted, r.PathValue("id"))
+		mu.Unlock()
+		writeCloudflare(w, true, map[string]string{"id": r.PathValue("id")})
+	})
+	server := httptest.NewServer(mux)
+	defer server.Close()
+
+	p := NewCloudflareProvider("token", 0)
+	p.baseURL = server.URL
+	ctx := context.Background()
+
+	fqdn := ChallengeFQDN("*.node.example.com")
+	assert.Equal(t, "_acme-challenge.node.example.com", fqdn)
+
+	require.NoError(t, p.Present(ctx, fqdn, "v1"))
+	require.Len(t, created, 1)
+	assert.Equal(t, "TXT", created[0]["type"])
+	assert.Equal(t, fqdn, created[0]["name"])
+	assert.Equal(t, "v1", created[0]["content"])
+
+	require.NoError(t, p.WaitForPropagation(ctx, fqdn, "v1"))
+	require.NoError(t, p.CleanUp(ctx, fqdn, "v1"))
+	assert.Equal(t, []string{"rec1"}, deleted)
+
+	// Unknown records are ignored
+	require.NoError(t, p.CleanUp(ctx, fqdn, "v1"))
+	assert.Len(t, deleted, 1)
+
+	err := p.Present(ctx, "_acme-challenge.example.org", "v2")
+	assert.ErrorContains(t, err, "no Cloudflare zone")
+}
+
+func TestCloudflareProvider_APIError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+		_ = json.NewEncoder(w).Encode(map[string]any{
+			"success": false,
+			"errors":  []map[string]any{{"code": 9109, "message": "Invalid access token"}},
+		})
+	}))
+	defer server.Close()
+
+	p := NewCloudflareProvider("bad", 0)
+	p.baseURL = server.URL
+
+	err := p.Present(context.Background(), "_acme-challenge.example.com", "v1")
+	assert.ErrorContains(t, err, "Invalid access token")
+}
+
+func writeCloudflare(w http.ResponseWriter, success bool, result any) {
+	w.Header().Set("Content-Type", "application/json")
+	_ = json.NewEncoder(w).Encode(map[string]any{"success": success, "errors": []any{}, "result": result})
+}
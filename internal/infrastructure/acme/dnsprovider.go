@@ -0,0 +1,48 @@
+// Package acme issues TLS certificates from an ACME CA (RFC 8555) using DNS-01 challenges.
+package acme
+
+import (
+	"context"
+	"strings"
+	"time"
+)
+
+// DNS provider names, as referenced by certificate records.
+const (
+	DNSProviderCloudflare   = "cloudflare"
+	DNSProviderChallTestSrv = "challtestsrv"
+)
+
+// DNSProvider publishes and removes the TXT records used for DNS-01 challenges.
+// fqdn is the record name without the trailing dot, e.g. "_acme-challenge.example.com".
+type DNSProvider interface {
+	Present(ctx context.Context, fqdn, value string) error
+	CleanUp(ctx context.Context, fqdn, value string) error
+}
+
+// PropagationWaiter is implemented by DNS providers whose records take time to reach
+// the authoritative name servers. The issuer waits on it before asking the CA to validate.
+type PropagationWaiter interface {
+	WaitForPropagation(ctx context.Context, fqdn, value string) error
+}
+
+// ChallengeFQDN returns the DNS-01 record name for a domain.
+// Wildcard domains are validated on their base domain.
+func ChallengeFQDN(domain string) string {
+	return "_acme-challenge." + strings.TrimPrefix(strings.TrimSuffix(domain, "."), "*.")
+}
+
+// sleepContext waits for d or until ctx is done.
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,333 @@
+package acme
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/hex"
+	"encoding/pem"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	xacme "golang.org/x/crypto/acme"
+
+	sharedConfig "github.com/orris-inc/orris/internal/shared/config"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// IssuedCertificate is a certificate obtained from the CA together with its private key.
+type IssuedCertificate struct {
+	CertificatePEM string // leaf certificate followed by the issuer chain
+	PrivateKeyPEM  string
+	Fingerprint    string // SHA-256 of the leaf certificate (hex)
+	NotBefore      time.Time
+	NotAfter       time.Time
+}
+
+// IssuerConfig configures an Issuer.
+type IssuerConfig struct {
+	DirectoryURL string
+	Email        string
+	AccountKey   crypto.Signer
+	HTTPClient   *http.Client // optional
+}
+
+// Issuer obtains certificates from an ACME CA, proving domain control with DNS-01
+// challenges published through the configured DNS providers.
+type Issuer struct {
+	client    *xacme.Client
+	email     string
+	providers map[string]DNSProvider
+	logger    logger.Interface
+
+	registerMu sync.Mutex
+	registered bool
+}
+
+// NewIssuer creates a new Issuer.
+func NewIssuer(cfg IssuerConfig, providers map[string]DNSProvider, log logger.Interface) *Issuer {
+	return &Issuer{
+		client: &xacme.Client{
+			Key:          cfg.AccountKey,
+			DirectoryURL: cfg.DirectoryURL,
+			HTTPClient:   cfg.HTTPClient,
+			UserAgent:    "orris",
+		},
+		email:     cfg.Email,
+		providers: providers,
+		logger:    log,
+	}
+}
+
+// NewIssuerFromConfig creates an Issuer from the application configuration, loading
+// (or generating) the account key and registering every DNS provider with credentials.
+func NewIssuerFromConfig(cfg sharedConfig.ACMEConfig, log logger.Interface) (*Issuer, error) {
+	if cfg.Email == "" {
+		return nil, fmt.Errorf("acme.email is required")
+	}
+
+	providers := make(map[string]DNSProvider)
+	if cfg.DNS.Cloudflare.APIToken != "" {
+		delay := time.Duration(cfg.DNS.Cloudflare.PropagationDelay) * time.Second
+		providers[DNSProviderCloudflare] = NewCloudflareProvider(cfg.DNS.Cloudflare.APIToken, delay)
+	}
+	if cfg.DNS.ChallTestSrv.URL != "" {
+		providers[DNSProviderChallTestSrv] = NewChallTestSrvProvider(cfg.DNS.ChallTestSrv.URL)
+	}
+	if len(providers) == 0 {
+		return nil, fmt.Errorf("no ACME DNS provider configured")
+	}
+
+	key, err := loadOrCreateAccountKey(cfg.AccountKeyPath)
+	if err != nil {
+		return nil, err
+	}
+
+	var httpClient *http.Client
+	if cfg.InsecureSkipVerify {
+		// Only meant for a local Pebble CA, which serves its directory with a self-signed certificate
+		httpClient = &http.Client{
+			Timeout: time.Minute,
+			Transport: &http.Transport{
+				TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+			},
+		}
+	}
+
+	return NewIssuer(IssuerConfig{
+		DirectoryURL: cfg.DirectoryURL,
+		Email:        cfg.Email,
+		AccountKey:   key,
+		HTTPClient:   httpClient,
+	}, providers, log), nil
+}
+
+// HasDNSProvider reports whether a DNS provider with the given name is configured.
+func (i *Issuer) HasDNSProvider(name string) bool {
+	_, ok := i.providers[name]
+	return ok
+}
+
+// DNSProviders returns the names of the configured DNS providers.
+func (i *Issuer) DNSProviders() []string {
+	names := make([]string, 0, len(i.providers))
+	for name := range i.providers {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	return names
+}
+
+// Issue obtains a certificate for domain (an exact name or a *.wildcard) using the
+// named DNS provider. The returned key is a fresh ECDSA P-256 key.
+func (i *Issuer) Issue(ctx context.Context, domain, dnsProvider string) (*IssuedCertificate, error) {
+	provider, ok := i.providers[dnsProvider]
+	if !ok {
+		return nil, fmt.Errorf("DNS provider %q is not configured", dnsProvider)
+	}
+
+	if err := i.ensureAccount(ctx); err != nil {
+		return nil, err
+	}
+
+	order, err := i.client.AuthorizeOrder(ctx, xacme.DomainIDs(domain))
+	if err != nil {
+		return nil, fmt.Errorf("failed to create order: %w", err)
+	}
+
+	for _, authzURL := range order.AuthzURLs {
+		if err := i.authorize(ctx, authzURL, provider); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := i.client.WaitOrder(ctx, order.URI); err != nil {
+		return nil, fmt.Errorf("order not ready: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+	csr, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:  pkix.Name{CommonName: domain},
+		DNSNames: []string{domain},
+	}, key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CSR: %w", err)
+	}
+
+	chain, _, err := i.client.CreateOrderCert(ctx, order.FinalizeURL, csr, true)
+	if err != nil {
+		return nil, fmt.Errorf("failed to finalize order: %w", err)
+	}
+
+	issued, err := buildIssuedCertificate(chain, key)
+	if err != nil {
+		return nil, err
+	}
+
+	i.logger.Infow("certificate issued",
+		"domain", domain,
+		"dns_provider", dnsProvider,
+		"not_after", issued.NotAfter,
+	)
+	return issued, nil
+}
+
+// ensureAccount registers the account key with the CA once per process.
+// An existing registration for the key is reused.
+func (i *Issuer) ensureAccount(ctx context.Context) error {
+	i.registerMu.Lock()
+	defer i.registerMu.Unlock()
+	if i.registered {
+		return nil
+	}
+
+	acct := &xacme.Account{Contact: []string{"mailto:" + i.email}}
+	if _, err := i.client.Register(ctx, acct, xacme.AcceptTOS); err != nil && !errors.Is(err, xacme.ErrAccountAlreadyExists) {
+		return fmt.Errorf("failed to register ACME account: %w", err)
+	}
+	i.registered = true
+	return nil
+}
+
+// authorize fulfils the DNS-01 challenge of a pending authorization.
+func (i *Issuer) authorize(ctx context.Context, authzURL string, provider DNSProvider) error {
+	authz, err := i.client.GetAuthorization(ctx, authzURL)
+	if err != nil {
+		return fmt.Errorf("failed to get authorization: %w", err)
+	}
+	if authz.Status == xacme.StatusValid {
+		return nil
+	}
+
+	var chal *xacme.Challenge
+	for _, c := range authz.Challenges {
+		if c.Type == "dns-01" {
+			chal = c
+			break
+		}
+	}
+	if chal == nil {
+		return fmt.Errorf("CA offered no dns-01 challenge for %s", authz.Identifier.Value)
+	}
+
+	value, err := i.client.DNS01ChallengeRecord(chal.Token)
+	if err != nil {
+		return fmt.Errorf("failed to compute challenge record: %w", err)
+	}
+	fqdn := ChallengeFQDN(authz.Identifier.Value)
+
+	if err := provider.Present(ctx, fqdn, value); err != nil {
+		return fmt.Errorf("failed to publish challenge record: %w", err)
+	}
+	defer func() {
+		// Clean up with a fresh context so records are removed even after a timeout
+		cleanupCtx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		if err := provider.CleanUp(cleanupCtx, fqdn, value); err != nil {
+			i.logger.Warnw("failed to remove challenge record", "fqdn", fqdn, "error", err)
+		}
+	}()
+
+	if waiter, ok := provider.(PropagationWaiter); ok {
+		if err := waiter.WaitForPropagation(ctx, fqdn, value); err != nil {
+			return fmt.Errorf("challenge record did not propagate: %w", err)
+		}
+	}
+
+	if _, err := i.client.Accept(ctx, chal); err != nil {
+		return fmt.Errorf("failed to accept challenge: %w", err)
+	}
+	if _, err := i.client.WaitAuthorization(ctx, authz.URI); err != nil {
+		return fmt.Errorf("authorization failed for %s: %w", authz.Identifier.Value, err)
+	}
+	return nil
+}
+
+// buildIssuedCertificate encodes the DER chain and key as PEM and extracts the leaf metadata.
+func buildIssuedCertificate(chain [][]byte, key *ecdsa.PrivateKey) (*IssuedCertificate, error) {
+	if len(chain) == 0 {
+		return nil, fmt.Errorf("CA returned an empty certificate chain")
+	}
+	leaf, err := x509.ParseCertificate(chain[0])
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse issued certificate: %w", err)
+	}
+
+	var certPEM strings.Builder
+	for _, der := range chain {
+		if err := pem.Encode(&certPEM, &pem.Block{Type: "CERTIFICATE", Bytes: der}); err != nil {
+			return nil, err
+		}
+	}
+
+	keyDER, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode certificate key: %w", err)
+	}
+	keyPEM := pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDER})
+
+	sum := sha256.Sum256(chain[0])
+	return &IssuedCertificate{
+		CertificatePEM: certPEM.String(),
+		PrivateKeyPEM:  string(keyPEM),
+		Fingerprint:    hex.EncodeToString(sum[:]),
+		NotBefore:      leaf.NotBefore,
+		NotAfter:       leaf.NotAfter,
+	}, nil
+}
+
+// loadOrCreateAccountKey reads the PEM-encoded ECDSA account key at path,
+// generating and saving a new one if the file does not exist.
+func loadOrCreateAccountKey(path string) (crypto.Signer, error) {
+	if path == "" {
+		return nil, fmt.Errorf("acme.account_key_path is required")
+	}
+
+	data, err := os.ReadFile(path)
+	if err == nil {
+		block, _ := pem.Decode(data)
+		if block == nil {
+			return nil, fmt.Errorf("invalid ACME account key file %s", path)
+		}
+		key, err := x509.ParseECPrivateKey(block.Bytes)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse ACME account key: %w", err)
+		}
+		return key, nil
+	}
+	if !os.IsNotExist(err) {
+		return nil, fmt.Errorf("failed to read ACME account key: %w", err)
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate ACME account key: %w", err)
+	}
+	der, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.MkdirAll(filepath.Dir(path), 0o700); err != nil {
+		return nil, fmt.Errorf("failed to create ACME account key directory: %w", err)
+	}
+	if err := os.WriteFile(path, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: der}), 0o600); err != nil {
+		return nil, fmt.Errorf("failed to save ACME account key: %w", err)
+	}
+	return key, nil
+}
@@ -0,0 +1,143 @@
+package acme
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"encoding/pem"
+	"net/http"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/infrastructure/acme/testutil"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+func newTestIssuer(t *testing.T, directoryURL string, dns DNSProvider) *Issuer {
+	t.Helper()
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	return NewIssuer(IssuerConfig{
+		DirectoryURL: directoryURL,
+		Email:        "admin@example.com",
+		AccountKey:   key,
+	}, map[string]DNSProvider{"memory": dns}, logger.NewLogger())
+}
+
+func TestIssuer_Issue(t *testing.T) {
+	dns := testutil.NewMemoryDNS()
+	ca, err := testutil.NewCA(dns.TXT, 90*24*time.Hour)
+	require.NoError(t, err)
+	defer ca.Close()
+
+	issuer := newTestIssuer(t, ca.DirectoryURL(), dns)
+	ctx := context.Background()
+
+	for _, domain := range []string{"node1.example.com", "*.example.com"} {
+		issued, err := issuer.Issue(ctx, domain, "memory")
+		require.NoError(t, err, domain)
+
+		// Chain is leaf + root, signed by the CA and matching the private key
+		certs := decodeChain(t, issued.CertificatePEM)
+		require.Len(t, certs, 2)
+		assert.Equal(t, []string{domain}, certs[0].DNSNames)
+		require.NoError(t, certs[0].CheckSignatureFrom(ca.Root()))
+		_, err = tls.X509KeyPair([]byte(issued.CertificatePEM), []byte(issued.PrivateKeyPEM))
+		require.NoError(t, err)
+
+		assert.Len(t, issued.Fingerprint, 64)
+		assert.True(t, issued.NotAfter.After(time.Now().Add(89*24*time.Hour)))
+		assert.Zero(t, dns.Len(), "challenge records removed after issuance")
+	}
+}
+
+func TestIssuer_IssueFailsWithoutChallengeRecord(t *testing.T) {
+	dns := testutil.NewMemoryDNS()
+	// The CA never sees the published records
+	ca, err := testutil.NewCA(func(string) []string { return nil }, 90*24*time.Hour)
+	require.NoError(t, err)
+	defer ca.Close()
+
+	issuer := newTestIssuer(t, ca.DirectoryURL(), dns)
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+	defer cancel()
+
+	_, err = issuer.Issue(ctx, "node1.example.com", "memory")
+	require.Error(t, err)
+	assert.Zero(t, dns.Len(), "challenge records removed after failure")
+
+	_, err = issuer.Issue(ctx, "node1.example.com", "unknown")
+	assert.ErrorContains(t, err, "not configured")
+}
+
+func TestLoadOrCreateAccountKey(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "acme", "account.key")
+
+	created, err := loadOrCreateAccountKey(path)
+	require.NoError(t, err)
+	info, err := os.Stat(path)
+	require.NoError(t, err)
+	assert.Equal(t, os.FileMode(0o600), info.Mode().Perm())
+
+	loaded, err := loadOrCreateAccountKey(path)
+	require.NoError(t, err)
+	assert.True(t, created.(*ecdsa.PrivateKey).Equal(loaded))
+}
+
+// TestIssuer_Pebble runs issuance against a real Pebble CA with pebble-challtestsrv,
+// e.g. the containers from github.com/letsencrypt/pebble/docker-compose.yml:
+//
+//	ACME_PEBBLE_DIRECTORY=https://localhost:14000/dir ACME_PEBBLE_CHALLTESTSRV=http://localhost:8055 go test ./internal/infrastructure/acme/
+func TestIssuer_Pebble(t *testing.T) {
+	directoryURL := os.Getenv("ACME_PEBBLE_DIRECTORY")
+	challTestSrvURL := os.Getenv("ACME_PEBBLE_CHALLTESTSRV")
+	if directoryURL == "" || challTestSrvURL == "" {
+		t.Skip("ACME_PEBBLE_DIRECTORY and ACME_PEBBLE_CHALLTESTSRV not set")
+	}
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	issuer := NewIssuer(IssuerConfig{
+		DirectoryURL: directoryURL,
+		Email:        "admin@example.com",
+		AccountKey:   key,
+		HTTPClient: &http.Client{Transport: &http.Transport{
+			TLSClientConfig: &tls.Config{InsecureSkipVerify: true},
+		}},
+	}, map[string]DNSProvider{
+		DNSProviderChallTestSrv: NewChallTestSrvProvider(challTestSrvURL),
+	}, logger.NewLogger())
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+	defer cancel()
+
+	issued, err := issuer.Issue(ctx, "node1.example.com", DNSProviderChallTestSrv)
+	require.NoError(t, err)
+	certs := decodeChain(t, issued.CertificatePEM)
+	assert.Equal(t, []string{"node1.example.com"}, certs[0].DNSNames)
+}
+
+func decodeChain(t *testing.T, chainPEM string) []*x509.Certificate {
+	t.Helper()
+	var certs []*x509.Certificate
+	rest := []byte(chainPEM)
+	for {
+		var block *pem.Block
+		block, rest = pem.Decode(rest)
+		if block == nil {
+			break
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		require.NoError(t, err)
+		certs = append(certs, cert)
+	}
+	return certs
+}
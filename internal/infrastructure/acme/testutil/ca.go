@@ -0,0 +1,594 @@
+// Package testutil provides an in-process ACME CA and DNS provider for testing
+// certificate issuance without network access.
+package testutil
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+)
+
+// MemoryDNS is an in-memory DNSProvider that records TXT records.
+type MemoryDNS struct {
+	mu      sync.Mutex
+	records map[string][]string
+}
+
+// NewMemoryDNS creates an empty MemoryDNS.
+func NewMemoryDNS() *MemoryDNS {
+	return &MemoryDNS{records: make(map[string][]string)}
+}
+
+// Present adds a TXT record.
+func (d *MemoryDNS) Present(_ context.Context, fqdn, value string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[fqdn] = append(d.records[fqdn], value)
+	return nil
+}
+
+// CleanUp removes a TXT record.
+func (d *MemoryDNS) CleanUp(_ context.Context, fqdn, value string) error {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	d.records[fqdn] = slices.DeleteFunc(d.records[fqdn], func(v string) bool { return v == value })
+	if len(d.records[fqdn]) == 0 {
+		delete(d.records, fqdn)
+	}
+	return nil
+}
+
+// TXT returns the TXT records published for fqdn.
+func (d *MemoryDNS) TXT(fqdn string) []string {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return slices.Clone(d.records[fqdn])
+}
+
+// Len returns the number of record names currently published.
+func (d *MemoryDNS) Len() int {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+	return len(d.records)
+}
+
+// CA is a minimal in-process ACME (RFC 8555) certificate authority, a stand-in for a
+// local Pebble instance in tests. It supports the account, order, DNS-01 authorization
+// and finalize flow, resolves challenge records through a TXT lookup function instead
+// of DNS, and signs certificates with a throwaway root. JWS signatures are not verified.
+type CA struct {
+	server   *httptest.Server
+	lookup   func(fqdn string) []string
+	root     *x509.Certificate
+	rootKey  *ecdsa.PrivateKey
+	validity time.Duration
+
+	mu       sync.Mutex
+	nextID   int
+	accounts map[string]string // JWK thumbprint -> account URL
+	kids     map[string]string // account URL -> JWK thumbprint
+	orders   map[string]*caOrder
+	authzs   map[string]*caAuthz
+}
+
+type caOrder struct {
+	id          string
+	status      string
+	identifiers []string
+	authzIDs    []string
+	certPEM     []byte
+}
+
+type caAuthz struct {
+	id         string
+	identifier string
+	wildcard   bool
+	status     string
+	token      string
+	thumbprint string
+}
+
+type jwsMessage struct {
+	Protected string `json:"protected"`
+	Payload   string `json:"payload"`
+}
+
+type jwsHeader struct {
+	JWK map[string]string `json:"jwk"`
+	KID string            `json:"kid"`
+}
+
+// NewCA starts a CA that validates DNS-01 challenges against lookup.
+// Issued certificates are valid for validity. Call Close when done.
+func NewCA(lookup func(fqdn string) []string, validity time.Duration) (*CA, error) {
+	rootKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		return nil, err
+	}
+	tmpl := &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test ACME Root"},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(10 * 365 * 24 * time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, tmpl, &rootKey.PublicKey, rootKey)
+	if err != nil {
+		return nil, err
+	}
+	root, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, err
+	}
+
+	ca := &CA{
+		lookup:   lookup,
+		root:     root,
+		rootKey:  rootKey,
+		validity: validity,
+		accounts: make(map[string]string),
+		kids:     make(map[string]string),
+		orders:   make(map[string]*caOrder),
+		authzs:   make(map[string]*caAuthz),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/directory", ca.handleDirectory)
+	mux.HandleFunc("/nonce", ca.handleNonce)
+	mux.HandleFunc("/account", ca.handleAccount)
+	mux.HandleFunc("/order", ca.handleNewOrder)
+	mux.HandleFunc("/order/", ca.handleOrder)
+	mux.HandleFunc("/authz/", ca.handleAuthz)
+	mux.HandleFunc("/chal/", ca.handleChallenge)
+	mux.HandleFunc("/finalize/", ca.handleFinalize)
+	mux.HandleFunc("/cert/", ca.handleCert)
+	ca.server = httptest.NewServer(mux)
+	return ca, nil
+}
+
+// DirectoryURL returns the ACME directory URL.
+func (ca *CA) DirectoryURL() string {
+	return ca.server.URL + "/directory"
+}
+
+// Root returns the root certificate that signs issued certificates.
+func (ca *CA) Root() *x509.Certificate {
+	return ca.root
+}
+
+// Close shuts the CA down.
+func (ca *CA) Close() {
+	ca.server.Close()
+}
+
+func (ca *CA) url(path string) string {
+	return ca.server.URL + path
+}
+
+func (ca *CA) newID() string {
+	ca.nextID++
+	return fmt.Sprintf("%d", ca.nextID)
+}
+
+func (ca *CA) handleDirectory(w http.ResponseWriter, _ *http.Request) {
+	writeJSON(w, http.StatusOK, map[string]any{
+		"newNonce":   ca.url("/nonce"),
+		"newAccount": ca.url("/account"),
+		"newOrder":   ca.url("/order"),
+		"meta":       map[string]any{"termsOfService": ca.url("/terms")},
+	})
+}
+
+func (ca *CA) handleNonce(w http.ResponseWriter, _ *http.Request) {
+	setNonce(w)
+	w.WriteHeader(http.StatusOK)
+}
+
+func (ca *CA) handleAccount(w http.ResponseWriter, r *http.Request) {
+	header, payload, err := readJWS(r)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+	if header.JWK == nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", "newAccount requires a JWK")
+		return
+	}
+	thumbprint, err := jwkThumbprint(header.JWK)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "badPublicKey", err.Error())
+		return
+	}
+
+	var req struct {
+		OnlyReturnExisting bool `json:"onlyReturnExisting"`
+	}
+	_ = json.Unmarshal(payload, &req)
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	if accountURL, ok := ca.accounts[thumbprint]; ok {
+		w.Header().Set("Location", accountURL)
+		writeJSON(w, http.StatusOK, map[string]any{"status": "valid"})
+		return
+	}
+	if req.OnlyReturnExisting {
+		writeProblem(w, http.StatusBadRequest, "accountDoesNotExist", "no account for key")
+		return
+	}
+
+	accountURL := ca.url("/account/" + ca.newID())
+	ca.accounts[thumbprint] = accountURL
+	ca.kids[accountURL] = thumbprint
+	w.Header().Set("Location", accountURL)
+	writeJSON(w, http.StatusCreated, map[string]any{"status": "valid"})
+}
+
+func (ca *CA) handleNewOrder(w http.ResponseWriter, r *http.Request) {
+	header, payload, err := readJWS(r)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	var req struct {
+		Identifiers []struct {
+			Type  string `json:"type"`
+			Value string `json:"value"`
+		} `json:"identifiers"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil || len(req.Identifiers) == 0 {
+		writeProblem(w, http.StatusBadRequest, "malformed", "invalid order request")
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	thumbprint, ok := ca.kids[header.KID]
+	if !ok {
+		writeProblem(w, http.StatusUnauthorized, "accountDoesNotExist", "unknown account")
+		return
+	}
+
+	order := &caOrder{id: ca.newID(), status: "pending"}
+	for _, ident := range req.Identifiers {
+		if ident.Type != "dns" {
+			writeProblem(w, http.StatusBadRequest, "unsupportedIdentifier", ident.Type)
+			return
+		}
+		base, wildcard := strings.CutPrefix(ident.Value, "*.")
+		tokenBytes := make([]byte, 16)
+		_, _ = rand.Read(tokenBytes)
+		authz := &caAuthz{
+			id:         ca.newID(),
+			identifier: base,
+			wildcard:   wildcard,
+			status:     "pending",
+			token:      base64.RawURLEncoding.EncodeToString(tokenBytes),
+			thumbprint: thumbprint,
+		}
+		ca.authzs[authz.id] = authz
+		order.identifiers = append(order.identifiers, ident.Value)
+		order.authzIDs = append(order.authzIDs, authz.id)
+	}
+	ca.orders[order.id] = order
+
+	w.Header().Set("Location", ca.url("/order/"+order.id))
+	writeJSON(w, http.StatusCreated, ca.orderJSON(order))
+}
+
+func (ca *CA) handleOrder(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := readJWS(r); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	order, ok := ca.orders[strings.TrimPrefix(r.URL.Path, "/order/")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "malformed", "order not found")
+		return
+	}
+	ca.refreshOrder(order)
+
+	w.Header().Set("Location", ca.url("/order/"+order.id))
+	writeJSON(w, http.StatusOK, ca.orderJSON(order))
+}
+
+func (ca *CA) handleAuthz(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := readJWS(r); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	authz, ok := ca.authzs[strings.TrimPrefix(r.URL.Path, "/authz/")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "malformed", "authorization not found")
+		return
+	}
+	writeJSON(w, http.StatusOK, ca.authzJSON(authz))
+}
+
+// handleChallenge validates the DNS-01 record synchronously when the client accepts the challenge.
+func (ca *CA) handleChallenge(w http.ResponseWriter, r *http.Request) {
+	_, payload, err := readJWS(r)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	ca.mu.Lock()
+	authz, ok := ca.authzs[strings.TrimPrefix(r.URL.Path, "/chal/")]
+	if !ok {
+		ca.mu.Unlock()
+		writeProblem(w, http.StatusNotFound, "malformed", "challenge not found")
+		return
+	}
+	accept := len(payload) > 0 && authz.status == "pending"
+	fqdn := "_acme-challenge." + authz.identifier
+	keyAuth := authz.token + "." + authz.thumbprint
+	ca.mu.Unlock()
+
+	if accept {
+		sum := sha256.Sum256([]byte(keyAuth))
+		expected := base64.RawURLEncoding.EncodeToString(sum[:])
+		status := "invalid"
+		if slices.Contains(ca.lookup(fqdn), expected) {
+			status = "valid"
+		}
+		ca.mu.Lock()
+		authz.status = status
+		ca.mu.Unlock()
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+	writeJSON(w, http.StatusOK, ca.challengeJSON(authz))
+}
+
+func (ca *CA) handleFinalize(w http.ResponseWriter, r *http.Request) {
+	_, payload, err := readJWS(r)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	var req struct {
+		CSR string `json:"csr"`
+	}
+	if err := json.Unmarshal(payload, &req); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", "invalid finalize request")
+		return
+	}
+	csrDER, err := base64.RawURLEncoding.DecodeString(req.CSR)
+	if err != nil {
+		writeProblem(w, http.StatusBadRequest, "badCSR", "invalid CSR encoding")
+		return
+	}
+	csr, err := x509.ParseCertificateRequest(csrDER)
+	if err != nil || csr.CheckSignature() != nil {
+		writeProblem(w, http.StatusBadRequest, "badCSR", "invalid CSR")
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	order, ok := ca.orders[strings.TrimPrefix(r.URL.Path, "/finalize/")]
+	if !ok {
+		writeProblem(w, http.StatusNotFound, "malformed", "order not found")
+		return
+	}
+	ca.refreshOrder(order)
+	if order.status != "ready" {
+		writeProblem(w, http.StatusForbidden, "orderNotReady", "order is "+order.status)
+		return
+	}
+	names := slices.Clone(csr.DNSNames)
+	slices.Sort(names)
+	want := slices.Clone(order.identifiers)
+	slices.Sort(want)
+	if !slices.Equal(names, want) {
+		writeProblem(w, http.StatusBadRequest, "badCSR", "CSR names do not match the order")
+		return
+	}
+
+	now := time.Now()
+	tmpl := &x509.Certificate{
+		SerialNumber: big.NewInt(now.UnixNano()),
+		Subject:      pkix.Name{CommonName: names[0]},
+		DNSNames:     csr.DNSNames,
+		NotBefore:    now.Add(-time.Minute),
+		NotAfter:     now.Add(ca.validity),
+		KeyUsage:     x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:  []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+	}
+	der, err := x509.CreateCertificate(rand.Reader, tmpl, ca.root, csr.PublicKey, ca.rootKey)
+	if err != nil {
+		writeProblem(w, http.StatusInternalServerError, "serverInternal", err.Error())
+		return
+	}
+	order.certPEM = append(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}),
+		pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: ca.root.Raw})...)
+	order.status = "valid"
+
+	w.Header().Set("Location", ca.url("/order/"+order.id))
+	writeJSON(w, http.StatusOK, ca.orderJSON(order))
+}
+
+func (ca *CA) handleCert(w http.ResponseWriter, r *http.Request) {
+	if _, _, err := readJWS(r); err != nil {
+		writeProblem(w, http.StatusBadRequest, "malformed", err.Error())
+		return
+	}
+
+	ca.mu.Lock()
+	defer ca.mu.Unlock()
+
+	order, ok := ca.orders[strings.TrimPrefix(r.URL.Path, "/cert/")]
+	if !ok || order.certPEM == nil {
+		writeProblem(w, http.StatusNotFound, "malformed", "certificate not found")
+		return
+	}
+	setNonce(w)
+	w.Header().Set("Content-Type", "application/pem-certificate-chain")
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(order.certPEM)
+}
+
+// refreshOrder moves a pending order to ready or invalid based on its authorizations.
+func (ca *CA) refreshOrder(order *caOrder) {
+	if order.status != "pending" {
+		return
+	}
+	ready := true
+	for _, id := range order.authzIDs {
+		switch ca.authzs[id].status {
+		case "invalid":
+			order.status = "invalid"
+			return
+		case "pending":
+			ready = false
+		}
+	}
+	if ready {
+		order.status = "ready"
+	}
+}
+
+func (ca *CA) orderJSON(order *caOrder) map[string]any {
+	identifiers := make([]map[string]string, len(order.identifiers))
+	for i, v := range order.identifiers {
+		identifiers[i] = map[string]string{"type": "dns", "value": v}
+	}
+	authzURLs := make([]string, len(order.authzIDs))
+	for i, id := range order.authzIDs {
+		authzURLs[i] = ca.url("/authz/" + id)
+	}
+	v := map[string]any{
+		"status":         order.status,
+		"identifiers":    identifiers,
+		"authorizations": authzURLs,
+		"finalize":       ca.url("/finalize/" + order.id),
+	}
+	if order.certPEM != nil {
+		v["certificate"] = ca.url("/cert/" + order.id)
+	}
+	if order.status == "invalid" {
+		v["error"] = map[string]any{"type": "urn:ietf:params:acme:error:unauthorized", "detail": "authorization failed"}
+	}
+	return v
+}
+
+func (ca *CA) authzJSON(authz *caAuthz) map[string]any {
+	return map[string]any{
+		"status":     authz.status,
+		"identifier": map[string]string{"type": "dns", "value": authz.identifier},
+		"wildcard":   authz.wildcard,
+		"challenges": []map[string]any{ca.challengeJSON(authz)},
+	}
+}
+
+func (ca *CA) challengeJSON(authz *caAuthz) map[string]any {
+	v := map[string]any{
+		"type":   "dns-01",
+		"url":    ca.url("/chal/" + authz.id),
+		"token":  authz.token,
+		"status": authz.status,
+	}
+	if authz.status == "invalid" {
+		v["error"] = map[string]any{
+			"type":   "urn:ietf:params:acme:error:unauthorized",
+			"detail": "no matching TXT record at _acme-challenge." + authz.identifier,
+		}
+	}
+	return v
+}
+
+// readJWS decodes the protected header and payload of a flattened JWS request body.
+func readJWS(r *http.Request) (*jwsHeader, []byte, error) {
+	if r.Method != http.MethodPost {
+		return nil, nil, fmt.Errorf("method %s not allowed", r.Method)
+	}
+	var msg jwsMessage
+	if err := json.NewDecoder(r.Body).Decode(&msg); err != nil {
+		return nil, nil, fmt.Errorf("invalid JWS: %w", err)
+	}
+	protected, err := base64.RawURLEncoding.DecodeString(msg.Protected)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWS header encoding: %w", err)
+	}
+	var header jwsHeader
+	if err := json.Unmarshal(protected, &header); err != nil {
+		return nil, nil, fmt.Errorf("invalid JWS header: %w", err)
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(msg.Payload)
+	if err != nil {
+		return nil, nil, fmt.Errorf("invalid JWS payload encoding: %w", err)
+	}
+	return &header, payload, nil
+}
+
+// jwkThumbprint computes the RFC 7638 thumbprint of an EC or RSA JWK.
+func jwkThumbprint(jwk map[string]string) (string, error) {
+	var canonical string
+	switch jwk["kty"] {
+	case "EC":
+		canonical = fmt.Sprintf(`{"crv":%q,"kty":"EC","x":%q,"y":%q}`, jwk["crv"], jwk["x"], jwk["y"])
+	case "RSA":
+		canonical = fmt.Sprintf(`{"e":%q,"kty":"RSA","n":%q}`, jwk["e"], jwk["n"])
+	default:
+		return "", fmt.Errorf("unsupported key type %q", jwk["kty"])
+	}
+	sum := sha256.Sum256([]byte(canonical))
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
+
+func setNonce(w http.ResponseWriter) {
+	b := make([]byte, 12)
+	_, _ = rand.Read(b)
+	w.Header().Set("Replay-Nonce", base64.RawURLEncoding.EncodeToString(b))
+	w.Header().Set("Cache-Control", "no-store")
+}
+
+func writeJSON(w http.ResponseWriter, status int, v any) {
+	setNonce(w)
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func writeProblem(w http.ResponseWriter, status int, problemType, detail string) {
+	setNonce(w)
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"type":   "urn:ietf:params:acme:error:" + problemType,
+		"detail": detail,
+		"status": status,
+	})
+}
@@ -0,0 +1,154 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// deviceLimitOverKeyPrefix marks a subscription currently seen above its device limit.
+	// Key format: device_limit:over:{subscriptionID}, value is the first-seen unix time.
+	deviceLimitOverKeyPrefix = "device_limit:over:"
+	// deviceLimitOverTTL expires over-limit streaks that stop being reported.
+	deviceLimitOverTTL = 10 * time.Minute
+	// deviceLimitBlockedKey is a sorted set of blocked subscription IDs scored by block expiry.
+	deviceLimitBlockedKey = "device_limit:blocked"
+)
+
+// DeviceLimitStore tracks cluster-wide device limit violations and temporary blocks.
+type DeviceLimitStore interface {
+	// MarkOverLimit records that a subscription is above its device limit and returns
+	// the time the current over-limit streak started.
+	MarkOverLimit(ctx context.Context, subscriptionID uint, now time.Time) (time.Time, error)
+	// ClearOverLimit ends the over-limit streak of the given subscriptions.
+	ClearOverLimit(ctx context.Context, subscriptionIDs []uint) error
+	// Block blocks a subscription until the given time. Returns false if it was already blocked.
+	Block(ctx context.Context, subscriptionID uint, until time.Time) (bool, error)
+	// GetBlocked returns which of the given subscriptions are blocked.
+	GetBlocked(ctx context.Context, subscriptionIDs []uint) (map[uint]bool, error)
+	// PopExpiredBlocks removes and returns subscriptions whose block has expired.
+	// Each ID is returned to exactly one caller across instances.
+	PopExpiredBlocks(ctx context.Context, now time.Time) ([]uint, error)
+}
+
+// RedisDeviceLimitStore implements DeviceLimitStore using Redis.
+type RedisDeviceLimitStore struct {
+	client *redis.Client
+}
+
+// NewRedisDeviceLimitStore creates a new RedisDeviceLimitStore.
+func NewRedisDeviceLimitStore(client *redis.Client) *RedisDeviceLimitStore {
+	return &RedisDeviceLimitStore{client: client}
+}
+
+func deviceLimitOverKey(subscriptionID uint) string {
+	return fmt.Sprintf("%s%d", deviceLimitOverKeyPrefix, subscriptionID)
+}
+
+// MarkOverLimit implements DeviceLimitStore.
+func (s *RedisDeviceLimitStore) MarkOverLimit(ctx context.Context, subscriptionID uint, now time.Time) (time.Time, error) {
+	key := deviceLimitOverKey(subscriptionID)
+
+	if err := s.client.SetNX(ctx, key, now.Unix(), deviceLimitOverTTL).Err(); err != nil {
+		return time.Time{}, fmt.Errorf("failed to mark device limit exceeded: %w", err)
+	}
+
+	since, err := s.client.Get(ctx, key).Int64()
+	if err == redis.Nil {
+		return now, nil
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get device limit exceeded time: %w", err)
+	}
+
+	return time.Unix(since, 0).UTC(), nil
+}
+
+// ClearOverLimit implements DeviceLimitStore.
+func (s *RedisDeviceLimitStore) ClearOverLimit(ctx context.Context, subscriptionIDs []uint) error {
+	if len(subscriptionIDs) == 0 {
+		return nil
+	}
+
+	keys := make([]string, len(subscriptionIDs))
+	for i, id := range subscriptionIDs {
+		keys[i] = deviceLimitOverKey(id)
+	}
+
+	if err := s.client.Del(ctx, keys...).Err(); err != nil {
+		return fmt.Errorf("failed to clear device limit exceeded state: %w", err)
+	}
+	return nil
+}
+
+// Block implements DeviceLimitStore.
+func (s *RedisDeviceLimitStore) Block(ctx context.Context, subscriptionID uint, until time.Time) (bool, error) {
+	added, err := s.client.ZAddNX(ctx, deviceLimitBlockedKey, redis.Z{
+		Score:  float64(until.Unix()),
+		Member: strconv.FormatUint(uint64(subscriptionID), 10),
+	}).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to block subscription: %w", err)
+	}
+	return added == 1, nil
+}
+
+// GetBlocked implements DeviceLimitStore.
+// Blocks past their expiry still count until PopExpiredBlocks releases them,
+// so the release and the node re-sync happen together.
+func (s *RedisDeviceLimitStore) GetBlocked(ctx context.Context, subscriptionIDs []uint) (map[uint]bool, error) {
+	result := make(map[uint]bool)
+	if len(subscriptionIDs) == 0 {
+		return result, nil
+	}
+
+	members := make([]string, len(subscriptionIDs))
+	for i, id := range subscriptionIDs {
+		members[i] = strconv.FormatUint(uint64(id), 10)
+	}
+
+	scores, err := s.client.ZMScore(ctx, deviceLimitBlockedKey, members...).Result()
+	if err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to get blocked subscriptions: %w", err)
+	}
+
+	for i, score := range scores {
+		if score != 0 {
+			result[subscriptionIDs[i]] = true
+		}
+	}
+	return result, nil
+}
+
+// PopExpiredBlocks implements DeviceLimitStore.
+func (s *RedisDeviceLimitStore) PopExpiredBlocks(ctx context.Context, now time.Time) ([]uint, error) {
+	members, err := s.client.ZRangeByScore(ctx, deviceLimitBlockedKey, &redis.ZRangeBy{
+		Min: "-inf",
+		Max: strconv.FormatInt(now.Unix(), 10),
+	}).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get expired blocks: %w", err)
+	}
+
+	released := make([]uint, 0, len(members))
+	for _, member := range members {
+		// ZREM is the claim: only the instance that removes the member releases it
+		removed, err := s.client.ZRem(ctx, deviceLimitBlockedKey, member).Result()
+		if err != nil {
+			return released, fmt.Errorf("failed to release block: %w", err)
+		}
+		if removed == 0 {
+			continue
+		}
+		id, err := strconv.ParseUint(member, 10, 64)
+		if err != nil {
+			continue
+		}
+		released = append(released, uint(id))
+	}
+	return released, nil
+}
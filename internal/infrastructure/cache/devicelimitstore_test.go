@@ -0,0 +1,58 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisDeviceLimitStore(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewRedisDeviceLimitStore(client)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Over-limit streak keeps its start time until cleared
+	since, err := store.MarkOverLimit(ctx, 1, now)
+	require.NoError(t, err)
+	assert.Equal(t, now, since)
+	since, err = store.MarkOverLimit(ctx, 1, now.Add(time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, now, since)
+
+	require.NoError(t, store.ClearOverLimit(ctx, []uint{1}))
+	since, err = store.MarkOverLimit(ctx, 1, now.Add(2*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(2*time.Minute), since)
+
+	// Blocking is idempotent
+	added, err := store.Block(ctx, 1, now.Add(10*time.Minute))
+	require.NoError(t, err)
+	assert.True(t, added)
+	added, err = store.Block(ctx, 1, now.Add(20*time.Minute))
+	require.NoError(t, err)
+	assert.False(t, added)
+	_, err = store.Block(ctx, 2, now.Add(30*time.Minute))
+	require.NoError(t, err)
+
+	blocked, err := store.GetBlocked(ctx, []uint{1, 2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]bool{1: true, 2: true}, blocked)
+
+	// Only expired blocks are released, and only once
+	released, err := store.PopExpiredBlocks(ctx, now.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Equal(t, []uint{1}, released)
+	released, err = store.PopExpiredBlocks(ctx, now.Add(15*time.Minute))
+	require.NoError(t, err)
+	assert.Empty(t, released)
+
+	blocked, err = store.GetBlocked(ctx, []uint{1, 2})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]bool{2: true}, blocked)
+}
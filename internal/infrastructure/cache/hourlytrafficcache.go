@@ -30,6 +30,11 @@ const (
 	hourlyFieldUpload   = "upload"
 	hourlyFieldDownload = "download"
 
+	// Raw (pre-multiplier) traffic fields, kept for operator statistics.
+	// Keys written before these fields existed fall back to upload/download.
+	hourlyFieldRawUpload   = "raw_upload"
+	hourlyFieldRawDownload = "raw_download"
+
 	// Hour key format layout (business timezone)
 	hourKeyLayout = "2006010215"
 )
@@ -54,12 +59,32 @@ type HourlyTrafficPoint struct {
 }
 
 // HourlyTrafficData represents traffic data for a subscription resource at a specific hour.
+// Upload/Download are billed bytes (node traffic multiplier applied);
+// RawUpload/RawDownload are the bytes actually transferred.
 type HourlyTrafficData struct {
 	SubscriptionID uint
 	ResourceType   string
 	ResourceID     uint
 	Upload         int64
 	Download       int64
+	RawUpload      int64
+	RawDownload    int64
+}
+
+// parseHourlyTrafficValues parses billed and raw traffic from an hourly hash.
+// Raw values fall back to billed values when absent (1:1 traffic or legacy keys).
+func parseHourlyTrafficValues(values map[string]string) (upload, download, rawUpload, rawDownload int64) {
+	upload, _ = strconv.ParseInt(values[hourlyFieldUpload], 10, 64)
+	download, _ = strconv.ParseInt(values[hourlyFieldDownload], 10, 64)
+
+	rawUpload, rawDownload = upload, download
+	if v, ok := values[hourlyFieldRawUpload]; ok {
+		rawUpload, _ = strconv.ParseInt(v, 10, 64)
+	}
+	if v, ok := values[hourlyFieldRawDownload]; ok {
+		rawDownload, _ = strconv.ParseInt(v, 10, 64)
+	}
+	return upload, download, rawUpload, rawDownload
 }
 
 // TrafficSummary represents aggregated traffic with upload/download breakdown.
@@ -72,8 +97,13 @@ type TrafficSummary struct {
 // HourlyTrafficCache defines the interface for hourly traffic caching.
 type HourlyTrafficCache interface {
 	// IncrementHourlyTraffic increments traffic for the current hour.
+	// The traffic is treated as billed 1:1 (raw bytes equal billed bytes).
 	IncrementHourlyTraffic(ctx context.Context, subscriptionID uint, resourceType string, resourceID uint, upload, download int64) error
 
+	// IncrementHourlyTrafficWithRaw increments billed and raw traffic for the current hour.
+	// Used for node traffic where a per-node multiplier is applied to billed bytes.
+	IncrementHourlyTrafficWithRaw(ctx context.Context, subscriptionID uint, resourceType string, resourceID uint, upload, download, rawUpload, rawDownload int64) error
+
 	// GetHourlyTraffic returns traffic for a specific hour.
 	GetHourlyTraffic(ctx context.Context, hour time.Time, subscriptionID uint, resourceType string, resourceID uint) (upload, download int64, err error)
 
@@ -113,7 +143,8 @@ type HourlyTrafficCache interface {
 	// Only returns data within the last 48 hours (Redis hourly data TTL).
 	GetTrafficGroupedBySubscription(ctx context.Context, resourceType string, from, to time.Time) (map[uint]*TrafficSummary, error)
 
-	// GetTrafficGroupedByResourceID returns traffic grouped by resource ID within a time range.
+	// GetTrafficGroupedByResourceID returns raw traffic grouped by resource ID within a time range.
+	// Raw traffic excludes node traffic multipliers (operator statistics).
 	// Only returns data within the last 48 hours (Redis hourly data TTL).
 	GetTrafficGroupedByResourceID(ctx context.Context, resourceType string, from, to time.Time) (map[uint]*TrafficSummary, error)
 
@@ -213,11 +244,16 @@ func parseHourlyTrafficKey(key string) (hourKey string, subscriptionID uint, res
 
 // IncrementHourlyTraffic increments traffic for the current hour.
 func (c *RedisHourlyTrafficCache) IncrementHourlyTraffic(ctx context.Context, subscriptionID uint, resourceType string, resourceID uint, upload, download int64) error {
+	return c.IncrementHourlyTrafficWithRaw(ctx, subscriptionID, resourceType, resourceID, upload, download, upload, download)
+}
+
+// IncrementHourlyTrafficWithRaw increments billed and raw traffic for the current hour.
+func (c *RedisHourlyTrafficCache) IncrementHourlyTrafficWithRaw(ctx context.Context, subscriptionID uint, resourceType string, resourceID uint, upload, download, rawUpload, rawDownload int64) error {
 	if err := validateResourceType(resourceType); err != nil {
 		return err
 	}
 
-	if upload == 0 && download == 0 {
+	if upload == 0 && download == 0 && rawUpload == 0 && rawDownload == 0 {
 		return nil
 	}
 
@@ -235,6 +271,12 @@ func (c *RedisHourlyTrafficCache) IncrementHourlyTraffic(ctx context.Context, su
 	if download > 0 {
 		pipe.HIncrBy(ctx, trafficKey, hourlyFieldDownload, download)
 	}
+	if rawUpload > 0 {
+		pipe.HIncrBy(ctx, trafficKey, hourlyFieldRawUpload, rawUpload)
+	}
+	if rawDownload > 0 {
+		pipe.HIncrBy(ctx, trafficKey, hourlyFieldRawDownload, rawDownload)
+	}
 
 	// Set expiration to prevent memory leak
 	pipe.Expire(ctx, trafficKey, hourlyTrafficTTL)
@@ -438,16 +480,17 @@ func (c *RedisHourlyTrafficCache) GetAllHourlyTraffic(ctx context.Context, hour
 			continue
 		}
 
-		upload, _ := strconv.ParseInt(values[hourlyFieldUpload], 10, 64)
-		download, _ := strconv.ParseInt(values[hourlyFieldDownload], 10, 64)
+		upload, download, rawUpload, rawDownload := parseHourlyTrafficValues(values)
 
-		if upload > 0 || download > 0 {
+		if upload > 0 || download > 0 || rawUpload > 0 || rawDownload > 0 {
 			result = append(result, HourlyTrafficData{
 				SubscriptionID: subscriptionID,
 				ResourceType:   resourceType,
 				ResourceID:     resourceID,
 				Upload:         upload,
 				Download:       download,
+				RawUpload:      rawUpload,
+				RawDownload:    rawDownload,
 			})
 		}
 	}
@@ -477,7 +520,8 @@ return deleted
 `)
 
 // getAndCleanupHourScript atomically retrieves all traffic data and cleans up.
-// Returns array of [key, upload, download, key, upload, download, ...]
+// Returns array of [key, upload, download, raw_upload, raw_download, key, ...]
+// Raw values fall back to upload/download when absent.
 var getAndCleanupHourScript = redis.NewScript(`
 local activeKey = KEYS[1]
 local keys = redis.call('SMEMBERS', activeKey)
@@ -488,15 +532,23 @@ for _, key in ipairs(keys) do
         table.insert(result, key)
         local upload = '0'
         local download = '0'
+        local rawUpload = nil
+        local rawDownload = nil
         for i = 1, #data, 2 do
             if data[i] == 'upload' then
                 upload = data[i+1]
             elseif data[i] == 'download' then
                 download = data[i+1]
+            elseif data[i] == 'raw_upload' then
+                rawUpload = data[i+1]
+            elseif data[i] == 'raw_download' then
+                rawDownload = data[i+1]
             end
         end
         table.insert(result, upload)
         table.insert(result, download)
+        table.insert(result, rawUpload or upload)
+        table.insert(result, rawDownload or download)
     end
     redis.call('DEL', key)
 end
@@ -519,7 +571,7 @@ func (c *RedisHourlyTrafficCache) GetAndCleanupHour(ctx context.Context, hour ti
 		return nil, fmt.Errorf("failed to get and cleanup hour data: %w", err)
 	}
 
-	// Parse result: [key, upload, download, key, upload, download, ...]
+	// Parse result: [key, upload, download, raw_upload, raw_download, key, ...]
 	items, ok := rawResult.([]any)
 	if !ok || len(items) == 0 {
 		c.logger.Debugw("no traffic data for hour", "hour_key", hourKey)
@@ -527,7 +579,7 @@ func (c *RedisHourlyTrafficCache) GetAndCleanupHour(ctx context.Context, hour ti
 	}
 
 	var result []HourlyTrafficData
-	for i := 0; i+2 < len(items); i += 3 {
+	for i := 0; i+4 < len(items); i += 5 {
 		key, ok := items[i].(string)
 		if !ok {
 			continue
@@ -544,16 +596,22 @@ func (c *RedisHourlyTrafficCache) GetAndCleanupHour(ctx context.Context, hour ti
 
 		uploadStr, _ := items[i+1].(string)
 		downloadStr, _ := items[i+2].(string)
+		rawUploadStr, _ := items[i+3].(string)
+		rawDownloadStr, _ := items[i+4].(string)
 		upload, _ := strconv.ParseInt(uploadStr, 10, 64)
 		download, _ := strconv.ParseInt(downloadStr, 10, 64)
+		rawUpload, _ := strconv.ParseInt(rawUploadStr, 10, 64)
+		rawDownload, _ := strconv.ParseInt(rawDownloadStr, 10, 64)
 
-		if upload > 0 || download > 0 {
+		if upload > 0 || download > 0 || rawUpload > 0 || rawDownload > 0 {
 			result = append(result, HourlyTrafficData{
 				SubscriptionID: subscriptionID,
 				ResourceType:   resourceType,
 				ResourceID:     resourceID,
 				Upload:         upload,
 				Download:       download,
+				RawUpload:      rawUpload,
+				RawDownload:    rawDownload,
 			})
 		}
 	}
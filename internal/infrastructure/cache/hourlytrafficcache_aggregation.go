@@ -252,6 +252,7 @@ func (c *RedisHourlyTrafficCache) GetTrafficGroupedBySubscription(ctx context.Co
 }
 
 // GetTrafficGroupedByResourceID returns traffic grouped by resource ID within a time range.
+// Returns raw traffic (before node traffic multipliers) since this is the operator view of per-resource traffic.
 func (c *RedisHourlyTrafficCache) GetTrafficGroupedByResourceID(ctx context.Context, resourceType string, from, to time.Time) (map[uint]*TrafficSummary, error) {
 	// ResourceType is required for this method
 	if resourceType == "" {
@@ -277,9 +278,9 @@ func (c *RedisHourlyTrafficCache) GetTrafficGroupedByResourceID(ctx context.Cont
 		if result[data.ResourceID] == nil {
 			result[data.ResourceID] = &TrafficSummary{}
 		}
-		result[data.ResourceID].Upload += utils.SafeInt64ToUint64(data.Upload)
-		result[data.ResourceID].Download += utils.SafeInt64ToUint64(data.Download)
-		result[data.ResourceID].Total += utils.SafeInt64ToUint64(data.Upload) + utils.SafeInt64ToUint64(data.Download)
+		result[data.ResourceID].Upload += utils.SafeInt64ToUint64(data.RawUpload)
+		result[data.ResourceID].Download += utils.SafeInt64ToUint64(data.RawDownload)
+		result[data.ResourceID].Total += utils.SafeInt64ToUint64(data.RawUpload) + utils.SafeInt64ToUint64(data.RawDownload)
 	}
 
 	c.logger.Debugw("got traffic grouped by resource ID",
@@ -452,16 +453,17 @@ func (c *RedisHourlyTrafficCache) GetAllHourlyTrafficBatch(ctx context.Context,
 			continue
 		}
 
-		upload, _ := strconv.ParseInt(values[hourlyFieldUpload], 10, 64)
-		download, _ := strconv.ParseInt(values[hourlyFieldDownload], 10, 64)
+		upload, download, rawUpload, rawDownload := parseHourlyTrafficValues(values)
 
-		if upload > 0 || download > 0 {
+		if upload > 0 || download > 0 || rawUpload > 0 || rawDownload > 0 {
 			result = append(result, HourlyTrafficData{
 				SubscriptionID: subscriptionID,
 				ResourceType:   resourceType,
 				ResourceID:     resourceID,
 				Upload:         upload,
 				Download:       download,
+				RawUpload:      rawUpload,
+				RawDownload:    rawDownload,
 			})
 		}
 	}
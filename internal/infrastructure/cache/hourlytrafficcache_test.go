@@ -735,3 +735,41 @@ func TestRedisHourlyTrafficCache_GetTotalTrafficBySubscriptionIDs_MultipleHours(
 	assert.Equal(t, uint64(100), result[2].Download) // 2 * 50
 	assert.Equal(t, uint64(200), result[2].Total)
 }
+
+func TestRedisHourlyTrafficCache_RawTraffic(t *testing.T) {
+	// Initialize biztime
+	biztime.MustInit("Asia/Shanghai")
+
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	log := newNopLogger()
+	cache := NewRedisHourlyTrafficCache(client, log)
+	ctx := context.Background()
+
+	// Node billed at 3x: billed and raw bytes are tracked separately
+	err := cache.IncrementHourlyTrafficWithRaw(ctx, 1, "node", 100, 3000, 6000, 1000, 2000)
+	require.NoError(t, err)
+
+	// 1:1 traffic falls back to billed bytes as raw
+	err = cache.IncrementHourlyTraffic(ctx, 2, "forward", 200, 500, 600)
+	require.NoError(t, err)
+
+	currentHour := biztime.TruncateToHourInBiz(biztime.NowUTC())
+	data, err := cache.GetAndCleanupHour(ctx, currentHour)
+	require.NoError(t, err)
+	require.Len(t, data, 2)
+
+	for _, d := range data {
+		switch d.ResourceType {
+		case "node":
+			assert.Equal(t, int64(3000), d.Upload)
+			assert.Equal(t, int64(6000), d.Download)
+			assert.Equal(t, int64(1000), d.RawUpload)
+			assert.Equal(t, int64(2000), d.RawDownload)
+		case "forward":
+			assert.Equal(t, d.Upload, d.RawUpload)
+			assert.Equal(t, d.Download, d.RawDownload)
+		}
+	}
+}
@@ -0,0 +1,113 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+)
+
+const (
+	// loadBalanceMetricsKeyPrefix is the prefix for load balance metrics keys.
+	// Key format: lb_metrics:{ruleID}
+	loadBalanceMetricsKeyPrefix = "lb_metrics:"
+)
+
+// loadBalanceMetricsData is the Redis representation of forward.LoadBalanceMetrics.
+type loadBalanceMetricsData struct {
+	Exits         map[string]exitMetricsData `json:"exits"`
+	PreferredExit uint                       `json:"preferred_exit,omitempty"`
+	UpdatedAt     int64                      `json:"updated_at"`
+	SyncedAt      int64                      `json:"synced_at,omitempty"`
+}
+
+type exitMetricsData struct {
+	LatencyMs   *int64 `json:"latency_ms,omitempty"`
+	Connections *int   `json:"connections,omitempty"`
+}
+
+// LoadBalanceMetricsStore stores the exit metrics of multi-exit rules in Redis so that
+// every instance building config syncs sends the same metrics. Metrics expire with
+// forward.LoadBalanceMetricsMaxAge, so metrics that are no longer refreshed, e.g. after
+// a rule switches strategy, are not sent to agents.
+type LoadBalanceMetricsStore struct {
+	client *redis.Client
+}
+
+// NewLoadBalanceMetricsStore creates a new LoadBalanceMetricsStore.
+func NewLoadBalanceMetricsStore(client *redis.Client) *LoadBalanceMetricsStore {
+	return &LoadBalanceMetricsStore{client: client}
+}
+
+func loadBalanceMetricsKey(ruleID uint) string {
+	return fmt.Sprintf("%s%d", loadBalanceMetricsKeyPrefix, ruleID)
+}
+
+// Get returns the metrics of a rule, or nil if none have been collected.
+func (s *LoadBalanceMetricsStore) Get(ctx context.Context, ruleID uint) (*forward.LoadBalanceMetrics, error) {
+	data, err := s.client.Get(ctx, loadBalanceMetricsKey(ruleID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get load balance metrics: %w", err)
+	}
+
+	var stored loadBalanceMetricsData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal load balance metrics: %w", err)
+	}
+
+	metrics := &forward.LoadBalanceMetrics{
+		Exits:         make(map[uint]forward.ExitMetrics, len(stored.Exits)),
+		PreferredExit: stored.PreferredExit,
+		UpdatedAt:     time.Unix(stored.UpdatedAt, 0).UTC(),
+	}
+	if stored.SyncedAt > 0 {
+		metrics.SyncedAt = time.Unix(stored.SyncedAt, 0).UTC()
+	}
+	for key, exit := range stored.Exits {
+		exitAgentID, err := strconv.ParseUint(key, 10, 64)
+		if err != nil {
+			continue
+		}
+		metrics.Exits[uint(exitAgentID)] = forward.ExitMetrics{
+			LatencyMs:   exit.LatencyMs,
+			Connections: exit.Connections,
+		}
+	}
+	return metrics, nil
+}
+
+// Set stores the metrics of a rule.
+func (s *LoadBalanceMetricsStore) Set(ctx context.Context, ruleID uint, metrics forward.LoadBalanceMetrics) error {
+	stored := loadBalanceMetricsData{
+		Exits:         make(map[string]exitMetricsData, len(metrics.Exits)),
+		PreferredExit: metrics.PreferredExit,
+		UpdatedAt:     metrics.UpdatedAt.Unix(),
+	}
+	if !metrics.SyncedAt.IsZero() {
+		stored.SyncedAt = metrics.SyncedAt.Unix()
+	}
+	for exitAgentID, exit := range metrics.Exits {
+		stored.Exits[strconv.FormatUint(uint64(exitAgentID), 10)] = exitMetricsData{
+			LatencyMs:   exit.LatencyMs,
+			Connections: exit.Connections,
+		}
+	}
+
+	data, err := json.Marshal(stored)
+	if err != nil {
+		return fmt.Errorf("failed to marshal load balance metrics: %w", err)
+	}
+
+	if err := s.client.Set(ctx, loadBalanceMetricsKey(ruleID), data, forward.LoadBalanceMetricsMaxAge).Err(); err != nil {
+		return fmt.Errorf("failed to set load balance metrics: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,47 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+)
+
+func TestLoadBalanceMetricsStore(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewLoadBalanceMetricsStore(client)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Rules without collected metrics return nil
+	metrics, err := store.Get(ctx, 1)
+	require.NoError(t, err)
+	assert.Nil(t, metrics)
+
+	latency := int64(35)
+	stored := forward.LoadBalanceMetrics{
+		Exits: map[uint]forward.ExitMetrics{
+			2: {LatencyMs: &latency},
+			3: {}, // probe failed
+		},
+		PreferredExit: 2,
+		UpdatedAt:     now,
+		SyncedAt:      now,
+	}
+	require.NoError(t, store.Set(ctx, 1, stored))
+
+	metrics, err = store.Get(ctx, 1)
+	require.NoError(t, err)
+	require.NotNil(t, metrics)
+	assert.Equal(t, stored, *metrics)
+
+	ttl, err := client.TTL(ctx, loadBalanceMetricsKey(1)).Result()
+	require.NoError(t, err)
+	assert.LessOrEqual(t, ttl, forward.LoadBalanceMetricsMaxAge)
+}
@@ -0,0 +1,72 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+const (
+	// portReservationKeyPrefix is the prefix for listen port reservation keys.
+	// Key format: port_reservation:{agentID}:{port}
+	portReservationKeyPrefix = "port_reservation:"
+
+	// portReservationTTL expires reservations that are never released, e.g. when the
+	// instance creating the rule stops. Creating a rule takes far less.
+	portReservationTTL = 2 * time.Minute
+)
+
+// PortReservationStore holds listen ports reserved on forward agents while rules are
+// created, so that concurrent requests on any instance never pick the same port.
+type PortReservationStore struct {
+	client *redis.Client
+}
+
+// NewPortReservationStore creates a new PortReservationStore.
+func NewPortReservationStore(client *redis.Client) *PortReservationStore {
+	return &PortReservationStore{client: client}
+}
+
+func portReservationKey(agentID uint, port uint16) string {
+	return fmt.Sprintf("%s%d:%d", portReservationKeyPrefix, agentID, port)
+}
+
+// Reserve reserves a port on an agent. Returns false if the port is already reserved.
+func (s *PortReservationStore) Reserve(ctx context.Context, agentID uint, port uint16) (bool, error) {
+	ok, err := s.client.SetNX(ctx, portReservationKey(agentID, port), 1, portReservationTTL).Result()
+	if err != nil {
+		return false, fmt.Errorf("failed to reserve port: %w", err)
+	}
+	return ok, nil
+}
+
+// Release releases a port reservation.
+func (s *PortReservationStore) Release(ctx context.Context, agentID uint, port uint16) error {
+	if err := s.client.Del(ctx, portReservationKey(agentID, port)).Err(); err != nil {
+		return fmt.Errorf("failed to release port reservation: %w", err)
+	}
+	return nil
+}
+
+// ListReserved returns the ports currently reserved on an agent.
+func (s *PortReservationStore) ListReserved(ctx context.Context, agentID uint) ([]uint16, error) {
+	prefix := fmt.Sprintf("%s%d:", portReservationKeyPrefix, agentID)
+
+	var ports []uint16
+	iter := s.client.Scan(ctx, 0, prefix+"*", 100).Iterator()
+	for iter.Next(ctx) {
+		port, err := strconv.ParseUint(strings.TrimPrefix(iter.Val(), prefix), 10, 16)
+		if err != nil {
+			continue
+		}
+		ports = append(ports, uint16(port))
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("failed to list port reservations: %w", err)
+	}
+	return ports, nil
+}
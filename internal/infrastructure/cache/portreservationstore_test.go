@@ -0,0 +1,44 @@
+package cache
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPortReservationStore(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewPortReservationStore(client)
+
+	ok, err := store.Reserve(ctx, 1, 10080)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	// A reserved port cannot be reserved again until released
+	ok, err = store.Reserve(ctx, 1, 10080)
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// Same port on another agent is independent
+	ok, err = store.Reserve(ctx, 2, 10080)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ok, err = store.Reserve(ctx, 1, 10081)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	ports, err := store.ListReserved(ctx, 1)
+	require.NoError(t, err)
+	assert.ElementsMatch(t, []uint16{10080, 10081}, ports)
+
+	require.NoError(t, store.Release(ctx, 1, 10080))
+	ok, err = store.Reserve(ctx, 1, 10080)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
@@ -27,6 +27,14 @@ const (
 	subFieldDownload            = "download"
 	subFieldLastFlushedUpload   = "last_flushed_upload"
 	subFieldLastFlushedDownload = "last_flushed_download"
+
+	// Raw (pre-multiplier) traffic fields. upload/download hold billed bytes
+	// with the node traffic multiplier applied; raw_* hold actual bytes.
+	// Keys without raw fields fall back to the billed values.
+	subFieldRawUpload              = "raw_upload"
+	subFieldRawDownload            = "raw_download"
+	subFieldLastFlushedRawUpload   = "last_flushed_raw_upload"
+	subFieldLastFlushedRawDownload = "last_flushed_raw_download"
 )
 
 // subSafeRemoveFromActiveSetScript atomically removes a key from active set
@@ -39,8 +47,13 @@ local current_upload = redis.call('HGET', KEYS[1], 'upload') or '0'
 local current_download = redis.call('HGET', KEYS[1], 'download') or '0'
 local last_flushed_upload = redis.call('HGET', KEYS[1], 'last_flushed_upload') or '0'
 local last_flushed_download = redis.call('HGET', KEYS[1], 'last_flushed_download') or '0'
+local current_raw_upload = redis.call('HGET', KEYS[1], 'raw_upload') or current_upload
+local current_raw_download = redis.call('HGET', KEYS[1], 'raw_download') or current_download
+local last_flushed_raw_upload = redis.call('HGET', KEYS[1], 'last_flushed_raw_upload') or last_flushed_upload
+local last_flushed_raw_download = redis.call('HGET', KEYS[1], 'last_flushed_raw_download') or last_flushed_download
 
-if current_upload == last_flushed_upload and current_download == last_flushed_download then
+if current_upload == last_flushed_upload and current_download == last_flushed_download
+    and current_raw_upload == last_flushed_raw_upload and current_raw_download == last_flushed_raw_download then
     redis.call('SREM', KEYS[2], ARGV[1])
     return 1
 end
@@ -52,20 +65,28 @@ return 0
 // KEYS[1] = traffic hash key, KEYS[2] = active set key
 // ARGV[1] = expected current upload, ARGV[2] = expected current download
 // ARGV[3] = member to remove (the key itself), ARGV[4] = TTL in seconds
+// ARGV[5] = expected current raw upload, ARGV[6] = expected current raw download
 // Returns 1 if removed from active set, 0 if kept (new data arrived)
 var subAtomicUpdateLastFlushedScript = redis.NewScript(`
 local current_upload = redis.call('HGET', KEYS[1], 'upload') or '0'
 local current_download = redis.call('HGET', KEYS[1], 'download') or '0'
+local current_raw_upload = redis.call('HGET', KEYS[1], 'raw_upload') or current_upload
+local current_raw_download = redis.call('HGET', KEYS[1], 'raw_download') or current_download
 local expected_upload = ARGV[1]
 local expected_download = ARGV[2]
+local expected_raw_upload = ARGV[5]
+local expected_raw_download = ARGV[6]
 
 -- Always update last_flushed to the values we successfully wrote to MySQL
 redis.call('HSET', KEYS[1], 'last_flushed_upload', expected_upload)
 redis.call('HSET', KEYS[1], 'last_flushed_download', expected_download)
+redis.call('HSET', KEYS[1], 'last_flushed_raw_upload', expected_raw_upload)
+redis.call('HSET', KEYS[1], 'last_flushed_raw_download', expected_raw_download)
 redis.call('EXPIRE', KEYS[1], ARGV[4])
 
 -- Only remove from active set if no new data has arrived
-if current_upload == expected_upload and current_download == expected_download then
+if current_upload == expected_upload and current_download == expected_download
+    and current_raw_upload == expected_raw_upload and current_raw_download == expected_raw_download then
     redis.call('SREM', KEYS[2], ARGV[3])
     return 1
 end
@@ -81,6 +102,7 @@ type SubscriptionTrafficData struct {
 // SubscriptionTrafficCache defines the interface for subscription traffic caching.
 type SubscriptionTrafficCache interface {
 	// IncrementSubscriptionTraffic atomically increments subscription traffic in Redis.
+	// The traffic is treated as billed 1:1 (raw bytes equal billed bytes).
 	IncrementSubscriptionTraffic(ctx context.Context, nodeID, subscriptionID uint, upload, download int64) error
 
 	// BatchIncrementSubscriptionTraffic atomically increments traffic for multiple subscriptions
@@ -101,11 +123,15 @@ type SubscriptionTrafficCache interface {
 }
 
 // SubscriptionTrafficBatchEntry represents a single entry for batch subscription traffic increment.
+// Upload/Download are billed bytes (node traffic multiplier applied),
+// RawUpload/RawDownload are the bytes reported by the node agent.
 type SubscriptionTrafficBatchEntry struct {
 	NodeID         uint
 	SubscriptionID uint
 	Upload         int64
 	Download       int64
+	RawUpload      int64
+	RawDownload    int64
 }
 
 // RedisSubscriptionTrafficCache implements SubscriptionTrafficCache using Redis.
@@ -148,6 +174,17 @@ func parseSubscriptionTrafficKey(key string) (nodeID, subscriptionID uint, err e
 	return uint(nid), uint(sid), nil
 }
 
+// parseRawTrafficField parses a raw traffic hash field, falling back to the billed value
+// for keys written before raw tracking existed.
+func parseRawTrafficField(values map[string]string, field string, fallback int64) int64 {
+	v, ok := values[field]
+	if !ok {
+		return fallback
+	}
+	n, _ := strconv.ParseInt(v, 10, 64)
+	return n
+}
+
 // IncrementSubscriptionTraffic atomically increments subscription traffic in Redis.
 func (c *RedisSubscriptionTrafficCache) IncrementSubscriptionTraffic(ctx context.Context, nodeID, subscriptionID uint, upload, download int64) error {
 	if upload == 0 && download == 0 {
@@ -159,9 +196,11 @@ func (c *RedisSubscriptionTrafficCache) IncrementSubscriptionTraffic(ctx context
 
 	if upload > 0 {
 		pipe.HIncrBy(ctx, key, subFieldUpload, upload)
+		pipe.HIncrBy(ctx, key, subFieldRawUpload, upload)
 	}
 	if download > 0 {
 		pipe.HIncrBy(ctx, key, subFieldDownload, download)
+		pipe.HIncrBy(ctx, key, subFieldRawDownload, download)
 	}
 
 	// Set expiration to prevent memory leak
@@ -195,7 +234,7 @@ func (c *RedisSubscriptionTrafficCache) BatchIncrementSubscriptionTraffic(ctx co
 	pipe := c.client.Pipeline()
 
 	for _, entry := range entries {
-		if entry.Upload == 0 && entry.Download == 0 {
+		if entry.Upload == 0 && entry.Download == 0 && entry.RawUpload == 0 && entry.RawDownload == 0 {
 			continue
 		}
 
@@ -207,6 +246,12 @@ func (c *RedisSubscriptionTrafficCache) BatchIncrementSubscriptionTraffic(ctx co
 		if entry.Download > 0 {
 			pipe.HIncrBy(ctx, key, subFieldDownload, entry.Download)
 		}
+		if entry.RawUpload > 0 {
+			pipe.HIncrBy(ctx, key, subFieldRawUpload, entry.RawUpload)
+		}
+		if entry.RawDownload > 0 {
+			pipe.HIncrBy(ctx, key, subFieldRawDownload, entry.RawDownload)
+		}
 
 		// Set expiration to prevent memory leak
 		pipe.Expire(ctx, key, subscriptionTrafficTTL)
@@ -304,12 +349,18 @@ func (c *RedisSubscriptionTrafficCache) FlushToDatabase(ctx context.Context) err
 		currentDownload, _ := strconv.ParseInt(values[subFieldDownload], 10, 64)
 		lastFlushedUpload, _ := strconv.ParseInt(values[subFieldLastFlushedUpload], 10, 64)
 		lastFlushedDownload, _ := strconv.ParseInt(values[subFieldLastFlushedDownload], 10, 64)
+		currentRawUpload := parseRawTrafficField(values, subFieldRawUpload, currentUpload)
+		currentRawDownload := parseRawTrafficField(values, subFieldRawDownload, currentDownload)
+		lastFlushedRawUpload := parseRawTrafficField(values, subFieldLastFlushedRawUpload, lastFlushedUpload)
+		lastFlushedRawDownload := parseRawTrafficField(values, subFieldLastFlushedRawDownload, lastFlushedDownload)
 
 		// Calculate increments
 		uploadDelta := currentUpload - lastFlushedUpload
 		downloadDelta := currentDownload - lastFlushedDownload
+		rawUploadDelta := currentRawUpload - lastFlushedRawUpload
+		rawDownloadDelta := currentRawDownload - lastFlushedRawDownload
 
-		if uploadDelta <= 0 && downloadDelta <= 0 {
+		if uploadDelta <= 0 && downloadDelta <= 0 && rawUploadDelta <= 0 && rawDownloadDelta <= 0 {
 			skippedCount++
 			// Use Lua script to atomically check and remove from active set
 			// This prevents race condition where new data arrives between check and remove
@@ -324,10 +375,17 @@ func (c *RedisSubscriptionTrafficCache) FlushToDatabase(ctx context.Context) err
 		if downloadDelta < 0 {
 			downloadDelta = 0
 		}
+		if rawUploadDelta < 0 {
+			rawUploadDelta = 0
+		}
+		if rawDownloadDelta < 0 {
+			rawDownloadDelta = 0
+		}
 
 		// Write to Redis HourlyTrafficCache instead of MySQL
 		// resourceType is "node" since traffic is collected per node
-		if err := c.hourlyTrafficCache.IncrementHourlyTraffic(ctx, subscriptionID, subscription.ResourceTypeNode.String(), nodeID, uploadDelta, downloadDelta); err != nil {
+		if err := c.hourlyTrafficCache.IncrementHourlyTrafficWithRaw(ctx, subscriptionID, subscription.ResourceTypeNode.String(), nodeID,
+			uploadDelta, downloadDelta, rawUploadDelta, rawDownloadDelta); err != nil {
 			c.logger.Errorw("failed to flush subscription traffic to hourly cache",
 				"node_id", nodeID,
 				"subscription_id", subscriptionID,
@@ -341,7 +399,7 @@ func (c *RedisSubscriptionTrafficCache) FlushToDatabase(ctx context.Context) err
 
 		// Atomically update last_flushed values and remove from active set if no new data
 		// Use Lua script to prevent data loss from race condition
-		removed, err := c.atomicUpdateLastFlushed(ctx, key, currentUpload, currentDownload)
+		removed, err := c.atomicUpdateLastFlushed(ctx, key, currentUpload, currentDownload, currentRawUpload, currentRawDownload)
 		if err != nil {
 			c.logger.Warnw("failed to update last_flushed values in redis",
 				"key", key,
@@ -385,10 +443,11 @@ func (c *RedisSubscriptionTrafficCache) safeRemoveFromActiveSet(ctx context.Cont
 }
 
 // atomicUpdateLastFlushed updates last_flushed values and conditionally removes from active set.
-func (c *RedisSubscriptionTrafficCache) atomicUpdateLastFlushed(ctx context.Context, key string, currentUpload, currentDownload int64) (bool, error) {
+func (c *RedisSubscriptionTrafficCache) atomicUpdateLastFlushed(ctx context.Context, key string, currentUpload, currentDownload, currentRawUpload, currentRawDownload int64) (bool, error) {
 	result, err := subAtomicUpdateLastFlushedScript.Run(ctx, c.client,
 		[]string{key, activeSubscriptionsSetKey},
 		currentUpload, currentDownload, key, int(subscriptionTrafficTTL.Seconds()),
+		currentRawUpload, currentRawDownload,
 	).Int()
 	if err != nil {
 		return false, err
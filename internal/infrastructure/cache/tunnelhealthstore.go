@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+)
+
+const (
+	// tunnelHealthKeyPrefix is the prefix for tunnel health state keys.
+	// Key format: tunnel_health:{ruleID}:{exitAgentID}
+	tunnelHealthKeyPrefix = "tunnel_health:"
+
+	// tunnelHealthTTL expires the state of tunnels that stop being reported, e.g. when an
+	// exit is removed from a rule. An expired demotion is lifted on the next config sync.
+	tunnelHealthTTL = 24 * time.Hour
+)
+
+// tunnelHealthStateData is the Redis representation of forward.TunnelHealthState.
+type tunnelHealthStateData struct {
+	Healthy    bool  `json:"healthy"`
+	Since      int64 `json:"since"`
+	RecordedAt int64 `json:"recorded_at"`
+	Demoted    bool  `json:"demoted,omitempty"`
+}
+
+// TunnelHealthStore stores the latest health of entry-to-exit tunnels in Redis so that
+// demoted exit agents are known on every instance building config syncs.
+type TunnelHealthStore struct {
+	client *redis.Client
+}
+
+// NewTunnelHealthStore creates a new TunnelHealthStore.
+func NewTunnelHealthStore(client *redis.Client) *TunnelHealthStore {
+	return &TunnelHealthStore{client: client}
+}
+
+func tunnelHealthKey(ruleID, exitAgentID uint) string {
+	return fmt.Sprintf("%s%d:%d", tunnelHealthKeyPrefix, ruleID, exitAgentID)
+}
+
+// Get returns the state of a tunnel, or nil if it has not been reported.
+func (s *TunnelHealthStore) Get(ctx context.Context, ruleID, exitAgentID uint) (*forward.TunnelHealthState, error) {
+	data, err := s.client.Get(ctx, tunnelHealthKey(ruleID, exitAgentID)).Bytes()
+	if err == redis.Nil {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tunnel health state: %w", err)
+	}
+
+	return decodeTunnelHealthState(data)
+}
+
+// Set stores the state of a tunnel.
+func (s *TunnelHealthStore) Set(ctx context.Context, ruleID, exitAgentID uint, state forward.TunnelHealthState) error {
+	data, err := json.Marshal(tunnelHealthStateData{
+		Healthy:    state.Healthy,
+		Since:      state.Since.Unix(),
+		RecordedAt: state.RecordedAt.Unix(),
+		Demoted:    state.Demoted,
+	})
+	if err != nil {
+		return fmt.Errorf("failed to marshal tunnel health state: %w", err)
+	}
+
+	if err := s.client.Set(ctx, tunnelHealthKey(ruleID, exitAgentID), data, tunnelHealthTTL).Err(); err != nil {
+		return fmt.Errorf("failed to set tunnel health state: %w", err)
+	}
+	return nil
+}
+
+// GetStates returns the states of the tunnels of a rule to the given exit agents.
+// Tunnels that have not been reported are missing from the result.
+func (s *TunnelHealthStore) GetStates(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]*forward.TunnelHealthState, error) {
+	result := make(map[uint]*forward.TunnelHealthState, len(exitAgentIDs))
+	if len(exitAgentIDs) == 0 {
+		return result, nil
+	}
+
+	keys := make([]string, len(exitAgentIDs))
+	for i, exitAgentID := range exitAgentIDs {
+		keys[i] = tunnelHealthKey(ruleID, exitAgentID)
+	}
+
+	values, err := s.client.MGet(ctx, keys...).Result()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get tunnel health states: %w", err)
+	}
+
+	for i, value := range values {
+		str, ok := value.(string)
+		if !ok {
+			continue
+		}
+		state, err := decodeTunnelHealthState([]byte(str))
+		if err != nil {
+			return nil, err
+		}
+		result[exitAgentIDs[i]] = state
+	}
+	return result, nil
+}
+
+// GetDemoted returns which of the given exit agents are demoted for a rule.
+func (s *TunnelHealthStore) GetDemoted(ctx context.Context, ruleID uint, exitAgentIDs []uint) (map[uint]bool, error) {
+	states, err := s.GetStates(ctx, ruleID, exitAgentIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	demoted := make(map[uint]bool)
+	for exitAgentID, state := range states {
+		if state.Demoted {
+			demoted[exitAgentID] = true
+		}
+	}
+	return demoted, nil
+}
+
+func decodeTunnelHealthState(data []byte) (*forward.TunnelHealthState, error) {
+	var stored tunnelHealthStateData
+	if err := json.Unmarshal(data, &stored); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal tunnel health state: %w", err)
+	}
+
+	return &forward.TunnelHealthState{
+		Healthy:    stored.Healthy,
+		Since:      time.Unix(stored.Since, 0).UTC(),
+		RecordedAt: time.Unix(stored.RecordedAt, 0).UTC(),
+		Demoted:    stored.Demoted,
+	}, nil
+}
@@ -0,0 +1,50 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+)
+
+func TestTunnelHealthStore(t *testing.T) {
+	client, cleanup := setupTestRedis(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	store := NewTunnelHealthStore(client)
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	// Unreported tunnels have no state
+	state, err := store.Get(ctx, 1, 2)
+	require.NoError(t, err)
+	assert.Nil(t, state)
+
+	unhealthy := forward.TunnelHealthState{Healthy: false, Since: now, RecordedAt: now, Demoted: true}
+	require.NoError(t, store.Set(ctx, 1, 2, unhealthy))
+	require.NoError(t, store.Set(ctx, 1, 3, forward.TunnelHealthState{Healthy: true, Since: now}))
+	// Same exit agent on another rule is tracked separately
+	require.NoError(t, store.Set(ctx, 9, 3, forward.TunnelHealthState{Healthy: false, Since: now, Demoted: true}))
+
+	state, err = store.Get(ctx, 1, 2)
+	require.NoError(t, err)
+	require.NotNil(t, state)
+	assert.Equal(t, unhealthy, *state)
+
+	states, err := store.GetStates(ctx, 1, []uint{2, 3, 4})
+	require.NoError(t, err)
+	assert.Len(t, states, 2)
+	assert.True(t, states[3].Healthy)
+
+	demoted, err := store.GetDemoted(ctx, 1, []uint{2, 3, 4})
+	require.NoError(t, err)
+	assert.Equal(t, map[uint]bool{2: true}, demoted)
+
+	states, err = store.GetStates(ctx, 1, nil)
+	require.NoError(t, err)
+	assert.Empty(t, states)
+}
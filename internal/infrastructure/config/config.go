@@ -23,6 +23,8 @@ type Config struct {
 	Admin        sharedConfig.AdminConfig        `mapstructure:"admin"`
 	Telegram     sharedConfig.TelegramConfig     `mapstructure:"telegram"`
 	WebAuthn     sharedConfig.WebAuthnConfig     `mapstructure:"webauthn"`
+	Metrics      sharedConfig.MetricsConfig      `mapstructure:"metrics"`
+	ACME         sharedConfig.ACMEConfig         `mapstructure:"acme"`
 }
 
 var (
@@ -183,4 +185,19 @@ func setDefaults() {
 	viper.SetDefault("webauthn.rp_name", "")
 	viper.SetDefault("webauthn.rp_origins", []string{})
 	viper.SetDefault("webauthn.timeout", 0)
+
+	// Metrics defaults - disabled until a token or IP allowlist is configured
+	viper.SetDefault("metrics.enabled", false)
+	viper.SetDefault("metrics.token", "")
+	viper.SetDefault("metrics.allowed_ips", []string{})
+
+	// ACME defaults - disabled until an account email and a DNS provider are configured
+	viper.SetDefault("acme.enabled", false)
+	viper.SetDefault("acme.directory_url", "https://acme-v02.api.letsencrypt.org/directory")
+	viper.SetDefault("acme.email", "")
+	viper.SetDefault("acme.account_key_path", "./data/acme/account.key")
+	viper.SetDefault("acme.insecure_skip_verify", false)
+	viper.SetDefault("acme.dns.cloudflare.api_token", "")
+	viper.SetDefault("acme.dns.cloudflare.propagation_delay", 30)
+	viper.SetDefault("acme.dns.challtestsrv.url", "")
 }
@@ -0,0 +1,6 @@
+-- +goose Up
+-- Add country_code column to nodes table for region-aware subscription proxy groups
+ALTER TABLE nodes ADD COLUMN country_code CHAR(2) NULL COMMENT 'ISO 3166-1 alpha-2 node location';
+
+-- +goose Down
+ALTER TABLE nodes DROP COLUMN country_code;
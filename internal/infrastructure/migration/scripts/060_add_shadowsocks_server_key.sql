@@ -0,0 +1,7 @@
+-- +goose Up
+-- Add server_key column for explicitly managed (rotatable) SS2022 server PSKs.
+-- NULL means the key is derived from the node token hash.
+ALTER TABLE node_shadowsocks_configs ADD COLUMN server_key VARCHAR(64) NULL COMMENT 'Base64 SS2022 server PSK';
+
+-- +goose Down
+ALTER TABLE node_shadowsocks_configs DROP COLUMN server_key;
@@ -0,0 +1,20 @@
+-- +goose Up
+-- WireGuard node configuration. Peer keypairs and tunnel addresses are derived per
+-- subscription, so only the server keypair and the address pool are stored.
+CREATE TABLE node_wireguard_configs (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    node_id BIGINT UNSIGNED NOT NULL,
+    private_key VARCHAR(64) NOT NULL,
+    address_pool VARCHAR(43) NOT NULL DEFAULT '10.66.0.0/16',
+    mtu INT NOT NULL DEFAULT 1420,
+    dns VARCHAR(255) NOT NULL DEFAULT '1.1.1.1',
+    persistent_keepalive INT NOT NULL DEFAULT 25,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    deleted_at TIMESTAMP NULL,
+    UNIQUE INDEX idx_node_wireguard_configs_node_id (node_id),
+    INDEX idx_node_wireguard_configs_deleted_at (deleted_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS node_wireguard_configs;
@@ -0,0 +1,15 @@
+-- +goose Up
+-- Add optional ShadowTLS layer columns to Shadowsocks configs.
+-- NULL shadowtls_version means ShadowTLS is disabled for the node.
+ALTER TABLE node_shadowsocks_configs
+    ADD COLUMN shadowtls_version TINYINT UNSIGNED NULL COMMENT 'ShadowTLS protocol version (2 or 3)',
+    ADD COLUMN shadowtls_password VARCHAR(128) NULL COMMENT 'ShadowTLS password',
+    ADD COLUMN shadowtls_handshake_server VARCHAR(255) NULL COMMENT 'ShadowTLS handshake server hostname',
+    ADD COLUMN shadowtls_handshake_port SMALLINT UNSIGNED NULL COMMENT 'ShadowTLS handshake server port';
+
+-- +goose Down
+ALTER TABLE node_shadowsocks_configs
+    DROP COLUMN shadowtls_version,
+    DROP COLUMN shadowtls_password,
+    DROP COLUMN shadowtls_handshake_server,
+    DROP COLUMN shadowtls_handshake_port;
@@ -0,0 +1,23 @@
+-- +goose Up
+-- Support XHTTP (SplitHTTP) and HTTPUpgrade transports for VLESS and VMess.
+-- transport_type is widened to fit "httpupgrade"; xhttp_* columns are only set for xhttp.
+ALTER TABLE node_vless_configs
+    MODIFY COLUMN transport_type VARCHAR(16) NOT NULL DEFAULT 'tcp',
+    ADD COLUMN xhttp_mode VARCHAR(16) NULL COMMENT 'XHTTP mode (auto, packet-up, stream-up, stream-one)',
+    ADD COLUMN xhttp_extra TEXT NULL COMMENT 'XHTTP extra options (JSON object)';
+
+ALTER TABLE node_vmess_configs
+    MODIFY COLUMN transport_type VARCHAR(16) NOT NULL DEFAULT 'tcp',
+    ADD COLUMN xhttp_mode VARCHAR(16) NULL COMMENT 'XHTTP mode (auto, packet-up, stream-up, stream-one)',
+    ADD COLUMN xhttp_extra TEXT NULL COMMENT 'XHTTP extra options (JSON object)';
+
+-- +goose Down
+ALTER TABLE node_vmess_configs
+    DROP COLUMN xhttp_extra,
+    DROP COLUMN xhttp_mode,
+    MODIFY COLUMN transport_type VARCHAR(10) NOT NULL DEFAULT 'tcp';
+
+ALTER TABLE node_vless_configs
+    DROP COLUMN xhttp_extra,
+    DROP COLUMN xhttp_mode,
+    MODIFY COLUMN transport_type VARCHAR(10) NOT NULL DEFAULT 'tcp';
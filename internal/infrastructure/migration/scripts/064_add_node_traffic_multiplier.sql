@@ -0,0 +1,15 @@
+-- +goose Up
+-- Add per-node billing rate applied to subscription usage (1 = billed 1:1)
+ALTER TABLE nodes ADD COLUMN traffic_multiplier DECIMAL(10,4) NOT NULL DEFAULT 1 COMMENT 'billing rate applied to subscription usage';
+
+-- Keep raw (pre-multiplier) usage alongside billed usage for operator statistics
+ALTER TABLE subscription_usage_stats
+    ADD COLUMN raw_upload BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'bytes uploaded before node traffic multiplier' AFTER total,
+    ADD COLUMN raw_download BIGINT UNSIGNED NOT NULL DEFAULT 0 COMMENT 'bytes downloaded before node traffic multiplier' AFTER raw_upload;
+
+-- Existing usage was billed 1:1
+UPDATE subscription_usage_stats SET raw_upload = upload, raw_download = download;
+
+-- +goose Down
+ALTER TABLE subscription_usage_stats DROP COLUMN raw_download, DROP COLUMN raw_upload;
+ALTER TABLE nodes DROP COLUMN traffic_multiplier;
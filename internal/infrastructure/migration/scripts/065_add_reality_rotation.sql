@@ -0,0 +1,19 @@
+-- +goose Up
+-- Scheduled Reality key and short ID rotation for VLESS nodes.
+-- The previous short ID stays accepted by the agent until reality_previous_short_id_expires_at.
+ALTER TABLE node_vless_configs
+    ADD COLUMN reality_rotation_interval_hours INT NOT NULL DEFAULT 0 COMMENT 'scheduled rotation interval in hours, 0 = disabled',
+    ADD COLUMN reality_rotation_overlap_hours INT NOT NULL DEFAULT 0 COMMENT 'hours the previous short ID stays accepted',
+    ADD COLUMN reality_rotate_key_pair TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'scheduled rotations also replace the key pair',
+    ADD COLUMN reality_previous_short_id VARCHAR(32) NOT NULL DEFAULT '' COMMENT 'short ID replaced by the last rotation',
+    ADD COLUMN reality_previous_short_id_expires_at DATETIME NULL COMMENT 'end of the previous short ID overlap window',
+    ADD COLUMN reality_rotated_at DATETIME NULL COMMENT 'last reality rotation time';
+
+-- +goose Down
+ALTER TABLE node_vless_configs
+    DROP COLUMN reality_rotated_at,
+    DROP COLUMN reality_previous_short_id_expires_at,
+    DROP COLUMN reality_previous_short_id,
+    DROP COLUMN reality_rotate_key_pair,
+    DROP COLUMN reality_rotation_overlap_hours,
+    DROP COLUMN reality_rotation_interval_hours;
@@ -0,0 +1,25 @@
+-- +goose Up
+-- Scheduled node maintenance windows. Target resource groups are resolved to node IDs
+-- when the window starts (affected_node_ids); entered_node_ids holds the nodes the window
+-- switched into maintenance so that only those are switched back at the end.
+CREATE TABLE maintenance_windows (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    sid VARCHAR(32) NOT NULL,
+    reason VARCHAR(500) NOT NULL,
+    starts_at DATETIME NOT NULL,
+    ends_at DATETIME NOT NULL,
+    node_ids JSON,
+    resource_group_ids JSON,
+    status VARCHAR(20) NOT NULL DEFAULT 'scheduled',
+    affected_node_ids JSON,
+    entered_node_ids JSON,
+    announcement_id BIGINT UNSIGNED NULL,
+    created_by BIGINT UNSIGNED NOT NULL DEFAULT 0,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_maintenance_windows_sid (sid),
+    INDEX idx_maintenance_windows_status_starts_at (status, starts_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS maintenance_windows;
@@ -0,0 +1,27 @@
+-- +goose Up
+-- ACME TLS certificates for node TLS server names, one record per domain.
+-- Nodes whose SNI (or domain server address) is covered by the domain receive
+-- certificate_pem / private_key_pem through the node hub.
+CREATE TABLE node_certificates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    sid VARCHAR(32) NOT NULL,
+    domain VARCHAR(255) NOT NULL,
+    dns_provider VARCHAR(50) NOT NULL,
+    auto_renew TINYINT(1) NOT NULL DEFAULT 1,
+    status VARCHAR(20) NOT NULL DEFAULT 'pending',
+    certificate_pem MEDIUMTEXT,
+    private_key_pem TEXT,
+    fingerprint VARCHAR(64) NOT NULL DEFAULT '',
+    not_before DATETIME NULL,
+    not_after DATETIME NULL,
+    last_error VARCHAR(1000) NOT NULL DEFAULT '',
+    last_attempt_at DATETIME NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_node_certificates_sid (sid),
+    UNIQUE INDEX idx_node_certificates_domain (domain),
+    INDEX idx_node_certificates_not_after (not_after)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS node_certificates;
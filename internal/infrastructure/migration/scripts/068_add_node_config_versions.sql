@@ -0,0 +1,18 @@
+-- +goose Up
+-- Immutable history of node protocol, route and DNS configuration. Each row holds a full
+-- snapshot (so any version can be restored) plus the changes against the previous version.
+CREATE TABLE node_config_versions (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    node_id BIGINT UNSIGNED NOT NULL,
+    version INT NOT NULL,
+    snapshot JSON NOT NULL,
+    changes JSON,
+    author_id BIGINT UNSIGNED NULL,
+    source VARCHAR(20) NOT NULL,
+    restored_from INT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_node_config_versions_node_version (node_id, version)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS node_config_versions;
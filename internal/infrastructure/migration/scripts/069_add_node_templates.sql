@@ -0,0 +1,20 @@
+-- +goose Up
+-- Reusable node configurations. A template holds a protocol, route and DNS configuration
+-- snapshot plus resource group memberships. Nodes provisioned from a template only need
+-- a server address and ports; server keys are never copied and are generated per node.
+CREATE TABLE node_templates (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    sid VARCHAR(32) NOT NULL,
+    name VARCHAR(100) NOT NULL,
+    description VARCHAR(500) NOT NULL DEFAULT '',
+    config JSON NOT NULL,
+    group_ids JSON,
+    created_by BIGINT UNSIGNED NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_node_templates_sid (sid),
+    UNIQUE INDEX idx_node_templates_name (name)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS node_templates;
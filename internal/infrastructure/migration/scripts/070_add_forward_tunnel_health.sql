@@ -0,0 +1,21 @@
+-- +goose Up
+-- Health timeline of the tunnels from a rule's entry agent to its exit agents, as reported
+-- by the entry agent. State changes are always stored; while the state holds, one sample
+-- per minute is kept. Records are pruned after the retention period.
+CREATE TABLE forward_tunnel_health (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    rule_id BIGINT UNSIGNED NOT NULL,
+    entry_agent_id BIGINT UNSIGNED NOT NULL,
+    exit_agent_id BIGINT UNSIGNED NOT NULL,
+    healthy TINYINT(1) NOT NULL,
+    fail_count INT NOT NULL DEFAULT 0,
+    latency_ms BIGINT NULL,
+    error_message VARCHAR(500) NOT NULL DEFAULT '',
+    checked_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    INDEX idx_forward_tunnel_health_rule (rule_id, checked_at),
+    INDEX idx_forward_tunnel_health_checked_at (checked_at)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS forward_tunnel_health;
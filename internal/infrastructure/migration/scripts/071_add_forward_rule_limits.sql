@@ -0,0 +1,13 @@
+-- +goose Up
+-- Per-rule bandwidth (Mbps) and concurrent connection limits enforced by agents.
+-- 0 means not set: subscription-bound rules inherit the limit from the subscription's plan.
+ALTER TABLE forward_rules
+    ADD COLUMN upload_limit_mbps INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN download_limit_mbps INT UNSIGNED NOT NULL DEFAULT 0,
+    ADD COLUMN max_connections INT UNSIGNED NOT NULL DEFAULT 0;
+
+-- +goose Down
+ALTER TABLE forward_rules
+    DROP COLUMN upload_limit_mbps,
+    DROP COLUMN download_limit_mbps,
+    DROP COLUMN max_connections;
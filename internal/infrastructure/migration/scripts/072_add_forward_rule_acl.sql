@@ -0,0 +1,6 @@
+-- +goose Up
+-- Source IP access control list (CIDR and GeoIP country allow/deny lists) enforced by entry agents.
+ALTER TABLE forward_rules ADD COLUMN acl JSON NULL;
+
+-- +goose Down
+ALTER TABLE forward_rules DROP COLUMN acl;
@@ -0,0 +1,17 @@
+-- +goose Up
+-- Tunnel addresses and peer keypairs of subscriptions on WireGuard nodes. Addresses are taken
+-- from the node's address pool; addresses of subscriptions that are no longer active are released
+-- for reuse once the pool runs full. Keypairs are random per node and subscription.
+CREATE TABLE node_wireguard_peers (
+    id BIGINT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    node_id BIGINT UNSIGNED NOT NULL,
+    subscription_id BIGINT UNSIGNED NOT NULL,
+    address VARCHAR(15) NOT NULL,
+    private_key VARCHAR(64) NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE INDEX idx_node_wireguard_peers_subscription (node_id, subscription_id),
+    UNIQUE INDEX idx_node_wireguard_peers_address (node_id, address)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_general_ci;
+
+-- +goose Down
+DROP TABLE IF EXISTS node_wireguard_peers;
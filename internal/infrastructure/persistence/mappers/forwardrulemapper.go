@@ -135,6 +135,15 @@ func (m *ForwardRuleMapperImpl) ToEntity(model *models.ForwardRuleModel) (*forwa
 		routeConfig = RouteConfigFromJSON(&routeJSON)
 	}
 
+	// Parse acl JSON
+	var acl *vo.RuleACL
+	if len(model.ACL) > 0 && string(model.ACL) != "null" {
+		acl = &vo.RuleACL{}
+		if err := json.Unmarshal(model.ACL, acl); err != nil {
+			return nil, fmt.Errorf("failed to parse acl: %w", err)
+		}
+	}
+
 	ipVersion := vo.IPVersion(model.IPVersion)
 	tunnelType := vo.TunnelType(model.TunnelType)
 	loadBalanceStrategy := vo.ParseLoadBalanceStrategy(model.LoadBalanceStrategy)
@@ -191,6 +200,12 @@ func (m *ForwardRuleMapperImpl) ToEntity(model *models.ForwardRuleModel) (*forwa
 		groupIDs,
 		routeConfig,
 		addressPreference,
+		vo.RuleLimits{
+			UploadMbps:     model.UploadLimitMbps,
+			DownloadMbps:   model.DownloadLimitMbps,
+			MaxConnections: model.MaxConnections,
+		},
+		acl,
 		serverAddress,
 		externalSource,
 		externalRuleID,
@@ -296,6 +311,16 @@ func (m *ForwardRuleMapperImpl) ToModel(entity *forward.ForwardRule) (*models.Fo
 		routeConfigJSON = rcBytes
 	}
 
+	// Serialize acl to JSON
+	var aclJSON datatypes.JSON
+	if !entity.ACL().IsEmpty() {
+		aclBytes, err := json.Marshal(entity.ACL())
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize acl: %w", err)
+		}
+		aclJSON = aclBytes
+	}
+
 	// Handle external rule fields
 	var serverAddress *string
 	if entity.ServerAddress() != "" {
@@ -344,6 +369,10 @@ func (m *ForwardRuleMapperImpl) ToModel(entity *forward.ForwardRule) (*models.Fo
 		GroupIDs:            groupIDsJSON,
 		RouteConfig:         routeConfigJSON,
 		AddressPreference:   entity.AddressPreference().String(),
+		UploadLimitMbps:     entity.Limits().UploadMbps,
+		DownloadLimitMbps:   entity.Limits().DownloadMbps,
+		MaxConnections:      entity.Limits().MaxConnections,
+		ACL:                 aclJSON,
 		ServerAddress:       serverAddress,
 		ExternalSource:      externalSource,
 		ExternalRuleID:      externalRuleID,
@@ -0,0 +1,79 @@
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/mapper"
+)
+
+// ForwardTunnelHealthMapper handles the conversion between tunnel health records and persistence models.
+type ForwardTunnelHealthMapper interface {
+	// ToEntity converts a persistence model to a domain entity.
+	ToEntity(model *models.ForwardTunnelHealthModel) (*forward.TunnelHealthRecord, error)
+
+	// ToModel converts a domain entity to a persistence model.
+	ToModel(entity *forward.TunnelHealthRecord) *models.ForwardTunnelHealthModel
+
+	// ToEntities converts multiple persistence models to domain entities.
+	ToEntities(models []*models.ForwardTunnelHealthModel) ([]*forward.TunnelHealthRecord, error)
+}
+
+// ForwardTunnelHealthMapperImpl is the concrete implementation of ForwardTunnelHealthMapper.
+type ForwardTunnelHealthMapperImpl struct{}
+
+// NewForwardTunnelHealthMapper creates a new tunnel health mapper.
+func NewForwardTunnelHealthMapper() ForwardTunnelHealthMapper {
+	return &ForwardTunnelHealthMapperImpl{}
+}
+
+// ToEntity converts a persistence model to a domain entity.
+func (m *ForwardTunnelHealthMapperImpl) ToEntity(model *models.ForwardTunnelHealthModel) (*forward.TunnelHealthRecord, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	entity, err := forward.ReconstructTunnelHealthRecord(
+		model.ID,
+		model.RuleID,
+		model.EntryAgentID,
+		model.ExitAgentID,
+		model.Healthy,
+		model.FailCount,
+		model.LatencyMs,
+		model.ErrorMessage,
+		model.CheckedAt,
+		model.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct tunnel health record entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+// ToModel converts a domain entity to a persistence model.
+func (m *ForwardTunnelHealthMapperImpl) ToModel(entity *forward.TunnelHealthRecord) *models.ForwardTunnelHealthModel {
+	if entity == nil {
+		return nil
+	}
+
+	return &models.ForwardTunnelHealthModel{
+		ID:           entity.ID(),
+		RuleID:       entity.RuleID(),
+		EntryAgentID: entity.EntryAgentID(),
+		ExitAgentID:  entity.ExitAgentID(),
+		Healthy:      entity.Healthy(),
+		FailCount:    entity.FailCount(),
+		LatencyMs:    entity.LatencyMs(),
+		ErrorMessage: entity.ErrorMessage(),
+		CheckedAt:    entity.CheckedAt(),
+		CreatedAt:    entity.CreatedAt(),
+	}
+}
+
+// ToEntities converts multiple persistence models to domain entities.
+func (m *ForwardTunnelHealthMapperImpl) ToEntities(modelList []*models.ForwardTunnelHealthModel) ([]*forward.TunnelHealthRecord, error) {
+	return mapper.MapSlicePtrWithID(modelList, m.ToEntity, func(model *models.ForwardTunnelHealthModel) uint { return model.ID })
+}
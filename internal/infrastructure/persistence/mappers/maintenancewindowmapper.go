@@ -0,0 +1,145 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/mapper"
+)
+
+// MaintenanceWindowMapper handles the conversion between maintenance window entities and persistence models.
+type MaintenanceWindowMapper interface {
+	// ToEntity converts a persistence model to a domain entity.
+	ToEntity(model *models.MaintenanceWindowModel) (*node.MaintenanceWindow, error)
+
+	// ToModel converts a domain entity to a persistence model.
+	ToModel(entity *node.MaintenanceWindow) (*models.MaintenanceWindowModel, error)
+
+	// ToEntities converts multiple persistence models to domain entities.
+	ToEntities(models []*models.MaintenanceWindowModel) ([]*node.MaintenanceWindow, error)
+}
+
+// MaintenanceWindowMapperImpl is the concrete implementation of MaintenanceWindowMapper.
+type MaintenanceWindowMapperImpl struct{}
+
+// NewMaintenanceWindowMapper creates a new maintenance window mapper.
+func NewMaintenanceWindowMapper() MaintenanceWindowMapper {
+	return &MaintenanceWindowMapperImpl{}
+}
+
+// ToEntity converts a persistence model to a domain entity.
+func (m *MaintenanceWindowMapperImpl) ToEntity(model *models.MaintenanceWindowModel) (*node.MaintenanceWindow, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	nodeIDs, err := unmarshalIDs(model.NodeIDs, "node_ids")
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := unmarshalIDs(model.ResourceGroupIDs, "resource_group_ids")
+	if err != nil {
+		return nil, err
+	}
+	affectedIDs, err := unmarshalIDs(model.AffectedNodeIDs, "affected_node_ids")
+	if err != nil {
+		return nil, err
+	}
+	enteredIDs, err := unmarshalIDs(model.EnteredNodeIDs, "entered_node_ids")
+	if err != nil {
+		return nil, err
+	}
+
+	entity, err := node.ReconstructMaintenanceWindow(
+		model.ID,
+		model.SID,
+		model.Reason,
+		model.StartsAt,
+		model.EndsAt,
+		nodeIDs,
+		groupIDs,
+		model.Status,
+		affectedIDs,
+		enteredIDs,
+		model.AnnouncementID,
+		model.CreatedBy,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct maintenance window entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+// ToModel converts a domain entity to a persistence model.
+func (m *MaintenanceWindowMapperImpl) ToModel(entity *node.MaintenanceWindow) (*models.MaintenanceWindowModel, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	nodeIDs, err := marshalIDs(entity.NodeIDs(), "node_ids")
+	if err != nil {
+		return nil, err
+	}
+	groupIDs, err := marshalIDs(entity.ResourceGroupIDs(), "resource_group_ids")
+	if err != nil {
+		return nil, err
+	}
+	affectedIDs, err := marshalIDs(entity.AffectedNodeIDs(), "affected_node_ids")
+	if err != nil {
+		return nil, err
+	}
+	enteredIDs, err := marshalIDs(entity.EnteredNodeIDs(), "entered_node_ids")
+	if err != nil {
+		return nil, err
+	}
+
+	return &models.MaintenanceWindowModel{
+		ID:               entity.ID(),
+		SID:              entity.SID(),
+		Reason:           entity.Reason(),
+		StartsAt:         entity.StartsAt(),
+		EndsAt:           entity.EndsAt(),
+		NodeIDs:          nodeIDs,
+		ResourceGroupIDs: groupIDs,
+		Status:           entity.Status().String(),
+		AffectedNodeIDs:  affectedIDs,
+		EnteredNodeIDs:   enteredIDs,
+		AnnouncementID:   entity.AnnouncementID(),
+		CreatedBy:        entity.CreatedBy(),
+		CreatedAt:        entity.CreatedAt(),
+		UpdatedAt:        entity.UpdatedAt(),
+	}, nil
+}
+
+// ToEntities converts multiple persistence models to domain entities.
+func (m *MaintenanceWindowMapperImpl) ToEntities(modelList []*models.MaintenanceWindowModel) ([]*node.MaintenanceWindow, error) {
+	return mapper.MapSlicePtrWithID(modelList, m.ToEntity, func(model *models.MaintenanceWindowModel) uint { return model.ID })
+}
+
+func unmarshalIDs(data datatypes.JSON, column string) ([]uint, error) {
+	var ids []uint
+	if len(data) > 0 {
+		if err := json.Unmarshal(data, &ids); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal %s: %w", column, err)
+		}
+	}
+	return ids, nil
+}
+
+func marshalIDs(ids []uint, column string) (datatypes.JSON, error) {
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal %s: %w", column, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,89 @@
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/mapper"
+)
+
+// NodeCertificateMapper handles the conversion between certificate entities and persistence models.
+type NodeCertificateMapper interface {
+	// ToEntity converts a persistence model to a domain entity.
+	ToEntity(model *models.NodeCertificateModel) (*node.Certificate, error)
+
+	// ToModel converts a domain entity to a persistence model.
+	ToModel(entity *node.Certificate) (*models.NodeCertificateModel, error)
+
+	// ToEntities converts multiple persistence models to domain entities.
+	ToEntities(models []*models.NodeCertificateModel) ([]*node.Certificate, error)
+}
+
+// NodeCertificateMapperImpl is the concrete implementation of NodeCertificateMapper.
+type NodeCertificateMapperImpl struct{}
+
+// NewNodeCertificateMapper creates a new node certificate mapper.
+func NewNodeCertificateMapper() NodeCertificateMapper {
+	return &NodeCertificateMapperImpl{}
+}
+
+// ToEntity converts a persistence model to a domain entity.
+func (m *NodeCertificateMapperImpl) ToEntity(model *models.NodeCertificateModel) (*node.Certificate, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	entity, err := node.ReconstructCertificate(
+		model.ID,
+		model.SID,
+		model.Domain,
+		model.DNSProvider,
+		model.AutoRenew,
+		model.Status,
+		model.CertificatePEM,
+		model.PrivateKeyPEM,
+		model.Fingerprint,
+		model.NotBefore,
+		model.NotAfter,
+		model.LastError,
+		model.LastAttemptAt,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct certificate entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+// ToModel converts a domain entity to a persistence model.
+func (m *NodeCertificateMapperImpl) ToModel(entity *node.Certificate) (*models.NodeCertificateModel, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	return &models.NodeCertificateModel{
+		ID:             entity.ID(),
+		SID:            entity.SID(),
+		Domain:         entity.Domain(),
+		DNSProvider:    entity.DNSProvider(),
+		AutoRenew:      entity.AutoRenew(),
+		Status:         entity.Status().String(),
+		CertificatePEM: entity.CertificatePEM(),
+		PrivateKeyPEM:  entity.PrivateKeyPEM(),
+		Fingerprint:    entity.Fingerprint(),
+		NotBefore:      entity.NotBefore(),
+		NotAfter:       entity.NotAfter(),
+		LastError:      entity.LastError(),
+		LastAttemptAt:  entity.LastAttemptAt(),
+		CreatedAt:      entity.CreatedAt(),
+		UpdatedAt:      entity.UpdatedAt(),
+	}, nil
+}
+
+// ToEntities converts multiple persistence models to domain entities.
+func (m *NodeCertificateMapperImpl) ToEntities(modelList []*models.NodeCertificateModel) ([]*node.Certificate, error) {
+	return mapper.MapSlicePtrWithID(modelList, m.ToEntity, func(model *models.NodeCertificateModel) uint { return model.ID })
+}
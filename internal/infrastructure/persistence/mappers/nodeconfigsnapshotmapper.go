@@ -0,0 +1,124 @@
+package mappers
+
+import (
+	"fmt"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+)
+
+// NodeConfigSnapshotJSON represents the JSON structure for ConfigSnapshot persistence.
+// Protocol configs reuse the protocol table models so snapshots round-trip through
+// the same conversions as the live configuration.
+type NodeConfigSnapshotJSON struct {
+	Protocol    string                         `json:"protocol"`
+	Shadowsocks *models.ShadowsocksConfigModel `json:"shadowsocks,omitempty"`
+	Trojan      *models.TrojanConfigModel      `json:"trojan,omitempty"`
+	VLESS       *models.VLESSConfigModel       `json:"vless,omitempty"`
+	VMess       *models.VMessConfigModel       `json:"vmess,omitempty"`
+	Hysteria2   *models.Hysteria2ConfigModel   `json:"hysteria2,omitempty"`
+	TUIC        *models.TUICConfigModel        `json:"tuic,omitempty"`
+	AnyTLS      *models.AnyTLSConfigModel      `json:"anytls,omitempty"`
+	WireGuard   *models.WireGuardConfigModel   `json:"wireguard,omitempty"`
+	Route       *RouteConfigJSON               `json:"route,omitempty"`
+	DNS         *DnsConfigJSON                 `json:"dns,omitempty"`
+}
+
+// nodeConfigSnapshotMapper converts configuration snapshots to and from their JSON structure.
+// It is shared by the configuration version and node template mappers.
+type nodeConfigSnapshotMapper struct {
+	shadowsocks ShadowsocksConfigMapper
+	trojan      TrojanConfigMapper
+	vless       VLESSConfigMapper
+	vmess       VMessConfigMapper
+	hysteria2   Hysteria2ConfigMapper
+	tuic        TUICConfigMapper
+	anytls      AnyTLSConfigMapper
+	wireguard   WireGuardConfigMapper
+}
+
+func newNodeConfigSnapshotMapper() *nodeConfigSnapshotMapper {
+	return &nodeConfigSnapshotMapper{
+		shadowsocks: NewShadowsocksConfigMapper(),
+		trojan:      NewTrojanConfigMapper(),
+		vless:       NewVLESSConfigMapper(),
+		vmess:       NewVMessConfigMapper(),
+		hysteria2:   NewHysteria2ConfigMapper(),
+		tuic:        NewTUICConfigMapper(),
+		anytls:      NewAnyTLSConfigMapper(),
+		wireguard:   NewWireGuardConfigMapper(),
+	}
+}
+
+// toJSON converts a configuration snapshot to its JSON structure
+func (m *nodeConfigSnapshotMapper) toJSON(s node.ConfigSnapshot) (*NodeConfigSnapshotJSON, error) {
+	sj := &NodeConfigSnapshotJSON{
+		Protocol: s.Protocol.String(),
+		Route:    RouteConfigToJSON(s.Route),
+		DNS:      dnsConfigToJSON(s.DNS),
+	}
+
+	var err error
+	switch s.Protocol {
+	case vo.ProtocolShadowsocks:
+		sj.Shadowsocks, err = m.shadowsocks.ToModel(0, s.Encryption, s.Plugin, s.ShadowTLS)
+	case vo.ProtocolTrojan:
+		sj.Trojan, err = m.trojan.ToModel(0, s.Trojan)
+	case vo.ProtocolVLESS:
+		sj.VLESS, err = m.vless.ToModel(0, s.VLESS)
+	case vo.ProtocolVMess:
+		sj.VMess, err = m.vmess.ToModel(0, s.VMess)
+	case vo.ProtocolHysteria2:
+		sj.Hysteria2, err = m.hysteria2.ToModel(0, s.Hysteria2)
+	case vo.ProtocolTUIC:
+		sj.TUIC, err = m.tuic.ToModel(0, s.TUIC)
+	case vo.ProtocolAnyTLS:
+		sj.AnyTLS, err = m.anytls.ToModel(0, s.AnyTLS)
+	case vo.ProtocolWireGuard:
+		sj.WireGuard, err = m.wireguard.ToModel(0, s.WireGuard)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to map %s config: %w", s.Protocol, err)
+	}
+
+	return sj, nil
+}
+
+// fromJSON converts a JSON structure back to a configuration snapshot
+func (m *nodeConfigSnapshotMapper) fromJSON(sj *NodeConfigSnapshotJSON) (node.ConfigSnapshot, error) {
+	s := node.ConfigSnapshot{
+		Protocol: vo.Protocol(sj.Protocol),
+		Route:    RouteConfigFromJSON(sj.Route),
+		DNS:      dnsConfigFromJSON(sj.DNS),
+	}
+
+	// Passwords and UUIDs are derived per subscription and never stored,
+	// so placeholders are used just like when loading the node itself
+	var err error
+	switch s.Protocol {
+	case vo.ProtocolShadowsocks:
+		if sj.Shadowsocks != nil {
+			s.Encryption, s.Plugin, s.ShadowTLS, err = m.shadowsocks.ToValueObjects(sj.Shadowsocks)
+		}
+	case vo.ProtocolTrojan:
+		s.Trojan, err = m.trojan.ToValueObject(sj.Trojan, "")
+	case vo.ProtocolVLESS:
+		s.VLESS, err = m.vless.ToValueObject(sj.VLESS)
+	case vo.ProtocolVMess:
+		s.VMess, err = m.vmess.ToValueObject(sj.VMess, "")
+	case vo.ProtocolHysteria2:
+		s.Hysteria2, err = m.hysteria2.ToValueObject(sj.Hysteria2, PlaceholderPassword)
+	case vo.ProtocolTUIC:
+		s.TUIC, err = m.tuic.ToValueObject(sj.TUIC, "", "")
+	case vo.ProtocolAnyTLS:
+		s.AnyTLS, err = m.anytls.ToValueObject(sj.AnyTLS, PlaceholderPassword)
+	case vo.ProtocolWireGuard:
+		s.WireGuard, err = m.wireguard.ToValueObject(sj.WireGuard)
+	}
+	if err != nil {
+		return node.ConfigSnapshot{}, fmt.Errorf("failed to map %s config: %w", s.Protocol, err)
+	}
+
+	return s, nil
+}
@@ -0,0 +1,133 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/mapper"
+)
+
+// NodeConfigChangeJSON represents the JSON structure for ConfigChange persistence
+type NodeConfigChangeJSON struct {
+	Path string `json:"path"`
+	Old  string `json:"old,omitempty"`
+	New  string `json:"new,omitempty"`
+}
+
+// NodeConfigVersionMapper handles the conversion between configuration version entities and persistence models.
+type NodeConfigVersionMapper interface {
+	// ToEntity converts a persistence model to a domain entity.
+	ToEntity(model *models.NodeConfigVersionModel) (*node.ConfigVersion, error)
+
+	// ToModel converts a domain entity to a persistence model.
+	ToModel(entity *node.ConfigVersion) (*models.NodeConfigVersionModel, error)
+
+	// ToEntities converts multiple persistence models to domain entities.
+	ToEntities(models []*models.NodeConfigVersionModel) ([]*node.ConfigVersion, error)
+}
+
+// NodeConfigVersionMapperImpl is the concrete implementation of NodeConfigVersionMapper.
+type NodeConfigVersionMapperImpl struct {
+	snapshots *nodeConfigSnapshotMapper
+}
+
+// NewNodeConfigVersionMapper creates a new node configuration version mapper.
+func NewNodeConfigVersionMapper() NodeConfigVersionMapper {
+	return &NodeConfigVersionMapperImpl{
+		snapshots: newNodeConfigSnapshotMapper(),
+	}
+}
+
+// ToEntity converts a persistence model to a domain entity.
+func (m *NodeConfigVersionMapperImpl) ToEntity(model *models.NodeConfigVersionModel) (*node.ConfigVersion, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	var snapshotJSON NodeConfigSnapshotJSON
+	if err := json.Unmarshal(model.Snapshot, &snapshotJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal snapshot: %w", err)
+	}
+	snapshot, err := m.snapshots.fromJSON(&snapshotJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var changesJSON []NodeConfigChangeJSON
+	if len(model.Changes) > 0 {
+		if err := json.Unmarshal(model.Changes, &changesJSON); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal changes: %w", err)
+		}
+	}
+	changes := make([]node.ConfigChange, 0, len(changesJSON))
+	for _, c := range changesJSON {
+		changes = append(changes, node.ConfigChange{Path: c.Path, Old: c.Old, New: c.New})
+	}
+
+	entity, err := node.ReconstructConfigVersion(
+		model.ID,
+		model.NodeID,
+		model.Version,
+		snapshot,
+		changes,
+		model.AuthorID,
+		model.Source,
+		model.RestoredFrom,
+		model.CreatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct config version entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+// ToModel converts a domain entity to a persistence model.
+func (m *NodeConfigVersionMapperImpl) ToModel(entity *node.ConfigVersion) (*models.NodeConfigVersionModel, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	snapshotJSON, err := m.snapshots.toJSON(entity.Snapshot())
+	if err != nil {
+		return nil, err
+	}
+	snapshot, err := json.Marshal(snapshotJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal snapshot: %w", err)
+	}
+
+	var changes datatypes.JSON
+	if len(entity.Changes()) > 0 {
+		changesJSON := make([]NodeConfigChangeJSON, 0, len(entity.Changes()))
+		for _, c := range entity.Changes() {
+			changesJSON = append(changesJSON, NodeConfigChangeJSON{Path: c.Path, Old: c.Old, New: c.New})
+		}
+		data, err := json.Marshal(changesJSON)
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal changes: %w", err)
+		}
+		changes = datatypes.JSON(data)
+	}
+
+	return &models.NodeConfigVersionModel{
+		ID:           entity.ID(),
+		NodeID:       entity.NodeID(),
+		Version:      entity.Version(),
+		Snapshot:     datatypes.JSON(snapshot),
+		Changes:      changes,
+		AuthorID:     entity.AuthorID(),
+		Source:       entity.Source().String(),
+		RestoredFrom: entity.RestoredFrom(),
+		CreatedAt:    entity.CreatedAt(),
+	}, nil
+}
+
+// ToEntities converts multiple persistence models to domain entities.
+func (m *NodeConfigVersionMapperImpl) ToEntities(modelList []*models.NodeConfigVersionModel) ([]*node.ConfigVersion, error) {
+	return mapper.MapSlicePtrWithID(modelList, m.ToEntity, func(model *models.NodeConfigVersionModel) uint { return model.ID })
+}
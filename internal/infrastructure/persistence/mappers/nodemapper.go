@@ -22,7 +22,7 @@ import (
 type NodeMapper interface {
 	// ToEntity converts a persistence model to a domain entity
 	// Protocol-specific configs are loaded separately from their respective tables
-	ToEntity(model *models.NodeModel, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, trojanConfig *vo.TrojanConfig, vlessConfig *vo.VLESSConfig, vmessConfig *vo.VMessConfig, hysteria2Config *vo.Hysteria2Config, tuicConfig *vo.TUICConfig, anytlsConfig *vo.AnyTLSConfig) (*node.Node, error)
+	ToEntity(model *models.NodeModel, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig, trojanConfig *vo.TrojanConfig, vlessConfig *vo.VLESSConfig, vmessConfig *vo.VMessConfig, hysteria2Config *vo.Hysteria2Config, tuicConfig *vo.TUICConfig, anytlsConfig *vo.AnyTLSConfig, wireguardConfig *vo.WireGuardConfig) (*node.Node, error)
 
 	// ToModel converts a domain entity to a persistence model
 	// Note: Protocol-specific configs are handled separately via their respective mappers
@@ -36,16 +36,18 @@ type NodeMapper interface {
 	// hysteria2Configs is a map of nodeID -> Hysteria2Config
 	// tuicConfigs is a map of nodeID -> TUICConfig
 	// anytlsConfigs is a map of nodeID -> AnyTLSConfig
-	ToEntities(models []*models.NodeModel, ssConfigs map[uint]*ShadowsocksConfigData, trojanConfigs map[uint]*vo.TrojanConfig, vlessConfigs map[uint]*vo.VLESSConfig, vmessConfigs map[uint]*vo.VMessConfig, hysteria2Configs map[uint]*vo.Hysteria2Config, tuicConfigs map[uint]*vo.TUICConfig, anytlsConfigs map[uint]*vo.AnyTLSConfig) ([]*node.Node, error)
+	// wireguardConfigs is a map of nodeID -> WireGuardConfig
+	ToEntities(models []*models.NodeModel, ssConfigs map[uint]*ShadowsocksConfigData, trojanConfigs map[uint]*vo.TrojanConfig, vlessConfigs map[uint]*vo.VLESSConfig, vmessConfigs map[uint]*vo.VMessConfig, hysteria2Configs map[uint]*vo.Hysteria2Config, tuicConfigs map[uint]*vo.TUICConfig, anytlsConfigs map[uint]*vo.AnyTLSConfig, wireguardConfigs map[uint]*vo.WireGuardConfig) ([]*node.Node, error)
 
 	// ToModels converts multiple domain entities to persistence models
 	ToModels(entities []*node.Node) ([]*models.NodeModel, error)
 }
 
-// ShadowsocksConfigData holds encryption and plugin config data
+// ShadowsocksConfigData holds encryption, plugin and ShadowTLS config data
 type ShadowsocksConfigData struct {
 	EncryptionConfig vo.EncryptionConfig
 	PluginConfig     *vo.PluginConfig
+	ShadowTLSConfig  *vo.ShadowTLSConfig
 }
 
 // RouteConfigJSON represents the JSON structure for RouteConfig persistence
@@ -139,7 +141,7 @@ func NewNodeMapper() NodeMapper {
 
 // ToEntity converts a persistence model to a domain entity
 // Protocol-specific configs are loaded separately and passed in
-func (m *NodeMapperImpl) ToEntity(model *models.NodeModel, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, trojanConfig *vo.TrojanConfig, vlessConfig *vo.VLESSConfig, vmessConfig *vo.VMessConfig, hysteria2Config *vo.Hysteria2Config, tuicConfig *vo.TUICConfig, anytlsConfig *vo.AnyTLSConfig) (*node.Node, error) {
+func (m *NodeMapperImpl) ToEntity(model *models.NodeModel, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig, trojanConfig *vo.TrojanConfig, vlessConfig *vo.VLESSConfig, vmessConfig *vo.VMessConfig, hysteria2Config *vo.Hysteria2Config, tuicConfig *vo.TUICConfig, anytlsConfig *vo.AnyTLSConfig, wireguardConfig *vo.WireGuardConfig) (*node.Node, error) {
 	if model == nil {
 		return nil, nil
 	}
@@ -179,6 +181,14 @@ func (m *NodeMapperImpl) ToEntity(model *models.NodeModel, encryptionConfig vo.E
 	// Create NodeMetadata value object
 	metadata := vo.NewNodeMetadata(region, tags, "")
 
+	// Parse country code (invalid legacy values are ignored)
+	var countryCode *vo.CountryCode
+	if model.CountryCode != nil {
+		if code, err := vo.NewCountryCode(*model.CountryCode); err == nil {
+			countryCode = &code
+		}
+	}
+
 	// Generate SID if not present (for legacy nodes without sid)
 	sid := model.SID
 	if sid == "" {
@@ -225,12 +235,14 @@ func (m *NodeMapperImpl) ToEntity(model *models.NodeModel, encryptionConfig vo.E
 		protocol,
 		encryptionConfig,
 		pluginConfig,
+		shadowTLSConfig,
 		trojanConfig,
 		vlessConfig,
 		vmessConfig,
 		hysteria2Config,
 		tuicConfig,
 		anytlsConfig,
+		wireguardConfig,
 		nodeStatus,
 		metadata,
 		groupIDs,
@@ -250,6 +262,8 @@ func (m *NodeMapperImpl) ToEntity(model *models.NodeModel, encryptionConfig vo.E
 		model.Arch,
 		model.ExpiresAt,
 		model.CostLabel,
+		countryCode,
+		model.TrafficMultiplier,
 		model.Version,
 		model.CreatedAt,
 		model.UpdatedAt,
@@ -319,6 +333,13 @@ func (m *NodeMapperImpl) ToModel(entity *node.Node) (*models.NodeModel, error) {
 		dnsConfigJSON = dnsBytes
 	}
 
+	// Prepare country code
+	var countryCode *string
+	if entity.CountryCode() != nil {
+		code := entity.CountryCode().String()
+		countryCode = &code
+	}
+
 	model := &models.NodeModel{
 		ID:                entity.ID(),
 		SID:               entity.SID(),
@@ -344,6 +365,8 @@ func (m *NodeMapperImpl) ToModel(entity *node.Node) (*models.NodeModel, error) {
 		Arch:              entity.AgentArch(),
 		ExpiresAt:         entity.ExpiresAt(),
 		CostLabel:         entity.CostLabel(),
+		CountryCode:       countryCode,
+		TrafficMultiplier: entity.TrafficMultiplier(),
 		Version:           entity.Version(),
 		CreatedAt:         entity.CreatedAt(),
 		UpdatedAt:         entity.UpdatedAt(),
@@ -359,19 +382,21 @@ func (m *NodeMapperImpl) ToModel(entity *node.Node) (*models.NodeModel, error) {
 // vmessConfigs is a map of nodeID -> VMessConfig
 // hysteria2Configs is a map of nodeID -> Hysteria2Config
 // tuicConfigs is a map of nodeID -> TUICConfig
-func (m *NodeMapperImpl) ToEntities(nodeModels []*models.NodeModel, ssConfigs map[uint]*ShadowsocksConfigData, trojanConfigs map[uint]*vo.TrojanConfig, vlessConfigs map[uint]*vo.VLESSConfig, vmessConfigs map[uint]*vo.VMessConfig, hysteria2Configs map[uint]*vo.Hysteria2Config, tuicConfigs map[uint]*vo.TUICConfig, anytlsConfigs map[uint]*vo.AnyTLSConfig) ([]*node.Node, error) {
+func (m *NodeMapperImpl) ToEntities(nodeModels []*models.NodeModel, ssConfigs map[uint]*ShadowsocksConfigData, trojanConfigs map[uint]*vo.TrojanConfig, vlessConfigs map[uint]*vo.VLESSConfig, vmessConfigs map[uint]*vo.VMessConfig, hysteria2Configs map[uint]*vo.Hysteria2Config, tuicConfigs map[uint]*vo.TUICConfig, anytlsConfigs map[uint]*vo.AnyTLSConfig, wireguardConfigs map[uint]*vo.WireGuardConfig) ([]*node.Node, error) {
 	entities := make([]*node.Node, 0, len(nodeModels))
 
 	for _, model := range nodeModels {
 		// Get protocol-specific configs for this node
 		var encryptionConfig vo.EncryptionConfig
 		var pluginConfig *vo.PluginConfig
+		var shadowTLSConfig *vo.ShadowTLSConfig
 		var trojanConfig *vo.TrojanConfig
 		var vlessConfig *vo.VLESSConfig
 		var vmessConfig *vo.VMessConfig
 		var hysteria2Config *vo.Hysteria2Config
 		var tuicConfig *vo.TUICConfig
 		var anytlsConfig *vo.AnyTLSConfig
+		var wireguardConfig *vo.WireGuardConfig
 
 		switch model.Protocol {
 		case "shadowsocks":
@@ -379,6 +404,7 @@ func (m *NodeMapperImpl) ToEntities(nodeModels []*models.NodeModel, ssConfigs ma
 				if ssData := ssConfigs[model.ID]; ssData != nil {
 					encryptionConfig = ssData.EncryptionConfig
 					pluginConfig = ssData.PluginConfig
+					shadowTLSConfig = ssData.ShadowTLSConfig
 				}
 			}
 		case "trojan":
@@ -405,9 +431,13 @@ func (m *NodeMapperImpl) ToEntities(nodeModels []*models.NodeModel, ssConfigs ma
 			if anytlsConfigs != nil {
 				anytlsConfig = anytlsConfigs[model.ID]
 			}
+		case "wireguard":
+			if wireguardConfigs != nil {
+				wireguardConfig = wireguardConfigs[model.ID]
+			}
 		}
 
-		entity, err := m.ToEntity(model, encryptionConfig, pluginConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig)
+		entity, err := m.ToEntity(model, encryptionConfig, pluginConfig, shadowTLSConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig, wireguardConfig)
 		if err != nil {
 			return nil, fmt.Errorf("failed to map model ID %d: %w", model.ID, err)
 		}
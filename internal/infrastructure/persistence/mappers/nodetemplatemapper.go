@@ -0,0 +1,118 @@
+package mappers
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/mapper"
+)
+
+// NodeTemplateMapper handles the conversion between node template entities and persistence models.
+type NodeTemplateMapper interface {
+	// ToEntity converts a persistence model to a domain entity.
+	ToEntity(model *models.NodeTemplateModel) (*node.NodeTemplate, error)
+
+	// ToModel converts a domain entity to a persistence model.
+	ToModel(entity *node.NodeTemplate) (*models.NodeTemplateModel, error)
+
+	// ToEntities converts multiple persistence models to domain entities.
+	ToEntities(models []*models.NodeTemplateModel) ([]*node.NodeTemplate, error)
+}
+
+// NodeTemplateMapperImpl is the concrete implementation of NodeTemplateMapper.
+type NodeTemplateMapperImpl struct {
+	snapshots *nodeConfigSnapshotMapper
+}
+
+// NewNodeTemplateMapper creates a new node template mapper.
+func NewNodeTemplateMapper() NodeTemplateMapper {
+	return &NodeTemplateMapperImpl{
+		snapshots: newNodeConfigSnapshotMapper(),
+	}
+}
+
+// ToEntity converts a persistence model to a domain entity.
+func (m *NodeTemplateMapperImpl) ToEntity(model *models.NodeTemplateModel) (*node.NodeTemplate, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	var configJSON NodeConfigSnapshotJSON
+	if err := json.Unmarshal(model.Config, &configJSON); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal config: %w", err)
+	}
+	config, err := m.snapshots.fromJSON(&configJSON)
+	if err != nil {
+		return nil, err
+	}
+
+	var groupIDs []uint
+	if len(model.GroupIDs) > 0 {
+		if err := json.Unmarshal(model.GroupIDs, &groupIDs); err != nil {
+			return nil, fmt.Errorf("failed to unmarshal group_ids: %w", err)
+		}
+	}
+
+	entity, err := node.ReconstructNodeTemplate(
+		model.ID,
+		model.SID,
+		model.Name,
+		model.Description,
+		config,
+		groupIDs,
+		model.CreatedBy,
+		model.CreatedAt,
+		model.UpdatedAt,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to reconstruct node template entity: %w", err)
+	}
+
+	return entity, nil
+}
+
+// ToModel converts a domain entity to a persistence model.
+func (m *NodeTemplateMapperImpl) ToModel(entity *node.NodeTemplate) (*models.NodeTemplateModel, error) {
+	if entity == nil {
+		return nil, nil
+	}
+
+	configJSON, err := m.snapshots.toJSON(entity.Config())
+	if err != nil {
+		return nil, err
+	}
+	config, err := json.Marshal(configJSON)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal config: %w", err)
+	}
+
+	var groupIDs datatypes.JSON
+	if len(entity.GroupIDs()) > 0 {
+		data, err := json.Marshal(entity.GroupIDs())
+		if err != nil {
+			return nil, fmt.Errorf("failed to marshal group_ids: %w", err)
+		}
+		groupIDs = datatypes.JSON(data)
+	}
+
+	return &models.NodeTemplateModel{
+		ID:          entity.ID(),
+		SID:         entity.SID(),
+		Name:        entity.Name(),
+		Description: entity.Description(),
+		Config:      datatypes.JSON(config),
+		GroupIDs:    groupIDs,
+		CreatedBy:   entity.CreatedBy(),
+		CreatedAt:   entity.CreatedAt(),
+		UpdatedAt:   entity.UpdatedAt(),
+	}, nil
+}
+
+// ToEntities converts multiple persistence models to domain entities.
+func (m *NodeTemplateMapperImpl) ToEntities(modelList []*models.NodeTemplateModel) ([]*node.NodeTemplate, error) {
+	return mapper.MapSlicePtrWithID(modelList, m.ToEntity, func(model *models.NodeTemplateModel) uint { return model.ID })
+}
@@ -12,11 +12,11 @@ import (
 
 // ShadowsocksConfigMapper handles the conversion between Shadowsocks config value objects and persistence models
 type ShadowsocksConfigMapper interface {
-	// ToValueObjects converts a persistence model to domain value objects (EncryptionConfig + PluginConfig)
-	ToValueObjects(model *models.ShadowsocksConfigModel) (vo.EncryptionConfig, *vo.PluginConfig, error)
+	// ToValueObjects converts a persistence model to domain value objects (EncryptionConfig + PluginConfig + ShadowTLSConfig)
+	ToValueObjects(model *models.ShadowsocksConfigModel) (vo.EncryptionConfig, *vo.PluginConfig, *vo.ShadowTLSConfig, error)
 
 	// ToModel converts domain value objects to a persistence model
-	ToModel(nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) (*models.ShadowsocksConfigModel, error)
+	ToModel(nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) (*models.ShadowsocksConfigModel, error)
 }
 
 // ShadowsocksConfigMapperImpl is the concrete implementation of ShadowsocksConfigMapper
@@ -28,15 +28,19 @@ func NewShadowsocksConfigMapper() ShadowsocksConfigMapper {
 }
 
 // ToValueObjects converts a persistence model to domain value objects
-func (m *ShadowsocksConfigMapperImpl) ToValueObjects(model *models.ShadowsocksConfigModel) (vo.EncryptionConfig, *vo.PluginConfig, error) {
+func (m *ShadowsocksConfigMapperImpl) ToValueObjects(model *models.ShadowsocksConfigModel) (vo.EncryptionConfig, *vo.PluginConfig, *vo.ShadowTLSConfig, error) {
 	if model == nil {
-		return vo.EncryptionConfig{}, nil, nil
+		return vo.EncryptionConfig{}, nil, nil, nil
 	}
 
 	// Create EncryptionConfig
-	encryptionConfig, err := vo.NewEncryptionConfig(model.EncryptionMethod)
+	serverKey := ""
+	if model.ServerKey != nil {
+		serverKey = *model.ServerKey
+	}
+	encryptionConfig, err := vo.NewEncryptionConfigWithServerKey(model.EncryptionMethod, serverKey)
 	if err != nil {
-		return vo.EncryptionConfig{}, nil, fmt.Errorf("failed to create encryption config: %w", err)
+		return vo.EncryptionConfig{}, nil, nil, fmt.Errorf("failed to create encryption config: %w", err)
 	}
 
 	// Create PluginConfig if present
@@ -45,25 +49,48 @@ func (m *ShadowsocksConfigMapperImpl) ToValueObjects(model *models.ShadowsocksCo
 		var opts map[string]string
 		if model.PluginOpts != nil {
 			if err := json.Unmarshal(model.PluginOpts, &opts); err != nil {
-				return vo.EncryptionConfig{}, nil, fmt.Errorf("failed to unmarshal plugin opts: %w", err)
+				return vo.EncryptionConfig{}, nil, nil, fmt.Errorf("failed to unmarshal plugin opts: %w", err)
 			}
 		}
 		pluginConfig, err = vo.NewPluginConfig(*model.Plugin, opts)
 		if err != nil {
-			return vo.EncryptionConfig{}, nil, fmt.Errorf("failed to create plugin config: %w", err)
+			return vo.EncryptionConfig{}, nil, nil, fmt.Errorf("failed to create plugin config: %w", err)
+		}
+	}
+
+	// Create ShadowTLSConfig if enabled
+	var shadowTLSConfig *vo.ShadowTLSConfig
+	if model.ShadowTLSVersion != nil && model.ShadowTLSPassword != nil && model.ShadowTLSHandshakeServer != nil {
+		var handshakePort uint16
+		if model.ShadowTLSHandshakePort != nil {
+			handshakePort = *model.ShadowTLSHandshakePort
+		}
+		stc, err := vo.NewShadowTLSConfig(
+			int(*model.ShadowTLSVersion),
+			*model.ShadowTLSPassword,
+			*model.ShadowTLSHandshakeServer,
+			handshakePort,
+		)
+		if err != nil {
+			return vo.EncryptionConfig{}, nil, nil, fmt.Errorf("failed to create shadowtls config: %w", err)
 		}
+		shadowTLSConfig = &stc
 	}
 
-	return encryptionConfig, pluginConfig, nil
+	return encryptionConfig, pluginConfig, shadowTLSConfig, nil
 }
 
 // ToModel converts domain value objects to a persistence model
-func (m *ShadowsocksConfigMapperImpl) ToModel(nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) (*models.ShadowsocksConfigModel, error) {
+func (m *ShadowsocksConfigMapperImpl) ToModel(nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) (*models.ShadowsocksConfigModel, error) {
 	model := &models.ShadowsocksConfigModel{
 		NodeID:           nodeID,
 		EncryptionMethod: encryptionConfig.Method(),
 	}
 
+	if serverKey := encryptionConfig.ServerKey(); serverKey != "" {
+		model.ServerKey = &serverKey
+	}
+
 	// Handle plugin config
 	if pluginConfig != nil {
 		pluginName := pluginConfig.Plugin()
@@ -79,5 +106,17 @@ func (m *ShadowsocksConfigMapperImpl) ToModel(nodeID uint, encryptionConfig vo.E
 		}
 	}
 
+	// Handle ShadowTLS config
+	if shadowTLSConfig != nil {
+		version := uint8(shadowTLSConfig.Version())
+		password := shadowTLSConfig.Password()
+		handshakeServer := shadowTLSConfig.HandshakeServer()
+		handshakePort := shadowTLSConfig.HandshakePort()
+		model.ShadowTLSVersion = &version
+		model.ShadowTLSPassword = &password
+		model.ShadowTLSHandshakeServer = &handshakeServer
+		model.ShadowTLSHandshakePort = &handshakePort
+	}
+
 	return model, nil
 }
@@ -47,6 +47,8 @@ func (m *SubscriptionUsageStatsMapperImpl) ToEntity(model *models.SubscriptionUs
 		model.Upload,
 		model.Download,
 		model.Total,
+		model.RawUpload,
+		model.RawDownload,
 		subscription.Granularity(model.Granularity),
 		model.Period,
 		model.CreatedAt,
@@ -74,6 +76,8 @@ func (m *SubscriptionUsageStatsMapperImpl) ToModel(entity *subscription.Subscrip
 		Upload:         entity.Upload(),
 		Download:       entity.Download(),
 		Total:          entity.Total(),
+		RawUpload:      entity.RawUpload(),
+		RawDownload:    entity.RawDownload(),
 		Granularity:    entity.Granularity().String(),
 		Period:         entity.Period(),
 		CreatedAt:      entity.CreatedAt(),
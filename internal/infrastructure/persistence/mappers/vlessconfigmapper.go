@@ -46,11 +46,22 @@ func (m *VLESSConfigMapperImpl) ToValueObject(model *models.VLESSConfigModel) (*
 		model.PublicKey,
 		model.ShortID,
 		model.SpiderX,
+		model.XHTTPMode,
+		model.XHTTPExtra,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create VLESS config value object: %w", err)
 	}
 
+	config = config.WithRealityRotation(vo.ReconstructRealityRotation(
+		model.RealityRotationIntervalHours,
+		model.RealityRotationOverlapHours,
+		model.RealityRotateKeyPair,
+		model.RealityPreviousShortID,
+		model.RealityPreviousShortIDExpiresAt,
+		model.RealityRotatedAt,
+	))
+
 	return &config, nil
 }
 
@@ -60,6 +71,8 @@ func (m *VLESSConfigMapperImpl) ToModel(nodeID uint, config *vo.VLESSConfig) (*m
 		return nil, nil
 	}
 
+	rotation := config.RealityRotation()
+
 	return &models.VLESSConfigModel{
 		NodeID:        nodeID,
 		TransportType: config.TransportType(),
@@ -75,5 +88,14 @@ func (m *VLESSConfigMapperImpl) ToModel(nodeID uint, config *vo.VLESSConfig) (*m
 		PublicKey:     config.PublicKey(),
 		ShortID:       config.ShortID(),
 		SpiderX:       config.SpiderX(),
+		XHTTPMode:     config.XHTTPMode(),
+		XHTTPExtra:    config.XHTTPExtra(),
+
+		RealityRotationIntervalHours:    rotation.IntervalHours(),
+		RealityRotationOverlapHours:     rotation.OverlapHours(),
+		RealityRotateKeyPair:            rotation.RotateKeyPair(),
+		RealityPreviousShortID:          rotation.PreviousShortID(),
+		RealityPreviousShortIDExpiresAt: rotation.PreviousShortIDExpiresAt(),
+		RealityRotatedAt:                rotation.RotatedAt(),
 	}, nil
 }
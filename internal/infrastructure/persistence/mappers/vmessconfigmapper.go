@@ -44,6 +44,8 @@ func (m *VMessConfigMapperImpl) ToValueObject(model *models.VMessConfigModel, uu
 		model.TLS,
 		model.SNI,
 		model.AllowInsecure,
+		model.XHTTPMode,
+		model.XHTTPExtra,
 	)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create vmess config value object: %w", err)
@@ -69,5 +71,7 @@ func (m *VMessConfigMapperImpl) ToModel(nodeID uint, config *vo.VMessConfig) (*m
 		TLS:           config.TLS(),
 		SNI:           config.SNI(),
 		AllowInsecure: config.AllowInsecure(),
+		XHTTPMode:     config.XHTTPMode(),
+		XHTTPExtra:    config.XHTTPExtra(),
 	}, nil
 }
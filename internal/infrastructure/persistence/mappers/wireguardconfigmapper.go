@@ -0,0 +1,62 @@
+package mappers
+
+import (
+	"fmt"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+)
+
+// WireGuardConfigMapper handles the conversion between WireGuardConfig value objects and persistence models
+type WireGuardConfigMapper interface {
+	// ToValueObject converts a persistence model to a domain value object
+	ToValueObject(model *models.WireGuardConfigModel) (*vo.WireGuardConfig, error)
+
+	// ToModel converts a domain value object to a persistence model
+	ToModel(nodeID uint, config *vo.WireGuardConfig) (*models.WireGuardConfigModel, error)
+}
+
+// WireGuardConfigMapperImpl is the concrete implementation of WireGuardConfigMapper
+type WireGuardConfigMapperImpl struct{}
+
+// NewWireGuardConfigMapper creates a new WireGuard config mapper
+func NewWireGuardConfigMapper() WireGuardConfigMapper {
+	return &WireGuardConfigMapperImpl{}
+}
+
+// ToValueObject converts a persistence model to a domain value object
+// Peer keys and addresses are derived per subscription, not stored in DB
+func (m *WireGuardConfigMapperImpl) ToValueObject(model *models.WireGuardConfigModel) (*vo.WireGuardConfig, error) {
+	if model == nil {
+		return nil, nil
+	}
+
+	config, err := vo.NewWireGuardConfig(
+		model.PrivateKey,
+		model.AddressPool,
+		model.MTU,
+		model.DNS,
+		model.PersistentKeepalive,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create WireGuard config value object: %w", err)
+	}
+
+	return &config, nil
+}
+
+// ToModel converts a domain value object to a persistence model
+func (m *WireGuardConfigMapperImpl) ToModel(nodeID uint, config *vo.WireGuardConfig) (*models.WireGuardConfigModel, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	return &models.WireGuardConfigModel{
+		NodeID:              nodeID,
+		PrivateKey:          config.PrivateKey(),
+		AddressPool:         config.AddressPool(),
+		MTU:                 config.MTU(),
+		DNS:                 config.DNS(),
+		PersistentKeepalive: config.PersistentKeepalive(),
+	}, nil
+}
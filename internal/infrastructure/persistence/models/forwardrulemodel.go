@@ -19,7 +19,7 @@ type ForwardRuleModel struct {
 	RuleType            string         `gorm:"not null;default:direct;size:20"`                                     // direct, chain, direct_chain, websocket
 	ExitAgentID         *uint          `gorm:"index:idx_forward_exit_agent_id"`                                     // exit agent ID for chain/websocket forward (nullable)
 	ExitAgents          datatypes.JSON `gorm:"type:json;default:null"`                                              // multiple exit agents with weights for load balancing (JSON array)
-	LoadBalanceStrategy string         `gorm:"column:load_balance_strategy;not null;default:failover;size:32"`      // load balance strategy: failover, weighted
+	LoadBalanceStrategy string         `gorm:"column:load_balance_strategy;not null;default:failover;size:32"`      // load balance strategy: failover, weighted, round_robin, least_connections, lowest_latency
 	ChainAgentIDs       datatypes.JSON `gorm:"type:json;default:null"`                                              // ordered array of intermediate agent IDs for chain forwarding
 	ChainPortConfig     datatypes.JSON `gorm:"type:json;default:null"`                                              // map of agent_id -> listen_port for direct_chain type or hybrid chain direct hops
 	TunnelHops          *int           `gorm:"column:tunnel_hops"`                                                  // number of hops using tunnel (nil=full tunnel, N=first N hops use tunnel)
@@ -41,6 +41,10 @@ type ForwardRuleModel struct {
 	GroupIDs          datatypes.JSON `gorm:"column:group_ids"`                                         // resource group IDs (JSON array)
 	RouteConfig       datatypes.JSON `gorm:"column:route_config"`                                       // per-rule routing configuration (JSON)
 	AddressPreference string         `gorm:"column:address_preference;not null;default:auto;size:10"` // address preference: auto, public, tunnel
+	UploadLimitMbps   uint32         `gorm:"column:upload_limit_mbps;not null;default:0"`             // upload bandwidth limit in Mbps (0 = not set)
+	DownloadLimitMbps uint32         `gorm:"column:download_limit_mbps;not null;default:0"`           // download bandwidth limit in Mbps (0 = not set)
+	MaxConnections    uint32         `gorm:"column:max_connections;not null;default:0"`               // max concurrent connections (0 = not set)
+	ACL               datatypes.JSON `gorm:"column:acl"`                                               // source IP access control list (JSON)
 	// External rule fields (used when RuleType = 'external')
 	ServerAddress  *string `gorm:"column:server_address;size:255;uniqueIndex:idx_listen_port_agent_server"` // server address for external rules
 	ExternalSource *string `gorm:"column:external_source;size:50"`                                          // external source identifier
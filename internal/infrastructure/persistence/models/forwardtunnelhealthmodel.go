@@ -0,0 +1,26 @@
+package models
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// ForwardTunnelHealthModel represents the database persistence model for tunnel health records.
+type ForwardTunnelHealthModel struct {
+	ID           uint      `gorm:"primarykey"`
+	RuleID       uint      `gorm:"not null;index:idx_forward_tunnel_health_rule,priority:1"`
+	EntryAgentID uint      `gorm:"not null"`
+	ExitAgentID  uint      `gorm:"not null"`
+	Healthy      bool      `gorm:"not null"`
+	FailCount    int       `gorm:"not null;default:0"`
+	LatencyMs    *int64    `gorm:"column:latency_ms"`
+	ErrorMessage string    `gorm:"not null;default:'';size:500"`
+	CheckedAt    time.Time `gorm:"not null;index:idx_forward_tunnel_health_rule,priority:2;index:idx_forward_tunnel_health_checked_at"`
+	CreatedAt    time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (ForwardTunnelHealthModel) TableName() string {
+	return constants.TableForwardTunnelHealth
+}
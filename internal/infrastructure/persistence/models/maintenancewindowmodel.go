@@ -0,0 +1,32 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// MaintenanceWindowModel represents the database persistence model for node maintenance windows.
+type MaintenanceWindowModel struct {
+	ID               uint           `gorm:"primarykey"`
+	SID              string         `gorm:"column:sid;not null;size:32;uniqueIndex:idx_maintenance_windows_sid"` // Stripe-style ID: mw_xxxxxxxx
+	Reason           string         `gorm:"not null;size:500"`
+	StartsAt         time.Time      `gorm:"not null"`
+	EndsAt           time.Time      `gorm:"not null"`
+	NodeIDs          datatypes.JSON `gorm:"column:node_ids"`           // explicitly targeted node IDs (JSON array)
+	ResourceGroupIDs datatypes.JSON `gorm:"column:resource_group_ids"` // targeted resource group IDs (JSON array)
+	Status           string         `gorm:"not null;default:scheduled;size:20"`
+	AffectedNodeIDs  datatypes.JSON `gorm:"column:affected_node_ids"` // resolved target nodes (JSON array)
+	EnteredNodeIDs   datatypes.JSON `gorm:"column:entered_node_ids"`  // nodes put into maintenance by the window (JSON array)
+	AnnouncementID   *uint
+	CreatedBy        uint `gorm:"not null;default:0"`
+	CreatedAt        time.Time
+	UpdatedAt        time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (MaintenanceWindowModel) TableName() string {
+	return constants.TableMaintenanceWindows
+}
@@ -0,0 +1,31 @@
+package models
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// NodeCertificateModel represents the database persistence model for node TLS certificates.
+type NodeCertificateModel struct {
+	ID             uint   `gorm:"primarykey"`
+	SID            string `gorm:"column:sid;not null;size:32;uniqueIndex:idx_node_certificates_sid"` // Stripe-style ID: cert_xxxxxxxx
+	Domain         string `gorm:"not null;size:255;uniqueIndex:idx_node_certificates_domain"`
+	DNSProvider    string `gorm:"column:dns_provider;not null;size:50"`
+	AutoRenew      bool   `gorm:"not null;default:true"`
+	Status         string `gorm:"not null;default:pending;size:20"`
+	CertificatePEM string `gorm:"column:certificate_pem;type:mediumtext"` // leaf certificate followed by the issuer chain
+	PrivateKeyPEM  string `gorm:"column:private_key_pem;type:text"`
+	Fingerprint    string `gorm:"not null;default:'';size:64"`
+	NotBefore      *time.Time
+	NotAfter       *time.Time `gorm:"index:idx_node_certificates_not_after"`
+	LastError      string     `gorm:"not null;default:'';size:1000"`
+	LastAttemptAt  *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (NodeCertificateModel) TableName() string {
+	return constants.TableNodeCertificates
+}
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// NodeConfigVersionModel represents the database persistence model for node configuration history.
+type NodeConfigVersionModel struct {
+	ID           uint           `gorm:"primarykey"`
+	NodeID       uint           `gorm:"not null;uniqueIndex:idx_node_config_versions_node_version,priority:1"`
+	Version      int            `gorm:"not null;uniqueIndex:idx_node_config_versions_node_version,priority:2"` // sequence number per node
+	Snapshot     datatypes.JSON `gorm:"not null"`                                                              // protocol, route and DNS configuration (JSON)
+	Changes      datatypes.JSON // changes against the previous version (JSON array)
+	AuthorID     *uint          // user who made the change, NULL for system-recorded versions
+	Source       string         `gorm:"not null;size:20"`
+	RestoredFrom *int           // version restored by a rollback
+	CreatedAt    time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (NodeConfigVersionModel) TableName() string {
+	return constants.TableNodeConfigVersions
+}
@@ -43,6 +43,8 @@ type NodeModel struct {
 	Arch              *string        `gorm:"size:20"`                                      // CPU architecture (amd64, arm64, arm, 386)
 	ExpiresAt         *time.Time     `gorm:"column:expires_at"`                            // expiration time (null = never expires)
 	CostLabel         *string        `gorm:"column:cost_label;size:50"`                    // cost label for display (e.g., "35$/m")
+	CountryCode       *string        `gorm:"column:country_code;size:2"`                   // ISO 3166-1 alpha-2 node location (e.g., "JP")
+	TrafficMultiplier float64        `gorm:"column:traffic_multiplier;not null;default:1"` // billing rate applied to subscription usage
 	Version           int            `gorm:"not null;default:1"`
 	CreatedAt         time.Time
 	UpdatedAt         time.Time
@@ -0,0 +1,27 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/datatypes"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// NodeTemplateModel represents the database persistence model for node templates.
+type NodeTemplateModel struct {
+	ID          uint           `gorm:"primarykey"`
+	SID         string         `gorm:"column:sid;not null;size:32;uniqueIndex:idx_node_templates_sid"` // Stripe-style ID: ntpl_xxxxxxxx
+	Name        string         `gorm:"not null;size:100;uniqueIndex:idx_node_templates_name"`
+	Description string         `gorm:"not null;default:'';size:500"`
+	Config      datatypes.JSON `gorm:"not null"`         // protocol, route and DNS configuration (JSON)
+	GroupIDs    datatypes.JSON `gorm:"column:group_ids"` // resource group IDs (JSON array)
+	CreatedBy   *uint
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (NodeTemplateModel) TableName() string {
+	return constants.TableNodeTemplates
+}
@@ -15,11 +15,17 @@ type ShadowsocksConfigModel struct {
 	ID               uint           `gorm:"primarykey"`
 	NodeID           uint           `gorm:"uniqueIndex;not null"` // Logical foreign key to nodes table
 	EncryptionMethod string         `gorm:"not null;size:50"`     // aes-256-gcm, aes-128-gcm, chacha20-ietf-poly1305
+	ServerKey        *string        `gorm:"size:64"`              // SS2022 server PSK (base64); NULL = derived from node token
 	Plugin           *string        `gorm:"size:100"`             // obfs-local, v2ray-plugin, etc.
 	PluginOpts       datatypes.JSON `gorm:"type:json"`            // Plugin options as JSON
-	CreatedAt        time.Time
-	UpdatedAt        time.Time
-	DeletedAt        gorm.DeletedAt `gorm:"index"`
+	// ShadowTLS layer (all NULL when disabled)
+	ShadowTLSVersion         *uint8  `gorm:"column:shadowtls_version"`
+	ShadowTLSPassword        *string `gorm:"column:shadowtls_password;size:128"`
+	ShadowTLSHandshakeServer *string `gorm:"column:shadowtls_handshake_server;size:255"`
+	ShadowTLSHandshakePort   *uint16 `gorm:"column:shadowtls_handshake_port"`
+	CreatedAt                time.Time
+	UpdatedAt                time.Time
+	DeletedAt                gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName specifies the table name for GORM
@@ -16,9 +16,11 @@ type SubscriptionUsageStatsModel struct {
 	SubscriptionID *uint     `gorm:"index:idx_subscription_period,priority:1"`
 	ResourceType   string    `gorm:"column:resource_type;not null;default:'node';size:50;index:idx_resource_period,priority:1"`
 	ResourceID     uint      `gorm:"column:resource_id;not null;default:0;index:idx_resource_period,priority:2"`
-	Upload         uint64    `gorm:"not null;default:0"` // bytes uploaded
-	Download       uint64    `gorm:"not null;default:0"` // bytes downloaded
-	Total          uint64    `gorm:"not null;default:0"` // total bytes (upload + download)
+	Upload         uint64    `gorm:"not null;default:0"`                     // bytes uploaded
+	Download       uint64    `gorm:"not null;default:0"`                     // bytes downloaded
+	Total          uint64    `gorm:"not null;default:0"`                     // total bytes (upload + download)
+	RawUpload      uint64    `gorm:"column:raw_upload;not null;default:0"`   // bytes uploaded before node traffic multiplier
+	RawDownload    uint64    `gorm:"column:raw_download;not null;default:0"` // bytes downloaded before node traffic multiplier
 	Granularity    string    `gorm:"column:granularity;not null;size:10;index:idx_subscription_period,priority:2;index:idx_resource_period,priority:3;comment:daily or monthly"`
 	Period         time.Time `gorm:"column:period;not null;type:date;index:idx_subscription_period,priority:3;index:idx_resource_period,priority:4;comment:date for daily, first day of month for monthly"`
 	CreatedAt      time.Time
@@ -13,22 +13,33 @@ import (
 type VLESSConfigModel struct {
 	ID            uint   `gorm:"primarykey"`
 	NodeID        uint   `gorm:"uniqueIndex;not null"`          // Logical foreign key to nodes table
-	TransportType string `gorm:"not null;size:10;default:tcp"`  // tcp, ws, grpc, h2
+	TransportType string `gorm:"not null;size:16;default:tcp"`  // tcp, ws, grpc, h2, xhttp, httpupgrade
 	Flow          string `gorm:"size:32"`                       // xtls-rprx-vision or empty
 	Security      string `gorm:"not null;size:16;default:none"` // none, tls, reality
 	SNI           string `gorm:"size:255"`                      // TLS Server Name Indication
 	Fingerprint   string `gorm:"size:64"`                       // TLS fingerprint (chrome, firefox, safari, etc.)
 	AllowInsecure bool   `gorm:"not null;default:false"`        // Allow insecure TLS connection
-	Host          string `gorm:"size:255"`                      // WebSocket/H2 host header
-	Path          string `gorm:"size:255"`                      // WebSocket/H2 path
+	Host          string `gorm:"size:255"`                      // WebSocket/H2/XHTTP/HTTPUpgrade host header
+	Path          string `gorm:"size:255"`                      // WebSocket/H2/XHTTP/HTTPUpgrade path
 	ServiceName   string `gorm:"size:255"`                      // gRPC service name
 	PrivateKey    string `gorm:"size:255"`                      // Reality private key (for server inbound)
 	PublicKey     string `gorm:"size:255"`                      // Reality public key (for client outbound)
 	ShortID       string `gorm:"size:32"`                       // Reality short ID
 	SpiderX       string `gorm:"size:255"`                      // Reality spider X parameter
-	CreatedAt     time.Time
-	UpdatedAt     time.Time
-	DeletedAt     gorm.DeletedAt `gorm:"index"`
+	XHTTPMode     string `gorm:"column:xhttp_mode;size:16"`     // XHTTP mode (auto, packet-up, stream-up, stream-one)
+	XHTTPExtra    string `gorm:"column:xhttp_extra;type:text"`  // XHTTP extra options (JSON object)
+
+	// Reality rotation policy and state
+	RealityRotationIntervalHours    int        `gorm:"not null;default:0"`          // Scheduled rotation interval, 0 = disabled
+	RealityRotationOverlapHours     int        `gorm:"not null;default:0"`          // Hours the previous short ID stays accepted
+	RealityRotateKeyPair            bool       `gorm:"not null;default:false"`      // Scheduled rotations also replace the key pair
+	RealityPreviousShortID          string     `gorm:"not null;size:32;default:''"` // Short ID replaced by the last rotation
+	RealityPreviousShortIDExpiresAt *time.Time // End of the previous short ID overlap window
+	RealityRotatedAt                *time.Time // Last Reality rotation time
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+	DeletedAt gorm.DeletedAt `gorm:"index"`
 }
 
 // TableName specifies the table name for GORM
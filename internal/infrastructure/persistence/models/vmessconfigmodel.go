@@ -15,13 +15,15 @@ type VMessConfigModel struct {
 	NodeID        uint   `gorm:"uniqueIndex;not null"`          // Logical foreign key to nodes table
 	AlterID       int    `gorm:"not null;default:0"`            // Alter ID (usually 0 for modern clients)
 	Security      string `gorm:"not null;size:32;default:auto"` // auto, aes-128-gcm, chacha20-poly1305, none, zero
-	TransportType string `gorm:"not null;size:10;default:tcp"`  // tcp, ws, grpc, http, quic
-	Host          string `gorm:"size:255"`                      // WebSocket/HTTP host header
-	Path          string `gorm:"size:255"`                      // WebSocket/HTTP path
+	TransportType string `gorm:"not null;size:16;default:tcp"`  // tcp, ws, grpc, http, quic, xhttp, httpupgrade
+	Host          string `gorm:"size:255"`                      // WebSocket/HTTP/XHTTP/HTTPUpgrade host header
+	Path          string `gorm:"size:255"`                      // WebSocket/HTTP/XHTTP/HTTPUpgrade path
 	ServiceName   string `gorm:"size:255"`                      // gRPC service name
 	TLS           bool   `gorm:"not null;default:false"`        // Enable TLS
 	SNI           string `gorm:"size:255"`                      // TLS Server Name Indication
 	AllowInsecure bool   `gorm:"not null;default:true"`         // Allow insecure TLS connection
+	XHTTPMode     string `gorm:"column:xhttp_mode;size:16"`     // XHTTP mode (auto, packet-up, stream-up, stream-one)
+	XHTTPExtra    string `gorm:"column:xhttp_extra;type:text"`  // XHTTP extra options (JSON object)
 	CreatedAt     time.Time
 	UpdatedAt     time.Time
 	DeletedAt     gorm.DeletedAt `gorm:"index"`
@@ -0,0 +1,29 @@
+package models
+
+import (
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// WireGuardConfigModel represents the database persistence model for WireGuard protocol configuration
+// Separated from NodeModel to follow protocol-specific table pattern
+type WireGuardConfigModel struct {
+	ID                  uint   `gorm:"primarykey"`
+	NodeID              uint   `gorm:"uniqueIndex;not null"`                         // Logical foreign key to nodes table
+	PrivateKey          string `gorm:"not null;size:64"`                             // Server private key (base64)
+	AddressPool         string `gorm:"not null;size:43;default:10.66.0.0/16"`        // Tunnel address pool CIDR
+	MTU                 int    `gorm:"column:mtu;not null;default:1420"`             // Tunnel MTU
+	DNS                 string `gorm:"column:dns;not null;size:255;default:1.1.1.1"` // Comma-separated DNS servers
+	PersistentKeepalive int    `gorm:"not null;default:25"`                          // Keepalive interval in seconds (0 = disabled)
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
+	DeletedAt           gorm.DeletedAt `gorm:"index"`
+}
+
+// TableName specifies the table name for GORM
+func (WireGuardConfigModel) TableName() string {
+	return constants.TableNodeWireGuardConfigs
+}
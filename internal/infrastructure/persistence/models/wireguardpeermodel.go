@@ -0,0 +1,22 @@
+package models
+
+import (
+	"time"
+
+	"github.com/orris-inc/orris/internal/shared/constants"
+)
+
+// WireGuardPeerModel represents the tunnel address and keypair of a subscription on a WireGuard node.
+type WireGuardPeerModel struct {
+	ID             uint   `gorm:"primarykey"`
+	NodeID         uint   `gorm:"not null;uniqueIndex:idx_node_wireguard_peers_subscription,priority:1;uniqueIndex:idx_node_wireguard_peers_address,priority:1"`
+	SubscriptionID uint   `gorm:"not null;uniqueIndex:idx_node_wireguard_peers_subscription,priority:2"`
+	Address        string `gorm:"not null;size:15;uniqueIndex:idx_node_wireguard_peers_address,priority:2"` // IPv4 tunnel address without prefix length
+	PrivateKey     string `gorm:"not null;size:64"`                                                         // Peer private key (base64), the public key is computed from it
+	CreatedAt      time.Time
+}
+
+// TableName specifies the table name for GORM.
+func (WireGuardPeerModel) TableName() string {
+	return constants.TableNodeWireGuardPeers
+}
@@ -3,8 +3,10 @@ package nodeutil
 
 import (
 	"encoding/json"
+	"strings"
 
 	"github.com/orris-inc/orris/internal/application/node/usecases"
+	domainNode "github.com/orris-inc/orris/internal/domain/node"
 	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
 	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
 )
@@ -12,13 +14,17 @@ import (
 // NodeSource contains the essential data needed to build a subscription node.
 // This abstraction allows building nodes from both NodeModel and ForwardRule sources.
 type NodeSource struct {
-	ID        uint
-	Name      string
-	Address   string
-	Port      uint16
-	Protocol  string
-	TokenHash string
-	SortOrder int
+	ID          uint
+	Name        string
+	Address     string
+	Port        uint16
+	Protocol    string
+	TokenHash   string
+	CountryCode string
+	SortOrder   int
+	// TrafficMultiplier is the node billing rate shown in the subscription name
+	// (nil = billed 1:1, or traffic is billed by a forward rule instead)
+	TrafficMultiplier *float64
 }
 
 // ProtocolConfigs holds loaded protocol configuration maps.
@@ -30,6 +36,7 @@ type ProtocolConfigs struct {
 	Hysteria2   map[uint]*models.Hysteria2ConfigModel
 	TUIC        map[uint]*models.TUICConfigModel
 	AnyTLS      map[uint]*models.AnyTLSConfigModel
+	WireGuard   map[uint]*models.WireGuardConfigModel
 }
 
 // NewProtocolConfigs creates an empty ProtocolConfigs instance.
@@ -42,6 +49,7 @@ func NewProtocolConfigs() ProtocolConfigs {
 		Hysteria2:   make(map[uint]*models.Hysteria2ConfigModel),
 		TUIC:        make(map[uint]*models.TUICConfigModel),
 		AnyTLS:      make(map[uint]*models.AnyTLSConfigModel),
+		WireGuard:   make(map[uint]*models.WireGuardConfigModel),
 	}
 }
 
@@ -50,14 +58,16 @@ func BuildNode(source NodeSource, configs ProtocolConfigs) *usecases.Node {
 	protocol := normalizeProtocol(source.Protocol)
 
 	node := &usecases.Node{
-		ID:               source.ID,
-		Name:             source.Name,
-		ServerAddress:    source.Address,
-		SubscriptionPort: source.Port,
-		Protocol:         protocol,
-		TokenHash:        source.TokenHash,
-		Password:         "",
-		SortOrder:        source.SortOrder,
+		ID:                source.ID,
+		Name:              source.Name,
+		ServerAddress:     source.Address,
+		SubscriptionPort:  source.Port,
+		Protocol:          protocol,
+		TokenHash:         source.TokenHash,
+		Password:          "",
+		CountryCode:       source.CountryCode,
+		TrafficMultiplier: source.TrafficMultiplier,
+		SortOrder:         source.SortOrder,
 	}
 
 	ApplyProtocolConfig(node, protocol, source.ID, configs)
@@ -89,6 +99,8 @@ func ApplyProtocolConfig(node *usecases.Node, protocol string, nodeID uint, conf
 		applyTUICConfig(node, nodeID, configs.TUIC)
 	case "anytls":
 		applyAnyTLSConfig(node, nodeID, configs.AnyTLS)
+	case "wireguard":
+		applyWireGuardConfig(node, nodeID, configs.WireGuard)
 	}
 }
 
@@ -100,12 +112,20 @@ func applyShadowsocksConfig(node *usecases.Node, nodeID uint, configs map[uint]*
 	}
 
 	node.EncryptionMethod = sc.EncryptionMethod
+	if sc.ServerKey != nil {
+		node.ServerKey = *sc.ServerKey
+	}
 	if sc.Plugin != nil {
 		node.Plugin = *sc.Plugin
 	}
 	if len(sc.PluginOpts) > 0 {
 		node.PluginOpts = parsePluginOpts(sc.PluginOpts)
 	}
+
+	mapper := mappers.NewShadowsocksConfigMapper()
+	if _, _, shadowTLSConfig, err := mapper.ToValueObjects(sc); err == nil {
+		node.ShadowTLSConfig = shadowTLSConfig
+	}
 }
 
 // parsePluginOpts converts JSON plugin options to a string map.
@@ -217,6 +237,22 @@ func applyAnyTLSConfig(node *usecases.Node, nodeID uint, configs map[uint]*model
 	node.AnyTLSConfig = config
 }
 
+// applyWireGuardConfig applies WireGuard-specific configuration to a node.
+func applyWireGuardConfig(node *usecases.Node, nodeID uint, configs map[uint]*models.WireGuardConfigModel) {
+	wc, ok := configs[nodeID]
+	if !ok {
+		return
+	}
+
+	mapper := mappers.NewWireGuardConfigMapper()
+	// Peer keys and addresses are derived per subscription during formatting
+	config, err := mapper.ToValueObject(wc)
+	if err != nil {
+		return
+	}
+	node.WireGuardConfig = config
+}
+
 // ResolveServerAddress returns the effective server address for subscription.
 // If server address is configured, use it; otherwise fall back to agent's reported public IP.
 func ResolveServerAddress(configuredAddr string, publicIPv4, publicIPv6 *string) string {
@@ -244,22 +280,43 @@ func NodeModelToSource(nm *models.NodeModel) NodeSource {
 	}
 
 	return NodeSource{
-		ID:        nm.ID,
-		Name:      nm.Name,
-		Address:   ResolveServerAddress(nm.ServerAddress, nm.PublicIPv4, nm.PublicIPv6),
-		Port:      port,
-		Protocol:  nm.Protocol,
-		TokenHash: nm.TokenHash,
-		SortOrder: nm.SortOrder,
+		ID:                nm.ID,
+		Name:              nm.Name,
+		Address:           ResolveServerAddress(nm.ServerAddress, nm.PublicIPv4, nm.PublicIPv6),
+		Port:              port,
+		Protocol:          nm.Protocol,
+		TokenHash:         nm.TokenHash,
+		CountryCode:       ModelCountryCode(nm),
+		SortOrder:         nm.SortOrder,
+		TrafficMultiplier: ModelTrafficMultiplier(nm),
+	}
+}
+
+// ModelTrafficMultiplier returns the node model's billing rate, or nil when usage is billed 1:1.
+func ModelTrafficMultiplier(nm *models.NodeModel) *float64 {
+	if nm.TrafficMultiplier == domainNode.DefaultTrafficMultiplier {
+		return nil
+	}
+	multiplier := nm.TrafficMultiplier
+	return &multiplier
+}
+
+// ModelCountryCode returns the node model's country code in upper case ("" if unset).
+func ModelCountryCode(nm *models.NodeModel) string {
+	if nm.CountryCode == nil {
+		return ""
 	}
+	return strings.ToUpper(*nm.CountryCode)
 }
 
 // CopyProtocolFieldsFromNode copies protocol-related fields from one usecases.Node to another.
 // This is used when building forwarded nodes that inherit protocol config from the original node.
 func CopyProtocolFieldsFromNode(dst, src *usecases.Node) {
 	dst.EncryptionMethod = src.EncryptionMethod
+	dst.ServerKey = src.ServerKey
 	dst.Plugin = src.Plugin
 	dst.PluginOpts = src.PluginOpts
+	dst.ShadowTLSConfig = src.ShadowTLSConfig
 	dst.TransportProtocol = src.TransportProtocol
 	dst.Host = src.Host
 	dst.Path = src.Path
@@ -271,4 +328,5 @@ func CopyProtocolFieldsFromNode(dst, src *usecases.Node) {
 	dst.Hysteria2Config = src.Hysteria2Config
 	dst.TUICConfig = src.TUICConfig
 	dst.AnyTLSConfig = src.AnyTLSConfig
+	dst.WireGuardConfig = src.WireGuardConfig
 }
@@ -38,6 +38,7 @@ func (l *ConfigLoader) LoadProtocolConfigs(ctx context.Context, nodeModels []mod
 	l.loadConfigsIntoMap(ctx, nodeIDsByProtocol["hysteria2"], &configs.Hysteria2)
 	l.loadConfigsIntoMap(ctx, nodeIDsByProtocol["tuic"], &configs.TUIC)
 	l.loadConfigsIntoMap(ctx, nodeIDsByProtocol["anytls"], &configs.AnyTLS)
+	l.loadConfigsIntoMap(ctx, nodeIDsByProtocol["wireguard"], &configs.WireGuard)
 
 	return configs
 }
@@ -78,6 +79,8 @@ func (l *ConfigLoader) loadConfigsIntoMap(ctx context.Context, nodeIDs []uint, t
 		l.loadTUICConfigs(ctx, nodeIDs, m)
 	case *map[uint]*models.AnyTLSConfigModel:
 		l.loadAnyTLSConfigs(ctx, nodeIDs, m)
+	case *map[uint]*models.WireGuardConfigModel:
+		l.loadWireGuardConfigs(ctx, nodeIDs, m)
 	}
 }
 
@@ -178,3 +181,17 @@ func (l *ConfigLoader) loadAnyTLSConfigs(ctx context.Context, nodeIDs []uint, ta
 		(*targetMap)[configs[i].NodeID] = &configs[i]
 	}
 }
+
+// loadWireGuardConfigs loads WireGuard configs into the provided map.
+func (l *ConfigLoader) loadWireGuardConfigs(ctx context.Context, nodeIDs []uint, targetMap *map[uint]*models.WireGuardConfigModel) {
+	var configs []models.WireGuardConfigModel
+	if err := l.db.WithContext(ctx).
+		Where("node_id IN ?", nodeIDs).
+		Find(&configs).Error; err != nil {
+		l.logger.Warnw("failed to query WireGuard configs", "error", err)
+		return
+	}
+	for i := range configs {
+		(*targetMap)[configs[i].NodeID] = &configs[i]
+	}
+}
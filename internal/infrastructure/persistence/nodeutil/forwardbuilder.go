@@ -48,13 +48,14 @@ func (b *ForwardedNodeBuilder) BuildFromNodeModel(rule *forward.ForwardRule, tar
 	}
 
 	source := NodeSource{
-		ID:        targetNode.ID,
-		Name:      rule.Name(),
-		Address:   serverAddress,
-		Port:      rule.ListenPort(),
-		Protocol:  targetNode.Protocol,
-		TokenHash: targetNode.TokenHash,
-		SortOrder: rule.SortOrder(),
+		ID:          targetNode.ID,
+		Name:        rule.Name(),
+		Address:     serverAddress,
+		Port:        rule.ListenPort(),
+		Protocol:    targetNode.Protocol,
+		TokenHash:   targetNode.TokenHash,
+		CountryCode: ModelCountryCode(targetNode),
+		SortOrder:   rule.SortOrder(),
 	}
 
 	return BuildNode(source, b.configs)
@@ -80,6 +81,7 @@ func (b *ForwardedNodeBuilder) BuildFromUsecaseNode(rule *forward.ForwardRule, o
 		Protocol:         originalNode.Protocol,
 		TokenHash:        originalNode.TokenHash,
 		Password:         originalNode.Password,
+		CountryCode:      originalNode.CountryCode, // traffic exits at the original node
 		SortOrder:        rule.SortOrder(),
 	}
 
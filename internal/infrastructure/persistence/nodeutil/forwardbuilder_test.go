@@ -573,6 +573,8 @@ func mustCreateDirectRule(t *testing.T, agentID uint, name string, listenPort ui
 		nil,                           // groupIDs
 		nil,                           // routeConfig
 		vo.AddressPreferenceAuto,      // addressPreference
+		vo.RuleLimits{},               // limits
+		nil,                           // acl
 		"",                            // serverAddress
 		"",                            // externalSource
 		"",                            // externalRuleID
@@ -618,6 +620,8 @@ func mustCreateDirectRuleWithTargetNode(t *testing.T, agentID uint, name string,
 		nil,                           // groupIDs
 		nil,                           // routeConfig
 		vo.AddressPreferenceAuto,      // addressPreference
+		vo.RuleLimits{},               // limits
+		nil,                           // acl
 		"",                            // serverAddress
 		"",                            // externalSource
 		"",                            // externalRuleID
@@ -663,6 +667,8 @@ func mustCreateExternalRule(t *testing.T, serverAddr string, listenPort uint16,
 		nil,                           // groupIDs
 		nil,                           // routeConfig
 		vo.AddressPreferenceAuto,      // addressPreference
+		vo.RuleLimits{},               // limits
+		nil,                           // acl
 		serverAddr,                    // serverAddress
 		externalSource,                // externalSource
 		"",                            // externalRuleID
@@ -2,11 +2,14 @@ package repository
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"strconv"
 	"strings"
 
 	"gorm.io/gorm"
 
+	"github.com/orris-inc/orris/internal/domain/forward"
 	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
 	"github.com/orris-inc/orris/internal/shared/db"
 	"github.com/orris-inc/orris/internal/shared/errors"
@@ -82,6 +85,57 @@ func (r *ForwardRuleRepositoryImpl) IsPortInUseByAgent(ctx context.Context, agen
 	return count > 0, nil
 }
 
+// ListPortUsageByAgent returns the ports the specified agent listens on across all rules.
+// This includes both main rule ports and chain_port_config entries.
+func (r *ForwardRuleRepositoryImpl) ListPortUsageByAgent(ctx context.Context, agentID uint) ([]forward.AgentPortUsage, error) {
+	var rows []models.ForwardRuleModel
+	tx := db.GetTxFromContext(ctx, r.db)
+	err := tx.Model(&models.ForwardRuleModel{}).
+		Scopes(db.NotDeleted()).
+		Select("id, sid, name, agent_id, listen_port, chain_port_config").
+		Where(
+			"agent_id = ? OR (chain_port_config IS NOT NULL AND JSON_EXTRACT(chain_port_config, CONCAT('$.\"', ?, '\"')) IS NOT NULL)",
+			agentID, agentID,
+		).
+		Order("id ASC").
+		Find(&rows).Error
+	if err != nil {
+		r.logger.Errorw("failed to list port usage by agent", "agent_id", agentID, "error", err)
+		return nil, fmt.Errorf("failed to list port usage: %w", err)
+	}
+
+	agentKey := strconv.FormatUint(uint64(agentID), 10)
+	usages := make([]forward.AgentPortUsage, 0, len(rows))
+	for _, row := range rows {
+		if row.AgentID == agentID && row.ListenPort > 0 {
+			usages = append(usages, forward.AgentPortUsage{
+				Port:     row.ListenPort,
+				RuleID:   row.ID,
+				RuleSID:  row.SID,
+				RuleName: row.Name,
+			})
+		}
+		if len(row.ChainPortConfig) == 0 {
+			continue
+		}
+		var chainPortConfig map[string]uint16
+		if err := json.Unmarshal(row.ChainPortConfig, &chainPortConfig); err != nil {
+			r.logger.Warnw("failed to unmarshal chain port config", "rule_id", row.ID, "error", err)
+			continue
+		}
+		if port, ok := chainPortConfig[agentKey]; ok && port > 0 {
+			usages = append(usages, forward.AgentPortUsage{
+				Port:     port,
+				RuleID:   row.ID,
+				RuleSID:  row.SID,
+				RuleName: row.Name,
+				ChainHop: true,
+			})
+		}
+	}
+	return usages, nil
+}
+
 // UpdateTraffic updates the traffic counters for a rule.
 func (r *ForwardRuleRepositoryImpl) UpdateTraffic(ctx context.Context, id uint, upload, download int64) error {
 	tx := db.GetTxFromContext(ctx, r.db)
@@ -0,0 +1,102 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// ForwardTunnelHealthRepositoryImpl implements the forward.TunnelHealthRepository interface.
+type ForwardTunnelHealthRepositoryImpl struct {
+	db     *gorm.DB
+	mapper mappers.ForwardTunnelHealthMapper
+	logger logger.Interface
+}
+
+// NewForwardTunnelHealthRepository creates a new tunnel health repository instance.
+func NewForwardTunnelHealthRepository(db *gorm.DB, logger logger.Interface) forward.TunnelHealthRepository {
+	return &ForwardTunnelHealthRepositoryImpl{
+		db:     db,
+		mapper: mappers.NewForwardTunnelHealthMapper(),
+		logger: logger,
+	}
+}
+
+// Create stores a tunnel health record.
+func (r *ForwardTunnelHealthRepositoryImpl) Create(ctx context.Context, record *forward.TunnelHealthRecord) error {
+	model := r.mapper.ToModel(record)
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		r.logger.Errorw("failed to create tunnel health record", "rule_id", record.RuleID(), "error", err)
+		return fmt.Errorf("failed to create tunnel health record: %w", err)
+	}
+
+	if err := record.SetID(model.ID); err != nil {
+		r.logger.Errorw("failed to set tunnel health record ID", "error", err)
+		return fmt.Errorf("failed to set tunnel health record ID: %w", err)
+	}
+
+	return nil
+}
+
+// List retrieves the tunnel health records of a rule, newest first.
+func (r *ForwardTunnelHealthRepositoryImpl) List(ctx context.Context, filter forward.TunnelHealthFilter) ([]*forward.TunnelHealthRecord, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.ForwardTunnelHealthModel{}).
+		Where("rule_id = ?", filter.RuleID)
+
+	if filter.ExitAgentID != 0 {
+		query = query.Where("exit_agent_id = ?", filter.ExitAgentID)
+	}
+	if filter.From != nil {
+		query = query.Where("checked_at >= ?", *filter.From)
+	}
+	if filter.To != nil {
+		query = query.Where("checked_at <= ?", *filter.To)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Errorw("failed to count tunnel health records", "rule_id", filter.RuleID, "error", err)
+		return nil, 0, fmt.Errorf("failed to count tunnel health records: %w", err)
+	}
+
+	query = query.Order("checked_at DESC").Order("id DESC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var modelList []*models.ForwardTunnelHealthModel
+	if err := query.Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list tunnel health records", "rule_id", filter.RuleID, "error", err)
+		return nil, 0, fmt.Errorf("failed to list tunnel health records: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map tunnel health models to entities", "error", err)
+		return nil, 0, fmt.Errorf("failed to map tunnel health records: %w", err)
+	}
+
+	return entities, total, nil
+}
+
+// DeleteBefore removes tunnel health records checked before the cutoff.
+func (r *ForwardTunnelHealthRepositoryImpl) DeleteBefore(ctx context.Context, cutoff time.Time) (int64, error) {
+	result := r.db.WithContext(ctx).
+		Where("checked_at < ?", cutoff).
+		Delete(&models.ForwardTunnelHealthModel{})
+	if result.Error != nil {
+		r.logger.Errorw("failed to delete tunnel health records", "cutoff", cutoff, "error", result.Error)
+		return 0, fmt.Errorf("failed to delete tunnel health records: %w", result.Error)
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// MaintenanceWindowRepositoryImpl implements the node.MaintenanceWindowRepository interface.
+type MaintenanceWindowRepositoryImpl struct {
+	db     *gorm.DB
+	mapper mappers.MaintenanceWindowMapper
+	logger logger.Interface
+}
+
+// NewMaintenanceWindowRepository creates a new maintenance window repository instance.
+func NewMaintenanceWindowRepository(db *gorm.DB, logger logger.Interface) node.MaintenanceWindowRepository {
+	return &MaintenanceWindowRepositoryImpl{
+		db:     db,
+		mapper: mappers.NewMaintenanceWindowMapper(),
+		logger: logger,
+	}
+}
+
+// Create creates a new maintenance window in the database.
+func (r *MaintenanceWindowRepositoryImpl) Create(ctx context.Context, window *node.MaintenanceWindow) error {
+	model, err := r.mapper.ToModel(window)
+	if err != nil {
+		r.logger.Errorw("failed to map maintenance window entity to model", "error", err)
+		return fmt.Errorf("failed to map maintenance window entity: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		r.logger.Errorw("failed to create maintenance window in database", "error", err)
+		return fmt.Errorf("failed to create maintenance window: %w", err)
+	}
+
+	if err := window.SetID(model.ID); err != nil {
+		r.logger.Errorw("failed to set maintenance window ID", "error", err)
+		return fmt.Errorf("failed to set maintenance window ID: %w", err)
+	}
+
+	return nil
+}
+
+// Update persists the status, resolved nodes and announcement of a maintenance window.
+func (r *MaintenanceWindowRepositoryImpl) Update(ctx context.Context, window *node.MaintenanceWindow) error {
+	model, err := r.mapper.ToModel(window)
+	if err != nil {
+		r.logger.Errorw("failed to map maintenance window entity to model", "error", err)
+		return fmt.Errorf("failed to map maintenance window entity: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.MaintenanceWindowModel{}).
+		Where("id = ?", model.ID).
+		Updates(map[string]any{
+			"status":            model.Status,
+			"affected_node_ids": model.AffectedNodeIDs,
+			"entered_node_ids":  model.EnteredNodeIDs,
+			"announcement_id":   model.AnnouncementID,
+			"updated_at":        model.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		r.logger.Errorw("failed to update maintenance window", "id", model.ID, "error", result.Error)
+		return fmt.Errorf("failed to update maintenance window: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("maintenance window", fmt.Sprintf("%d", model.ID))
+	}
+
+	return nil
+}
+
+// GetBySID retrieves a maintenance window by its Stripe-style ID.
+func (r *MaintenanceWindowRepositoryImpl) GetBySID(ctx context.Context, sid string) (*node.MaintenanceWindow, error) {
+	var model models.MaintenanceWindowModel
+
+	if err := r.db.WithContext(ctx).Where("sid = ?", sid).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get maintenance window by SID", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to get maintenance window: %w", err)
+	}
+
+	entity, err := r.mapper.ToEntity(&model)
+	if err != nil {
+		r.logger.Errorw("failed to map maintenance window model to entity", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to map maintenance window: %w", err)
+	}
+
+	return entity, nil
+}
+
+// List retrieves maintenance windows with optional filters, newest start time first.
+func (r *MaintenanceWindowRepositoryImpl) List(ctx context.Context, filter node.MaintenanceWindowFilter) ([]*node.MaintenanceWindow, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.MaintenanceWindowModel{})
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Errorw("failed to count maintenance windows", "error", err)
+		return nil, 0, fmt.Errorf("failed to count maintenance windows: %w", err)
+	}
+
+	query = query.Order("starts_at DESC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var modelList []*models.MaintenanceWindowModel
+	if err := query.Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list maintenance windows", "error", err)
+		return nil, 0, fmt.Errorf("failed to list maintenance windows: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map maintenance window models to entities", "error", err)
+		return nil, 0, fmt.Errorf("failed to map maintenance windows: %w", err)
+	}
+
+	return entities, total, nil
+}
+
+// ListUnfinished returns all scheduled and active maintenance windows.
+func (r *MaintenanceWindowRepositoryImpl) ListUnfinished(ctx context.Context) ([]*node.MaintenanceWindow, error) {
+	return r.findByStatuses(ctx, node.MaintenanceWindowStatusScheduled, node.MaintenanceWindowStatusActive)
+}
+
+// ListActive returns maintenance windows that are currently in progress.
+func (r *MaintenanceWindowRepositoryImpl) ListActive(ctx context.Context) ([]*node.MaintenanceWindow, error) {
+	return r.findByStatuses(ctx, node.MaintenanceWindowStatusActive)
+}
+
+func (r *MaintenanceWindowRepositoryImpl) findByStatuses(ctx context.Context, statuses ...node.MaintenanceWindowStatus) ([]*node.MaintenanceWindow, error) {
+	values := make([]string, len(statuses))
+	for i, s := range statuses {
+		values[i] = s.String()
+	}
+
+	var modelList []*models.MaintenanceWindowModel
+	if err := r.db.WithContext(ctx).
+		Where("status IN ?", values).
+		Order("starts_at ASC").
+		Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to find maintenance windows by status", "statuses", values, "error", err)
+		return nil, fmt.Errorf("failed to find maintenance windows: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map maintenance window models to entities", "error", err)
+		return nil, fmt.Errorf("failed to map maintenance windows: %w", err)
+	}
+
+	return entities, nil
+}
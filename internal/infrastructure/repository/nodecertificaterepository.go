@@ -0,0 +1,230 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// NodeCertificateRepositoryImpl implements the node.CertificateRepository interface.
+type NodeCertificateRepositoryImpl struct {
+	db     *gorm.DB
+	mapper mappers.NodeCertificateMapper
+	logger logger.Interface
+}
+
+// NewNodeCertificateRepository creates a new node certificate repository instance.
+func NewNodeCertificateRepository(db *gorm.DB, logger logger.Interface) node.CertificateRepository {
+	return &NodeCertificateRepositoryImpl{
+		db:     db,
+		mapper: mappers.NewNodeCertificateMapper(),
+		logger: logger,
+	}
+}
+
+// Create creates a new certificate in the database.
+func (r *NodeCertificateRepositoryImpl) Create(ctx context.Context, cert *node.Certificate) error {
+	model, err := r.mapper.ToModel(cert)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate entity to model", "error", err)
+		return fmt.Errorf("failed to map certificate entity: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		if errors.IsDuplicateError(err) {
+			return errors.NewConflictError("certificate already exists for domain", cert.Domain())
+		}
+		r.logger.Errorw("failed to create certificate in database", "domain", cert.Domain(), "error", err)
+		return fmt.Errorf("failed to create certificate: %w", err)
+	}
+
+	if err := cert.SetID(model.ID); err != nil {
+		r.logger.Errorw("failed to set certificate ID", "error", err)
+		return fmt.Errorf("failed to set certificate ID: %w", err)
+	}
+
+	return nil
+}
+
+// Update persists the issuance state and material of a certificate.
+func (r *NodeCertificateRepositoryImpl) Update(ctx context.Context, cert *node.Certificate) error {
+	model, err := r.mapper.ToModel(cert)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate entity to model", "error", err)
+		return fmt.Errorf("failed to map certificate entity: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.NodeCertificateModel{}).
+		Where("id = ?", model.ID).
+		Updates(map[string]any{
+			"auto_renew":      model.AutoRenew,
+			"status":          model.Status,
+			"certificate_pem": model.CertificatePEM,
+			"private_key_pem": model.PrivateKeyPEM,
+			"fingerprint":     model.Fingerprint,
+			"not_before":      model.NotBefore,
+			"not_after":       model.NotAfter,
+			"last_error":      model.LastError,
+			"last_attempt_at": model.LastAttemptAt,
+			"updated_at":      model.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		r.logger.Errorw("failed to update certificate", "id", model.ID, "error", result.Error)
+		return fmt.Errorf("failed to update certificate: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("certificate", fmt.Sprintf("%d", model.ID))
+	}
+
+	return nil
+}
+
+// Delete removes a certificate.
+func (r *NodeCertificateRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.NodeCertificateModel{}, id)
+	if result.Error != nil {
+		r.logger.Errorw("failed to delete certificate", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete certificate: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("certificate", fmt.Sprintf("%d", id))
+	}
+
+	return nil
+}
+
+// GetBySID retrieves a certificate by its Stripe-style ID.
+func (r *NodeCertificateRepositoryImpl) GetBySID(ctx context.Context, sid string) (*node.Certificate, error) {
+	return r.findOne(ctx, "sid = ?", sid)
+}
+
+// GetByDomain retrieves a certificate by its domain.
+func (r *NodeCertificateRepositoryImpl) GetByDomain(ctx context.Context, domain string) (*node.Certificate, error) {
+	return r.findOne(ctx, "domain = ?", domain)
+}
+
+// List retrieves certificates with optional filters, ordered by domain.
+func (r *NodeCertificateRepositoryImpl) List(ctx context.Context, filter node.CertificateFilter) ([]*node.Certificate, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.NodeCertificateModel{})
+
+	if filter.Status != nil {
+		query = query.Where("status = ?", filter.Status.String())
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Errorw("failed to count certificates", "error", err)
+		return nil, 0, fmt.Errorf("failed to count certificates: %w", err)
+	}
+
+	query = query.Order("domain ASC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var modelList []*models.NodeCertificateModel
+	if err := query.Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list certificates", "error", err)
+		return nil, 0, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate models to entities", "error", err)
+		return nil, 0, fmt.Errorf("failed to map certificates: %w", err)
+	}
+
+	return entities, total, nil
+}
+
+// ListAll returns every certificate ordered by domain.
+func (r *NodeCertificateRepositoryImpl) ListAll(ctx context.Context) ([]*node.Certificate, error) {
+	var modelList []*models.NodeCertificateModel
+	if err := r.db.WithContext(ctx).Order("domain ASC").Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list all certificates", "error", err)
+		return nil, fmt.Errorf("failed to list certificates: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate models to entities", "error", err)
+		return nil, fmt.Errorf("failed to map certificates: %w", err)
+	}
+
+	return entities, nil
+}
+
+// FindExpiring returns issued certificates expiring within the given number of days.
+func (r *NodeCertificateRepositoryImpl) FindExpiring(ctx context.Context, days int) ([]*node.Certificate, error) {
+	threshold := biztime.NowUTC().AddDate(0, 0, days)
+
+	var modelList []*models.NodeCertificateModel
+	if err := r.db.WithContext(ctx).
+		Where("not_after IS NOT NULL AND not_after <= ?", threshold).
+		Order("not_after ASC").
+		Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to find expiring certificates", "days", days, "error", err)
+		return nil, fmt.Errorf("failed to find expiring certificates: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate models to entities", "error", err)
+		return nil, fmt.Errorf("failed to map certificates: %w", err)
+	}
+
+	return entities, nil
+}
+
+// ClaimIssuance moves a certificate into the issuing state with a conditional update,
+// so that only one instance runs an ACME order for the certificate at a time.
+func (r *NodeCertificateRepositoryImpl) ClaimIssuance(ctx context.Context, cert *node.Certificate, staleBefore time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&models.NodeCertificateModel{}).
+		Where("id = ?", cert.ID()).
+		Where("status <> ? OR last_attempt_at IS NULL OR last_attempt_at < ?", node.CertificateStatusIssuing.String(), staleBefore).
+		Updates(map[string]any{
+			"status":          cert.Status().String(),
+			"last_attempt_at": cert.LastAttemptAt(),
+			"updated_at":      cert.UpdatedAt(),
+		})
+
+	if result.Error != nil {
+		r.logger.Errorw("failed to claim certificate issuance", "id", cert.ID(), "error", result.Error)
+		return false, fmt.Errorf("failed to claim certificate issuance: %w", result.Error)
+	}
+
+	return result.RowsAffected == 1, nil
+}
+
+func (r *NodeCertificateRepositoryImpl) findOne(ctx context.Context, query string, arg any) (*node.Certificate, error) {
+	var model models.NodeCertificateModel
+
+	if err := r.db.WithContext(ctx).Where(query, arg).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get certificate", "query", query, "error", err)
+		return nil, fmt.Errorf("failed to get certificate: %w", err)
+	}
+
+	entity, err := r.mapper.ToEntity(&model)
+	if err != nil {
+		r.logger.Errorw("failed to map certificate model to entity", "id", model.ID, "error", err)
+		return nil, fmt.Errorf("failed to map certificate: %w", err)
+	}
+
+	return entity, nil
+}
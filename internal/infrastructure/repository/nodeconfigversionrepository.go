@@ -0,0 +1,129 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// NodeConfigVersionRepositoryImpl implements the node.ConfigVersionRepository interface.
+type NodeConfigVersionRepositoryImpl struct {
+	db     *gorm.DB
+	mapper mappers.NodeConfigVersionMapper
+	logger logger.Interface
+}
+
+// NewNodeConfigVersionRepository creates a new node configuration version repository instance.
+func NewNodeConfigVersionRepository(db *gorm.DB, logger logger.Interface) node.ConfigVersionRepository {
+	return &NodeConfigVersionRepositoryImpl{
+		db:     db,
+		mapper: mappers.NewNodeConfigVersionMapper(),
+		logger: logger,
+	}
+}
+
+// Create stores a new configuration version.
+func (r *NodeConfigVersionRepositoryImpl) Create(ctx context.Context, version *node.ConfigVersion) error {
+	model, err := r.mapper.ToModel(version)
+	if err != nil {
+		r.logger.Errorw("failed to map config version entity to model", "error", err)
+		return fmt.Errorf("failed to map config version entity: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		if errors.IsDuplicateError(err) {
+			return errors.NewConflictError("node configuration was changed concurrently, please retry")
+		}
+		r.logger.Errorw("failed to create config version in database", "node_id", model.NodeID, "version", model.Version, "error", err)
+		return fmt.Errorf("failed to create config version: %w", err)
+	}
+
+	if err := version.SetID(model.ID); err != nil {
+		r.logger.Errorw("failed to set config version ID", "error", err)
+		return fmt.Errorf("failed to set config version ID: %w", err)
+	}
+
+	return nil
+}
+
+// GetByVersion retrieves a configuration version by node and version number.
+func (r *NodeConfigVersionRepositoryImpl) GetByVersion(ctx context.Context, nodeID uint, version int) (*node.ConfigVersion, error) {
+	var model models.NodeConfigVersionModel
+
+	if err := r.db.WithContext(ctx).
+		Where("node_id = ? AND version = ?", nodeID, version).
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get config version", "node_id", nodeID, "version", version, "error", err)
+		return nil, fmt.Errorf("failed to get config version: %w", err)
+	}
+
+	return r.toEntity(&model)
+}
+
+// GetLatest retrieves the most recent configuration version of a node.
+func (r *NodeConfigVersionRepositoryImpl) GetLatest(ctx context.Context, nodeID uint) (*node.ConfigVersion, error) {
+	var model models.NodeConfigVersionModel
+
+	if err := r.db.WithContext(ctx).
+		Where("node_id = ?", nodeID).
+		Order("version DESC").
+		First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get latest config version", "node_id", nodeID, "error", err)
+		return nil, fmt.Errorf("failed to get latest config version: %w", err)
+	}
+
+	return r.toEntity(&model)
+}
+
+// ListByNodeID retrieves the configuration versions of a node, newest first.
+func (r *NodeConfigVersionRepositoryImpl) ListByNodeID(ctx context.Context, nodeID uint, filter node.ConfigVersionFilter) ([]*node.ConfigVersion, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.NodeConfigVersionModel{}).Where("node_id = ?", nodeID)
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Errorw("failed to count config versions", "node_id", nodeID, "error", err)
+		return nil, 0, fmt.Errorf("failed to count config versions: %w", err)
+	}
+
+	query = query.Order("version DESC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var modelList []*models.NodeConfigVersionModel
+	if err := query.Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list config versions", "node_id", nodeID, "error", err)
+		return nil, 0, fmt.Errorf("failed to list config versions: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map config version models to entities", "node_id", nodeID, "error", err)
+		return nil, 0, fmt.Errorf("failed to map config versions: %w", err)
+	}
+
+	return entities, total, nil
+}
+
+func (r *NodeConfigVersionRepositoryImpl) toEntity(model *models.NodeConfigVersionModel) (*node.ConfigVersion, error) {
+	entity, err := r.mapper.ToEntity(model)
+	if err != nil {
+		r.logger.Errorw("failed to map config version model to entity", "id", model.ID, "error", err)
+		return nil, fmt.Errorf("failed to map config version: %w", err)
+	}
+	return entity, nil
+}
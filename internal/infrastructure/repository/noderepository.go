@@ -44,6 +44,7 @@ type NodeRepositoryImpl struct {
 	hysteria2ConfigRepo   *Hysteria2ConfigRepository
 	tuicConfigRepo        *TUICConfigRepository
 	anytlsConfigRepo      *AnyTLSConfigRepository
+	wireguardConfigRepo   *WireGuardConfigRepository
 	logger                logger.Interface
 }
 
@@ -59,6 +60,7 @@ func NewNodeRepository(db *gorm.DB, logger logger.Interface) node.NodeRepository
 		hysteria2ConfigRepo:   NewHysteria2ConfigRepository(db, logger),
 		tuicConfigRepo:        NewTUICConfigRepository(db, logger),
 		anytlsConfigRepo:      NewAnyTLSConfigRepository(db, logger),
+		wireguardConfigRepo:   NewWireGuardConfigRepository(db, logger),
 		logger:                logger,
 	}
 }
@@ -91,7 +93,7 @@ func (r *NodeRepositoryImpl) Create(ctx context.Context, nodeEntity *node.Node)
 		// Create protocol-specific config based on protocol type
 		switch nodeEntity.Protocol() {
 		case vo.ProtocolShadowsocks:
-			if err := r.shadowsocksConfigRepo.CreateInTx(tx, model.ID, nodeEntity.EncryptionConfig(), nodeEntity.PluginConfig()); err != nil {
+			if err := r.shadowsocksConfigRepo.CreateInTx(tx, model.ID, nodeEntity.EncryptionConfig(), nodeEntity.PluginConfig(), nodeEntity.ShadowTLSConfig()); err != nil {
 				return fmt.Errorf("failed to create shadowsocks config: %w", err)
 			}
 		case vo.ProtocolTrojan:
@@ -130,6 +132,12 @@ func (r *NodeRepositoryImpl) Create(ctx context.Context, nodeEntity *node.Node)
 					return fmt.Errorf("failed to create anytls config: %w", err)
 				}
 			}
+		case vo.ProtocolWireGuard:
+			if nodeEntity.WireGuardConfig() != nil {
+				if err := r.wireguardConfigRepo.CreateInTx(tx, model.ID, nodeEntity.WireGuardConfig()); err != nil {
+					return fmt.Errorf("failed to create wireguard config: %w", err)
+				}
+			}
 		}
 
 		return nil
@@ -176,8 +184,8 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			Select(
 				"name", "server_address", "agent_port", "subscription_port",
 				"protocol", "status", "region", "tags", "sort_order",
-				"maintenance_reason", "token_hash", "api_token", "group_ids", "route_config", "mute_notification",
-				"expires_at", "cost_label", "version", "updated_at",
+				"maintenance_reason", "token_hash", "api_token", "group_ids", "route_config", "dns_config", "mute_notification",
+				"expires_at", "cost_label", "country_code", "traffic_multiplier", "version", "updated_at",
 			).
 			Updates(model)
 
@@ -204,7 +212,7 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 		// Delete all other protocol configs when updating (handles protocol change)
 		switch nodeEntity.Protocol() {
 		case vo.ProtocolShadowsocks:
-			if err := r.shadowsocksConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.EncryptionConfig(), nodeEntity.PluginConfig()); err != nil {
+			if err := r.shadowsocksConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.EncryptionConfig(), nodeEntity.PluginConfig(), nodeEntity.ShadowTLSConfig()); err != nil {
 				return fmt.Errorf("failed to update shadowsocks config: %w", err)
 			}
 			// Delete other protocol configs if they exist (protocol changed)
@@ -226,6 +234,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolTrojan:
 			if err := r.trojanConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.TrojanConfig()); err != nil {
 				return fmt.Errorf("failed to update trojan config: %w", err)
@@ -249,6 +260,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolVLESS:
 			if err := r.vlessConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.VLESSConfig()); err != nil {
 				return fmt.Errorf("failed to update vless config: %w", err)
@@ -272,6 +286,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolVMess:
 			if err := r.vmessConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.VMessConfig()); err != nil {
 				return fmt.Errorf("failed to update vmess config: %w", err)
@@ -295,6 +312,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolHysteria2:
 			if err := r.hysteria2ConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.Hysteria2Config()); err != nil {
 				return fmt.Errorf("failed to update hysteria2 config: %w", err)
@@ -318,6 +338,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolTUIC:
 			if err := r.tuicConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.TUICConfig()); err != nil {
 				return fmt.Errorf("failed to update tuic config: %w", err)
@@ -341,6 +364,9 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete anytls config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
 		case vo.ProtocolAnyTLS:
 			if err := r.anytlsConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.AnyTLSConfig()); err != nil {
 				return fmt.Errorf("failed to update anytls config: %w", err)
@@ -364,6 +390,35 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 			if err := r.tuicConfigRepo.DeleteInTx(tx, model.ID); err != nil {
 				return fmt.Errorf("failed to delete tuic config: %w", err)
 			}
+			if err := r.wireguardConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete wireguard config: %w", err)
+			}
+		case vo.ProtocolWireGuard:
+			if err := r.wireguardConfigRepo.UpdateInTx(tx, model.ID, nodeEntity.WireGuardConfig()); err != nil {
+				return fmt.Errorf("failed to update wireguard config: %w", err)
+			}
+			// Delete other protocol configs if they exist (protocol changed)
+			if err := r.shadowsocksConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete shadowsocks config: %w", err)
+			}
+			if err := r.trojanConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete trojan config: %w", err)
+			}
+			if err := r.vlessConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete vless config: %w", err)
+			}
+			if err := r.vmessConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete vmess config: %w", err)
+			}
+			if err := r.hysteria2ConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete hysteria2 config: %w", err)
+			}
+			if err := r.tuicConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete tuic config: %w", err)
+			}
+			if err := r.anytlsConfigRepo.DeleteInTx(tx, model.ID); err != nil {
+				return fmt.Errorf("failed to delete anytls config: %w", err)
+			}
 		}
 
 		return nil
@@ -378,7 +433,7 @@ func (r *NodeRepositoryImpl) Update(ctx context.Context, nodeEntity *node.Node)
 	return nil
 }
 
-// Delete permanently deletes a node and its associated protocol configs from the database.
+// Delete permanently deletes a node and its associated protocol configs and configuration history from the database.
 func (r *NodeRepositoryImpl) Delete(ctx context.Context, id uint) error {
 	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		// Delete protocol configs first
@@ -403,6 +458,19 @@ func (r *NodeRepositoryImpl) Delete(ctx context.Context, id uint) error {
 		if err := r.anytlsConfigRepo.DeleteInTx(tx, id); err != nil {
 			return fmt.Errorf("failed to delete anytls config: %w", err)
 		}
+		if err := r.wireguardConfigRepo.DeleteInTx(tx, id); err != nil {
+			return fmt.Errorf("failed to delete wireguard config: %w", err)
+		}
+
+		// Configuration history belongs to the node
+		if err := tx.Where("node_id = ?", id).Delete(&models.NodeConfigVersionModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete config versions: %w", err)
+		}
+
+		// Release the WireGuard tunnel addresses allocated on the node
+		if err := tx.Where("node_id = ?", id).Delete(&models.WireGuardPeerModel{}).Error; err != nil {
+			return fmt.Errorf("failed to delete wireguard peers: %w", err)
+		}
 
 		// Hard delete node using Unscoped() to bypass soft delete
 		result := tx.Unscoped().Delete(&models.NodeModel{}, id)
@@ -83,6 +83,7 @@ func (r *NodeRepositoryImpl) List(ctx context.Context, filter node.NodeFilter) (
 	hysteria2NodeIDs := make([]uint, 0, protoCapacity)
 	tuicNodeIDs := make([]uint, 0, protoCapacity)
 	anytlsNodeIDs := make([]uint, 0, protoCapacity)
+	wireguardNodeIDs := make([]uint, 0, protoCapacity)
 	for _, m := range nodeModels {
 		switch m.Protocol {
 		case "shadowsocks":
@@ -99,6 +100,8 @@ func (r *NodeRepositoryImpl) List(ctx context.Context, filter node.NodeFilter) (
 			tuicNodeIDs = append(tuicNodeIDs, m.ID)
 		case "anytls":
 			anytlsNodeIDs = append(anytlsNodeIDs, m.ID)
+		case "wireguard":
+			wireguardNodeIDs = append(wireguardNodeIDs, m.ID)
 		}
 	}
 
@@ -113,6 +116,7 @@ func (r *NodeRepositoryImpl) List(ctx context.Context, filter node.NodeFilter) (
 		hysteria2Configs map[uint]*vo.Hysteria2Config
 		tuicConfigs      map[uint]*vo.TUICConfig
 		anytlsConfigs    map[uint]*vo.AnyTLSConfig
+		wireguardConfigs map[uint]*vo.WireGuardConfig
 	)
 
 	g, gctx := errgroup.WithContext(ctx)
@@ -208,6 +212,19 @@ func (r *NodeRepositoryImpl) List(ctx context.Context, filter node.NodeFilter) (
 		})
 	}
 
+	// WireGuard configs
+	if len(wireguardNodeIDs) > 0 {
+		g.Go(func() error {
+			configs, err := r.wireguardConfigRepo.GetByNodeIDs(gctx, wireguardNodeIDs)
+			if err != nil {
+				r.logger.Errorw("failed to get wireguard configs", "error", err)
+				return fmt.Errorf("failed to get wireguard configs: %w", err)
+			}
+			wireguardConfigs = configs
+			return nil
+		})
+	}
+
 	// Wait for all goroutines to complete
 	if err := g.Wait(); err != nil {
 		return nil, 0, err
@@ -219,11 +236,12 @@ func (r *NodeRepositoryImpl) List(ctx context.Context, filter node.NodeFilter) (
 		ssConfigs[nodeID] = &mappers.ShadowsocksConfigData{
 			EncryptionConfig: data.EncryptionConfig,
 			PluginConfig:     data.PluginConfig,
+			ShadowTLSConfig:  data.ShadowTLSConfig,
 		}
 	}
 
 	// Convert models to entities
-	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs)
+	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs, wireguardConfigs)
 	if err != nil {
 		r.logger.Errorw("failed to map node models to entities", "error", err)
 		return nil, 0, fmt.Errorf("failed to map nodes: %w", err)
@@ -296,6 +314,7 @@ func (r *NodeRepositoryImpl) loadProtocolConfigsAndConvert(ctx context.Context,
 	hysteria2NodeIDs := make([]uint, 0, protoCapacity)
 	tuicNodeIDs := make([]uint, 0, protoCapacity)
 	anytlsNodeIDs := make([]uint, 0, protoCapacity)
+	wireguardNodeIDs := make([]uint, 0, protoCapacity)
 	for _, m := range nodeModels {
 		switch m.Protocol {
 		case "shadowsocks":
@@ -312,6 +331,8 @@ func (r *NodeRepositoryImpl) loadProtocolConfigsAndConvert(ctx context.Context,
 			tuicNodeIDs = append(tuicNodeIDs, m.ID)
 		case "anytls":
 			anytlsNodeIDs = append(anytlsNodeIDs, m.ID)
+		case "wireguard":
+			wireguardNodeIDs = append(wireguardNodeIDs, m.ID)
 		}
 	}
 
@@ -326,6 +347,7 @@ func (r *NodeRepositoryImpl) loadProtocolConfigsAndConvert(ctx context.Context,
 		hysteria2Configs map[uint]*vo.Hysteria2Config
 		tuicConfigs      map[uint]*vo.TUICConfig
 		anytlsConfigs    map[uint]*vo.AnyTLSConfig
+		wireguardConfigs map[uint]*vo.WireGuardConfig
 	)
 
 	g, gctx := errgroup.WithContext(ctx)
@@ -400,6 +422,16 @@ func (r *NodeRepositoryImpl) loadProtocolConfigsAndConvert(ctx context.Context,
 			return nil
 		})
 	}
+	if len(wireguardNodeIDs) > 0 {
+		g.Go(func() error {
+			configs, err := r.wireguardConfigRepo.GetByNodeIDs(gctx, wireguardNodeIDs)
+			if err != nil {
+				return fmt.Errorf("failed to get wireguard configs: %w", err)
+			}
+			wireguardConfigs = configs
+			return nil
+		})
+	}
 
 	if err := g.Wait(); err != nil {
 		r.logger.Errorw("failed to load protocol configs", "error", err)
@@ -412,10 +444,11 @@ func (r *NodeRepositoryImpl) loadProtocolConfigsAndConvert(ctx context.Context,
 		ssConfigs[nodeID] = &mappers.ShadowsocksConfigData{
 			EncryptionConfig: data.EncryptionConfig,
 			PluginConfig:     data.PluginConfig,
+			ShadowTLSConfig:  data.ShadowTLSConfig,
 		}
 	}
 
-	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs)
+	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs, wireguardConfigs)
 	if err != nil {
 		r.logger.Errorw("failed to map node models to entities", "error", err)
 		return nil, fmt.Errorf("failed to map nodes: %w", err)
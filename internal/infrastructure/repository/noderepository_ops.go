@@ -578,6 +578,35 @@ func (r *NodeRepositoryImpl) CountAll(ctx context.Context) (int64, error) {
 	return count, nil
 }
 
+// GetTrafficMultipliers returns the billing rate for each of the given node IDs.
+// Nodes that do not exist are omitted from the result.
+func (r *NodeRepositoryImpl) GetTrafficMultipliers(ctx context.Context, nodeIDs []uint) (map[uint]float64, error) {
+	result := make(map[uint]float64, len(nodeIDs))
+	if len(nodeIDs) == 0 {
+		return result, nil
+	}
+
+	var rows []struct {
+		ID                uint    `gorm:"column:id"`
+		TrafficMultiplier float64 `gorm:"column:traffic_multiplier"`
+	}
+
+	if err := r.db.WithContext(ctx).
+		Model(&models.NodeModel{}).
+		Select("id, traffic_multiplier").
+		Where("id IN ?", nodeIDs).
+		Find(&rows).Error; err != nil {
+		r.logger.Errorw("failed to get node traffic multipliers", "node_count", len(nodeIDs), "error", err)
+		return nil, fmt.Errorf("failed to get node traffic multipliers: %w", err)
+	}
+
+	for _, row := range rows {
+		result[row.ID] = row.TrafficMultiplier
+	}
+
+	return result, nil
+}
+
 // FindOfflineNodes returns nodes whose last_seen_at is before the given cutoff time.
 // Only returns nodes that have reported at least once (last_seen_at IS NOT NULL).
 // This is a lightweight query that avoids loading protocol configs.
@@ -613,3 +642,19 @@ func (r *NodeRepositoryImpl) FindOfflineNodes(ctx context.Context, cutoff time.T
 
 	return nodes, nil
 }
+
+// ListRealityRotationNodes returns VLESS Reality nodes that have scheduled rotation enabled
+// or a previous short ID still waiting to expire.
+func (r *NodeRepositoryImpl) ListRealityRotationNodes(ctx context.Context) ([]*node.Node, error) {
+	var nodeIDs []uint
+	if err := r.db.WithContext(ctx).
+		Model(&models.VLESSConfigModel{}).
+		Where("security = ?", "reality").
+		Where("reality_rotation_interval_hours > 0 OR reality_previous_short_id <> ''").
+		Pluck("node_id", &nodeIDs).Error; err != nil {
+		r.logger.Errorw("failed to list reality rotation nodes", "error", err)
+		return nil, fmt.Errorf("failed to list reality rotation nodes: %w", err)
+	}
+
+	return r.GetByIDs(ctx, nodeIDs)
+}
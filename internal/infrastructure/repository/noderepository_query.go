@@ -29,16 +29,18 @@ func (r *NodeRepositoryImpl) GetByID(ctx context.Context, id uint) (*node.Node,
 	var trojanConfig *vo.TrojanConfig
 	var encryptionConfig vo.EncryptionConfig
 	var pluginConfig *vo.PluginConfig
+	var shadowTLSConfig *vo.ShadowTLSConfig
 	var vlessConfig *vo.VLESSConfig
 	var vmessConfig *vo.VMessConfig
 	var hysteria2Config *vo.Hysteria2Config
 	var tuicConfig *vo.TUICConfig
 	var anytlsConfig *vo.AnyTLSConfig
+	var wireguardConfig *vo.WireGuardConfig
 
 	switch model.Protocol {
 	case "shadowsocks":
 		var err error
-		encryptionConfig, pluginConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, id)
+		encryptionConfig, pluginConfig, shadowTLSConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, id)
 		if err != nil {
 			r.logger.Errorw("failed to get shadowsocks config", "node_id", id, "error", err)
 			return nil, fmt.Errorf("failed to get shadowsocks config: %w", err)
@@ -85,9 +87,16 @@ func (r *NodeRepositoryImpl) GetByID(ctx context.Context, id uint) (*node.Node,
 			r.logger.Errorw("failed to get anytls config", "node_id", id, "error", err)
 			return nil, fmt.Errorf("failed to get anytls config: %w", err)
 		}
+	case "wireguard":
+		var err error
+		wireguardConfig, err = r.wireguardConfigRepo.GetByNodeID(ctx, id)
+		if err != nil {
+			r.logger.Errorw("failed to get wireguard config", "node_id", id, "error", err)
+			return nil, fmt.Errorf("failed to get wireguard config: %w", err)
+		}
 	}
 
-	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig)
+	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, shadowTLSConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig, wireguardConfig)
 	if err != nil {
 		r.logger.Errorw("failed to map node model to entity", "id", id, "error", err)
 		return nil, fmt.Errorf("failed to map node: %w", err)
@@ -112,16 +121,18 @@ func (r *NodeRepositoryImpl) GetBySID(ctx context.Context, sid string) (*node.No
 	var trojanConfig *vo.TrojanConfig
 	var encryptionConfig vo.EncryptionConfig
 	var pluginConfig *vo.PluginConfig
+	var shadowTLSConfig *vo.ShadowTLSConfig
 	var vlessConfig *vo.VLESSConfig
 	var vmessConfig *vo.VMessConfig
 	var hysteria2Config *vo.Hysteria2Config
 	var tuicConfig *vo.TUICConfig
 	var anytlsConfig *vo.AnyTLSConfig
+	var wireguardConfig *vo.WireGuardConfig
 
 	switch model.Protocol {
 	case "shadowsocks":
 		var err error
-		encryptionConfig, pluginConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, model.ID)
+		encryptionConfig, pluginConfig, shadowTLSConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, model.ID)
 		if err != nil {
 			r.logger.Errorw("failed to get shadowsocks config", "node_id", model.ID, "error", err)
 			return nil, fmt.Errorf("failed to get shadowsocks config: %w", err)
@@ -168,9 +179,16 @@ func (r *NodeRepositoryImpl) GetBySID(ctx context.Context, sid string) (*node.No
 			r.logger.Errorw("failed to get anytls config", "node_id", model.ID, "error", err)
 			return nil, fmt.Errorf("failed to get anytls config: %w", err)
 		}
+	case "wireguard":
+		var err error
+		wireguardConfig, err = r.wireguardConfigRepo.GetByNodeID(ctx, model.ID)
+		if err != nil {
+			r.logger.Errorw("failed to get wireguard config", "node_id", model.ID, "error", err)
+			return nil, fmt.Errorf("failed to get wireguard config: %w", err)
+		}
 	}
 
-	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig)
+	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, shadowTLSConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig, wireguardConfig)
 	if err != nil {
 		r.logger.Errorw("failed to map node model to entity", "sid", sid, "error", err)
 		return nil, fmt.Errorf("failed to map node: %w", err)
@@ -210,6 +228,7 @@ func (r *NodeRepositoryImpl) GetBySIDs(ctx context.Context, sids []string) ([]*n
 		ssConfigs[nodeID] = &mappers.ShadowsocksConfigData{
 			EncryptionConfig: data.EncryptionConfig,
 			PluginConfig:     data.PluginConfig,
+			ShadowTLSConfig:  data.ShadowTLSConfig,
 		}
 	}
 
@@ -255,8 +274,15 @@ func (r *NodeRepositoryImpl) GetBySIDs(ctx context.Context, sids []string) ([]*n
 		anytlsConfigs = make(map[uint]*vo.AnyTLSConfig)
 	}
 
+	// Load wireguard configs
+	wireguardConfigs, err := r.wireguardConfigRepo.GetByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		r.logger.Warnw("failed to load wireguard configs", "error", err)
+		wireguardConfigs = make(map[uint]*vo.WireGuardConfig)
+	}
+
 	// Convert to entities
-	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs)
+	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs, wireguardConfigs)
 	if err != nil {
 		r.logger.Errorw("failed to map node models to entities", "error", err)
 		return nil, fmt.Errorf("failed to map nodes: %w", err)
@@ -296,6 +322,7 @@ func (r *NodeRepositoryImpl) GetByIDs(ctx context.Context, ids []uint) ([]*node.
 		ssConfigs[nodeID] = &mappers.ShadowsocksConfigData{
 			EncryptionConfig: data.EncryptionConfig,
 			PluginConfig:     data.PluginConfig,
+			ShadowTLSConfig:  data.ShadowTLSConfig,
 		}
 	}
 
@@ -341,8 +368,15 @@ func (r *NodeRepositoryImpl) GetByIDs(ctx context.Context, ids []uint) ([]*node.
 		anytlsConfigs = make(map[uint]*vo.AnyTLSConfig)
 	}
 
+	// Load wireguard configs
+	wireguardConfigs, err := r.wireguardConfigRepo.GetByNodeIDs(ctx, nodeIDs)
+	if err != nil {
+		r.logger.Warnw("failed to load wireguard configs", "error", err)
+		wireguardConfigs = make(map[uint]*vo.WireGuardConfig)
+	}
+
 	// Convert to entities
-	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs)
+	entities, err := r.mapper.ToEntities(nodeModels, ssConfigs, trojanConfigs, vlessConfigs, vmessConfigs, hysteria2Configs, tuicConfigs, anytlsConfigs, wireguardConfigs)
 	if err != nil {
 		r.logger.Errorw("failed to map node models to entities", "error", err)
 		return nil, fmt.Errorf("failed to map nodes: %w", err)
@@ -367,16 +401,18 @@ func (r *NodeRepositoryImpl) GetByToken(ctx context.Context, tokenHash string) (
 	var trojanConfig *vo.TrojanConfig
 	var encryptionConfig vo.EncryptionConfig
 	var pluginConfig *vo.PluginConfig
+	var shadowTLSConfig *vo.ShadowTLSConfig
 	var vlessConfig *vo.VLESSConfig
 	var vmessConfig *vo.VMessConfig
 	var hysteria2Config *vo.Hysteria2Config
 	var tuicConfig *vo.TUICConfig
 	var anytlsConfig *vo.AnyTLSConfig
+	var wireguardConfig *vo.WireGuardConfig
 
 	switch model.Protocol {
 	case "shadowsocks":
 		var err error
-		encryptionConfig, pluginConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, model.ID)
+		encryptionConfig, pluginConfig, shadowTLSConfig, err = r.shadowsocksConfigRepo.GetByNodeID(ctx, model.ID)
 		if err != nil {
 			r.logger.Errorw("failed to get shadowsocks config", "node_id", model.ID, "error", err)
 			return nil, fmt.Errorf("failed to get shadowsocks config: %w", err)
@@ -423,9 +459,16 @@ func (r *NodeRepositoryImpl) GetByToken(ctx context.Context, tokenHash string) (
 			r.logger.Errorw("failed to get anytls config", "node_id", model.ID, "error", err)
 			return nil, fmt.Errorf("failed to get anytls config: %w", err)
 		}
+	case "wireguard":
+		var err error
+		wireguardConfig, err = r.wireguardConfigRepo.GetByNodeID(ctx, model.ID)
+		if err != nil {
+			r.logger.Errorw("failed to get wireguard config", "node_id", model.ID, "error", err)
+			return nil, fmt.Errorf("failed to get wireguard config: %w", err)
+		}
 	}
 
-	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig)
+	entity, err := r.mapper.ToEntity(&model, encryptionConfig, pluginConfig, shadowTLSConfig, trojanConfig, vlessConfig, vmessConfig, hysteria2Config, tuicConfig, anytlsConfig, wireguardConfig)
 	if err != nil {
 		r.logger.Errorw("failed to map node model to entity", "token_hash", tokenHash, "error", err)
 		return nil, fmt.Errorf("failed to map node: %w", err)
@@ -0,0 +1,154 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// NodeTemplateRepositoryImpl implements the node.NodeTemplateRepository interface.
+type NodeTemplateRepositoryImpl struct {
+	db     *gorm.DB
+	mapper mappers.NodeTemplateMapper
+	logger logger.Interface
+}
+
+// NewNodeTemplateRepository creates a new node template repository instance.
+func NewNodeTemplateRepository(db *gorm.DB, logger logger.Interface) node.NodeTemplateRepository {
+	return &NodeTemplateRepositoryImpl{
+		db:     db,
+		mapper: mappers.NewNodeTemplateMapper(),
+		logger: logger,
+	}
+}
+
+// Create creates a new node template in the database.
+func (r *NodeTemplateRepositoryImpl) Create(ctx context.Context, template *node.NodeTemplate) error {
+	model, err := r.mapper.ToModel(template)
+	if err != nil {
+		r.logger.Errorw("failed to map node template entity to model", "error", err)
+		return fmt.Errorf("failed to map node template entity: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		if errors.IsDuplicateError(err) {
+			return errors.NewConflictError("node template with this name already exists", template.Name())
+		}
+		r.logger.Errorw("failed to create node template in database", "name", template.Name(), "error", err)
+		return fmt.Errorf("failed to create node template: %w", err)
+	}
+
+	if err := template.SetID(model.ID); err != nil {
+		r.logger.Errorw("failed to set node template ID", "error", err)
+		return fmt.Errorf("failed to set node template ID: %w", err)
+	}
+
+	return nil
+}
+
+// Update persists the name, description, configuration and groups of a node template.
+func (r *NodeTemplateRepositoryImpl) Update(ctx context.Context, template *node.NodeTemplate) error {
+	model, err := r.mapper.ToModel(template)
+	if err != nil {
+		r.logger.Errorw("failed to map node template entity to model", "error", err)
+		return fmt.Errorf("failed to map node template entity: %w", err)
+	}
+
+	result := r.db.WithContext(ctx).Model(&models.NodeTemplateModel{}).
+		Where("id = ?", model.ID).
+		Updates(map[string]any{
+			"name":        model.Name,
+			"description": model.Description,
+			"config":      model.Config,
+			"group_ids":   model.GroupIDs,
+			"updated_at":  model.UpdatedAt,
+		})
+
+	if result.Error != nil {
+		if errors.IsDuplicateError(result.Error) {
+			return errors.NewConflictError("node template with this name already exists", template.Name())
+		}
+		r.logger.Errorw("failed to update node template", "id", model.ID, "error", result.Error)
+		return fmt.Errorf("failed to update node template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("node template", fmt.Sprintf("%d", model.ID))
+	}
+
+	return nil
+}
+
+// Delete removes a node template. Nodes provisioned from it are not affected.
+func (r *NodeTemplateRepositoryImpl) Delete(ctx context.Context, id uint) error {
+	result := r.db.WithContext(ctx).Delete(&models.NodeTemplateModel{}, id)
+	if result.Error != nil {
+		r.logger.Errorw("failed to delete node template", "id", id, "error", result.Error)
+		return fmt.Errorf("failed to delete node template: %w", result.Error)
+	}
+
+	if result.RowsAffected == 0 {
+		return errors.NewNotFoundError("node template", fmt.Sprintf("%d", id))
+	}
+
+	return nil
+}
+
+// GetBySID retrieves a node template by its Stripe-style ID.
+func (r *NodeTemplateRepositoryImpl) GetBySID(ctx context.Context, sid string) (*node.NodeTemplate, error) {
+	var model models.NodeTemplateModel
+
+	if err := r.db.WithContext(ctx).Where("sid = ?", sid).First(&model).Error; err != nil {
+		if err == gorm.ErrRecordNotFound {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get node template", "sid", sid, "error", err)
+		return nil, fmt.Errorf("failed to get node template: %w", err)
+	}
+
+	entity, err := r.mapper.ToEntity(&model)
+	if err != nil {
+		r.logger.Errorw("failed to map node template model to entity", "id", model.ID, "error", err)
+		return nil, fmt.Errorf("failed to map node template: %w", err)
+	}
+
+	return entity, nil
+}
+
+// List retrieves node templates ordered by name.
+func (r *NodeTemplateRepositoryImpl) List(ctx context.Context, filter node.NodeTemplateFilter) ([]*node.NodeTemplate, int64, error) {
+	query := r.db.WithContext(ctx).Model(&models.NodeTemplateModel{})
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		r.logger.Errorw("failed to count node templates", "error", err)
+		return nil, 0, fmt.Errorf("failed to count node templates: %w", err)
+	}
+
+	query = query.Order("name ASC")
+	if filter.Page > 0 && filter.PageSize > 0 {
+		offset := (filter.Page - 1) * filter.PageSize
+		query = query.Offset(offset).Limit(filter.PageSize)
+	}
+
+	var modelList []*models.NodeTemplateModel
+	if err := query.Find(&modelList).Error; err != nil {
+		r.logger.Errorw("failed to list node templates", "error", err)
+		return nil, 0, fmt.Errorf("failed to list node templates: %w", err)
+	}
+
+	entities, err := r.mapper.ToEntities(modelList)
+	if err != nil {
+		r.logger.Errorw("failed to map node template models to entities", "error", err)
+		return nil, 0, fmt.Errorf("failed to map node templates: %w", err)
+	}
+
+	return entities, total, nil
+}
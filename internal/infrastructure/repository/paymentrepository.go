@@ -285,6 +285,29 @@ func (r *PaymentRepository) CountPendingUSDTPaymentsByUser(ctx context.Context,
 	return int(count), nil
 }
 
+// CountByStatus returns the number of payments in each status
+func (r *PaymentRepository) CountByStatus(ctx context.Context) (map[vo.PaymentStatus]int64, error) {
+	var rows []struct {
+		PaymentStatus string
+		Count         int64
+	}
+
+	if err := db.GetTxFromContext(ctx, r.db).
+		Model(&models.PaymentModel{}).
+		Select("payment_status, COUNT(*) AS count").
+		Group("payment_status").
+		Scan(&rows).Error; err != nil {
+		return nil, fmt.Errorf("failed to count payments by status: %w", err)
+	}
+
+	counts := make(map[vo.PaymentStatus]int64, len(rows))
+	for _, row := range rows {
+		counts[vo.PaymentStatus(row.PaymentStatus)] = row.Count
+	}
+
+	return counts, nil
+}
+
 // GetPaidPaymentsNeedingActivation returns paid non-USDT payments
 // that have subscription_activation_pending=true in metadata
 func (r *PaymentRepository) GetPaidPaymentsNeedingActivation(ctx context.Context) ([]*payment.Payment, error) {
@@ -29,8 +29,8 @@ func NewShadowsocksConfigRepository(db *gorm.DB, logger logger.Interface) *Shado
 }
 
 // Create creates a new ShadowsocksConfig record for a node
-func (r *ShadowsocksConfigRepository) Create(ctx context.Context, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) error {
-	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig)
+func (r *ShadowsocksConfigRepository) Create(ctx context.Context, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) error {
+	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	if err != nil {
 		return fmt.Errorf("failed to map shadowsocks config to model: %w", err)
 	}
@@ -45,23 +45,24 @@ func (r *ShadowsocksConfigRepository) Create(ctx context.Context, nodeID uint, e
 }
 
 // GetByNodeID retrieves ShadowsocksConfig for a specific node
-func (r *ShadowsocksConfigRepository) GetByNodeID(ctx context.Context, nodeID uint) (vo.EncryptionConfig, *vo.PluginConfig, error) {
+func (r *ShadowsocksConfigRepository) GetByNodeID(ctx context.Context, nodeID uint) (vo.EncryptionConfig, *vo.PluginConfig, *vo.ShadowTLSConfig, error) {
 	var model models.ShadowsocksConfigModel
 	if err := r.db.WithContext(ctx).Where("node_id = ?", nodeID).First(&model).Error; err != nil {
 		if err == gorm.ErrRecordNotFound {
-			return vo.EncryptionConfig{}, nil, nil
+			return vo.EncryptionConfig{}, nil, nil, nil
 		}
 		r.logger.Errorw("failed to get shadowsocks config", "node_id", nodeID, "error", err)
-		return vo.EncryptionConfig{}, nil, fmt.Errorf("failed to get shadowsocks config: %w", err)
+		return vo.EncryptionConfig{}, nil, nil, fmt.Errorf("failed to get shadowsocks config: %w", err)
 	}
 
 	return r.mapper.ToValueObjects(&model)
 }
 
-// ShadowsocksConfigData holds the encryption and plugin config for a node
+// ShadowsocksConfigData holds the encryption, plugin and ShadowTLS config for a node
 type ShadowsocksConfigData struct {
 	EncryptionConfig vo.EncryptionConfig
 	PluginConfig     *vo.PluginConfig
+	ShadowTLSConfig  *vo.ShadowTLSConfig
 }
 
 // GetByNodeIDs retrieves ShadowsocksConfigs for multiple nodes
@@ -73,7 +74,8 @@ func (r *ShadowsocksConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs
 
 	var ssModels []models.ShadowsocksConfigModel
 	if err := r.db.WithContext(ctx).
-		Select("node_id", "encryption_method", "plugin", "plugin_opts").
+		Select("node_id", "encryption_method", "server_key", "plugin", "plugin_opts",
+			"shadowtls_version", "shadowtls_password", "shadowtls_handshake_server", "shadowtls_handshake_port").
 		Where("node_id IN ?", nodeIDs).
 		Find(&ssModels).Error; err != nil {
 		r.logger.Errorw("failed to get shadowsocks configs by node IDs", "node_ids", nodeIDs, "error", err)
@@ -82,7 +84,7 @@ func (r *ShadowsocksConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs
 
 	result := make(map[uint]*ShadowsocksConfigData)
 	for _, model := range ssModels {
-		encryptionConfig, pluginConfig, err := r.mapper.ToValueObjects(&model)
+		encryptionConfig, pluginConfig, shadowTLSConfig, err := r.mapper.ToValueObjects(&model)
 		if err != nil {
 			r.logger.Warnw("failed to map shadowsocks config", "node_id", model.NodeID, "error", err)
 			continue
@@ -90,6 +92,7 @@ func (r *ShadowsocksConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs
 		result[model.NodeID] = &ShadowsocksConfigData{
 			EncryptionConfig: encryptionConfig,
 			PluginConfig:     pluginConfig,
+			ShadowTLSConfig:  shadowTLSConfig,
 		}
 	}
 
@@ -97,20 +100,20 @@ func (r *ShadowsocksConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs
 }
 
 // Update updates the ShadowsocksConfig for a node
-func (r *ShadowsocksConfigRepository) Update(ctx context.Context, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) error {
+func (r *ShadowsocksConfigRepository) Update(ctx context.Context, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) error {
 	// Check if record exists
 	var existing models.ShadowsocksConfigModel
 	err := r.db.WithContext(ctx).Where("node_id = ?", nodeID).First(&existing).Error
 	if err == gorm.ErrRecordNotFound {
 		// Create new record
-		return r.Create(ctx, nodeID, encryptionConfig, pluginConfig)
+		return r.Create(ctx, nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to check existing shadowsocks config: %w", err)
 	}
 
 	// Update existing record
-	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig)
+	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	if err != nil {
 		return fmt.Errorf("failed to map shadowsocks config to model: %w", err)
 	}
@@ -141,8 +144,8 @@ func (r *ShadowsocksConfigRepository) DeleteByNodeID(ctx context.Context, nodeID
 }
 
 // CreateInTx creates a ShadowsocksConfig record within a transaction
-func (r *ShadowsocksConfigRepository) CreateInTx(tx *gorm.DB, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) error {
-	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig)
+func (r *ShadowsocksConfigRepository) CreateInTx(tx *gorm.DB, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) error {
+	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	if err != nil {
 		return fmt.Errorf("failed to map shadowsocks config to model: %w", err)
 	}
@@ -155,17 +158,17 @@ func (r *ShadowsocksConfigRepository) CreateInTx(tx *gorm.DB, nodeID uint, encry
 }
 
 // UpdateInTx updates a ShadowsocksConfig record within a transaction
-func (r *ShadowsocksConfigRepository) UpdateInTx(tx *gorm.DB, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig) error {
+func (r *ShadowsocksConfigRepository) UpdateInTx(tx *gorm.DB, nodeID uint, encryptionConfig vo.EncryptionConfig, pluginConfig *vo.PluginConfig, shadowTLSConfig *vo.ShadowTLSConfig) error {
 	var existing models.ShadowsocksConfigModel
 	err := tx.Where("node_id = ?", nodeID).First(&existing).Error
 	if err == gorm.ErrRecordNotFound {
-		return r.CreateInTx(tx, nodeID, encryptionConfig, pluginConfig)
+		return r.CreateInTx(tx, nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	}
 	if err != nil {
 		return fmt.Errorf("failed to check existing shadowsocks config: %w", err)
 	}
 
-	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig)
+	model, err := r.mapper.ToModel(nodeID, encryptionConfig, pluginConfig, shadowTLSConfig)
 	if err != nil {
 		return fmt.Errorf("failed to map shadowsocks config to model: %w", err)
 	}
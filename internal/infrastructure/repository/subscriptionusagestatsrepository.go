@@ -47,7 +47,7 @@ func (r *SubscriptionUsageStatsRepositoryImpl) Upsert(ctx context.Context, stats
 			{Name: "granularity"},
 			{Name: "period"},
 		},
-		DoUpdates: clause.AssignmentColumns([]string{"upload", "download", "total", "updated_at"}),
+		DoUpdates: clause.AssignmentColumns([]string{"upload", "download", "total", "raw_upload", "raw_download", "updated_at"}),
 	}).Create(model).Error
 
 	if err != nil {
@@ -569,7 +569,8 @@ func (r *SubscriptionUsageStatsRepositoryImpl) GetUsageGroupedBySubscription(
 }
 
 // GetUsageGroupedByResourceID retrieves aggregated usage grouped by resource ID with pagination.
-// Uses daily granularity for aggregation.
+// Uses daily granularity for aggregation. Returns raw usage (before node traffic multipliers)
+// since this is the operator view of per-resource traffic.
 func (r *SubscriptionUsageStatsRepositoryImpl) GetUsageGroupedByResourceID(
 	ctx context.Context,
 	resourceType string,
@@ -614,7 +615,7 @@ func (r *SubscriptionUsageStatsRepositoryImpl) GetUsageGroupedByResourceID(
 	}
 	offset := (page - 1) * pageSize
 	dataQuery := baseQuery.Session(&gorm.Session{}).
-		Select("resource_id, SUM(upload) as upload, SUM(download) as download, SUM(total) as total").
+		Select("resource_id, SUM(raw_upload) as upload, SUM(raw_download) as download, SUM(raw_upload + raw_download) as total").
 		Group("resource_id").
 		Order("total DESC").
 		Offset(offset).
@@ -73,7 +73,10 @@ func (r *VLESSConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs []uint
 	var vlessModels []models.VLESSConfigModel
 	if err := r.db.WithContext(ctx).
 		Select("node_id", "transport_type", "flow", "security", "sni", "fingerprint",
-			"allow_insecure", "host", "path", "service_name", "private_key", "public_key", "short_id", "spider_x").
+			"allow_insecure", "host", "path", "service_name", "private_key", "public_key", "short_id", "spider_x",
+			"xhttp_mode", "xhttp_extra", "reality_rotation_interval_hours", "reality_rotation_overlap_hours",
+			"reality_rotate_key_pair", "reality_previous_short_id", "reality_previous_short_id_expires_at",
+			"reality_rotated_at").
 		Where("node_id IN ?", nodeIDs).
 		Find(&vlessModels).Error; err != nil {
 		r.logger.Errorw("failed to get VLESS configs by node IDs", "node_ids", nodeIDs, "error", err)
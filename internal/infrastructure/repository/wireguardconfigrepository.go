@@ -0,0 +1,199 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	vo "github.com/orris-inc/orris/internal/domain/node/valueobjects"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/mappers"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// WireGuardConfigRepository handles persistence operations for WireGuardConfig
+type WireGuardConfigRepository struct {
+	db     *gorm.DB
+	mapper mappers.WireGuardConfigMapper
+	logger logger.Interface
+}
+
+// NewWireGuardConfigRepository creates a new WireGuardConfigRepository
+func NewWireGuardConfigRepository(db *gorm.DB, logger logger.Interface) *WireGuardConfigRepository {
+	return &WireGuardConfigRepository{
+		db:     db,
+		mapper: mappers.NewWireGuardConfigMapper(),
+		logger: logger,
+	}
+}
+
+// Create creates a new WireGuardConfig record for a node
+func (r *WireGuardConfigRepository) Create(ctx context.Context, nodeID uint, config *vo.WireGuardConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	model, err := r.mapper.ToModel(nodeID, config)
+	if err != nil {
+		return fmt.Errorf("failed to map WireGuard config to model: %w", err)
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		r.logger.Errorw("failed to create WireGuard config", "node_id", nodeID, "error", err)
+		return fmt.Errorf("failed to create WireGuard config: %w", err)
+	}
+
+	r.logger.Infow("WireGuard config created", "node_id", nodeID, "id", model.ID)
+	return nil
+}
+
+// GetByNodeID retrieves WireGuardConfig for a specific node
+func (r *WireGuardConfigRepository) GetByNodeID(ctx context.Context, nodeID uint) (*vo.WireGuardConfig, error) {
+	var model models.WireGuardConfigModel
+	if err := r.db.WithContext(ctx).Where("node_id = ?", nodeID).First(&model).Error; err != nil {
+		if errors.Is(err, gorm.ErrRecordNotFound) {
+			return nil, nil
+		}
+		r.logger.Errorw("failed to get WireGuard config", "node_id", nodeID, "error", err)
+		return nil, fmt.Errorf("failed to get WireGuard config: %w", err)
+	}
+
+	return r.mapper.ToValueObject(&model)
+}
+
+// GetByNodeIDs retrieves WireGuardConfigs for multiple nodes
+// Returns a map of nodeID -> WireGuardConfig
+func (r *WireGuardConfigRepository) GetByNodeIDs(ctx context.Context, nodeIDs []uint) (map[uint]*vo.WireGuardConfig, error) {
+	if len(nodeIDs) == 0 {
+		return make(map[uint]*vo.WireGuardConfig), nil
+	}
+
+	var wireguardModels []models.WireGuardConfigModel
+	if err := r.db.WithContext(ctx).
+		Select("node_id", "private_key", "address_pool", "mtu", "dns", "persistent_keepalive").
+		Where("node_id IN ?", nodeIDs).
+		Find(&wireguardModels).Error; err != nil {
+		r.logger.Errorw("failed to get WireGuard configs by node IDs", "node_ids", nodeIDs, "error", err)
+		return nil, fmt.Errorf("failed to get WireGuard configs: %w", err)
+	}
+
+	result := make(map[uint]*vo.WireGuardConfig)
+	for _, model := range wireguardModels {
+		config, err := r.mapper.ToValueObject(&model)
+		if err != nil {
+			r.logger.Warnw("failed to map WireGuard config", "node_id", model.NodeID, "error", err)
+			continue
+		}
+		result[model.NodeID] = config
+	}
+
+	return result, nil
+}
+
+// Update updates the WireGuardConfig for a node
+func (r *WireGuardConfigRepository) Update(ctx context.Context, nodeID uint, config *vo.WireGuardConfig) error {
+	if config == nil {
+		// If config is nil, delete the existing record
+		return r.DeleteByNodeID(ctx, nodeID)
+	}
+
+	// Check if record exists
+	var existing models.WireGuardConfigModel
+	err := r.db.WithContext(ctx).Where("node_id = ?", nodeID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		// Create new record
+		return r.Create(ctx, nodeID, config)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check existing WireGuard config: %w", err)
+	}
+
+	// Update existing record
+	model, err := r.mapper.ToModel(nodeID, config)
+	if err != nil {
+		return fmt.Errorf("failed to map WireGuard config to model: %w", err)
+	}
+	model.ID = existing.ID
+	model.CreatedAt = existing.CreatedAt // Preserve original creation time
+
+	if err := r.db.WithContext(ctx).Save(model).Error; err != nil {
+		r.logger.Errorw("failed to update WireGuard config", "node_id", nodeID, "error", err)
+		return fmt.Errorf("failed to update WireGuard config: %w", err)
+	}
+
+	r.logger.Infow("WireGuard config updated", "node_id", nodeID)
+	return nil
+}
+
+// DeleteByNodeID permanently deletes the WireGuardConfig for a node.
+func (r *WireGuardConfigRepository) DeleteByNodeID(ctx context.Context, nodeID uint) error {
+	result := r.db.WithContext(ctx).Unscoped().Where("node_id = ?", nodeID).Delete(&models.WireGuardConfigModel{})
+	if result.Error != nil {
+		r.logger.Errorw("failed to delete WireGuard config", "node_id", nodeID, "error", result.Error)
+		return fmt.Errorf("failed to delete WireGuard config: %w", result.Error)
+	}
+
+	if result.RowsAffected > 0 {
+		r.logger.Infow("WireGuard config deleted", "node_id", nodeID)
+	}
+	return nil
+}
+
+// CreateInTx creates a WireGuardConfig record within a transaction
+func (r *WireGuardConfigRepository) CreateInTx(tx *gorm.DB, nodeID uint, config *vo.WireGuardConfig) error {
+	if config == nil {
+		return nil
+	}
+
+	model, err := r.mapper.ToModel(nodeID, config)
+	if err != nil {
+		return fmt.Errorf("failed to map WireGuard config to model: %w", err)
+	}
+
+	if err := tx.Create(model).Error; err != nil {
+		return fmt.Errorf("failed to create WireGuard config: %w", err)
+	}
+
+	return nil
+}
+
+// UpdateInTx updates a WireGuardConfig record within a transaction
+func (r *WireGuardConfigRepository) UpdateInTx(tx *gorm.DB, nodeID uint, config *vo.WireGuardConfig) error {
+	if config == nil {
+		return r.deleteInTx(tx, nodeID)
+	}
+
+	var existing models.WireGuardConfigModel
+	err := tx.Where("node_id = ?", nodeID).First(&existing).Error
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return r.CreateInTx(tx, nodeID, config)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to check existing WireGuard config: %w", err)
+	}
+
+	model, err := r.mapper.ToModel(nodeID, config)
+	if err != nil {
+		return fmt.Errorf("failed to map WireGuard config to model: %w", err)
+	}
+	model.ID = existing.ID
+	model.CreatedAt = existing.CreatedAt // Preserve original creation time
+
+	if err := tx.Save(model).Error; err != nil {
+		return fmt.Errorf("failed to update WireGuard config: %w", err)
+	}
+
+	return nil
+}
+
+// deleteInTx permanently deletes a WireGuardConfig record within a transaction.
+func (r *WireGuardConfigRepository) deleteInTx(tx *gorm.DB, nodeID uint) error {
+	return tx.Unscoped().Where("node_id = ?", nodeID).Delete(&models.WireGuardConfigModel{}).Error
+}
+
+// DeleteInTx permanently deletes a WireGuardConfig record within a transaction.
+func (r *WireGuardConfigRepository) DeleteInTx(tx *gorm.DB, nodeID uint) error {
+	return r.deleteInTx(tx, nodeID)
+}
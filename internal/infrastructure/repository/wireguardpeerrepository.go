@@ -0,0 +1,85 @@
+package repository
+
+import (
+	"context"
+	"fmt"
+
+	"gorm.io/gorm"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/infrastructure/persistence/models"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// WireGuardPeerRepositoryImpl implements the node.WireGuardPeerRepository interface.
+type WireGuardPeerRepositoryImpl struct {
+	db     *gorm.DB
+	logger logger.Interface
+}
+
+// NewWireGuardPeerRepository creates a new WireGuard peer address repository instance.
+func NewWireGuardPeerRepository(db *gorm.DB, logger logger.Interface) node.WireGuardPeerRepository {
+	return &WireGuardPeerRepositoryImpl{
+		db:     db,
+		logger: logger,
+	}
+}
+
+// ListByNodeID returns the peers of a node, keyed by subscription ID.
+func (r *WireGuardPeerRepositoryImpl) ListByNodeID(ctx context.Context, nodeID uint) (map[uint]*node.WireGuardPeer, error) {
+	var peerModels []models.WireGuardPeerModel
+	if err := r.db.WithContext(ctx).
+		Where("node_id = ?", nodeID).
+		Find(&peerModels).Error; err != nil {
+		r.logger.Errorw("failed to list wireguard peers", "node_id", nodeID, "error", err)
+		return nil, fmt.Errorf("failed to list wireguard peers: %w", err)
+	}
+
+	result := make(map[uint]*node.WireGuardPeer, len(peerModels))
+	for _, model := range peerModels {
+		peer, err := node.ReconstructWireGuardPeer(model.SubscriptionID, model.Address, model.PrivateKey)
+		if err != nil {
+			r.logger.Errorw("failed to reconstruct wireguard peer", "node_id", nodeID, "subscription_id", model.SubscriptionID, "error", err)
+			return nil, fmt.Errorf("failed to reconstruct wireguard peer: %w", err)
+		}
+		result[model.SubscriptionID] = peer
+	}
+	return result, nil
+}
+
+// Create stores the peer of a subscription on a node.
+func (r *WireGuardPeerRepositoryImpl) Create(ctx context.Context, nodeID uint, peer *node.WireGuardPeer) error {
+	model := &models.WireGuardPeerModel{
+		NodeID:         nodeID,
+		SubscriptionID: peer.SubscriptionID(),
+		Address:        peer.Address(),
+		PrivateKey:     peer.PrivateKey(),
+	}
+
+	if err := r.db.WithContext(ctx).Create(model).Error; err != nil {
+		if errors.IsDuplicateError(err) {
+			return errors.NewConflictError("wireguard peer address is already allocated")
+		}
+		r.logger.Errorw("failed to create wireguard peer", "node_id", nodeID, "subscription_id", peer.SubscriptionID(), "error", err)
+		return fmt.Errorf("failed to create wireguard peer: %w", err)
+	}
+
+	return nil
+}
+
+// Delete releases the addresses of the given subscriptions on a node.
+func (r *WireGuardPeerRepositoryImpl) Delete(ctx context.Context, nodeID uint, subscriptionIDs []uint) error {
+	if len(subscriptionIDs) == 0 {
+		return nil
+	}
+
+	if err := r.db.WithContext(ctx).
+		Where("node_id = ? AND subscription_id IN ?", nodeID, subscriptionIDs).
+		Delete(&models.WireGuardPeerModel{}).Error; err != nil {
+		r.logger.Errorw("failed to delete wireguard peers", "node_id", nodeID, "error", err)
+		return fmt.Errorf("failed to delete wireguard peers: %w", err)
+	}
+
+	return nil
+}
@@ -34,10 +34,12 @@ type SchedulerManager struct {
 }
 
 // NewSchedulerManager creates a new SchedulerManager instance.
-// It initializes gocron with the business timezone for cron expressions.
+// It initializes gocron with the business timezone for cron expressions
+// and records job run times for the metrics endpoint.
 func NewSchedulerManager(log logger.Interface) (*SchedulerManager, error) {
 	scheduler, err := gocron.NewScheduler(
 		gocron.WithLocation(biztime.Location()),
+		gocron.WithMonitorStatus(jobMonitor{}),
 	)
 	if err != nil {
 		return nil, err
@@ -191,6 +193,323 @@ func (m *SchedulerManager) processExpiredSubscriptions(
 	}
 }
 
+// ========================================
+// Node Jobs (15 min interval)
+// ========================================
+
+// RegisterNodeJobs registers node maintenance jobs:
+// - Rotate VLESS Reality keys whose rotation interval has elapsed
+// - Expire previous Reality short IDs whose overlap window has closed
+// - Start and end scheduled maintenance windows (every minute)
+func (m *SchedulerManager) RegisterNodeJobs(
+	realityRotationJob BatchJob,
+	maintenanceWindowJob BatchJob,
+) error {
+	_, err := m.scheduler.NewJob(
+		gocron.DurationJob(15*time.Minute),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			m.processRealityRotation(ctx, realityRotationJob)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("node", "reality-rotation"),
+		gocron.WithName("node-reality-rotation"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.scheduler.NewJob(
+		gocron.DurationJob(1*time.Minute),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			m.processMaintenanceWindows(ctx, maintenanceWindowJob)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("node", "maintenance-window"),
+		gocron.WithName("node-maintenance-windows"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Infow("registered node jobs",
+		"reality_rotation_interval", "15m",
+		"maintenance_window_interval", "1m",
+	)
+	return nil
+}
+
+func (m *SchedulerManager) processRealityRotation(ctx context.Context, realityRotationJob BatchJob) {
+	startTime := biztime.NowUTC()
+
+	count, err := realityRotationJob.Execute(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to process reality key rotation",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("reality key rotation processed",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+func (m *SchedulerManager) processMaintenanceWindows(ctx context.Context, maintenanceWindowJob BatchJob) {
+	startTime := biztime.NowUTC()
+
+	count, err := maintenanceWindowJob.Execute(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to process maintenance windows",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("maintenance windows processed",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+// ========================================
+// Certificate Jobs (1 hour / 5 min interval)
+// ========================================
+
+// CertificateReconciler delivers changed certificates to the node agents connected to this instance.
+type CertificateReconciler interface {
+	Reconcile(ctx context.Context) (int, error)
+}
+
+// RegisterCertificateJobs registers node TLS certificate jobs:
+// - Issue pending certificates, renew expiring ones and retry failed issuances (hourly)
+// - Deliver changed certificates to the node agents connected to this instance (every 5 minutes)
+func (m *SchedulerManager) RegisterCertificateJobs(
+	renewalJob BatchJob,
+	reconciler CertificateReconciler,
+) error {
+	_, err := m.scheduler.NewJob(
+		gocron.DurationJob(1*time.Hour),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 55*time.Minute)
+			defer cancel()
+			m.processCertificateRenewal(ctx, renewalJob)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("node", "certificate"),
+		gocron.WithName("node-certificate-renewal"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.scheduler.NewJob(
+		gocron.DurationJob(5*time.Minute),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			m.processCertificateSync(ctx, reconciler)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("node", "certificate"),
+		gocron.WithName("node-certificate-sync"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Infow("registered certificate jobs",
+		"renewal_interval", "1h",
+		"sync_interval", "5m",
+	)
+	return nil
+}
+
+func (m *SchedulerManager) processCertificateRenewal(ctx context.Context, renewalJob BatchJob) {
+	startTime := biztime.NowUTC()
+
+	count, err := renewalJob.Execute(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to process certificate renewal",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("certificate renewal processed",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+func (m *SchedulerManager) processCertificateSync(ctx context.Context, reconciler CertificateReconciler) {
+	startTime := biztime.NowUTC()
+
+	count, err := reconciler.Reconcile(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to sync certificates to nodes",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("certificates synced to nodes",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+// ========================================
+// Device Limit Jobs (1 min interval)
+// ========================================
+
+// DeviceLimitBlockReleaser restores subscriptions whose device limit block has expired.
+type DeviceLimitBlockReleaser interface {
+	ReleaseExpiredBlocks(ctx context.Context) (int, error)
+}
+
+// RegisterDeviceLimitJobs registers the job that releases expired device limit blocks.
+func (m *SchedulerManager) RegisterDeviceLimitJobs(releaser DeviceLimitBlockReleaser) error {
+	_, err := m.scheduler.NewJob(
+		gocron.DurationJob(1*time.Minute),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 2*time.Minute)
+			defer cancel()
+			m.processDeviceLimitBlocks(ctx, releaser)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("node", "device-limit"),
+		gocron.WithName("device-limit-release"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Infow("registered device limit jobs", "interval", "1m")
+	return nil
+}
+
+func (m *SchedulerManager) processDeviceLimitBlocks(ctx context.Context, releaser DeviceLimitBlockReleaser) {
+	startTime := biztime.NowUTC()
+
+	count, err := releaser.ReleaseExpiredBlocks(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to release device limit blocks",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("device limit blocks released",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+// ========================================
+// Forward Jobs (3 min / 1 hour interval)
+// ========================================
+
+// RegisterForwardJobs registers forward maintenance jobs:
+// - Refresh exit metrics of metric-based load balancing rules (every 3 minutes)
+// - Prune the tunnel health timeline past its retention period (hourly)
+func (m *SchedulerManager) RegisterForwardJobs(loadBalanceMetricsJob, tunnelHealthPruneJob BatchJob) error {
+	_, err := m.scheduler.NewJob(
+		gocron.DurationJob(3*time.Minute),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 5*time.Minute)
+			defer cancel()
+			m.processLoadBalanceMetrics(ctx, loadBalanceMetricsJob)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("forward", "load-balance"),
+		gocron.WithName("forward-load-balance-metrics"),
+	)
+	if err != nil {
+		return err
+	}
+
+	_, err = m.scheduler.NewJob(
+		gocron.DurationJob(1*time.Hour),
+		gocron.NewTask(func() {
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Minute)
+			defer cancel()
+			m.processTunnelHealthPrune(ctx, tunnelHealthPruneJob)
+		}),
+		gocron.WithSingletonMode(gocron.LimitModeReschedule),
+		gocron.WithTags("forward", "tunnel-health"),
+		gocron.WithName("forward-tunnel-health-prune"),
+	)
+	if err != nil {
+		return err
+	}
+
+	m.logger.Infow("registered forward jobs",
+		"load_balance_metrics_interval", "3m",
+		"tunnel_health_prune_interval", "1h",
+	)
+	return nil
+}
+
+func (m *SchedulerManager) processLoadBalanceMetrics(ctx context.Context, metricsJob BatchJob) {
+	startTime := biztime.NowUTC()
+
+	count, err := metricsJob.Execute(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to refresh load balance metrics",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("load balance metrics refreshed",
+			"synced_rules", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
+func (m *SchedulerManager) processTunnelHealthPrune(ctx context.Context, pruneJob BatchJob) {
+	startTime := biztime.NowUTC()
+
+	count, err := pruneJob.Execute(ctx)
+	if err != nil {
+		m.logger.Errorw("failed to prune tunnel health records",
+			"error", err,
+			"duration", time.Since(startTime),
+		)
+		return
+	}
+
+	if count > 0 {
+		m.logger.Infow("tunnel health records pruned",
+			"count", count,
+			"duration", time.Since(startTime),
+		)
+	}
+}
+
 // ========================================
 // Usage Aggregation Jobs (cron-based)
 // ========================================
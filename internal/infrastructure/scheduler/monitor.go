@@ -0,0 +1,26 @@
+package scheduler
+
+import (
+	"time"
+
+	"github.com/go-co-op/gocron/v2"
+	"github.com/google/uuid"
+
+	"github.com/orris-inc/orris/internal/shared/metrics"
+)
+
+// jobMonitor records job run times into the scheduler job duration histogram.
+type jobMonitor struct{}
+
+var _ gocron.MonitorStatus = jobMonitor{}
+
+// IncrementJob implements gocron.Monitor. Run counts are derived from the histogram.
+func (jobMonitor) IncrementJob(uuid.UUID, string, []string, gocron.JobStatus) {}
+
+// RecordJobTiming implements gocron.Monitor. Timings are recorded with their status instead.
+func (jobMonitor) RecordJobTiming(time.Time, time.Time, uuid.UUID, string, []string) {}
+
+// RecordJobTimingWithStatus implements gocron.MonitorStatus.
+func (jobMonitor) RecordJobTimingWithStatus(start, end time.Time, _ uuid.UUID, name string, _ []string, status gocron.JobStatus, _ error) {
+	metrics.SchedulerJobDuration.WithLabelValues(name, string(status)).Observe(end.Sub(start).Seconds())
+}
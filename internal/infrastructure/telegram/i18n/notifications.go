@@ -247,6 +247,103 @@ func BuildAgentRecoveryMessage(lang Lang, agentSID, agentName string, onlineAt t
 	)
 }
 
+// TunnelAlertInfo identifies the forward tunnel a tunnel alert is about
+type TunnelAlertInfo struct {
+	RuleSID        string
+	RuleName       string
+	EntryAgentSID  string
+	EntryAgentName string
+	ExitAgentSID   string
+	ExitAgentName  string
+}
+
+// BuildTunnelUnhealthyMessage builds a forward tunnel unhealthy notification message (HTML format)
+func BuildTunnelUnhealthyMessage(lang Lang, info TunnelAlertInfo, failCount int, errMsg string, demoted bool, unhealthyAt time.Time) string {
+	unhealthyAtStr := html.EscapeString(biztime.FormatInBizTimezone(unhealthyAt, "2006-01-02 15:04:05"))
+	if errMsg == "" {
+		errMsg = "-"
+	}
+
+	if lang == EN {
+		action := "⚠️ No other exit is available, traffic still goes to this exit"
+		if demoted {
+			action = "🔀 Exit demoted to backup, traffic moved to the other exits"
+		}
+		return fmt.Sprintf("🔴 <b>Forward Tunnel Unhealthy</b>\n\n"+
+			"<blockquote>Rule: <code>%s</code> (<code>%s</code>)\n"+
+			"Entry: <code>%s</code> (<code>%s</code>)\n"+
+			"Exit: <code>%s</code> (<code>%s</code>)\n"+
+			"Failed checks: %d\n"+
+			"Error: %s\n"+
+			"Time: %s</blockquote>\n\n"+
+			"%s",
+			html.EscapeString(info.RuleName), html.EscapeString(info.RuleSID),
+			html.EscapeString(info.EntryAgentName), html.EscapeString(info.EntryAgentSID),
+			html.EscapeString(info.ExitAgentName), html.EscapeString(info.ExitAgentSID),
+			failCount, html.EscapeString(errMsg), unhealthyAtStr, action,
+		)
+	}
+
+	action := "⚠️ 无其他可用出口，流量仍经过此出口"
+	if demoted {
+		action = "🔀 出口已降级为备用，流量已切换至其他出口"
+	}
+	return fmt.Sprintf("🔴 <b>转发隧道异常告警</b>\n\n"+
+		"<blockquote>规则：<code>%s</code>（<code>%s</code>）\n"+
+		"入口：<code>%s</code>（<code>%s</code>）\n"+
+		"出口：<code>%s</code>（<code>%s</code>）\n"+
+		"连续失败：%d 次\n"+
+		"错误：%s\n"+
+		"时间：%s</blockquote>\n\n"+
+		"%s",
+		html.EscapeString(info.RuleName), html.EscapeString(info.RuleSID),
+		html.EscapeString(info.EntryAgentName), html.EscapeString(info.EntryAgentSID),
+		html.EscapeString(info.ExitAgentName), html.EscapeString(info.ExitAgentSID),
+		failCount, html.EscapeString(errMsg), unhealthyAtStr, action,
+	)
+}
+
+// BuildTunnelRecoveryMessage builds a forward tunnel recovery notification message (HTML format)
+func BuildTunnelRecoveryMessage(lang Lang, info TunnelAlertInfo, restored bool, recoveredAt time.Time, downtimeMinutes int64) string {
+	recoveredAtStr := html.EscapeString(biztime.FormatInBizTimezone(recoveredAt, "2006-01-02 15:04:05"))
+
+	if lang == EN {
+		action := "✅ Forward tunnel is healthy again"
+		if restored {
+			action = "✅ Forward tunnel is healthy again, exit restored to its configured weight"
+		}
+		return fmt.Sprintf("🟢 <b>Forward Tunnel Recovery</b>\n\n"+
+			"<blockquote>Rule: <code>%s</code> (<code>%s</code>)\n"+
+			"Entry: <code>%s</code> (<code>%s</code>)\n"+
+			"Exit: <code>%s</code> (<code>%s</code>)\n"+
+			"Recovered at: %s\n"+
+			"Downtime: %d min</blockquote>\n\n"+
+			"%s",
+			html.EscapeString(info.RuleName), html.EscapeString(info.RuleSID),
+			html.EscapeString(info.EntryAgentName), html.EscapeString(info.EntryAgentSID),
+			html.EscapeString(info.ExitAgentName), html.EscapeString(info.ExitAgentSID),
+			recoveredAtStr, downtimeMinutes, action,
+		)
+	}
+
+	action := "✅ 转发隧道已恢复正常"
+	if restored {
+		action = "✅ 转发隧道已恢复正常，出口已恢复原有权重"
+	}
+	return fmt.Sprintf("🟢 <b>转发隧道恢复通知</b>\n\n"+
+		"<blockquote>规则：<code>%s</code>（<code>%s</code>）\n"+
+		"入口：<code>%s</code>（<code>%s</code>）\n"+
+		"出口：<code>%s</code>（<code>%s</code>）\n"+
+		"恢复时间：%s\n"+
+		"异常时长：%d 分钟</blockquote>\n\n"+
+		"%s",
+		html.EscapeString(info.RuleName), html.EscapeString(info.RuleSID),
+		html.EscapeString(info.EntryAgentName), html.EscapeString(info.EntryAgentSID),
+		html.EscapeString(info.ExitAgentName), html.EscapeString(info.ExitAgentSID),
+		recoveredAtStr, downtimeMinutes, action,
+	)
+}
+
 // BuildMuteKeyboard builds an inline keyboard with mute button for offline alerts.
 // Returns any to avoid circular import with the telegram package which defines
 // its own exported InlineKeyboardMarkup type.
@@ -324,3 +421,32 @@ func formatAmount(amount float64, currency string) string {
 
 	return fmt.Sprintf("%s%.2f", symbol, amount)
 }
+
+// BuildWireGuardPoolExhaustedMessage builds a WireGuard address pool exhausted notification message (HTML format)
+func BuildWireGuardPoolExhaustedMessage(lang Lang, nodeSID, nodeName, addressPool string, capacity, pending int, detectedAt time.Time) string {
+	detectedAtStr := html.EscapeString(biztime.FormatInBizTimezone(detectedAt, "2006-01-02 15:04:05"))
+
+	if lang == EN {
+		return fmt.Sprintf("🔴 <b>WireGuard Address Pool Exhausted</b>\n\n"+
+			"<blockquote>Node: <code>%s</code>\n"+
+			"ID: <code>%s</code>\n"+
+			"Address pool: <code>%s</code> (%d addresses)\n"+
+			"Subscriptions without address: %d\n"+
+			"Time: %s</blockquote>\n\n"+
+			"⚠️ These subscriptions cannot use this node, please enlarge the address pool",
+			html.EscapeString(nodeName), html.EscapeString(nodeSID), html.EscapeString(addressPool), capacity,
+			pending, detectedAtStr,
+		)
+	}
+
+	return fmt.Sprintf("🔴 <b>WireGuard 地址池耗尽告警</b>\n\n"+
+		"<blockquote>节点：<code>%s</code>\n"+
+		"ID：<code>%s</code>\n"+
+		"地址池：<code>%s</code>（%d 个地址）\n"+
+		"未分配地址的订阅：%d\n"+
+		"时间：%s</blockquote>\n\n"+
+		"⚠️ 这些订阅无法使用该节点，请扩大地址池",
+		html.EscapeString(nodeName), html.EscapeString(nodeSID), html.EscapeString(addressPool), capacity,
+		pending, detectedAtStr,
+	)
+}
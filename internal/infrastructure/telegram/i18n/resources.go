@@ -111,6 +111,83 @@ func BuildResourceExpiringMessage(lang Lang, agents []ExpiringResourceInfo, node
 	return msg
 }
 
+// ExpiringCertificateInfo holds lightweight node certificate info for building expiring notification messages.
+type ExpiringCertificateInfo struct {
+	SID           string
+	Domain        string
+	ExpiresAt     time.Time
+	DaysRemaining int
+	Expired       bool
+	AutoRenew     bool
+	LastError     string
+}
+
+// BuildCertificateExpiringMessage builds a notification message (HTML format) for node TLS
+// certificates that expire soon or have expired, usually because renewal keeps failing.
+func BuildCertificateExpiringMessage(lang Lang, certs []ExpiringCertificateInfo) string {
+	if len(certs) == 0 {
+		return ""
+	}
+
+	var msg string
+
+	if lang == EN {
+		msg = fmt.Sprintf("🔐 <b>Node Certificate Expiration Reminder</b>\n\n<b>Certificate (%d)</b>\n<blockquote>", len(certs))
+		for i, c := range certs {
+			if i > 0 {
+				msg += "\n"
+			}
+			expiresAtStr := biztime.FormatInBizTimezone(c.ExpiresAt, "2006-01-02")
+			msg += fmt.Sprintf("%s <code>%s</code> - %s\n", getCertificateUrgencyIcon(c), html.EscapeString(c.SID), html.EscapeString(c.Domain))
+			if c.Expired {
+				msg += fmt.Sprintf("   └ expired (%s)", expiresAtStr)
+			} else {
+				msg += fmt.Sprintf("   └ %s (%s)", formatDaysRemainingEN(c.DaysRemaining), expiresAtStr)
+			}
+			if !c.AutoRenew {
+				msg += "\n   └ Auto-renew: off"
+			}
+			if c.LastError != "" {
+				msg += fmt.Sprintf("\n   └ Last error: %s", html.EscapeString(c.LastError))
+			}
+		}
+		msg += "</blockquote>\n"
+		msg += "\n💡 Check the DNS provider settings or issue the certificate manually"
+	} else {
+		msg = fmt.Sprintf("🔐 <b>节点证书即将到期提醒</b>\n\n<b>证书 (%d个)</b>\n<blockquote>", len(certs))
+		for i, c := range certs {
+			if i > 0 {
+				msg += "\n"
+			}
+			expiresAtStr := biztime.FormatInBizTimezone(c.ExpiresAt, "2006-01-02")
+			msg += fmt.Sprintf("%s <code>%s</code> - %s\n", getCertificateUrgencyIcon(c), html.EscapeString(c.SID), html.EscapeString(c.Domain))
+			if c.Expired {
+				msg += fmt.Sprintf("   └ 已过期 (%s)", expiresAtStr)
+			} else {
+				msg += fmt.Sprintf("   └ %s (%s)", formatDaysRemainingZH(c.DaysRemaining), expiresAtStr)
+			}
+			if !c.AutoRenew {
+				msg += "\n   └ 自动续期: 已关闭"
+			}
+			if c.LastError != "" {
+				msg += fmt.Sprintf("\n   └ 最近错误: %s", html.EscapeString(c.LastError))
+			}
+		}
+		msg += "</blockquote>\n"
+		msg += "\n💡 请检查 DNS 服务商配置或手动签发证书"
+	}
+
+	return msg
+}
+
+// getCertificateUrgencyIcon returns the urgency indicator for a certificate, treating expired ones as critical
+func getCertificateUrgencyIcon(c ExpiringCertificateInfo) string {
+	if c.Expired {
+		return "⛔"
+	}
+	return getUrgencyIcon(c.DaysRemaining)
+}
+
 // formatDaysRemainingZH returns a Chinese human-readable string for days remaining
 func formatDaysRemainingZH(days int) string {
 	switch days {
@@ -27,7 +27,7 @@ func NewSubscriptionTemplateLoader(path string, logger logger.Interface) *Subscr
 
 // Load loads all templates from the configured directory
 // Template files are named: custom.{format}.yaml or custom.{format}.conf
-// Supported formats: clash, surge, v2ray, sip008, base64
+// Supported formats: clash, surge, quanx, loon, v2ray, sip008, base64
 func (l *SubscriptionTemplateLoader) Load() error {
 	// Check if templates directory exists
 	if _, err := os.Stat(l.path); os.IsNotExist(err) {
@@ -36,7 +36,7 @@ func (l *SubscriptionTemplateLoader) Load() error {
 	}
 
 	// Supported template file patterns
-	formats := []string{"clash", "surge", "v2ray", "sip008", "base64"}
+	formats := []string{"clash", "surge", "quanx", "loon", "v2ray", "sip008", "base64"}
 	extensions := []string{".yaml", ".yml", ".conf"}
 
 	for _, format := range formats {
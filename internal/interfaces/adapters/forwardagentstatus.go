@@ -118,6 +118,14 @@ func (a *ForwardAgentStatusAdapter) UpdateStatus(ctx context.Context, agentID ui
 		return fmt.Errorf("failed to store forward agent status: %w", err)
 	}
 
+	// History is best-effort: a failure must not reject the status report
+	if err := systemstatus.RecordHistory(ctx, a.redisClient, systemstatus.HistoryResourceForwardAgent, agentID, &status.SystemStatus, biztime.NowUTC()); err != nil {
+		a.logger.Warnw("failed to record forward agent metrics history",
+			"error", err,
+			"agent_id", agentID,
+		)
+	}
+
 	a.logger.Debugw("forward agent status updated in redis",
 		"agent_id", agentID,
 		"cpu", status.CPUPercent,
@@ -0,0 +1,33 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/orris-inc/orris/internal/domain/node"
+)
+
+// MaintenanceNodeCheckerAdapter reports the nodes covered by running maintenance windows.
+type MaintenanceNodeCheckerAdapter struct {
+	windowRepo node.MaintenanceWindowRepository
+}
+
+// NewMaintenanceNodeCheckerAdapter creates a new MaintenanceNodeCheckerAdapter.
+func NewMaintenanceNodeCheckerAdapter(windowRepo node.MaintenanceWindowRepository) *MaintenanceNodeCheckerAdapter {
+	return &MaintenanceNodeCheckerAdapter{windowRepo: windowRepo}
+}
+
+// MaintenanceNodeIDs implements telegramAdminUsecases.NodeMaintenanceChecker.
+func (a *MaintenanceNodeCheckerAdapter) MaintenanceNodeIDs(ctx context.Context) (map[uint]struct{}, error) {
+	windows, err := a.windowRepo.ListActive(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	ids := make(map[uint]struct{})
+	for _, w := range windows {
+		for _, nodeID := range w.AffectedNodeIDs() {
+			ids[nodeID] = struct{}{}
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,99 @@
+package adapters
+
+import (
+	"context"
+	"fmt"
+
+	nodeUsecases "github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/domain/notification"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// maintenanceAnnouncementPriority is the announcement priority used for maintenance notices (1-5)
+const maintenanceAnnouncementPriority = 4
+
+// MaintenanceNoticePublisherAdapter publishes node maintenance notices through the notification domain.
+type MaintenanceNoticePublisherAdapter struct {
+	announcementRepo notification.AnnouncementRepository
+	notificationRepo notification.NotificationRepository
+	logger           logger.Interface
+}
+
+// NewMaintenanceNoticePublisherAdapter creates a new MaintenanceNoticePublisherAdapter.
+func NewMaintenanceNoticePublisherAdapter(
+	announcementRepo notification.AnnouncementRepository,
+	notificationRepo notification.NotificationRepository,
+	logger logger.Interface,
+) *MaintenanceNoticePublisherAdapter {
+	return &MaintenanceNoticePublisherAdapter{
+		announcementRepo: announcementRepo,
+		notificationRepo: notificationRepo,
+		logger:           logger,
+	}
+}
+
+// PublishMaintenanceNotice implements nodeUsecases.MaintenanceNoticePublisher.
+// The announcement is published immediately and expires when the window ends.
+// Subscribers of the affected nodes also get an in-app notification.
+func (a *MaintenanceNoticePublisherAdapter) PublishMaintenanceNotice(ctx context.Context, notice nodeUsecases.MaintenanceNotice) (uint, error) {
+	expiresAt := notice.ExpiresAt
+	announcement, err := notification.CreateMaintenanceAnnouncement(
+		notice.Title,
+		notice.Content,
+		notice.CreatorID,
+		maintenanceAnnouncementPriority,
+		nil,
+		&expiresAt,
+		id.NewAnnouncementID,
+	)
+	if err != nil {
+		return 0, fmt.Errorf("failed to create maintenance announcement: %w", err)
+	}
+	if err := announcement.Publish(); err != nil {
+		return 0, fmt.Errorf("failed to publish maintenance announcement: %w", err)
+	}
+	if err := a.announcementRepo.Create(ctx, announcement); err != nil {
+		return 0, fmt.Errorf("failed to save maintenance announcement: %w", err)
+	}
+
+	if len(notice.UserIDs) > 0 {
+		notifications := make([]*notification.Notification, 0, len(notice.UserIDs))
+		for _, userID := range notice.UserIDs {
+			n, err := notification.CreateSystemNotification(userID, notice.Title, notice.Content)
+			if err != nil {
+				a.logger.Warnw("failed to create maintenance notification", "user_id", userID, "error", err)
+				continue
+			}
+			notifications = append(notifications, n)
+		}
+		// The announcement is already public, so a failed fan-out is not fatal
+		if err := a.notificationRepo.BulkCreate(ctx, notifications); err != nil {
+			a.logger.Warnw("failed to send maintenance notifications",
+				"announcement_id", announcement.ID(),
+				"user_count", len(notifications),
+				"error", err,
+			)
+		}
+	}
+
+	return announcement.ID(), nil
+}
+
+// WithdrawMaintenanceNotice implements nodeUsecases.MaintenanceNoticePublisher.
+func (a *MaintenanceNoticePublisherAdapter) WithdrawMaintenanceNotice(ctx context.Context, announcementID uint) error {
+	announcement, err := a.announcementRepo.GetByID(ctx, announcementID)
+	if err != nil {
+		return fmt.Errorf("failed to get maintenance announcement: %w", err)
+	}
+	if announcement == nil {
+		return nil
+	}
+	if err := announcement.Archive(); err != nil {
+		return fmt.Errorf("failed to archive maintenance announcement: %w", err)
+	}
+	if err := a.announcementRepo.Update(ctx, announcement); err != nil {
+		return fmt.Errorf("failed to save maintenance announcement: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,226 @@
+package adapters
+
+import (
+	"cmp"
+	"context"
+	"slices"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	nodeUsecases "github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/domain/forward"
+	"github.com/orris-inc/orris/internal/domain/node"
+	"github.com/orris-inc/orris/internal/domain/payment"
+	vo "github.com/orris-inc/orris/internal/domain/payment/valueobjects"
+	"github.com/orris-inc/orris/internal/shared/metrics"
+)
+
+// OnlineConnectionLister lists the nodes and forward agents connected to this instance's hub.
+type OnlineConnectionLister interface {
+	GetOnlineNodes() []uint
+	GetOnlineAgents() []uint
+}
+
+var (
+	nodeUpDesc = prometheus.NewDesc("orris_node_up",
+		"Whether the node agent is connected to this instance (1) or not (0).",
+		[]string{"node_id", "node_name"}, nil)
+	nodeOnlineSubscriptionsDesc = prometheus.NewDesc("orris_node_online_subscriptions",
+		"Number of subscriptions currently online on the node.",
+		[]string{"node_id", "node_name"}, nil)
+	nodeTrafficDesc = prometheus.NewDesc("orris_node_traffic_bytes_total",
+		"Raw traffic reported by the node since this instance started.",
+		[]string{"node_id", "node_name", "direction"}, nil)
+
+	forwardAgentUpDesc = prometheus.NewDesc("orris_forward_agent_up",
+		"Whether the forward agent is connected to this instance (1) or not (0).",
+		[]string{"agent_id", "agent_name"}, nil)
+	forwardRuleTrafficDesc = prometheus.NewDesc("orris_forward_rule_traffic_bytes_total",
+		"Raw traffic reported for the forward rule since this instance started.",
+		[]string{"rule_id", "rule_name", "direction"}, nil)
+
+	paymentsDesc = prometheus.NewDesc("orris_payments",
+		"Number of payments by status.",
+		[]string{"status"}, nil)
+)
+
+// NodeMetricsCollector exposes node online state, online subscription counts and traffic.
+type NodeMetricsCollector struct {
+	nodeRepo      node.NodeRepository
+	hub           OnlineConnectionLister
+	onlineCounter nodeUsecases.NodeOnlineSubscriptionCounter
+}
+
+// NewNodeMetricsCollector creates a new NodeMetricsCollector.
+func NewNodeMetricsCollector(
+	nodeRepo node.NodeRepository,
+	hub OnlineConnectionLister,
+	onlineCounter nodeUsecases.NodeOnlineSubscriptionCounter,
+) *NodeMetricsCollector {
+	return &NodeMetricsCollector{
+		nodeRepo:      nodeRepo,
+		hub:           hub,
+		onlineCounter: onlineCounter,
+	}
+}
+
+// Describe implements metrics.ScrapeCollector.
+func (c *NodeMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- nodeUpDesc
+	ch <- nodeOnlineSubscriptionsDesc
+	ch <- nodeTrafficDesc
+}
+
+// Collect implements metrics.ScrapeCollector.
+func (c *NodeMetricsCollector) Collect(ctx context.Context) ([]prometheus.Metric, error) {
+	nodes, err := c.nodeRepo.GetAllMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(nodes, func(a, b *node.NodeMetadata) int { return cmp.Compare(a.ID, b.ID) })
+
+	ids := make([]uint, len(nodes))
+	for i, n := range nodes {
+		ids[i] = n.ID
+	}
+	subscriptionCounts, err := c.onlineCounter.GetNodeOnlineSubscriptionCounts(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	online := toSet(c.hub.GetOnlineNodes())
+	traffic := metrics.NodeTraffic.Snapshot()
+	collected := make([]prometheus.Metric, 0, 2*len(nodes)+2*len(traffic))
+	for _, n := range nodes {
+		collected = append(collected,
+			prometheus.MustNewConstMetric(nodeUpDesc, prometheus.GaugeValue, boolToFloat(online[n.ID]), n.SID, n.Name),
+			prometheus.MustNewConstMetric(nodeOnlineSubscriptionsDesc, prometheus.GaugeValue, float64(subscriptionCounts[n.ID]), n.SID, n.Name),
+		)
+		t, ok := traffic[n.ID]
+		if !ok {
+			continue
+		}
+		collected = append(collected,
+			prometheus.MustNewConstMetric(nodeTrafficDesc, prometheus.CounterValue, float64(t.Upload), n.SID, n.Name, "upload"),
+			prometheus.MustNewConstMetric(nodeTrafficDesc, prometheus.CounterValue, float64(t.Download), n.SID, n.Name, "download"),
+		)
+	}
+	return collected, nil
+}
+
+// ForwardMetricsCollector exposes forward agent online state and per-rule traffic.
+type ForwardMetricsCollector struct {
+	agentRepo forward.AgentRepository
+	ruleRepo  forward.RuleReader
+	hub       OnlineConnectionLister
+}
+
+// NewForwardMetricsCollector creates a new ForwardMetricsCollector.
+func NewForwardMetricsCollector(
+	agentRepo forward.AgentRepository,
+	ruleRepo forward.RuleReader,
+	hub OnlineConnectionLister,
+) *ForwardMetricsCollector {
+	return &ForwardMetricsCollector{
+		agentRepo: agentRepo,
+		ruleRepo:  ruleRepo,
+		hub:       hub,
+	}
+}
+
+// Describe implements metrics.ScrapeCollector.
+func (c *ForwardMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- forwardAgentUpDesc
+	ch <- forwardRuleTrafficDesc
+}
+
+// Collect implements metrics.ScrapeCollector.
+func (c *ForwardMetricsCollector) Collect(ctx context.Context) ([]prometheus.Metric, error) {
+	agents, err := c.agentRepo.GetAllEnabledMetadata(ctx)
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(agents, func(a, b *forward.AgentMetadata) int { return cmp.Compare(a.ID, b.ID) })
+
+	traffic := metrics.ForwardRuleTraffic.Snapshot()
+	ruleIDs := make([]uint, 0, len(traffic))
+	for id := range traffic {
+		ruleIDs = append(ruleIDs, id)
+	}
+	slices.Sort(ruleIDs)
+
+	var rules map[uint]*forward.ForwardRule
+	if len(ruleIDs) > 0 {
+		rules, err = c.ruleRepo.GetByIDs(ctx, ruleIDs)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	online := toSet(c.hub.GetOnlineAgents())
+	collected := make([]prometheus.Metric, 0, len(agents)+2*len(ruleIDs))
+	for _, a := range agents {
+		collected = append(collected,
+			prometheus.MustNewConstMetric(forwardAgentUpDesc, prometheus.GaugeValue, boolToFloat(online[a.ID]), a.SID, a.Name))
+	}
+
+	for _, id := range ruleIDs {
+		rule, ok := rules[id]
+		if !ok {
+			continue // rule deleted since the traffic was reported
+		}
+		t := traffic[id]
+		collected = append(collected,
+			prometheus.MustNewConstMetric(forwardRuleTrafficDesc, prometheus.CounterValue, float64(t.Upload), rule.SID(), rule.Name(), "upload"),
+			prometheus.MustNewConstMetric(forwardRuleTrafficDesc, prometheus.CounterValue, float64(t.Download), rule.SID(), rule.Name(), "download"),
+		)
+	}
+	return collected, nil
+}
+
+// PaymentMetricsCollector exposes payment counts by status.
+type PaymentMetricsCollector struct {
+	paymentRepo payment.PaymentRepository
+}
+
+// NewPaymentMetricsCollector creates a new PaymentMetricsCollector.
+func NewPaymentMetricsCollector(paymentRepo payment.PaymentRepository) *PaymentMetricsCollector {
+	return &PaymentMetricsCollector{paymentRepo: paymentRepo}
+}
+
+// Describe implements metrics.ScrapeCollector.
+func (c *PaymentMetricsCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- paymentsDesc
+}
+
+// Collect implements metrics.ScrapeCollector.
+func (c *PaymentMetricsCollector) Collect(ctx context.Context) ([]prometheus.Metric, error) {
+	counts, err := c.paymentRepo.CountByStatus(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	// Always emit the known statuses so absent ones read as zero rather than missing
+	statuses := []vo.PaymentStatus{vo.PaymentStatusPending, vo.PaymentStatusPaid, vo.PaymentStatusFailed, vo.PaymentStatusExpired}
+	collected := make([]prometheus.Metric, 0, len(statuses))
+	for _, status := range statuses {
+		collected = append(collected,
+			prometheus.MustNewConstMetric(paymentsDesc, prometheus.GaugeValue, float64(counts[status]), status.String()))
+	}
+	return collected, nil
+}
+
+func toSet(ids []uint) map[uint]bool {
+	set := make(map[uint]bool, len(ids))
+	for _, id := range ids {
+		set[id] = true
+	}
+	return set
+}
+
+func boolToFloat(b bool) float64 {
+	if b {
+		return 1
+	}
+	return 0
+}
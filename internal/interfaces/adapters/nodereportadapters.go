@@ -380,6 +380,14 @@ func (a *NodeSystemStatusUpdaterAdapter) UpdateSystemStatus(ctx context.Context,
 		return fmt.Errorf("failed to store node status: %w", err)
 	}
 
+	// History is best-effort: a failure must not reject the status report
+	if err := systemstatus.RecordHistory(ctx, a.redisClient, systemstatus.HistoryResourceNode, nodeID, &status.SystemStatus, biztime.NowUTC()); err != nil {
+		a.logger.Warnw("failed to record node metrics history",
+			"error", err,
+			"node_id", nodeID,
+		)
+	}
+
 	a.logger.Debugw("node system status updated in redis",
 		"node_id", nodeID,
 		"cpu_percent", status.CPUPercent,
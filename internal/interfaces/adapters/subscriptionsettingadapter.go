@@ -2,7 +2,10 @@ package adapters
 
 import (
 	"context"
+	"encoding/json"
+	"strings"
 
+	nodeUsecases "github.com/orris-inc/orris/internal/application/node/usecases"
 	settingUsecases "github.com/orris-inc/orris/internal/application/setting/usecases"
 )
 
@@ -13,8 +16,15 @@ const SubscriptionSettingCategory = "subscription"
 const (
 	// SubscriptionSettingShowInfoNodes controls whether to show info nodes in subscription.
 	SubscriptionSettingShowInfoNodes = "show_info_nodes"
+	// SubscriptionSettingUserAgentRules holds the admin-defined User-Agent to format mapping (JSON array).
+	SubscriptionSettingUserAgentRules = "user_agent_rules"
+	// SubscriptionSettingProfileUpdateInterval is the suggested client auto-update interval in hours.
+	SubscriptionSettingProfileUpdateInterval = "profile_update_interval"
 )
 
+// DefaultProfileUpdateIntervalHours is the default Profile-Update-Interval header value.
+const DefaultProfileUpdateIntervalHours = 24
+
 // SubscriptionSettingProviderAdapter adapts SettingProvider to SubscriptionSettingProvider interface.
 type SubscriptionSettingProviderAdapter struct {
 	provider *settingUsecases.SettingProvider
@@ -32,3 +42,44 @@ func NewSubscriptionSettingProviderAdapter(provider *settingUsecases.SettingProv
 func (a *SubscriptionSettingProviderAdapter) IsShowInfoNodesEnabled(ctx context.Context) bool {
 	return a.provider.GetBool(ctx, SubscriptionSettingCategory, SubscriptionSettingShowInfoNodes, false)
 }
+
+// GetProfileInfo returns the profile metadata for subscription response headers.
+// The title is the site name (branding.app_name) and the web page URL is the frontend URL.
+func (a *SubscriptionSettingProviderAdapter) GetProfileInfo(ctx context.Context) *nodeUsecases.SubscriptionProfileInfo {
+	interval := a.provider.GetInt(ctx, SubscriptionSettingCategory, SubscriptionSettingProfileUpdateInterval, DefaultProfileUpdateIntervalHours)
+	if interval < 0 {
+		interval = 0
+	}
+
+	webPageURL, _ := a.provider.GetFrontendURL(ctx).Value.(string)
+
+	return &nodeUsecases.SubscriptionProfileInfo{
+		Title:               a.provider.GetString(ctx, "branding", "app_name", "Orris"),
+		UpdateIntervalHours: interval,
+		WebPageURL:          strings.TrimSpace(webPageURL),
+	}
+}
+
+// GetUserAgentFormatRules returns the admin-defined User-Agent to format rules.
+// Rules with an empty pattern or an unsupported format are skipped.
+// Returns nil when no rules are configured or the stored value cannot be parsed.
+func (a *SubscriptionSettingProviderAdapter) GetUserAgentFormatRules(ctx context.Context) []nodeUsecases.UserAgentFormatRule {
+	raw := a.provider.GetString(ctx, SubscriptionSettingCategory, SubscriptionSettingUserAgentRules, "")
+	if raw == "" {
+		return nil
+	}
+
+	var rules []nodeUsecases.UserAgentFormatRule
+	if err := json.Unmarshal([]byte(raw), &rules); err != nil {
+		return nil
+	}
+
+	valid := make([]nodeUsecases.UserAgentFormatRule, 0, len(rules))
+	for _, rule := range rules {
+		if rule.Validate() != nil {
+			continue
+		}
+		valid = append(valid, rule)
+	}
+	return valid
+}
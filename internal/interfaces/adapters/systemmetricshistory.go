@@ -0,0 +1,55 @@
+package adapters
+
+import (
+	"context"
+
+	"github.com/redis/go-redis/v9"
+
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
+	"github.com/orris-inc/orris/internal/interfaces/adapters/systemstatus"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// SystemMetricsHistoryAdapter queries the downsampled system metrics history that the
+// node and forward agent status updaters record on every status report.
+type SystemMetricsHistoryAdapter struct {
+	redisClient *redis.Client
+	logger      logger.Interface
+}
+
+// NewSystemMetricsHistoryAdapter creates a new system metrics history adapter.
+func NewSystemMetricsHistoryAdapter(
+	redisClient *redis.Client,
+	logger logger.Interface,
+) *SystemMetricsHistoryAdapter {
+	return &SystemMetricsHistoryAdapter{
+		redisClient: redisClient,
+		logger:      logger,
+	}
+}
+
+// GetNodeMetrics returns the metrics history of a node.
+func (a *SystemMetricsHistoryAdapter) GetNodeMetrics(ctx context.Context, nodeID uint, rng commondto.SystemMetricsRange) ([]commondto.SystemMetricsPoint, error) {
+	points, err := systemstatus.QueryHistory(ctx, a.redisClient, systemstatus.HistoryResourceNode, nodeID, rng)
+	if err != nil {
+		a.logger.Errorw("failed to get node metrics history from redis",
+			"error", err,
+			"node_id", nodeID,
+		)
+		return nil, err
+	}
+	return points, nil
+}
+
+// GetAgentMetrics returns the metrics history of a forward agent.
+func (a *SystemMetricsHistoryAdapter) GetAgentMetrics(ctx context.Context, agentID uint, rng commondto.SystemMetricsRange) ([]commondto.SystemMetricsPoint, error) {
+	points, err := systemstatus.QueryHistory(ctx, a.redisClient, systemstatus.HistoryResourceForwardAgent, agentID, rng)
+	if err != nil {
+		a.logger.Errorw("failed to get forward agent metrics history from redis",
+			"error", err,
+			"agent_id", agentID,
+		)
+		return nil, err
+	}
+	return points, nil
+}
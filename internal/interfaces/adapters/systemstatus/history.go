@@ -0,0 +1,169 @@
+package systemstatus
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
+)
+
+// Resource types used in system metrics history keys.
+const (
+	HistoryResourceNode         = "node"
+	HistoryResourceForwardAgent = "forward_agent"
+)
+
+const (
+	// Key format: sysmetrics:{resourceType}:{resourceID}:{resolution}:{bucketUnix}
+	historyKeyPrefix = "sysmetrics:"
+
+	// Hash fields: count plus sum_{metric} and max_{metric} for each history metric
+	historyFieldCount = "count"
+	historySumPrefix  = "sum_"
+	historyMaxPrefix  = "max_"
+
+	// Maximum number of buckets returned by a single query (30 days of hours, 24 hours of minutes)
+	historyMaxBuckets = 24 * 60
+)
+
+// historyMetrics lists the metrics kept in history, in SystemMetricsPoint field order.
+var historyMetrics = []string{
+	FieldCPUPercent,
+	FieldMemoryPercent,
+	FieldDiskPercent,
+	FieldNetworkRxRate,
+	FieldNetworkTxRate,
+	FieldTCPConnections,
+	FieldUDPConnections,
+}
+
+// historyResolutions lists the resolutions every sample is aggregated into.
+var historyResolutions = []commondto.SystemMetricsResolution{
+	commondto.SystemMetricsResolutionMinute,
+	commondto.SystemMetricsResolutionHour,
+}
+
+// recordHistoryScript adds one sample to the minute and hour buckets in a single round trip.
+// KEYS: bucket keys. ARGV[1..#KEYS]: TTL seconds per key, followed by metric name/value pairs.
+var recordHistoryScript = redis.NewScript(`
+	local n = #KEYS
+	for k = 1, n do
+		local key = KEYS[k]
+		redis.call('HINCRBY', key, 'count', 1)
+		for i = n + 1, #ARGV, 2 do
+			local name = ARGV[i]
+			local value = tonumber(ARGV[i + 1])
+			redis.call('HINCRBYFLOAT', key, 'sum_' .. name, value)
+			local current = tonumber(redis.call('HGET', key, 'max_' .. name))
+			if current == nil or value > current then
+				redis.call('HSET', key, 'max_' .. name, ARGV[i + 1])
+			end
+		end
+		redis.call('EXPIRE', key, ARGV[k])
+	end
+	return n
+`)
+
+// historyKey generates the Redis key of one history bucket.
+func historyKey(resourceType string, resourceID uint, resolution commondto.SystemMetricsResolution, bucket time.Time) string {
+	return fmt.Sprintf("%s%s:%d:%s:%d", historyKeyPrefix, resourceType, resourceID, resolution, bucket.Unix())
+}
+
+// historyValues returns the sample values in historyMetrics order.
+func historyValues(status *commondto.SystemStatus) []float64 {
+	return []float64{
+		status.CPUPercent,
+		status.MemoryPercent,
+		status.DiskPercent,
+		float64(status.NetworkRxRate),
+		float64(status.NetworkTxRate),
+		float64(status.TCPConnections),
+		float64(status.UDPConnections),
+	}
+}
+
+// RecordHistory aggregates a status report into the minute and hour history buckets of a resource.
+func RecordHistory(ctx context.Context, client *redis.Client, resourceType string, resourceID uint, status *commondto.SystemStatus, now time.Time) error {
+	keys := make([]string, 0, len(historyResolutions))
+	args := make([]any, 0, len(historyResolutions)+2*len(historyMetrics))
+	for _, r := range historyResolutions {
+		keys = append(keys, historyKey(resourceType, resourceID, r, now.UTC().Truncate(r.Step())))
+		// Keep one extra bucket so the oldest bucket in the retention window is complete
+		args = append(args, int((r.Retention() + r.Step()).Seconds()))
+	}
+	for i, v := range historyValues(status) {
+		args = append(args, historyMetrics[i], strconv.FormatFloat(v, 'f', -1, 64))
+	}
+
+	if err := recordHistoryScript.Run(ctx, client, keys, args...).Err(); err != nil {
+		return fmt.Errorf("failed to record system metrics history: %w", err)
+	}
+	return nil
+}
+
+// QueryHistory returns the history buckets of a resource within the range, oldest first.
+// Buckets without samples are skipped.
+func QueryHistory(ctx context.Context, client *redis.Client, resourceType string, resourceID uint, rng commondto.SystemMetricsRange) ([]commondto.SystemMetricsPoint, error) {
+	step := rng.Resolution.Step()
+
+	var buckets []time.Time
+	for t := rng.From.Truncate(step); !t.After(rng.To) && len(buckets) < historyMaxBuckets+1; t = t.Add(step) {
+		buckets = append(buckets, t)
+	}
+	if len(buckets) == 0 {
+		return []commondto.SystemMetricsPoint{}, nil
+	}
+
+	pipe := client.Pipeline()
+	cmds := make([]*redis.MapStringStringCmd, len(buckets))
+	for i, bucket := range buckets {
+		cmds[i] = pipe.HGetAll(ctx, historyKey(resourceType, resourceID, rng.Resolution, bucket))
+	}
+	if _, err := pipe.Exec(ctx); err != nil && err != redis.Nil {
+		return nil, fmt.Errorf("failed to query system metrics history: %w", err)
+	}
+
+	points := make([]commondto.SystemMetricsPoint, 0, len(buckets))
+	for i, cmd := range cmds {
+		values, err := cmd.Result()
+		if err != nil || len(values) == 0 {
+			continue
+		}
+		if point, ok := parseHistoryBucket(buckets[i], values); ok {
+			points = append(points, point)
+		}
+	}
+
+	return points, nil
+}
+
+// parseHistoryBucket converts a history hash into a time series point.
+func parseHistoryBucket(bucket time.Time, values map[string]string) (commondto.SystemMetricsPoint, bool) {
+	count, _ := strconv.Atoi(values[historyFieldCount])
+	if count <= 0 {
+		return commondto.SystemMetricsPoint{}, false
+	}
+
+	stats := make([]commondto.MetricStat, len(historyMetrics))
+	for i, name := range historyMetrics {
+		sum, _ := strconv.ParseFloat(values[historySumPrefix+name], 64)
+		peak, _ := strconv.ParseFloat(values[historyMaxPrefix+name], 64)
+		stats[i] = commondto.MetricStat{Avg: sum / float64(count), Max: peak}
+	}
+
+	return commondto.SystemMetricsPoint{
+		Time:           bucket,
+		Samples:        count,
+		CPUPercent:     stats[0],
+		MemoryPercent:  stats[1],
+		DiskPercent:    stats[2],
+		NetworkRxRate:  stats[3],
+		NetworkTxRate:  stats[4],
+		TCPConnections: stats[5],
+		UDPConnections: stats[6],
+	}, true
+}
@@ -0,0 +1,97 @@
+package systemstatus
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
+)
+
+func TestRecordAndQueryHistory(t *testing.T) {
+	mr := miniredis.RunT(t)
+	client := redis.NewClient(&redis.Options{Addr: mr.Addr()})
+	defer client.Close()
+
+	ctx := context.Background()
+	base := time.Date(2026, 3, 1, 10, 0, 0, 0, time.UTC)
+
+	samples := []struct {
+		at     time.Time
+		status commondto.SystemStatus
+	}{
+		{base.Add(5 * time.Second), commondto.SystemStatus{CPUPercent: 20, NetworkRxRate: 1000, TCPConnections: 10}},
+		{base.Add(35 * time.Second), commondto.SystemStatus{CPUPercent: 80, NetworkRxRate: 3000, TCPConnections: 30}},
+		{base.Add(90 * time.Second), commondto.SystemStatus{CPUPercent: 50, NetworkRxRate: 2000, TCPConnections: 20}},
+	}
+	for _, s := range samples {
+		require.NoError(t, RecordHistory(ctx, client, HistoryResourceNode, 7, &s.status, s.at))
+	}
+	// Other resources must not leak into the series
+	require.NoError(t, RecordHistory(ctx, client, HistoryResourceForwardAgent, 7, &commondto.SystemStatus{CPUPercent: 99}, base))
+
+	minutes, err := QueryHistory(ctx, client, HistoryResourceNode, 7, commondto.SystemMetricsRange{
+		Resolution: commondto.SystemMetricsResolutionMinute,
+		From:       base,
+		To:         base.Add(5 * time.Minute),
+	})
+	require.NoError(t, err)
+	require.Len(t, minutes, 2, "empty buckets are skipped")
+
+	assert.Equal(t, base, minutes[0].Time)
+	assert.Equal(t, 2, minutes[0].Samples)
+	assert.InDelta(t, 50, minutes[0].CPUPercent.Avg, 0.001)
+	assert.InDelta(t, 80, minutes[0].CPUPercent.Max, 0.001)
+	assert.InDelta(t, 2000, minutes[0].NetworkRxRate.Avg, 0.001)
+	assert.InDelta(t, 30, minutes[0].TCPConnections.Max, 0.001)
+	assert.Equal(t, base.Add(time.Minute), minutes[1].Time)
+	assert.Equal(t, 1, minutes[1].Samples)
+
+	hours, err := QueryHistory(ctx, client, HistoryResourceNode, 7, commondto.SystemMetricsRange{
+		Resolution: commondto.SystemMetricsResolutionHour,
+		From:       base.Add(-time.Hour),
+		To:         base.Add(time.Hour),
+	})
+	require.NoError(t, err)
+	require.Len(t, hours, 1)
+	assert.Equal(t, 3, hours[0].Samples)
+	assert.InDelta(t, 50, hours[0].CPUPercent.Avg, 0.001)
+	assert.InDelta(t, 80, hours[0].CPUPercent.Max, 0.001)
+
+	minuteTTL := mr.TTL(historyKey(HistoryResourceNode, 7, commondto.SystemMetricsResolutionMinute, base))
+	assert.Equal(t, 24*time.Hour+time.Minute, minuteTTL)
+	hourTTL := mr.TTL(historyKey(HistoryResourceNode, 7, commondto.SystemMetricsResolutionHour, base))
+	assert.Equal(t, 30*24*time.Hour+time.Hour, hourTTL)
+}
+
+func TestNewSystemMetricsRange(t *testing.T) {
+	now := time.Date(2026, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	rng, err := commondto.NewSystemMetricsRange("", time.Time{}, time.Time{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, commondto.SystemMetricsResolutionMinute, rng.Resolution)
+	assert.Equal(t, now.Add(-24*time.Hour), rng.From)
+	assert.Equal(t, now, rng.To)
+
+	rng, err = commondto.NewSystemMetricsRange("", now.Add(-7*24*time.Hour), time.Time{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, commondto.SystemMetricsResolutionHour, rng.Resolution, "older than 24h picks hours")
+
+	rng, err = commondto.NewSystemMetricsRange("minute", now.Add(-7*24*time.Hour), time.Time{}, now)
+	require.NoError(t, err)
+	assert.Equal(t, now.Add(-24*time.Hour), rng.From, "clamped to minute retention")
+
+	_, err = commondto.NewSystemMetricsRange("second", time.Time{}, time.Time{}, now)
+	assert.Error(t, err)
+
+	_, err = commondto.NewSystemMetricsRange("", now, now.Add(-time.Hour), now)
+	assert.Error(t, err, "from after to")
+
+	_, err = commondto.NewSystemMetricsRange("minute", now.Add(-72*time.Hour), now.Add(-48*time.Hour), now)
+	assert.Error(t, err, "range outside retention")
+}
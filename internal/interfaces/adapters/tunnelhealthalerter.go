@@ -0,0 +1,51 @@
+package adapters
+
+import (
+	"context"
+
+	forwardUsecases "github.com/orris-inc/orris/internal/application/forward/usecases"
+	telegramAdmin "github.com/orris-inc/orris/internal/application/telegram/admin"
+)
+
+// TunnelHealthAlerterAdapter sends forward tunnel health alerts through the admin Telegram bot.
+type TunnelHealthAlerterAdapter struct {
+	notifier telegramAdmin.AdminNotifier
+}
+
+// NewTunnelHealthAlerterAdapter creates a new TunnelHealthAlerterAdapter.
+func NewTunnelHealthAlerterAdapter(notifier telegramAdmin.AdminNotifier) *TunnelHealthAlerterAdapter {
+	return &TunnelHealthAlerterAdapter{notifier: notifier}
+}
+
+// AlertTunnelUnhealthy implements forwardUsecases.TunnelHealthAlerter.
+func (a *TunnelHealthAlerterAdapter) AlertTunnelUnhealthy(ctx context.Context, alert forwardUsecases.TunnelHealthAlert) error {
+	return a.notifier.NotifyTunnelUnhealthy(ctx, telegramAdmin.NotifyTunnelUnhealthyCommand{
+		RuleSID:          alert.RuleSID,
+		RuleName:         alert.RuleName,
+		EntryAgentSID:    alert.EntryAgentSID,
+		EntryAgentName:   alert.EntryAgentName,
+		ExitAgentSID:     alert.ExitAgentSID,
+		ExitAgentName:    alert.ExitAgentName,
+		FailCount:        alert.FailCount,
+		Error:            alert.Error,
+		Demoted:          alert.Demoted,
+		UnhealthyAt:      alert.At,
+		MuteNotification: alert.MuteNotification,
+	})
+}
+
+// AlertTunnelRecovered implements forwardUsecases.TunnelHealthAlerter.
+func (a *TunnelHealthAlerterAdapter) AlertTunnelRecovered(ctx context.Context, alert forwardUsecases.TunnelHealthAlert) error {
+	return a.notifier.NotifyTunnelRecovery(ctx, telegramAdmin.NotifyTunnelRecoveryCommand{
+		RuleSID:          alert.RuleSID,
+		RuleName:         alert.RuleName,
+		EntryAgentSID:    alert.EntryAgentSID,
+		EntryAgentName:   alert.EntryAgentName,
+		ExitAgentSID:     alert.ExitAgentSID,
+		ExitAgentName:    alert.ExitAgentName,
+		Restored:         alert.Demoted,
+		RecoveredAt:      alert.At,
+		DowntimeMinutes:  int64(alert.At.Sub(alert.Since).Minutes()),
+		MuteNotification: alert.MuteNotification,
+	})
+}
@@ -0,0 +1,30 @@
+package adapters
+
+import (
+	"context"
+
+	nodeServices "github.com/orris-inc/orris/internal/application/node/services"
+	telegramAdmin "github.com/orris-inc/orris/internal/application/telegram/admin"
+)
+
+// WireGuardPoolAlerterAdapter sends WireGuard address pool alerts through the admin Telegram bot.
+type WireGuardPoolAlerterAdapter struct {
+	notifier telegramAdmin.AdminNotifier
+}
+
+// NewWireGuardPoolAlerterAdapter creates a new WireGuardPoolAlerterAdapter.
+func NewWireGuardPoolAlerterAdapter(notifier telegramAdmin.AdminNotifier) *WireGuardPoolAlerterAdapter {
+	return &WireGuardPoolAlerterAdapter{notifier: notifier}
+}
+
+// AlertWireGuardPoolExhausted implements nodeServices.WireGuardPoolAlerter.
+func (a *WireGuardPoolAlerterAdapter) AlertWireGuardPoolExhausted(ctx context.Context, alert nodeServices.WireGuardPoolAlert) error {
+	return a.notifier.NotifyWireGuardPoolExhausted(ctx, telegramAdmin.NotifyWireGuardPoolExhaustedCommand{
+		NodeSID:     alert.NodeSID,
+		NodeName:    alert.NodeName,
+		AddressPool: alert.AddressPool,
+		Capacity:    alert.Capacity,
+		Pending:     alert.Pending,
+		DetectedAt:  alert.At,
+	})
+}
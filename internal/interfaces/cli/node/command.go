@@ -0,0 +1,225 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/infrastructure/config"
+	"github.com/orris-inc/orris/internal/infrastructure/database"
+	"github.com/orris-inc/orris/internal/infrastructure/repository"
+	"github.com/orris-inc/orris/internal/shared/biztime"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+var (
+	env            string
+	configPath     string
+	file           string
+	format         string
+	dryRun         bool
+	output         string
+	nodeIDs        []string
+	groupID        string
+	tag            string
+	includeSecrets bool
+)
+
+func NewCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "node",
+		Short: "Node management tools",
+		Long:  `Import and export nodes in bulk using YAML/JSON manifests or share URIs.`,
+	}
+
+	cmd.PersistentFlags().StringVarP(&env, "env", "e", "development", "Environment (development, test, production)")
+	cmd.PersistentFlags().StringVarP(&configPath, "config", "c", "", "Path to config file (default: ./configs/config.yaml)")
+
+	cmd.AddCommand(
+		newImportCommand(),
+		newExportCommand(),
+	)
+
+	return cmd
+}
+
+func newImportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "import",
+		Short: "Import nodes from a manifest",
+		Long: `Create nodes from a YAML or JSON manifest, or from share URIs (ss://, vmess://, vless://,
+trojan://, hysteria2://, tuic://, anytls://) one per line. Every entry is validated before
+anything is created; use --dry-run to only validate.`,
+		RunE: runImport,
+	}
+
+	cmd.Flags().StringVarP(&file, "file", "f", "", "Manifest file to import, - for stdin (required)")
+	cmd.Flags().StringVar(&format, "format", "", "Manifest format: yaml, json or uri (default: detected from content)")
+	cmd.Flags().BoolVar(&dryRun, "dry-run", false, "Only validate the manifest, do not create nodes")
+	cmd.MarkFlagRequired("file")
+
+	return cmd
+}
+
+func newExportCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "export",
+		Short: "Export nodes to a manifest",
+		Long: `Export admin nodes with their protocol, route and DNS configuration and resource group
+memberships as a portable manifest. Server keys are only exported with --include-secrets.`,
+		RunE: runExport,
+	}
+
+	cmd.Flags().StringVarP(&output, "output", "o", "", "Output file (default: stdout)")
+	cmd.Flags().StringVar(&format, "format", usecases.NodeManifestFormatYAML, "Manifest format: yaml or json")
+	cmd.Flags().StringSliceVar(&nodeIDs, "node", nil, "Export only these nodes (node SIDs, repeatable)")
+	cmd.Flags().StringVar(&groupID, "group", "", "Export only nodes in this resource group (group SID)")
+	cmd.Flags().StringVar(&tag, "tag", "", "Export only nodes with this tag")
+	cmd.Flags().BoolVar(&includeSecrets, "include-secrets", false, "Include Reality and WireGuard server keys")
+
+	return cmd
+}
+
+func initEnv() (logger.Interface, error) {
+	cfg, err := config.Load(env, configPath)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load config: %w", err)
+	}
+
+	if err := logger.Init(&cfg.Logger); err != nil {
+		return nil, fmt.Errorf("failed to initialize logger: %w", err)
+	}
+
+	// Initialize business timezone for date boundary calculations
+	if err := biztime.Init(cfg.Server.Timezone); err != nil {
+		return nil, fmt.Errorf("failed to initialize business timezone: %w", err)
+	}
+
+	if err := database.Init(&cfg.Database); err != nil {
+		return nil, fmt.Errorf("failed to initialize database: %w", err)
+	}
+
+	return logger.NewLogger(), nil
+}
+
+func runImport(cmd *cobra.Command, args []string) error {
+	var (
+		data []byte
+		err  error
+	)
+	if file == "-" {
+		data, err = io.ReadAll(os.Stdin)
+	} else {
+		data, err = os.ReadFile(file)
+	}
+	if err != nil {
+		return fmt.Errorf("failed to read manifest: %w", err)
+	}
+
+	entries, err := usecases.ParseNodeManifest(data, format)
+	if err != nil {
+		return err
+	}
+
+	log, err := initEnv()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+	defer database.Close()
+
+	db := database.Get()
+	nodeRepo := repository.NewNodeRepository(db, log)
+	createNodeUC := usecases.NewCreateNodeUseCase(nodeRepo, repository.NewResourceGroupRepository(db, log), log)
+	createNodeUC.SetConfigVersionRecorder(
+		usecases.NewConfigVersionRecorder(repository.NewNodeConfigVersionRepository(db, log), log),
+	)
+	importUC := usecases.NewImportNodesUseCase(createNodeUC, log)
+
+	result, err := importUC.Execute(context.Background(), usecases.ImportNodesCommand{
+		Nodes:  entries,
+		DryRun: dryRun,
+	})
+	if err != nil {
+		return err
+	}
+
+	failed := 0
+	for _, n := range result.Nodes {
+		line := fmt.Sprintf("  [%d] %-8s %s", n.Index, n.Status, n.Name)
+		if n.NodeSID != "" {
+			line += fmt.Sprintf(" (%s)", n.NodeSID)
+		}
+		if n.APIToken != "" {
+			line += "  token: " + n.APIToken
+		}
+		if n.Error != "" {
+			line += "  error: " + n.Error
+			failed++
+		}
+		fmt.Println(line)
+	}
+
+	switch {
+	case !result.Valid:
+		return fmt.Errorf("manifest has %d invalid node(s), nothing was imported", failed)
+	case result.DryRun:
+		fmt.Printf("✅ Manifest is valid, %d node(s) would be imported\n", len(result.Nodes))
+	case failed > 0:
+		return fmt.Errorf("imported %d node(s), %d failed", result.Created, failed)
+	default:
+		fmt.Printf("✅ Imported %d node(s)\n", result.Created)
+	}
+	return nil
+}
+
+func runExport(cmd *cobra.Command, args []string) error {
+	format = strings.ToLower(format)
+	if format != usecases.NodeManifestFormatYAML && format != usecases.NodeManifestFormatJSON {
+		return errors.NewValidationError("format must be yaml or json")
+	}
+
+	log, err := initEnv()
+	if err != nil {
+		return err
+	}
+	defer logger.Sync()
+	defer database.Close()
+
+	db := database.Get()
+	exportUC := usecases.NewExportNodesUseCase(
+		repository.NewNodeRepository(db, log), repository.NewResourceGroupRepository(db, log), log,
+	)
+
+	manifest, err := exportUC.Execute(context.Background(), usecases.ExportNodesQuery{
+		NodeSIDs:       nodeIDs,
+		GroupSID:       groupID,
+		Tag:            tag,
+		IncludeSecrets: includeSecrets,
+	})
+	if err != nil {
+		return err
+	}
+
+	content, err := usecases.EncodeNodeManifest(manifest, format)
+	if err != nil {
+		return err
+	}
+
+	if output == "" {
+		_, err = os.Stdout.Write(content)
+		return err
+	}
+	if err := os.WriteFile(filepath.Clean(output), content, 0o600); err != nil {
+		return fmt.Errorf("failed to write manifest: %w", err)
+	}
+	fmt.Fprintf(os.Stderr, "✅ Exported %d node(s) to %s\n", len(manifest.Nodes), output)
+	return nil
+}
@@ -112,6 +112,7 @@ type Container struct {
 	nodeStatusHandler              *adapters.NodeStatusHandler
 	forwardStatusHandler           *adapters.ForwardStatusHandler
 	nodeConfigSyncService          *nodeServices.NodeConfigSyncService
+	certificateSyncService         *nodeServices.CertificateSyncService
 	subscriptionSyncService        *nodeServices.SubscriptionSyncService
 	quotaCacheSyncService          *subscriptionServices.QuotaCacheSyncService
 	alertStateManager              *cache.AlertStateManager
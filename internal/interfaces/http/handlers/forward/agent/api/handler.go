@@ -95,6 +95,20 @@ func (h *Handler) SetNodeRepo(nodeRepo node.NodeRepository) {
 	}
 }
 
+// SetDemotedExitsReader sets the reader used to send demoted exit agents as backups.
+func (h *Handler) SetDemotedExitsReader(reader dto.DemotedExitsReader) {
+	if h.ruleConverter != nil {
+		h.ruleConverter.SetDemotedExitsReader(reader)
+	}
+}
+
+// SetRuleLimitsResolver sets the resolver used to apply plan limits to subscription-bound rules.
+func (h *Handler) SetRuleLimitsResolver(resolver dto.RuleLimitsResolver) {
+	if h.ruleConverter != nil {
+		h.ruleConverter.SetRuleLimitsResolver(resolver)
+	}
+}
+
 // getAuthenticatedAgentID extracts the authenticated forward agent ID from context.
 // Returns the agent ID or an error if not found.
 func (h *Handler) getAuthenticatedAgentID(c *gin.Context) (uint, error) {
@@ -7,6 +7,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/orris-inc/orris/internal/shared/metrics"
 	"github.com/orris-inc/orris/internal/shared/utils"
 )
 
@@ -190,6 +191,7 @@ func (h *Handler) ReportTraffic(c *gin.Context) {
 			errorCount++
 			continue
 		}
+		metrics.ForwardRuleTraffic.Add(info.id, item.UploadBytes, item.DownloadBytes)
 
 		// Also record traffic to subscription_usages table (for unified traffic tracking)
 		// Apply traffic multiplier before recording to subscription_usages
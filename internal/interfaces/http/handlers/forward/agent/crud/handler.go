@@ -27,6 +27,8 @@ type Handler struct {
 	regenerateTokenUC       *usecases.RegenerateForwardAgentTokenUseCase
 	getAgentTokenUC         *usecases.GetForwardAgentTokenUseCase
 	getAgentStatusUC        *usecases.GetAgentStatusUseCase
+	getAgentMetricsUC       *usecases.GetAgentMetricsUseCase
+	getAgentPortUsageUC     *usecases.GetAgentPortUsageUseCase
 	getRuleOverallStatusUC  *usecases.GetRuleOverallStatusUseCase
 	generateInstallScriptUC *usecases.GenerateInstallScriptUseCase
 	serverURL               string
@@ -45,6 +47,8 @@ func NewHandler(
 	regenerateTokenUC *usecases.RegenerateForwardAgentTokenUseCase,
 	getAgentTokenUC *usecases.GetForwardAgentTokenUseCase,
 	getAgentStatusUC *usecases.GetAgentStatusUseCase,
+	getAgentMetricsUC *usecases.GetAgentMetricsUseCase,
+	getAgentPortUsageUC *usecases.GetAgentPortUsageUseCase,
 	getRuleOverallStatusUC *usecases.GetRuleOverallStatusUseCase,
 	generateInstallScriptUC *usecases.GenerateInstallScriptUseCase,
 	serverURL string,
@@ -61,6 +65,8 @@ func NewHandler(
 		regenerateTokenUC:       regenerateTokenUC,
 		getAgentTokenUC:         getAgentTokenUC,
 		getAgentStatusUC:        getAgentStatusUC,
+		getAgentMetricsUC:       getAgentMetricsUC,
+		getAgentPortUsageUC:     getAgentPortUsageUC,
 		getRuleOverallStatusUC:  getRuleOverallStatusUC,
 		generateInstallScriptUC: generateInstallScriptUC,
 		serverURL:               serverURL,
@@ -367,6 +373,64 @@ func (h *Handler) GetAgentStatus(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "", result)
 }
 
+// GetAgentMetricsRequest represents query parameters for forward agent metrics history.
+type GetAgentMetricsRequest struct {
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Resolution string    `form:"resolution" binding:"omitempty,oneof=minute hour"`
+}
+
+// GetAgentMetrics handles GET /forward-agents/:id/metrics
+// Query params:
+//   - from, to (optional, RFC3339): time range, defaults to the last 24 hours
+//   - resolution (optional): minute (kept 24h) or hour (kept 30d), picked from the range if omitted
+func (h *Handler) GetAgentMetrics(c *gin.Context) {
+	shortID, err := utils.ParseSIDParam(c, "id", id.PrefixForwardAgent, "forward agent")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	var req GetAgentMetricsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnw("invalid query for forward agent metrics", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	query := usecases.GetAgentMetricsQuery{
+		ShortID:    shortID,
+		From:       req.From,
+		To:         req.To,
+		Resolution: req.Resolution,
+	}
+	result, err := h.getAgentMetricsUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
+// GetAgentPortUsage handles GET /forward-agents/:id/port-usage
+// Returns the ports used by rules and reserved on the agent, and the utilization of its port pool.
+func (h *Handler) GetAgentPortUsage(c *gin.Context) {
+	shortID, err := utils.ParseSIDParam(c, "id", id.PrefixForwardAgent, "forward agent")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.getAgentPortUsageUC.Execute(c.Request.Context(), shortID)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
 // GetInstallScript handles GET /forward-agents/:id/install-script
 // Query params:
 //   - token (optional): API token. If not provided, uses agent's current stored token
@@ -168,6 +168,10 @@ func (h *Handler) BatchCreateRules(c *gin.Context) {
 			GroupSIDs:          r.GroupSIDs,
 			Route:             r.Route,
 			AddressPreference: r.AddressPreference,
+			UploadLimitMbps:   r.UploadLimitMbps,
+			DownloadLimitMbps: r.DownloadLimitMbps,
+			MaxConnections:    r.MaxConnections,
+			ACL:               r.ACL,
 		})
 		cmdIndices = append(cmdIndices, i)
 	}
@@ -164,6 +164,10 @@ func (h *Handler) CreateRule(c *gin.Context) {
 		GroupSIDs:           req.GroupSIDs,
 		Route:              req.Route,
 		AddressPreference:  req.AddressPreference,
+		UploadLimitMbps:    req.UploadLimitMbps,
+		DownloadLimitMbps:  req.DownloadLimitMbps,
+		MaxConnections:     req.MaxConnections,
+		ACL:                req.ACL,
 		// External rule fields
 		ServerAddress:  req.ServerAddress,
 		ExternalSource: req.ExternalSource,
@@ -332,6 +336,10 @@ func (h *Handler) UpdateRule(c *gin.Context) {
 		Route:              req.Route,
 		ClearRoute:         req.ClearRoute,
 		AddressPreference:  req.AddressPreference,
+		UploadLimitMbps:    req.UploadLimitMbps,
+		DownloadLimitMbps:  req.DownloadLimitMbps,
+		MaxConnections:     req.MaxConnections,
+		ACL:                req.ACL,
 	}
 
 	if err := h.updateRuleUC.Execute(c.Request.Context(), cmd); err != nil {
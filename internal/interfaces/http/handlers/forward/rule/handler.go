@@ -2,24 +2,27 @@
 package rule
 
 import (
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	nodedto "github.com/orris-inc/orris/internal/application/node/dto"
 	"github.com/orris-inc/orris/internal/shared/logger"
 )
 
 // Handler handles HTTP requests for forward rules.
 type Handler struct {
-	createRuleUC   createRuleUseCase
-	getRuleUC      getRuleUseCase
-	updateRuleUC   updateRuleUseCase
-	deleteRuleUC   deleteRuleUseCase
-	listRulesUC    listRulesUseCase
-	enableRuleUC   enableRuleUseCase
-	disableRuleUC  disableRuleUseCase
-	resetTrafficUC resetTrafficUseCase
-	reorderRulesUC reorderRulesUseCase
-	batchRuleUC    batchRuleUseCase
-	probeService   probeService
-	logger         logger.Interface
+	createRuleUC              createRuleUseCase
+	getRuleUC                 getRuleUseCase
+	updateRuleUC              updateRuleUseCase
+	deleteRuleUC              deleteRuleUseCase
+	listRulesUC               listRulesUseCase
+	enableRuleUC              enableRuleUseCase
+	disableRuleUC             disableRuleUseCase
+	resetTrafficUC            resetTrafficUseCase
+	reorderRulesUC            reorderRulesUseCase
+	batchRuleUC               batchRuleUseCase
+	getTunnelHealthUC         getTunnelHealthUseCase
+	listTunnelHealthRecordsUC listTunnelHealthRecordsUseCase
+	probeService              probeService
+	logger                    logger.Interface
 }
 
 // NewHandler creates a new Handler.
@@ -34,22 +37,26 @@ func NewHandler(
 	resetTrafficUC resetTrafficUseCase,
 	reorderRulesUC reorderRulesUseCase,
 	batchRuleUC batchRuleUseCase,
+	getTunnelHealthUC getTunnelHealthUseCase,
+	listTunnelHealthRecordsUC listTunnelHealthRecordsUseCase,
 	probeService probeService,
 	log logger.Interface,
 ) *Handler {
 	return &Handler{
-		createRuleUC:   createRuleUC,
-		getRuleUC:      getRuleUC,
-		updateRuleUC:   updateRuleUC,
-		deleteRuleUC:   deleteRuleUC,
-		listRulesUC:    listRulesUC,
-		enableRuleUC:   enableRuleUC,
-		disableRuleUC:  disableRuleUC,
-		resetTrafficUC: resetTrafficUC,
-		reorderRulesUC: reorderRulesUC,
-		batchRuleUC:    batchRuleUC,
-		probeService:   probeService,
-		logger:         log,
+		createRuleUC:              createRuleUC,
+		getRuleUC:                 getRuleUC,
+		updateRuleUC:              updateRuleUC,
+		deleteRuleUC:              deleteRuleUC,
+		listRulesUC:               listRulesUC,
+		enableRuleUC:              enableRuleUC,
+		disableRuleUC:             disableRuleUC,
+		resetTrafficUC:            resetTrafficUC,
+		reorderRulesUC:            reorderRulesUC,
+		batchRuleUC:               batchRuleUC,
+		getTunnelHealthUC:         getTunnelHealthUC,
+		listTunnelHealthRecordsUC: listTunnelHealthRecordsUC,
+		probeService:              probeService,
+		logger:                    log,
 	}
 }
 
@@ -71,7 +78,7 @@ type CreateForwardRuleRequest struct {
 	RuleType            string             `json:"rule_type" binding:"required,oneof=direct entry chain direct_chain external" example:"direct"`
 	ExitAgentID         string             `json:"exit_agent_id,omitempty" example:"fa_yL8nQ3wM4oR"`
 	ExitAgents          []ExitAgentRequest `json:"exit_agents,omitempty" binding:"omitempty,max=10,dive"`
-	LoadBalanceStrategy string             `json:"load_balance_strategy,omitempty" binding:"omitempty,oneof=failover weighted" example:"failover"` // failover (default), weighted
+	LoadBalanceStrategy string             `json:"load_balance_strategy,omitempty" binding:"omitempty,oneof=failover weighted round_robin least_connections lowest_latency" example:"failover"` // failover (default), weighted, round_robin, least_connections, lowest_latency
 	ChainAgentIDs       []string           `json:"chain_agent_ids,omitempty" example:"[\"fa_aaa\",\"fa_bbb\"]"`
 	ChainPortConfig     map[string]uint16  `json:"chain_port_config,omitempty" example:"{\"fa_xK9mP2vL3nQ\":8080,\"fa_yL8nQ3wM4oR\":9090}"`
 	TunnelHops          *int               `json:"tunnel_hops,omitempty" binding:"omitempty,gte=0,lte=10" example:"2"`
@@ -90,6 +97,10 @@ type CreateForwardRuleRequest struct {
 	GroupSIDs           []string                `json:"group_sids,omitempty" example:"[\"rg_xxx\",\"rg_yyy\"]"`
 	Route               *nodedto.RouteConfigDTO `json:"route,omitempty"`                                                                                 // per-rule routing configuration
 	AddressPreference   string                  `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"` // address preference: auto, public, tunnel
+	UploadLimitMbps     uint32                  `json:"upload_limit_mbps,omitempty" binding:"omitempty,lte=100000" example:"100"`             // upload bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	DownloadLimitMbps   uint32                  `json:"download_limit_mbps,omitempty" binding:"omitempty,lte=100000" example:"100"`           // download bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	MaxConnections      uint32                  `json:"max_connections,omitempty" binding:"omitempty,lte=1000000" example:"1000"`             // max concurrent connections (0 = inherit from plan or unlimited)
+	ACL                 *dto.RuleACLDTO         `json:"acl,omitempty"`                                                                       // source IP access control list
 	// External rule fields (only for rule_type=external)
 	ServerAddress  string `json:"server_address,omitempty" example:"example.com"`
 	ExternalSource string `json:"external_source,omitempty" example:"third-party-provider"`
@@ -102,7 +113,7 @@ type UpdateForwardRuleRequest struct {
 	AgentID             *string            `json:"agent_id,omitempty" example:"fa_xK9mP2vL3nQ"`
 	ExitAgentID         *string            `json:"exit_agent_id,omitempty" example:"fa_yL8nQ3wM4oR"`
 	ExitAgents          []ExitAgentRequest `json:"exit_agents,omitempty" binding:"omitempty,max=10,dive"`
-	LoadBalanceStrategy *string            `json:"load_balance_strategy,omitempty" binding:"omitempty,oneof=failover weighted" example:"failover"` // failover, weighted
+	LoadBalanceStrategy *string            `json:"load_balance_strategy,omitempty" binding:"omitempty,oneof=failover weighted round_robin least_connections lowest_latency" example:"failover"` // failover, weighted, round_robin, least_connections, lowest_latency
 	ChainAgentIDs       []string           `json:"chain_agent_ids,omitempty" example:"[\"fa_aaa\",\"fa_bbb\"]"`
 	ChainPortConfig     map[string]uint16  `json:"chain_port_config,omitempty" example:"{\"fa_xK9mP2vL3nQ\":8080,\"fa_yL8nQ3wM4oR\":9090}"`
 	TunnelHops          *int               `json:"tunnel_hops,omitempty" binding:"omitempty,gte=0,lte=10" example:"2"`
@@ -121,6 +132,10 @@ type UpdateForwardRuleRequest struct {
 	Route               *nodedto.RouteConfigDTO `json:"route,omitempty"`                                                                                 // per-rule routing configuration
 	ClearRoute          *bool                   `json:"clear_route,omitempty"`                                                                           // true to clear route config
 	AddressPreference   *string                 `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"` // address preference: auto, public, tunnel
+	UploadLimitMbps     *uint32                 `json:"upload_limit_mbps,omitempty" binding:"omitempty,lte=100000" example:"100"`             // upload bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	DownloadLimitMbps   *uint32                 `json:"download_limit_mbps,omitempty" binding:"omitempty,lte=100000" example:"100"`           // download bandwidth limit in Mbps (0 = inherit from plan or unlimited)
+	MaxConnections      *uint32                 `json:"max_connections,omitempty" binding:"omitempty,lte=1000000" example:"1000"`             // max concurrent connections (0 = inherit from plan or unlimited)
+	ACL                 *dto.RuleACLDTO         `json:"acl,omitempty"`                                                                       // source IP access control list (empty lists clear it)
 }
 
 // UpdateStatusRequest represents a request to update forward rule status.
@@ -52,6 +52,14 @@ type batchRuleUseCase interface {
 	BatchUpdate(ctx context.Context, cmd usecases.BatchUpdateCommand) (*dto.BatchOperationResult, error)
 }
 
+type getTunnelHealthUseCase interface {
+	Execute(ctx context.Context, query usecases.GetTunnelHealthQuery) (*dto.RuleTunnelHealthResponse, error)
+}
+
+type listTunnelHealthRecordsUseCase interface {
+	Execute(ctx context.Context, query usecases.ListTunnelHealthRecordsQuery) (*usecases.ListTunnelHealthRecordsResult, error)
+}
+
 type probeService interface {
 	ProbeRuleByShortID(ctx context.Context, shortID string, ipVersionOverride string) (*dto.RuleProbeResponse, error)
 }
@@ -0,0 +1,79 @@
+package rule
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/forward/usecases"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// GetTunnelHealth handles GET /forward-rules/:id/tunnel-health
+func (h *Handler) GetTunnelHealth(c *gin.Context) {
+	shortID, err := utils.ParseSIDParam(c, "id", id.PrefixForwardRule, "forward rule")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.getTunnelHealthUC.Execute(c.Request.Context(), usecases.GetTunnelHealthQuery{RuleSID: shortID})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
+// ListTunnelHealthRecords handles GET /forward-rules/:id/tunnel-health/records
+// Query params: exit_agent_id, from, to (RFC3339), page, page_size
+func (h *Handler) ListTunnelHealthRecords(c *gin.Context) {
+	shortID, err := utils.ParseSIDParam(c, "id", id.PrefixForwardRule, "forward rule")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	query := usecases.ListTunnelHealthRecordsQuery{RuleSID: shortID}
+
+	if exitAgentID := c.Query("exit_agent_id"); exitAgentID != "" {
+		if err := id.ValidatePrefix(exitAgentID, id.PrefixForwardAgent); err != nil {
+			utils.ErrorResponseWithError(c, errors.NewValidationError("invalid exit_agent_id format, expected fa_xxxxx"))
+			return
+		}
+		query.ExitAgentSID = exitAgentID
+	}
+
+	if fromStr := c.Query("from"); fromStr != "" {
+		from, err := time.Parse(time.RFC3339, fromStr)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "invalid from time format, use RFC3339")
+			return
+		}
+		query.From = &from
+	}
+	if toStr := c.Query("to"); toStr != "" {
+		to, err := time.Parse(time.RFC3339, toStr)
+		if err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "invalid to time format, use RFC3339")
+			return
+		}
+		query.To = &to
+	}
+
+	p := utils.ParsePagination(c)
+	query.Page = p.Page
+	query.PageSize = p.PageSize
+
+	result, err := h.listTunnelHealthRecordsUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.ListSuccessResponse(c, result.Records, result.Total, p.Page, p.PageSize)
+}
@@ -7,6 +7,7 @@ import (
 	"github.com/gin-gonic/gin"
 	"github.com/gin-gonic/gin/binding"
 
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	"github.com/orris-inc/orris/internal/application/forward/usecases"
 	"github.com/orris-inc/orris/internal/shared/errors"
 	"github.com/orris-inc/orris/internal/shared/id"
@@ -74,6 +75,7 @@ type CreateForwardRuleRequest struct {
 	SortOrder         *int              `json:"sort_order,omitempty" binding:"omitempty,gte=0" example:"100"`
 	Remark            string            `json:"remark,omitempty" example:"Forward to internal MySQL server"`
 	AddressPreference string            `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"`
+	ACL               *dto.RuleACLDTO   `json:"acl,omitempty"` // source IP access control list
 }
 
 // UpdateForwardRuleRequest represents a request to update a forward rule.
@@ -96,6 +98,7 @@ type UpdateForwardRuleRequest struct {
 	SortOrder         *int              `json:"sort_order,omitempty" example:"100"`
 	Remark            *string           `json:"remark,omitempty" example:"Updated remark"`
 	AddressPreference *string           `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"`
+	ACL               *dto.RuleACLDTO   `json:"acl,omitempty"` // source IP access control list (empty lists clear it)
 }
 
 // ReorderForwardRulesRequest represents a request to reorder forward rules.
@@ -216,6 +219,7 @@ func (h *Handler) CreateRule(c *gin.Context) {
 		SortOrder:          req.SortOrder,
 		Remark:             req.Remark,
 		AddressPreference:  req.AddressPreference,
+		ACL:                req.ACL,
 		RuleLimit:          ruleLimit,
 	}
 
@@ -401,6 +405,7 @@ func (h *Handler) UpdateRule(c *gin.Context) {
 		SortOrder:          req.SortOrder,
 		Remark:             req.Remark,
 		AddressPreference:  req.AddressPreference,
+		ACL:                req.ACL,
 	}
 
 	if err := h.updateRuleUC.Execute(c.Request.Context(), cmd); err != nil {
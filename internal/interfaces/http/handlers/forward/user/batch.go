@@ -171,6 +171,7 @@ func (h *Handler) BatchCreateRules(c *gin.Context) {
 			SortOrder:          r.SortOrder,
 			Remark:             r.Remark,
 			AddressPreference:  r.AddressPreference,
+			ACL:                r.ACL,
 		})
 		cmdIndices = append(cmdIndices, i)
 	}
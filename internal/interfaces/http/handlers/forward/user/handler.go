@@ -2,6 +2,7 @@
 package user
 
 import (
+	"github.com/orris-inc/orris/internal/application/forward/dto"
 	"github.com/orris-inc/orris/internal/application/forward/usecases"
 	"github.com/orris-inc/orris/internal/shared/logger"
 )
@@ -77,6 +78,7 @@ type CreateUserForwardRuleRequest struct {
 	SortOrder         *int              `json:"sort_order,omitempty" binding:"omitempty,gte=0" example:"100"`
 	Remark            string            `json:"remark,omitempty" example:"Forward to internal MySQL server"`
 	AddressPreference string            `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"`
+	ACL               *dto.RuleACLDTO   `json:"acl,omitempty"` // source IP access control list
 }
 
 // UpdateForwardRuleRequest represents a request to update a forward rule.
@@ -99,6 +101,7 @@ type UpdateForwardRuleRequest struct {
 	SortOrder         *int              `json:"sort_order,omitempty" example:"100"`
 	Remark            *string           `json:"remark,omitempty" example:"Updated remark"`
 	AddressPreference *string           `json:"address_preference,omitempty" binding:"omitempty,oneof=auto public tunnel" example:"auto"`
+	ACL               *dto.RuleACLDTO   `json:"acl,omitempty"` // source IP access control list (empty lists clear it)
 }
 
 // ReorderForwardRulesRequest represents a request to reorder forward rules.
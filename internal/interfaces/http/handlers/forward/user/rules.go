@@ -104,6 +104,7 @@ func (h *Handler) CreateRule(c *gin.Context) {
 		SortOrder:          req.SortOrder,
 		Remark:             req.Remark,
 		AddressPreference:  req.AddressPreference,
+		ACL:                req.ACL,
 	}
 
 	result, err := h.createRuleUC.Execute(c.Request.Context(), cmd)
@@ -288,6 +289,7 @@ func (h *Handler) UpdateRule(c *gin.Context) {
 		SortOrder:          req.SortOrder,
 		Remark:             req.Remark,
 		AddressPreference:  req.AddressPreference,
+		ACL:                req.ACL,
 	}
 
 	if err := h.updateRuleUC.Execute(c.Request.Context(), cmd); err != nil {
@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+
+	"github.com/orris-inc/orris/internal/shared/logger"
+)
+
+// MetricsHandler serves Prometheus metrics.
+type MetricsHandler struct {
+	handler http.Handler
+}
+
+// NewMetricsHandler creates a new MetricsHandler.
+func NewMetricsHandler(gatherer prometheus.Gatherer, logger logger.Interface) *MetricsHandler {
+	return &MetricsHandler{
+		handler: promhttp.HandlerFor(gatherer, promhttp.HandlerOpts{
+			ErrorLog:      metricsErrorLogger{logger: logger},
+			ErrorHandling: promhttp.ContinueOnError,
+		}),
+	}
+}
+
+// Scrape handles GET /metrics in the Prometheus text exposition format.
+// Collectors that fail are omitted from the output so the remaining metrics stay available.
+func (h *MetricsHandler) Scrape(c *gin.Context) {
+	h.handler.ServeHTTP(c.Writer, c.Request)
+}
+
+// metricsErrorLogger reports gathering errors through the application logger.
+type metricsErrorLogger struct {
+	logger logger.Interface
+}
+
+// Println implements promhttp.Logger.
+func (l metricsErrorLogger) Println(v ...any) {
+	l.logger.Warnw("failed to collect some metrics", "error", fmt.Sprint(v...))
+}
@@ -0,0 +1,181 @@
+package node
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// NodeCertificateHandler handles ACME-issued node TLS certificates.
+type NodeCertificateHandler struct {
+	createUC *usecases.CreateCertificateUseCase
+	listUC   *usecases.ListCertificatesUseCase
+	getUC    *usecases.GetCertificateUseCase
+	updateUC *usecases.UpdateCertificateUseCase
+	deleteUC *usecases.DeleteCertificateUseCase
+	issueUC  *usecases.IssueCertificateUseCase
+	logger   logger.Interface
+}
+
+// NewNodeCertificateHandler creates a new NodeCertificateHandler.
+func NewNodeCertificateHandler(
+	createUC *usecases.CreateCertificateUseCase,
+	listUC *usecases.ListCertificatesUseCase,
+	getUC *usecases.GetCertificateUseCase,
+	updateUC *usecases.UpdateCertificateUseCase,
+	deleteUC *usecases.DeleteCertificateUseCase,
+	issueUC *usecases.IssueCertificateUseCase,
+	log logger.Interface,
+) *NodeCertificateHandler {
+	return &NodeCertificateHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		getUC:    getUC,
+		updateUC: updateUC,
+		deleteUC: deleteUC,
+		issueUC:  issueUC,
+		logger:   log,
+	}
+}
+
+// CreateCertificateRequest represents the request to add a certificate for a domain.
+type CreateCertificateRequest struct {
+	Domain      string `json:"domain" binding:"required,max=253"`
+	DNSProvider string `json:"dns_provider" binding:"required,max=50"`
+	AutoRenew   *bool  `json:"auto_renew"` // defaults to true
+}
+
+// UpdateCertificateRequest represents the request to update a certificate.
+type UpdateCertificateRequest struct {
+	AutoRenew *bool `json:"auto_renew"`
+}
+
+// CreateCertificate handles POST /nodes/certificates
+func (h *NodeCertificateHandler) CreateCertificate(c *gin.Context) {
+	var req CreateCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for create certificate", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	autoRenew := true
+	if req.AutoRenew != nil {
+		autoRenew = *req.AutoRenew
+	}
+
+	result, err := h.createUC.Execute(c.Request.Context(), usecases.CreateCertificateCommand{
+		Domain:      req.Domain,
+		DNSProvider: req.DNSProvider,
+		AutoRenew:   autoRenew,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Certificate created, issuance started")
+}
+
+// ListCertificates handles GET /nodes/certificates
+func (h *NodeCertificateHandler) ListCertificates(c *gin.Context) {
+	p := utils.ParsePagination(c)
+
+	query := usecases.ListCertificatesQuery{
+		Page:     p.Page,
+		PageSize: p.PageSize,
+	}
+	if status := c.Query("status"); status != "" {
+		query.Status = &status
+	}
+
+	result, err := h.listUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.ListSuccessResponse(c, result.Certificates, result.Total, p.Page, p.PageSize)
+}
+
+// GetCertificate handles GET /nodes/certificates/:id
+func (h *NodeCertificateHandler) GetCertificate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixCertificate, "certificate")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.getUC.Execute(c.Request.Context(), usecases.GetCertificateQuery{SID: sid})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
+// UpdateCertificate handles PATCH /nodes/certificates/:id
+func (h *NodeCertificateHandler) UpdateCertificate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixCertificate, "certificate")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	var req UpdateCertificateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for update certificate", "sid", sid, "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.updateUC.Execute(c.Request.Context(), usecases.UpdateCertificateCommand{
+		SID:       sid,
+		AutoRenew: req.AutoRenew,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Certificate updated successfully", result)
+}
+
+// DeleteCertificate handles DELETE /nodes/certificates/:id
+func (h *NodeCertificateHandler) DeleteCertificate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixCertificate, "certificate")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), usecases.DeleteCertificateCommand{SID: sid}); err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.NoContentResponse(c)
+}
+
+// IssueCertificate handles POST /nodes/certificates/:id/issue
+func (h *NodeCertificateHandler) IssueCertificate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixCertificate, "certificate")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.issueUC.Execute(c.Request.Context(), usecases.IssueCertificateCommand{SID: sid})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Certificate issuance started", result)
+}
@@ -0,0 +1,134 @@
+package node
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// NodeConfigVersionHandler handles node configuration history.
+type NodeConfigVersionHandler struct {
+	listUC     *usecases.ListNodeConfigVersionsUseCase
+	diffUC     *usecases.DiffNodeConfigVersionsUseCase
+	rollbackUC *usecases.RollbackNodeConfigVersionUseCase
+	logger     logger.Interface
+}
+
+// NewNodeConfigVersionHandler creates a new NodeConfigVersionHandler.
+func NewNodeConfigVersionHandler(
+	listUC *usecases.ListNodeConfigVersionsUseCase,
+	diffUC *usecases.DiffNodeConfigVersionsUseCase,
+	rollbackUC *usecases.RollbackNodeConfigVersionUseCase,
+	log logger.Interface,
+) *NodeConfigVersionHandler {
+	return &NodeConfigVersionHandler{
+		listUC:     listUC,
+		diffUC:     diffUC,
+		rollbackUC: rollbackUC,
+		logger:     log,
+	}
+}
+
+// ListConfigVersions handles GET /nodes/:id/config-versions
+func (h *NodeConfigVersionHandler) ListConfigVersions(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	p := utils.ParsePagination(c)
+	result, err := h.listUC.Execute(c.Request.Context(), usecases.ListNodeConfigVersionsQuery{
+		NodeSID:  sid,
+		Page:     p.Page,
+		PageSize: p.PageSize,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.ListSuccessResponse(c, result.Versions, result.Total, p.Page, p.PageSize)
+}
+
+// DiffConfigVersions handles GET /nodes/:id/config-versions/diff?from=&to=
+func (h *NodeConfigVersionHandler) DiffConfigVersions(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	from, err := parseConfigVersionNumber(c.Query("from"), "from")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+	query := usecases.DiffNodeConfigVersionsQuery{NodeSID: sid, From: from}
+	if raw := c.Query("to"); raw != "" {
+		to, err := parseConfigVersionNumber(raw, "to")
+		if err != nil {
+			utils.ErrorResponseWithError(c, err)
+			return
+		}
+		query.To = &to
+	}
+
+	result, err := h.diffUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
+// RollbackConfigVersion handles POST /nodes/:id/config-versions/:version/rollback?restore_secrets=
+func (h *NodeConfigVersionHandler) RollbackConfigVersion(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	version, err := parseConfigVersionNumber(c.Param("version"), "version")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.rollbackUC.Execute(c.Request.Context(), usecases.RollbackNodeConfigVersionCommand{
+		NodeSID:        sid,
+		Version:        version,
+		RequestedBy:    userID,
+		RestoreSecrets: c.Query("restore_secrets") == "true" || c.Query("restore_secrets") == "1",
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Node configuration rolled back successfully", result)
+}
+
+// parseConfigVersionNumber parses a positive configuration version number.
+func parseConfigVersionNumber(raw, name string) (int, error) {
+	version, err := strconv.Atoi(raw)
+	if err != nil || version < 1 {
+		return 0, errors.NewValidationError(name + " must be a positive version number")
+	}
+	return version, nil
+}
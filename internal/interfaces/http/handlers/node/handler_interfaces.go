@@ -3,6 +3,7 @@ package node
 import (
 	"context"
 
+	commondto "github.com/orris-inc/orris/internal/application/common/dto"
 	"github.com/orris-inc/orris/internal/application/node/dto"
 	"github.com/orris-inc/orris/internal/application/node/usecases"
 )
@@ -33,6 +34,18 @@ type generateNodeTokenUseCase interface {
 	Execute(ctx context.Context, cmd usecases.GenerateNodeTokenCommand) (*usecases.GenerateNodeTokenResult, error)
 }
 
+type rotateNodeServerKeyUseCase interface {
+	Execute(ctx context.Context, cmd usecases.RotateNodeServerKeyCommand) (*usecases.RotateNodeServerKeyResult, error)
+}
+
+type rotateRealityKeysUseCase interface {
+	Execute(ctx context.Context, cmd usecases.RotateRealityKeysCommand) (*usecases.RotateRealityKeysResult, error)
+}
+
+type getNodeMetricsUseCase interface {
+	Execute(ctx context.Context, query usecases.GetNodeMetricsQuery) (*commondto.SystemMetricsSeries, error)
+}
+
 type generateNodeInstallScriptUseCase interface {
 	Execute(ctx context.Context, query usecases.GenerateNodeInstallScriptQuery) (*usecases.GenerateNodeInstallScriptResult, error)
 }
@@ -0,0 +1,127 @@
+package node
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// NodeMaintenanceHandler handles scheduled node maintenance windows.
+type NodeMaintenanceHandler struct {
+	createUC *usecases.CreateMaintenanceWindowUseCase
+	listUC   *usecases.ListMaintenanceWindowsUseCase
+	cancelUC *usecases.CancelMaintenanceWindowUseCase
+	logger   logger.Interface
+}
+
+// NewNodeMaintenanceHandler creates a new NodeMaintenanceHandler.
+func NewNodeMaintenanceHandler(
+	createUC *usecases.CreateMaintenanceWindowUseCase,
+	listUC *usecases.ListMaintenanceWindowsUseCase,
+	cancelUC *usecases.CancelMaintenanceWindowUseCase,
+	log logger.Interface,
+) *NodeMaintenanceHandler {
+	return &NodeMaintenanceHandler{
+		createUC: createUC,
+		listUC:   listUC,
+		cancelUC: cancelUC,
+		logger:   log,
+	}
+}
+
+// CreateMaintenanceWindowRequest represents the request to schedule a maintenance window.
+type CreateMaintenanceWindowRequest struct {
+	Reason           string    `json:"reason" binding:"required,max=500"`
+	StartsAt         time.Time `json:"starts_at" binding:"required"`
+	EndsAt           time.Time `json:"ends_at" binding:"required"`
+	NodeIDs          []string  `json:"node_ids"`
+	ResourceGroupIDs []string  `json:"resource_group_ids"`
+}
+
+// CreateMaintenanceWindow handles POST /nodes/maintenance-windows
+func (h *NodeMaintenanceHandler) CreateMaintenanceWindow(c *gin.Context) {
+	var req CreateMaintenanceWindowRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for create maintenance window", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	userID, err := utils.GetUserIDFromContext(c)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	for _, sid := range req.NodeIDs {
+		if err := id.ValidatePrefix(sid, id.PrefixNode); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "invalid node ID: "+sid)
+			return
+		}
+	}
+	for _, sid := range req.ResourceGroupIDs {
+		if err := id.ValidatePrefix(sid, id.PrefixResourceGroup); err != nil {
+			utils.ErrorResponse(c, http.StatusBadRequest, "invalid resource group ID: "+sid)
+			return
+		}
+	}
+
+	result, err := h.createUC.Execute(c.Request.Context(), usecases.CreateMaintenanceWindowCommand{
+		Reason:            req.Reason,
+		StartsAt:          req.StartsAt,
+		EndsAt:            req.EndsAt,
+		NodeSIDs:          req.NodeIDs,
+		ResourceGroupSIDs: req.ResourceGroupIDs,
+		CreatedBy:         userID,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Maintenance window scheduled successfully")
+}
+
+// ListMaintenanceWindows handles GET /nodes/maintenance-windows
+func (h *NodeMaintenanceHandler) ListMaintenanceWindows(c *gin.Context) {
+	p := utils.ParsePagination(c)
+
+	query := usecases.ListMaintenanceWindowsQuery{
+		Page:     p.Page,
+		PageSize: p.PageSize,
+	}
+	if status := c.Query("status"); status != "" {
+		query.Status = &status
+	}
+
+	result, err := h.listUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.ListSuccessResponse(c, result.Windows, result.Total, p.Page, p.PageSize)
+}
+
+// CancelMaintenanceWindow handles POST /nodes/maintenance-windows/:id/cancel
+func (h *NodeMaintenanceHandler) CancelMaintenanceWindow(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixMaintenanceWindow, "maintenance window")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.cancelUC.Execute(c.Request.Context(), usecases.CancelMaintenanceWindowCommand{SID: sid})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Maintenance window cancelled successfully", result)
+}
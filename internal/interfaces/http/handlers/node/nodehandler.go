@@ -23,6 +23,9 @@ type NodeHandler struct {
 	generateTokenUC              generateNodeTokenUseCase
 	generateInstallScriptUC      generateNodeInstallScriptUseCase
 	generateBatchInstallScriptUC generateBatchInstallScriptUseCase
+	rotateServerKeyUC            rotateNodeServerKeyUseCase
+	rotateRealityKeysUC          rotateRealityKeysUseCase
+	getNodeMetricsUC             getNodeMetricsUseCase
 	apiURL                       string
 	logger                       logger.Interface
 }
@@ -53,6 +56,21 @@ func NewNodeHandler(
 	}
 }
 
+// SetRotateServerKeyUseCase sets the use case for SS2022 server key rotation (optional).
+func (h *NodeHandler) SetRotateServerKeyUseCase(uc rotateNodeServerKeyUseCase) {
+	h.rotateServerKeyUC = uc
+}
+
+// SetRotateRealityKeysUseCase sets the use case for VLESS Reality key rotation (optional).
+func (h *NodeHandler) SetRotateRealityKeysUseCase(uc rotateRealityKeysUseCase) {
+	h.rotateRealityKeysUC = uc
+}
+
+// SetGetNodeMetricsUseCase sets the use case for node system metrics history (optional).
+func (h *NodeHandler) SetGetNodeMetricsUseCase(uc getNodeMetricsUseCase) {
+	h.getNodeMetricsUC = uc
+}
+
 // CreateNode handles POST /nodes
 func (h *NodeHandler) CreateNode(c *gin.Context) {
 	var req CreateNodeRequest
@@ -63,6 +81,10 @@ func (h *NodeHandler) CreateNode(c *gin.Context) {
 	}
 
 	cmd := req.ToCommand()
+	// The author is recorded in the configuration history
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.CreatedBy = userID
+	}
 	result, err := h.createNodeUC.Execute(c.Request.Context(), cmd)
 	if err != nil {
 		utils.ErrorResponseWithError(c, err)
@@ -143,6 +165,10 @@ func (h *NodeHandler) UpdateNode(c *gin.Context) {
 	// Override expires_at with validated values (handler is the source of truth)
 	cmd.ExpiresAt = parsedExpiresAt
 	cmd.ClearExpiresAt = clearExpiresAt
+	// The author is recorded in the configuration history
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.UpdatedBy = userID
+	}
 	result, err := h.updateNodeUC.Execute(c.Request.Context(), cmd)
 	if err != nil {
 		utils.ErrorResponseWithError(c, err)
@@ -206,6 +232,103 @@ func (h *NodeHandler) GenerateToken(c *gin.Context) {
 	utils.SuccessResponse(c, http.StatusOK, "Token generated successfully", result)
 }
 
+// RotateServerKey handles POST /nodes/:id/rotate-server-key
+func (h *NodeHandler) RotateServerKey(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if h.rotateServerKeyUC == nil {
+		utils.ErrorResponseWithError(c, errors.NewInternalError("server key rotation is not configured"))
+		return
+	}
+
+	cmd := usecases.RotateNodeServerKeyCommand{SID: sid}
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.RotatedBy = userID
+	}
+	result, err := h.rotateServerKeyUC.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Server key rotated successfully", result)
+}
+
+// RotateRealityKeys handles POST /nodes/:id/rotate-reality-keys
+func (h *NodeHandler) RotateRealityKeys(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if h.rotateRealityKeysUC == nil {
+		utils.ErrorResponseWithError(c, errors.NewInternalError("reality key rotation is not configured"))
+		return
+	}
+
+	cmd := usecases.RotateRealityKeysCommand{SID: sid}
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.RotatedBy = userID
+	}
+	result, err := h.rotateRealityKeysUC.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Reality keys rotated successfully", result)
+}
+
+// GetNodeMetricsRequest represents query parameters for node metrics history
+type GetNodeMetricsRequest struct {
+	From       time.Time `form:"from" time_format:"2006-01-02T15:04:05Z07:00"`
+	To         time.Time `form:"to" time_format:"2006-01-02T15:04:05Z07:00"`
+	Resolution string    `form:"resolution" binding:"omitempty,oneof=minute hour"`
+}
+
+// GetNodeMetrics handles GET /nodes/:id/metrics
+// Query params:
+//   - from, to (optional, RFC3339): time range, defaults to the last 24 hours
+//   - resolution (optional): minute (kept 24h) or hour (kept 30d), picked from the range if omitted
+func (h *NodeHandler) GetNodeMetrics(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if h.getNodeMetricsUC == nil {
+		utils.ErrorResponseWithError(c, errors.NewInternalError("node metrics history is not configured"))
+		return
+	}
+
+	var req GetNodeMetricsRequest
+	if err := c.ShouldBindQuery(&req); err != nil {
+		h.logger.Warnw("invalid query for node metrics", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	query := usecases.GetNodeMetricsQuery{
+		SID:        sid,
+		From:       req.From,
+		To:         req.To,
+		Resolution: req.Resolution,
+	}
+	result, err := h.getNodeMetricsUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
 // GetInstallScript handles GET /nodes/:id/install-script
 // Query params:
 //   - token (optional): API token. If not provided, uses node's current stored token
@@ -280,11 +403,14 @@ type CreateNodeRequest struct {
 	ServerAddress    string            `json:"server_address,omitempty" example:"1.2.3.4"`
 	AgentPort        uint16            `json:"agent_port" binding:"required" example:"8388" comment:"Port for agent connections"`
 	SubscriptionPort *uint16           `json:"subscription_port,omitempty" example:"8389" comment:"Port for client subscriptions (if null, uses agent_port)"`
-	Protocol         string            `json:"protocol" binding:"required,oneof=shadowsocks trojan vless vmess hysteria2 tuic anytls" example:"shadowsocks" comment:"Protocol type"`
-	EncryptionMethod string            `json:"encryption_method,omitempty" example:"aes-256-gcm" comment:"Encryption method (for Shadowsocks)"`
+	Protocol         string            `json:"protocol" binding:"required,oneof=shadowsocks trojan vless vmess hysteria2 tuic anytls wireguard" example:"shadowsocks" comment:"Protocol type"`
+	EncryptionMethod string            `json:"encryption_method,omitempty" example:"2022-blake3-aes-128-gcm" comment:"Encryption method (for Shadowsocks, 2022-blake3-* only for new configs)"`
+	ServerKey        string            `json:"server_key,omitempty" comment:"Shadowsocks 2022 server key (optional, derived from the node token if empty)"`
 	Plugin           *string           `json:"plugin,omitempty" example:"obfs-local"`
 	PluginOpts       map[string]string `json:"plugin_opts,omitempty"`
 	Region           string            `json:"region,omitempty" example:"West Coast"`
+	CountryCode      string            `json:"country_code,omitempty" binding:"omitempty,len=2,alpha" example:"US" comment:"ISO 3166-1 alpha-2 node location, used for subscription region groups"`
+	TrafficMultiplier *float64         `json:"traffic_multiplier,omitempty" binding:"omitempty,gte=0,lte=100" example:"0.5" comment:"Billing rate applied to subscription usage (default 1)"`
 	Tags             []string          `json:"tags,omitempty" example:"premium,fast"`
 	Description      string            `json:"description,omitempty" example:"High-speed US server"`
 	SortOrder        int               `json:"sort_order,omitempty" example:"1"`
@@ -301,7 +427,7 @@ type CreateNodeRequest struct {
 	DNS *dto.DnsConfigDTO `json:"dns,omitempty" comment:"DNS configuration for DNS-based unlocking"`
 
 	// VLESS specific fields
-	VLESSTransportType     string `json:"vless_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc h2" example:"tcp" comment:"VLESS transport type"`
+	VLESSTransportType     string `json:"vless_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc h2 xhttp httpupgrade" example:"tcp" comment:"VLESS transport type"`
 	VLESSFlow              string `json:"vless_flow,omitempty" example:"xtls-rprx-vision" comment:"VLESS flow control"`
 	VLESSSecurity          string `json:"vless_security,omitempty" binding:"omitempty,oneof=none tls reality" example:"tls" comment:"VLESS security type"`
 	VLESSSni               string `json:"vless_sni,omitempty" example:"example.com" comment:"VLESS TLS SNI"`
@@ -314,17 +440,25 @@ type CreateNodeRequest struct {
 	VLESSRealityPublicKey  string `json:"vless_reality_public_key,omitempty" comment:"VLESS Reality public key (optional, auto-generated if empty)"`
 	VLESSRealityShortID    string `json:"vless_reality_short_id,omitempty" comment:"VLESS Reality short ID (optional, auto-generated if empty)"`
 	VLESSRealitySpiderX    string `json:"vless_reality_spider_x,omitempty" comment:"VLESS Reality spider X"`
+	VLESSXHTTPMode         string `json:"vless_xhttp_mode,omitempty" binding:"omitempty,oneof=auto packet-up stream-up stream-one" example:"auto" comment:"VLESS XHTTP mode"`
+	VLESSXHTTPExtra        string `json:"vless_xhttp_extra,omitempty" comment:"VLESS XHTTP extra options (JSON object)"`
+	// VLESS Reality rotation policy (reality security only)
+	VLESSRealityRotationIntervalHours int  `json:"vless_reality_rotation_interval_hours,omitempty" binding:"omitempty,gte=0,lte=8760" example:"168" comment:"Scheduled Reality key/short ID rotation interval in hours (0 = disabled)"`
+	VLESSRealityRotationOverlapHours  int  `json:"vless_reality_rotation_overlap_hours,omitempty" binding:"omitempty,gte=0,lte=720" example:"24" comment:"Hours the previous short ID stays accepted after a rotation"`
+	VLESSRealityRotateKeyPair         bool `json:"vless_reality_rotate_key_pair,omitempty" comment:"Scheduled rotations also replace the Reality key pair"`
 
 	// VMess specific fields
 	VMessAlterID       int    `json:"vmess_alter_id,omitempty" example:"0" comment:"VMess alter ID"`
 	VMessSecurity      string `json:"vmess_security,omitempty" binding:"omitempty,oneof=auto aes-128-gcm chacha20-poly1305 none zero" example:"auto" comment:"VMess security"`
-	VMessTransportType string `json:"vmess_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc http quic" example:"tcp" comment:"VMess transport type"`
+	VMessTransportType string `json:"vmess_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc http quic xhttp httpupgrade" example:"tcp" comment:"VMess transport type"`
 	VMessHost          string `json:"vmess_host,omitempty" comment:"VMess WS/HTTP host header"`
 	VMessPath          string `json:"vmess_path,omitempty" comment:"VMess WS/HTTP path"`
 	VMessServiceName   string `json:"vmess_service_name,omitempty" comment:"VMess gRPC service name"`
 	VMessTLS           bool   `json:"vmess_tls,omitempty" comment:"VMess TLS enabled"`
 	VMessSni           string `json:"vmess_sni,omitempty" comment:"VMess TLS SNI"`
 	VMessAllowInsecure bool   `json:"vmess_allow_insecure,omitempty" comment:"VMess allow insecure TLS"`
+	VMessXHTTPMode     string `json:"vmess_xhttp_mode,omitempty" binding:"omitempty,oneof=auto packet-up stream-up stream-one" example:"auto" comment:"VMess XHTTP mode"`
+	VMessXHTTPExtra    string `json:"vmess_xhttp_extra,omitempty" comment:"VMess XHTTP extra options (JSON object)"`
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl string `json:"hysteria2_congestion_control,omitempty" binding:"omitempty,oneof=cubic bbr new_reno" example:"bbr" comment:"Hysteria2 congestion control"`
@@ -351,6 +485,19 @@ type CreateNodeRequest struct {
 	AnyTLSIdleSessionCheckInterval string `json:"anytls_idle_session_check_interval,omitempty" comment:"AnyTLS idle session check interval"`
 	AnyTLSIdleSessionTimeout       string `json:"anytls_idle_session_timeout,omitempty" comment:"AnyTLS idle session timeout"`
 	AnyTLSMinIdleSession           int    `json:"anytls_min_idle_session,omitempty" comment:"AnyTLS minimum idle sessions"`
+
+	// WireGuard specific fields
+	WireGuardPrivateKey          string `json:"wireguard_private_key,omitempty" comment:"WireGuard server private key (optional, auto-generated if empty)"`
+	WireGuardAddressPool         string `json:"wireguard_address_pool,omitempty" example:"10.66.0.0/16" comment:"WireGuard tunnel address pool (IPv4 CIDR)"`
+	WireGuardMTU                 int    `json:"wireguard_mtu,omitempty" example:"1420" comment:"WireGuard tunnel MTU"`
+	WireGuardDNS                 string `json:"wireguard_dns,omitempty" example:"1.1.1.1" comment:"WireGuard DNS servers pushed to clients (comma-separated)"`
+	WireGuardPersistentKeepalive *int   `json:"wireguard_persistent_keepalive,omitempty" example:"25" comment:"WireGuard persistent keepalive in seconds (0 disables)"`
+
+	// ShadowTLS specific fields (Shadowsocks only)
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty" example:"www.microsoft.com" comment:"ShadowTLS handshake server (enables ShadowTLS when set)"`
+	ShadowTLSHandshakePort   uint16 `json:"shadowtls_handshake_port,omitempty" binding:"omitempty,min=1,max=65535" example:"443" comment:"ShadowTLS handshake server port (default 443)"`
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty" comment:"ShadowTLS password (optional, auto-generated if empty)"`
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty" binding:"omitempty,oneof=2 3" example:"3" comment:"ShadowTLS protocol version (default 3)"`
 }
 
 func (r *CreateNodeRequest) ToCommand() usecases.CreateNodeCommand {
@@ -361,9 +508,12 @@ func (r *CreateNodeRequest) ToCommand() usecases.CreateNodeCommand {
 		SubscriptionPort:  r.SubscriptionPort,
 		Protocol:          r.Protocol,
 		Method:            r.EncryptionMethod,
+		ServerKey:         r.ServerKey,
 		Plugin:            r.Plugin,
 		PluginOpts:        r.PluginOpts,
 		Region:            r.Region,
+		CountryCode:       r.CountryCode,
+		TrafficMultiplier: r.TrafficMultiplier,
 		Tags:              r.Tags,
 		Description:       r.Description,
 		SortOrder:         r.SortOrder,
@@ -389,6 +539,12 @@ func (r *CreateNodeRequest) ToCommand() usecases.CreateNodeCommand {
 		VLESSRealityPublicKey:  r.VLESSRealityPublicKey,
 		VLESSRealityShortID:    r.VLESSRealityShortID,
 		VLESSRealitySpiderX:    r.VLESSRealitySpiderX,
+		VLESSXHTTPMode:         r.VLESSXHTTPMode,
+		VLESSXHTTPExtra:        r.VLESSXHTTPExtra,
+		// VLESS Reality rotation
+		VLESSRealityRotationIntervalHours: r.VLESSRealityRotationIntervalHours,
+		VLESSRealityRotationOverlapHours:  r.VLESSRealityRotationOverlapHours,
+		VLESSRealityRotateKeyPair:         r.VLESSRealityRotateKeyPair,
 		// VMess
 		VMessAlterID:       r.VMessAlterID,
 		VMessSecurity:      r.VMessSecurity,
@@ -399,6 +555,8 @@ func (r *CreateNodeRequest) ToCommand() usecases.CreateNodeCommand {
 		VMessTLS:           r.VMessTLS,
 		VMessSni:           r.VMessSni,
 		VMessAllowInsecure: r.VMessAllowInsecure,
+		VMessXHTTPMode:     r.VMessXHTTPMode,
+		VMessXHTTPExtra:    r.VMessXHTTPExtra,
 		// Hysteria2
 		Hysteria2CongestionControl: r.Hysteria2CongestionControl,
 		Hysteria2Obfs:              r.Hysteria2Obfs,
@@ -422,6 +580,18 @@ func (r *CreateNodeRequest) ToCommand() usecases.CreateNodeCommand {
 		AnyTLSIdleSessionCheckInterval: r.AnyTLSIdleSessionCheckInterval,
 		AnyTLSIdleSessionTimeout:       r.AnyTLSIdleSessionTimeout,
 		AnyTLSMinIdleSession:           r.AnyTLSMinIdleSession,
+		// WireGuard
+		WireGuardPrivateKey:          r.WireGuardPrivateKey,
+		WireGuardAddressPool:         r.WireGuardAddressPool,
+		WireGuardMTU:                 r.WireGuardMTU,
+		WireGuardDNS:                 r.WireGuardDNS,
+		WireGuardPersistentKeepalive: r.WireGuardPersistentKeepalive,
+
+		// ShadowTLS
+		ShadowTLSHandshakeServer: r.ShadowTLSHandshakeServer,
+		ShadowTLSHandshakePort:   r.ShadowTLSHandshakePort,
+		ShadowTLSPassword:        r.ShadowTLSPassword,
+		ShadowTLSVersion:         r.ShadowTLSVersion,
 	}
 }
 
@@ -430,7 +600,7 @@ type UpdateNodeRequest struct {
 	ServerAddress    *string           `json:"server_address,omitempty" example:"2.3.4.5"`
 	AgentPort        *uint16           `json:"agent_port,omitempty" example:"8388" comment:"Port for agent connections"`
 	SubscriptionPort *uint16           `json:"subscription_port,omitempty" example:"8389" comment:"Port for client subscriptions"`
-	EncryptionMethod *string           `json:"encryption_method,omitempty" example:"chacha20-ietf-poly1305" comment:"Encryption method (for Shadowsocks)"`
+	EncryptionMethod *string           `json:"encryption_method,omitempty" example:"2022-blake3-aes-256-gcm" comment:"Encryption method (for Shadowsocks, 2022-blake3-* only for new configs)"`
 	Plugin           *string           `json:"plugin,omitempty" example:"v2ray-plugin"`
 	PluginOpts       map[string]string `json:"plugin_opts,omitempty"`
 	Status           *string           `json:"status,omitempty" binding:"omitempty,oneof=active inactive maintenance" example:"active"`
@@ -454,7 +624,7 @@ type UpdateNodeRequest struct {
 	ClearDNS bool              `json:"clear_dns,omitempty" comment:"Set to true to clear DNS configuration"`
 
 	// VLESS specific fields
-	VLESSTransportType     *string `json:"vless_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc h2" comment:"VLESS transport type"`
+	VLESSTransportType     *string `json:"vless_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc h2 xhttp httpupgrade" comment:"VLESS transport type"`
 	VLESSFlow              *string `json:"vless_flow,omitempty" comment:"VLESS flow control"`
 	VLESSSecurity          *string `json:"vless_security,omitempty" binding:"omitempty,oneof=none tls reality" comment:"VLESS security type"`
 	VLESSSni               *string `json:"vless_sni,omitempty" comment:"VLESS TLS SNI"`
@@ -467,17 +637,25 @@ type UpdateNodeRequest struct {
 	VLESSRealityPublicKey  *string `json:"vless_reality_public_key,omitempty" comment:"VLESS Reality public key (optional, auto-generated if empty)"`
 	VLESSRealityShortID    *string `json:"vless_reality_short_id,omitempty" comment:"VLESS Reality short ID (optional, auto-generated if empty)"`
 	VLESSRealitySpiderX    *string `json:"vless_reality_spider_x,omitempty" comment:"VLESS Reality spider X"`
+	VLESSXHTTPMode         *string `json:"vless_xhttp_mode,omitempty" binding:"omitempty,oneof=auto packet-up stream-up stream-one" comment:"VLESS XHTTP mode"`
+	VLESSXHTTPExtra        *string `json:"vless_xhttp_extra,omitempty" comment:"VLESS XHTTP extra options (JSON object)"`
+	// VLESS Reality rotation policy (reality security only, omit to keep unchanged)
+	VLESSRealityRotationIntervalHours *int  `json:"vless_reality_rotation_interval_hours,omitempty" binding:"omitempty,gte=0,lte=8760" comment:"Scheduled Reality key/short ID rotation interval in hours (0 = disabled)"`
+	VLESSRealityRotationOverlapHours  *int  `json:"vless_reality_rotation_overlap_hours,omitempty" binding:"omitempty,gte=0,lte=720" comment:"Hours the previous short ID stays accepted after a rotation"`
+	VLESSRealityRotateKeyPair         *bool `json:"vless_reality_rotate_key_pair,omitempty" comment:"Scheduled rotations also replace the Reality key pair"`
 
 	// VMess specific fields
 	VMessAlterID       *int    `json:"vmess_alter_id,omitempty" comment:"VMess alter ID"`
 	VMessSecurity      *string `json:"vmess_security,omitempty" binding:"omitempty,oneof=auto aes-128-gcm chacha20-poly1305 none zero" comment:"VMess security"`
-	VMessTransportType *string `json:"vmess_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc http quic" comment:"VMess transport type"`
+	VMessTransportType *string `json:"vmess_transport_type,omitempty" binding:"omitempty,oneof=tcp ws grpc http quic xhttp httpupgrade" comment:"VMess transport type"`
 	VMessHost          *string `json:"vmess_host,omitempty" comment:"VMess WS/HTTP host header"`
 	VMessPath          *string `json:"vmess_path,omitempty" comment:"VMess WS/HTTP path"`
 	VMessServiceName   *string `json:"vmess_service_name,omitempty" comment:"VMess gRPC service name"`
 	VMessTLS           *bool   `json:"vmess_tls,omitempty" comment:"VMess TLS enabled"`
 	VMessSni           *string `json:"vmess_sni,omitempty" comment:"VMess TLS SNI"`
 	VMessAllowInsecure *bool   `json:"vmess_allow_insecure,omitempty" comment:"VMess allow insecure TLS"`
+	VMessXHTTPMode     *string `json:"vmess_xhttp_mode,omitempty" binding:"omitempty,oneof=auto packet-up stream-up stream-one" comment:"VMess XHTTP mode"`
+	VMessXHTTPExtra    *string `json:"vmess_xhttp_extra,omitempty" comment:"VMess XHTTP extra options (JSON object)"`
 
 	// Hysteria2 specific fields
 	Hysteria2CongestionControl *string `json:"hysteria2_congestion_control,omitempty" binding:"omitempty,oneof=cubic bbr new_reno" comment:"Hysteria2 congestion control"`
@@ -505,9 +683,28 @@ type UpdateNodeRequest struct {
 	AnyTLSIdleSessionTimeout       *string `json:"anytls_idle_session_timeout,omitempty" comment:"AnyTLS idle session timeout"`
 	AnyTLSMinIdleSession           *int    `json:"anytls_min_idle_session,omitempty" comment:"AnyTLS minimum idle sessions"`
 
+	// WireGuard specific fields
+	WireGuardPrivateKey          *string `json:"wireguard_private_key,omitempty" comment:"WireGuard server private key"`
+	WireGuardAddressPool         *string `json:"wireguard_address_pool,omitempty" comment:"WireGuard tunnel address pool (IPv4 CIDR)"`
+	WireGuardMTU                 *int    `json:"wireguard_mtu,omitempty" comment:"WireGuard tunnel MTU"`
+	WireGuardDNS                 *string `json:"wireguard_dns,omitempty" comment:"WireGuard DNS servers pushed to clients (comma-separated)"`
+	WireGuardPersistentKeepalive *int    `json:"wireguard_persistent_keepalive,omitempty" comment:"WireGuard persistent keepalive in seconds (0 disables)"`
+
+	// ShadowTLS specific fields (Shadowsocks only)
+	ShadowTLSHandshakeServer *string `json:"shadowtls_handshake_server,omitempty" comment:"ShadowTLS handshake server (empty string disables ShadowTLS)"`
+	ShadowTLSHandshakePort   *uint16 `json:"shadowtls_handshake_port,omitempty" binding:"omitempty,min=1,max=65535" comment:"ShadowTLS handshake server port"`
+	ShadowTLSPassword        *string `json:"shadowtls_password,omitempty" comment:"ShadowTLS password (empty string generates a new one)"`
+	ShadowTLSVersion         *int    `json:"shadowtls_version,omitempty" binding:"omitempty,oneof=2 3" comment:"ShadowTLS protocol version"`
+
 	// Expiration and cost label fields
 	ExpiresAt *string `json:"expires_at,omitempty" example:"2025-12-31T23:59:59Z" comment:"Expiration time in ISO8601 format (empty string to clear, omit to keep unchanged)"`
 	CostLabel *string `json:"cost_label,omitempty" example:"35$/m" comment:"Cost label for display (empty string to clear, omit to keep unchanged)"`
+
+	// CountryCode is the ISO 3166-1 alpha-2 node location (empty string to clear, omit to keep unchanged)
+	CountryCode *string `json:"country_code,omitempty" binding:"omitempty,max=2" example:"JP" comment:"ISO 3166-1 alpha-2 node location (empty string to clear, omit to keep unchanged)"`
+
+	// TrafficMultiplier is the billing rate applied to subscription usage (omit to keep unchanged)
+	TrafficMultiplier *float64 `json:"traffic_multiplier,omitempty" binding:"omitempty,gte=0,lte=100" example:"3" comment:"Billing rate applied to subscription usage (omit to keep unchanged)"`
 }
 
 func (r *UpdateNodeRequest) ToCommand(sid string) usecases.UpdateNodeCommand {
@@ -550,6 +747,12 @@ func (r *UpdateNodeRequest) ToCommand(sid string) usecases.UpdateNodeCommand {
 		VLESSRealityPublicKey:  r.VLESSRealityPublicKey,
 		VLESSRealityShortID:    r.VLESSRealityShortID,
 		VLESSRealitySpiderX:    r.VLESSRealitySpiderX,
+		VLESSXHTTPMode:         r.VLESSXHTTPMode,
+		VLESSXHTTPExtra:        r.VLESSXHTTPExtra,
+		// VLESS Reality rotation
+		VLESSRealityRotationIntervalHours: r.VLESSRealityRotationIntervalHours,
+		VLESSRealityRotationOverlapHours:  r.VLESSRealityRotationOverlapHours,
+		VLESSRealityRotateKeyPair:         r.VLESSRealityRotateKeyPair,
 		// VMess
 		VMessAlterID:       r.VMessAlterID,
 		VMessSecurity:      r.VMessSecurity,
@@ -560,6 +763,8 @@ func (r *UpdateNodeRequest) ToCommand(sid string) usecases.UpdateNodeCommand {
 		VMessTLS:           r.VMessTLS,
 		VMessSni:           r.VMessSni,
 		VMessAllowInsecure: r.VMessAllowInsecure,
+		VMessXHTTPMode:     r.VMessXHTTPMode,
+		VMessXHTTPExtra:    r.VMessXHTTPExtra,
 		// Hysteria2
 		Hysteria2CongestionControl: r.Hysteria2CongestionControl,
 		Hysteria2Obfs:              r.Hysteria2Obfs,
@@ -583,6 +788,18 @@ func (r *UpdateNodeRequest) ToCommand(sid string) usecases.UpdateNodeCommand {
 		AnyTLSIdleSessionCheckInterval: r.AnyTLSIdleSessionCheckInterval,
 		AnyTLSIdleSessionTimeout:       r.AnyTLSIdleSessionTimeout,
 		AnyTLSMinIdleSession:           r.AnyTLSMinIdleSession,
+		// WireGuard
+		WireGuardPrivateKey:          r.WireGuardPrivateKey,
+		WireGuardAddressPool:         r.WireGuardAddressPool,
+		WireGuardMTU:                 r.WireGuardMTU,
+		WireGuardDNS:                 r.WireGuardDNS,
+		WireGuardPersistentKeepalive: r.WireGuardPersistentKeepalive,
+
+		// ShadowTLS
+		ShadowTLSHandshakeServer: r.ShadowTLSHandshakeServer,
+		ShadowTLSHandshakePort:   r.ShadowTLSHandshakePort,
+		ShadowTLSPassword:        r.ShadowTLSPassword,
+		ShadowTLSVersion:         r.ShadowTLSVersion,
 	}
 
 	// Note: ExpiresAt is handled by the handler layer after ToCommand returns.
@@ -600,6 +817,17 @@ func (r *UpdateNodeRequest) ToCommand(sid string) usecases.UpdateNodeCommand {
 		}
 	}
 
+	// Handle CountryCode field (format is validated by the use case)
+	if r.CountryCode != nil {
+		if *r.CountryCode == "" {
+			cmd.ClearCountryCode = true
+		} else {
+			cmd.CountryCode = r.CountryCode
+		}
+	}
+
+	cmd.TrafficMultiplier = r.TrafficMultiplier
+
 	return cmd
 }
 
@@ -0,0 +1,133 @@
+package node
+
+import (
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// maxNodeManifestSize limits the size of an uploaded node manifest.
+const maxNodeManifestSize = 4 << 20 // 4MB
+
+// NodeManifestHandler handles bulk node import and export.
+type NodeManifestHandler struct {
+	importUC *usecases.ImportNodesUseCase
+	exportUC *usecases.ExportNodesUseCase
+	logger   logger.Interface
+}
+
+// NewNodeManifestHandler creates a new NodeManifestHandler.
+func NewNodeManifestHandler(
+	importUC *usecases.ImportNodesUseCase,
+	exportUC *usecases.ExportNodesUseCase,
+	log logger.Interface,
+) *NodeManifestHandler {
+	return &NodeManifestHandler{
+		importUC: importUC,
+		exportUC: exportUC,
+		logger:   log,
+	}
+}
+
+// ImportNodes handles POST /nodes/import?dry_run=&format=
+// The body is a YAML or JSON manifest, or share URIs one per line.
+func (h *NodeManifestHandler) ImportNodes(c *gin.Context) {
+	data, err := io.ReadAll(io.LimitReader(c.Request.Body, maxNodeManifestSize+1))
+	if err != nil {
+		h.logger.Errorw("failed to read node manifest", "error", err)
+		utils.ErrorResponseWithError(c, errors.NewBadRequestError("failed to read request body"))
+		return
+	}
+	if len(data) > maxNodeManifestSize {
+		utils.ErrorResponseWithError(c, errors.NewValidationError("manifest exceeds 4MB limit"))
+		return
+	}
+
+	entries, err := usecases.ParseNodeManifest(data, c.Query("format"))
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	cmd := usecases.ImportNodesCommand{
+		Nodes:  entries,
+		DryRun: c.Query("dry_run") == "true" || c.Query("dry_run") == "1",
+	}
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.ImportedBy = userID
+	}
+
+	result, err := h.importUC.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if result.DryRun || !result.Valid {
+		utils.SuccessResponse(c, http.StatusOK, "", result)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Nodes imported successfully", result)
+}
+
+// ExportNodes handles GET /nodes/export?format=yaml|json&node_ids=&group_id=&tag=&include_secrets=
+func (h *NodeManifestHandler) ExportNodes(c *gin.Context) {
+	format := c.DefaultQuery("format", usecases.NodeManifestFormatYAML)
+	if format != usecases.NodeManifestFormatYAML && format != usecases.NodeManifestFormatJSON {
+		utils.ErrorResponseWithError(c, errors.NewValidationError("format must be yaml or json"))
+		return
+	}
+
+	query := usecases.ExportNodesQuery{
+		Tag:            c.Query("tag"),
+		IncludeSecrets: c.Query("include_secrets") == "true" || c.Query("include_secrets") == "1",
+	}
+	if raw := c.Query("node_ids"); raw != "" {
+		for _, sid := range strings.Split(raw, ",") {
+			sid = strings.TrimSpace(sid)
+			if sid == "" {
+				continue
+			}
+			if err := id.ValidatePrefix(sid, id.PrefixNode); err != nil {
+				utils.ErrorResponseWithError(c, errors.NewValidationError("invalid node ID: "+sid))
+				return
+			}
+			query.NodeSIDs = append(query.NodeSIDs, sid)
+		}
+	}
+	if groupSID := c.Query("group_id"); groupSID != "" {
+		if err := id.ValidatePrefix(groupSID, id.PrefixResourceGroup); err != nil {
+			utils.ErrorResponseWithError(c, errors.NewValidationError("invalid resource group ID: "+groupSID))
+			return
+		}
+		query.GroupSID = groupSID
+	}
+
+	manifest, err := h.exportUC.Execute(c.Request.Context(), query)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	content, err := usecases.EncodeNodeManifest(manifest, format)
+	if err != nil {
+		h.logger.Errorw("failed to encode node manifest", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	contentType := "application/yaml; charset=utf-8"
+	if format == usecases.NodeManifestFormatJSON {
+		contentType = "application/json; charset=utf-8"
+	}
+	c.Header("Content-Disposition", `attachment; filename="nodes.`+format+`"`)
+	c.Data(http.StatusOK, contentType, content)
+}
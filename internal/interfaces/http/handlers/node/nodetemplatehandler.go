@@ -0,0 +1,265 @@
+package node
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
+	"github.com/orris-inc/orris/internal/shared/errors"
+	"github.com/orris-inc/orris/internal/shared/id"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// NodeTemplateHandler handles node templates and provisioning nodes from templates or clones.
+type NodeTemplateHandler struct {
+	createUC    *usecases.CreateNodeTemplateUseCase
+	listUC      *usecases.ListNodeTemplatesUseCase
+	getUC       *usecases.GetNodeTemplateUseCase
+	updateUC    *usecases.UpdateNodeTemplateUseCase
+	deleteUC    *usecases.DeleteNodeTemplateUseCase
+	provisionUC *usecases.ProvisionNodesUseCase
+	apiURL      string
+	logger      logger.Interface
+}
+
+// NewNodeTemplateHandler creates a new NodeTemplateHandler.
+func NewNodeTemplateHandler(
+	createUC *usecases.CreateNodeTemplateUseCase,
+	listUC *usecases.ListNodeTemplatesUseCase,
+	getUC *usecases.GetNodeTemplateUseCase,
+	updateUC *usecases.UpdateNodeTemplateUseCase,
+	deleteUC *usecases.DeleteNodeTemplateUseCase,
+	provisionUC *usecases.ProvisionNodesUseCase,
+	apiURL string,
+	log logger.Interface,
+) *NodeTemplateHandler {
+	return &NodeTemplateHandler{
+		createUC:    createUC,
+		listUC:      listUC,
+		getUC:       getUC,
+		updateUC:    updateUC,
+		deleteUC:    deleteUC,
+		provisionUC: provisionUC,
+		apiURL:      apiURL,
+		logger:      log,
+	}
+}
+
+// CreateNodeTemplateRequest represents the request to save a node's configuration as a template.
+type CreateNodeTemplateRequest struct {
+	Name         string    `json:"name" binding:"required,max=100" example:"HK VLESS Reality"`
+	Description  string    `json:"description" binding:"max=500"`
+	SourceNodeID string    `json:"source_node_id" binding:"required" example:"node_xK9mP2vL3nQ" comment:"Node whose configuration is saved"`
+	GroupSIDs    *[]string `json:"group_sids" example:"[\"rg_xK9mP2vL3nQ\"]" comment:"Resource groups provisioned nodes join (omit to copy the source node's groups)"`
+}
+
+// UpdateNodeTemplateRequest represents the request to update a node template.
+type UpdateNodeTemplateRequest struct {
+	Name         *string   `json:"name" binding:"omitempty,max=100"`
+	Description  *string   `json:"description" binding:"omitempty,max=500"`
+	SourceNodeID *string   `json:"source_node_id" example:"node_xK9mP2vL3nQ" comment:"Re-capture the configuration from this node"`
+	GroupSIDs    *[]string `json:"group_sids" example:"[\"rg_xK9mP2vL3nQ\"]" comment:"Resource groups provisioned nodes join (empty array to remove all)"`
+}
+
+// ProvisionNodeRequest describes a single node to provision. Only the server address
+// and ports are usually given; everything else comes from the template or source node.
+type ProvisionNodeRequest struct {
+	Name             string   `json:"name" binding:"max=100" example:"HK-Node-02" comment:"Defaults to the template or source node name followed by the address"`
+	ServerAddress    string   `json:"server_address" example:"1.2.3.4"`
+	AgentPort        uint16   `json:"agent_port" example:"443" comment:"Required for templates, defaults to the source node's port for clones"`
+	SubscriptionPort *uint16  `json:"subscription_port" example:"8443"`
+	Region           string   `json:"region" example:"hk"`
+	CountryCode      string   `json:"country_code" example:"HK"`
+	Tags             []string `json:"tags"`
+}
+
+// ProvisionNodesRequest represents the request to create nodes from a template or clone a node.
+type ProvisionNodesRequest struct {
+	Nodes  []ProvisionNodeRequest `json:"nodes" binding:"required,min=1,max=100,dive"`
+	DryRun bool                   `json:"dry_run" comment:"Only validate, do not create nodes"`
+}
+
+// CreateTemplate handles POST /nodes/templates
+func (h *NodeTemplateHandler) CreateTemplate(c *gin.Context) {
+	var req CreateNodeTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for create node template", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+	if err := id.ValidatePrefix(req.SourceNodeID, id.PrefixNode); err != nil {
+		utils.ErrorResponseWithError(c, errors.NewValidationError("invalid node ID: "+req.SourceNodeID))
+		return
+	}
+
+	cmd := usecases.CreateNodeTemplateCommand{
+		Name:          req.Name,
+		Description:   req.Description,
+		SourceNodeSID: req.SourceNodeID,
+		GroupSIDs:     req.GroupSIDs,
+	}
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.CreatedBy = userID
+	}
+
+	result, err := h.createUC.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.CreatedResponse(c, result, "Node template created successfully")
+}
+
+// ListTemplates handles GET /nodes/templates
+func (h *NodeTemplateHandler) ListTemplates(c *gin.Context) {
+	p := utils.ParsePagination(c)
+
+	result, err := h.listUC.Execute(c.Request.Context(), usecases.ListNodeTemplatesQuery{
+		Page:     p.Page,
+		PageSize: p.PageSize,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.ListSuccessResponse(c, result.Templates, result.Total, p.Page, p.PageSize)
+}
+
+// GetTemplate handles GET /nodes/templates/:id
+func (h *NodeTemplateHandler) GetTemplate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNodeTemplate, "node template")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	result, err := h.getUC.Execute(c.Request.Context(), usecases.GetNodeTemplateQuery{SID: sid})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "", result)
+}
+
+// UpdateTemplate handles PATCH /nodes/templates/:id
+func (h *NodeTemplateHandler) UpdateTemplate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNodeTemplate, "node template")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	var req UpdateNodeTemplateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for update node template", "sid", sid, "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+	if req.SourceNodeID != nil {
+		if err := id.ValidatePrefix(*req.SourceNodeID, id.PrefixNode); err != nil {
+			utils.ErrorResponseWithError(c, errors.NewValidationError("invalid node ID: "+*req.SourceNodeID))
+			return
+		}
+	}
+
+	result, err := h.updateUC.Execute(c.Request.Context(), usecases.UpdateNodeTemplateCommand{
+		SID:           sid,
+		Name:          req.Name,
+		Description:   req.Description,
+		SourceNodeSID: req.SourceNodeID,
+		GroupSIDs:     req.GroupSIDs,
+	})
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.SuccessResponse(c, http.StatusOK, "Node template updated successfully", result)
+}
+
+// DeleteTemplate handles DELETE /nodes/templates/:id
+func (h *NodeTemplateHandler) DeleteTemplate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNodeTemplate, "node template")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if err := h.deleteUC.Execute(c.Request.Context(), usecases.DeleteNodeTemplateCommand{SID: sid}); err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	utils.NoContentResponse(c)
+}
+
+// ProvisionFromTemplate handles POST /nodes/templates/:id/provision?api_url=
+func (h *NodeTemplateHandler) ProvisionFromTemplate(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNodeTemplate, "node template")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	h.provision(c, usecases.ProvisionNodesCommand{TemplateSID: sid})
+}
+
+// CloneNode handles POST /nodes/:id/clone?api_url=
+func (h *NodeTemplateHandler) CloneNode(c *gin.Context) {
+	sid, err := utils.ParseSIDParam(c, "id", id.PrefixNode, "node")
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	h.provision(c, usecases.ProvisionNodesCommand{SourceNodeSID: sid})
+}
+
+func (h *NodeTemplateHandler) provision(c *gin.Context, cmd usecases.ProvisionNodesCommand) {
+	var req ProvisionNodesRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.logger.Warnw("invalid request body for provision nodes", "error", err)
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	cmd.DryRun = req.DryRun
+	cmd.Nodes = make([]usecases.ProvisionNodeSpec, 0, len(req.Nodes))
+	for _, n := range req.Nodes {
+		cmd.Nodes = append(cmd.Nodes, usecases.ProvisionNodeSpec{
+			Name:             n.Name,
+			ServerAddress:    n.ServerAddress,
+			AgentPort:        n.AgentPort,
+			SubscriptionPort: n.SubscriptionPort,
+			Region:           n.Region,
+			CountryCode:      n.CountryCode,
+			Tags:             n.Tags,
+		})
+	}
+
+	// Use query param to override API URL if provided
+	cmd.APIURL = c.Query("api_url")
+	if cmd.APIURL == "" {
+		cmd.APIURL = h.apiURL
+	}
+	if userID, err := utils.GetUserIDFromContext(c); err == nil {
+		cmd.CreatedBy = userID
+	}
+
+	result, err := h.provisionUC.Execute(c.Request.Context(), cmd)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
+
+	if result.DryRun || !result.Valid {
+		utils.SuccessResponse(c, http.StatusOK, "", result)
+		return
+	}
+	utils.SuccessResponse(c, http.StatusOK, "Nodes provisioned successfully", result)
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
 	"strings"
 
 	"github.com/gin-gonic/gin"
@@ -18,37 +20,55 @@ type GenerateSubscriptionExecutor interface {
 	Execute(ctx context.Context, cmd usecases.GenerateSubscriptionCommand) (*usecases.GenerateSubscriptionResult, error)
 }
 
+// SubscriptionFormatRuleProvider provides admin-defined User-Agent to format rules.
+type SubscriptionFormatRuleProvider interface {
+	GetUserAgentFormatRules(ctx context.Context) []usecases.UserAgentFormatRule
+}
+
 type SubscriptionHandler struct {
 	generateSubscriptionUC GenerateSubscriptionExecutor
+	formatRuleProvider     SubscriptionFormatRuleProvider
 	logger                 logger.Interface
 }
 
 func NewSubscriptionHandler(
 	generateSubscriptionUC GenerateSubscriptionExecutor,
+	formatRuleProvider SubscriptionFormatRuleProvider,
 	log logger.Interface,
 ) *SubscriptionHandler {
 	return &SubscriptionHandler{
 		generateSubscriptionUC: generateSubscriptionUC,
+		formatRuleProvider:     formatRuleProvider,
 		logger:                 log,
 	}
 }
 
 // GetSubscription handles GET /s/:token with auto-format detection from User-Agent
 func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
+	// Auto-detect format from User-Agent header
+	h.serveSubscription(c, h.negotiateFormat(c.Request.Context(), c.GetHeader("User-Agent")))
+}
+
+// serveSubscription generates the subscription in the given format and writes the response.
+// Node filter query parameters (include, exclude, protocol, limit, rename) apply to every format.
+func (h *SubscriptionHandler) serveSubscription(c *gin.Context, format string) {
 	token := c.Param("token")
 	if token == "" {
 		utils.ErrorResponseWithError(c, errors.NewValidationError("Subscription token is required"))
 		return
 	}
 
-	// Auto-detect format from User-Agent header
-	userAgent := c.GetHeader("User-Agent")
-	format := detectFormatFromUserAgent(userAgent)
+	filter, err := parseNodeFilter(c)
+	if err != nil {
+		utils.ErrorResponseWithError(c, err)
+		return
+	}
 
 	cmd := usecases.GenerateSubscriptionCommand{
 		SubscriptionToken: token,
 		Format:            format,
 		NodeMode:          c.DefaultQuery("mode", usecases.NodeModeAll),
+		Filter:            filter,
 	}
 
 	result, err := h.generateSubscriptionUC.Execute(c.Request.Context(), cmd)
@@ -57,12 +77,68 @@ func (h *SubscriptionHandler) GetSubscription(c *gin.Context) {
 		return
 	}
 
+	h.writeSubscriptionResponse(c, result)
+}
+
+// parseNodeFilter reads the node filter query parameters.
+// protocol accepts repeated and comma-separated values; rename may be repeated.
+func parseNodeFilter(c *gin.Context) (*usecases.SubscriptionNodeFilter, error) {
+	filter := &usecases.SubscriptionNodeFilter{
+		Include: c.Query("include"),
+		Exclude: c.Query("exclude"),
+		Rename:  c.QueryArray("rename"),
+	}
+
+	for _, value := range c.QueryArray("protocol") {
+		for _, protocol := range strings.Split(value, ",") {
+			if protocol = strings.TrimSpace(protocol); protocol != "" {
+				filter.Protocols = append(filter.Protocols, protocol)
+			}
+		}
+	}
+
+	if raw := c.Query("limit"); raw != "" {
+		limit, err := strconv.Atoi(raw)
+		if err != nil || limit < 0 {
+			return nil, errors.NewValidationError("limit must be a non-negative integer")
+		}
+		filter.Limit = limit
+	}
+
+	return filter, nil
+}
+
+// subscriptionFileNames maps formats to the fallback file name used in Content-Disposition.
+var subscriptionFileNames = map[string]string{
+	"clash":     "clash.yaml",
+	"surge":     "surge.conf",
+	"quanx":     "quanx.conf",
+	"loon":      "loon.conf",
+	"v2ray":     "v2ray.json",
+	"sip008":    "sip008.json",
+	"singbox":   "singbox.json",
+	"wireguard": "wireguard.conf",
+}
+
+// writeSubscriptionResponse writes the subscription content together with the
+// Subscription-Userinfo and profile metadata headers read by clients such as
+// Clash Verge, Stash and Shadowrocket.
+func (h *SubscriptionHandler) writeSubscriptionResponse(c *gin.Context, result *usecases.GenerateSubscriptionResult) {
 	c.Header("Content-Type", result.ContentType)
 	c.Header("Subscription-Userinfo", h.formatUserInfo(result.UserInfo))
 
-	// Set filename header based on format
-	if format == "clash" {
-		c.Header("Content-Disposition", "attachment; filename=clash.yaml")
+	var title string
+	if profile := result.Profile; profile != nil {
+		title = profile.Title
+		if profile.UpdateIntervalHours > 0 {
+			c.Header("Profile-Update-Interval", strconv.Itoa(profile.UpdateIntervalHours))
+		}
+		if profile.WebPageURL != "" {
+			c.Header("Profile-Web-Page-Url", profile.WebPageURL)
+		}
+	}
+	if disposition := formatContentDisposition(result.Format, title); disposition != "" {
+		c.Header("Content-Disposition", disposition)
 	}
 
 	c.String(http.StatusOK, result.Content)
@@ -81,105 +157,88 @@ func (h *SubscriptionHandler) formatUserInfo(userInfo *usecases.SubscriptionUser
 	)
 }
 
-// GetClashSubscription handles GET /s/:token/clash
-func (h *SubscriptionHandler) GetClashSubscription(c *gin.Context) {
-	token := c.Param("token")
-	if token == "" {
-		utils.ErrorResponseWithError(c, errors.NewValidationError("Subscription token is required"))
-		return
-	}
-
-	cmd := usecases.GenerateSubscriptionCommand{
-		SubscriptionToken: token,
-		Format:            "clash",
-		NodeMode:          c.DefaultQuery("mode", usecases.NodeModeAll),
+// formatContentDisposition builds the Content-Disposition header. Clients use the
+// file name as the profile name, so the site name is sent as an RFC 5987 encoded
+// filename* with the per-format file name as the ASCII fallback.
+// Returns "" when there is neither a title nor a fallback file name.
+func formatContentDisposition(format, title string) string {
+	fileName := subscriptionFileNames[format]
+	title = strings.TrimSpace(title)
+	if title == "" {
+		if fileName == "" {
+			return ""
+		}
+		return "attachment; filename=" + fileName
 	}
-
-	result, err := h.generateSubscriptionUC.Execute(c.Request.Context(), cmd)
-	if err != nil {
-		utils.ErrorResponseWithError(c, err)
-		return
+	if fileName == "" {
+		fileName = "subscription"
 	}
+	encoded := strings.ReplaceAll(url.QueryEscape(title), "+", "%20")
+	return fmt.Sprintf("attachment; filename=%s; filename*=UTF-8''%s", fileName, encoded)
+}
 
-	c.Header("Content-Type", result.ContentType)
-	c.Header("Subscription-Userinfo", h.formatUserInfo(result.UserInfo))
-	c.Header("Content-Disposition", "attachment; filename=clash.yaml")
-	c.String(http.StatusOK, result.Content)
+// GetClashSubscription handles GET /s/:token/clash
+func (h *SubscriptionHandler) GetClashSubscription(c *gin.Context) {
+	h.serveSubscription(c, "clash")
 }
 
 // GetV2RaySubscription handles GET /s/:token/v2ray
 func (h *SubscriptionHandler) GetV2RaySubscription(c *gin.Context) {
-	token := c.Param("token")
-	if token == "" {
-		utils.ErrorResponseWithError(c, errors.NewValidationError("Subscription token is required"))
-		return
-	}
-
-	cmd := usecases.GenerateSubscriptionCommand{
-		SubscriptionToken: token,
-		Format:            "v2ray",
-		NodeMode:          c.DefaultQuery("mode", usecases.NodeModeAll),
-	}
-
-	result, err := h.generateSubscriptionUC.Execute(c.Request.Context(), cmd)
-	if err != nil {
-		utils.ErrorResponseWithError(c, err)
-		return
-	}
-
-	c.Header("Content-Type", result.ContentType)
-	c.Header("Subscription-Userinfo", h.formatUserInfo(result.UserInfo))
-	c.String(http.StatusOK, result.Content)
+	h.serveSubscription(c, "v2ray")
 }
 
 // GetSIP008Subscription handles GET /s/:token/sip008
 func (h *SubscriptionHandler) GetSIP008Subscription(c *gin.Context) {
-	token := c.Param("token")
-	if token == "" {
-		utils.ErrorResponseWithError(c, errors.NewValidationError("Subscription token is required"))
-		return
-	}
+	h.serveSubscription(c, "sip008")
+}
 
-	cmd := usecases.GenerateSubscriptionCommand{
-		SubscriptionToken: token,
-		Format:            "sip008",
-		NodeMode:          c.DefaultQuery("mode", usecases.NodeModeAll),
-	}
+// GetSurgeSubscription handles GET /s/:token/surge
+func (h *SubscriptionHandler) GetSurgeSubscription(c *gin.Context) {
+	h.serveSubscription(c, "surge")
+}
 
-	result, err := h.generateSubscriptionUC.Execute(c.Request.Context(), cmd)
-	if err != nil {
-		utils.ErrorResponseWithError(c, err)
-		return
-	}
+// GetSingBoxSubscription handles GET /s/:token/singbox
+func (h *SubscriptionHandler) GetSingBoxSubscription(c *gin.Context) {
+	h.serveSubscription(c, "singbox")
+}
 
-	c.Header("Content-Type", result.ContentType)
-	c.Header("Subscription-Userinfo", h.formatUserInfo(result.UserInfo))
-	c.String(http.StatusOK, result.Content)
+// GetQuantumultXSubscription handles GET /s/:token/quanx
+func (h *SubscriptionHandler) GetQuantumultXSubscription(c *gin.Context) {
+	h.serveSubscription(c, "quanx")
 }
 
-// GetSurgeSubscription handles GET /s/:token/surge
-func (h *SubscriptionHandler) GetSurgeSubscription(c *gin.Context) {
-	token := c.Param("token")
-	if token == "" {
-		utils.ErrorResponseWithError(c, errors.NewValidationError("Subscription token is required"))
-		return
-	}
+// GetLoonSubscription handles GET /s/:token/loon
+func (h *SubscriptionHandler) GetLoonSubscription(c *gin.Context) {
+	h.serveSubscription(c, "loon")
+}
 
-	cmd := usecases.GenerateSubscriptionCommand{
-		SubscriptionToken: token,
-		Format:            "surge",
-		NodeMode:          c.DefaultQuery("mode", usecases.NodeModeAll),
-	}
+// GetWireGuardSubscription handles GET /s/:token/wireguard
+func (h *SubscriptionHandler) GetWireGuardSubscription(c *gin.Context) {
+	h.serveSubscription(c, "wireguard")
+}
 
-	result, err := h.generateSubscriptionUC.Execute(c.Request.Context(), cmd)
-	if err != nil {
-		utils.ErrorResponseWithError(c, err)
-		return
+// negotiateFormat picks the subscription format for a User-Agent.
+// Admin-defined rules take precedence over the built-in client detection.
+func (h *SubscriptionHandler) negotiateFormat(ctx context.Context, userAgent string) string {
+	if h.formatRuleProvider != nil && userAgent != "" {
+		if format, ok := matchUserAgentFormatRules(userAgent, h.formatRuleProvider.GetUserAgentFormatRules(ctx)); ok {
+			return format
+		}
 	}
+	return detectFormatFromUserAgent(userAgent)
+}
 
-	c.Header("Content-Type", result.ContentType)
-	c.Header("Subscription-Userinfo", h.formatUserInfo(result.UserInfo))
-	c.String(http.StatusOK, result.Content)
+// matchUserAgentFormatRules returns the format of the first rule whose pattern
+// is contained in the User-Agent (case-insensitive).
+func matchUserAgentFormatRules(userAgent string, rules []usecases.UserAgentFormatRule) (string, bool) {
+	ua := strings.ToLower(userAgent)
+	for _, rule := range rules {
+		pattern := strings.ToLower(strings.TrimSpace(rule.Pattern))
+		if pattern != "" && strings.Contains(ua, pattern) {
+			return rule.Format, true
+		}
+	}
+	return "", false
 }
 
 // detectFormatFromUserAgent detects subscription format from User-Agent header
@@ -190,8 +249,14 @@ func detectFormatFromUserAgent(userAgent string) string {
 
 	ua := strings.ToLower(userAgent)
 
-	// Clash clients
-	if strings.Contains(ua, "clash") {
+	// sing-box clients (SFA/SFI/SFM/SFT report "sing-box" in their User-Agent)
+	// Checked first because some sing-box based clients also mention clash compatibility
+	if strings.Contains(ua, "sing-box") || strings.Contains(ua, "singbox") {
+		return "singbox"
+	}
+
+	// Clash clients (Clash Verge, ClashX, Clash Meta, mihomo, Stash)
+	if strings.Contains(ua, "clash") || strings.Contains(ua, "mihomo") || strings.Contains(ua, "stash") {
 		return "clash"
 	}
 
@@ -200,9 +265,14 @@ func detectFormatFromUserAgent(userAgent string) string {
 		return "surge"
 	}
 
-	// Quantumult clients
+	// Quantumult X clients
 	if strings.Contains(ua, "quantumult") {
-		return "base64"
+		return "quanx"
+	}
+
+	// Loon clients
+	if strings.Contains(ua, "loon") {
+		return "loon"
 	}
 
 	// Shadowrocket clients
@@ -2,6 +2,8 @@ package node
 
 import (
 	"testing"
+
+	"github.com/orris-inc/orris/internal/application/node/usecases"
 )
 
 func TestDetectFormatFromUserAgent(t *testing.T) {
@@ -41,14 +43,19 @@ func TestDetectFormatFromUserAgent(t *testing.T) {
 			expected:  "surge",
 		},
 		{
-			name:      "Quantumult client returns base64 format",
+			name:      "Quantumult client returns quanx format",
 			userAgent: "Quantumult/1.0.0",
-			expected:  "base64",
+			expected:  "quanx",
 		},
 		{
-			name:      "Quantumult X client returns base64 format",
+			name:      "Quantumult X client returns quanx format",
 			userAgent: "Quantumult%20X/1.0.0",
-			expected:  "base64",
+			expected:  "quanx",
+		},
+		{
+			name:      "Loon client returns loon format",
+			userAgent: "Loon/3.2.1 (iPhone; iOS 17.5)",
+			expected:  "loon",
 		},
 		{
 			name:      "Shadowrocket client returns base64 format",
@@ -85,6 +92,26 @@ func TestDetectFormatFromUserAgent(t *testing.T) {
 			userAgent: "SURGE/1.0.0",
 			expected:  "surge",
 		},
+		{
+			name:      "mihomo client returns clash format",
+			userAgent: "mihomo/1.18.5",
+			expected:  "clash",
+		},
+		{
+			name:      "Stash client returns clash format",
+			userAgent: "Stash/2.4.0",
+			expected:  "clash",
+		},
+		{
+			name:      "SFA client returns singbox format",
+			userAgent: "SFA/1.10.1 (Android 14; sing-box 1.10.1)",
+			expected:  "singbox",
+		},
+		{
+			name:      "sing-box CLI returns singbox format",
+			userAgent: "sing-box 1.11.0",
+			expected:  "singbox",
+		},
 	}
 
 	for _, tt := range tests {
@@ -96,3 +123,55 @@ func TestDetectFormatFromUserAgent(t *testing.T) {
 		})
 	}
 }
+
+func TestMatchUserAgentFormatRules(t *testing.T) {
+	rules := []usecases.UserAgentFormatRule{
+		{Pattern: "Shadowrocket", Format: "clash"},
+		{Pattern: "  ", Format: "surge"},
+		{Pattern: "rocket", Format: "sip008"},
+	}
+
+	tests := []struct {
+		name      string
+		userAgent string
+		format    string
+		matched   bool
+	}{
+		{name: "first matching rule wins", userAgent: "Shadowrocket/2070", format: "clash", matched: true},
+		{name: "case insensitive match", userAgent: "SHADOWROCKET/1.0", format: "clash", matched: true},
+		{name: "later rule matches when earlier does not", userAgent: "MyRocket/1.0", format: "sip008", matched: true},
+		{name: "blank pattern never matches", userAgent: "curl/8.0", format: "", matched: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, ok := matchUserAgentFormatRules(tt.userAgent, rules)
+			if format != tt.format || ok != tt.matched {
+				t.Errorf("matchUserAgentFormatRules(%q) = (%q, %v), expected (%q, %v)", tt.userAgent, format, ok, tt.format, tt.matched)
+			}
+		})
+	}
+}
+
+func TestFormatContentDisposition(t *testing.T) {
+	tests := []struct {
+		name     string
+		format   string
+		title    string
+		expected string
+	}{
+		{name: "no title keeps format file name", format: "clash", title: "", expected: "attachment; filename=clash.yaml"},
+		{name: "no title and no file name omits header", format: "base64", title: "", expected: ""},
+		{name: "title is RFC 5987 encoded", format: "clash", title: "My VPN", expected: "attachment; filename=clash.yaml; filename*=UTF-8''My%20VPN"},
+		{name: "non-ASCII title", format: "base64", title: "机场", expected: "attachment; filename=subscription; filename*=UTF-8''%E6%9C%BA%E5%9C%BA"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result := formatContentDisposition(tt.format, tt.title)
+			if result != tt.expected {
+				t.Errorf("formatContentDisposition(%q, %q) = %q, expected %q", tt.format, tt.title, result, tt.expected)
+			}
+		})
+	}
+}
@@ -285,7 +285,7 @@ type CreateUserNodeRequest struct {
 	AgentPort         uint16            `json:"agent_port" binding:"required,min=1,max=65535" example:"8388"`
 	SubscriptionPort  *uint16           `json:"subscription_port,omitempty" binding:"omitempty,min=1,max=65535" example:"8389"`
 	Protocol          string            `json:"protocol" binding:"required,oneof=shadowsocks trojan" example:"shadowsocks"`
-	Method            string            `json:"method,omitempty" example:"aes-256-gcm"`
+	Method            string            `json:"method,omitempty" example:"2022-blake3-aes-128-gcm"`
 	Plugin            *string           `json:"plugin,omitempty" example:"obfs-local"`
 	PluginOpts        map[string]string `json:"plugin_opts,omitempty"`
 	TransportProtocol string            `json:"transport_protocol,omitempty" binding:"omitempty,oneof=tcp ws grpc" example:"tcp"`
@@ -293,6 +293,12 @@ type CreateUserNodeRequest struct {
 	Path              string            `json:"path,omitempty" example:"/trojan"`
 	SNI               string            `json:"sni,omitempty" example:"example.com"`
 	AllowInsecure     bool              `json:"allow_insecure,omitempty" example:"false"`
+
+	// ShadowTLS fields (Shadowsocks only); ShadowTLS is enabled when a handshake server is set
+	ShadowTLSHandshakeServer string `json:"shadowtls_handshake_server,omitempty" example:"www.microsoft.com"`
+	ShadowTLSHandshakePort   uint16 `json:"shadowtls_handshake_port,omitempty" binding:"omitempty,min=1,max=65535" example:"443"`
+	ShadowTLSPassword        string `json:"shadowtls_password,omitempty"`
+	ShadowTLSVersion         int    `json:"shadowtls_version,omitempty" binding:"omitempty,oneof=2 3" example:"3"`
 }
 
 func (r *CreateUserNodeRequest) ToCommand(userID uint) usecases.CreateUserNodeCommand {
@@ -311,6 +317,11 @@ func (r *CreateUserNodeRequest) ToCommand(userID uint) usecases.CreateUserNodeCo
 		Path:              r.Path,
 		SNI:               r.SNI,
 		AllowInsecure:     r.AllowInsecure,
+
+		ShadowTLSHandshakeServer: r.ShadowTLSHandshakeServer,
+		ShadowTLSHandshakePort:   r.ShadowTLSHandshakePort,
+		ShadowTLSPassword:        r.ShadowTLSPassword,
+		ShadowTLSVersion:         r.ShadowTLSVersion,
 	}
 }
 
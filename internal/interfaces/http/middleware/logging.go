@@ -1,14 +1,35 @@
 package middleware
 
 import (
+	"strconv"
 	"time"
 
 	"github.com/gin-gonic/gin"
 
 	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/metrics"
 )
 
+// Logger logs completed requests and records their latency in the HTTP request histogram.
 func Logger(log logger.Interface) gin.HandlerFunc {
+	logRequest := requestLogger(log)
+	return func(c *gin.Context) {
+		start := time.Now()
+
+		logRequest(c)
+
+		// Use the route template to keep label cardinality bounded
+		route := c.FullPath()
+		if route == "" {
+			route = "unmatched"
+		}
+		metrics.HTTPRequestDuration.
+			WithLabelValues(c.Request.Method, route, strconv.Itoa(c.Writer.Status())).
+			Observe(time.Since(start).Seconds())
+	}
+}
+
+func requestLogger(log logger.Interface) gin.HandlerFunc {
 	return gin.LoggerWithFormatter(func(param gin.LogFormatterParams) string {
 		args := []any{
 			"method", param.Method,
@@ -0,0 +1,77 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/orris-inc/orris/internal/shared/config"
+	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/utils"
+)
+
+// MetricsAuth protects the metrics endpoint with a bearer token and/or a client IP allowlist.
+// When both are configured, a request must satisfy both.
+// Returns an error if an allowlist entry is neither an IP nor a CIDR.
+func MetricsAuth(cfg config.MetricsConfig, log logger.Interface) (gin.HandlerFunc, error) {
+	nets := make([]*net.IPNet, 0, len(cfg.AllowedIPs))
+	for _, entry := range cfg.AllowedIPs {
+		entry = strings.TrimSpace(entry)
+		if !strings.Contains(entry, "/") {
+			ip := net.ParseIP(entry)
+			if ip == nil {
+				return nil, fmt.Errorf("invalid metrics allowed IP: %q", entry)
+			}
+			bits := 8 * net.IPv6len
+			if ip4 := ip.To4(); ip4 != nil {
+				ip, bits = ip4, 8*net.IPv4len
+			}
+			nets = append(nets, &net.IPNet{IP: ip, Mask: net.CIDRMask(bits, bits)})
+			continue
+		}
+		_, ipNet, err := net.ParseCIDR(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid metrics allowed CIDR %q: %w", entry, err)
+		}
+		nets = append(nets, ipNet)
+	}
+	token := []byte(cfg.Token)
+
+	return func(c *gin.Context) {
+		if len(nets) > 0 && !ipAllowed(nets, c.ClientIP()) {
+			log.Warnw("metrics scrape from disallowed IP", "ip", c.ClientIP())
+			utils.ErrorResponse(c, http.StatusForbidden, "access denied")
+			c.Abort()
+			return
+		}
+
+		if len(token) > 0 {
+			provided, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer ")
+			if !ok || subtle.ConstantTimeCompare([]byte(provided), token) != 1 {
+				log.Warnw("metrics scrape with invalid token", "ip", c.ClientIP())
+				utils.ErrorResponse(c, http.StatusUnauthorized, "invalid metrics token")
+				c.Abort()
+				return
+			}
+		}
+
+		c.Next()
+	}, nil
+}
+
+func ipAllowed(nets []*net.IPNet, clientIP string) bool {
+	ip := net.ParseIP(clientIP)
+	if ip == nil {
+		return false
+	}
+	for _, n := range nets {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
@@ -79,7 +79,13 @@ type Router struct {
 	agentHubHandler                *forwardAgentHubHandlers.Handler
 	nodeHubHandler                 *nodeHandlers.NodeHubHandler
 	nodeVersionHandler             *nodeHandlers.NodeVersionHandler
+	nodeMaintenanceHandler         *nodeHandlers.NodeMaintenanceHandler
+	nodeCertificateHandler         *nodeHandlers.NodeCertificateHandler
+	nodeConfigVersionHandler       *nodeHandlers.NodeConfigVersionHandler
+	nodeManifestHandler            *nodeHandlers.NodeManifestHandler
+	nodeTemplateHandler            *nodeHandlers.NodeTemplateHandler
 	nodeSSEHandler                 *nodeHandlers.NodeSSEHandler
+	metricsHandler                 *handlers.MetricsHandler
 	adminHub                       *services.AdminHub
 	configSyncService              *forwardServices.ConfigSyncService
 	trafficLimitEnforcementSvc     *forwardServices.TrafficLimitEnforcementService
@@ -151,7 +157,13 @@ func NewRouter(userService *user.ServiceDDD, db *gorm.DB, cfg *config.Config, lo
 		agentHubHandler:                c.hdlrs.agentHubHandler,
 		nodeHubHandler:                 c.hdlrs.nodeHubHandler,
 		nodeVersionHandler:             c.hdlrs.nodeVersionHandler,
+		nodeMaintenanceHandler:         c.hdlrs.nodeMaintenanceHandler,
+		nodeCertificateHandler:         c.hdlrs.nodeCertificateHandler,
+		nodeConfigVersionHandler:       c.hdlrs.nodeConfigVersionHandler,
+		nodeManifestHandler:            c.hdlrs.nodeManifestHandler,
+		nodeTemplateHandler:            c.hdlrs.nodeTemplateHandler,
 		nodeSSEHandler:                 c.hdlrs.nodeSSEHandler,
+		metricsHandler:                 c.hdlrs.metricsHandler,
 		adminHub:                       c.adminHub,
 		configSyncService:              c.configSyncService,
 		trafficLimitEnforcementSvc:     c.trafficLimitEnforcementSvc,
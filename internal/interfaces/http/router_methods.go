@@ -31,6 +31,7 @@ func (r *Router) SetupRoutes(cfg *config.Config) {
 
 	r.engine.GET("/health", r.userHandler.HealthCheck)
 	r.engine.GET("/version", r.userHandler.Version)
+	r.setupMetricsRoute(cfg)
 
 	routes.SetupAuthRoutes(r.engine, &routes.AuthRouteConfig{
 		AuthHandler:    r.authHandler,
@@ -74,17 +75,22 @@ func (r *Router) SetupRoutes(cfg *config.Config) {
 	})
 
 	routes.SetupNodeRoutes(r.engine, &routes.NodeRouteConfig{
-		NodeHandler:         r.nodeHandler,
-		NodeHubHandler:      r.nodeHubHandler,
-		NodeVersionHandler:  r.nodeVersionHandler,
-		NodeSSEHandler:      r.nodeSSEHandler,
-		UserNodeHandler:     r.userNodeHandler,
-		SubscriptionHandler: r.nodeSubscriptionHandler,
-		AuthMiddleware:      r.authMiddleware,
-		NodeTokenMW:         r.nodeTokenMiddleware,
-		NodeOwnerMW:         r.nodeOwnerMiddleware,
-		NodeQuotaMW:         r.nodeQuotaMiddleware,
-		RateLimiter:         r.rateLimiter,
+		NodeHandler:          r.nodeHandler,
+		NodeHubHandler:       r.nodeHubHandler,
+		NodeVersionHandler:   r.nodeVersionHandler,
+		MaintenanceHandler:   r.nodeMaintenanceHandler,
+		CertificateHandler:   r.nodeCertificateHandler,
+		ConfigVersionHandler: r.nodeConfigVersionHandler,
+		ManifestHandler:      r.nodeManifestHandler,
+		TemplateHandler:      r.nodeTemplateHandler,
+		NodeSSEHandler:       r.nodeSSEHandler,
+		UserNodeHandler:      r.userNodeHandler,
+		SubscriptionHandler:  r.nodeSubscriptionHandler,
+		AuthMiddleware:       r.authMiddleware,
+		NodeTokenMW:          r.nodeTokenMiddleware,
+		NodeOwnerMW:          r.nodeOwnerMiddleware,
+		NodeQuotaMW:          r.nodeQuotaMiddleware,
+		RateLimiter:          r.rateLimiter,
 	})
 
 	routes.SetupAgentAPIRoutes(r.engine, &routes.AgentAPIRouteConfig{
@@ -150,6 +156,26 @@ func (r *Router) SetupRoutes(cfg *config.Config) {
 	r.setupPublicRoutes()
 }
 
+// setupMetricsRoute exposes GET /metrics when enabled. The endpoint is never
+// registered without a token or IP allowlist, as it reveals node and agent names.
+func (r *Router) setupMetricsRoute(cfg *config.Config) {
+	if !cfg.Metrics.Enabled {
+		return
+	}
+	if !cfg.Metrics.IsProtected() {
+		r.logger.Warnw("metrics endpoint enabled without token or allowed_ips, not registering /metrics")
+		return
+	}
+
+	metricsAuth, err := middleware.MetricsAuth(cfg.Metrics, r.logger)
+	if err != nil {
+		r.logger.Errorw("invalid metrics configuration, not registering /metrics", "error", err)
+		return
+	}
+	r.engine.GET("/metrics", metricsAuth, r.metricsHandler.Scrape)
+	r.logger.Infow("metrics endpoint registered", "path", "/metrics")
+}
+
 // setupPublicRoutes configures public endpoints that don't require authentication.
 func (r *Router) setupPublicRoutes() {
 	if r.settingHandler == nil {
@@ -64,6 +64,10 @@ func SetupForwardRoutes(engine *gin.Engine, cfg *ForwardRouteConfig) {
 
 		// Rule status (aggregated from all agents)
 		forwardRules.GET("/:id/status", cfg.ForwardAgentHandler.GetRuleOverallStatus)
+
+		// Tunnel health (entry to exit agents) and its timeline
+		forwardRules.GET("/:id/tunnel-health", cfg.ForwardRuleHandler.GetTunnelHealth)
+		forwardRules.GET("/:id/tunnel-health/records", cfg.ForwardRuleHandler.ListTunnelHealthRecords)
 	}
 
 	// Forward agents management (admin only)
@@ -92,6 +96,10 @@ func SetupForwardRoutes(engine *gin.Engine, cfg *ForwardRouteConfig) {
 
 		// Runtime status (from agent reports)
 		forwardAgents.GET("/:id/status", cfg.ForwardAgentHandler.GetAgentStatus)
+		// System metrics history (1-minute buckets for 24h, 1-hour buckets for 30d)
+		forwardAgents.GET("/:id/metrics", cfg.ForwardAgentHandler.GetAgentMetrics)
+		// Listen port utilization of the agent's port pool
+		forwardAgents.GET("/:id/port-usage", cfg.ForwardAgentHandler.GetAgentPortUsage)
 
 		// Token operations
 		forwardAgents.GET("/:id/token", cfg.ForwardAgentHandler.GetToken)
@@ -11,17 +11,22 @@ import (
 
 // NodeRouteConfig holds dependencies for node routes
 type NodeRouteConfig struct {
-	NodeHandler         *handlers.NodeHandler
-	NodeHubHandler      *nodeHandlers.NodeHubHandler
-	NodeVersionHandler  *nodeHandlers.NodeVersionHandler
-	NodeSSEHandler      *nodeHandlers.NodeSSEHandler
-	UserNodeHandler     *nodeHandlers.UserNodeHandler
-	SubscriptionHandler *handlers.NodeSubscriptionHandler
-	AuthMiddleware      *middleware.AuthMiddleware
-	NodeTokenMW         *middleware.NodeTokenMiddleware
-	NodeOwnerMW         *middleware.NodeOwnerMiddleware
-	NodeQuotaMW         *middleware.NodeQuotaMiddleware
-	RateLimiter         *middleware.RateLimiter
+	NodeHandler          *handlers.NodeHandler
+	NodeHubHandler       *nodeHandlers.NodeHubHandler
+	NodeVersionHandler   *nodeHandlers.NodeVersionHandler
+	MaintenanceHandler   *nodeHandlers.NodeMaintenanceHandler
+	CertificateHandler   *nodeHandlers.NodeCertificateHandler
+	ConfigVersionHandler *nodeHandlers.NodeConfigVersionHandler
+	ManifestHandler      *nodeHandlers.NodeManifestHandler
+	TemplateHandler      *nodeHandlers.NodeTemplateHandler
+	NodeSSEHandler       *nodeHandlers.NodeSSEHandler
+	UserNodeHandler      *nodeHandlers.UserNodeHandler
+	SubscriptionHandler  *handlers.NodeSubscriptionHandler
+	AuthMiddleware       *middleware.AuthMiddleware
+	NodeTokenMW          *middleware.NodeTokenMiddleware
+	NodeOwnerMW          *middleware.NodeOwnerMiddleware
+	NodeQuotaMW          *middleware.NodeQuotaMiddleware
+	RateLimiter          *middleware.RateLimiter
 }
 
 // SetupNodeRoutes configures all node management routes
@@ -55,6 +60,18 @@ func SetupNodeRoutes(engine *gin.Engine, config *NodeRouteConfig) {
 		nodes.POST("/:id/tokens",
 			authorization.RequireAdmin(),
 			config.NodeHandler.GenerateToken)
+		// Using POST to replace the SS2022 server key with a new random key
+		nodes.POST("/:id/rotate-server-key",
+			authorization.RequireAdmin(),
+			config.NodeHandler.RotateServerKey)
+		// Using POST to replace the VLESS Reality key pair and short ID
+		nodes.POST("/:id/rotate-reality-keys",
+			authorization.RequireAdmin(),
+			config.NodeHandler.RotateRealityKeys)
+		// System metrics history (1-minute buckets for 24h, 1-hour buckets for 30d)
+		nodes.GET("/:id/metrics",
+			authorization.RequireAdmin(),
+			config.NodeHandler.GetNodeMetrics)
 		// Using GET for retrieving install script
 		nodes.GET("/:id/install-script",
 			authorization.RequireAdmin(),
@@ -67,6 +84,89 @@ func SetupNodeRoutes(engine *gin.Engine, config *NodeRouteConfig) {
 				config.NodeSSEHandler.Events)
 		}
 
+		// Scheduled maintenance windows (must be registered before /:id)
+		if config.MaintenanceHandler != nil {
+			nodes.POST("/maintenance-windows",
+				authorization.RequireAdmin(),
+				config.MaintenanceHandler.CreateMaintenanceWindow)
+			nodes.GET("/maintenance-windows",
+				authorization.RequireAdmin(),
+				config.MaintenanceHandler.ListMaintenanceWindows)
+			nodes.POST("/maintenance-windows/:id/cancel",
+				authorization.RequireAdmin(),
+				config.MaintenanceHandler.CancelMaintenanceWindow)
+		}
+
+		// ACME-issued node TLS certificates (must be registered before /:id)
+		if config.CertificateHandler != nil {
+			nodes.POST("/certificates",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.CreateCertificate)
+			nodes.GET("/certificates",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.ListCertificates)
+			nodes.GET("/certificates/:id",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.GetCertificate)
+			nodes.PATCH("/certificates/:id",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.UpdateCertificate)
+			nodes.DELETE("/certificates/:id",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.DeleteCertificate)
+			nodes.POST("/certificates/:id/issue",
+				authorization.RequireAdmin(),
+				config.CertificateHandler.IssueCertificate)
+		}
+
+		// Bulk import and export (must be registered before /:id)
+		if config.ManifestHandler != nil {
+			nodes.POST("/import",
+				authorization.RequireAdmin(),
+				config.ManifestHandler.ImportNodes)
+			nodes.GET("/export",
+				authorization.RequireAdmin(),
+				config.ManifestHandler.ExportNodes)
+		}
+
+		// Node templates, templated provisioning and cloning (must be registered before /:id)
+		if config.TemplateHandler != nil {
+			nodes.POST("/templates",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.CreateTemplate)
+			nodes.GET("/templates",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.ListTemplates)
+			nodes.GET("/templates/:id",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.GetTemplate)
+			nodes.PATCH("/templates/:id",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.UpdateTemplate)
+			nodes.DELETE("/templates/:id",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.DeleteTemplate)
+			nodes.POST("/templates/:id/provision",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.ProvisionFromTemplate)
+			nodes.POST("/:id/clone",
+				authorization.RequireAdmin(),
+				config.TemplateHandler.CloneNode)
+		}
+
+		// Node configuration history
+		if config.ConfigVersionHandler != nil {
+			nodes.GET("/:id/config-versions",
+				authorization.RequireAdmin(),
+				config.ConfigVersionHandler.ListConfigVersions)
+			nodes.GET("/:id/config-versions/diff",
+				authorization.RequireAdmin(),
+				config.ConfigVersionHandler.DiffConfigVersions)
+			nodes.POST("/:id/config-versions/:version/rollback",
+				authorization.RequireAdmin(),
+				config.ConfigVersionHandler.RollbackConfigVersion)
+		}
+
 		// Version management endpoints
 		if config.NodeVersionHandler != nil {
 			// Batch update (must be registered before /:id to avoid conflicts)
@@ -179,5 +279,37 @@ func SetupNodeRoutes(engine *gin.Engine, config *NodeRouteConfig) {
 		sub.HEAD("/:token/surge",
 			config.RateLimiter.Limit(),
 			config.SubscriptionHandler.GetSurgeSubscription)
+
+		// Quantumult X subscription format
+		sub.GET("/:token/quanx",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetQuantumultXSubscription)
+		sub.HEAD("/:token/quanx",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetQuantumultXSubscription)
+
+		// Loon subscription format
+		sub.GET("/:token/loon",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetLoonSubscription)
+		sub.HEAD("/:token/loon",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetLoonSubscription)
+
+		// sing-box subscription format
+		sub.GET("/:token/singbox",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetSingBoxSubscription)
+		sub.HEAD("/:token/singbox",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetSingBoxSubscription)
+
+		// WireGuard .conf file (wg-quick format)
+		sub.GET("/:token/wireguard",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetWireGuardSubscription)
+		sub.HEAD("/:token/wireguard",
+			config.RateLimiter.Limit(),
+			config.SubscriptionHandler.GetWireGuardSubscription)
 	}
 }
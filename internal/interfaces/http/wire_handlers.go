@@ -39,13 +39,18 @@ type allHandlers struct {
 	paymentHandler *handlers.PaymentHandler
 
 	// Node
-	nodeHandler             *handlers.NodeHandler
-	nodeSubscriptionHandler *handlers.NodeSubscriptionHandler
-	userNodeHandler         *nodeHandlers.UserNodeHandler
-	agentHandler            *nodeHandlers.AgentHandler
-	nodeHubHandler          *nodeHandlers.NodeHubHandler
-	nodeVersionHandler      *nodeHandlers.NodeVersionHandler
-	nodeSSEHandler          *nodeHandlers.NodeSSEHandler
+	nodeHandler              *handlers.NodeHandler
+	nodeSubscriptionHandler  *handlers.NodeSubscriptionHandler
+	userNodeHandler          *nodeHandlers.UserNodeHandler
+	agentHandler             *nodeHandlers.AgentHandler
+	nodeHubHandler           *nodeHandlers.NodeHubHandler
+	nodeVersionHandler       *nodeHandlers.NodeVersionHandler
+	nodeMaintenanceHandler   *nodeHandlers.NodeMaintenanceHandler
+	nodeCertificateHandler   *nodeHandlers.NodeCertificateHandler
+	nodeConfigVersionHandler *nodeHandlers.NodeConfigVersionHandler
+	nodeManifestHandler      *nodeHandlers.NodeManifestHandler
+	nodeTemplateHandler      *nodeHandlers.NodeTemplateHandler
+	nodeSSEHandler           *nodeHandlers.NodeSSEHandler
 
 	// Forward
 	forwardRuleHandler             *forwardRuleHandlers.Handler
@@ -72,4 +77,7 @@ type allHandlers struct {
 	adminTrafficStatsHandler  *adminHandlers.TrafficStatsHandler
 	adminTelegramHandler      *adminHandlers.AdminTelegramHandler
 	settingHandler            *adminHandlers.SettingHandler
+
+	// Metrics
+	metricsHandler *handlers.MetricsHandler
 }
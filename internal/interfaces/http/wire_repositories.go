@@ -25,8 +25,14 @@ type repositories struct {
 	planPricingRepo            subscription.PlanPricingRepository
 	paymentRepo                *repository.PaymentRepository
 	nodeRepoImpl               node.NodeRepository
+	maintenanceWindowRepo      node.MaintenanceWindowRepository
+	nodeCertificateRepo        node.CertificateRepository
+	nodeConfigVersionRepo      node.ConfigVersionRepository
+	nodeTemplateRepo           node.NodeTemplateRepository
+	wireguardPeerRepo          node.WireGuardPeerRepository
 	forwardRuleRepo            forward.Repository
 	forwardAgentRepo           forward.AgentRepository
+	forwardTunnelHealthRepo    forward.TunnelHealthRepository
 	resourceGroupRepo          resource.Repository
 	announcementRepo           notification.AnnouncementRepository
 	notificationRepo           notification.NotificationRepository
@@ -5,6 +5,8 @@ import (
 	"errors"
 	"time"
 
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
 	"github.com/redis/go-redis/v9"
 	"gorm.io/gorm"
 
@@ -27,6 +29,7 @@ import (
 	"github.com/orris-inc/orris/internal/application/user/helpers"
 	"github.com/orris-inc/orris/internal/application/user/usecases"
 	"github.com/orris-inc/orris/internal/interfaces/adapters"
+	"github.com/orris-inc/orris/internal/infrastructure/acme"
 	"github.com/orris-inc/orris/internal/infrastructure/auth"
 	"github.com/orris-inc/orris/internal/infrastructure/cache"
 	"github.com/orris-inc/orris/internal/infrastructure/config"
@@ -59,6 +62,7 @@ import (
 	dto "github.com/orris-inc/orris/internal/shared/hubprotocol/forward"
 	nodedto "github.com/orris-inc/orris/internal/shared/hubprotocol/node"
 	"github.com/orris-inc/orris/internal/shared/logger"
+	"github.com/orris-inc/orris/internal/shared/metrics"
 	"github.com/orris-inc/orris/internal/shared/services/markdown"
 )
 
@@ -139,8 +143,14 @@ func newRepositories(db *gorm.DB, log logger.Interface) *repositories {
 		planPricingRepo:            repository.NewPlanPricingRepository(db, log),
 		paymentRepo:                repository.NewPaymentRepository(db, log),
 		nodeRepoImpl:               repository.NewNodeRepository(db, log),
+		maintenanceWindowRepo:      repository.NewMaintenanceWindowRepository(db, log),
+		nodeCertificateRepo:        repository.NewNodeCertificateRepository(db, log),
+		nodeConfigVersionRepo:      repository.NewNodeConfigVersionRepository(db, log),
+		nodeTemplateRepo:           repository.NewNodeTemplateRepository(db, log),
+		wireguardPeerRepo:          repository.NewWireGuardPeerRepository(db, log),
 		forwardRuleRepo:            repository.NewForwardRuleRepository(db, log),
 		forwardAgentRepo:           repository.NewForwardAgentRepository(db, log),
+		forwardTunnelHealthRepo:    repository.NewForwardTunnelHealthRepository(db, log),
 		resourceGroupRepo:          repository.NewResourceGroupRepository(db, log),
 		announcementRepo:           repository.NewAnnouncementRepository(db),
 		notificationRepo:           repository.NewNotificationRepository(db),
@@ -332,9 +342,91 @@ func (c *Container) initNode() {
 	ucs.listNodesUC = nodeUsecases.NewListNodesUseCase(repos.nodeRepoImpl, repos.resourceGroupRepo, repos.userRepo, c.nodeStatusQuerier, c.nodeAgentReleaseService, log)
 	ucs.listNodesUC.SetOnlineSubscriptionCounter(c.onlineSubscriptionTracker)
 	ucs.generateNodeTokenUC = nodeUsecases.NewGenerateNodeTokenUseCase(repos.nodeRepoImpl, log)
+	ucs.rotateNodeServerKeyUC = nodeUsecases.NewRotateNodeServerKeyUseCase(repos.nodeRepoImpl, log)
+	ucs.rotateRealityKeysUC = nodeUsecases.NewRotateRealityKeysUseCase(repos.nodeRepoImpl, log)
+	ucs.rotateDueRealityKeysUC = nodeUsecases.NewRotateDueRealityKeysUseCase(repos.nodeRepoImpl, log)
+	ucs.getNodeMetricsUC = nodeUsecases.NewGetNodeMetricsUseCase(
+		repos.nodeRepoImpl, adapters.NewSystemMetricsHistoryAdapter(c.redis, log), log,
+	)
+
+	// Initialize node configuration history use cases
+	configVersionRecorder := nodeUsecases.NewConfigVersionRecorder(repos.nodeConfigVersionRepo, log)
+	ucs.createNodeUC.SetConfigVersionRecorder(configVersionRecorder)
+	ucs.updateNodeUC.SetConfigVersionRecorder(configVersionRecorder)
+	ucs.rotateNodeServerKeyUC.SetConfigVersionRecorder(configVersionRecorder)
+	ucs.rotateRealityKeysUC.SetConfigVersionRecorder(configVersionRecorder)
+	ucs.rotateDueRealityKeysUC.SetConfigVersionRecorder(configVersionRecorder)
+	ucs.listNodeConfigVersionsUC = nodeUsecases.NewListNodeConfigVersionsUseCase(
+		repos.nodeRepoImpl, repos.nodeConfigVersionRepo, repos.userRepo, log,
+	)
+	ucs.diffNodeConfigVersionsUC = nodeUsecases.NewDiffNodeConfigVersionsUseCase(repos.nodeRepoImpl, repos.nodeConfigVersionRepo, log)
+	ucs.rollbackNodeConfigVersionUC = nodeUsecases.NewRollbackNodeConfigVersionUseCase(
+		repos.nodeRepoImpl, repos.nodeConfigVersionRepo, repos.userRepo, configVersionRecorder, log,
+	)
+
+	// Initialize node bulk import and export use cases
+	ucs.importNodesUC = nodeUsecases.NewImportNodesUseCase(ucs.createNodeUC, log)
+	ucs.exportNodesUC = nodeUsecases.NewExportNodesUseCase(repos.nodeRepoImpl, repos.resourceGroupRepo, log)
+
+	// Initialize maintenance window use cases
+	maintenanceNoticePublisher := adapters.NewMaintenanceNoticePublisherAdapter(repos.announcementRepo, repos.notificationRepo, log)
+	ucs.createMaintenanceWindowUC = nodeUsecases.NewCreateMaintenanceWindowUseCase(
+		repos.maintenanceWindowRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, repos.subscriptionRepo, log,
+	)
+	ucs.createMaintenanceWindowUC.SetNoticePublisher(maintenanceNoticePublisher)
+	ucs.listMaintenanceWindowsUC = nodeUsecases.NewListMaintenanceWindowsUseCase(
+		repos.maintenanceWindowRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, log,
+	)
+	ucs.cancelMaintenanceWindowUC = nodeUsecases.NewCancelMaintenanceWindowUseCase(
+		repos.maintenanceWindowRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, log,
+	)
+	ucs.cancelMaintenanceWindowUC.SetNoticePublisher(maintenanceNoticePublisher)
+	ucs.processMaintenanceWindowsUC = nodeUsecases.NewProcessMaintenanceWindowsUseCase(repos.maintenanceWindowRepo, repos.nodeRepoImpl, log)
+	ucs.processMaintenanceWindowsUC.SetNoticePublisher(maintenanceNoticePublisher)
+
+	if err := c.schedulerManager.RegisterNodeJobs(ucs.rotateDueRealityKeysUC, ucs.processMaintenanceWindowsUC); err != nil {
+		log.Warnw("failed to register node jobs", "error", err)
+	}
+
+	// Initialize node certificate use cases (issuance requires ACME to be enabled)
+	var certIssuer nodeUsecases.CertificateIssuer
+	if cfg.ACME.Enabled {
+		issuer, err := acme.NewIssuerFromConfig(cfg.ACME, log)
+		if err != nil {
+			log.Warnw("failed to initialize ACME issuer, certificate issuance disabled", "error", err)
+		} else {
+			certIssuer = issuer
+			log.Infow("ACME certificate issuance enabled",
+				"directory_url", cfg.ACME.DirectoryURL,
+				"dns_providers", issuer.DNSProviders(),
+			)
+		}
+	}
+	ucs.createCertificateUC = nodeUsecases.NewCreateCertificateUseCase(repos.nodeCertificateRepo, certIssuer, log)
+	ucs.listCertificatesUC = nodeUsecases.NewListCertificatesUseCase(repos.nodeCertificateRepo, log)
+	ucs.getCertificateUC = nodeUsecases.NewGetCertificateUseCase(repos.nodeCertificateRepo, log)
+	ucs.updateCertificateUC = nodeUsecases.NewUpdateCertificateUseCase(repos.nodeCertificateRepo, log)
+	ucs.deleteCertificateUC = nodeUsecases.NewDeleteCertificateUseCase(repos.nodeCertificateRepo, log)
+	ucs.issueCertificateUC = nodeUsecases.NewIssueCertificateUseCase(repos.nodeCertificateRepo, certIssuer, log)
+	ucs.renewCertificatesUC = nodeUsecases.NewRenewCertificatesUseCase(repos.nodeCertificateRepo, certIssuer, log)
 	ucs.generateNodeInstallScriptUC = nodeUsecases.NewGenerateNodeInstallScriptUseCase(repos.nodeRepoImpl, log)
 	ucs.generateBatchInstallScriptUC = nodeUsecases.NewGenerateBatchInstallScriptUseCase(repos.nodeRepoImpl, log)
 
+	// Initialize node template use cases
+	ucs.createNodeTemplateUC = nodeUsecases.NewCreateNodeTemplateUseCase(
+		repos.nodeTemplateRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, log,
+	)
+	ucs.listNodeTemplatesUC = nodeUsecases.NewListNodeTemplatesUseCase(repos.nodeTemplateRepo, repos.resourceGroupRepo, log)
+	ucs.getNodeTemplateUC = nodeUsecases.NewGetNodeTemplateUseCase(repos.nodeTemplateRepo, repos.resourceGroupRepo, log)
+	ucs.updateNodeTemplateUC = nodeUsecases.NewUpdateNodeTemplateUseCase(
+		repos.nodeTemplateRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, log,
+	)
+	ucs.deleteNodeTemplateUC = nodeUsecases.NewDeleteNodeTemplateUseCase(repos.nodeTemplateRepo, log)
+	ucs.provisionNodesUC = nodeUsecases.NewProvisionNodesUseCase(
+		repos.nodeTemplateRepo, repos.nodeRepoImpl, repos.resourceGroupRepo,
+		ucs.importNodesUC, ucs.generateBatchInstallScriptUC, log,
+	)
+
 	// Initialize user node use cases
 	ucs.createUserNodeUC = nodeUsecases.NewCreateUserNodeUseCase(repos.nodeRepoImpl, log)
 	ucs.listUserNodesUC = nodeUsecases.NewListUserNodesUseCase(repos.nodeRepoImpl, log)
@@ -359,6 +451,9 @@ func (c *Container) initNode() {
 		ucs.generateNodeTokenUC, ucs.generateNodeInstallScriptUC, ucs.generateBatchInstallScriptUC, apiBaseURL,
 		log,
 	)
+	hdlrs.nodeHandler.SetRotateServerKeyUseCase(ucs.rotateNodeServerKeyUC)
+	hdlrs.nodeHandler.SetRotateRealityKeysUseCase(ucs.rotateRealityKeysUC)
+	hdlrs.nodeHandler.SetGetNodeMetricsUseCase(ucs.getNodeMetricsUC)
 	// Note: nodeSubscriptionHandler is created later after settingProvider is initialized
 	hdlrs.userNodeHandler = nodeHandlers.NewUserNodeHandler(
 		ucs.createUserNodeUC, ucs.listUserNodesUC, ucs.getUserNodeUC,
@@ -366,6 +461,11 @@ func (c *Container) initNode() {
 		ucs.getUserNodeUsageUC, ucs.getUserNodeInstallScriptUC, ucs.getUserBatchInstallScriptUC,
 		apiBaseURL, log,
 	)
+	hdlrs.nodeTemplateHandler = nodeHandlers.NewNodeTemplateHandler(
+		ucs.createNodeTemplateUC, ucs.listNodeTemplatesUC, ucs.getNodeTemplateUC,
+		ucs.updateNodeTemplateUC, ucs.deleteNodeTemplateUC, ucs.provisionNodesUC,
+		apiBaseURL, log,
+	)
 
 	hdlrs.ticketHandler = ticketHandlers.NewTicketHandler(nil, nil, nil, nil, nil, nil, nil, nil, nil, log)
 
@@ -429,10 +529,9 @@ func (c *Container) initSettingsAndAuth() {
 	// Initialize generateSubscriptionUC (uses settingProvider)
 	ucs.generateSubscriptionUC = nodeUsecases.NewGenerateSubscriptionUseCase(
 		c.nodeRepoAdapter, c.tokenValidator, c.templateLoader,
-		repos.subscriptionPlanRepo, repos.subscriptionUsageStatsRepo, c.hourlyTrafficCache,
-		subscriptionSettingAdapter, log,
+		repos.subscriptionPlanRepo, subscriptionSettingAdapter, log,
 	)
-	hdlrs.nodeSubscriptionHandler = handlers.NewNodeSubscriptionHandler(ucs.generateSubscriptionUC, log)
+	hdlrs.nodeSubscriptionHandler = handlers.NewNodeSubscriptionHandler(ucs.generateSubscriptionUC, subscriptionSettingAdapter, log)
 
 	// Create setting provider adapter to break reverse dependency from infrastructure to application
 	c.settingProviderAdapt = &settingProviderAdapter{provider: settingProvider}
@@ -698,7 +797,7 @@ func (c *Container) initForward() {
 	c.subscriptionTrafficCache = cache.NewRedisSubscriptionTrafficCache(
 		c.redis, c.hourlyTrafficCache, repos.subscriptionUsageRepo, log,
 	)
-	c.subscriptionTrafficBuffer = nodeServices.NewSubscriptionTrafficBuffer(c.subscriptionTrafficCache, log)
+	c.subscriptionTrafficBuffer = nodeServices.NewSubscriptionTrafficBuffer(c.subscriptionTrafficCache, repos.nodeRepoImpl, log)
 	c.subscriptionTrafficBuffer.Start()
 
 	// Initialize subscription quota cache for node traffic limit checking
@@ -748,6 +847,8 @@ func (c *Container) initForward() {
 		repos.nodeRepoImpl, repos.forwardAgentRepo,
 		c.alertStateManager, &botServiceProviderAdapter{c.telegramBotManager}, log,
 	)
+	adminNotificationProcessor.SetNodeMaintenanceChecker(adapters.NewMaintenanceNodeCheckerAdapter(repos.maintenanceWindowRepo))
+	adminNotificationProcessor.SetCertificateExpiryFinder(repos.nodeCertificateRepo)
 	if err := c.schedulerManager.RegisterAdminNotificationJobs(adminNotificationProcessor); err != nil {
 		log.Warnw("failed to register admin notification jobs", "error", err)
 	}
@@ -831,6 +932,16 @@ func (c *Container) initForward() {
 	agentLastSeenUpdater := adapters.NewAgentLastSeenUpdaterAdapter(repos.forwardAgentRepo)
 	agentInfoUpdater := adapters.NewAgentInfoUpdaterAdapter(repos.forwardAgentRepo)
 	ucs.getAgentStatusUC = forwardUsecases.NewGetAgentStatusUseCase(repos.forwardAgentRepo, forwardAgentStatusAdapter, log)
+	ucs.getAgentMetricsUC = forwardUsecases.NewGetAgentMetricsUseCase(
+		repos.forwardAgentRepo, adapters.NewSystemMetricsHistoryAdapter(c.redis, log), log,
+	)
+
+	// Initialize listen port allocation: ports are reserved in Redis while rules are created
+	portReservationStore := cache.NewPortReservationStore(c.redis)
+	portAllocator := forwardServices.NewPortAllocator(repos.forwardRuleRepo, portReservationStore, log)
+	ucs.getAgentPortUsageUC = forwardUsecases.NewGetAgentPortUsageUseCase(
+		repos.forwardAgentRepo, repos.forwardRuleRepo, portReservationStore, log,
+	)
 	ucs.getRuleOverallStatusUC = forwardUsecases.NewGetRuleOverallStatusUseCase(repos.forwardRuleRepo, repos.forwardAgentRepo, ruleSyncStatusAdapter, log)
 	ucs.getForwardAgentTokenUC = forwardUsecases.NewGetForwardAgentTokenUseCase(repos.forwardAgentRepo, log)
 	ucs.generateInstallScriptUC = forwardUsecases.NewGenerateInstallScriptUseCase(repos.forwardAgentRepo, log)
@@ -903,8 +1014,39 @@ func (c *Container) initForward() {
 	)
 	c.agentHub.RegisterMessageHandler(trafficMessageHandler)
 
+	// Initialize tunnel health tracking: demoted exit agents are sent as backups in config syncs
+	tunnelHealthStore := cache.NewTunnelHealthStore(c.redis)
+	c.configSyncService.SetDemotedExitsReader(tunnelHealthStore)
+	hdlrs.forwardAgentAPIHandler.SetDemotedExitsReader(tunnelHealthStore)
+	recordTunnelHealthUC := forwardUsecases.NewRecordTunnelHealthUseCase(
+		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.forwardTunnelHealthRepo, tunnelHealthStore,
+		c.configSyncService, adapters.NewTunnelHealthAlerterAdapter(c.adminNotificationServiceDDD), log,
+	)
+	ucs.getTunnelHealthUC = forwardUsecases.NewGetTunnelHealthUseCase(
+		repos.forwardRuleRepo, repos.forwardAgentRepo, tunnelHealthStore, log,
+	)
+	ucs.listTunnelHealthRecordsUC = forwardUsecases.NewListTunnelHealthRecordsUseCase(
+		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.forwardTunnelHealthRepo, log,
+	)
+	pruneTunnelHealthUC := forwardUsecases.NewPruneTunnelHealthUseCase(repos.forwardTunnelHealthRepo, log)
+
+	// Initialize exit metrics of least_connections and lowest_latency rules, sent in config syncs
+	lbMetricsStore := cache.NewLoadBalanceMetricsStore(c.redis)
+	c.configSyncService.SetLoadBalanceMetricsReader(lbMetricsStore)
+	refreshLBMetricsUC := forwardUsecases.NewRefreshLoadBalanceMetricsUseCase(
+		repos.forwardRuleRepo, ruleSyncStatusAdapter, probeService, lbMetricsStore, c.configSyncService, log,
+	)
+	if err := c.schedulerManager.RegisterForwardJobs(refreshLBMetricsUC, pruneTunnelHealthUC); err != nil {
+		log.Warnw("failed to register forward jobs", "error", err)
+	}
+
+	// Subscription-bound rules inherit the limits they don't set from their plan
+	ruleLimitsResolver := forwardServices.NewPlanRuleLimitsResolver(repos.subscriptionRepo, repos.subscriptionPlanRepo)
+	c.configSyncService.SetRuleLimitsResolver(ruleLimitsResolver)
+	hdlrs.forwardAgentAPIHandler.SetRuleLimitsResolver(ruleLimitsResolver)
+
 	// Initialize and register tunnel health handler
-	tunnelHealthHandler := forwardServices.NewTunnelHealthHandler(log)
+	tunnelHealthHandler := forwardServices.NewTunnelHealthHandler(recordTunnelHealthUC, log)
 	c.agentHub.RegisterMessageHandler(tunnelHealthHandler)
 
 	// Create done channel for rule traffic flush scheduler
@@ -947,7 +1089,7 @@ func (c *Container) initForward() {
 		ucs.updateForwardAgentUC, ucs.deleteForwardAgentUC,
 		ucs.enableForwardAgentUC, ucs.disableForwardAgentUC,
 		ucs.regenerateForwardAgentTokenUC, ucs.getForwardAgentTokenUC,
-		ucs.getAgentStatusUC, ucs.getRuleOverallStatusUC,
+		ucs.getAgentStatusUC, ucs.getAgentMetricsUC, ucs.getAgentPortUsageUC, ucs.getRuleOverallStatusUC,
 		ucs.generateInstallScriptUC, serverBaseURL, log,
 	)
 
@@ -958,11 +1100,22 @@ func (c *Container) initForward() {
 	hdlrs.nodeVersionHandler = nodeHandlers.NewNodeVersionHandler(
 		repos.nodeRepoImpl, c.nodeAgentReleaseService, c.agentHub, log,
 	)
+	hdlrs.nodeMaintenanceHandler = nodeHandlers.NewNodeMaintenanceHandler(
+		ucs.createMaintenanceWindowUC, ucs.listMaintenanceWindowsUC, ucs.cancelMaintenanceWindowUC, log,
+	)
+	hdlrs.nodeConfigVersionHandler = nodeHandlers.NewNodeConfigVersionHandler(
+		ucs.listNodeConfigVersionsUC, ucs.diffNodeConfigVersionsUC, ucs.rollbackNodeConfigVersionUC, log,
+	)
+	hdlrs.nodeManifestHandler = nodeHandlers.NewNodeManifestHandler(ucs.importNodesUC, ucs.exportNodesUC, log)
+	hdlrs.nodeCertificateHandler = nodeHandlers.NewNodeCertificateHandler(
+		ucs.createCertificateUC, ucs.listCertificatesUC, ucs.getCertificateUC,
+		ucs.updateCertificateUC, ucs.deleteCertificateUC, ucs.issueCertificateUC, log,
+	)
 
 	// Now initialize forward rule use cases with configSyncService
 	ucs.createForwardRuleUC = forwardUsecases.NewCreateForwardRuleUseCase(
 		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl,
-		repos.resourceGroupRepo, repos.subscriptionPlanRepo, c.configSyncService, log,
+		repos.resourceGroupRepo, repos.subscriptionPlanRepo, c.configSyncService, portAllocator, log,
 	)
 	ucs.getForwardRuleUC = forwardUsecases.NewGetForwardRuleUseCase(
 		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, repos.resourceGroupRepo, log,
@@ -985,7 +1138,7 @@ func (c *Container) initForward() {
 
 	// Initialize user forward rule use cases
 	ucs.createUserForwardRuleUC = forwardUsecases.NewCreateUserForwardRuleUseCase(
-		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, c.configSyncService, log,
+		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, c.configSyncService, portAllocator, log,
 	)
 	ucs.listUserForwardRulesUC = forwardUsecases.NewListUserForwardRulesUseCase(
 		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, ruleSyncStatusAdapter, log,
@@ -1025,7 +1178,7 @@ func (c *Container) initForward() {
 
 	// Initialize subscription forward rule use cases
 	ucs.createSubscriptionForwardRuleUC = forwardUsecases.NewCreateSubscriptionForwardRuleUseCase(
-		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, c.configSyncService, log,
+		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl, c.configSyncService, portAllocator, log,
 	)
 	ucs.listSubscriptionForwardRulesUC = forwardUsecases.NewListSubscriptionForwardRulesUseCase(
 		repos.forwardRuleRepo, repos.forwardAgentRepo, repos.nodeRepoImpl,
@@ -1053,7 +1206,8 @@ func (c *Container) initForward() {
 		ucs.deleteForwardRuleUC, ucs.listForwardRulesUC,
 		ucs.enableForwardRuleUC, ucs.disableForwardRuleUC,
 		ucs.resetForwardTrafficUC, ucs.reorderForwardRulesUC,
-		ucs.batchForwardRuleUC, probeService, log,
+		ucs.batchForwardRuleUC, ucs.getTunnelHealthUC, ucs.listTunnelHealthRecordsUC,
+		probeService, log,
 	)
 
 	// Initialize agent hub handler
@@ -1066,9 +1220,21 @@ func (c *Container) initForward() {
 	// Initialize node config sync service
 	c.nodeConfigSyncService = nodeServices.NewNodeConfigSyncService(repos.nodeRepoImpl, repos.forwardRuleRepo, c.agentHub, log)
 
+	// Initialize node certificate sync service
+	c.certificateSyncService = nodeServices.NewCertificateSyncService(repos.nodeCertificateRepo, repos.nodeRepoImpl, c.agentHub, log)
+
 	// Initialize subscription sync service
 	c.subscriptionSyncService = nodeServices.NewSubscriptionSyncService(repos.nodeRepoImpl, repos.subscriptionRepo, repos.subscriptionPlanRepo, repos.resourceGroupRepo, c.agentHub, log)
 
+	// Initialize WireGuard peer address allocation; exhausted pools are reported to admins
+	wireguardAddressAllocator := nodeServices.NewWireGuardAddressAllocator(
+		repos.wireguardPeerRepo, repos.nodeRepoImpl, repos.subscriptionRepo, log,
+	)
+	wireguardAddressAllocator.SetAlerter(adapters.NewWireGuardPoolAlerterAdapter(c.adminNotificationServiceDDD))
+	c.subscriptionSyncService.SetWireGuardPeerAllocator(wireguardAddressAllocator)
+	ucs.getNodeSubscriptionsUC.SetWireGuardPeerAllocator(wireguardAddressAllocator)
+	ucs.generateSubscriptionUC.SetWireGuardPeerAllocator(wireguardAddressAllocator)
+
 	// Initialize Redis Pub/Sub event bus
 	subscriptionEventBus := pubsub.NewRedisSubscriptionEventBus(c.redis, log)
 	c.subscriptionSyncService.SetEventPublisher(subscriptionEventBus)
@@ -1098,6 +1264,19 @@ func (c *Container) initForward() {
 	// Set deactivation notifier on node traffic limit enforcement service
 	c.nodeTrafficLimitEnforcementSvc.SetDeactivationNotifier(c.subscriptionSyncService)
 
+	// Initialize cluster-wide device limit enforcement; blocked subscriptions are
+	// withheld from node syncs until the release job restores them
+	deviceLimitEnforcementSvc := nodeServices.NewDeviceLimitEnforcementService(
+		repos.subscriptionRepo, repos.subscriptionPlanRepo, c.onlineSubscriptionTracker,
+		cache.NewRedisDeviceLimitStore(c.redis), c.subscriptionSyncService, log,
+	)
+	c.subscriptionSyncService.SetBlockChecker(deviceLimitEnforcementSvc)
+	ucs.getNodeSubscriptionsUC.SetBlockChecker(deviceLimitEnforcementSvc)
+	ucs.reportOnlineSubscriptionsUC.SetDeviceLimitEnforcer(deviceLimitEnforcementSvc)
+	if err := c.schedulerManager.RegisterDeviceLimitJobs(deviceLimitEnforcementSvc); err != nil {
+		log.Warnw("failed to register device limit jobs", "error", err)
+	}
+
 	// Initialize subscription event handler
 	subscriptionEventHandler := nodeServices.NewSubscriptionEventHandler(
 		repos.subscriptionRepo, c.subscriptionSyncService, log,
@@ -1188,6 +1367,12 @@ func (c *Container) initCallbacksAndNotifiers() {
 				"node_id", nodeID, "error", err)
 		}
 
+		// Send the TLS certificate covering the node's server name
+		if err := c.certificateSyncService.SyncToNode(ctx, nodeID); err != nil {
+			log.Warnw("failed to sync certificate to node on connect",
+				"node_id", nodeID, "error", err)
+		}
+
 		// Get node info
 		n, err := repos.nodeRepoImpl.GetByID(ctx, nodeID)
 		if err != nil {
@@ -1361,6 +1546,20 @@ func (c *Container) initCallbacksAndNotifiers() {
 
 	// Set config change notifier for node update use case
 	ucs.updateNodeUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.rotateNodeServerKeyUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.rotateRealityKeysUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.rotateDueRealityKeysUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.rollbackNodeConfigVersionUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.cancelMaintenanceWindowUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+	ucs.processMaintenanceWindowsUC.SetConfigChangeNotifier(c.nodeConfigSyncService)
+
+	// Deliver issued certificates to connected node agents
+	ucs.createCertificateUC.SetDeliverer(c.certificateSyncService)
+	ucs.issueCertificateUC.SetDeliverer(c.certificateSyncService)
+	ucs.renewCertificatesUC.SetDeliverer(c.certificateSyncService)
+	if err := c.schedulerManager.RegisterCertificateJobs(ucs.renewCertificatesUC, c.certificateSyncService); err != nil {
+		log.Warnw("failed to register certificate jobs", "error", err)
+	}
 
 	// Set subscription change notifier for subscription use cases
 	ucs.createSubscriptionUC.SetSubscriptionNotifier(c.subscriptionSyncService)
@@ -1380,6 +1579,10 @@ func (c *Container) initCallbacksAndNotifiers() {
 	ucs.updatePlanUC.SetSubscriptionRepo(repos.subscriptionRepo)
 	ucs.updatePlanUC.SetQuotaCacheManager(c.quotaCacheSyncService)
 	ucs.updatePlanUC.SetSubscriptionNotifier(c.subscriptionSyncService)
+
+	// Re-sync forward rules that inherit the plan's speed and connection limits
+	ucs.updatePlanUC.SetForwardRuleLimitsNotifier(c.configSyncService)
+	ucs.changePlanUC.SetForwardRuleLimitsNotifier(c.configSyncService)
 }
 
 // ============================================================
@@ -1405,6 +1608,7 @@ func (c *Container) initRemainingHandlers() {
 	ucs.getSubscriptionUC.SetQuotaService(ucs.quotaService)
 	ucs.listUserSubscriptionsUC.SetQuotaService(ucs.quotaService)
 	ucs.updateSubscriptionUC.SetQuotaService(ucs.quotaService)
+	ucs.generateSubscriptionUC.SetTrafficReader(ucs.quotaService)
 
 	// Initialize forward quota middleware with QuotaService
 	c.forwardQuotaMiddleware = middleware.NewForwardQuotaMiddleware(
@@ -1451,6 +1655,20 @@ func (c *Container) initRemainingHandlers() {
 
 	// Initialize forward agent SSE handler
 	hdlrs.forwardAgentSSEHandler = forwardAgentCrudHandlers.NewForwardAgentSSEHandler(c.adminHub, log)
+
+	// Initialize Prometheus metrics registry and handler. Collectors query repositories
+	// at scrape time, which is bounded by metricsScrapeTimeout per collector.
+	const metricsScrapeTimeout = 10 * time.Second
+	metricsRegistry := prometheus.NewRegistry()
+	metrics.RegisterInstruments(metricsRegistry)
+	metricsRegistry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+		metrics.NewScrapeCollector("nodes", adapters.NewNodeMetricsCollector(repos.nodeRepoImpl, c.agentHub, c.onlineSubscriptionTracker), metricsScrapeTimeout),
+		metrics.NewScrapeCollector("forward", adapters.NewForwardMetricsCollector(repos.forwardAgentRepo, repos.forwardRuleRepo, c.agentHub), metricsScrapeTimeout),
+		metrics.NewScrapeCollector("payments", adapters.NewPaymentMetricsCollector(repos.paymentRepo), metricsScrapeTimeout),
+	)
+	hdlrs.metricsHandler = handlers.NewMetricsHandler(metricsRegistry, log)
 }
 
 // logSubscriberExit logs a hub subscriber exit at the appropriate level.
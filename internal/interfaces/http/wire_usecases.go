@@ -78,6 +78,37 @@ type allUseCases struct {
 	deleteNodeUC                *nodeUsecases.DeleteNodeUseCase
 	listNodesUC                 *nodeUsecases.ListNodesUseCase
 	generateNodeTokenUC         *nodeUsecases.GenerateNodeTokenUseCase
+	rotateNodeServerKeyUC       *nodeUsecases.RotateNodeServerKeyUseCase
+	rotateRealityKeysUC         *nodeUsecases.RotateRealityKeysUseCase
+	rotateDueRealityKeysUC      *nodeUsecases.RotateDueRealityKeysUseCase
+	getNodeMetricsUC            *nodeUsecases.GetNodeMetricsUseCase
+	// Node maintenance windows
+	createMaintenanceWindowUC   *nodeUsecases.CreateMaintenanceWindowUseCase
+	listMaintenanceWindowsUC    *nodeUsecases.ListMaintenanceWindowsUseCase
+	cancelMaintenanceWindowUC   *nodeUsecases.CancelMaintenanceWindowUseCase
+	processMaintenanceWindowsUC *nodeUsecases.ProcessMaintenanceWindowsUseCase
+	// Node configuration history
+	listNodeConfigVersionsUC    *nodeUsecases.ListNodeConfigVersionsUseCase
+	diffNodeConfigVersionsUC    *nodeUsecases.DiffNodeConfigVersionsUseCase
+	rollbackNodeConfigVersionUC *nodeUsecases.RollbackNodeConfigVersionUseCase
+	// Node bulk import and export
+	importNodesUC               *nodeUsecases.ImportNodesUseCase
+	exportNodesUC               *nodeUsecases.ExportNodesUseCase
+	// Node templates
+	createNodeTemplateUC        *nodeUsecases.CreateNodeTemplateUseCase
+	listNodeTemplatesUC         *nodeUsecases.ListNodeTemplatesUseCase
+	getNodeTemplateUC           *nodeUsecases.GetNodeTemplateUseCase
+	updateNodeTemplateUC        *nodeUsecases.UpdateNodeTemplateUseCase
+	deleteNodeTemplateUC        *nodeUsecases.DeleteNodeTemplateUseCase
+	provisionNodesUC            *nodeUsecases.ProvisionNodesUseCase
+	// Node certificates
+	createCertificateUC         *nodeUsecases.CreateCertificateUseCase
+	listCertificatesUC          *nodeUsecases.ListCertificatesUseCase
+	getCertificateUC            *nodeUsecases.GetCertificateUseCase
+	updateCertificateUC         *nodeUsecases.UpdateCertificateUseCase
+	deleteCertificateUC         *nodeUsecases.DeleteCertificateUseCase
+	issueCertificateUC          *nodeUsecases.IssueCertificateUseCase
+	renewCertificatesUC         *nodeUsecases.RenewCertificatesUseCase
 	generateNodeInstallScriptUC *nodeUsecases.GenerateNodeInstallScriptUseCase
 	generateBatchInstallScriptUC *nodeUsecases.GenerateBatchInstallScriptUseCase
 	validateNodeTokenUC         *nodeUsecases.ValidateNodeTokenUseCase
@@ -110,6 +141,8 @@ type allUseCases struct {
 	regenerateForwardAgentTokenUC  *forwardUsecases.RegenerateForwardAgentTokenUseCase
 	validateForwardAgentTokenUC    *forwardUsecases.ValidateForwardAgentTokenUseCase
 	getAgentStatusUC               *forwardUsecases.GetAgentStatusUseCase
+	getAgentMetricsUC              *forwardUsecases.GetAgentMetricsUseCase
+	getAgentPortUsageUC            *forwardUsecases.GetAgentPortUsageUseCase
 	getRuleOverallStatusUC         *forwardUsecases.GetRuleOverallStatusUseCase
 	getForwardAgentTokenUC         *forwardUsecases.GetForwardAgentTokenUseCase
 	generateInstallScriptUC        *forwardUsecases.GenerateInstallScriptUseCase
@@ -117,16 +150,18 @@ type allUseCases struct {
 	reportRuleSyncStatusUC         *forwardUsecases.ReportRuleSyncStatusUseCase
 
 	// Forward Rule
-	createForwardRuleUC    *forwardUsecases.CreateForwardRuleUseCase
-	getForwardRuleUC       *forwardUsecases.GetForwardRuleUseCase
-	updateForwardRuleUC    *forwardUsecases.UpdateForwardRuleUseCase
-	deleteForwardRuleUC    *forwardUsecases.DeleteForwardRuleUseCase
-	listForwardRulesUC     *forwardUsecases.ListForwardRulesUseCase
-	enableForwardRuleUC    *forwardUsecases.EnableForwardRuleUseCase
-	disableForwardRuleUC   *forwardUsecases.DisableForwardRuleUseCase
-	resetForwardTrafficUC  *forwardUsecases.ResetForwardRuleTrafficUseCase
-	reorderForwardRulesUC  *forwardUsecases.ReorderForwardRulesUseCase
-	batchForwardRuleUC     *forwardUsecases.BatchForwardRuleUseCase
+	createForwardRuleUC       *forwardUsecases.CreateForwardRuleUseCase
+	getForwardRuleUC          *forwardUsecases.GetForwardRuleUseCase
+	updateForwardRuleUC       *forwardUsecases.UpdateForwardRuleUseCase
+	deleteForwardRuleUC       *forwardUsecases.DeleteForwardRuleUseCase
+	listForwardRulesUC        *forwardUsecases.ListForwardRulesUseCase
+	enableForwardRuleUC       *forwardUsecases.EnableForwardRuleUseCase
+	disableForwardRuleUC      *forwardUsecases.DisableForwardRuleUseCase
+	resetForwardTrafficUC     *forwardUsecases.ResetForwardRuleTrafficUseCase
+	reorderForwardRulesUC     *forwardUsecases.ReorderForwardRulesUseCase
+	batchForwardRuleUC        *forwardUsecases.BatchForwardRuleUseCase
+	getTunnelHealthUC         *forwardUsecases.GetTunnelHealthUseCase
+	listTunnelHealthRecordsUC *forwardUsecases.ListTunnelHealthRecordsUseCase
 
 	// User Forward Rule
 	createUserForwardRuleUC    *forwardUsecases.CreateUserForwardRuleUseCase
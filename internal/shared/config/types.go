@@ -285,3 +285,57 @@ func findFirstOf(s string, chars string) int {
 	}
 	return -1
 }
+
+// MetricsConfig holds Prometheus metrics endpoint configuration
+type MetricsConfig struct {
+	// Enabled exposes GET /metrics when true
+	Enabled bool `mapstructure:"enabled"`
+	// Token is the bearer token scrapers must send in the Authorization header
+	Token string `mapstructure:"token"`
+	// AllowedIPs lists client IPs or CIDRs allowed to scrape
+	AllowedIPs []string `mapstructure:"allowed_ips"`
+}
+
+// IsProtected returns true if a token or an IP allowlist is configured.
+// When both are configured, a scrape must satisfy both.
+func (m *MetricsConfig) IsProtected() bool {
+	return m.Token != "" || len(m.AllowedIPs) > 0
+}
+
+// ACMEConfig holds ACME certificate issuance configuration for node TLS certificates
+type ACMEConfig struct {
+	// Enabled turns on certificate issuance and the renewal scheduler
+	Enabled bool `mapstructure:"enabled"`
+	// DirectoryURL is the ACME directory endpoint (Let's Encrypt production by default)
+	DirectoryURL string `mapstructure:"directory_url"`
+	// Email is the ACME account contact address for expiry and policy notices
+	Email string `mapstructure:"email"`
+	// AccountKeyPath stores the ACME account key; it is generated on first use
+	AccountKeyPath string `mapstructure:"account_key_path"`
+	// InsecureSkipVerify disables TLS verification of the directory (only for a local Pebble CA)
+	InsecureSkipVerify bool `mapstructure:"insecure_skip_verify"`
+	// DNS configures the DNS providers available for DNS-01 challenges
+	DNS ACMEDNSConfig `mapstructure:"dns"`
+}
+
+// ACMEDNSConfig holds the credentials of the DNS providers used for DNS-01 challenges.
+// A provider is available when its credentials are set.
+type ACMEDNSConfig struct {
+	Cloudflare   CloudflareDNSConfig   `mapstructure:"cloudflare"`
+	ChallTestSrv ChallTestSrvDNSConfig `mapstructure:"challtestsrv"`
+}
+
+// CloudflareDNSConfig holds Cloudflare DNS provider configuration
+type CloudflareDNSConfig struct {
+	// APIToken is a Cloudflare API token with Zone.DNS edit permission
+	APIToken string `mapstructure:"api_token"`
+	// PropagationDelay is the number of seconds to wait after creating the TXT record
+	PropagationDelay int `mapstructure:"propagation_delay"`
+}
+
+// ChallTestSrvDNSConfig configures the Pebble challenge test server as a DNS provider.
+// It is only meant for testing against a local Pebble CA.
+type ChallTestSrvDNSConfig struct {
+	// URL is the management API address of pebble-challtestsrv (e.g. http://localhost:8055)
+	URL string `mapstructure:"url"`
+}
@@ -49,6 +49,13 @@ const (
 	TableUSDTAmountSuffixes      = "usdt_amount_suffixes"
 	TableUserAnnouncementReads   = "user_announcement_reads"
 	TableNodeAnyTLSConfigs       = "node_anytls_configs"
+	TableNodeWireGuardConfigs    = "node_wireguard_configs"
+	TableNodeWireGuardPeers      = "node_wireguard_peers"
+	TableMaintenanceWindows      = "maintenance_windows"
+	TableNodeCertificates        = "node_certificates"
+	TableNodeConfigVersions      = "node_config_versions"
+	TableNodeTemplates           = "node_templates"
+	TableForwardTunnelHealth     = "forward_tunnel_health"
 
 	// Default values
 	DefaultCurrency = "CNY"
@@ -89,12 +89,44 @@ type ConfigSyncData struct {
 
 // ExitAgentSyncData represents an exit agent with connection info for load balancing.
 type ExitAgentSyncData struct {
-	AgentID string `json:"agent_id"` // Stripe-style prefixed ID
-	Weight  uint16 `json:"weight"`   // Load balancing weight (0-100, 0=backup)
-	Address string `json:"address"`  // Exit agent public address
-	WsPort  uint16 `json:"ws_port"`  // Exit agent WebSocket port
-	TlsPort uint16 `json:"tls_port"` // Exit agent TLS port
-	Online  bool   `json:"online"`   // Exit agent online status
+	AgentID     string `json:"agent_id"`              // Stripe-style prefixed ID
+	Weight      uint16 `json:"weight"`                // Load balancing weight (0-100, 0=backup)
+	Address     string `json:"address"`               // Exit agent public address
+	WsPort      uint16 `json:"ws_port"`               // Exit agent WebSocket port
+	TlsPort     uint16 `json:"tls_port"`              // Exit agent TLS port
+	Online      bool   `json:"online"`                // Exit agent online status
+	LatencyMs   *int64 `json:"latency_ms,omitempty"`  // Last probed latency through this exit (lowest_latency only)
+	Connections *int   `json:"connections,omitempty"` // Active connections on the exit agent across all rules (least_connections only)
+}
+
+// LoadBalanceConfig represents the parameters of metric-based load balance strategies.
+// Exit metrics are refreshed by the server periodically; the rule is re-synced when the
+// preferred exit changes. Entry agents should fall back to weights once metrics are older
+// than MetricsMaxAge, and may add their own connections opened since MetricsUpdatedAt.
+type LoadBalanceConfig struct {
+	PreferredExitAgentID string `json:"preferred_exit_agent_id,omitempty"` // Exit currently preferred by the server
+	LatencyToleranceMs   int64  `json:"latency_tolerance_ms,omitempty"`    // lowest_latency: switch only when another exit is faster by more than this
+	ConnectionTolerance  int    `json:"connection_tolerance,omitempty"`    // least_connections: switch only when another exit has this many fewer connections
+	MetricsUpdatedAt     int64  `json:"metrics_updated_at,omitempty"`      // When exit metrics were collected (Unix seconds)
+	MetricsMaxAge        int64  `json:"metrics_max_age,omitempty"`         // Seconds after which exit metrics are stale
+}
+
+// RuleLimits represents the bandwidth and connection limits enforced by agents for a rule.
+// A zero or omitted limit means unlimited.
+type RuleLimits struct {
+	UploadMbps     uint32 `json:"upload_mbps,omitempty"`     // Upload bandwidth limit in Mbps
+	DownloadMbps   uint32 `json:"download_mbps,omitempty"`   // Download bandwidth limit in Mbps
+	MaxConnections uint32 `json:"max_connections,omitempty"` // Max concurrent connections
+}
+
+// RuleACL represents the source IP access control list enforced by the entry agent for a rule.
+// Deny entries take precedence over allow entries. When any allow list is set, only sources
+// matching an allow entry are accepted. Countries are lowercase GeoIP codes (e.g., "cn", "us").
+type RuleACL struct {
+	AllowCIDRs     []string `json:"allow_cidrs,omitempty"`     // Accepted source CIDRs
+	DenyCIDRs      []string `json:"deny_cidrs,omitempty"`      // Rejected source CIDRs
+	AllowCountries []string `json:"allow_countries,omitempty"` // Accepted source GeoIP country codes
+	DenyCountries  []string `json:"deny_countries,omitempty"`  // Rejected source GeoIP country codes
 }
 
 // HealthCheckConfig represents health check configuration for load balancing failover.
@@ -132,8 +164,11 @@ type RuleSyncData struct {
 	IsLastInChain          bool     `json:"is_last_in_chain,omitempty"`
 	// Multiple exit agents for load balancing (entry rules only, mutually exclusive with NextHop* fields)
 	ExitAgents          []ExitAgentSyncData `json:"exit_agents,omitempty"`
-	LoadBalanceStrategy string              `json:"load_balance_strategy,omitempty"` // Load balance strategy: "failover" (default), "weighted"
+	LoadBalanceStrategy string              `json:"load_balance_strategy,omitempty"` // Load balance strategy: "failover" (default), "weighted", "round_robin", "least_connections", "lowest_latency"
 	HealthCheck         *HealthCheckConfig  `json:"health_check,omitempty"`          // Health check config for load balancing failover
+	LoadBalance         *LoadBalanceConfig  `json:"load_balance,omitempty"`          // Parameters of metric-based strategies (least_connections, lowest_latency)
+	Limits              *RuleLimits         `json:"limits,omitempty"`                // Bandwidth and connection limits (omitted when unlimited)
+	ACL                 *RuleACL            `json:"acl,omitempty"`                   // Source IP access control list (omitted when all sources are accepted)
 }
 
 // ConfigAckData represents agent acknowledgment of config sync.
@@ -13,6 +13,7 @@ const (
 	NodeMsgTypeCommand          = "command"
 	NodeMsgTypeConfigSync       = "config_sync"
 	NodeMsgTypeSubscriptionSync = "subscription_sync"
+	NodeMsgTypeCertificateSync  = "certificate_sync"
 )
 
 // NodeHubMessage is the unified WebSocket message envelope for node agents.
@@ -30,6 +31,18 @@ type NodeCommandData struct {
 	Payload   any    `json:"payload,omitempty"`
 }
 
+// NodeCertificateSyncData carries the TLS certificate covering the node's server name.
+// It is sent when the node connects and whenever the certificate is issued or renewed.
+type NodeCertificateSyncData struct {
+	CertificateID  string `json:"certificate_id"`  // Stripe-style prefixed ID (e.g., "cert_xK9mP2vL3nQ")
+	Domain         string `json:"domain"`          // certificate domain, may be a wildcard (*.example.com)
+	CertificatePEM string `json:"certificate_pem"` // leaf certificate followed by the issuer chain
+	PrivateKeyPEM  string `json:"private_key_pem"`
+	Fingerprint    string `json:"fingerprint"` // SHA-256 of the leaf certificate (hex)
+	NotBefore      int64  `json:"not_before"`  // Unix seconds
+	NotAfter       int64  `json:"not_after"`   // Unix seconds
+}
+
 // Node command action constants.
 const (
 	NodeCmdActionReloadConfig   = "reload_config"
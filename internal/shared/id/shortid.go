@@ -34,6 +34,9 @@ const (
 	PrefixSubscriptionUsageStats = "usagestat"
 	PrefixPasskeyCredential      = "pk"
 	PrefixAnnouncement           = "ann"
+	PrefixMaintenanceWindow      = "mw"
+	PrefixCertificate            = "cert"
+	PrefixNodeTemplate           = "ntpl"
 )
 
 // knownPrefixes is a list of all known prefixes sorted by length (longest first)
@@ -52,6 +55,9 @@ func init() {
 		PrefixPlanPricing,
 		PrefixResourceGroup,
 		PrefixAnnouncement,
+		PrefixMaintenanceWindow,
+		PrefixCertificate,
+		PrefixNodeTemplate,
 		PrefixForwardAgent,
 		PrefixForwardRule,
 		PrefixSubscription,
@@ -369,3 +375,33 @@ func NewAnnouncementID() (string, error) {
 func ParseAnnouncementID(prefixedID string) (string, error) {
 	return ExtractShortID(prefixedID, PrefixAnnouncement)
 }
+
+// NewMaintenanceWindowID generates a new Maintenance Window SID (mw_xxx).
+func NewMaintenanceWindowID() (string, error) {
+	return NewSID(PrefixMaintenanceWindow)
+}
+
+// ParseMaintenanceWindowID extracts the short ID from a Maintenance Window prefixed ID.
+func ParseMaintenanceWindowID(prefixedID string) (string, error) {
+	return ExtractShortID(prefixedID, PrefixMaintenanceWindow)
+}
+
+// NewCertificateID generates a new Certificate SID (cert_xxx).
+func NewCertificateID() (string, error) {
+	return NewSID(PrefixCertificate)
+}
+
+// ParseCertificateID extracts the short ID from a Certificate prefixed ID.
+func ParseCertificateID(prefixedID string) (string, error) {
+	return ExtractShortID(prefixedID, PrefixCertificate)
+}
+
+// NewNodeTemplateID generates a new Node Template SID (ntpl_xxx).
+func NewNodeTemplateID() (string, error) {
+	return NewSID(PrefixNodeTemplate)
+}
+
+// ParseNodeTemplateID extracts the short ID from a Node Template prefixed ID.
+func ParseNodeTemplateID(prefixedID string) (string, error) {
+	return ExtractShortID(prefixedID, PrefixNodeTemplate)
+}
@@ -246,6 +246,9 @@ func TestNewSIDFormats(t *testing.T) {
 		{"SubscriptionUsageStats", NewSubscriptionUsageStatsID, PrefixSubscriptionUsageStats},
 		{"PasskeyCredential", NewPasskeyCredentialID, PrefixPasskeyCredential},
 		{"Announcement", NewAnnouncementID, PrefixAnnouncement},
+		{"MaintenanceWindow", NewMaintenanceWindowID, PrefixMaintenanceWindow},
+		{"Certificate", NewCertificateID, PrefixCertificate},
+		{"NodeTemplate", NewNodeTemplateID, PrefixNodeTemplate},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,53 @@
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+// ScrapeCollector reads state at scrape time, e.g. from repositories.
+type ScrapeCollector interface {
+	// Describe sends the descriptors of all metrics the collector emits.
+	Describe(ch chan<- *prometheus.Desc)
+	// Collect returns the current metrics.
+	Collect(ctx context.Context) ([]prometheus.Metric, error)
+}
+
+// scrapeCollector adapts a ScrapeCollector to prometheus.Collector.
+type scrapeCollector struct {
+	name      string
+	collector ScrapeCollector
+	timeout   time.Duration
+}
+
+// NewScrapeCollector adapts c to prometheus.Collector, bounding every scrape by timeout.
+// When c fails, none of its metrics are exposed, so a partial family is never served;
+// the error is reported to the registry, which still serves the other collectors when
+// gathered with continue-on-error handling. name is only used in error messages.
+func NewScrapeCollector(name string, c ScrapeCollector, timeout time.Duration) prometheus.Collector {
+	return &scrapeCollector{name: name, collector: c, timeout: timeout}
+}
+
+// Describe implements prometheus.Collector.
+func (c *scrapeCollector) Describe(ch chan<- *prometheus.Desc) {
+	c.collector.Describe(ch)
+}
+
+// Collect implements prometheus.Collector.
+func (c *scrapeCollector) Collect(ch chan<- prometheus.Metric) {
+	ctx, cancel := context.WithTimeout(context.Background(), c.timeout)
+	defer cancel()
+
+	collected, err := c.collector.Collect(ctx)
+	if err != nil {
+		err = fmt.Errorf("collector %s: %w", c.name, err)
+		ch <- prometheus.NewInvalidMetric(prometheus.NewInvalidDesc(err), err)
+		return
+	}
+	for _, m := range collected {
+		ch <- m
+	}
+}
@@ -0,0 +1,79 @@
+package metrics
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testUpDesc = prometheus.NewDesc("test_up", "Whether the test target is up.", []string{"name"}, nil)
+
+// stubScrapeCollector returns one sample per name, or err.
+type stubScrapeCollector struct {
+	names []string
+	err   error
+}
+
+func (c *stubScrapeCollector) Describe(ch chan<- *prometheus.Desc) {
+	ch <- testUpDesc
+}
+
+func (c *stubScrapeCollector) Collect(ctx context.Context) ([]prometheus.Metric, error) {
+	if _, ok := ctx.Deadline(); !ok {
+		return nil, errors.New("scrape is not bounded")
+	}
+	if c.err != nil {
+		return nil, c.err
+	}
+	var collected []prometheus.Metric
+	for _, name := range c.names {
+		collected = append(collected, prometheus.MustNewConstMetric(testUpDesc, prometheus.GaugeValue, 1, name))
+	}
+	return collected, nil
+}
+
+func TestScrapeCollector(t *testing.T) {
+	hist := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "test_duration_seconds",
+		Help:    "Test latency.",
+		Buckets: []float64{0.1, 1},
+	}, []string{"route"})
+	hist.WithLabelValues("/a").Observe(0.05)
+
+	r := prometheus.NewRegistry()
+	r.MustRegister(hist, NewScrapeCollector("up", &stubScrapeCollector{names: []string{`a "quoted" \ name`}}, time.Second))
+
+	expected := `# HELP test_up Whether the test target is up.
+# TYPE test_up gauge
+test_up{name="a \"quoted\" \\ name"} 1
+`
+	require.NoError(t, testutil.GatherAndCompare(r, strings.NewReader(expected), "test_up"))
+
+	// A failing collector exposes nothing, the others are still gathered
+	broken := prometheus.NewRegistry()
+	broken.MustRegister(hist, NewScrapeCollector("broken", &stubScrapeCollector{names: []string{"b"}, err: errors.New("boom")}, time.Second))
+	families, err := broken.Gather()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "collector broken")
+	require.Len(t, families, 1)
+	assert.Equal(t, "test_duration_seconds", families[0].GetName())
+}
+
+func TestTrafficCounter(t *testing.T) {
+	c := NewTrafficCounter()
+	c.Add(1, 100, 200)
+	c.Add(1, 50, -10)
+	c.Add(2, 0, 0)
+
+	snapshot := c.Snapshot()
+	assert.Equal(t, TrafficTotals{Upload: 150, Download: 200}, snapshot[1])
+	_, ok := snapshot[2]
+	assert.False(t, ok, "zero traffic is not recorded")
+}
@@ -0,0 +1,35 @@
+// Package metrics holds the process-wide Prometheus instruments and the helpers used to
+// expose state that is read at scrape time, such as node status from repositories.
+package metrics
+
+import "github.com/prometheus/client_golang/prometheus"
+
+// Process-wide instruments. They are always recorded; whether they are exposed
+// depends on the metrics endpoint being enabled.
+var (
+	// HTTPRequestDuration observes API request latency by method, route template and status code.
+	HTTPRequestDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "orris_http_request_duration_seconds",
+		Help:    "HTTP request latency in seconds.",
+		Buckets: []float64{0.005, 0.01, 0.025, 0.05, 0.1, 0.25, 0.5, 1, 2.5, 5, 10},
+	}, []string{"method", "route", "status"})
+
+	// SchedulerJobDuration observes scheduled job run time by job name and result.
+	SchedulerJobDuration = prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "orris_scheduler_job_duration_seconds",
+		Help:    "Scheduled job run time in seconds.",
+		Buckets: []float64{0.01, 0.05, 0.1, 0.5, 1, 5, 10, 30, 60, 300},
+	}, []string{"job", "status"})
+
+	// NodeTraffic accumulates traffic reported by node agents, keyed by node ID.
+	NodeTraffic = NewTrafficCounter()
+
+	// ForwardRuleTraffic accumulates traffic reported by forward agents, keyed by rule ID.
+	ForwardRuleTraffic = NewTrafficCounter()
+)
+
+// RegisterInstruments adds the process-wide histograms to a registry.
+// Traffic counters are exposed by collectors that can resolve their IDs.
+func RegisterInstruments(r prometheus.Registerer) {
+	r.MustRegister(HTTPRequestDuration, SchedulerJobDuration)
+}
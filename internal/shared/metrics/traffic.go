@@ -0,0 +1,48 @@
+package metrics
+
+import "sync"
+
+// TrafficTotals holds cumulative traffic of a single resource in bytes.
+type TrafficTotals struct {
+	Upload   uint64
+	Download uint64
+}
+
+// TrafficCounter accumulates upload/download bytes per resource ID since process start.
+// It is fed from the traffic ingestion paths and read at scrape time, where the IDs
+// are resolved to external identifiers.
+type TrafficCounter struct {
+	mu     sync.Mutex
+	totals map[uint]TrafficTotals
+}
+
+// NewTrafficCounter creates an empty traffic counter.
+func NewTrafficCounter() *TrafficCounter {
+	return &TrafficCounter{totals: make(map[uint]TrafficTotals)}
+}
+
+// Add accumulates traffic for a resource. Negative values are ignored.
+func (c *TrafficCounter) Add(id uint, upload, download int64) {
+	upload, download = max(upload, 0), max(download, 0)
+	if upload == 0 && download == 0 {
+		return
+	}
+	c.mu.Lock()
+	t := c.totals[id]
+	t.Upload += uint64(upload)
+	t.Download += uint64(download)
+	c.totals[id] = t
+	c.mu.Unlock()
+}
+
+// Snapshot returns a copy of the current totals.
+func (c *TrafficCounter) Snapshot() map[uint]TrafficTotals {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	out := make(map[uint]TrafficTotals, len(c.totals))
+	for id, t := range c.totals {
+		out[id] = t
+	}
+	return out
+}